@@ -16,18 +16,44 @@ func main() {
 		&entity.TemplateTask{},
 		&entity.TemplateTaskAttachment{},
 		&entity.TemplateTaskChecklist{},
+		&entity.TemplateTaskRule{},
+		&entity.TemplateTaskApprovalStep{},
+		&entity.TemplateTaskVersion{},
 		&entity.EmployeeTask{},
 		&entity.EmployeeTaskAttachment{},
 		&entity.EmployeeTaskFiles{},
 		&entity.EmployeeHiring{},
+		&entity.EmployeeOffboarding{},
 		&entity.EmployeeTaskChecklist{},
+		&entity.EmployeeTaskHistory{},
+		&entity.EmployeeTaskApproval{},
+		&entity.VerifierDelegation{},
 		&entity.Event{},
 		&entity.EventEmployee{},
 		&entity.SurveyTemplate{},
 		&entity.AnswerType{},
 		&entity.Question{},
 		&entity.QuestionOption{},
+		&entity.QuestionCondition{},
+		&entity.QuestionMatrixRow{},
 		&entity.SurveyResponse{},
+		&entity.SurveyQuizAttempt{},
+		&entity.SurveyQuizAttemptAnswer{},
+		&entity.SurveyExportJob{},
+		&entity.SurveyCampaign{},
+		&entity.SurveyCampaignOffset{},
+		&entity.SurveyCampaignRule{},
+		&entity.SurveyCampaignDispatch{},
+		&entity.SurveyDistribution{},
+		&entity.SurveyDistributionRule{},
+		&entity.SurveyDistributionRecipient{},
+		&entity.Holiday{},
+		&entity.WorkWeek{},
+		&entity.OnboardingBackfill{},
+		&entity.OnboardingBackfillItem{},
+		&entity.PolicyDocument{},
+		&entity.PolicyDocumentVersion{},
+		&entity.PolicyAcknowledgement{},
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -35,35 +61,10 @@ func main() {
 		log.Info("Migration success")
 	}
 
-	answerTypes := []entity.AnswerType{
-		{
-			Name: "Multiple Choice",
-		},
-		{
-			Name: "Short Answer",
-		},
-		{
-			Name: "Long Answer",
-		},
-		{
-			Name: "Checkbox",
-		},
-		{
-			Name: "Dropdown",
-		},
-		{
-			Name: "Rating",
-		},
-		{
-			Name: "Link",
-		}, 
-		{
-			Name: "Attachment",
-		},
-	}
-
-	for _, answerType := range answerTypes {
-		err = db.Create(&answerType).Error
+	// answer types are matched by name, so running the migration again only adds the new ones
+	for _, name := range entity.AnswerTypeNames {
+		answerType := entity.AnswerType{}
+		err = db.Where(entity.AnswerType{Name: name}).FirstOrCreate(&answerType).Error
 		if err != nil {
 			log.Fatal(err)
 		}
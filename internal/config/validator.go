@@ -19,5 +19,21 @@ func NewValidator(viper *viper.Viper) *validator.Validate {
 	validate.RegisterValidation("employee_task_kanban_validation", request.EmployeeTaskKanbanValidation)
 	validate.RegisterValidation("event_status_validation", request.EventStatusValidation)
 	validate.RegisterValidation("survey_template_status_validation", request.SurveyTemplateStatusValidation)
+	validate.RegisterValidation("holiday_type_validation", request.HolidayTypeValidation)
+	validate.RegisterValidation("template_task_rule_criterion_validation", request.TemplateTaskRuleCriterionValidation)
+	validate.RegisterValidation("template_task_rule_operator_validation", request.TemplateTaskRuleOperatorValidation)
+	validate.RegisterValidation("onboarding_backfill_change_type_validation", request.OnboardingBackfillChangeTypeValidation)
+	validate.RegisterValidation("template_task_version_propagation_validation", request.TemplateTaskVersionPropagationValidation)
+	validate.RegisterValidation("template_bundle_conflict_strategy_validation", request.TemplateBundleConflictStrategyValidation)
+	validate.RegisterValidation("task_source_validation", request.TaskSourceValidation)
+	validate.RegisterValidation("approval_approver_type_validation", request.ApprovalApproverTypeValidation)
+	validate.RegisterValidation("task_kind_validation", request.TaskKindValidation)
+	validate.RegisterValidation("employee_task_file_status_validation", request.EmployeeTaskFileStatusValidation)
+	validate.RegisterValidation("policy_document_status_validation", request.PolicyDocumentStatusValidation)
+	validate.RegisterValidation("survey_export_format_validation", request.SurveyExportFormatValidation)
+	validate.RegisterValidation("question_condition_operator_validation", request.QuestionConditionOperatorValidation)
+	validate.RegisterValidation("question_condition_match_validation", request.QuestionConditionMatchValidation)
+	validate.RegisterValidation("survey_template_anonymity_validation", request.SurveyTemplateAnonymityValidation)
+	validate.RegisterValidation("survey_campaign_status_validation", request.SurveyCampaignStatusValidation)
 	return validate
 }
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IEmployeeTaskApprovalDTO interface {
+	ConvertEntitiesToChainResponse(employeeTaskID uuid.UUID, ents []entity.EmployeeTaskApproval) *response.EmployeeTaskApprovalChainResponse
+}
+
+type EmployeeTaskApprovalDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewEmployeeTaskApprovalDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) IEmployeeTaskApprovalDTO {
+	return &EmployeeTaskApprovalDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func EmployeeTaskApprovalDTOFactory(log *logrus.Logger, viper *viper.Viper) IEmployeeTaskApprovalDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewEmployeeTaskApprovalDTO(log, viper, employeeMessage)
+}
+
+func (dto *EmployeeTaskApprovalDTO) ConvertEntitiesToChainResponse(employeeTaskID uuid.UUID, ents []entity.EmployeeTaskApproval) *response.EmployeeTaskApprovalChainResponse {
+	// the same approver usually appears in every round
+	names := map[uuid.UUID]string{}
+	employeeName := func(id *uuid.UUID) string {
+		if id == nil {
+			return ""
+		}
+		if name, ok := names[*id]; ok {
+			return name
+		}
+		employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+			ID: id.String(),
+		})
+		if err != nil {
+			dto.Log.Errorf("[EmployeeTaskApprovalDTO.ConvertEntitiesToChainResponse] " + err.Error())
+			return ""
+		}
+		names[*id] = employee.Name
+		return employee.Name
+	}
+
+	approvals := make([]response.EmployeeTaskApprovalResponse, 0, len(ents))
+	for _, ent := range ents {
+		approvals = append(approvals, response.EmployeeTaskApprovalResponse{
+			ID:             ent.ID,
+			EmployeeTaskID: ent.EmployeeTaskID,
+			Round:          ent.Round,
+			StepOrder:      ent.StepOrder,
+			ApproverType:   ent.ApproverType,
+			ApproverID:     ent.ApproverID,
+			ApproverName:   employeeName(ent.ApproverID),
+			Status:         ent.Status,
+			ActedBy:        ent.ActedBy,
+			ActedByName:    employeeName(ent.ActedBy),
+			ActedAt:        ent.ActedAt,
+			Reason:         ent.Reason,
+			CreatedAt:      ent.CreatedAt,
+			UpdatedAt:      ent.UpdatedAt,
+		})
+	}
+
+	round, steps := service.CurrentApprovalRound(ents)
+	res := &response.EmployeeTaskApprovalChainResponse{
+		EmployeeTaskID:        employeeTaskID,
+		Round:                 round,
+		Status:                entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_APPROVED,
+		EmployeeTaskApprovals: approvals,
+	}
+	for _, step := range steps {
+		if step.Status == entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_REJECTED {
+			res.Status = entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_REJECTED
+			break
+		}
+		if step.Status == entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING {
+			stepOrder := step.StepOrder
+			res.Status = entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING
+			res.CurrentStepOrder = &stepOrder
+			break
+		}
+	}
+
+	return res
+}
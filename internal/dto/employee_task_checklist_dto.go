@@ -2,6 +2,8 @@ package dto
 
 import (
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -12,22 +14,37 @@ type IEmployeeTaskChecklistDTO interface {
 }
 
 type EmployeeTaskChecklistDTO struct {
-	Log   *logrus.Logger
-	Viper *viper.Viper
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
 }
 
-func NewEmployeeTaskChecklistDTO(log *logrus.Logger, viper *viper.Viper) IEmployeeTaskChecklistDTO {
+func NewEmployeeTaskChecklistDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) IEmployeeTaskChecklistDTO {
 	return &EmployeeTaskChecklistDTO{
-		Log:   log,
-		Viper: viper,
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
 	}
 }
 
 func EmployeeTaskChecklistDTOFactory(log *logrus.Logger, viper *viper.Viper) IEmployeeTaskChecklistDTO {
-	return NewEmployeeTaskChecklistDTO(log, viper)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewEmployeeTaskChecklistDTO(log, viper, employeeMessage)
 }
 
 func (dto *EmployeeTaskChecklistDTO) ConvertEntityToResponse(ent *entity.EmployeeTaskChecklist) *response.EmployeeTaskChecklistResponse {
+	var assigneeName string
+	if ent.AssigneeID != nil {
+		employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+			ID: ent.AssigneeID.String(),
+		})
+		if err != nil {
+			dto.Log.Errorf("[EmployeeTaskChecklistDTO.ConvertEntityToResponse] " + err.Error())
+		} else {
+			assigneeName = employee.Name
+		}
+	}
+
 	return &response.EmployeeTaskChecklistResponse{
 		ID:             ent.ID,
 		EmployeeTaskID: ent.EmployeeTaskID,
@@ -35,7 +52,14 @@ func (dto *EmployeeTaskChecklistDTO) ConvertEntityToResponse(ent *entity.Employe
 		IsChecked:      ent.IsChecked,
 		VerifiedBy:     ent.VerifiedBy,
 		MidsuitID:      ent.MidsuitID,
+		SortOrder:      ent.SortOrder,
+		AssigneeID:     ent.AssigneeID,
+		DueDate:        ent.DueDate,
+		IsRequired:     ent.IsRequired,
+		CheckedAt:      ent.CheckedAt,
+		CheckedBy:      ent.CheckedBy,
 		CreatedAt:      ent.CreatedAt,
 		UpdatedAt:      ent.UpdatedAt,
+		AssigneeName:   assigneeName,
 	}
 }
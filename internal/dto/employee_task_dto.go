@@ -1,10 +1,14 @@
 package dto
 
 import (
+	"sort"
+	"time"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -23,6 +27,9 @@ type EmployeeTaskDTO struct {
 	EmployeeMessage           messaging.IEmployeeMessage
 	QuestionDTO               IQuestionDTO
 	SurveyTemplateDTO         ISurveyTemplateDTO
+	VerifierDelegationService service.IVerifierDelegationService
+	EmployeeTaskFileDTO       IEmployeeTaskFileDTO
+	EmployeeTaskKindService   service.IEmployeeTaskKindService
 }
 
 func NewEmployeeTaskDTO(
@@ -33,6 +40,9 @@ func NewEmployeeTaskDTO(
 	employeeMessage messaging.IEmployeeMessage,
 	questionDTO IQuestionDTO,
 	surveyTemplateDTO ISurveyTemplateDTO,
+	verifierDelegationService service.IVerifierDelegationService,
+	employeeTaskFileDTO IEmployeeTaskFileDTO,
+	employeeTaskKindService service.IEmployeeTaskKindService,
 ) IEmployeeTaskDTO {
 	return &EmployeeTaskDTO{
 		Log:                       log,
@@ -42,6 +52,9 @@ func NewEmployeeTaskDTO(
 		EmployeeMessage:           employeeMessage,
 		QuestionDTO:               questionDTO,
 		SurveyTemplateDTO:         surveyTemplateDTO,
+		VerifierDelegationService: verifierDelegationService,
+		EmployeeTaskFileDTO:       employeeTaskFileDTO,
+		EmployeeTaskKindService:   employeeTaskKindService,
 	}
 }
 
@@ -51,7 +64,10 @@ func EmployeeTaskDTOFactory(log *logrus.Logger, viper *viper.Viper) IEmployeeTas
 	employeeMessage := messaging.EmployeeMessageFactory(log)
 	questionDTO := QuestionDTOFactory(log, viper)
 	surveyTemplateDTO := SurveyTemplateDTOFactory(log, viper)
-	return NewEmployeeTaskDTO(log, viper, employeeTaskAttachmentDTO, employeeTaskChecklistDTO, employeeMessage, questionDTO, surveyTemplateDTO)
+	verifierDelegationService := service.VerifierDelegationServiceFactory(log)
+	employeeTaskFileDTO := EmployeeTaskFileDTOFactory(log, viper)
+	employeeTaskKindService := service.EmployeeTaskKindServiceFactory(log)
+	return NewEmployeeTaskDTO(log, viper, employeeTaskAttachmentDTO, employeeTaskChecklistDTO, employeeMessage, questionDTO, surveyTemplateDTO, verifierDelegationService, employeeTaskFileDTO, employeeTaskKindService)
 }
 
 func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *response.EmployeeTaskResponse {
@@ -68,6 +84,29 @@ func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *r
 		}
 	}
 
+	// while the verifier is away their delegate verifies instead
+	effectiveVerifiedBy := ent.VerifiedBy
+	effectiveVerifiedByName := verifiedByName
+	var verifierDelegationID *uuid.UUID
+	if ent.VerifiedBy != nil {
+		delegateID, verifierDelegation, err := dto.VerifierDelegationService.ResolveVerifier(*ent.VerifiedBy, time.Now())
+		if err != nil {
+			dto.Log.Errorf("[EmployeeTaskDTO.ConvertEntityToResponse] " + err.Error())
+		} else if verifierDelegation != nil {
+			effectiveVerifiedBy = &delegateID
+			verifierDelegationID = &verifierDelegation.ID
+			effectiveVerifiedByName = ""
+			employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+				ID: delegateID.String(),
+			})
+			if err != nil {
+				dto.Log.Errorf("[EmployeeTaskDTO.ConvertEntityToResponse] " + err.Error())
+			} else {
+				effectiveVerifiedByName = employee.Name
+			}
+		}
+	}
+
 	var employeeName string
 	var employeeMidsuitID string
 	if ent.EmployeeID != nil {
@@ -105,6 +144,16 @@ func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *r
 		}
 	}
 
+	var kindRequirement string
+	if ent.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		missing, err := dto.EmployeeTaskKindService.MissingRequirement(ent)
+		if err != nil {
+			dto.Log.Errorf("[EmployeeTaskDTO.ConvertEntityToResponse] " + err.Error())
+		} else {
+			kindRequirement = missing
+		}
+	}
+
 	return &response.EmployeeTaskResponse{
 		ID: ent.ID,
 		CoverPath: func() *string {
@@ -121,16 +170,17 @@ func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *r
 
 			return ent.SurveyTemplateID
 		}(),
-		CoverPathOrigin: ent.CoverPath,
-		EmployeeID:      ent.EmployeeID,
-		TemplateTaskID:  ent.TemplateTaskID,
-		VerifiedBy:      ent.VerifiedBy,
-		Name:            ent.Name,
-		Priority:        ent.Priority,
-		Description:     ent.Description,
-		StartDate:       ent.StartDate,
-		EndDate:         ent.EndDate,
-		IsDone:          ent.IsDone,
+		CoverPathOrigin:       ent.CoverPath,
+		EmployeeID:            ent.EmployeeID,
+		TemplateTaskID:        ent.TemplateTaskID,
+		TemplateTaskVersionID: ent.TemplateTaskVersionID,
+		VerifiedBy:            ent.VerifiedBy,
+		Name:                  ent.Name,
+		Priority:              ent.Priority,
+		Description:           ent.Description,
+		StartDate:             ent.StartDate,
+		EndDate:               ent.EndDate,
+		IsDone:                ent.IsDone,
 		EmployeeMidsuitID: func() *string {
 			if employeeMidsuitID == "" {
 				return nil
@@ -157,14 +207,27 @@ func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *r
 		Progress:         progress,
 		ProgressVerified: progressVerified,
 		MidsuitID:        ent.MidsuitID,
+		Kind:             ent.TaskKind(),
+		PassingScore:     ent.PassingScore,
+		QuizScore:        ent.QuizScore,
+		AcknowledgedAt:   ent.AcknowledgedAt,
+		ClosedAt:         ent.ClosedAt,
+		KindRequirement:  kindRequirement,
 		CreatedAt:        ent.CreatedAt,
 		UpdatedAt:        ent.UpdatedAt,
 
-		VerifiedByName: verifiedByName,
-		EmployeeName:   employeeName,
+		VerifiedByName:          verifiedByName,
+		EffectiveVerifiedBy:     effectiveVerifiedBy,
+		EffectiveVerifiedByName: effectiveVerifiedByName,
+		VerifierDelegationID:    verifierDelegationID,
+		EmployeeName:            employeeName,
 		EmployeeTaskChecklists: func() []response.EmployeeTaskChecklistResponse {
 			var checklists []response.EmployeeTaskChecklistResponse
-			for _, checklist := range ent.EmployeeTaskChecklists {
+			sortedChecklists := append(ent.EmployeeTaskChecklists[:0:0], ent.EmployeeTaskChecklists...)
+			sort.SliceStable(sortedChecklists, func(i, j int) bool {
+				return sortedChecklists[i].SortOrder < sortedChecklists[j].SortOrder
+			})
+			for _, checklist := range sortedChecklists {
 				response := dto.EmployeeTaskChecklistDTO.ConvertEntityToResponse(&checklist)
 				checklists = append(checklists, *response)
 			}
@@ -178,11 +241,19 @@ func (dto *EmployeeTaskDTO) ConvertEntityToResponse(ent *entity.EmployeeTask) *r
 			}
 			return attachments
 		}(),
+		EmployeeTaskFiles: func() []response.EmployeeTaskFileResponse {
+			var files []response.EmployeeTaskFileResponse
+			for _, file := range ent.EmployeeTaskFiles {
+				response := dto.EmployeeTaskFileDTO.ConvertEntityToResponse(&file)
+				files = append(files, *response)
+			}
+			return files
+		}(),
 		SurveyTemplate: func() *response.SurveyTemplateResponse {
 			if ent.SurveyTemplate == nil {
 				return nil
 			}
-			return dto.SurveyTemplateDTO.ConvertEntityToResponse(ent.SurveyTemplate)
+			return HideQuizAnswerKey(dto.SurveyTemplateDTO.ConvertEntityToResponse(ent.SurveyTemplate))
 		}(),
 	}
 }
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IEmployeeTaskFileDTO interface {
+	ConvertEntityToResponse(ent *entity.EmployeeTaskFiles) *response.EmployeeTaskFileResponse
+}
+
+type EmployeeTaskFileDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewEmployeeTaskFileDTO(log *logrus.Logger, viper *viper.Viper) IEmployeeTaskFileDTO {
+	return &EmployeeTaskFileDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func EmployeeTaskFileDTOFactory(log *logrus.Logger, viper *viper.Viper) IEmployeeTaskFileDTO {
+	return NewEmployeeTaskFileDTO(log, viper)
+}
+
+func (dto *EmployeeTaskFileDTO) ConvertEntityToResponse(ent *entity.EmployeeTaskFiles) *response.EmployeeTaskFileResponse {
+	return &response.EmployeeTaskFileResponse{
+		ID:             ent.ID,
+		EmployeeTaskID: ent.EmployeeTaskID,
+		Path: func() string {
+			if ent.Path == "" {
+				return ""
+			}
+			return dto.Viper.GetString("app.url") + ent.Path
+		}(),
+		PathOrigin: ent.Path,
+		Status:     ent.Status,
+		ReviewedBy: ent.ReviewedBy,
+		ReviewedAt: ent.ReviewedAt,
+		Notes:      ent.Notes,
+		CreatedAt:  ent.CreatedAt,
+		UpdatedAt:  ent.UpdatedAt,
+	}
+}
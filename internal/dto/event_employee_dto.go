@@ -50,6 +50,7 @@ func (dto *EventEmployeeDTO) ConvertEntityToResponse(ent *entity.EventEmployee)
 		ID:           ent.ID,
 		EventID:      ent.EventID,
 		EmployeeID:   ent.EmployeeID,
+		CheckedInAt:  ent.CheckedInAt,
 		EmployeeName: employeeName,
 		CreatedAt:    ent.CreatedAt,
 		UpdatedAt:    ent.UpdatedAt,
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IHolidayDTO interface {
+	ConvertEntityToResponse(ent *entity.Holiday) *response.HolidayResponse
+}
+
+type HolidayDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewHolidayDTO(log *logrus.Logger, viper *viper.Viper) IHolidayDTO {
+	return &HolidayDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func HolidayDTOFactory(log *logrus.Logger, viper *viper.Viper) IHolidayDTO {
+	return NewHolidayDTO(log, viper)
+}
+
+func (dto *HolidayDTO) ConvertEntityToResponse(ent *entity.Holiday) *response.HolidayResponse {
+	return &response.HolidayResponse{
+		ID:             ent.ID,
+		OrganizationID: ent.OrganizationID,
+		Name:           ent.Name,
+		Date:           ent.Date,
+		Type:           ent.Type,
+		Description:    ent.Description,
+		CreatedAt:      ent.CreatedAt,
+		UpdatedAt:      ent.UpdatedAt,
+	}
+}
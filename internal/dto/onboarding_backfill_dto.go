@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IOnboardingBackfillDTO interface {
+	ConvertEntityToResponse(ent *entity.OnboardingBackfill) *response.OnboardingBackfillResponse
+}
+
+type OnboardingBackfillDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewOnboardingBackfillDTO(log *logrus.Logger, viper *viper.Viper) IOnboardingBackfillDTO {
+	return &OnboardingBackfillDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func OnboardingBackfillDTOFactory(log *logrus.Logger, viper *viper.Viper) IOnboardingBackfillDTO {
+	return NewOnboardingBackfillDTO(log, viper)
+}
+
+func (dto *OnboardingBackfillDTO) ConvertEntityToResponse(ent *entity.OnboardingBackfill) *response.OnboardingBackfillResponse {
+	var progress float64
+	if ent.Total > 0 {
+		progress = float64(ent.Processed) / float64(ent.Total) * 100
+	}
+
+	return &response.OnboardingBackfillResponse{
+		ID:                    ent.ID,
+		OrganizationType:      ent.OrganizationType,
+		TemplateTaskVersionID: ent.TemplateTaskVersionID,
+		Status:                ent.Status,
+		Total:                 ent.Total,
+		Processed:             ent.Processed,
+		Succeeded:             ent.Succeeded,
+		Failed:                ent.Failed,
+		Skipped:               ent.Skipped,
+		Progress:              progress,
+		StartedAt:             ent.StartedAt,
+		FinishedAt:            ent.FinishedAt,
+		CreatedAt:             ent.CreatedAt,
+		UpdatedAt:             ent.UpdatedAt,
+		OnboardingBackfillItems: func() []response.OnboardingBackfillItemResponse {
+			var items []response.OnboardingBackfillItemResponse
+			for _, item := range ent.OnboardingBackfillItems {
+				items = append(items, response.OnboardingBackfillItemResponse{
+					ID:             item.ID,
+					EmployeeID:     item.EmployeeID,
+					TemplateTaskID: item.TemplateTaskID,
+					EmployeeTaskID: item.EmployeeTaskID,
+					ChangeType:     item.ChangeType,
+					ChecklistName:  item.ChecklistName,
+					Status:         item.Status,
+					Message:        item.Message,
+				})
+			}
+			return items
+		}(),
+	}
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IPolicyDocumentDTO interface {
+	ConvertEntityToResponse(ent *entity.PolicyDocument) *response.PolicyDocumentResponse
+	ConvertVersionEntityToResponse(ent *entity.PolicyDocumentVersion) *response.PolicyDocumentVersionResponse
+	ConvertAcknowledgementEntityToResponse(ent *entity.PolicyAcknowledgement) *response.PolicyAcknowledgementResponse
+}
+
+type PolicyDocumentDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewPolicyDocumentDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) IPolicyDocumentDTO {
+	return &PolicyDocumentDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func PolicyDocumentDTOFactory(log *logrus.Logger, viper *viper.Viper) IPolicyDocumentDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewPolicyDocumentDTO(log, viper, employeeMessage)
+}
+
+func (dto *PolicyDocumentDTO) ConvertEntityToResponse(ent *entity.PolicyDocument) *response.PolicyDocumentResponse {
+	var currentVersion *response.PolicyDocumentVersionResponse
+	versions := make([]response.PolicyDocumentVersionResponse, 0, len(ent.PolicyDocumentVersions))
+	for _, version := range ent.PolicyDocumentVersions {
+		versionResponse := dto.ConvertVersionEntityToResponse(&version)
+		if version.VersionNumber == ent.CurrentVersionNumber {
+			currentVersion = versionResponse
+		}
+		versions = append(versions, *versionResponse)
+	}
+
+	return &response.PolicyDocumentResponse{
+		ID:                     ent.ID,
+		Name:                   ent.Name,
+		Description:            ent.Description,
+		Status:                 ent.Status,
+		CurrentVersionNumber:   ent.CurrentVersionNumber,
+		CurrentVersion:         currentVersion,
+		PolicyDocumentVersions: versions,
+		CreatedAt:              ent.CreatedAt,
+		UpdatedAt:              ent.UpdatedAt,
+	}
+}
+
+func (dto *PolicyDocumentDTO) ConvertVersionEntityToResponse(ent *entity.PolicyDocumentVersion) *response.PolicyDocumentVersionResponse {
+	return &response.PolicyDocumentVersionResponse{
+		ID:               ent.ID,
+		PolicyDocumentID: ent.PolicyDocumentID,
+		VersionNumber:    ent.VersionNumber,
+		Path: func() string {
+			if ent.Path == "" {
+				return ""
+			}
+			return dto.Viper.GetString("app.url") + ent.Path
+		}(),
+		PathOrigin:  ent.Path,
+		ContentHash: ent.ContentHash,
+		ChangeNote:  ent.ChangeNote,
+		CreatedBy:   ent.CreatedBy,
+		CreatedAt:   ent.CreatedAt,
+	}
+}
+
+func (dto *PolicyDocumentDTO) ConvertAcknowledgementEntityToResponse(ent *entity.PolicyAcknowledgement) *response.PolicyAcknowledgementResponse {
+	employeeName := ""
+	employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: ent.EmployeeID.String(),
+	})
+	if err != nil {
+		dto.Log.Errorf("[PolicyDocumentDTO.ConvertAcknowledgementEntityToResponse] " + err.Error())
+	} else if employee != nil {
+		employeeName = employee.Name
+	}
+
+	return &response.PolicyAcknowledgementResponse{
+		ID:               ent.ID,
+		Sequence:         ent.Sequence,
+		PolicyDocumentID: ent.PolicyDocumentID,
+		PolicyDocumentName: func() string {
+			if ent.PolicyDocument == nil {
+				return ""
+			}
+			return ent.PolicyDocument.Name
+		}(),
+		PolicyDocumentVersionID: ent.PolicyDocumentVersionID,
+		VersionNumber:           ent.VersionNumber,
+		EmployeeID:              ent.EmployeeID,
+		EmployeeName:            employeeName,
+		DocumentHash:            ent.DocumentHash,
+		AcknowledgedAt:          ent.AcknowledgedAt,
+		IPAddress:               ent.IPAddress,
+		UserAgent:               ent.UserAgent,
+		PreviousHash:            ent.PreviousHash,
+		Hash:                    ent.Hash,
+	}
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IQuestionConditionDTO interface {
+	ConvertEntityToResponse(ent *entity.QuestionCondition) *response.QuestionConditionResponse
+}
+
+type QuestionConditionDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewQuestionConditionDTO(log *logrus.Logger, viper *viper.Viper) IQuestionConditionDTO {
+	return &QuestionConditionDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func QuestionConditionDTOFactory(log *logrus.Logger, viper *viper.Viper) IQuestionConditionDTO {
+	return NewQuestionConditionDTO(log, viper)
+}
+
+func (dto *QuestionConditionDTO) ConvertEntityToResponse(ent *entity.QuestionCondition) *response.QuestionConditionResponse {
+	return &response.QuestionConditionResponse{
+		ID:               ent.ID,
+		QuestionID:       ent.QuestionID,
+		SourceQuestionID: ent.SourceQuestionID,
+		SourceQuestionNumber: func() int {
+			if ent.SourceQuestion == nil {
+				return 0
+			}
+			return ent.SourceQuestion.Number
+		}(),
+		Operator:  string(ent.Operator),
+		Value:     ent.Value,
+		CreatedAt: ent.CreatedAt,
+		UpdatedAt: ent.UpdatedAt,
+	}
+}
@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"strings"
+	"time"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
 	"github.com/sirupsen/logrus"
@@ -12,11 +15,13 @@ type IQuestionDTO interface {
 }
 
 type QuestionDTO struct {
-	Log               *logrus.Logger
-	Viper             *viper.Viper
-	AnswerTypeDTO     IAnswerTypeDTO
-	QuestionOptionDTO IQuestionOptionDTO
-	SurveyResponseDTO ISurveyResponseDTO
+	Log                  *logrus.Logger
+	Viper                *viper.Viper
+	AnswerTypeDTO        IAnswerTypeDTO
+	QuestionOptionDTO    IQuestionOptionDTO
+	SurveyResponseDTO    ISurveyResponseDTO
+	QuestionConditionDTO IQuestionConditionDTO
+	QuestionMatrixRowDTO IQuestionMatrixRowDTO
 }
 
 func NewQuestionDTO(
@@ -25,13 +30,17 @@ func NewQuestionDTO(
 	answerTypeDTO IAnswerTypeDTO,
 	questionOptionDTO IQuestionOptionDTO,
 	surveyResponseDTO ISurveyResponseDTO,
+	questionConditionDTO IQuestionConditionDTO,
+	questionMatrixRowDTO IQuestionMatrixRowDTO,
 ) IQuestionDTO {
 	return &QuestionDTO{
-		Log:               log,
-		Viper:             viper,
-		AnswerTypeDTO:     answerTypeDTO,
-		QuestionOptionDTO: questionOptionDTO,
-		SurveyResponseDTO: surveyResponseDTO,
+		Log:                  log,
+		Viper:                viper,
+		AnswerTypeDTO:        answerTypeDTO,
+		QuestionOptionDTO:    questionOptionDTO,
+		SurveyResponseDTO:    surveyResponseDTO,
+		QuestionConditionDTO: questionConditionDTO,
+		QuestionMatrixRowDTO: questionMatrixRowDTO,
 	}
 }
 
@@ -39,7 +48,9 @@ func QuestionDTOFactory(log *logrus.Logger, viper *viper.Viper) IQuestionDTO {
 	answerTypeDTO := AnswerTypeDTOFactory(log)
 	questionOptionDTO := QuestionOptionDTOFactory(log, viper)
 	surveyResponseDTO := SurveyResponseDTOFactory(log, viper)
-	return NewQuestionDTO(log, viper, answerTypeDTO, questionOptionDTO, surveyResponseDTO)
+	questionConditionDTO := QuestionConditionDTOFactory(log, viper)
+	questionMatrixRowDTO := QuestionMatrixRowDTOFactory(log, viper)
+	return NewQuestionDTO(log, viper, answerTypeDTO, questionOptionDTO, surveyResponseDTO, questionConditionDTO, questionMatrixRowDTO)
 }
 
 func (dto *QuestionDTO) ConvertEntityToResponse(ent *entity.Question) *response.QuestionResponse {
@@ -55,10 +66,29 @@ func (dto *QuestionDTO) ConvertEntityToResponse(ent *entity.Question) *response.
 			path := dto.Viper.GetString("app.url") + *ent.Attachment
 			return &path
 		}(),
-		Number:    ent.Number,
-		MaxStars:  ent.MaxStars,
-		CreatedAt: ent.CreatedAt,
-		UpdatedAt: ent.UpdatedAt,
+		Number:     ent.Number,
+		MaxStars:   ent.MaxStars,
+		Points:     ent.Points,
+		IsRequired: ent.IsRequired,
+		MinLength:  ent.MinLength,
+		MaxLength:  ent.MaxLength,
+		Pattern:    ent.Pattern,
+		AllowedFileTypes: func() []string {
+			if ent.AllowedFileTypes == nil || *ent.AllowedFileTypes == "" {
+				return nil
+			}
+			return strings.Split(*ent.AllowedFileTypes, ",")
+		}(),
+		MaxFileSizeKB:  ent.MaxFileSizeKB,
+		MinSelections:  ent.MinSelections,
+		MaxSelections:  ent.MaxSelections,
+		MinValue:       ent.MinValue,
+		MaxValue:       ent.MaxValue,
+		MinDate:        formatQuestionDate(ent.MinDate),
+		MaxDate:        formatQuestionDate(ent.MaxDate),
+		ConditionMatch: string(ent.ConditionMatch),
+		CreatedAt:      ent.CreatedAt,
+		UpdatedAt:      ent.UpdatedAt,
 
 		AnswerType: func() *response.AnswerTypeResponse {
 			if ent.AnswerType == nil {
@@ -76,6 +106,16 @@ func (dto *QuestionDTO) ConvertEntityToResponse(ent *entity.Question) *response.
 			}
 			return responses
 		}(),
+		MatrixRows: func() []response.QuestionMatrixRowResponse {
+			if len(ent.MatrixRows) == 0 {
+				return nil
+			}
+			var responses []response.QuestionMatrixRowResponse
+			for _, matrixRow := range ent.MatrixRows {
+				responses = append(responses, *dto.QuestionMatrixRowDTO.ConvertEntityToResponse(&matrixRow))
+			}
+			return responses
+		}(),
 		SurveyResponses: func() []response.SurveyResponseResponse {
 			if len(ent.SurveyResponses) == 0 {
 				return nil
@@ -86,5 +126,23 @@ func (dto *QuestionDTO) ConvertEntityToResponse(ent *entity.Question) *response.
 			}
 			return responses
 		}(),
+		DisplayConditions: func() []response.QuestionConditionResponse {
+			if len(ent.DisplayConditions) == 0 {
+				return nil
+			}
+			var responses []response.QuestionConditionResponse
+			for _, questionCondition := range ent.DisplayConditions {
+				responses = append(responses, *dto.QuestionConditionDTO.ConvertEntityToResponse(&questionCondition))
+			}
+			return responses
+		}(),
+	}
+}
+
+func formatQuestionDate(date *time.Time) *string {
+	if date == nil {
+		return nil
 	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
 }
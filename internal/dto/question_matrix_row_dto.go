@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IQuestionMatrixRowDTO interface {
+	ConvertEntityToResponse(ent *entity.QuestionMatrixRow) *response.QuestionMatrixRowResponse
+}
+
+type QuestionMatrixRowDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewQuestionMatrixRowDTO(log *logrus.Logger, viper *viper.Viper) IQuestionMatrixRowDTO {
+	return &QuestionMatrixRowDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func QuestionMatrixRowDTOFactory(log *logrus.Logger, viper *viper.Viper) IQuestionMatrixRowDTO {
+	return NewQuestionMatrixRowDTO(log, viper)
+}
+
+func (dto *QuestionMatrixRowDTO) ConvertEntityToResponse(ent *entity.QuestionMatrixRow) *response.QuestionMatrixRowResponse {
+	return &response.QuestionMatrixRowResponse{
+		ID:         ent.ID,
+		QuestionID: ent.QuestionID,
+		RowText:    ent.RowText,
+		Number:     ent.Number,
+		CreatedAt:  ent.CreatedAt,
+		UpdatedAt:  ent.UpdatedAt,
+	}
+}
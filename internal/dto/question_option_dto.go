@@ -32,6 +32,7 @@ func (dto *QuestionOptionDTO) ConvertEntityToResponse(ent *entity.QuestionOption
 		ID:         ent.ID,
 		QuestionID: ent.QuestionID,
 		OptionText: ent.OptionText,
+		IsCorrect:  &ent.IsCorrect,
 		CreatedAt:  ent.CreatedAt,
 		UpdatedAt:  ent.UpdatedAt,
 	}
@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyCampaignDTO interface {
+	ConvertEntityToResponse(ent *entity.SurveyCampaign) *response.SurveyCampaignResponse
+	ConvertDispatchEntityToResponse(ent *entity.SurveyCampaignDispatch) *response.SurveyCampaignDispatchResponse
+}
+
+type SurveyCampaignDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewSurveyCampaignDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) ISurveyCampaignDTO {
+	return &SurveyCampaignDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func SurveyCampaignDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyCampaignDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewSurveyCampaignDTO(log, viper, employeeMessage)
+}
+
+func (dto *SurveyCampaignDTO) ConvertEntityToResponse(ent *entity.SurveyCampaign) *response.SurveyCampaignResponse {
+	offsetDays := make([]int, 0, len(ent.SurveyCampaignOffsets))
+	for _, offset := range ent.SurveyCampaignOffsets {
+		offsetDays = append(offsetDays, offset.OffsetDays)
+	}
+
+	rules := make([]response.SurveyCampaignRuleResponse, 0, len(ent.SurveyCampaignRules))
+	for _, rule := range ent.SurveyCampaignRules {
+		rules = append(rules, response.SurveyCampaignRuleResponse{
+			ID:          rule.ID,
+			GroupNumber: rule.GroupNumber,
+			Criterion:   rule.Criterion,
+			Operator:    rule.Operator,
+			Value:       rule.Value,
+		})
+	}
+
+	res := &response.SurveyCampaignResponse{
+		ID:               ent.ID,
+		Name:             ent.Name,
+		SurveyTemplateID: ent.SurveyTemplateID,
+		Status:           ent.Status,
+		StartDate:        ent.StartDate,
+		ResponseDays:     ent.ResponseDays,
+		ReminderDays:     ent.ReminderDays,
+		OffsetDays:       offsetDays,
+		Rules:            rules,
+		CreatedBy:        ent.CreatedBy,
+		CreatedAt:        ent.CreatedAt,
+		UpdatedAt:        ent.UpdatedAt,
+	}
+	if ent.SurveyTemplate != nil {
+		res.SurveyNumber = ent.SurveyTemplate.SurveyNumber
+		res.SurveyTemplateTitle = ent.SurveyTemplate.Title
+	}
+
+	return res
+}
+
+func (dto *SurveyCampaignDTO) ConvertDispatchEntityToResponse(ent *entity.SurveyCampaignDispatch) *response.SurveyCampaignDispatchResponse {
+	employeeName := ""
+	employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: ent.EmployeeID.String(),
+	})
+	if err != nil {
+		dto.Log.Errorf("[SurveyCampaignDTO.ConvertDispatchEntityToResponse] " + err.Error())
+	} else {
+		employeeName = employee.Name
+	}
+
+	return &response.SurveyCampaignDispatchResponse{
+		ID:               ent.ID,
+		SurveyCampaignID: ent.SurveyCampaignID,
+		EmployeeHiringID: ent.EmployeeHiringID,
+		EmployeeID:       ent.EmployeeID,
+		EmployeeName:     employeeName,
+		EmployeeTaskID:   ent.EmployeeTaskID,
+		OffsetDays:       ent.OffsetDays,
+		ScheduledOn:      ent.ScheduledOn,
+		DueDate:          ent.DueDate,
+		Status:           ent.Status,
+		Message:          ent.Message,
+		SentAt:           ent.SentAt,
+		RemindedAt:       ent.RemindedAt,
+		ClosedAt:         ent.ClosedAt,
+		CreatedAt:        ent.CreatedAt,
+		UpdatedAt:        ent.UpdatedAt,
+	}
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyDistributionDTO interface {
+	ConvertEntityToResponse(ent *entity.SurveyDistribution) *response.SurveyDistributionResponse
+	ConvertRecipientEntityToResponse(ent *entity.SurveyDistributionRecipient) *response.SurveyDistributionRecipientResponse
+}
+
+type SurveyDistributionDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewSurveyDistributionDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) ISurveyDistributionDTO {
+	return &SurveyDistributionDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func SurveyDistributionDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyDistributionDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewSurveyDistributionDTO(log, viper, employeeMessage)
+}
+
+func (dto *SurveyDistributionDTO) ConvertEntityToResponse(ent *entity.SurveyDistribution) *response.SurveyDistributionResponse {
+	rules := make([]response.SurveyDistributionRuleResponse, 0, len(ent.SurveyDistributionRules))
+	for _, rule := range ent.SurveyDistributionRules {
+		rules = append(rules, response.SurveyDistributionRuleResponse{
+			ID:          rule.ID,
+			GroupNumber: rule.GroupNumber,
+			Criterion:   rule.Criterion,
+			Operator:    rule.Operator,
+			Value:       rule.Value,
+		})
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	res := &response.SurveyDistributionResponse{
+		ID:               ent.ID,
+		Name:             ent.Name,
+		SurveyTemplateID: ent.SurveyTemplateID,
+		Status:           ent.Status(time.Now().In(loc)),
+		OpensOn:          ent.OpensOn,
+		ClosesOn:         ent.ClosesOn,
+		ClosedAt:         ent.ClosedAt,
+		Rules:            rules,
+		CreatedBy:        ent.CreatedBy,
+		CreatedAt:        ent.CreatedAt,
+		UpdatedAt:        ent.UpdatedAt,
+	}
+	if ent.SurveyTemplate != nil {
+		res.SurveyNumber = ent.SurveyTemplate.SurveyNumber
+		res.SurveyTemplateTitle = ent.SurveyTemplate.Title
+		res.VersionNumber = ent.SurveyTemplate.VersionNumber
+	}
+
+	return res
+}
+
+func (dto *SurveyDistributionDTO) ConvertRecipientEntityToResponse(ent *entity.SurveyDistributionRecipient) *response.SurveyDistributionRecipientResponse {
+	employeeName := ""
+	employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: ent.EmployeeID.String(),
+	})
+	if err != nil {
+		dto.Log.Errorf("[SurveyDistributionDTO.ConvertRecipientEntityToResponse] " + err.Error())
+	} else {
+		employeeName = employee.Name
+	}
+
+	res := &response.SurveyDistributionRecipientResponse{
+		ID:                   ent.ID,
+		SurveyDistributionID: ent.SurveyDistributionID,
+		EmployeeID:           ent.EmployeeID,
+		EmployeeName:         employeeName,
+		Status:               ent.Status,
+		SubmittedAt:          ent.SubmittedAt,
+		CreatedAt:            ent.CreatedAt,
+		UpdatedAt:            ent.UpdatedAt,
+	}
+	if ent.SurveyDistribution != nil {
+		res.SurveyDistribution = dto.ConvertEntityToResponse(ent.SurveyDistribution)
+	}
+
+	return res
+}
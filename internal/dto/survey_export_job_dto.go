@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyExportJobDTO interface {
+	ConvertEntityToResponse(ent *entity.SurveyExportJob) *response.SurveyExportJobResponse
+}
+
+type SurveyExportJobDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewSurveyExportJobDTO(log *logrus.Logger, viper *viper.Viper) ISurveyExportJobDTO {
+	return &SurveyExportJobDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func SurveyExportJobDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyExportJobDTO {
+	return NewSurveyExportJobDTO(log, viper)
+}
+
+func (dto *SurveyExportJobDTO) ConvertEntityToResponse(ent *entity.SurveyExportJob) *response.SurveyExportJobResponse {
+	var progress float64
+	if ent.Total > 0 {
+		progress = float64(ent.Processed) / float64(ent.Total) * 100
+	}
+
+	var downloadURL string
+	if ent.Status == entity.SURVEY_EXPORT_JOB_STATUS_ENUM_COMPLETED && ent.Path != "" {
+		downloadURL = dto.Viper.GetString("app.url") + ent.Path
+	}
+
+	return &response.SurveyExportJobResponse{
+		ID:               ent.ID,
+		Format:           ent.Format,
+		SurveyTemplateID: ent.SurveyTemplateID,
+		AllVersions:      ent.AllVersions,
+		OrganizationID:   ent.OrganizationID,
+		SubmittedFrom:    ent.SubmittedFrom,
+		SubmittedTo:      ent.SubmittedTo,
+		Status:           ent.Status,
+		Total:            ent.Total,
+		Processed:        ent.Processed,
+		Rows:             ent.Rows,
+		Progress:         progress,
+		DownloadURL:      downloadURL,
+		Message:          ent.Message,
+		RequestedBy:      ent.RequestedBy,
+		StartedAt:        ent.StartedAt,
+		FinishedAt:       ent.FinishedAt,
+		CreatedAt:        ent.CreatedAt,
+		UpdatedAt:        ent.UpdatedAt,
+	}
+}
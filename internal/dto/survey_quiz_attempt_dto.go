@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyQuizAttemptDTO interface {
+	ConvertEntityToResponse(ent *entity.SurveyQuizAttempt) *response.SurveyQuizAttemptResponse
+}
+
+type SurveyQuizAttemptDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewSurveyQuizAttemptDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) ISurveyQuizAttemptDTO {
+	return &SurveyQuizAttemptDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func SurveyQuizAttemptDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyQuizAttemptDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewSurveyQuizAttemptDTO(log, viper, employeeMessage)
+}
+
+func (dto *SurveyQuizAttemptDTO) ConvertEntityToResponse(ent *entity.SurveyQuizAttempt) *response.SurveyQuizAttemptResponse {
+	employeeName := ""
+	if ent.EmployeeID != nil {
+		employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+			ID: ent.EmployeeID.String(),
+		})
+		if err != nil {
+			dto.Log.Errorf("[SurveyQuizAttemptDTO.ConvertEntityToResponse] " + err.Error())
+		} else if employee != nil {
+			employeeName = employee.Name
+		}
+	}
+
+	answers := make([]response.SurveyQuizAttemptAnswerResponse, 0, len(ent.Answers))
+	for _, answer := range ent.Answers {
+		answerResponse := response.SurveyQuizAttemptAnswerResponse{
+			ID:         answer.ID,
+			QuestionID: answer.QuestionID,
+			Answer:     answer.Answer,
+			IsCorrect:  answer.IsCorrect,
+			Points:     answer.Points,
+			MaxPoints:  answer.MaxPoints,
+		}
+		if answer.Question != nil {
+			answerResponse.Question = answer.Question.Question
+			answerResponse.Number = answer.Question.Number
+		}
+		answers = append(answers, answerResponse)
+	}
+
+	return &response.SurveyQuizAttemptResponse{
+		ID:               ent.ID,
+		SurveyTemplateID: ent.SurveyTemplateID,
+		EmployeeTaskID:   ent.EmployeeTaskID,
+		EmployeeID:       ent.EmployeeID,
+		EmployeeName:     employeeName,
+		AttemptNumber:    ent.AttemptNumber,
+		Score:            ent.Score,
+		MaxScore:         ent.MaxScore,
+		Percentage:       ent.Percentage,
+		PassingScore:     ent.PassingScore,
+		Passed:           ent.Passed,
+		SubmittedAt:      ent.SubmittedAt,
+		Answers:          answers,
+	}
+}
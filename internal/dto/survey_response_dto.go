@@ -29,11 +29,12 @@ func SurveyResponseDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyRes
 
 func (dto *SurveyResponseDTO) ConvertEntityToResponse(ent *entity.SurveyResponse) *response.SurveyResponseResponse {
 	return &response.SurveyResponseResponse{
-		ID:               ent.ID,
-		SurveyTemplateID: ent.SurveyTemplateID,
-		EmployeeTaskID:   ent.EmployeeTaskID,
-		QuestionID:       ent.QuestionID,
-		Answer:           ent.Answer,
+		ID:                            ent.ID,
+		SurveyTemplateID:              ent.SurveyTemplateID,
+		EmployeeTaskID:                ent.EmployeeTaskID,
+		SurveyDistributionRecipientID: ent.SurveyDistributionRecipientID,
+		QuestionID:                    ent.QuestionID,
+		Answer:                        ent.Answer,
 		AnswerFile: func() string {
 			if ent.AnswerFile != "" {
 				return dto.Viper.GetString("app.url") + ent.AnswerFile
@@ -32,12 +32,19 @@ func SurveyTemplateDTOFactory(log *logrus.Logger, viper *viper.Viper) ISurveyTem
 
 func (dto *SurveyTemplateDTO) ConvertEntityToResponse(ent *entity.SurveyTemplate) *response.SurveyTemplateResponse {
 	return &response.SurveyTemplateResponse{
-		ID:           ent.ID,
-		SurveyNumber: ent.SurveyNumber,
-		Title:        ent.Title,
-		Status:       ent.Status,
-		CreatedAt:    ent.CreatedAt,
-		UpdatedAt:    ent.UpdatedAt,
+		ID:            ent.ID,
+		SurveyNumber:  ent.SurveyNumber,
+		Title:         ent.Title,
+		Status:        ent.Status,
+		VersionNumber: ent.VersionNumber,
+		PublishedAt:   ent.PublishedAt,
+		IsQuiz:        ent.IsQuiz,
+		PassingScore:  ent.PassingScore,
+		MaxAttempts:   ent.MaxAttempts,
+		Anonymity:     ent.Anonymity,
+		MinGroupSize:  ent.MinGroupSize,
+		CreatedAt:     ent.CreatedAt,
+		UpdatedAt:     ent.UpdatedAt,
 
 		Questions: func() []response.QuestionResponse {
 			if ent.Questions == nil {
@@ -52,3 +59,26 @@ func (dto *SurveyTemplateDTO) ConvertEntityToResponse(ent *entity.SurveyTemplate
 		}(),
 	}
 }
+
+// HideQuizAnswerKey removes the correct options from a survey template shown to the employee
+// who answers it.
+func HideQuizAnswerKey(resp *response.SurveyTemplateResponse) *response.SurveyTemplateResponse {
+	if resp == nil {
+		return nil
+	}
+	for i := range resp.Questions {
+		HideQuestionAnswerKey(&resp.Questions[i])
+	}
+	return resp
+}
+
+// HideQuestionAnswerKey removes the correct options from a single question.
+func HideQuestionAnswerKey(resp *response.QuestionResponse) *response.QuestionResponse {
+	if resp == nil {
+		return nil
+	}
+	for i := range resp.QuestionOptions {
+		resp.QuestionOptions[i].IsCorrect = nil
+	}
+	return resp
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ITemplateTaskApprovalStepDTO interface {
+	ConvertEntityToResponse(ent *entity.TemplateTaskApprovalStep) *response.TemplateTaskApprovalStepResponse
+}
+
+type TemplateTaskApprovalStepDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewTemplateTaskApprovalStepDTO(log *logrus.Logger, viper *viper.Viper) ITemplateTaskApprovalStepDTO {
+	return &TemplateTaskApprovalStepDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func TemplateTaskApprovalStepDTOFactory(log *logrus.Logger, viper *viper.Viper) ITemplateTaskApprovalStepDTO {
+	return NewTemplateTaskApprovalStepDTO(log, viper)
+}
+
+func (dto *TemplateTaskApprovalStepDTO) ConvertEntityToResponse(ent *entity.TemplateTaskApprovalStep) *response.TemplateTaskApprovalStepResponse {
+	return &response.TemplateTaskApprovalStepResponse{
+		ID:                 ent.ID,
+		TemplateTaskID:     ent.TemplateTaskID,
+		StepOrder:          ent.StepOrder,
+		ApproverType:       ent.ApproverType,
+		ApproverEmployeeID: ent.ApproverEmployeeID,
+		CreatedAt:          ent.CreatedAt,
+		UpdatedAt:          ent.UpdatedAt,
+	}
+}
@@ -32,6 +32,10 @@ func (dto *TemplateTaskChecklistDTO) ConvertEntityToResponse(ent *entity.Templat
 		ID:             ent.ID,
 		TemplateTaskID: ent.TemplateTaskID,
 		Name:           ent.Name,
+		SortOrder:      ent.SortOrder,
+		AssigneeID:     ent.AssigneeID,
+		DueOffsetDays:  ent.DueOffsetDays,
+		IsRequired:     ent.IsRequired,
 		CreatedAt:      ent.CreatedAt,
 		UpdatedAt:      ent.UpdatedAt,
 	}
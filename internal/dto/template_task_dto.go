@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"sort"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
 	"github.com/google/uuid"
@@ -13,20 +15,24 @@ type ITemplateTaskDTO interface {
 }
 
 type TemplateTaskDTO struct {
-	Log                       *logrus.Logger
-	Viper                     *viper.Viper
-	TemplateTaskAttachmentDTO ITemplateTaskAttachmentDTO
-	TemplateTaskChecklistDTO  ITemplateTaskChecklistDTO
-	SurveyTemplateDTO         ISurveyTemplateDTO
+	Log                         *logrus.Logger
+	Viper                       *viper.Viper
+	TemplateTaskAttachmentDTO   ITemplateTaskAttachmentDTO
+	TemplateTaskChecklistDTO    ITemplateTaskChecklistDTO
+	SurveyTemplateDTO           ISurveyTemplateDTO
+	TemplateTaskRuleDTO         ITemplateTaskRuleDTO
+	TemplateTaskApprovalStepDTO ITemplateTaskApprovalStepDTO
 }
 
-func NewTemplateTaskDTO(log *logrus.Logger, viper *viper.Viper, templateTaskAttachmentDTO ITemplateTaskAttachmentDTO, templateTaskChecklistDTO ITemplateTaskChecklistDTO, surveyTemplateDTO ISurveyTemplateDTO) ITemplateTaskDTO {
+func NewTemplateTaskDTO(log *logrus.Logger, viper *viper.Viper, templateTaskAttachmentDTO ITemplateTaskAttachmentDTO, templateTaskChecklistDTO ITemplateTaskChecklistDTO, surveyTemplateDTO ISurveyTemplateDTO, templateTaskRuleDTO ITemplateTaskRuleDTO, templateTaskApprovalStepDTO ITemplateTaskApprovalStepDTO) ITemplateTaskDTO {
 	return &TemplateTaskDTO{
-		Log:                       log,
-		Viper:                     viper,
-		TemplateTaskAttachmentDTO: templateTaskAttachmentDTO,
-		TemplateTaskChecklistDTO:  templateTaskChecklistDTO,
-		SurveyTemplateDTO:         surveyTemplateDTO,
+		Log:                         log,
+		Viper:                       viper,
+		TemplateTaskAttachmentDTO:   templateTaskAttachmentDTO,
+		TemplateTaskChecklistDTO:    templateTaskChecklistDTO,
+		SurveyTemplateDTO:           surveyTemplateDTO,
+		TemplateTaskRuleDTO:         templateTaskRuleDTO,
+		TemplateTaskApprovalStepDTO: templateTaskApprovalStepDTO,
 	}
 }
 
@@ -34,7 +40,9 @@ func TemplateTaskDTOFactory(log *logrus.Logger, viper *viper.Viper) ITemplateTas
 	templateTaskAttachmentDTO := TemplateTaskAttachmentDTOFactory(log, viper)
 	templateTaskChecklistDTO := TemplateTaskChecklistDTOFactory(log, viper)
 	surveyTemplateDTO := SurveyTemplateDTOFactory(log, viper)
-	return NewTemplateTaskDTO(log, viper, templateTaskAttachmentDTO, templateTaskChecklistDTO, surveyTemplateDTO)
+	templateTaskRuleDTO := TemplateTaskRuleDTOFactory(log, viper)
+	templateTaskApprovalStepDTO := TemplateTaskApprovalStepDTOFactory(log, viper)
+	return NewTemplateTaskDTO(log, viper, templateTaskAttachmentDTO, templateTaskChecklistDTO, surveyTemplateDTO, templateTaskRuleDTO, templateTaskApprovalStepDTO)
 }
 
 func (dto *TemplateTaskDTO) ConvertEntityToResponse(ent *entity.TemplateTask) *response.TemplateTaskResponse {
@@ -67,6 +75,8 @@ func (dto *TemplateTaskDTO) ConvertEntityToResponse(ent *entity.TemplateTask) *r
 		Status:           ent.Status,
 		Source:           ent.Source,
 		OrganizationType: ent.OrganizationType,
+		Kind:             ent.TaskKind(),
+		PassingScore:     ent.PassingScore,
 		CreatedAt:        ent.CreatedAt,
 		UpdatedAt:        ent.UpdatedAt,
 		TemplateTaskAttachments: func() []response.TemplateTaskAttachmentResponse {
@@ -85,12 +95,38 @@ func (dto *TemplateTaskDTO) ConvertEntityToResponse(ent *entity.TemplateTask) *r
 			if ent.TemplateTaskChecklists == nil || len(ent.TemplateTaskChecklists) == 0 {
 				return nil
 			}
-			for _, checklist := range ent.TemplateTaskChecklists {
+			sortedChecklists := append(ent.TemplateTaskChecklists[:0:0], ent.TemplateTaskChecklists...)
+			sort.SliceStable(sortedChecklists, func(i, j int) bool {
+				return sortedChecklists[i].SortOrder < sortedChecklists[j].SortOrder
+			})
+			for _, checklist := range sortedChecklists {
 				resp := dto.TemplateTaskChecklistDTO.ConvertEntityToResponse(&checklist)
 				res = append(res, *resp)
 			}
 			return res
 		}(),
+		TemplateTaskRules: func() []response.TemplateTaskRuleResponse {
+			var res []response.TemplateTaskRuleResponse
+			if len(ent.TemplateTaskRules) == 0 {
+				return nil
+			}
+			for _, rule := range ent.TemplateTaskRules {
+				resp := dto.TemplateTaskRuleDTO.ConvertEntityToResponse(&rule)
+				res = append(res, *resp)
+			}
+			return res
+		}(),
+		TemplateTaskApprovalSteps: func() []response.TemplateTaskApprovalStepResponse {
+			var res []response.TemplateTaskApprovalStepResponse
+			if len(ent.TemplateTaskApprovalSteps) == 0 {
+				return nil
+			}
+			for _, step := range ent.TemplateTaskApprovalSteps {
+				resp := dto.TemplateTaskApprovalStepDTO.ConvertEntityToResponse(&step)
+				res = append(res, *resp)
+			}
+			return res
+		}(),
 		SurveyTemplate: func() *response.SurveyTemplateResponse {
 			if ent.SurveyTemplate == nil {
 				return nil
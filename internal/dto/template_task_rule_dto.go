@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ITemplateTaskRuleDTO interface {
+	ConvertEntityToResponse(ent *entity.TemplateTaskRule) *response.TemplateTaskRuleResponse
+}
+
+type TemplateTaskRuleDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewTemplateTaskRuleDTO(log *logrus.Logger, viper *viper.Viper) ITemplateTaskRuleDTO {
+	return &TemplateTaskRuleDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func TemplateTaskRuleDTOFactory(log *logrus.Logger, viper *viper.Viper) ITemplateTaskRuleDTO {
+	return NewTemplateTaskRuleDTO(log, viper)
+}
+
+func (dto *TemplateTaskRuleDTO) ConvertEntityToResponse(ent *entity.TemplateTaskRule) *response.TemplateTaskRuleResponse {
+	return &response.TemplateTaskRuleResponse{
+		ID:             ent.ID,
+		TemplateTaskID: ent.TemplateTaskID,
+		GroupNumber:    ent.GroupNumber,
+		Criterion:      ent.Criterion,
+		Operator:       ent.Operator,
+		Value:          ent.Value,
+		CreatedAt:      ent.CreatedAt,
+		UpdatedAt:      ent.UpdatedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ITemplateTaskVersionDTO interface {
+	ConvertEntityToResponse(ent *entity.TemplateTaskVersion) *response.TemplateTaskVersionResponse
+}
+
+type TemplateTaskVersionDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewTemplateTaskVersionDTO(log *logrus.Logger, viper *viper.Viper) ITemplateTaskVersionDTO {
+	return &TemplateTaskVersionDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func TemplateTaskVersionDTOFactory(log *logrus.Logger, viper *viper.Viper) ITemplateTaskVersionDTO {
+	return NewTemplateTaskVersionDTO(log, viper)
+}
+
+func (dto *TemplateTaskVersionDTO) ConvertEntityToResponse(ent *entity.TemplateTaskVersion) *response.TemplateTaskVersionResponse {
+	var snapshot *entity.TemplateTaskSnapshot
+	if ent.Snapshot != "" {
+		snapshot = &entity.TemplateTaskSnapshot{}
+		if err := json.Unmarshal([]byte(ent.Snapshot), snapshot); err != nil {
+			dto.Log.Error("[TemplateTaskVersionDTO.ConvertEntityToResponse] error decoding snapshot: ", err)
+			snapshot = nil
+		}
+	}
+
+	return &response.TemplateTaskVersionResponse{
+		ID:             ent.ID,
+		TemplateTaskID: ent.TemplateTaskID,
+		VersionNumber:  ent.VersionNumber,
+		Propagation:    ent.Propagation,
+		ChangeNote:     ent.ChangeNote,
+		Snapshot:       snapshot,
+		CreatedAt:      ent.CreatedAt,
+	}
+}
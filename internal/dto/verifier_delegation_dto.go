@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IVerifierDelegationDTO interface {
+	ConvertEntityToResponse(ent *entity.VerifierDelegation) *response.VerifierDelegationResponse
+}
+
+type VerifierDelegationDTO struct {
+	Log             *logrus.Logger
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewVerifierDelegationDTO(log *logrus.Logger, viper *viper.Viper, employeeMessage messaging.IEmployeeMessage) IVerifierDelegationDTO {
+	return &VerifierDelegationDTO{
+		Log:             log,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func VerifierDelegationDTOFactory(log *logrus.Logger, viper *viper.Viper) IVerifierDelegationDTO {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewVerifierDelegationDTO(log, viper, employeeMessage)
+}
+
+func (dto *VerifierDelegationDTO) employeeName(id string) string {
+	employee, err := dto.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: id,
+	})
+	if err != nil {
+		dto.Log.Errorf("[VerifierDelegationDTO.ConvertEntityToResponse] " + err.Error())
+		return ""
+	}
+
+	return employee.Name
+}
+
+func (dto *VerifierDelegationDTO) ConvertEntityToResponse(ent *entity.VerifierDelegation) *response.VerifierDelegationResponse {
+	today := time.Now().Format("2006-01-02")
+	return &response.VerifierDelegationResponse{
+		ID:            ent.ID,
+		DelegatorID:   ent.DelegatorID,
+		DelegatorName: dto.employeeName(ent.DelegatorID.String()),
+		DelegateID:    ent.DelegateID,
+		DelegateName:  dto.employeeName(ent.DelegateID.String()),
+		StartDate:     ent.StartDate,
+		EndDate:       ent.EndDate,
+		Reason:        ent.Reason,
+		IsActive:      ent.RevokedAt == nil && ent.StartDate.Format("2006-01-02") <= today && ent.EndDate.Format("2006-01-02") >= today,
+		CreatedBy:     ent.CreatedBy,
+		RevokedAt:     ent.RevokedAt,
+		RevokedBy:     ent.RevokedBy,
+		CreatedAt:     ent.CreatedAt,
+		UpdatedAt:     ent.UpdatedAt,
+	}
+}
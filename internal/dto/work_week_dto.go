@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IWorkWeekDTO interface {
+	ConvertEntityToResponse(ent *entity.WorkWeek) *response.WorkWeekResponse
+}
+
+type WorkWeekDTO struct {
+	Log   *logrus.Logger
+	Viper *viper.Viper
+}
+
+func NewWorkWeekDTO(log *logrus.Logger, viper *viper.Viper) IWorkWeekDTO {
+	return &WorkWeekDTO{
+		Log:   log,
+		Viper: viper,
+	}
+}
+
+func WorkWeekDTOFactory(log *logrus.Logger, viper *viper.Viper) IWorkWeekDTO {
+	return NewWorkWeekDTO(log, viper)
+}
+
+func (dto *WorkWeekDTO) ConvertEntityToResponse(ent *entity.WorkWeek) *response.WorkWeekResponse {
+	return &response.WorkWeekResponse{
+		ID:             ent.ID,
+		OrganizationID: ent.OrganizationID,
+		Monday:         ent.Monday,
+		Tuesday:        ent.Tuesday,
+		Wednesday:      ent.Wednesday,
+		Thursday:       ent.Thursday,
+		Friday:         ent.Friday,
+		Saturday:       ent.Saturday,
+		Sunday:         ent.Sunday,
+		CreatedAt:      ent.CreatedAt,
+		UpdatedAt:      ent.UpdatedAt,
+	}
+}
@@ -7,6 +7,41 @@ import (
 	"gorm.io/gorm"
 )
 
+// Names of the answer types seeded by the migration. Questions refer to their answer type by
+// id, so the name is what tells how an answer is to be read.
+const (
+	ANSWER_TYPE_MULTIPLE_CHOICE = "Multiple Choice"
+	ANSWER_TYPE_SHORT_ANSWER    = "Short Answer"
+	ANSWER_TYPE_LONG_ANSWER     = "Long Answer"
+	ANSWER_TYPE_CHECKBOX        = "Checkbox"
+	ANSWER_TYPE_DROPDOWN        = "Dropdown"
+	ANSWER_TYPE_RATING          = "Rating"
+	ANSWER_TYPE_LINK            = "Link"
+	ANSWER_TYPE_ATTACHMENT      = "Attachment"
+	ANSWER_TYPE_NUMBER          = "Number"
+	ANSWER_TYPE_DATE            = "Date"
+	ANSWER_TYPE_DATE_RANGE      = "Date Range"
+	ANSWER_TYPE_MATRIX          = "Matrix"
+	ANSWER_TYPE_RANKING         = "Ranking"
+)
+
+// AnswerTypeNames lists every answer type the migration seeds.
+var AnswerTypeNames = []string{
+	ANSWER_TYPE_MULTIPLE_CHOICE,
+	ANSWER_TYPE_SHORT_ANSWER,
+	ANSWER_TYPE_LONG_ANSWER,
+	ANSWER_TYPE_CHECKBOX,
+	ANSWER_TYPE_DROPDOWN,
+	ANSWER_TYPE_RATING,
+	ANSWER_TYPE_LINK,
+	ANSWER_TYPE_ATTACHMENT,
+	ANSWER_TYPE_NUMBER,
+	ANSWER_TYPE_DATE,
+	ANSWER_TYPE_DATE_RANGE,
+	ANSWER_TYPE_MATRIX,
+	ANSWER_TYPE_RANKING,
+}
+
 type AnswerType struct {
 	gorm.Model `json:"-"`
 	ID         uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
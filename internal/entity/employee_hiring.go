@@ -7,11 +7,22 @@ import (
 	"gorm.io/gorm"
 )
 
+type EmployeeHiringStatusEnum string
+
+const (
+	EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE    EmployeeHiringStatusEnum = "ACTIVE"
+	EMPLOYEE_HIRING_STATUS_ENUM_PAUSED    EmployeeHiringStatusEnum = "PAUSED"
+	EMPLOYEE_HIRING_STATUS_ENUM_CANCELLED EmployeeHiringStatusEnum = "CANCELLED"
+)
+
 type EmployeeHiring struct {
-	gorm.Model `json:"-"`
-	ID         uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
-	EmployeeID uuid.UUID `json:"employee_id" gorm:"type:char(36);not null"`
-	HiringDate time.Time `json:"hiring_date" gorm:"type:date;not null"`
+	gorm.Model      `json:"-"`
+	ID              uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
+	EmployeeID      uuid.UUID                `json:"employee_id" gorm:"type:char(36);not null"`
+	HiringDate      time.Time                `json:"hiring_date" gorm:"type:date;not null"`
+	Status          EmployeeHiringStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'ACTIVE'"`
+	StatusReason    string                   `json:"status_reason" gorm:"type:text;default:null"`
+	StatusChangedAt *time.Time               `json:"status_changed_at" gorm:"type:timestamp;default:null"`
 }
 
 func (e *EmployeeHiring) BeforeCreate(tx *gorm.DB) (err error) {
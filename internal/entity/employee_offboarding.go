@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EmployeeOffboarding struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	EmployeeID       uuid.UUID `json:"employee_id" gorm:"type:char(36);not null"`
+	OrganizationType string    `json:"organization_type" gorm:"type:varchar(255);not null"`
+	StartDate        time.Time `json:"start_date" gorm:"type:date;not null"`
+	LastWorkingDate  time.Time `json:"last_working_date" gorm:"type:date;not null"`
+	Reason           string    `json:"reason" gorm:"type:text;default:null"`
+}
+
+func (e *EmployeeOffboarding) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *EmployeeOffboarding) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (EmployeeOffboarding) TableName() string {
+	return "employee_offboardings"
+}
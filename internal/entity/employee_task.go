@@ -31,28 +31,73 @@ const (
 	EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED   EmployeeTaskKanbanEnum = "COMPLETED"
 )
 
+// Sources of employee and template tasks. Onboarding and offboarding tasks are planned from
+// their own templates and kept on separate kanban boards.
+const (
+	TASK_SOURCE_ONBOARDING  = "ONBOARDING"
+	TASK_SOURCE_OFFBOARDING = "OFFBOARDING"
+)
+
+// TaskKindEnum decides what completes a task besides its checklist and approvals. Tasks made
+// before kinds existed have no kind, see EmployeeTask.TaskKind.
+type TaskKindEnum string
+
+const (
+	TASK_KIND_ENUM_GENERIC          TaskKindEnum = "GENERIC"
+	TASK_KIND_ENUM_SURVEY           TaskKindEnum = "SURVEY"
+	TASK_KIND_ENUM_DOCUMENT_UPLOAD  TaskKindEnum = "DOCUMENT_UPLOAD"
+	TASK_KIND_ENUM_ACKNOWLEDGEMENT  TaskKindEnum = "ACKNOWLEDGEMENT"
+	TASK_KIND_ENUM_QUIZ             TaskKindEnum = "QUIZ"
+	TASK_KIND_ENUM_EVENT_ATTENDANCE TaskKindEnum = "EVENT_ATTENDANCE"
+)
+
+// ResolveTaskKind gives tasks without a kind the one they behaved as, a survey task when a
+// survey template is linked and a generic task otherwise.
+func ResolveTaskKind(kind TaskKindEnum, surveyTemplateID *uuid.UUID) TaskKindEnum {
+	if kind != "" {
+		return kind
+	}
+	if surveyTemplateID != nil {
+		return TASK_KIND_ENUM_SURVEY
+	}
+	return TASK_KIND_ENUM_GENERIC
+}
+
 type EmployeeTask struct {
-	gorm.Model       `json:"-"`
-	ID               uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
-	CoverPath        *string                  `json:"cover_path" gorm:"type:varchar(255);default:null"`
-	EmployeeID       *uuid.UUID               `json:"employee_id" gorm:"type:char(36);not null"`
-	TemplateTaskID   *uuid.UUID               `json:"template_task_id" gorm:"type:char(36);default:null"`
-	SurveyTemplateID *uuid.UUID               `json:"survey_template_id" gorm:"type:char(36);default:null"`
-	VerifiedBy       *uuid.UUID               `json:"verified_by" gorm:"type:char(36);default:null"`
-	Name             string                   `json:"name" gorm:"type:varchar(255);not null"`
-	Priority         EmployeeTaskPriorityEnum `json:"priority" gorm:"type:varchar(255);not null"`
-	Description      string                   `json:"description" gorm:"type:text;default:null"`
-	StartDate        time.Time                `json:"start_date" gorm:"type:date;not null"`
-	EndDate          time.Time                `json:"end_date" gorm:"type:date;not null"`
-	IsDone           string                   `json:"is_done" gorm:"type:varchar(255);not null;default:'NO'"`
-	Proof            *string                  `json:"proof" gorm:"type:varchar(255);default:null"`
-	Status           EmployeeTaskStatusEnum   `json:"status" gorm:"type:varchar(255);not null;default:'ACTIVE'"`
-	Kanban           EmployeeTaskKanbanEnum   `json:"kanban" gorm:"type:varchar(255);not null;default:'TO_DO'"`
-	Notes            string                   `json:"notes" gorm:"type:text;default:null"`
-	Source           string                   `json:"source" gorm:"type:varchar(255);default:null"`
-	MidsuitID        *string                  `json:"midsuit_id" gorm:"type:varchar(255);default:null"`
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	CoverPath      *string    `json:"cover_path" gorm:"type:varchar(255);default:null"`
+	EmployeeID     *uuid.UUID `json:"employee_id" gorm:"type:char(36);not null"`
+	TemplateTaskID *uuid.UUID `json:"template_task_id" gorm:"type:char(36);default:null"`
+	// TemplateTaskVersionID is the version of the template task the task was generated from
+	TemplateTaskVersionID *uuid.UUID               `json:"template_task_version_id" gorm:"type:char(36);default:null"`
+	SurveyTemplateID      *uuid.UUID               `json:"survey_template_id" gorm:"type:char(36);default:null"`
+	VerifiedBy            *uuid.UUID               `json:"verified_by" gorm:"type:char(36);default:null"`
+	Name                  string                   `json:"name" gorm:"type:varchar(255);not null"`
+	Priority              EmployeeTaskPriorityEnum `json:"priority" gorm:"type:varchar(255);not null"`
+	Description           string                   `json:"description" gorm:"type:text;default:null"`
+	StartDate             time.Time                `json:"start_date" gorm:"type:date;not null"`
+	EndDate               time.Time                `json:"end_date" gorm:"type:date;not null"`
+	IsDone                string                   `json:"is_done" gorm:"type:varchar(255);not null;default:'NO'"`
+	Proof                 *string                  `json:"proof" gorm:"type:varchar(255);default:null"`
+	Status                EmployeeTaskStatusEnum   `json:"status" gorm:"type:varchar(255);not null;default:'ACTIVE'"`
+	Kanban                EmployeeTaskKanbanEnum   `json:"kanban" gorm:"type:varchar(255);not null;default:'TO_DO'"`
+	Notes                 string                   `json:"notes" gorm:"type:text;default:null"`
+	Source                string                   `json:"source" gorm:"type:varchar(255);default:null"`
+	MidsuitID             *string                  `json:"midsuit_id" gorm:"type:varchar(255);default:null"`
+	// PausedAt is set while the task is inactive because the onboarding of the employee is paused
+	PausedAt *time.Time   `json:"paused_at" gorm:"type:timestamp;default:null"`
+	Kind     TaskKindEnum `json:"kind" gorm:"type:varchar(255);default:null"`
+	// PassingScore and QuizScore are percentages, a quiz task completes once its score passes
+	PassingScore   *int       `json:"passing_score" gorm:"type:int;default:null"`
+	QuizScore      *int       `json:"quiz_score" gorm:"type:int;default:null"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at" gorm:"type:timestamp;default:null"`
+	// ClosedAt is set when the response deadline of a survey campaign passed, the survey of a
+	// closed task can no longer be answered
+	ClosedAt *time.Time `json:"closed_at" gorm:"type:timestamp;default:null"`
 
 	TemplateTask            *TemplateTask            `json:"template_task" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TemplateTaskVersion     *TemplateTaskVersion     `json:"template_task_version" gorm:"foreignKey:TemplateTaskVersionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	EmployeeTaskChecklists  []EmployeeTaskChecklist  `json:"employee_task_checklists" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	EmployeeTaskAttachments []EmployeeTaskAttachment `json:"employee_task_attachments" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	EmployeeTaskFiles       []EmployeeTaskFiles      `json:"employee_task_files" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -74,6 +119,10 @@ func (e *EmployeeTask) BeforeUpdate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+func (e *EmployeeTask) TaskKind() TaskKindEnum {
+	return ResolveTaskKind(e.Kind, e.SurveyTemplateID)
+}
+
 func (EmployeeTask) TableName() string {
 	return "employee_tasks"
 }
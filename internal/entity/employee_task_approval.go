@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EmployeeTaskApprovalStatusEnum string
+
+const (
+	EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING  EmployeeTaskApprovalStatusEnum = "PENDING"
+	EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_APPROVED EmployeeTaskApprovalStatusEnum = "APPROVED"
+	EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_REJECTED EmployeeTaskApprovalStatusEnum = "REJECTED"
+)
+
+// EmployeeTaskApproval is one step of the approval chain of an employee task. A rejection sends
+// the task back to the employee and starts a new Round of the whole chain; earlier rounds are
+// kept as history.
+type EmployeeTaskApproval struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID                      `json:"id" gorm:"type:char(36);primaryKey;"`
+	EmployeeTaskID uuid.UUID                      `json:"employee_task_id" gorm:"type:char(36);not null"`
+	Round          int                            `json:"round" gorm:"type:int;not null;default:1"`
+	StepOrder      int                            `json:"step_order" gorm:"type:int;not null"`
+	ApproverType   ApprovalApproverTypeEnum       `json:"approver_type" gorm:"type:varchar(255);not null"`
+	ApproverID     *uuid.UUID                     `json:"approver_id" gorm:"type:char(36);default:null"`
+	Status         EmployeeTaskApprovalStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	ActedBy        *uuid.UUID                     `json:"acted_by" gorm:"type:char(36);default:null"`
+	ActedAt        *time.Time                     `json:"acted_at" gorm:"type:timestamp;default:null"`
+	Reason         string                         `json:"reason" gorm:"type:text;default:null"`
+
+	EmployeeTask *EmployeeTask `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *EmployeeTaskApproval) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *EmployeeTaskApproval) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (EmployeeTaskApproval) TableName() string {
+	return "employee_task_approvals"
+}
@@ -15,6 +15,12 @@ type EmployeeTaskChecklist struct {
 	IsChecked      string     `json:"is_checked" gorm:"type:varchar(255);not null;default:'NO'"`
 	VerifiedBy     *uuid.UUID `json:"verified_by" gorm:"type:char(36);default:null"`
 	MidsuitID      *string    `json:"midsuit_id" gorm:"type:varchar(255);default:null"`
+	SortOrder      int        `json:"sort_order" gorm:"type:int;not null;default:0"`
+	AssigneeID     *uuid.UUID `json:"assignee_id" gorm:"type:char(36);default:null"`
+	DueDate        *time.Time `json:"due_date" gorm:"type:date;default:null"`
+	IsRequired     string     `json:"is_required" gorm:"type:varchar(255);not null;default:'NO'"`
+	CheckedAt      *time.Time `json:"checked_at" gorm:"type:timestamp;default:null"`
+	CheckedBy      *uuid.UUID `json:"checked_by" gorm:"type:char(36);default:null"`
 
 	EmployeeTask *EmployeeTask `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
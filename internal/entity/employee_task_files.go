@@ -7,11 +7,25 @@ import (
 	"gorm.io/gorm"
 )
 
+type EmployeeTaskFileStatusEnum string
+
+const (
+	EMPLOYEE_TASK_FILE_STATUS_ENUM_PENDING  EmployeeTaskFileStatusEnum = "PENDING"
+	EMPLOYEE_TASK_FILE_STATUS_ENUM_ACCEPTED EmployeeTaskFileStatusEnum = "ACCEPTED"
+	EMPLOYEE_TASK_FILE_STATUS_ENUM_REJECTED EmployeeTaskFileStatusEnum = "REJECTED"
+)
+
+// EmployeeTaskFiles are documents the employee uploads for a task. A document upload task is
+// completed by accepting one of them.
 type EmployeeTaskFiles struct {
 	gorm.Model     `json:"-"`
-	ID             uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
-	EmployeeTaskID uuid.UUID `json:"employee_task_id" gorm:"type:char(36);not null"`
-	Path           string    `json:"path" gorm:"type:varchar(255);not null"`
+	ID             uuid.UUID                  `json:"id" gorm:"type:char(36);primaryKey;"`
+	EmployeeTaskID uuid.UUID                  `json:"employee_task_id" gorm:"type:char(36);not null"`
+	Path           string                     `json:"path" gorm:"type:varchar(255);not null"`
+	Status         EmployeeTaskFileStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	ReviewedBy     *uuid.UUID                 `json:"reviewed_by" gorm:"type:char(36);default:null"`
+	ReviewedAt     *time.Time                 `json:"reviewed_at" gorm:"type:timestamp;default:null"`
+	Notes          string                     `json:"notes" gorm:"type:text;default:null"`
 
 	EmployeeTask *EmployeeTask `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EmployeeTaskHistoryActionEnum string
+
+const (
+	EMPLOYEE_TASK_HISTORY_ACTION_ENUM_REASSIGN EmployeeTaskHistoryActionEnum = "REASSIGN"
+)
+
+// EmployeeTaskHistory records who moved an employee task from one employee to another.
+type EmployeeTaskHistory struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID                     `json:"id" gorm:"type:char(36);primaryKey;"`
+	EmployeeTaskID uuid.UUID                     `json:"employee_task_id" gorm:"type:char(36);not null"`
+	Action         EmployeeTaskHistoryActionEnum `json:"action" gorm:"type:varchar(255);not null"`
+	FromEmployeeID *uuid.UUID                    `json:"from_employee_id" gorm:"type:char(36);default:null"`
+	ToEmployeeID   *uuid.UUID                    `json:"to_employee_id" gorm:"type:char(36);default:null"`
+	ActorID        *uuid.UUID                    `json:"actor_id" gorm:"type:char(36);default:null"`
+	Reason         string                        `json:"reason" gorm:"type:text;default:null"`
+
+	EmployeeTask *EmployeeTask `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *EmployeeTaskHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *EmployeeTaskHistory) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (EmployeeTaskHistory) TableName() string {
+	return "employee_task_histories"
+}
@@ -12,6 +12,8 @@ type EventEmployee struct {
 	ID         uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
 	EventID    uuid.UUID  `json:"event_id" gorm:"type:char(36);not null"`
 	EmployeeID *uuid.UUID `json:"employee_id" gorm:"type:char(36);not null"`
+	// CheckedInAt is set when the employee checks in, it completes their event attendance task
+	CheckedInAt *time.Time `json:"checked_in_at" gorm:"type:timestamp;default:null"`
 
 	Event *Event `json:"event" gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
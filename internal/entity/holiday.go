@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type HolidayTypeEnum string
+
+const (
+	HOLIDAY_TYPE_ENUM_NATIONAL         HolidayTypeEnum = "NATIONAL"
+	HOLIDAY_TYPE_ENUM_COLLECTIVE_LEAVE HolidayTypeEnum = "COLLECTIVE_LEAVE"
+	HOLIDAY_TYPE_ENUM_COMPANY          HolidayTypeEnum = "COMPANY"
+)
+
+// Holiday is a non-working date. Rows without an OrganizationID apply to
+// every organization (e.g. Indonesian public holidays and cuti bersama).
+type Holiday struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID       `json:"id" gorm:"type:char(36);primaryKey;"`
+	OrganizationID *uuid.UUID      `json:"organization_id" gorm:"type:char(36);default:null"`
+	Name           string          `json:"name" gorm:"type:varchar(255);not null"`
+	Date           time.Time       `json:"date" gorm:"type:date;not null;index"`
+	Type           HolidayTypeEnum `json:"type" gorm:"type:varchar(255);not null;default:'NATIONAL'"`
+	Description    string          `json:"description" gorm:"type:text;default:null"`
+}
+
+func (h *Holiday) BeforeCreate(tx *gorm.DB) (err error) {
+	h.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	h.CreatedAt = time.Now().In(loc)
+	h.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (h *Holiday) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	h.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OnboardingBackfillStatusEnum string
+
+const (
+	ONBOARDING_BACKFILL_STATUS_ENUM_PENDING   OnboardingBackfillStatusEnum = "PENDING"
+	ONBOARDING_BACKFILL_STATUS_ENUM_RUNNING   OnboardingBackfillStatusEnum = "RUNNING"
+	ONBOARDING_BACKFILL_STATUS_ENUM_COMPLETED OnboardingBackfillStatusEnum = "COMPLETED"
+	ONBOARDING_BACKFILL_STATUS_ENUM_FAILED    OnboardingBackfillStatusEnum = "FAILED"
+)
+
+// OnboardingBackfill is a batch that copies template task changes onto employees
+// whose onboarding is still in progress. The counters are updated as items are applied.
+type OnboardingBackfill struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	OrganizationType string    `json:"organization_type" gorm:"type:varchar(255);not null"`
+	// TemplateTaskVersionID is set when the backfill propagates a template task version,
+	// employee tasks are linked to it once all of their changes are applied
+	TemplateTaskVersionID *uuid.UUID                   `json:"template_task_version_id" gorm:"type:char(36);default:null"`
+	Status                OnboardingBackfillStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	Total                 int                          `json:"total" gorm:"type:int;not null;default:0"`
+	Processed             int                          `json:"processed" gorm:"type:int;not null;default:0"`
+	Succeeded             int                          `json:"succeeded" gorm:"type:int;not null;default:0"`
+	Failed                int                          `json:"failed" gorm:"type:int;not null;default:0"`
+	Skipped               int                          `json:"skipped" gorm:"type:int;not null;default:0"`
+	StartedAt             *time.Time                   `json:"started_at" gorm:"default:null"`
+	FinishedAt            *time.Time                   `json:"finished_at" gorm:"default:null"`
+
+	OnboardingBackfillItems []OnboardingBackfillItem `json:"onboarding_backfill_items" gorm:"foreignKey:OnboardingBackfillID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (o *OnboardingBackfill) BeforeCreate(tx *gorm.DB) (err error) {
+	o.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	o.CreatedAt = time.Now().In(loc)
+	o.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (o *OnboardingBackfill) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	o.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (OnboardingBackfill) TableName() string {
+	return "onboarding_backfills"
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OnboardingBackfillChangeTypeEnum string
+
+const (
+	ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_TASK         OnboardingBackfillChangeTypeEnum = "ADD_TASK"
+	ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_CHECKLIST    OnboardingBackfillChangeTypeEnum = "ADD_CHECKLIST"
+	ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK      OnboardingBackfillChangeTypeEnum = "UPDATE_TASK"
+	ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_CHECKLIST OnboardingBackfillChangeTypeEnum = "UPDATE_CHECKLIST"
+)
+
+type OnboardingBackfillItemStatusEnum string
+
+const (
+	ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_PENDING OnboardingBackfillItemStatusEnum = "PENDING"
+	ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_APPLIED OnboardingBackfillItemStatusEnum = "APPLIED"
+	ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_SKIPPED OnboardingBackfillItemStatusEnum = "SKIPPED"
+	ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_FAILED  OnboardingBackfillItemStatusEnum = "FAILED"
+)
+
+// OnboardingBackfillItem is a single change of a backfill, applied to one employee.
+type OnboardingBackfillItem struct {
+	gorm.Model           `json:"-"`
+	ID                   uuid.UUID                        `json:"id" gorm:"type:char(36);primaryKey;"`
+	OnboardingBackfillID uuid.UUID                        `json:"onboarding_backfill_id" gorm:"type:char(36);not null"`
+	EmployeeID           uuid.UUID                        `json:"employee_id" gorm:"type:char(36);not null"`
+	TemplateTaskID       uuid.UUID                        `json:"template_task_id" gorm:"type:char(36);not null"`
+	EmployeeTaskID       *uuid.UUID                       `json:"employee_task_id" gorm:"type:char(36);default:null"`
+	ChangeType           OnboardingBackfillChangeTypeEnum `json:"change_type" gorm:"type:varchar(255);not null"`
+	ChecklistName        string                           `json:"checklist_name" gorm:"type:varchar(255);default:null"`
+	Status               OnboardingBackfillItemStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	Message              string                           `json:"message" gorm:"type:text;default:null"`
+
+	OnboardingBackfill *OnboardingBackfill `json:"onboarding_backfill" gorm:"foreignKey:OnboardingBackfillID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (o *OnboardingBackfillItem) BeforeCreate(tx *gorm.DB) (err error) {
+	o.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	o.CreatedAt = time.Now().In(loc)
+	o.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (o *OnboardingBackfillItem) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	o.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (OnboardingBackfillItem) TableName() string {
+	return "onboarding_backfill_items"
+}
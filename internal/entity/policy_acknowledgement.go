@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyAcknowledgement records that an employee accepted a version of a policy document.
+// Acknowledgements form a single append-only chain: the hash of every record covers its own
+// fields and the hash of the record before it, so editing or removing a record breaks the
+// chain from there on. Records are never updated and an employee acknowledges a version once.
+type PolicyAcknowledgement struct {
+	gorm.Model              `json:"-"`
+	ID                      uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	Sequence                int64     `json:"sequence" gorm:"type:bigint;not null;uniqueIndex"`
+	PolicyDocumentID        uuid.UUID `json:"policy_document_id" gorm:"type:char(36);not null;index"`
+	PolicyDocumentVersionID uuid.UUID `json:"policy_document_version_id" gorm:"type:char(36);not null;uniqueIndex:idx_policy_acknowledgements_version_employee"`
+	VersionNumber           int       `json:"version_number" gorm:"type:int;not null"`
+	EmployeeID              uuid.UUID `json:"employee_id" gorm:"type:char(36);not null;index;uniqueIndex:idx_policy_acknowledgements_version_employee"`
+	DocumentHash            string    `json:"document_hash" gorm:"type:char(64);not null"`
+	// AcknowledgedAt keeps its time zone so the hash can be recomputed from the stored value
+	AcknowledgedAt time.Time `json:"acknowledged_at" gorm:"type:timestamptz;not null"`
+	IPAddress      string    `json:"ip_address" gorm:"type:varchar(255);default:null"`
+	UserAgent      string    `json:"user_agent" gorm:"type:text;default:null"`
+	PreviousHash   string    `json:"previous_hash" gorm:"type:char(64);not null"`
+	Hash           string    `json:"hash" gorm:"type:char(64);not null;uniqueIndex"`
+
+	PolicyDocument        *PolicyDocument        `json:"policy_document" gorm:"foreignKey:PolicyDocumentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	PolicyDocumentVersion *PolicyDocumentVersion `json:"policy_document_version" gorm:"foreignKey:PolicyDocumentVersionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+}
+
+func (e *PolicyAcknowledgement) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (PolicyAcknowledgement) TableName() string {
+	return "policy_acknowledgements"
+}
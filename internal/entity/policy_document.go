@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PolicyDocumentStatusEnum string
+
+const (
+	POLICY_DOCUMENT_STATUS_ENUM_ACTIVE   PolicyDocumentStatusEnum = "ACTIVE"
+	POLICY_DOCUMENT_STATUS_ENUM_INACTIVE PolicyDocumentStatusEnum = "INACTIVE"
+)
+
+// PolicyDocument is a company policy, like the code of conduct, that employees have to read
+// and accept. Every upload of the document adds a version, employees acknowledge the current
+// one.
+type PolicyDocument struct {
+	gorm.Model           `json:"-"`
+	ID                   uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
+	Name                 string                   `json:"name" gorm:"type:varchar(255);not null"`
+	Description          string                   `json:"description" gorm:"type:text;default:null"`
+	Status               PolicyDocumentStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'ACTIVE'"`
+	CurrentVersionNumber int                      `json:"current_version_number" gorm:"type:int;not null;default:0"`
+
+	PolicyDocumentVersions []PolicyDocumentVersion `json:"policy_document_versions" gorm:"foreignKey:PolicyDocumentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *PolicyDocument) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *PolicyDocument) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (PolicyDocument) TableName() string {
+	return "policy_documents"
+}
+
+// PolicyDocumentVersion is one uploaded revision of a policy document. The content hash is the
+// sha256 of the file, it is what an acknowledgement of the version is bound to.
+type PolicyDocumentVersion struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	PolicyDocumentID uuid.UUID  `json:"policy_document_id" gorm:"type:char(36);not null;uniqueIndex:idx_policy_document_versions_number"`
+	VersionNumber    int        `json:"version_number" gorm:"type:int;not null;uniqueIndex:idx_policy_document_versions_number"`
+	Path             string     `json:"path" gorm:"type:varchar(255);not null"`
+	ContentHash      string     `json:"content_hash" gorm:"type:char(64);not null"`
+	ChangeNote       string     `json:"change_note" gorm:"type:text;default:null"`
+	CreatedBy        *uuid.UUID `json:"created_by" gorm:"type:char(36);default:null"`
+
+	PolicyDocument *PolicyDocument `json:"policy_document" gorm:"foreignKey:PolicyDocumentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *PolicyDocumentVersion) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *PolicyDocumentVersion) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (PolicyDocumentVersion) TableName() string {
+	return "policy_document_versions"
+}
@@ -17,11 +17,31 @@ type Question struct {
 	IsCompleted      string    `json:"is_completed" gorm:"type:varchar(255);not null;default:'NO'"`
 	Number           int       `json:"number" gorm:"type:int;not null"`
 	MaxStars         int       `json:"max_stars" gorm:"type:int;default:0"`
+	// Points is what a correct answer is worth when the survey template is a quiz
+	Points int `json:"points" gorm:"type:int;not null;default:1"`
+	// answer rules, a nil rule is not checked. AllowedFileTypes is a comma separated list of extensions
+	IsRequired       bool       `json:"is_required" gorm:"type:boolean;not null;default:false"`
+	MinLength        *int       `json:"min_length" gorm:"type:int;default:null"`
+	MaxLength        *int       `json:"max_length" gorm:"type:int;default:null"`
+	Pattern          *string    `json:"pattern" gorm:"type:text;default:null"`
+	AllowedFileTypes *string    `json:"allowed_file_types" gorm:"type:varchar(255);default:null"`
+	MaxFileSizeKB    *int       `json:"max_file_size_kb" gorm:"type:int;default:null"`
+	MinSelections    *int       `json:"min_selections" gorm:"type:int;default:null"`
+	MaxSelections    *int       `json:"max_selections" gorm:"type:int;default:null"`
+	MinValue         *float64   `json:"min_value" gorm:"type:decimal(18,4);default:null"`
+	MaxValue         *float64   `json:"max_value" gorm:"type:decimal(18,4);default:null"`
+	MinDate          *time.Time `json:"min_date" gorm:"type:date;default:null"`
+	MaxDate          *time.Time `json:"max_date" gorm:"type:date;default:null"`
+	// ConditionMatch combines the display conditions, a question without any is always shown
+	ConditionMatch QuestionConditionMatchEnum `json:"condition_match" gorm:"type:varchar(10);not null;default:'ALL'"`
 
 	SurveyTemplate  *SurveyTemplate  `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	AnswerType      *AnswerType      `json:"answer_type" gorm:"foreignKey:AnswerTypeID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	QuestionOptions []QuestionOption `json:"question_options" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	SurveyResponses []SurveyResponse `json:"survey_responses" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// MatrixRows are the rows of a matrix question, its options being the shared scale of the columns
+	MatrixRows        []QuestionMatrixRow `json:"matrix_rows" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	DisplayConditions []QuestionCondition `json:"display_conditions" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (q *Question) BeforeCreate(tx *gorm.DB) (err error) {
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionConditionOperatorEnum string
+
+const (
+	QUESTION_CONDITION_OPERATOR_ENUM_EQUALS                QuestionConditionOperatorEnum = "EQUALS"
+	QUESTION_CONDITION_OPERATOR_ENUM_NOT_EQUALS            QuestionConditionOperatorEnum = "NOT_EQUALS"
+	QUESTION_CONDITION_OPERATOR_ENUM_CONTAINS              QuestionConditionOperatorEnum = "CONTAINS"
+	QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN             QuestionConditionOperatorEnum = "LESS_THAN"
+	QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN_OR_EQUAL    QuestionConditionOperatorEnum = "LESS_THAN_OR_EQUAL"
+	QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN          QuestionConditionOperatorEnum = "GREATER_THAN"
+	QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL QuestionConditionOperatorEnum = "GREATER_THAN_OR_EQUAL"
+	QUESTION_CONDITION_OPERATOR_ENUM_ANSWERED              QuestionConditionOperatorEnum = "ANSWERED"
+	QUESTION_CONDITION_OPERATOR_ENUM_NOT_ANSWERED          QuestionConditionOperatorEnum = "NOT_ANSWERED"
+)
+
+// QuestionConditionMatchEnum is how the display conditions of a question are combined.
+type QuestionConditionMatchEnum string
+
+const (
+	QUESTION_CONDITION_MATCH_ENUM_ALL QuestionConditionMatchEnum = "ALL"
+	QUESTION_CONDITION_MATCH_ENUM_ANY QuestionConditionMatchEnum = "ANY"
+)
+
+// QuestionCondition shows its question only when the answer to the source question matches.
+type QuestionCondition struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID                     `json:"id" gorm:"type:char(36);primaryKey;"`
+	QuestionID       uuid.UUID                     `json:"question_id" gorm:"type:char(36);not null"`
+	SourceQuestionID uuid.UUID                     `json:"source_question_id" gorm:"type:char(36);not null"`
+	Operator         QuestionConditionOperatorEnum `json:"operator" gorm:"type:varchar(50);not null"`
+	Value            string                        `json:"value" gorm:"type:text;default:null"`
+
+	Question       *Question `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SourceQuestion *Question `json:"source_question" gorm:"foreignKey:SourceQuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (q *QuestionCondition) BeforeCreate(tx *gorm.DB) (err error) {
+	q.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.CreatedAt = time.Now().In(loc)
+	q.UpdatedAt = time.Now().In(loc)
+	return
+}
+
+func (q *QuestionCondition) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.UpdatedAt = time.Now().In(loc)
+	return
+}
+
+func (q *QuestionCondition) BeforeDelete(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.DeletedAt = gorm.DeletedAt{
+		Time:  time.Now().In(loc),
+		Valid: true,
+	}
+	return
+}
+
+func (QuestionCondition) TableName() string {
+	return "question_conditions"
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionMatrixRow struct {
+	gorm.Model `json:"-"`
+	ID         uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	QuestionID uuid.UUID `json:"question_id" gorm:"type:char(36);not null"`
+	RowText    string    `json:"row_text" gorm:"type:text;not null"`
+	Number     int       `json:"number" gorm:"type:int;not null"`
+
+	Question *Question `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (q *QuestionMatrixRow) BeforeCreate(tx *gorm.DB) (err error) {
+	q.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.CreatedAt = time.Now().In(loc)
+	q.UpdatedAt = time.Now().In(loc)
+	return
+}
+
+func (q *QuestionMatrixRow) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.UpdatedAt = time.Now().In(loc)
+	return
+}
+
+func (q *QuestionMatrixRow) BeforeDelete(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	q.DeletedAt = gorm.DeletedAt{
+		Time:  time.Now().In(loc),
+		Valid: true,
+	}
+	return
+}
+
+func (QuestionMatrixRow) TableName() string {
+	return "question_matrix_rows"
+}
@@ -12,6 +12,7 @@ type QuestionOption struct {
 	ID         uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
 	QuestionID uuid.UUID `json:"question_id" gorm:"type:char(36);not null"`
 	OptionText string    `json:"option_text" gorm:"type:text;not null"`
+	IsCorrect  bool      `json:"is_correct" gorm:"type:boolean;not null;default:false"`
 
 	Question *Question `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
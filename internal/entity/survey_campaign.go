@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyCampaignStatusEnum string
+
+const (
+	SURVEY_CAMPAIGN_STATUS_ENUM_ACTIVE   SurveyCampaignStatusEnum = "ACTIVE"
+	SURVEY_CAMPAIGN_STATUS_ENUM_INACTIVE SurveyCampaignStatusEnum = "INACTIVE"
+)
+
+// SurveyCampaign sends its survey template to the new hires matching its rules once per
+// offset, the offset days after their hiring date. Send days before the start date are left
+// out, so that a new campaign does not reach everyone hired before it. A survey is open for
+// ResponseDays calendar days and reminded ReminderDays after it was sent while unanswered.
+type SurveyCampaign struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
+	Name             string                   `json:"name" gorm:"type:varchar(255);not null"`
+	SurveyTemplateID uuid.UUID                `json:"survey_template_id" gorm:"type:char(36);not null"`
+	Status           SurveyCampaignStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'ACTIVE'"`
+	StartDate        time.Time                `json:"start_date" gorm:"type:date;not null"`
+	ResponseDays     int                      `json:"response_days" gorm:"type:int;not null"`
+	ReminderDays     *int                     `json:"reminder_days" gorm:"type:int;default:null"`
+	CreatedBy        *uuid.UUID               `json:"created_by" gorm:"type:char(36);default:null"`
+
+	SurveyTemplate        *SurveyTemplate        `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyCampaignOffsets []SurveyCampaignOffset `json:"survey_campaign_offsets" gorm:"foreignKey:SurveyCampaignID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyCampaignRules   []SurveyCampaignRule   `json:"survey_campaign_rules" gorm:"foreignKey:SurveyCampaignID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *SurveyCampaign) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyCampaign) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+// TemplateTaskRules gives the audience rules of the campaign the shape of template task rules,
+// which are evaluated the same way.
+func (e *SurveyCampaign) TemplateTaskRules() []TemplateTaskRule {
+	rules := make([]TemplateTaskRule, 0, len(e.SurveyCampaignRules))
+	for _, rule := range e.SurveyCampaignRules {
+		rules = append(rules, TemplateTaskRule{
+			ID:          rule.ID,
+			GroupNumber: rule.GroupNumber,
+			Criterion:   rule.Criterion,
+			Operator:    rule.Operator,
+			Value:       rule.Value,
+		})
+	}
+	return rules
+}
+
+func (SurveyCampaign) TableName() string {
+	return "survey_campaigns"
+}
+
+// SurveyCampaignOffset is a day, counted from the hiring date, on which the survey is sent.
+type SurveyCampaignOffset struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyCampaignID uuid.UUID `json:"survey_campaign_id" gorm:"type:char(36);not null"`
+	OffsetDays       int       `json:"offset_days" gorm:"type:int;not null"`
+}
+
+func (e *SurveyCampaignOffset) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyCampaignOffset) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyCampaignOffset) TableName() string {
+	return "survey_campaign_offsets"
+}
+
+// SurveyCampaignRule narrows down the hires a campaign reaches, with the criteria and
+// operators of template task rules. Rules of a group must all match and any group has to
+// match, a campaign without rules reaches every hire.
+type SurveyCampaignRule struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID                     `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyCampaignID uuid.UUID                     `json:"survey_campaign_id" gorm:"type:char(36);not null"`
+	GroupNumber      int                           `json:"group_number" gorm:"type:int;not null;default:1"`
+	Criterion        TemplateTaskRuleCriterionEnum `json:"criterion" gorm:"type:varchar(255);not null"`
+	Operator         TemplateTaskRuleOperatorEnum  `json:"operator" gorm:"type:varchar(255);not null"`
+	Value            string                        `json:"value" gorm:"type:text;not null"`
+}
+
+func (e *SurveyCampaignRule) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyCampaignRule) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyCampaignRule) TableName() string {
+	return "survey_campaign_rules"
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyCampaignDispatchStatusEnum string
+
+const (
+	SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SENT      SurveyCampaignDispatchStatusEnum = "SENT"
+	SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SKIPPED   SurveyCampaignDispatchStatusEnum = "SKIPPED"
+	SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_COMPLETED SurveyCampaignDispatchStatusEnum = "COMPLETED"
+	SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_EXPIRED   SurveyCampaignDispatchStatusEnum = "EXPIRED"
+)
+
+// SurveyCampaignDispatch records what a campaign did for an offset of a hiring, so that the
+// scheduler handles every one once. The unique index on the campaign, hiring and offset keeps a
+// scheduled run and one asked for through the API from both sending it. Hires outside the audience are recorded as SKIPPED with
+// the reason in Message. A sent survey ends COMPLETED once answered or EXPIRED when its due
+// date passed first.
+type SurveyCampaignDispatch struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID                        `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyCampaignID uuid.UUID                        `json:"survey_campaign_id" gorm:"type:char(36);not null;uniqueIndex:idx_survey_campaign_dispatch"`
+	EmployeeHiringID uuid.UUID                        `json:"employee_hiring_id" gorm:"type:char(36);not null;uniqueIndex:idx_survey_campaign_dispatch"`
+	OffsetDays       int                              `json:"offset_days" gorm:"type:int;not null;uniqueIndex:idx_survey_campaign_dispatch"`
+	EmployeeID       uuid.UUID                        `json:"employee_id" gorm:"type:char(36);not null"`
+	EmployeeTaskID   *uuid.UUID                       `json:"employee_task_id" gorm:"type:char(36);default:null"`
+	ScheduledOn      time.Time                        `json:"scheduled_on" gorm:"type:date;not null"`
+	DueDate          *time.Time                       `json:"due_date" gorm:"type:date;default:null"`
+	Status           SurveyCampaignDispatchStatusEnum `json:"status" gorm:"type:varchar(255);not null"`
+	Message          string                           `json:"message" gorm:"type:text;default:null"`
+	SentAt           *time.Time                       `json:"sent_at" gorm:"type:timestamp;default:null"`
+	RemindedAt       *time.Time                       `json:"reminded_at" gorm:"type:timestamp;default:null"`
+	ClosedAt         *time.Time                       `json:"closed_at" gorm:"type:timestamp;default:null"`
+
+	SurveyCampaign *SurveyCampaign `json:"survey_campaign" gorm:"foreignKey:SurveyCampaignID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmployeeTask   *EmployeeTask   `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (e *SurveyCampaignDispatch) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyCampaignDispatch) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyCampaignDispatch) TableName() string {
+	return "survey_campaign_dispatches"
+}
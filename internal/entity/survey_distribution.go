@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyDistributionStatusEnum string
+
+// The status of a distribution follows from its dates, it is not stored.
+const (
+	SURVEY_DISTRIBUTION_STATUS_ENUM_SCHEDULED SurveyDistributionStatusEnum = "SCHEDULED"
+	SURVEY_DISTRIBUTION_STATUS_ENUM_OPEN      SurveyDistributionStatusEnum = "OPEN"
+	SURVEY_DISTRIBUTION_STATUS_ENUM_CLOSED    SurveyDistributionStatusEnum = "CLOSED"
+)
+
+// SurveyDistribution sends a published survey template to a list of employees and to the
+// hires matching its rules, without an employee task. It takes answers from the OpensOn day
+// through the ClosesOn day, or until it is closed by hand at ClosedAt.
+type SurveyDistribution struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	Name             string     `json:"name" gorm:"type:varchar(255);not null"`
+	SurveyTemplateID uuid.UUID  `json:"survey_template_id" gorm:"type:char(36);not null"`
+	OpensOn          time.Time  `json:"opens_on" gorm:"type:date;not null"`
+	ClosesOn         *time.Time `json:"closes_on" gorm:"type:date;default:null"`
+	ClosedAt         *time.Time `json:"closed_at" gorm:"type:timestamp;default:null"`
+	CreatedBy        *uuid.UUID `json:"created_by" gorm:"type:char(36);default:null"`
+
+	SurveyTemplate               *SurveyTemplate               `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyDistributionRules      []SurveyDistributionRule      `json:"survey_distribution_rules" gorm:"foreignKey:SurveyDistributionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyDistributionRecipients []SurveyDistributionRecipient `json:"survey_distribution_recipients" gorm:"foreignKey:SurveyDistributionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *SurveyDistribution) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyDistribution) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+// Status tells whether the distribution takes answers at now.
+func (e *SurveyDistribution) Status(now time.Time) SurveyDistributionStatusEnum {
+	today := now.Format("2006-01-02")
+	if e.ClosedAt != nil || (e.ClosesOn != nil && today > e.ClosesOn.Format("2006-01-02")) {
+		return SURVEY_DISTRIBUTION_STATUS_ENUM_CLOSED
+	}
+	if today < e.OpensOn.Format("2006-01-02") {
+		return SURVEY_DISTRIBUTION_STATUS_ENUM_SCHEDULED
+	}
+	return SURVEY_DISTRIBUTION_STATUS_ENUM_OPEN
+}
+
+// TemplateTaskRules gives the audience rules of the distribution the shape of template task
+// rules, which are evaluated the same way.
+func (e *SurveyDistribution) TemplateTaskRules() []TemplateTaskRule {
+	rules := make([]TemplateTaskRule, 0, len(e.SurveyDistributionRules))
+	for _, rule := range e.SurveyDistributionRules {
+		rules = append(rules, TemplateTaskRule{
+			ID:          rule.ID,
+			GroupNumber: rule.GroupNumber,
+			Criterion:   rule.Criterion,
+			Operator:    rule.Operator,
+			Value:       rule.Value,
+		})
+	}
+	return rules
+}
+
+func (SurveyDistribution) TableName() string {
+	return "survey_distributions"
+}
+
+// SurveyDistributionRule picks hires for a distribution, with the criteria and operators of
+// template task rules. Rules of a group must all match and any group has to match. A
+// distribution without rules only reaches the employees it lists.
+type SurveyDistributionRule struct {
+	gorm.Model           `json:"-"`
+	ID                   uuid.UUID                     `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyDistributionID uuid.UUID                     `json:"survey_distribution_id" gorm:"type:char(36);not null"`
+	GroupNumber          int                           `json:"group_number" gorm:"type:int;not null;default:1"`
+	Criterion            TemplateTaskRuleCriterionEnum `json:"criterion" gorm:"type:varchar(255);not null"`
+	Operator             TemplateTaskRuleOperatorEnum  `json:"operator" gorm:"type:varchar(255);not null"`
+	Value                string                        `json:"value" gorm:"type:text;not null"`
+}
+
+func (e *SurveyDistributionRule) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyDistributionRule) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyDistributionRule) TableName() string {
+	return "survey_distribution_rules"
+}
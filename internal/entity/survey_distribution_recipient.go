@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyDistributionRecipientStatusEnum string
+
+const (
+	SURVEY_DISTRIBUTION_RECIPIENT_STATUS_ENUM_PENDING   SurveyDistributionRecipientStatusEnum = "PENDING"
+	SURVEY_DISTRIBUTION_RECIPIENT_STATUS_ENUM_SUBMITTED SurveyDistributionRecipientStatusEnum = "SUBMITTED"
+)
+
+// SurveyDistributionRecipient is an employee a distribution was sent to. The answers of the
+// employee are saved against it, it is SUBMITTED once every required question is answered.
+type SurveyDistributionRecipient struct {
+	gorm.Model           `json:"-"`
+	ID                   uuid.UUID                             `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyDistributionID uuid.UUID                             `json:"survey_distribution_id" gorm:"type:char(36);not null;uniqueIndex:idx_survey_distribution_recipient"`
+	EmployeeID           uuid.UUID                             `json:"employee_id" gorm:"type:char(36);not null;uniqueIndex:idx_survey_distribution_recipient"`
+	Status               SurveyDistributionRecipientStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	SubmittedAt          *time.Time                            `json:"submitted_at" gorm:"type:timestamp;default:null"`
+
+	SurveyDistribution *SurveyDistribution `json:"survey_distribution" gorm:"foreignKey:SurveyDistributionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyResponses    []SurveyResponse    `json:"survey_responses" gorm:"foreignKey:SurveyDistributionRecipientID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *SurveyDistributionRecipient) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyDistributionRecipient) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyDistributionRecipient) TableName() string {
+	return "survey_distribution_recipients"
+}
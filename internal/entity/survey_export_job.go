@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SurveyExportFormatEnum string
+
+const (
+	SURVEY_EXPORT_FORMAT_ENUM_XLSX  SurveyExportFormatEnum = "XLSX"
+	SURVEY_EXPORT_FORMAT_ENUM_CSV   SurveyExportFormatEnum = "CSV"
+	SURVEY_EXPORT_FORMAT_ENUM_JSONL SurveyExportFormatEnum = "JSONL"
+)
+
+type SurveyExportJobStatusEnum string
+
+const (
+	SURVEY_EXPORT_JOB_STATUS_ENUM_PENDING   SurveyExportJobStatusEnum = "PENDING"
+	SURVEY_EXPORT_JOB_STATUS_ENUM_RUNNING   SurveyExportJobStatusEnum = "RUNNING"
+	SURVEY_EXPORT_JOB_STATUS_ENUM_COMPLETED SurveyExportJobStatusEnum = "COMPLETED"
+	SURVEY_EXPORT_JOB_STATUS_ENUM_FAILED    SurveyExportJobStatusEnum = "FAILED"
+)
+
+// SurveyExportJob writes the survey responses matching its filters to a file in the
+// background. Total is the number of employee tasks to go through, Rows the number written,
+// which is lower when the organization filter leaves some of them out.
+type SurveyExportJob struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID                 `json:"id" gorm:"type:char(36);primaryKey;"`
+	Format           SurveyExportFormatEnum    `json:"format" gorm:"type:varchar(255);not null"`
+	SurveyTemplateID *uuid.UUID                `json:"survey_template_id" gorm:"type:char(36);default:null"`
+	AllVersions      bool                      `json:"all_versions" gorm:"type:boolean;not null;default:false"`
+	OrganizationID   *uuid.UUID                `json:"organization_id" gorm:"type:char(36);default:null"`
+	SubmittedFrom    *time.Time                `json:"submitted_from" gorm:"type:date;default:null"`
+	SubmittedTo      *time.Time                `json:"submitted_to" gorm:"type:date;default:null"`
+	Status           SurveyExportJobStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'PENDING'"`
+	Total            int                       `json:"total" gorm:"type:int;not null;default:0"`
+	Processed        int                       `json:"processed" gorm:"type:int;not null;default:0"`
+	Rows             int                       `json:"rows" gorm:"type:int;not null;default:0"`
+	Path             string                    `json:"path" gorm:"type:varchar(255);default:null"`
+	Message          string                    `json:"message" gorm:"type:text;default:null"`
+	RequestedBy      *uuid.UUID                `json:"requested_by" gorm:"type:char(36);default:null"`
+	StartedAt        *time.Time                `json:"started_at" gorm:"default:null"`
+	FinishedAt       *time.Time                `json:"finished_at" gorm:"default:null"`
+}
+
+func (s *SurveyExportJob) BeforeCreate(tx *gorm.DB) (err error) {
+	s.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	s.CreatedAt = time.Now().In(loc)
+	s.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (s *SurveyExportJob) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	s.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyExportJob) TableName() string {
+	return "survey_export_jobs"
+}
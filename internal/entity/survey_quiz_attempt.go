@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SurveyQuizAttempt is one graded submission of a quiz survey template for an employee task.
+// The answers are copied into the attempt because the responses of the task are overwritten by
+// the next attempt.
+type SurveyQuizAttempt struct {
+	gorm.Model       `json:"-"`
+	ID               uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyTemplateID uuid.UUID  `json:"survey_template_id" gorm:"type:char(36);not null"`
+	EmployeeTaskID   uuid.UUID  `json:"employee_task_id" gorm:"type:char(36);not null;index"`
+	EmployeeID       *uuid.UUID `json:"employee_id" gorm:"type:char(36);default:null"`
+	AttemptNumber    int        `json:"attempt_number" gorm:"type:int;not null"`
+	Score            int        `json:"score" gorm:"type:int;not null;default:0"`
+	MaxScore         int        `json:"max_score" gorm:"type:int;not null;default:0"`
+	Percentage       int        `json:"percentage" gorm:"type:int;not null;default:0"`
+	PassingScore     *int       `json:"passing_score" gorm:"type:int;default:null"`
+	Passed           bool       `json:"passed" gorm:"type:boolean;not null;default:false"`
+	SubmittedAt      time.Time  `json:"submitted_at" gorm:"type:timestamp;not null"`
+
+	SurveyTemplate *SurveyTemplate           `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmployeeTask   *EmployeeTask             `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Answers        []SurveyQuizAttemptAnswer `json:"answers" gorm:"foreignKey:SurveyQuizAttemptID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *SurveyQuizAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyQuizAttempt) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyQuizAttempt) TableName() string {
+	return "survey_quiz_attempts"
+}
+
+// SurveyQuizAttemptAnswer is the graded answer of one scored question in an attempt.
+type SurveyQuizAttemptAnswer struct {
+	gorm.Model          `json:"-"`
+	ID                  uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyQuizAttemptID uuid.UUID `json:"survey_quiz_attempt_id" gorm:"type:char(36);not null"`
+	QuestionID          uuid.UUID `json:"question_id" gorm:"type:char(36);not null"`
+	Answer              string    `json:"answer" gorm:"type:text;default:null"`
+	IsCorrect           bool      `json:"is_correct" gorm:"type:boolean;not null;default:false"`
+	Points              int       `json:"points" gorm:"type:int;not null;default:0"`
+	MaxPoints           int       `json:"max_points" gorm:"type:int;not null;default:0"`
+
+	Question *Question `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (e *SurveyQuizAttemptAnswer) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *SurveyQuizAttemptAnswer) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (SurveyQuizAttemptAnswer) TableName() string {
+	return "survey_quiz_attempt_answers"
+}
@@ -7,18 +7,34 @@ import (
 	"gorm.io/gorm"
 )
 
+// SurveyResponse is an answer given either through an employee task or as a recipient of a
+// survey distribution, exactly one of EmployeeTaskID and SurveyDistributionRecipientID is set.
 type SurveyResponse struct {
-	gorm.Model       `json:"-"`
-	ID               uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
-	SurveyTemplateID uuid.UUID `json:"survey_template_id" gorm:"type:char(36);not null"`
-	EmployeeTaskID   uuid.UUID `json:"employee_task_id" gorm:"type:char(36);not null"`
-	QuestionID       uuid.UUID `json:"question_id" gorm:"type:char(36);not null"`
-	Answer           string    `json:"answer" gorm:"type:text;default:null"`
-	AnswerFile       string    `json:"answer_file" gorm:"type:text;default:null"`
-
-	SurveyTemplate *SurveyTemplate `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	EmployeeTask   *EmployeeTask   `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Question       *Question       `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	gorm.Model                    `json:"-"`
+	ID                            uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	SurveyTemplateID              uuid.UUID  `json:"survey_template_id" gorm:"type:char(36);not null"`
+	EmployeeTaskID                *uuid.UUID `json:"employee_task_id" gorm:"type:char(36);default:null"`
+	SurveyDistributionRecipientID *uuid.UUID `json:"survey_distribution_recipient_id" gorm:"type:char(36);default:null"`
+	QuestionID                    uuid.UUID  `json:"question_id" gorm:"type:char(36);not null"`
+	Answer                        string     `json:"answer" gorm:"type:text;default:null"`
+	AnswerFile                    string     `json:"answer_file" gorm:"type:text;default:null"`
+
+	SurveyTemplate              *SurveyTemplate              `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmployeeTask                *EmployeeTask                `json:"employee_task" gorm:"foreignKey:EmployeeTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyDistributionRecipient *SurveyDistributionRecipient `json:"survey_distribution_recipient" gorm:"foreignKey:SurveyDistributionRecipientID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Question                    *Question                    `json:"question" gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// RespondentID identifies who gave the answer, the employee task or the distribution
+// recipient it was saved against.
+func (s *SurveyResponse) RespondentID() uuid.UUID {
+	if s.EmployeeTaskID != nil {
+		return *s.EmployeeTaskID
+	}
+	if s.SurveyDistributionRecipientID != nil {
+		return *s.SurveyDistributionRecipientID
+	}
+	return uuid.Nil
 }
 
 func (s *SurveyResponse) BeforeCreate(tx *gorm.DB) (err error) {
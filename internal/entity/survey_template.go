@@ -14,16 +14,61 @@ const (
 	SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED SurveyTemplateStatusEnum = "SUBMITTED"
 )
 
+// SurveyTemplateAnonymityEnum decides how much of the respondents the reports show. The
+// responses stay linked to their employee task so completion is still tracked, but an
+// ANONYMOUS or CONFIDENTIAL survey is exported without who answered, and a CONFIDENTIAL one
+// is only reported on for groups of at least MinGroupSize respondents.
+type SurveyTemplateAnonymityEnum string
+
+const (
+	SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE         SurveyTemplateAnonymityEnum = "NONE"
+	SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS    SurveyTemplateAnonymityEnum = "ANONYMOUS"
+	SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL SurveyTemplateAnonymityEnum = "CONFIDENTIAL"
+)
+
+// SURVEY_TEMPLATE_DEFAULT_MIN_GROUP_SIZE is used by confidential surveys without a group size
+const SURVEY_TEMPLATE_DEFAULT_MIN_GROUP_SIZE = 5
+
 type SurveyTemplate struct {
 	gorm.Model   `json:"-"`
 	ID           uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
 	SurveyNumber string                   `json:"survey_number" gorm:"type:varchar(255);not null"`
 	Title        string                   `json:"title" gorm:"type:varchar(255);not null"`
 	Status       SurveyTemplateStatusEnum `json:"status" gorm:"type:varchar(255);not null;default:'DRAFT'"`
+	// VersionNumber counts the versions of a survey, which share its SurveyNumber. A published
+	// version does not change anymore, editing it makes the next version as a draft.
+	VersionNumber int        `json:"version_number" gorm:"type:int;not null;default:1"`
+	PublishedAt   *time.Time `json:"published_at" gorm:"type:timestamp;default:null"`
+	// IsQuiz grades every submitted response against the correct options of the questions.
+	// PassingScore is the percentage of points needed to pass, MaxAttempts limits the graded
+	// submissions per employee task and is unlimited when nil.
+	IsQuiz       bool                        `json:"is_quiz" gorm:"type:boolean;not null;default:false"`
+	PassingScore *int                        `json:"passing_score" gorm:"type:int;default:null"`
+	MaxAttempts  *int                        `json:"max_attempts" gorm:"type:int;default:null"`
+	Anonymity    SurveyTemplateAnonymityEnum `json:"anonymity" gorm:"type:varchar(255);not null;default:'NONE'"`
+	MinGroupSize *int                        `json:"min_group_size" gorm:"type:int;default:null"`
 
-	Questions       []Question       `json:"questions" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	SurveyResponses []SurveyResponse `json:"survey_responses" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	EmployeeTasks   []EmployeeTask   `json:"employee_tasks" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Questions       []Question          `json:"questions" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyResponses []SurveyResponse    `json:"survey_responses" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmployeeTasks   []EmployeeTask      `json:"employee_tasks" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	QuizAttempts    []SurveyQuizAttempt `json:"quiz_attempts" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// IsAnonymous tells whether the respondents are left out of the exports.
+func (s *SurveyTemplate) IsAnonymous() bool {
+	return s.Anonymity == SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS || s.Anonymity == SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL
+}
+
+// ReportingGroupSize is the number of respondents a group needs before its answers are
+// reported, 1 unless the survey is confidential.
+func (s *SurveyTemplate) ReportingGroupSize() int {
+	if s.Anonymity != SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL {
+		return 1
+	}
+	if s.MinGroupSize == nil {
+		return SURVEY_TEMPLATE_DEFAULT_MIN_GROUP_SIZE
+	}
+	return *s.MinGroupSize
 }
 
 func (s *SurveyTemplate) BeforeCreate(tx *gorm.DB) (err error) {
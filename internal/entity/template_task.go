@@ -34,11 +34,15 @@ type TemplateTask struct {
 	Description      string                   `json:"description" gorm:"type:text;default:null"`
 	Source           string                   `json:"source" gorm:"type:text;default:null"`
 	OrganizationType string                   `json:"organization_type" gorm:"type:varchar(255);default:null"`
+	Kind             TaskKindEnum             `json:"kind" gorm:"type:varchar(255);default:null"`
+	PassingScore     *int                     `json:"passing_score" gorm:"type:int;default:null"`
 
-	TemplateTaskAttachments []TemplateTaskAttachment `json:"template_task_attachments" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	TemplateTaskChecklists  []TemplateTaskChecklist  `json:"template_task_checklists" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Events                  []Event                  `json:"events" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	SurveyTemplate          *SurveyTemplate          `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TemplateTaskAttachments   []TemplateTaskAttachment   `json:"template_task_attachments" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TemplateTaskChecklists    []TemplateTaskChecklist    `json:"template_task_checklists" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TemplateTaskRules         []TemplateTaskRule         `json:"template_task_rules" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TemplateTaskApprovalSteps []TemplateTaskApprovalStep `json:"template_task_approval_steps" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Events                    []Event                    `json:"events" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SurveyTemplate            *SurveyTemplate            `json:"survey_template" gorm:"foreignKey:SurveyTemplateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (t *TemplateTask) BeforeCreate(tx *gorm.DB) (err error) {
@@ -55,6 +59,10 @@ func (t *TemplateTask) BeforeUpdate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+func (t *TemplateTask) TaskKind() TaskKindEnum {
+	return ResolveTaskKind(t.Kind, t.SurveyTemplateID)
+}
+
 func (TemplateTask) TableName() string {
 	return "template_tasks"
 }
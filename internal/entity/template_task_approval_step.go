@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ApprovalApproverTypeEnum string
+
+const (
+	APPROVAL_APPROVER_TYPE_ENUM_DIRECT_MANAGER  ApprovalApproverTypeEnum = "DIRECT_MANAGER"
+	APPROVAL_APPROVER_TYPE_ENUM_HR              ApprovalApproverTypeEnum = "HR"
+	APPROVAL_APPROVER_TYPE_ENUM_DEPARTMENT_HEAD ApprovalApproverTypeEnum = "DEPARTMENT_HEAD"
+	APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE        ApprovalApproverTypeEnum = "EMPLOYEE"
+)
+
+// TemplateTaskApprovalStep is one step of the sequential approval chain of a template task.
+// Steps are approved in StepOrder. Except for EMPLOYEE steps, the approver is looked up from
+// the organization structure of the employee when the task is created.
+type TemplateTaskApprovalStep struct {
+	gorm.Model         `json:"-"`
+	ID                 uuid.UUID                `json:"id" gorm:"type:char(36);primaryKey;"`
+	TemplateTaskID     uuid.UUID                `json:"template_task_id" gorm:"type:char(36);not null"`
+	StepOrder          int                      `json:"step_order" gorm:"type:int;not null"`
+	ApproverType       ApprovalApproverTypeEnum `json:"approver_type" gorm:"type:varchar(255);not null"`
+	ApproverEmployeeID *uuid.UUID               `json:"approver_employee_id" gorm:"type:char(36);default:null"`
+
+	TemplateTask *TemplateTask `json:"template_task" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (t *TemplateTaskApprovalStep) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	t.CreatedAt = time.Now().In(loc)
+	t.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (t *TemplateTaskApprovalStep) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	t.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (TemplateTaskApprovalStep) TableName() string {
+	return "template_task_approval_steps"
+}
@@ -9,9 +9,13 @@ import (
 
 type TemplateTaskChecklist struct {
 	gorm.Model     `json:"-"`
-	ID             uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
-	TemplateTaskID uuid.UUID `json:"template_task_id" gorm:"type:char(36);not null"`
-	Name           string    `json:"name" gorm:"type:varchar(255);not null"`
+	ID             uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	TemplateTaskID uuid.UUID  `json:"template_task_id" gorm:"type:char(36);not null"`
+	Name           string     `json:"name" gorm:"type:varchar(255);not null"`
+	SortOrder      int        `json:"sort_order" gorm:"type:int;not null;default:0"`
+	AssigneeID     *uuid.UUID `json:"assignee_id" gorm:"type:char(36);default:null"`
+	DueOffsetDays  *int       `json:"due_offset_days" gorm:"type:int;default:null"`
+	IsRequired     string     `json:"is_required" gorm:"type:varchar(255);not null;default:'NO'"`
 
 	TemplateTask *TemplateTask `json:"template_task" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TemplateTaskRuleCriterionEnum string
+
+const (
+	TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL              TemplateTaskRuleCriterionEnum = "JOB_LEVEL"
+	TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB                    TemplateTaskRuleCriterionEnum = "JOB"
+	TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_LOCATION  TemplateTaskRuleCriterionEnum = "ORGANIZATION_LOCATION"
+	TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE TemplateTaskRuleCriterionEnum = "ORGANIZATION_STRUCTURE"
+	TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE        TemplateTaskRuleCriterionEnum = "EMPLOYMENT_TYPE"
+)
+
+type TemplateTaskRuleOperatorEnum string
+
+const (
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS                TemplateTaskRuleOperatorEnum = "EQUALS"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_EQUALS            TemplateTaskRuleOperatorEnum = "NOT_EQUALS"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN                    TemplateTaskRuleOperatorEnum = "IN"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN                TemplateTaskRuleOperatorEnum = "NOT_IN"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL TemplateTaskRuleOperatorEnum = "GREATER_THAN_OR_EQUAL"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL    TemplateTaskRuleOperatorEnum = "LESS_THAN_OR_EQUAL"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE            TemplateTaskRuleOperatorEnum = "IN_SUBTREE"
+	TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE        TemplateTaskRuleOperatorEnum = "NOT_IN_SUBTREE"
+)
+
+// TemplateTaskRule narrows down which hires receive a template task. Rules that
+// share a GroupNumber must all match (AND); the task is assigned when any
+// group matches (OR). A template task without rules applies to every hire of
+// its organization type.
+type TemplateTaskRule struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID                     `json:"id" gorm:"type:char(36);primaryKey;"`
+	TemplateTaskID uuid.UUID                     `json:"template_task_id" gorm:"type:char(36);not null"`
+	GroupNumber    int                           `json:"group_number" gorm:"type:int;not null;default:1"`
+	Criterion      TemplateTaskRuleCriterionEnum `json:"criterion" gorm:"type:varchar(255);not null"`
+	Operator       TemplateTaskRuleOperatorEnum  `json:"operator" gorm:"type:varchar(255);not null"`
+	Value          string                        `json:"value" gorm:"type:text;not null"`
+
+	TemplateTask *TemplateTask `json:"template_task" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (t *TemplateTaskRule) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	t.CreatedAt = time.Now().In(loc)
+	t.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (t *TemplateTaskRule) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	t.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (TemplateTaskRule) TableName() string {
+	return "template_task_rules"
+}
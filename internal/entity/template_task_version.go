@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TemplateTaskVersionPropagationEnum string
+
+const (
+	TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY    TemplateTaskVersionPropagationEnum = "NEW_HIRES_ONLY"
+	TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_UPDATE_OPEN_TASKS TemplateTaskVersionPropagationEnum = "UPDATE_OPEN_TASKS"
+)
+
+type TemplateTaskSnapshotRule struct {
+	GroupNumber int    `json:"group_number"`
+	Criterion   string `json:"criterion"`
+	Operator    string `json:"operator"`
+	Value       string `json:"value"`
+}
+
+type TemplateTaskSnapshotApprovalStep struct {
+	StepOrder          int        `json:"step_order"`
+	ApproverType       string     `json:"approver_type"`
+	ApproverEmployeeID *uuid.UUID `json:"approver_employee_id"`
+}
+
+// TemplateTaskSnapshotChecklistSetting holds the settings of a checklist item that differ from
+// the defaults, so snapshots of plain checklists keep encoding as before.
+type TemplateTaskSnapshotChecklistSetting struct {
+	Name          string     `json:"name"`
+	SortOrder     int        `json:"sort_order"`
+	AssigneeID    *uuid.UUID `json:"assignee_id"`
+	DueOffsetDays *int       `json:"due_offset_days"`
+	IsRequired    string     `json:"is_required"`
+}
+
+// TemplateTaskSnapshot is the content of a template task at the time a version was made.
+type TemplateTaskSnapshot struct {
+	Name              string                                 `json:"name"`
+	Description       string                                 `json:"description"`
+	Priority          string                                 `json:"priority"`
+	DueDuration       *int                                   `json:"due_duration"`
+	Status            string                                 `json:"status"`
+	CoverPath         *string                                `json:"cover_path"`
+	SurveyTemplateID  *uuid.UUID                             `json:"survey_template_id"`
+	OrganizationType  string                                 `json:"organization_type"`
+	Checklists        []string                               `json:"checklists"`
+	Attachments       []string                               `json:"attachments"`
+	Rules             []TemplateTaskSnapshotRule             `json:"rules"`
+	ApprovalSteps     []TemplateTaskSnapshotApprovalStep     `json:"approval_steps,omitempty"`
+	ChecklistSettings []TemplateTaskSnapshotChecklistSetting `json:"checklist_settings,omitempty"`
+	Kind              string                                 `json:"kind,omitempty"`
+	PassingScore      *int                                   `json:"passing_score,omitempty"`
+}
+
+// TemplateTaskVersion is an immutable copy of a template task. Employee tasks point at
+// the version they were generated from.
+type TemplateTaskVersion struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID                          `json:"id" gorm:"type:char(36);primaryKey;"`
+	TemplateTaskID uuid.UUID                          `json:"template_task_id" gorm:"type:char(36);not null;uniqueIndex:idx_template_task_version_number"`
+	VersionNumber  int                                `json:"version_number" gorm:"type:int;not null;uniqueIndex:idx_template_task_version_number"`
+	Snapshot       string                             `json:"snapshot" gorm:"type:text;not null"`
+	Propagation    TemplateTaskVersionPropagationEnum `json:"propagation" gorm:"type:varchar(255);not null;default:'NEW_HIRES_ONLY'"`
+	ChangeNote     string                             `json:"change_note" gorm:"type:text;default:null"`
+
+	TemplateTask *TemplateTask `json:"template_task" gorm:"foreignKey:TemplateTaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (t *TemplateTaskVersion) BeforeCreate(tx *gorm.DB) (err error) {
+	t.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	t.CreatedAt = time.Now().In(loc)
+	t.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (t *TemplateTaskVersion) BeforeUpdate(tx *gorm.DB) (err error) {
+	return errors.New("template task versions are immutable")
+}
+
+func (TemplateTaskVersion) TableName() string {
+	return "template_task_versions"
+}
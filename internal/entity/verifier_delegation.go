@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerifierDelegation hands the verification of every task of the delegator to the delegate
+// from the start date until the end date, both inclusive, unless it is revoked earlier.
+type VerifierDelegation struct {
+	gorm.Model  `json:"-"`
+	ID          uuid.UUID  `json:"id" gorm:"type:char(36);primaryKey;"`
+	DelegatorID uuid.UUID  `json:"delegator_id" gorm:"type:char(36);not null"`
+	DelegateID  uuid.UUID  `json:"delegate_id" gorm:"type:char(36);not null"`
+	StartDate   time.Time  `json:"start_date" gorm:"type:date;not null"`
+	EndDate     time.Time  `json:"end_date" gorm:"type:date;not null"`
+	Reason      string     `json:"reason" gorm:"type:text;default:null"`
+	CreatedBy   *uuid.UUID `json:"created_by" gorm:"type:char(36);default:null"`
+	RevokedAt   *time.Time `json:"revoked_at" gorm:"type:timestamp;default:null"`
+	RevokedBy   *uuid.UUID `json:"revoked_by" gorm:"type:char(36);default:null"`
+}
+
+func (e *VerifierDelegation) BeforeCreate(tx *gorm.DB) (err error) {
+	e.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.CreatedAt = time.Now().In(loc)
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (e *VerifierDelegation) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	e.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (VerifierDelegation) TableName() string {
+	return "verifier_delegations"
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkWeek configures which weekdays an organization works. Organizations
+// without a row fall back to Monday through Friday.
+type WorkWeek struct {
+	gorm.Model     `json:"-"`
+	ID             uuid.UUID `json:"id" gorm:"type:char(36);primaryKey;"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:char(36);not null;uniqueIndex"`
+	Monday         string    `json:"monday" gorm:"type:varchar(255);not null;default:'YES'"`
+	Tuesday        string    `json:"tuesday" gorm:"type:varchar(255);not null;default:'YES'"`
+	Wednesday      string    `json:"wednesday" gorm:"type:varchar(255);not null;default:'YES'"`
+	Thursday       string    `json:"thursday" gorm:"type:varchar(255);not null;default:'YES'"`
+	Friday         string    `json:"friday" gorm:"type:varchar(255);not null;default:'YES'"`
+	Saturday       string    `json:"saturday" gorm:"type:varchar(255);not null;default:'NO'"`
+	Sunday         string    `json:"sunday" gorm:"type:varchar(255);not null;default:'NO'"`
+}
+
+func (w *WorkWeek) BeforeCreate(tx *gorm.DB) (err error) {
+	w.ID = uuid.New()
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	w.CreatedAt = time.Now().In(loc)
+	w.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (w *WorkWeek) BeforeUpdate(tx *gorm.DB) (err error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	w.UpdatedAt = time.Now().In(loc)
+	return nil
+}
+
+func (WorkWeek) TableName() string {
+	return "work_weeks"
+}
+
+// IsWorkingWeekday reports whether the given weekday is configured as a working day.
+func (w *WorkWeek) IsWorkingWeekday(day time.Weekday) bool {
+	switch day {
+	case time.Monday:
+		return w.Monday == "YES"
+	case time.Tuesday:
+		return w.Tuesday == "YES"
+	case time.Wednesday:
+		return w.Wednesday == "YES"
+	case time.Thursday:
+		return w.Thursday == "YES"
+	case time.Friday:
+		return w.Friday == "YES"
+	case time.Saturday:
+		return w.Saturday == "YES"
+	case time.Sunday:
+		return w.Sunday == "YES"
+	}
+	return false
+}
@@ -14,6 +14,7 @@ type IUserHelper interface {
 	GetOrganizationID(user map[string]interface{}) (uuid.UUID, error)
 	GetUserId(user map[string]interface{}) (uuid.UUID, error)
 	GetUserName(user map[string]interface{}) (string, error)
+	HasAnyRole(user map[string]interface{}, roles []string) bool
 }
 
 type UserHelper struct {
@@ -232,3 +233,34 @@ func (h *UserHelper) GetUserName(user map[string]interface{}) (string, error) {
 	h.Log.Infof("User Name: %s", userName)
 	return userName, nil
 }
+
+// HasAnyRole reports whether one of the roles of the user has one of the given names.
+func (h *UserHelper) HasAnyRole(user map[string]interface{}, roles []string) bool {
+	userData, ok := user["user"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	userRoles, ok := userData["roles"].([]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, userRole := range userRoles {
+		role, ok := userRole.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := role["name"].(string)
+		if !ok {
+			continue
+		}
+		for _, wanted := range roles {
+			if name == wanted {
+				return true
+			}
+		}
+	}
+
+	return false
+}
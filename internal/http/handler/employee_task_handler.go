@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/middleware"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
 	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
@@ -33,14 +36,40 @@ type IEmployeeTaskHandler interface {
 	FindAllPaginatedByEmployeeID(ctx *gin.Context)
 	FindByIDForResponse(ctx *gin.Context)
 	FindAllPaginatedSurvey(ctx *gin.Context)
+	PreviewEmployeeTasksForRecruitment(ctx *gin.Context)
+	PreviewOnboardingBackfill(ctx *gin.Context)
+	ApplyOnboardingBackfill(ctx *gin.Context)
+	FindAllOnboardingBackfillsPaginated(ctx *gin.Context)
+	FindOnboardingBackfillByID(ctx *gin.Context)
+	BulkAssignEmployeeTasks(ctx *gin.Context)
+	BulkMoveEmployeeTasks(ctx *gin.Context)
+	BulkVerifyEmployeeTasks(ctx *gin.Context)
+	BulkReassignVerifier(ctx *gin.Context)
+	BulkDeleteEmployeeTasks(ctx *gin.Context)
+	CreateEmployeeTasksForOffboarding(ctx *gin.Context)
+	PreviewEmployeeTasksForOffboarding(ctx *gin.Context)
+	CancelOnboarding(ctx *gin.Context)
+	PauseOnboarding(ctx *gin.Context)
+	ResumeOnboarding(ctx *gin.Context)
+	ReassignEmployeeTask(ctx *gin.Context)
+	FindAllHistoriesByEmployeeTaskID(ctx *gin.Context)
+	ApproveEmployeeTask(ctx *gin.Context)
+	RejectEmployeeTask(ctx *gin.Context)
+	FindApprovalsByEmployeeTaskID(ctx *gin.Context)
+	UploadEmployeeTaskFile(ctx *gin.Context)
+	ReviewEmployeeTaskFile(ctx *gin.Context)
+	AcknowledgeEmployeeTask(ctx *gin.Context)
+	RecordEmployeeTaskQuizScore(ctx *gin.Context)
+	CheckInEvent(ctx *gin.Context)
 }
 
 type EmployeeTaskHandler struct {
-	Log      *logrus.Logger
-	Viper    *viper.Viper
-	Validate *validator.Validate
-	UseCase  usecase.IEmployeeTaskUseCase
-	DB       *gorm.DB
+	Log        *logrus.Logger
+	Viper      *viper.Viper
+	Validate   *validator.Validate
+	UseCase    usecase.IEmployeeTaskUseCase
+	DB         *gorm.DB
+	UserHelper helper.IUserHelper
 }
 
 func NewEmployeeTaskHandler(
@@ -49,13 +78,15 @@ func NewEmployeeTaskHandler(
 	validate *validator.Validate,
 	useCase usecase.IEmployeeTaskUseCase,
 	db *gorm.DB,
+	userHelper helper.IUserHelper,
 ) IEmployeeTaskHandler {
 	return &EmployeeTaskHandler{
-		Log:      log,
-		Viper:    viper,
-		Validate: validate,
-		UseCase:  useCase,
-		DB:       db,
+		Log:        log,
+		Viper:      viper,
+		Validate:   validate,
+		UseCase:    useCase,
+		DB:         db,
+		UserHelper: userHelper,
 	}
 }
 
@@ -66,7 +97,8 @@ func EmployeeTaskHandlerFactory(
 	validate := config.NewValidator(viper)
 	db := config.NewDatabase()
 	useCase := usecase.EmployeeTaskUseCaseFactory(log, viper)
-	return NewEmployeeTaskHandler(log, viper, validate, useCase, db)
+	userHelper := helper.UserHelperFactory(log)
+	return NewEmployeeTaskHandler(log, viper, validate, useCase, db, userHelper)
 }
 
 // CreateEmployeeTask create new employee task
@@ -99,6 +131,10 @@ func (h *EmployeeTaskHandler) CreateEmployeeTask(ctx *gin.Context) {
 	files := form.File["employee_task_attachments[file]"]
 	checklistNames := form.Value["employee_task_checklists[name]"]
 	checklistIds := form.Value["employee_task_checklists[id]"]
+	checklistSortOrders := form.Value["employee_task_checklists[sort_order]"]
+	checklistAssigneeIDs := form.Value["employee_task_checklists[assignee_id]"]
+	checklistDueDates := form.Value["employee_task_checklists[due_date]"]
+	checklistIsRequireds := form.Value["employee_task_checklists[is_required]"]
 
 	if len(files) > 0 {
 		for _, file := range files {
@@ -126,9 +162,19 @@ func (h *EmployeeTaskHandler) CreateEmployeeTask(ctx *gin.Context) {
 				checklistId = nil
 			}
 
+			sortOrder, err := checklistFormIntValue(checklistSortOrders, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid employee_task_checklists[sort_order]", err.Error())
+				return
+			}
+
 			req.EmployeeTaskChecklists = append(req.EmployeeTaskChecklists, request.EmployeeTaskChecklistRequest{
-				ID:   checklistId,
-				Name: name,
+				ID:         checklistId,
+				Name:       name,
+				SortOrder:  sortOrder,
+				AssigneeID: checklistFormValue(checklistAssigneeIDs, i),
+				DueDate:    checklistFormValue(checklistDueDates, i),
+				IsRequired: checklistFormValue(checklistIsRequireds, i),
 			})
 		}
 	}
@@ -282,6 +328,10 @@ func (h *EmployeeTaskHandler) UpdateEmployeeTask(ctx *gin.Context) {
 	files := form.File["employee_task_attachments[file]"]
 	checklistNames := form.Value["employee_task_checklists[name]"]
 	checklistIds := form.Value["employee_task_checklists[id]"]
+	checklistSortOrders := form.Value["employee_task_checklists[sort_order]"]
+	checklistAssigneeIDs := form.Value["employee_task_checklists[assignee_id]"]
+	checklistDueDates := form.Value["employee_task_checklists[due_date]"]
+	checklistIsRequireds := form.Value["employee_task_checklists[is_required]"]
 	checklistIsCheckeds := form.Value["employee_task_checklists[is_checked]"]
 	checklistVerifiedBys := form.Value["employee_task_checklists[verified_by]"]
 
@@ -325,11 +375,21 @@ func (h *EmployeeTaskHandler) UpdateEmployeeTask(ctx *gin.Context) {
 				checklistVerifiedBy = nil
 			}
 
+			sortOrder, err := checklistFormIntValue(checklistSortOrders, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid employee_task_checklists[sort_order]", err.Error())
+				return
+			}
+
 			req.EmployeeTaskChecklists = append(req.EmployeeTaskChecklists, request.EmployeeTaskChecklistRequest{
 				ID:         checklistId,
 				Name:       name,
 				IsChecked:  checklistIsChecked,
 				VerifiedBy: checklistVerifiedBy,
+				SortOrder:  sortOrder,
+				AssigneeID: checklistFormValue(checklistAssigneeIDs, i),
+				DueDate:    checklistFormValue(checklistDueDates, i),
+				IsRequired: checklistFormValue(checklistIsRequireds, i),
 			})
 		}
 	}
@@ -355,6 +415,10 @@ func (h *EmployeeTaskHandler) UpdateEmployeeTask(ctx *gin.Context) {
 	}
 	defer tx.Rollback()
 
+	if actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper); err == nil {
+		req.ActorEmployeeID = &actor.EmployeeID
+	}
+
 	res, err := h.UseCase.UpdateEmployeeTask(&req)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.UpdateEmployeeTask] " + err.Error())
@@ -464,7 +528,7 @@ func (h *EmployeeTaskHandler) UpdateEmployeeTaskMidsuit(ctx *gin.Context) {
 				checklistCok = ""
 			}
 
-			h.Log.Infof("checklistId cok: %v, name: %s, isChecked: %s, verifiedBy: %s", checklistCok, name, checklistIsChecked, checklistVerifiedBy)
+			h.Log.Infof("checklistId cok: %v, name: %s, isChecked: %v, verifiedBy: %v", checklistCok, name, checklistIsChecked, checklistVerifiedBy)
 
 			req.EmployeeTaskChecklists = append(req.EmployeeTaskChecklists, request.EmployeeTaskChecklistMidsuitRequest{
 				MidsuitID:  checklistId,
@@ -641,6 +705,7 @@ func (h *EmployeeTaskHandler) FindAllPaginated(ctx *gin.Context) {
 // @Produce  json
 // @Param kanban query string true "Kanban"
 // @Param employee_id query string true "Employee ID"
+// @Param source query string false "ONBOARDING (default) or OFFBOARDING"
 // @Success 200 {object} response.EmployeeTaskResponse
 // @Security BearerAuth
 // @Router /employee-tasks/count [get]
@@ -663,7 +728,12 @@ func (h *EmployeeTaskHandler) CountByKanbanAndEmployeeID(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.UseCase.CountByKanbanAndEmployeeID(entity.EmployeeTaskKanbanEnum(kanban), parsedEmployeeID)
+	source, ok := h.taskSourceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.UseCase.CountByKanbanAndEmployeeID(entity.EmployeeTaskKanbanEnum(kanban), parsedEmployeeID, source)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.CountByKanbanAndEmployeeID] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -681,6 +751,7 @@ func (h *EmployeeTaskHandler) CountByKanbanAndEmployeeID(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param employee_id query string true "Employee ID"
+// @Param source query string false "ONBOARDING (default) or OFFBOARDING"
 // @Success 200 {object} response.EmployeeTaskResponse
 // @Security BearerAuth
 // @Router /employee-tasks/employee [get]
@@ -697,7 +768,12 @@ func (h *EmployeeTaskHandler) FindAllByEmployeeID(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.UseCase.FindAllByEmployeeID(parsedEmployeeID)
+	source, ok := h.taskSourceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.UseCase.FindAllByEmployeeID(parsedEmployeeID, source)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.FindAllByEmployeeID] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -720,6 +796,7 @@ func (h *EmployeeTaskHandler) FindAllByEmployeeID(ctx *gin.Context) {
 // @Param page_size query int false "Page Size"
 // @Param search query string false "Search"
 // @Param created_at query string false "Created At"
+// @Param source query string false "ONBOARDING (default) or OFFBOARDING"
 // @Success 200 {object} response.EmployeeTaskResponse
 // @Security BearerAuth
 // @Router /employee-tasks/employee-kanban [get]
@@ -766,7 +843,12 @@ func (h *EmployeeTaskHandler) FindAllByEmployeeIDAndKanbanPaginated(ctx *gin.Con
 		"created_at": createdAt,
 	}
 
-	res, total, err := h.UseCase.FindAllByEmployeeIDAndKanbanPaginated(parsedEmployeeID, entity.EmployeeTaskKanbanEnum(kanban), page, pageSize, search, sort)
+	source, ok := h.taskSourceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	res, total, err := h.UseCase.FindAllByEmployeeIDAndKanbanPaginated(parsedEmployeeID, source, entity.EmployeeTaskKanbanEnum(kanban), page, pageSize, search, sort)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.FindAllByEmployeeIDAndKanbanPaginated] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -787,6 +869,7 @@ func (h *EmployeeTaskHandler) FindAllByEmployeeIDAndKanbanPaginated(ctx *gin.Con
 // @Accept  json
 // @Produce  json
 // @Param employee_id query string true "Employee ID"
+// @Param source query string false "ONBOARDING (default) or OFFBOARDING"
 // @Success 200 {object} response.EmployeeTaskProgressResponse
 // @Security BearerAuth
 // @Router /employee-tasks/employee-kanban/count [get]
@@ -803,7 +886,12 @@ func (h *EmployeeTaskHandler) CountKanbanProgressByEmployeeID(ctx *gin.Context)
 		return
 	}
 
-	res, err := h.UseCase.CountKanbanProgressByEmployeeID(parsedEmployeeID)
+	source, ok := h.taskSourceQuery(ctx)
+	if !ok {
+		return
+	}
+
+	res, err := h.UseCase.CountKanbanProgressByEmployeeID(parsedEmployeeID, source)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.CountKanbanProgressByEmployeeID] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -881,7 +969,7 @@ func (h *EmployeeTaskHandler) FindAllPaginatedByEmployeeID(ctx *gin.Context) {
 // FindByIDForResponse find employee task by id for response
 //
 // @Summary Find employee task by id for response
-// @Description Find employee task by id for response
+// @Description Find employee task by id with its survey answers. Answers to an anonymous or confidential survey are only returned to the employee of the task
 // @Tags Employee Task
 // @Accept  json
 // @Produce  json
@@ -896,7 +984,14 @@ func (h *EmployeeTaskHandler) FindByIDForResponse(ctx *gin.Context) {
 		return
 	}
 
-	res, err := h.UseCase.FindByIDForResponse(id)
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.FindByIDForResponse] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, err := h.UseCase.FindByIDForResponse(id, actor.EmployeeID)
 	if err != nil {
 		h.Log.Error("[EmployeeTaskHandler.FindByIDForResponse] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -957,3 +1052,1034 @@ func (h *EmployeeTaskHandler) FindAllPaginatedSurvey(ctx *gin.Context) {
 		"total":          total,
 	})
 }
+
+// PreviewEmployeeTasksForRecruitment preview the onboarding plan of a hire
+//
+// @Summary Preview onboarding plan of a hire
+// @Description Run the recruitment task generation without writing to the database or Midsuit and return the tasks that would be created
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.CreateEmployeeTasksForRecruitment true "Hire"
+// @Success 200 {object} response.OnboardingPlanResponse
+// @Security BearerAuth
+// @Router /employee-tasks/recruitment/preview [post]
+func (h *EmployeeTaskHandler) PreviewEmployeeTasksForRecruitment(ctx *gin.Context) {
+	var req request.CreateEmployeeTasksForRecruitment
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForRecruitment] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForRecruitment] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.PreviewEmployeeTasksForRecruitment(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForRecruitment] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success preview employee tasks", res)
+}
+
+// PreviewOnboardingBackfill preview template task changes for employees still onboarding
+//
+// @Summary Preview onboarding backfill
+// @Description List the tasks and checklists that would be added to, or changed on, employees whose onboarding is still in progress
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.PreviewOnboardingBackfillRequest true "Preview Onboarding Backfill"
+// @Success 200 {object} response.OnboardingBackfillPreviewResponse
+// @Security BearerAuth
+// @Router /employee-tasks/backfill/preview [post]
+func (h *EmployeeTaskHandler) PreviewOnboardingBackfill(ctx *gin.Context) {
+	var req request.PreviewOnboardingBackfillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewOnboardingBackfill] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewOnboardingBackfill] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.PreviewOnboardingBackfill(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewOnboardingBackfill] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success preview onboarding backfill", res)
+}
+
+// ApplyOnboardingBackfill apply template task changes to employees still onboarding
+//
+// @Summary Apply onboarding backfill
+// @Description Start applying the selected changes of the preview in the background. Without selections every change is applied
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ApplyOnboardingBackfillRequest true "Apply Onboarding Backfill"
+// @Success 202 {object} response.OnboardingBackfillResponse
+// @Security BearerAuth
+// @Router /employee-tasks/backfill [post]
+func (h *EmployeeTaskHandler) ApplyOnboardingBackfill(ctx *gin.Context) {
+	var req request.ApplyOnboardingBackfillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApplyOnboardingBackfill] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApplyOnboardingBackfill] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ApplyOnboardingBackfill(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApplyOnboardingBackfill] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusAccepted, "success start onboarding backfill", res)
+}
+
+// FindAllOnboardingBackfillsPaginated find all onboarding backfills paginated
+//
+// @Summary Find all onboarding backfills paginated
+// @Description Find all onboarding backfills paginated
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Success 200 {object} response.OnboardingBackfillResponse
+// @Security BearerAuth
+// @Router /employee-tasks/backfill [get]
+func (h *EmployeeTaskHandler) FindAllOnboardingBackfillsPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.UseCase.FindAllOnboardingBackfillsPaginated(page, pageSize, sort)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.FindAllOnboardingBackfillsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all onboarding backfills", gin.H{
+		"onboarding_backfills": res,
+		"total":                total,
+	})
+}
+
+// FindOnboardingBackfillByID find onboarding backfill by id
+//
+// @Summary Find onboarding backfill by id
+// @Description Find onboarding backfill by id, including its progress and the outcome of every change
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Onboarding Backfill ID"
+// @Success 200 {object} response.OnboardingBackfillResponse
+// @Security BearerAuth
+// @Router /employee-tasks/backfill/{id} [get]
+func (h *EmployeeTaskHandler) FindOnboardingBackfillByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		utils.BadRequestResponse(ctx, "id is required", "id is required")
+		return
+	}
+
+	onboardingBackfillID, err := uuid.Parse(id)
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindOnboardingBackfillByID(onboardingBackfillID)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.FindOnboardingBackfillByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find onboarding backfill", res)
+}
+
+// BulkAssignEmployeeTasks bulk assign a template task
+//
+// @Summary Bulk assign a template task
+// @Description Create an employee task from the template task for each employee. Employees who already have the task are skipped. Without end_date the due date is counted in working days from start_date (default today)
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.BulkAssignEmployeeTaskRequest true "Bulk assign a template task"
+// @Success 200 {object} response.BulkEmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/bulk/assign [post]
+func (h *EmployeeTaskHandler) BulkAssignEmployeeTasks(ctx *gin.Context) {
+	var req request.BulkAssignEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkAssignEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkAssignEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.BulkAssignEmployeeTasks(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkAssignEmployeeTasks] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success bulk assign employee tasks", res)
+}
+
+// BulkMoveEmployeeTasks bulk move employee tasks
+//
+// @Summary Bulk move employee tasks
+// @Description Move employee tasks to a kanban column. Each task is reported separately
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.BulkMoveEmployeeTaskRequest true "Bulk move employee tasks"
+// @Success 200 {object} response.BulkEmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/bulk/kanban [post]
+func (h *EmployeeTaskHandler) BulkMoveEmployeeTasks(ctx *gin.Context) {
+	var req request.BulkMoveEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkMoveEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkMoveEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.BulkMoveEmployeeTasks(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkMoveEmployeeTasks] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success bulk move employee tasks", res)
+}
+
+// BulkVerifyEmployeeTasks bulk verify employee tasks
+//
+// @Summary Bulk verify employee tasks
+// @Description Complete NEED_REVIEW employee tasks and check their checklists as the verifier. Each task is reported separately
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.BulkVerifyEmployeeTaskRequest true "Bulk verify employee tasks"
+// @Success 200 {object} response.BulkEmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/bulk/verify [post]
+func (h *EmployeeTaskHandler) BulkVerifyEmployeeTasks(ctx *gin.Context) {
+	var req request.BulkVerifyEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkVerifyEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkVerifyEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.BulkVerifyEmployeeTasks(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkVerifyEmployeeTasks] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success bulk verify employee tasks", res)
+}
+
+// BulkReassignVerifier bulk reassign verifier
+//
+// @Summary Bulk reassign verifier
+// @Description Assign another verifier to employee tasks. Each task is reported separately
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.BulkReassignVerifierRequest true "Bulk reassign verifier"
+// @Success 200 {object} response.BulkEmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/bulk/verifier [post]
+func (h *EmployeeTaskHandler) BulkReassignVerifier(ctx *gin.Context) {
+	var req request.BulkReassignVerifierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkReassignVerifier] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkReassignVerifier] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.BulkReassignVerifier(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkReassignVerifier] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success bulk reassign verifier", res)
+}
+
+// BulkDeleteEmployeeTasks bulk delete employee tasks
+//
+// @Summary Bulk delete employee tasks
+// @Description Soft delete employee tasks. Each task is reported separately
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.BulkDeleteEmployeeTaskRequest true "Bulk delete employee tasks"
+// @Success 200 {object} response.BulkEmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/bulk/delete [post]
+func (h *EmployeeTaskHandler) BulkDeleteEmployeeTasks(ctx *gin.Context) {
+	var req request.BulkDeleteEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkDeleteEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkDeleteEmployeeTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.BulkDeleteEmployeeTasks(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.BulkDeleteEmployeeTasks] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success bulk delete employee tasks", res)
+}
+
+// CreateEmployeeTasksForOffboarding create offboarding tasks for a leaving employee
+//
+// @Summary Create employee tasks for offboarding
+// @Description Create tasks from the offboarding templates. No task is due after the last working date
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.CreateEmployeeTasksForOffboarding true "Offboarding"
+// @Success 201 {string} string
+// @Security BearerAuth
+// @Router /employee-tasks/offboarding [post]
+func (h *EmployeeTaskHandler) CreateEmployeeTasksForOffboarding(ctx *gin.Context) {
+	var req request.CreateEmployeeTasksForOffboarding
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CreateEmployeeTasksForOffboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CreateEmployeeTasksForOffboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.UseCase.CreateEmployeeTasksForOffboarding(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CreateEmployeeTasksForOffboarding] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create employee tasks for offboarding", nil)
+}
+
+// PreviewEmployeeTasksForOffboarding preview the offboarding tasks of a leaving employee
+//
+// @Summary Preview employee tasks for offboarding
+// @Description Show the offboarding tasks that would be created, without creating them
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.CreateEmployeeTasksForOffboarding true "Offboarding"
+// @Success 200 {object} response.OnboardingPlanResponse
+// @Security BearerAuth
+// @Router /employee-tasks/offboarding/preview [post]
+func (h *EmployeeTaskHandler) PreviewEmployeeTasksForOffboarding(ctx *gin.Context) {
+	var req request.CreateEmployeeTasksForOffboarding
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForOffboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForOffboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.PreviewEmployeeTasksForOffboarding(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PreviewEmployeeTasksForOffboarding] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success preview employee tasks", res)
+}
+
+// taskSourceQuery reads the source query param, defaulting to onboarding. It writes a bad
+// request response and returns false when the source is unknown.
+func (h *EmployeeTaskHandler) taskSourceQuery(ctx *gin.Context) (string, bool) {
+	source := ctx.Query("source")
+	if source == "" {
+		return entity.TASK_SOURCE_ONBOARDING, true
+	}
+	if source != entity.TASK_SOURCE_ONBOARDING && source != entity.TASK_SOURCE_OFFBOARDING {
+		utils.BadRequestResponse(ctx, "invalid source", "source must be ONBOARDING or OFFBOARDING")
+		return "", false
+	}
+
+	return source, true
+}
+
+// CancelOnboarding cancel the onboarding of an employee
+//
+// @Summary Cancel onboarding
+// @Description Make the onboarding tasks of a withdrawn hire inactive, in Midsuit too, and take the hire off events that have not finished
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ChangeOnboardingStatusRequest true "Cancel Onboarding"
+// @Success 200 {object} response.OnboardingStatusResponse
+// @Security BearerAuth
+// @Router /employee-tasks/onboarding/cancel [post]
+func (h *EmployeeTaskHandler) CancelOnboarding(ctx *gin.Context) {
+	var req request.ChangeOnboardingStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CancelOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CancelOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.CancelOnboarding(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CancelOnboarding] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success cancel onboarding", res)
+}
+
+// PauseOnboarding pause the onboarding of an employee
+//
+// @Summary Pause onboarding
+// @Description Make the active onboarding tasks of a hire inactive until the onboarding is resumed
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ChangeOnboardingStatusRequest true "Pause Onboarding"
+// @Success 200 {object} response.OnboardingStatusResponse
+// @Security BearerAuth
+// @Router /employee-tasks/onboarding/pause [post]
+func (h *EmployeeTaskHandler) PauseOnboarding(ctx *gin.Context) {
+	var req request.ChangeOnboardingStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PauseOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PauseOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.PauseOnboarding(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.PauseOnboarding] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success pause onboarding", res)
+}
+
+// ResumeOnboarding resume the onboarding of an employee
+//
+// @Summary Resume onboarding
+// @Description Reactivate the onboarding tasks made inactive by a pause
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ChangeOnboardingStatusRequest true "Resume Onboarding"
+// @Success 200 {object} response.OnboardingStatusResponse
+// @Security BearerAuth
+// @Router /employee-tasks/onboarding/resume [post]
+func (h *EmployeeTaskHandler) ResumeOnboarding(ctx *gin.Context) {
+	var req request.ChangeOnboardingStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ResumeOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ResumeOnboarding] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ResumeOnboarding(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ResumeOnboarding] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success resume onboarding", res)
+}
+
+// ReassignEmployeeTask move an employee task to another employee
+//
+// @Summary Reassign employee task
+// @Description Move an unfinished employee task to another employee. Only admins, the verifier of the task and the delegate of the verifier may do this
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ReassignEmployeeTaskRequest true "Reassign Employee Task"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/reassign [post]
+func (h *EmployeeTaskHandler) ReassignEmployeeTask(ctx *gin.Context) {
+	var req request.ReassignEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReassignEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReassignEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReassignEmployeeTask] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.ReassignEmployeeTask(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReassignEmployeeTask] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success reassign employee task", res)
+}
+
+// FindAllHistoriesByEmployeeTaskID find the reassignment history of an employee task
+//
+// @Summary Find employee task history
+// @Description Find who reassigned an employee task, newest first
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Employee Task ID"
+// @Success 200 {object} response.EmployeeTaskHistoryResponse
+// @Security BearerAuth
+// @Router /employee-tasks/{id}/histories [get]
+func (h *EmployeeTaskHandler) FindAllHistoriesByEmployeeTaskID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindAllHistoriesByEmployeeTaskID(id)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.FindAllHistoriesByEmployeeTaskID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find employee task histories", res)
+}
+
+// ApproveEmployeeTask approve the current step of the approval chain of an employee task
+//
+// @Summary Approve employee task
+// @Description Approve the current step of the approval chain of an employee task in NEED_REVIEW. Only admins, the approver of the step and the delegate of the approver may do this. Approving the last step completes the task
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ApproveEmployeeTaskRequest true "Approve employee task"
+// @Success 200 {object} response.EmployeeTaskApprovalChainResponse
+// @Security BearerAuth
+// @Router /employee-tasks/approvals/approve [post]
+func (h *EmployeeTaskHandler) ApproveEmployeeTask(ctx *gin.Context) {
+	var req request.ApproveEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApproveEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApproveEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApproveEmployeeTask] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.ApproveEmployeeTask(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ApproveEmployeeTask] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success approve employee task", res)
+}
+
+// RejectEmployeeTask reject the current step of the approval chain of an employee task
+//
+// @Summary Reject employee task
+// @Description Reject the current step of the approval chain of an employee task with a reason. The task goes back to IN_PROGRESS and the chain starts again from the first step
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.RejectEmployeeTaskRequest true "Reject employee task"
+// @Success 200 {object} response.EmployeeTaskApprovalChainResponse
+// @Security BearerAuth
+// @Router /employee-tasks/approvals/reject [post]
+func (h *EmployeeTaskHandler) RejectEmployeeTask(ctx *gin.Context) {
+	var req request.RejectEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RejectEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RejectEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RejectEmployeeTask] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.RejectEmployeeTask(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RejectEmployeeTask] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success reject employee task", res)
+}
+
+// FindApprovalsByEmployeeTaskID find the approval chain of an employee task
+//
+// @Summary Find employee task approvals
+// @Description Find the approval chain of an employee task with the steps of every round
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Employee Task ID"
+// @Success 200 {object} response.EmployeeTaskApprovalChainResponse
+// @Security BearerAuth
+// @Router /employee-tasks/{id}/approvals [get]
+func (h *EmployeeTaskHandler) FindApprovalsByEmployeeTaskID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindApprovalsByEmployeeTaskID(id)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.FindApprovalsByEmployeeTaskID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find employee task approvals", res)
+}
+
+// resolveTaskActor finds the employee of the logged in user and whether one of their roles is
+// listed in employee_task.admin_roles.
+func resolveTaskActor(ctx *gin.Context, log *logrus.Logger, viper *viper.Viper, userHelper helper.IUserHelper) (request.TaskActor, error) {
+	user, err := middleware.GetUser(ctx, log)
+	if err != nil {
+		return request.TaskActor{}, err
+	}
+
+	employeeID, err := userHelper.GetEmployeeId(user)
+	if err != nil {
+		return request.TaskActor{}, err
+	}
+
+	return request.TaskActor{
+		EmployeeID: employeeID,
+		IsAdmin:    userHelper.HasAnyRole(user, viper.GetStringSlice("employee_task.admin_roles")),
+	}, nil
+}
+
+// UploadEmployeeTaskFile upload a document for a document upload employee task
+//
+// @Summary Upload employee task file
+// @Description Upload a document for a DOCUMENT_UPLOAD employee task. Only admins and the employee of the task may do this. The task goes to NEED_REVIEW until a document is accepted
+// @Tags Employee Task
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param body body request.UploadEmployeeTaskFileRequest true "Upload employee task file"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/files [post]
+func (h *EmployeeTaskHandler) UploadEmployeeTaskFile(ctx *gin.Context) {
+	var req request.UploadEmployeeTaskFileRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.UploadEmployeeTaskFile] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.UploadEmployeeTaskFile] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.UploadEmployeeTaskFile] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	timestamp := time.Now().UnixNano()
+	filePath := "storage/employee_tasks/files/" + strconv.FormatInt(timestamp, 10) + "_" + req.File.Filename
+	if err := ctx.SaveUploadedFile(req.File, filePath); err != nil {
+		h.Log.Error("failed to save employee task file: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to save employee task file", err.Error())
+		return
+	}
+	req.File = nil
+	req.Path = filePath
+
+	res, err := h.UseCase.UploadEmployeeTaskFile(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.UploadEmployeeTaskFile] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success upload employee task file", res)
+}
+
+// ReviewEmployeeTaskFile accept or reject an uploaded employee task document
+//
+// @Summary Review employee task file
+// @Description Accept or reject a pending document of a DOCUMENT_UPLOAD employee task. Only admins, the verifier of the task and the delegate of the verifier may do this. Accepting completes the task, rejecting the last pending document sends it back to IN_PROGRESS
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.ReviewEmployeeTaskFileRequest true "Review employee task file"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/files/review [post]
+func (h *EmployeeTaskHandler) ReviewEmployeeTaskFile(ctx *gin.Context) {
+	var req request.ReviewEmployeeTaskFileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReviewEmployeeTaskFile] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReviewEmployeeTaskFile] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReviewEmployeeTaskFile] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.ReviewEmployeeTaskFile(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.ReviewEmployeeTaskFile] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success review employee task file", res)
+}
+
+// AcknowledgeEmployeeTask e-acknowledge an acknowledgement employee task
+//
+// @Summary Acknowledge employee task
+// @Description Record the e-acknowledgement of an ACKNOWLEDGEMENT employee task and complete it. Only the employee of the task may do this
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.AcknowledgeEmployeeTaskRequest true "Acknowledge employee task"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/acknowledge [post]
+func (h *EmployeeTaskHandler) AcknowledgeEmployeeTask(ctx *gin.Context) {
+	var req request.AcknowledgeEmployeeTaskRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.AcknowledgeEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.AcknowledgeEmployeeTask] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.AcknowledgeEmployeeTask] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.AcknowledgeEmployeeTask(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.AcknowledgeEmployeeTask] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success acknowledge employee task", res)
+}
+
+// RecordEmployeeTaskQuizScore record the score of a quiz employee task
+//
+// @Summary Record employee task quiz score
+// @Description Record the score of a QUIZ employee task. Only admins, the verifier of the task and the delegate of the verifier may do this. A passing score completes the task, a failing one sends it back to IN_PROGRESS
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.RecordEmployeeTaskQuizScoreRequest true "Record employee task quiz score"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/quiz-score [post]
+func (h *EmployeeTaskHandler) RecordEmployeeTaskQuizScore(ctx *gin.Context) {
+	var req request.RecordEmployeeTaskQuizScoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RecordEmployeeTaskQuizScore] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RecordEmployeeTaskQuizScore] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RecordEmployeeTaskQuizScore] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.RecordEmployeeTaskQuizScore(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.RecordEmployeeTaskQuizScore] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success record employee task quiz score", res)
+}
+
+// CheckInEvent check an employee in at an event
+//
+// @Summary Check in event
+// @Description Check an invited employee in at an event and complete their EVENT_ATTENDANCE task of the event's template task. Admins may check anyone in, employees only themselves. The response is empty when the employee has no such task
+// @Tags Employee Task
+// @Accept  json
+// @Produce  json
+// @Param body body request.CheckInEventRequest true "Check in event"
+// @Success 200 {object} response.EmployeeTaskResponse
+// @Security BearerAuth
+// @Router /employee-tasks/event-check-in [post]
+func (h *EmployeeTaskHandler) CheckInEvent(ctx *gin.Context) {
+	var req request.CheckInEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CheckInEvent] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CheckInEvent] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CheckInEvent] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.CheckInEvent(&req)
+	if err != nil {
+		h.Log.Error("[EmployeeTaskHandler.CheckInEvent] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success check in event", res)
+}
+
+// checklistFormValue returns the i-th value of a checklist form array, or nil when the array is
+// shorter because the value was left out.
+func checklistFormValue(values []string, i int) *string {
+	if i >= len(values) {
+		return nil
+	}
+	return &values[i]
+}
+
+// checklistFormIntValue is checklistFormValue for numbers, an empty value counts as left out.
+func checklistFormIntValue(values []string, i int) (*int, error) {
+	value := checklistFormValue(values, i)
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.Atoi(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
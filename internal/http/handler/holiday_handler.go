@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type IHolidayHandler interface {
+	CreateHoliday(ctx *gin.Context)
+	UpdateHoliday(ctx *gin.Context)
+	DeleteHoliday(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+	ImportHolidays(ctx *gin.Context)
+	CalculateDueDate(ctx *gin.Context)
+}
+
+type HolidayHandler struct {
+	Log      *logrus.Logger
+	Viper    *viper.Viper
+	Validate *validator.Validate
+	UseCase  usecase.IHolidayUseCase
+	DB       *gorm.DB
+}
+
+func NewHolidayHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.IHolidayUseCase,
+	db *gorm.DB,
+) IHolidayHandler {
+	return &HolidayHandler{
+		Log:      log,
+		Viper:    viper,
+		Validate: validate,
+		UseCase:  useCase,
+		DB:       db,
+	}
+}
+
+func HolidayHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) IHolidayHandler {
+	db := config.NewDatabase()
+	validate := config.NewValidator(viper)
+	useCase := usecase.HolidayUseCaseFactory(log, viper)
+	return NewHolidayHandler(log, viper, validate, useCase, db)
+}
+
+// CreateHoliday creates a new holiday
+//
+// @Summary Create a new holiday
+// @Description Create a new holiday, leave organization_id empty for a national holiday
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param holiday body request.CreateHolidayRequest true "Holiday data"
+// @Security BearerAuth
+// @Success 201 {object} response.HolidayResponse
+// @Router /holidays [post]
+func (h *HolidayHandler) CreateHoliday(ctx *gin.Context) {
+	var req request.CreateHolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[HolidayHandler.CreateHoliday] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[HolidayHandler.CreateHoliday] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	holiday, err := h.UseCase.CreateHoliday(&req)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.CreateHoliday] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to create holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Holiday created", holiday)
+}
+
+// UpdateHoliday updates a holiday
+//
+// @Summary Update a holiday
+// @Description Update a holiday
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param holiday body request.UpdateHolidayRequest true "Holiday data"
+// @Security BearerAuth
+// @Success 200 {object} response.HolidayResponse
+// @Router /holidays/update [put]
+func (h *HolidayHandler) UpdateHoliday(ctx *gin.Context) {
+	var req request.UpdateHolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[HolidayHandler.UpdateHoliday] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[HolidayHandler.UpdateHoliday] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	holiday, err := h.UseCase.UpdateHoliday(&req)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.UpdateHoliday] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to update holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Holiday updated", holiday)
+}
+
+// DeleteHoliday deletes a holiday
+//
+// @Summary Delete a holiday
+// @Description Delete a holiday
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Security BearerAuth
+// @Success 204 "Holiday deleted"
+// @Router /holidays/{id} [delete]
+func (h *HolidayHandler) DeleteHoliday(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		h.Log.Error("[HolidayHandler.DeleteHoliday] ID is required")
+		utils.BadRequestResponse(ctx, "ID is required", "ID is required")
+		return
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.DeleteHoliday] " + err.Error())
+		utils.BadRequestResponse(ctx, "Invalid ID", "Invalid ID")
+		return
+	}
+
+	err = h.UseCase.DeleteHoliday(parsedID)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.DeleteHoliday] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to delete holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusNoContent, "Holiday deleted", nil)
+}
+
+// FindAllPaginated finds all holidays with pagination
+//
+// @Summary Find all holidays with pagination
+// @Description Find all holidays with pagination
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param search query string false "Search"
+// @Param date query string false "Date sort direction"
+// @Security BearerAuth
+// @Success 200 {object} response.HolidayResponse
+// @Router /holidays [get]
+func (h *HolidayHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	search := ctx.Query("search")
+
+	date := ctx.Query("date")
+	if date == "" {
+		date = "ASC"
+	}
+
+	sort := map[string]interface{}{
+		"date": date,
+	}
+	holidays, total, err := h.UseCase.FindAllPaginated(page, pageSize, search, sort)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to find holidays", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Holidays found", gin.H{
+		"holidays": holidays,
+		"total":    total,
+	})
+}
+
+// FindByID finds a holiday by ID
+//
+// @Summary Find a holiday by ID
+// @Description Find a holiday by ID
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Security BearerAuth
+// @Success 200 {object} response.HolidayResponse
+// @Router /holidays/{id} [get]
+func (h *HolidayHandler) FindByID(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		h.Log.Error("[HolidayHandler.FindByID] ID is required")
+		utils.BadRequestResponse(ctx, "ID is required", "ID is required")
+		return
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.FindByID] " + err.Error())
+		utils.BadRequestResponse(ctx, "Invalid ID", "Invalid ID")
+		return
+	}
+
+	holiday, err := h.UseCase.FindByID(parsedID)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.FindByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to find holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Holiday found", holiday)
+}
+
+// ImportHolidays imports a list of holidays
+//
+// @Summary Import holidays
+// @Description Create or update holidays in bulk, matched by organization and date
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param holidays body request.ImportHolidayRequest true "Holiday list"
+// @Security BearerAuth
+// @Success 200 {object} response.ImportHolidayResponse
+// @Router /holidays/import [post]
+func (h *HolidayHandler) ImportHolidays(ctx *gin.Context) {
+	var req request.ImportHolidayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[HolidayHandler.ImportHolidays] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[HolidayHandler.ImportHolidays] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ImportHolidays(ctx, &req)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.ImportHolidays] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to import holidays", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Holidays imported", res)
+}
+
+// CalculateDueDate calculates a due date in working days
+//
+// @Summary Calculate due date
+// @Description Add a number of working days to a start date, skipping non-working weekdays and holidays
+// @Tags Holidays
+// @Accept json
+// @Produce json
+// @Param start_date query string true "Start date (2006-01-02)"
+// @Param days query int true "Working days"
+// @Param organization_id query string false "Organization ID"
+// @Security BearerAuth
+// @Success 200 {object} response.WorkingDayResponse
+// @Router /holidays/due-date [get]
+func (h *HolidayHandler) CalculateDueDate(ctx *gin.Context) {
+	startDate := ctx.Query("start_date")
+	if startDate == "" {
+		h.Log.Error("[HolidayHandler.CalculateDueDate] start_date is required")
+		utils.BadRequestResponse(ctx, "start_date is required", "start_date is required")
+		return
+	}
+
+	days, err := strconv.Atoi(ctx.Query("days"))
+	if err != nil || days < 0 {
+		h.Log.Error("[HolidayHandler.CalculateDueDate] invalid days query param")
+		utils.BadRequestResponse(ctx, "invalid days query param", "invalid days query param")
+		return
+	}
+
+	var organizationID *uuid.UUID
+	if orgID := ctx.Query("organization_id"); orgID != "" {
+		parsedOrgID, err := uuid.Parse(orgID)
+		if err != nil {
+			h.Log.Error("[HolidayHandler.CalculateDueDate] " + err.Error())
+			utils.BadRequestResponse(ctx, "Invalid organization ID", err.Error())
+			return
+		}
+		organizationID = &parsedOrgID
+	}
+
+	res, err := h.UseCase.CalculateDueDate(organizationID, startDate, days)
+	if err != nil {
+		h.Log.Error("[HolidayHandler.CalculateDueDate] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to calculate due date", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Due date calculated", res)
+}
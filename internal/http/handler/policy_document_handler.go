@@ -0,0 +1,514 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IPolicyDocumentHandler interface {
+	CreatePolicyDocument(ctx *gin.Context)
+	UpdatePolicyDocument(ctx *gin.Context)
+	CreatePolicyDocumentVersion(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+	AcknowledgePolicyDocument(ctx *gin.Context)
+	FindPendingAcknowledgements(ctx *gin.Context)
+	FindAllAcknowledgementsPaginated(ctx *gin.Context)
+	VerifyPolicyAcknowledgementChain(ctx *gin.Context)
+}
+
+type PolicyDocumentHandler struct {
+	Log        *logrus.Logger
+	Viper      *viper.Viper
+	Validate   *validator.Validate
+	UseCase    usecase.IPolicyDocumentUseCase
+	UserHelper helper.IUserHelper
+}
+
+func NewPolicyDocumentHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.IPolicyDocumentUseCase,
+	userHelper helper.IUserHelper,
+) IPolicyDocumentHandler {
+	return &PolicyDocumentHandler{
+		Log:        log,
+		Viper:      viper,
+		Validate:   validate,
+		UseCase:    useCase,
+		UserHelper: userHelper,
+	}
+}
+
+func PolicyDocumentHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) IPolicyDocumentHandler {
+	useCase := usecase.PolicyDocumentUseCaseFactory(log, viper)
+	validate := config.NewValidator(viper)
+	userHelper := helper.UserHelperFactory(log)
+	return NewPolicyDocumentHandler(log, viper, validate, useCase, userHelper)
+}
+
+// CreatePolicyDocument create policy document
+//
+// @Summary Create policy document
+// @Description Create a policy document with the uploaded file as version 1. Only admins may do this
+// @Tags Policy Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Param body body request.CreatePolicyDocumentRequest true "Create Policy Document"
+// @Success 201 {object} response.PolicyDocumentResponse
+// @Security BearerAuth
+// @Router /policy-documents [post]
+func (h *PolicyDocumentHandler) CreatePolicyDocument(ctx *gin.Context) {
+	var req request.CreatePolicyDocumentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocument] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	timestamp := time.Now().UnixNano()
+	filePath := "storage/policy_documents/" + strconv.FormatInt(timestamp, 10) + "_" + req.File.Filename
+	if err := ctx.SaveUploadedFile(req.File, filePath); err != nil {
+		h.Log.Error("failed to save policy document file: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to save policy document file", err.Error())
+		return
+	}
+	req.File = nil
+	req.Path = filePath
+
+	res, err := h.UseCase.CreatePolicyDocument(&req)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocument] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create policy document", res)
+}
+
+// UpdatePolicyDocument update policy document
+//
+// @Summary Update policy document
+// @Description Update the name, description and status of a policy document. Only admins may do this
+// @Tags Policy Documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy Document ID"
+// @Param body body request.UpdatePolicyDocumentRequest true "Update Policy Document"
+// @Success 200 {object} response.PolicyDocumentResponse
+// @Security BearerAuth
+// @Router /policy-documents/{id} [put]
+func (h *PolicyDocumentHandler) UpdatePolicyDocument(ctx *gin.Context) {
+	var req request.UpdatePolicyDocumentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.UpdatePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+	req.ID = ctx.Param("id")
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.UpdatePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.UpdatePolicyDocument] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.UpdatePolicyDocument(&req)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.UpdatePolicyDocument] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success update policy document", res)
+}
+
+// CreatePolicyDocumentVersion upload a new version of a policy document
+//
+// @Summary Create policy document version
+// @Description Upload a new version of a policy document, it becomes the current version that employees have to acknowledge. Only admins may do this
+// @Tags Policy Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Policy Document ID"
+// @Param body body request.CreatePolicyDocumentVersionRequest true "Create Policy Document Version"
+// @Success 201 {object} response.PolicyDocumentResponse
+// @Security BearerAuth
+// @Router /policy-documents/{id}/versions [post]
+func (h *PolicyDocumentHandler) CreatePolicyDocumentVersion(ctx *gin.Context) {
+	var req request.CreatePolicyDocumentVersionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocumentVersion] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+	req.PolicyDocumentID = ctx.Param("id")
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocumentVersion] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocumentVersion] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	timestamp := time.Now().UnixNano()
+	filePath := "storage/policy_documents/" + strconv.FormatInt(timestamp, 10) + "_" + req.File.Filename
+	if err := ctx.SaveUploadedFile(req.File, filePath); err != nil {
+		h.Log.Error("failed to save policy document file: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to save policy document file", err.Error())
+		return
+	}
+	req.File = nil
+	req.Path = filePath
+
+	res, err := h.UseCase.CreatePolicyDocumentVersion(&req)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.CreatePolicyDocumentVersion] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create policy document version", res)
+}
+
+// FindAllPaginated find all policy documents paginated
+//
+// @Summary Find all policy documents paginated
+// @Description Find all policy documents paginated
+// @Tags Policy Documents
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param search query string false "Search"
+// @Param status query string false "Status"
+// @Param created_at query string false "Created At"
+// @Success 200 {object} response.PolicyDocumentResponse
+// @Security BearerAuth
+// @Router /policy-documents [get]
+func (h *PolicyDocumentHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, ctx.Query("search"), ctx.Query("status"), sort)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all policy documents", gin.H{
+		"policy_documents": res,
+		"total":            total,
+	})
+}
+
+// FindByID find policy document by id
+//
+// @Summary Find policy document by id
+// @Description Find policy document by id with all of its versions
+// @Tags Policy Documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy Document ID"
+// @Success 200 {object} response.PolicyDocumentResponse
+// @Security BearerAuth
+// @Router /policy-documents/{id} [get]
+func (h *PolicyDocumentHandler) FindByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindByID(id)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find policy document", res)
+}
+
+// AcknowledgePolicyDocument acknowledge the current version of a policy document
+//
+// @Summary Acknowledge policy document
+// @Description The logged in employee accepts the current version of the policy document. The document hash, IP address and user agent are recorded in the hash-chained acknowledgement log
+// @Tags Policy Documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy Document ID"
+// @Param body body request.AcknowledgePolicyDocumentRequest true "Acknowledge Policy Document"
+// @Success 201 {object} response.PolicyAcknowledgementResponse
+// @Security BearerAuth
+// @Router /policy-documents/{id}/acknowledge [post]
+func (h *PolicyDocumentHandler) AcknowledgePolicyDocument(ctx *gin.Context) {
+	var req request.AcknowledgePolicyDocumentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.AcknowledgePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+	req.PolicyDocumentID = ctx.Param("id")
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[PolicyDocumentHandler.AcknowledgePolicyDocument] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.AcknowledgePolicyDocument] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+	req.IPAddress = ctx.ClientIP()
+	req.UserAgent = ctx.Request.UserAgent()
+
+	res, err := h.UseCase.AcknowledgePolicyDocument(&req)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.AcknowledgePolicyDocument] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success acknowledge policy document", res)
+}
+
+// FindPendingAcknowledgements find employees who have not acknowledged a policy document
+//
+// @Summary Find pending policy acknowledgements
+// @Description List the employees with an active hiring who have not acknowledged the current version of the policy document. Only admins may do this
+// @Tags Policy Documents
+// @Accept json
+// @Produce json
+// @Param id path string true "Policy Document ID"
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Success 200 {object} response.PendingPolicyAcknowledgementResponse
+// @Security BearerAuth
+// @Router /policy-documents/{id}/pending-acknowledgements [get]
+func (h *PolicyDocumentHandler) FindPendingAcknowledgements(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindPendingAcknowledgements] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, total, err := h.UseCase.FindPendingAcknowledgements(id, page, pageSize, actor)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindPendingAcknowledgements] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find pending policy acknowledgements", gin.H{
+		"pending_acknowledgements": res,
+		"total":                    total,
+	})
+}
+
+// FindAllAcknowledgementsPaginated find all policy acknowledgements paginated
+//
+// @Summary Find all policy acknowledgements paginated
+// @Description Admins see every acknowledgement, optionally of one document or employee. Other employees only see their own
+// @Tags Policy Acknowledgements
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param policy_document_id query string false "Policy Document ID"
+// @Param employee_id query string false "Employee ID"
+// @Param sequence query string false "Sequence"
+// @Success 200 {object} response.PolicyAcknowledgementResponse
+// @Security BearerAuth
+// @Router /policy-acknowledgements [get]
+func (h *PolicyDocumentHandler) FindAllAcknowledgementsPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	var policyDocumentID *uuid.UUID
+	if ctx.Query("policy_document_id") != "" {
+		parsedPolicyDocumentID, err := uuid.Parse(ctx.Query("policy_document_id"))
+		if err != nil {
+			utils.BadRequestResponse(ctx, "invalid policy_document_id", "invalid policy_document_id")
+			return
+		}
+		policyDocumentID = &parsedPolicyDocumentID
+	}
+
+	var employeeID *uuid.UUID
+	if ctx.Query("employee_id") != "" {
+		parsedEmployeeID, err := uuid.Parse(ctx.Query("employee_id"))
+		if err != nil {
+			utils.BadRequestResponse(ctx, "invalid employee_id", "invalid employee_id")
+			return
+		}
+		employeeID = &parsedEmployeeID
+	}
+
+	sequence := ctx.Query("sequence")
+	if sequence == "" {
+		sequence = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"sequence": sequence,
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindAllAcknowledgementsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, total, err := h.UseCase.FindAllAcknowledgementsPaginated(page, pageSize, policyDocumentID, employeeID, actor, sort)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.FindAllAcknowledgementsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all policy acknowledgements", gin.H{
+		"policy_acknowledgements": res,
+		"total":                   total,
+	})
+}
+
+// VerifyPolicyAcknowledgementChain verify the policy acknowledgement log
+//
+// @Summary Verify policy acknowledgement chain
+// @Description Recompute the hash chain of the acknowledgement log and report the first record that was changed, deleted or is missing. Only admins may do this
+// @Tags Policy Acknowledgements
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.PolicyAcknowledgementChainResponse
+// @Security BearerAuth
+// @Router /policy-acknowledgements/verify [get]
+func (h *PolicyDocumentHandler) VerifyPolicyAcknowledgementChain(ctx *gin.Context) {
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.VerifyPolicyAcknowledgementChain] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, err := h.UseCase.VerifyPolicyAcknowledgementChain(actor)
+	if err != nil {
+		h.Log.Error("[PolicyDocumentHandler.VerifyPolicyAcknowledgementChain] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success verify policy acknowledgement chain", res)
+}
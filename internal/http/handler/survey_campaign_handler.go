@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyCampaignHandler interface {
+	CreateSurveyCampaign(ctx *gin.Context)
+	UpdateSurveyCampaign(ctx *gin.Context)
+	DeleteSurveyCampaign(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+	FindAllDispatchesPaginated(ctx *gin.Context)
+	RunSurveyCampaigns(ctx *gin.Context)
+}
+
+type SurveyCampaignHandler struct {
+	Log        *logrus.Logger
+	Viper      *viper.Viper
+	Validate   *validator.Validate
+	UseCase    usecase.ISurveyCampaignUseCase
+	UserHelper helper.IUserHelper
+}
+
+func NewSurveyCampaignHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.ISurveyCampaignUseCase,
+	userHelper helper.IUserHelper,
+) ISurveyCampaignHandler {
+	return &SurveyCampaignHandler{
+		Log:        log,
+		Viper:      viper,
+		Validate:   validate,
+		UseCase:    useCase,
+		UserHelper: userHelper,
+	}
+}
+
+func SurveyCampaignHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) ISurveyCampaignHandler {
+	useCase := usecase.SurveyCampaignUseCaseFactory(log, viper)
+	validate := config.NewValidator(viper)
+	userHelper := helper.UserHelperFactory(log)
+	return NewSurveyCampaignHandler(log, viper, validate, useCase, userHelper)
+}
+
+// CreateSurveyCampaign create a survey campaign
+//
+// @Summary Create survey campaign
+// @Description Send a published survey template to the new hires matching the rules, offset_days days after their hiring date. Surveys close response_days after they are due and are reminded reminder_days after they are sent
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param body body request.CreateSurveyCampaignRequest true "Create Survey Campaign"
+// @Success 201 {object} response.SurveyCampaignResponse
+// @Security BearerAuth
+// @Router /survey-campaigns [post]
+func (h *SurveyCampaignHandler) CreateSurveyCampaign(ctx *gin.Context) {
+	var req request.CreateSurveyCampaignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[SurveyCampaignHandler.CreateSurveyCampaign] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyCampaignHandler.CreateSurveyCampaign] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.CreateSurveyCampaign] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.CreateSurveyCampaign(&req)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.CreateSurveyCampaign] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create survey campaign", res)
+}
+
+// UpdateSurveyCampaign update a survey campaign
+//
+// @Summary Update survey campaign
+// @Description Update a survey campaign, the surveys it already sent are not changed
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param body body request.UpdateSurveyCampaignRequest true "Update Survey Campaign"
+// @Success 200 {object} response.SurveyCampaignResponse
+// @Security BearerAuth
+// @Router /survey-campaigns/update [put]
+func (h *SurveyCampaignHandler) UpdateSurveyCampaign(ctx *gin.Context) {
+	var req request.UpdateSurveyCampaignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[SurveyCampaignHandler.UpdateSurveyCampaign] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyCampaignHandler.UpdateSurveyCampaign] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.UpdateSurveyCampaign(&req)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.UpdateSurveyCampaign] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success update survey campaign", res)
+}
+
+// DeleteSurveyCampaign delete a survey campaign
+//
+// @Summary Delete survey campaign
+// @Description Stop a survey campaign, the surveys it sent stay open until their due date
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Campaign ID"
+// @Success 200 {string} string
+// @Security BearerAuth
+// @Router /survey-campaigns/{id} [delete]
+func (h *SurveyCampaignHandler) DeleteSurveyCampaign(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	if err := h.UseCase.DeleteSurveyCampaign(id); err != nil {
+		h.Log.Error("[SurveyCampaignHandler.DeleteSurveyCampaign] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success delete survey campaign", nil)
+}
+
+// FindAllPaginated find all survey campaigns paginated
+//
+// @Summary Find all survey campaigns paginated
+// @Description Find all survey campaigns paginated, optionally by status
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param search query string false "Search"
+// @Param status query string false "Status"
+// @Param created_at query string false "Created At"
+// @Success 200 {object} response.SurveyCampaignResponse
+// @Security BearerAuth
+// @Router /survey-campaigns [get]
+func (h *SurveyCampaignHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, ctx.Query("search"), ctx.Query("status"), sort)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey campaigns", gin.H{
+		"survey_campaigns": res,
+		"total":            total,
+	})
+}
+
+// FindByID find survey campaign by id
+//
+// @Summary Find survey campaign by id
+// @Description Find survey campaign by id with its offsets and rules
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Campaign ID"
+// @Success 200 {object} response.SurveyCampaignResponse
+// @Security BearerAuth
+// @Router /survey-campaigns/{id} [get]
+func (h *SurveyCampaignHandler) FindByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindByID(id)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.FindByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey campaign", res)
+}
+
+// FindAllDispatchesPaginated find the surveys sent by a survey campaign
+//
+// @Summary Find survey campaign dispatches paginated
+// @Description The surveys a campaign sent or skipped, one per hire and offset, optionally by status
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Campaign ID"
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param status query string false "Status"
+// @Param scheduled_on query string false "Scheduled On"
+// @Success 200 {object} response.SurveyCampaignDispatchResponse
+// @Security BearerAuth
+// @Router /survey-campaigns/{id}/dispatches [get]
+func (h *SurveyCampaignHandler) FindAllDispatchesPaginated(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	scheduledOn := ctx.Query("scheduled_on")
+	if scheduledOn == "" {
+		scheduledOn = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"scheduled_on": scheduledOn,
+	}
+
+	res, total, err := h.UseCase.FindAllDispatchesPaginated(id, page, pageSize, ctx.Query("status"), sort)
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.FindAllDispatchesPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey campaign dispatches", gin.H{
+		"survey_campaign_dispatches": res,
+		"total":                      total,
+	})
+}
+
+// RunSurveyCampaigns run the survey campaigns now
+//
+// @Summary Run survey campaigns
+// @Description Send the surveys that are due, remind and close the open ones now instead of waiting for the scheduler
+// @Tags Survey Campaigns
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.SurveyCampaignRunResponse
+// @Security BearerAuth
+// @Router /survey-campaigns/run [post]
+func (h *SurveyCampaignHandler) RunSurveyCampaigns(ctx *gin.Context) {
+	res, err := h.UseCase.RunSurveyCampaigns(time.Now())
+	if err != nil {
+		h.Log.Error("[SurveyCampaignHandler.RunSurveyCampaigns] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success run survey campaigns", res)
+}
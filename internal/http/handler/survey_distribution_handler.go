@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyDistributionHandler interface {
+	CreateSurveyDistribution(ctx *gin.Context)
+	UpdateSurveyDistribution(ctx *gin.Context)
+	CloseSurveyDistribution(ctx *gin.Context)
+	DeleteSurveyDistribution(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+	FindAllRecipientsPaginated(ctx *gin.Context)
+	FindAllMine(ctx *gin.Context)
+	FindSurveyDistributionAnalytics(ctx *gin.Context)
+}
+
+type SurveyDistributionHandler struct {
+	Log        *logrus.Logger
+	Viper      *viper.Viper
+	Validate   *validator.Validate
+	UseCase    usecase.ISurveyDistributionUseCase
+	UserHelper helper.IUserHelper
+}
+
+func NewSurveyDistributionHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.ISurveyDistributionUseCase,
+	userHelper helper.IUserHelper,
+) ISurveyDistributionHandler {
+	return &SurveyDistributionHandler{
+		Log:        log,
+		Viper:      viper,
+		Validate:   validate,
+		UseCase:    useCase,
+		UserHelper: userHelper,
+	}
+}
+
+func SurveyDistributionHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) ISurveyDistributionHandler {
+	useCase := usecase.SurveyDistributionUseCaseFactory(log, viper)
+	validate := config.NewValidator(viper)
+	userHelper := helper.UserHelperFactory(log)
+	return NewSurveyDistributionHandler(log, viper, validate, useCase, userHelper)
+}
+
+// CreateSurveyDistribution create a survey distribution
+//
+// @Summary Create survey distribution
+// @Description Send a published survey template to the listed employees and to the hires matching the rules, without employee tasks. The survey takes answers from opens_on through closes_on
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param body body request.CreateSurveyDistributionRequest true "Create Survey Distribution"
+// @Success 201 {object} response.SurveyDistributionResponse
+// @Security BearerAuth
+// @Router /survey-distributions [post]
+func (h *SurveyDistributionHandler) CreateSurveyDistribution(ctx *gin.Context) {
+	var req request.CreateSurveyDistributionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[SurveyDistributionHandler.CreateSurveyDistribution] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyDistributionHandler.CreateSurveyDistribution] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.CreateSurveyDistribution] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.CreateSurveyDistribution(&req)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.CreateSurveyDistribution] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create survey distribution", res)
+}
+
+// UpdateSurveyDistribution update a survey distribution
+//
+// @Summary Update survey distribution
+// @Description Update the name, dates and rules of a survey distribution. The employees listed and matched now are added to its recipients, none are removed
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param body body request.UpdateSurveyDistributionRequest true "Update Survey Distribution"
+// @Success 200 {object} response.SurveyDistributionResponse
+// @Security BearerAuth
+// @Router /survey-distributions/update [put]
+func (h *SurveyDistributionHandler) UpdateSurveyDistribution(ctx *gin.Context) {
+	var req request.UpdateSurveyDistributionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[SurveyDistributionHandler.UpdateSurveyDistribution] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyDistributionHandler.UpdateSurveyDistribution] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.UpdateSurveyDistribution(&req)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.UpdateSurveyDistribution] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success update survey distribution", res)
+}
+
+// CloseSurveyDistribution close a survey distribution
+//
+// @Summary Close survey distribution
+// @Description Stop a survey distribution from taking answers before its closing day
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Distribution ID"
+// @Success 200 {object} response.SurveyDistributionResponse
+// @Security BearerAuth
+// @Router /survey-distributions/{id}/close [post]
+func (h *SurveyDistributionHandler) CloseSurveyDistribution(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.CloseSurveyDistribution(id)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.CloseSurveyDistribution] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success close survey distribution", res)
+}
+
+// DeleteSurveyDistribution delete a survey distribution
+//
+// @Summary Delete survey distribution
+// @Description Delete a survey distribution with its recipients
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Distribution ID"
+// @Success 200 {string} string
+// @Security BearerAuth
+// @Router /survey-distributions/{id} [delete]
+func (h *SurveyDistributionHandler) DeleteSurveyDistribution(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	if err := h.UseCase.DeleteSurveyDistribution(id); err != nil {
+		h.Log.Error("[SurveyDistributionHandler.DeleteSurveyDistribution] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success delete survey distribution", nil)
+}
+
+// FindAllPaginated find all survey distributions paginated
+//
+// @Summary Find all survey distributions paginated
+// @Description Find all survey distributions paginated, optionally by status (SCHEDULED, OPEN or CLOSED)
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param search query string false "Search"
+// @Param status query string false "Status"
+// @Param created_at query string false "Created At"
+// @Success 200 {object} response.SurveyDistributionResponse
+// @Security BearerAuth
+// @Router /survey-distributions [get]
+func (h *SurveyDistributionHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, ctx.Query("search"), ctx.Query("status"), sort)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey distributions", gin.H{
+		"survey_distributions": res,
+		"total":                total,
+	})
+}
+
+// FindByID find survey distribution by id
+//
+// @Summary Find survey distribution by id
+// @Description Find survey distribution by id with its rules and recipient counts
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Distribution ID"
+// @Success 200 {object} response.SurveyDistributionResponse
+// @Security BearerAuth
+// @Router /survey-distributions/{id} [get]
+func (h *SurveyDistributionHandler) FindByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindByID(id)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey distribution", res)
+}
+
+// FindAllRecipientsPaginated find the recipients of a survey distribution
+//
+// @Summary Find survey distribution recipients paginated
+// @Description The employees a survey distribution was sent to, optionally by status (PENDING or SUBMITTED)
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Distribution ID"
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param status query string false "Status"
+// @Param created_at query string false "Created At"
+// @Success 200 {object} response.SurveyDistributionRecipientResponse
+// @Security BearerAuth
+// @Router /survey-distributions/{id}/recipients [get]
+func (h *SurveyDistributionHandler) FindAllRecipientsPaginated(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "ASC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.UseCase.FindAllRecipientsPaginated(id, page, pageSize, ctx.Query("status"), sort)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindAllRecipientsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey distribution recipients", gin.H{
+		"survey_distribution_recipients": res,
+		"total":                          total,
+	})
+}
+
+// FindAllMine find the survey distributions sent to the logged in employee
+//
+// @Summary Find my survey distributions
+// @Description The survey distributions sent to the logged in employee, newest first, with the status of each
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.SurveyDistributionRecipientResponse
+// @Security BearerAuth
+// @Router /survey-distributions/me [get]
+func (h *SurveyDistributionHandler) FindAllMine(ctx *gin.Context) {
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindAllMine] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, err := h.UseCase.FindAllByEmployeeID(actor.EmployeeID)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindAllMine] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find my survey distributions", res)
+}
+
+// FindSurveyDistributionAnalytics find the analytics of a survey distribution
+//
+// @Summary Find survey distribution analytics
+// @Description Aggregate the answers of the recipients of a survey distribution, respecting the anonymity of its survey template
+// @Tags Survey Distributions
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey Distribution ID"
+// @Success 200 {object} response.SurveyAnalyticsResponse
+// @Security BearerAuth
+// @Router /survey-distributions/{id}/analytics [get]
+func (h *SurveyDistributionHandler) FindSurveyDistributionAnalytics(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindSurveyDistributionAnalytics(id)
+	if err != nil {
+		h.Log.Error("[SurveyDistributionHandler.FindSurveyDistributionAnalytics] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey distribution analytics", res)
+}
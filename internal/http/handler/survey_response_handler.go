@@ -1,46 +1,47 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
 	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
-	"github.com/xuri/excelize/v2"
 )
 
-// contains checks if a string exists in a slice of strings.
-func contains(slice []string, item string) bool {
-	for _, v := range slice {
-		if v == item {
-			return true
-		}
-	}
-	return false
-}
-
 type ISurveyResponseHandler interface {
 	CreateOrUpdateSurveyResponses(ctx *gin.Context)
 	CreateOrUpdateSurveyResponsesBulk(ctx *gin.Context)
 	ExportSurveyResponses(ctx *gin.Context)
+	CreateSurveyExportJob(ctx *gin.Context)
+	FindSurveyExportJobByID(ctx *gin.Context)
+	FindAllSurveyExportJobsPaginated(ctx *gin.Context)
+	FindSurveyQuizResult(ctx *gin.Context)
+	FindAllSurveyQuizAttemptsPaginated(ctx *gin.Context)
+	CreateOrUpdateSurveyDistributionResponses(ctx *gin.Context)
+	FindSurveyDistributionResponses(ctx *gin.Context)
 }
 
 type SurveyResponseHandler struct {
-	Log                 *logrus.Logger
-	Viper               *viper.Viper
-	Validate            *validator.Validate
-	UseCase             usecase.ISurveyResponseUseCase
-	UserHelper          helper.IUserHelper
-	EmployeeTaskUseCase usecase.IEmployeeTaskUseCase
+	Log           *logrus.Logger
+	Viper         *viper.Viper
+	Validate      *validator.Validate
+	UseCase       usecase.ISurveyResponseUseCase
+	UserHelper    helper.IUserHelper
+	ExportUseCase usecase.ISurveyExportUseCase
 }
 
 func NewSurveyResponseHandler(
@@ -49,15 +50,15 @@ func NewSurveyResponseHandler(
 	validate *validator.Validate,
 	useCase usecase.ISurveyResponseUseCase,
 	userHelper helper.IUserHelper,
-	employeeTaskUseCase usecase.IEmployeeTaskUseCase,
+	exportUseCase usecase.ISurveyExportUseCase,
 ) ISurveyResponseHandler {
 	return &SurveyResponseHandler{
-		Log:                 log,
-		Viper:               viper,
-		Validate:            validate,
-		UseCase:             useCase,
-		UserHelper:          userHelper,
-		EmployeeTaskUseCase: employeeTaskUseCase,
+		Log:           log,
+		Viper:         viper,
+		Validate:      validate,
+		UseCase:       useCase,
+		UserHelper:    userHelper,
+		ExportUseCase: exportUseCase,
 	}
 }
 
@@ -68,8 +69,8 @@ func SurveyResponseHandlerFactory(
 	useCase := usecase.SurveyResponseUseCaseFactory(log, viper)
 	validate := config.NewValidator(viper)
 	userHelper := helper.UserHelperFactory(log)
-	employeeTaskUseCase := usecase.EmployeeTaskUseCaseFactory(log, viper)
-	return NewSurveyResponseHandler(log, viper, validate, useCase, userHelper, employeeTaskUseCase)
+	exportUseCase := usecase.SurveyExportUseCaseFactory(log, viper)
+	return NewSurveyResponseHandler(log, viper, validate, useCase, userHelper, exportUseCase)
 }
 
 func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponses(ctx *gin.Context) {
@@ -107,18 +108,9 @@ func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponses(ctx *gin.Context)
 
 		h.Log.Infof("answer: %v", answer)
 
-		var answerFilePath string
-
+		var answerFile *multipart.FileHeader
 		if len(answerFiles) > i {
-			file := answerFiles[i]
-			timestamp := time.Now().UnixNano()
-			filePath := "storage/answers/files/" + strconv.FormatInt(timestamp, 10) + "_" + file.Filename
-			if err := ctx.SaveUploadedFile(file, filePath); err != nil {
-				h.Log.Error("Failed to save answer file: ", err)
-				utils.ErrorResponse(ctx, 500, "error", "Failed to save answer file")
-				return
-			}
-			answerFilePath = filePath
+			answerFile = answerFiles[i]
 		}
 
 		payload.Answers = append(payload.Answers, request.AnswerRequest{
@@ -126,7 +118,7 @@ func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponses(ctx *gin.Context)
 			SurveyTemplateID: jobPostingID,
 			EmployeeTaskID:   userProfileID,
 			Answer:           answer,
-			AnswerPath:       answerFilePath,
+			AnswerFile:       answerFile,
 		})
 	}
 
@@ -136,6 +128,34 @@ func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponses(ctx *gin.Context)
 		return
 	}
 
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateOrUpdateSurveyResponses] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	payload.Actor = actor
+
+	// the files are only kept once the answers pass the rules of the question
+	if err := h.UseCase.ValidateSurveyResponses(&payload); err != nil {
+		h.Log.Errorf("Error when validating answers: %v", err)
+		h.answerValidationErrorResponse(ctx, err)
+		return
+	}
+
+	for i, answer := range payload.Answers {
+		if answer.AnswerFile == nil {
+			continue
+		}
+		filePath, err := h.saveAnswerFile(ctx, answer.AnswerFile)
+		if err != nil {
+			h.Log.Error("Failed to save answer file: ", err)
+			utils.ErrorResponse(ctx, 500, "error", "Failed to save answer file")
+			return
+		}
+		payload.Answers[i].AnswerPath = filePath
+	}
+
 	h.Log.Infof("payload: %v", payload)
 
 	questionResponse, err := h.UseCase.CreateOrUpdateSurveyResponses(&payload)
@@ -165,16 +185,173 @@ func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponsesBulk(ctx *gin.Conte
 	surveyTemplateID := ctx.Request.FormValue("survey_template_id")
 	employeeTaskID := ctx.Request.FormValue("employee_task_id")
 	kanban := ctx.Request.FormValue("kanban")
-	answerIDs := ctx.PostFormArray("answers[id]")
-	questionIDs := ctx.PostFormArray("answers[question_id]")
-	answers := ctx.PostFormArray("answers[answer]")
 
 	// Process each answer
 	var payload request.SurveyResponseBulkRequest
 	payload.SurveyTemplateID = surveyTemplateID
 	payload.EmployeeTaskID = employeeTaskID
 	payload.Kanban = kanban
+	payload.Answers = h.bindAnswerBulkRequests(ctx)
+
+	if err := h.Validate.Struct(payload); err != nil {
+		h.Log.Errorf("Error when validating payload: %v", err)
+		utils.ErrorResponse(ctx, 400, "error", err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateOrUpdateSurveyResponsesBulk] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	payload.Actor = actor
+
+	if err := h.UseCase.ValidateSurveyResponsesBulk(&payload); err != nil {
+		h.Log.Errorf("Error when validating answers: %v", err)
+		h.answerValidationErrorResponse(ctx, err)
+		return
+	}
+
+	for i, answer := range payload.Answers {
+		if answer.AnswerFile == nil {
+			continue
+		}
+		filePath, err := h.saveAnswerFile(ctx, answer.AnswerFile)
+		if err != nil {
+			h.Log.Error("Failed to save answer file: ", err)
+			utils.ErrorResponse(ctx, 500, "error", "Failed to save answer file")
+			return
+		}
+		payload.Answers[i].AnswerPath = filePath
+
+		h.Log.Infof("answer file path: %v", filePath)
+	}
+
+	h.Log.Infof("payload: %v", payload)
+
+	questionResponse, err := h.UseCase.CreateOrUpdateSurveyResponsesBulk(&payload)
+	if err != nil {
+		h.Log.Errorf("Error when creating or updating question responses: %v", err)
+		utils.ErrorResponse(ctx, 500, "error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, 201, "success answer question", questionResponse)
+}
+
+// CreateOrUpdateSurveyDistributionResponses answer a survey distribution
+//
+// @Summary Answer survey distribution
+// @Description Save the answers of the logged in employee to a survey distribution sent to them, while it is open. submit=true hands the survey in and needs every required question answered
+// @Tags Survey Responses
+// @Accept multipart/form-data
+// @Produce json
+// @Param survey_distribution_id formData string true "Survey Distribution ID"
+// @Param submit formData bool false "Submit"
+// @Success 201 {object} response.SurveyTemplateResponse
+// @Security BearerAuth
+// @Router /survey-responses/distribution [post]
+func (h *SurveyResponseHandler) CreateOrUpdateSurveyDistributionResponses(ctx *gin.Context) {
+	if err := ctx.Request.ParseMultipartForm(10 << 20); err != nil { // 10MB limit
+		h.Log.Error("Failed to parse form-data: ", err)
+		utils.BadRequestResponse(ctx, "bad request", err.Error())
+		return
+	}
+
+	submit, _ := strconv.ParseBool(ctx.Request.FormValue("submit"))
+	payload := request.SurveyDistributionResponseRequest{
+		SurveyDistributionID: ctx.Request.FormValue("survey_distribution_id"),
+		Submit:               submit,
+		Answers:              h.bindAnswerBulkRequests(ctx),
+	}
+
+	if err := h.Validate.Struct(payload); err != nil {
+		h.Log.Errorf("Error when validating payload: %v", err)
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateOrUpdateSurveyDistributionResponses] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	payload.Actor = actor
+
+	if err := h.UseCase.ValidateSurveyDistributionResponses(&payload); err != nil {
+		h.Log.Errorf("Error when validating answers: %v", err)
+		h.answerValidationErrorResponse(ctx, err)
+		return
+	}
+
+	for i, answer := range payload.Answers {
+		if answer.AnswerFile == nil {
+			continue
+		}
+		filePath, err := h.saveAnswerFile(ctx, answer.AnswerFile)
+		if err != nil {
+			h.Log.Error("Failed to save answer file: ", err)
+			utils.ErrorResponse(ctx, http.StatusInternalServerError, "error", "Failed to save answer file")
+			return
+		}
+		payload.Answers[i].AnswerPath = filePath
+	}
+
+	res, err := h.UseCase.CreateOrUpdateSurveyDistributionResponses(&payload)
+	if err != nil {
+		h.Log.Errorf("Error when creating or updating survey distribution responses: %v", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success answer survey distribution", res)
+}
+
+// FindSurveyDistributionResponses find the answers to a survey distribution
+//
+// @Summary Find my answers to a survey distribution
+// @Description The survey template of a survey distribution sent to the logged in employee with their answers
+// @Tags Survey Responses
+// @Accept json
+// @Produce json
+// @Param survey_distribution_id path string true "Survey Distribution ID"
+// @Success 200 {object} response.SurveyTemplateResponse
+// @Security BearerAuth
+// @Router /survey-responses/distribution/{survey_distribution_id} [get]
+func (h *SurveyResponseHandler) FindSurveyDistributionResponses(ctx *gin.Context) {
+	surveyDistributionID, err := uuid.Parse(ctx.Param("survey_distribution_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid survey_distribution_id", "invalid survey_distribution_id")
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindSurveyDistributionResponses] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
 
+	res, err := h.UseCase.FindSurveyDistributionResponses(surveyDistributionID, actor.EmployeeID)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindSurveyDistributionResponses] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey distribution responses", res)
+}
+
+// bindAnswerBulkRequests reads the answers of a multipart form, the files of the answers are
+// sent as answers[i][answer_file].
+func (h *SurveyResponseHandler) bindAnswerBulkRequests(ctx *gin.Context) []request.AnswerBulkRequest {
+	answerIDs := ctx.PostFormArray("answers[id]")
+	questionIDs := ctx.PostFormArray("answers[question_id]")
+	answers := ctx.PostFormArray("answers[answer]")
+
+	var answerRequests []request.AnswerBulkRequest
 	for i := range questionIDs {
 		questionID := questionIDs[i]
 
@@ -195,173 +372,307 @@ func (h *SurveyResponseHandler) CreateOrUpdateSurveyResponsesBulk(ctx *gin.Conte
 		}
 
 		// Get the answer file if available
-		var answerFilePath string
+		var answerFile *multipart.FileHeader
 		fileKey := fmt.Sprintf("answers[%d][answer_file]", i) // Dynamically construct the file key
 		fileHeaders := ctx.Request.MultipartForm.File[fileKey]
 		if len(fileHeaders) > 0 && fileHeaders[0] != nil {
-			file := fileHeaders[0]
-			timestamp := time.Now().UnixNano()
-			filePath := "storage/answers/files/" + strconv.FormatInt(timestamp, 10) + "_" + file.Filename
-			if err := ctx.SaveUploadedFile(file, filePath); err != nil {
-				h.Log.Error("Failed to save answer file: ", err)
-				utils.ErrorResponse(ctx, 500, "error", "Failed to save answer file")
-				return
-			}
-			answerFilePath = filePath
-
-			h.Log.Infof("answer file path: %v", answerFilePath)
+			answerFile = fileHeaders[0]
 		}
 
-		// Append the answer to the payload
-		payload.Answers = append(payload.Answers, request.AnswerBulkRequest{
+		// Append the answer
+		answerRequests = append(answerRequests, request.AnswerBulkRequest{
 			ID:         answerID,
 			QuestionID: questionID,
 			Answer:     answer,
-			AnswerPath: answerFilePath,
+			AnswerFile: answerFile,
 		})
 	}
 
-	if err := h.Validate.Struct(payload); err != nil {
-		h.Log.Errorf("Error when validating payload: %v", err)
-		utils.ErrorResponse(ctx, 400, "error", err.Error())
+	return answerRequests
+}
+
+func (h *SurveyResponseHandler) saveAnswerFile(ctx *gin.Context, file *multipart.FileHeader) (string, error) {
+	timestamp := time.Now().UnixNano()
+	filePath := "storage/answers/files/" + strconv.FormatInt(timestamp, 10) + "_" + file.Filename
+	if err := ctx.SaveUploadedFile(file, filePath); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// answerValidationErrorResponse answers 422 with the field errors when the answers broke the
+// rules of their questions.
+func (h *SurveyResponseHandler) answerValidationErrorResponse(ctx *gin.Context, err error) {
+	if validationErr, ok := service.IsSurveyAnswerValidationError(err); ok {
+		utils.FormatResponse(ctx, http.StatusUnprocessableEntity, "error", "invalid answers", validationErr.Errors)
+		return
+	}
+	if err.Error() == "question not found" || err.Error() == "survey template not found" {
+		utils.ErrorResponse(ctx, http.StatusNotFound, "error", err.Error())
 		return
 	}
+	utils.ErrorResponse(ctx, http.StatusInternalServerError, "error", err.Error())
+}
 
-	h.Log.Infof("payload: %v", payload)
+// ExportSurveyResponses export survey responses
+//
+// @Summary Export survey responses
+// @Description Stream the survey responses matching the filters as XLSX, CSV or JSON Lines, a row per employee task. Exports with more employee tasks than the sync limit are rejected, create an export job for those
+// @Tags Survey Responses
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Format (XLSX, CSV, JSONL)"
+// @Param survey_template_id query string false "Survey Template ID"
+// @Param organization_id query string false "Organization ID"
+// @Param submitted_from query string false "Submitted From (YYYY-MM-DD)"
+// @Param submitted_to query string false "Submitted To (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Security BearerAuth
+// @Router /survey-responses/export [get]
+func (h *SurveyResponseHandler) ExportSurveyResponses(ctx *gin.Context) {
+	var req request.SurveyExportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.Log.Error("[SurveyResponseHandler.ExportSurveyResponses] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
 
-	questionResponse, err := h.UseCase.CreateOrUpdateSurveyResponsesBulk(&payload)
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyResponseHandler.ExportSurveyResponses] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.ExportUseCase.ValidateDirectExport(&req); err != nil {
+		h.Log.Error("[SurveyResponseHandler.ExportSurveyResponses] " + err.Error())
+		if errors.Is(err, usecase.ErrSurveyExportTooLarge) {
+			utils.BadRequestResponse(ctx, err.Error(), err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	format := entity.SurveyExportFormatEnum(req.Format)
+	ctx.Header("Content-Type", service.SurveyExportContentType(format))
+	ctx.Header("Content-Disposition", "attachment; filename=survey_responses"+service.SurveyExportExtension(format))
+	ctx.Header("Content-Transfer-Encoding", "binary")
+
+	// the body is streamed, once it started an error can only be logged
+	if err := h.ExportUseCase.ExportSurveyResponses(&req, ctx.Writer); err != nil {
+		h.Log.Error("[SurveyResponseHandler.ExportSurveyResponses] " + err.Error())
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.Writer.Header().Del("Content-Transfer-Encoding")
+			utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to export survey responses", err.Error())
+		}
+		return
+	}
+}
+
+// CreateSurveyExportJob export survey responses in the background
+//
+// @Summary Create survey export job
+// @Description Write the survey responses matching the filters to a file in the background. The job reports its progress and a download url once completed
+// @Tags Survey Responses
+// @Accept json
+// @Produce json
+// @Param payload body request.SurveyExportRequest true "Survey Export"
+// @Success 202 {object} response.SurveyExportJobResponse
+// @Security BearerAuth
+// @Router /survey-responses/exports [post]
+func (h *SurveyResponseHandler) CreateSurveyExportJob(ctx *gin.Context) {
+	var req request.SurveyExportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateSurveyExportJob] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateSurveyExportJob] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
 	if err != nil {
-		h.Log.Errorf("Error when creating or updating question responses: %v", err)
-		utils.ErrorResponse(ctx, 500, "error", err.Error())
+		h.Log.Error("[SurveyResponseHandler.CreateSurveyExportJob] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
 		return
 	}
 
-	utils.SuccessResponse(ctx, 201, "success answer question", questionResponse)
+	res, err := h.ExportUseCase.CreateSurveyExportJob(&req, actor)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.CreateSurveyExportJob] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusAccepted, "success create survey export job", res)
 }
 
-func (h *SurveyResponseHandler) ExportSurveyResponses(ctx *gin.Context) {
-	employeeTasks, err := h.EmployeeTaskUseCase.FindAllSurvey()
+// FindSurveyExportJobByID find survey export job by id
+//
+// @Summary Find survey export job by id
+// @Description Find the progress of a survey export job and its download url once completed
+// @Tags Survey Responses
+// @Produce json
+// @Param id path string true "Survey Export Job ID"
+// @Success 200 {object} response.SurveyExportJobResponse
+// @Security BearerAuth
+// @Router /survey-responses/exports/{id} [get]
+func (h *SurveyResponseHandler) FindSurveyExportJobByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
-		h.Log.Errorf("Error when getting employee tasks: %v", err)
-		utils.ErrorResponse(ctx, 500, "error", err.Error())
+		h.Log.Error("[SurveyResponseHandler.FindSurveyExportJobByID] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
 		return
 	}
 
-	if len(*employeeTasks) == 0 {
-		h.Log.Error("No employee tasks found")
-		utils.ErrorResponse(ctx, 404, "error", "No employee tasks found")
+	res, err := h.ExportUseCase.FindSurveyExportJobByID(id)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindSurveyExportJobByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
 		return
 	}
 
-	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
-			utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to export survey responses", err.Error())
-			return
-		}
-	}()
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey export job", res)
+}
 
-	f.SetSheetName("Sheet1", "Survey Responses")
+// FindAllSurveyExportJobsPaginated find all survey export jobs paginated
+//
+// @Summary Find all survey export jobs paginated
+// @Description Find all survey export jobs, the latest first
+// @Tags Survey Responses
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Success 200 {object} response.SurveyExportJobResponse
+// @Security BearerAuth
+// @Router /survey-responses/exports [get]
+func (h *SurveyResponseHandler) FindAllSurveyExportJobsPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
 
-	headers := []string{"Employee Task Name", "Employee Name", "Survey Name"}
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
 
-	var maxQuestions int
-	for _, employeeTask := range *employeeTasks {
-		if len(employeeTask.SurveyTemplate.Questions) > maxQuestions {
-			maxQuestions = len(employeeTask.SurveyTemplate.Questions)
-		}
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
 
-		for _, question := range employeeTask.SurveyTemplate.Questions {
-			if !contains(headers, question.Question) {
-				headers = append(headers, question.Question)
-			}
-		}
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	res, total, err := h.ExportUseCase.FindAllSurveyExportJobsPaginated(page, pageSize, sort)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindAllSurveyExportJobsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
 	}
-	// for i := 1; i <= maxQuestions; i++ {
-	// 	headers = append(headers, fmt.Sprintf("Question %d", i))
-	// }
 
-	// Define header style
-	headerStyle, err := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{
-			Bold:  true,
-			Size:  12,
-			Color: "#000000",
-		},
-		Fill: excelize.Fill{
-			Type:    "pattern",
-			Color:   []string{"#90EE90"}, // Light green background
-			Pattern: 1,
-		},
-		Alignment: &excelize.Alignment{
-			Horizontal: "center",
-			Vertical:   "center",
-		},
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey export jobs", gin.H{
+		"survey_export_jobs": res,
+		"total":              total,
 	})
+}
+
+// FindSurveyQuizResult find the quiz result of an employee task
+//
+// @Summary Find survey quiz result
+// @Description Every graded attempt of the employee task at its quiz survey template, with the score of each answer and the attempts left
+// @Tags Survey Responses
+// @Accept json
+// @Produce json
+// @Param employee_task_id query string true "Employee Task ID"
+// @Success 200 {object} response.SurveyQuizResultResponse
+// @Security BearerAuth
+// @Router /survey-responses/quiz-result [get]
+func (h *SurveyResponseHandler) FindSurveyQuizResult(ctx *gin.Context) {
+	employeeTaskID, err := uuid.Parse(ctx.Query("employee_task_id"))
 	if err != nil {
-		h.Log.Errorf("Error when creating header style: %v", err)
-		utils.ErrorResponse(ctx, 500, "error", err.Error())
+		utils.BadRequestResponse(ctx, "invalid employee_task_id", "invalid employee_task_id")
 		return
 	}
 
-	// Write headers to the Excel file
-	for i, header := range headers {
-		col := string(rune('A' + i))
-		cell := fmt.Sprintf("%s1", col)
-		f.SetCellValue("Survey Responses", cell, header)
-		f.SetCellStyle("Survey Responses", cell, cell, headerStyle)
+	res, err := h.UseCase.FindSurveyQuizResult(employeeTaskID)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindSurveyQuizResult] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
 	}
 
-	// Write data rows
-	for rowIndex, employeeTask := range *employeeTasks {
-		row := rowIndex + 2 // Start from the second row
-		f.SetCellValue("Survey Responses", fmt.Sprintf("A%d", row), employeeTask.EmployeeName)
-		f.SetCellValue("Survey Responses", fmt.Sprintf("B%d", row), employeeTask.SurveyTemplate.Title)
+	utils.SuccessResponse(ctx, http.StatusOK, "success find survey quiz result", res)
+}
 
-		// Fetch survey responses
-		surveyResponses, err := h.EmployeeTaskUseCase.FindByIDForResponse(employeeTask.ID.String())
+// FindAllSurveyQuizAttemptsPaginated find all attempts at a quiz survey template paginated
+//
+// @Summary Find all survey quiz attempts paginated
+// @Description Graded attempts of every employee at a quiz survey template, optionally only the passed or failed ones
+// @Tags Survey Responses
+// @Accept json
+// @Produce json
+// @Param survey_template_id query string true "Survey Template ID"
+// @Param passed query bool false "Passed"
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param submitted_at query string false "Submitted At"
+// @Success 200 {object} response.SurveyQuizAttemptResponse
+// @Security BearerAuth
+// @Router /survey-responses/quiz-attempts [get]
+func (h *SurveyResponseHandler) FindAllSurveyQuizAttemptsPaginated(ctx *gin.Context) {
+	surveyTemplateID, err := uuid.Parse(ctx.Query("survey_template_id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid survey_template_id", "invalid survey_template_id")
+		return
+	}
+
+	var passed *bool
+	if ctx.Query("passed") != "" {
+		parsedPassed, err := strconv.ParseBool(ctx.Query("passed"))
 		if err != nil {
-			h.Log.Errorf("Error when getting survey responses: %v", err)
-			utils.ErrorResponse(ctx, 500, "error", err.Error())
+			utils.BadRequestResponse(ctx, "invalid passed", "invalid passed")
 			return
 		}
+		passed = &parsedPassed
+	}
 
-		// Write answers to the corresponding question columns
-		for questionIndex, question := range surveyResponses.SurveyTemplate.Questions {
-			col := string(rune('C' + questionIndex))
-			var concatenatedValue string
-
-			if len(question.SurveyResponses) > 0 {
-				for _, answer := range question.SurveyResponses {
-					if concatenatedValue != "" {
-						concatenatedValue += ", "
-					}
-					if answer.AnswerFile == "" {
-						concatenatedValue += answer.Answer
-					} else {
-						concatenatedValue += h.Viper.GetString("app.url") + answer.AnswerFile
-					}
-				}
-			}
-
-			f.SetCellValue("Survey Responses", fmt.Sprintf("%s%d", col, row), concatenatedValue)
-		}
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
 	}
 
-	// Set column widths for better readability
-	f.SetColWidth("Survey Responses", "A", "C", 20)
-	f.SetColWidth("Survey Responses", "D", string(rune('C'+maxQuestions-1)), 30)
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
 
-	// Set response headers for file download
-	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	ctx.Header("Content-Disposition", "attachment; filename=survey_responses.xlsx")
-	ctx.Header("Content-Transfer-Encoding", "binary")
+	submittedAt := ctx.Query("submitted_at")
+	if submittedAt == "" {
+		submittedAt = "DESC"
+	}
 
-	// Write the Excel file to the response
-	if err := f.Write(ctx.Writer); err != nil {
-		fmt.Println(err)
-		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to export survey responses", err.Error())
+	sort := map[string]interface{}{
+		"submitted_at": submittedAt,
+	}
+
+	res, total, err := h.UseCase.FindAllSurveyQuizAttemptsPaginated(page, pageSize, surveyTemplateID, passed, sort)
+	if err != nil {
+		h.Log.Error("[SurveyResponseHandler.FindAllSurveyQuizAttemptsPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
 		return
 	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all survey quiz attempts", gin.H{
+		"quiz_attempts": res,
+		"total":         total,
+	})
 }
@@ -21,6 +21,10 @@ type ISurveyTemplateHandler interface {
 	FindAllSurveyTemplatesPaginated(ctx *gin.Context)
 	FindSurveyTemplateByID(ctx *gin.Context)
 	DeleteSurveyTemplate(ctx *gin.Context)
+	FindSurveyAnalytics(ctx *gin.Context)
+	PublishSurveyTemplate(ctx *gin.Context)
+	FindAllSurveyTemplateVersions(ctx *gin.Context)
+	FindSurveyVersionAnalytics(ctx *gin.Context)
 }
 
 type SurveyTemplateHandler struct {
@@ -30,6 +34,7 @@ type SurveyTemplateHandler struct {
 	UseCase           usecase.ISurveyTemplateUseCase
 	QuestionUseCase   usecase.IQuestionUseCase
 	AnswerTypeUseCase usecase.IAnswerTypeUseCase
+	AnalyticsUseCase  usecase.ISurveyAnalyticsUseCase
 	DB                *gorm.DB
 }
 
@@ -40,6 +45,7 @@ func NewSurveyTemplateHandler(
 	useCase usecase.ISurveyTemplateUseCase,
 	questionUseCase usecase.IQuestionUseCase,
 	answerTypeUseCase usecase.IAnswerTypeUseCase,
+	analyticsUseCase usecase.ISurveyAnalyticsUseCase,
 	db *gorm.DB) ISurveyTemplateHandler {
 	return &SurveyTemplateHandler{
 		Log:               log,
@@ -48,6 +54,7 @@ func NewSurveyTemplateHandler(
 		UseCase:           useCase,
 		QuestionUseCase:   questionUseCase,
 		AnswerTypeUseCase: answerTypeUseCase,
+		AnalyticsUseCase:  analyticsUseCase,
 		DB:                db,
 	}
 }
@@ -59,6 +66,7 @@ func SurveyTemplateHandlerFactory(
 	useCase := usecase.SurveyTemplateUseCaseFactory(log, viper)
 	questionUseCase := usecase.QuestionUseCaseFactory(log, viper)
 	answerTypeUseCase := usecase.AnswerTypeUseCaseFactory(log)
+	analyticsUseCase := usecase.SurveyAnalyticsUseCaseFactory(log, viper)
 	validate := config.NewValidator(viper)
 	db := config.NewDatabase()
 	return NewSurveyTemplateHandler(
@@ -68,6 +76,7 @@ func SurveyTemplateHandlerFactory(
 		useCase,
 		questionUseCase,
 		answerTypeUseCase,
+		analyticsUseCase,
 		db,
 	)
 }
@@ -344,3 +353,139 @@ func (h *SurveyTemplateHandler) DeleteSurveyTemplate(ctx *gin.Context) {
 
 	utils.SuccessResponse(ctx, http.StatusOK, "success", nil)
 }
+
+// FindSurveyAnalytics aggregate the answers to a survey template
+//
+// @Summary Find survey template analytics
+// @Description Option distributions of choice questions, mean, median, histogram and NPS of rating questions and response rates, optionally only for an organization, a join date cohort and a submitted date range
+// @Tags Survey Templates
+// @Produce json
+// @Param id path string true "Survey Template ID"
+// @Param organization_id query string false "Organization ID"
+// @Param joined_from query string false "Joined From (YYYY-MM-DD)"
+// @Param joined_to query string false "Joined To (YYYY-MM-DD)"
+// @Param submitted_from query string false "Submitted From (YYYY-MM-DD)"
+// @Param submitted_to query string false "Submitted To (YYYY-MM-DD)"
+// @Success 200 {object} response.SurveyAnalyticsResponse
+// @Security BearerAuth
+// @Router /survey-templates/{id}/analytics [get]
+func (h *SurveyTemplateHandler) FindSurveyAnalytics(ctx *gin.Context) {
+	var req request.SurveyAnalyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyAnalytics] Error when binding request: ", err)
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+	req.SurveyTemplateID = ctx.Param("id")
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyAnalytics] Error when validating request: ", err)
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.AnalyticsUseCase.FindSurveyAnalytics(&req)
+	if err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyAnalytics] Error when finding survey analytics: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to find survey analytics", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success", res)
+}
+
+// PublishSurveyTemplate publish a draft version of a survey template
+//
+// @Summary Publish survey template version
+// @Description Publish a draft version so that new employee tasks are given it, the responses to earlier versions stay with them
+// @Tags Survey Templates
+// @Produce json
+// @Param id path string true "Survey Template ID"
+// @Success 200 {object} response.SurveyTemplateResponse
+// @Security BearerAuth
+// @Router /survey-templates/{id}/publish [post]
+func (h *SurveyTemplateHandler) PublishSurveyTemplate(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		h.Log.Error("[SurveyTemplateHandler.PublishSurveyTemplate] Error when getting id from url param: id is empty")
+		utils.BadRequestResponse(ctx, "id is required", "id is required")
+		return
+	}
+
+	res, err := h.UseCase.PublishSurveyTemplate(id)
+	if err != nil {
+		h.Log.Error("[SurveyTemplateHandler.PublishSurveyTemplate] Error when publishing survey template: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to publish survey template", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success", res)
+}
+
+// FindAllSurveyTemplateVersions list the versions of a survey template
+//
+// @Summary Find survey template versions
+// @Description Every version of the survey of a survey template, from the first
+// @Tags Survey Templates
+// @Produce json
+// @Param id path string true "Survey Template ID"
+// @Success 200 {array} response.SurveyTemplateResponse
+// @Security BearerAuth
+// @Router /survey-templates/{id}/versions [get]
+func (h *SurveyTemplateHandler) FindAllSurveyTemplateVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		h.Log.Error("[SurveyTemplateHandler.FindAllSurveyTemplateVersions] Error when getting id from url param: id is empty")
+		utils.BadRequestResponse(ctx, "id is required", "id is required")
+		return
+	}
+
+	res, err := h.UseCase.FindAllSurveyTemplateVersions(id)
+	if err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindAllSurveyTemplateVersions] Error when finding survey template versions: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to find survey template versions", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success", res)
+}
+
+// FindSurveyVersionAnalytics compare the versions of a survey template
+//
+// @Summary Find survey template version analytics
+// @Description Response rates and means of every version of a survey template, with the questions lined up by their text
+// @Tags Survey Templates
+// @Produce json
+// @Param id path string true "Survey Template ID"
+// @Param organization_id query string false "Organization ID"
+// @Param joined_from query string false "Joined From (YYYY-MM-DD)"
+// @Param joined_to query string false "Joined To (YYYY-MM-DD)"
+// @Param submitted_from query string false "Submitted From (YYYY-MM-DD)"
+// @Param submitted_to query string false "Submitted To (YYYY-MM-DD)"
+// @Success 200 {object} response.SurveyVersionAnalyticsResponse
+// @Security BearerAuth
+// @Router /survey-templates/{id}/analytics/versions [get]
+func (h *SurveyTemplateHandler) FindSurveyVersionAnalytics(ctx *gin.Context) {
+	var req request.SurveyAnalyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyVersionAnalytics] Error when binding request: ", err)
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+	req.SurveyTemplateID = ctx.Param("id")
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyVersionAnalytics] Error when validating request: ", err)
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.AnalyticsUseCase.FindSurveyVersionAnalytics(&req)
+	if err != nil {
+		h.Log.Error("[SurveyTemplateHandler.FindSurveyVersionAnalytics] Error when finding survey version analytics: ", err)
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "failed to find survey version analytics", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success", res)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ITemplateBundleHandler interface {
+	ExportTemplateBundle(ctx *gin.Context)
+	ImportTemplateBundle(ctx *gin.Context)
+}
+
+type TemplateBundleHandler struct {
+	Log      *logrus.Logger
+	Viper    *viper.Viper
+	Validate *validator.Validate
+	UseCase  usecase.ITemplateBundleUseCase
+}
+
+func NewTemplateBundleHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.ITemplateBundleUseCase,
+) ITemplateBundleHandler {
+	return &TemplateBundleHandler{
+		Log:      log,
+		Viper:    viper,
+		Validate: validate,
+		UseCase:  useCase,
+	}
+}
+
+func TemplateBundleHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) ITemplateBundleHandler {
+	useCase := usecase.TemplateBundleUseCaseFactory(log, viper)
+	validate := config.NewValidator(viper)
+	return NewTemplateBundleHandler(log, viper, validate, useCase)
+}
+
+// ExportTemplateBundle export template tasks and survey templates as a bundle
+//
+// @Summary Export template bundle
+// @Description Export template tasks, their checklists, attachments, rules and linked survey templates as a JSON document, or as a ZIP archive that also holds the stored files
+// @Tags Template Bundles
+// @Accept json
+// @Produce json
+// @Produce application/zip
+// @Param body body request.ExportTemplateBundleRequest true "Export Template Bundle"
+// @Success 200 {object} response.TemplateBundleResponse
+// @Security BearerAuth
+// @Router /template-bundles/export [post]
+func (h *TemplateBundleHandler) ExportTemplateBundle(ctx *gin.Context) {
+	var req request.ExportTemplateBundleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[TemplateBundleHandler.ExportTemplateBundle] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[TemplateBundleHandler.ExportTemplateBundle] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	bundle, err := h.UseCase.ExportTemplateBundle(&req)
+	if err != nil {
+		h.Log.Error("[TemplateBundleHandler.ExportTemplateBundle] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	fileName := "template-bundle-" + strconv.FormatInt(time.Now().Unix(), 10)
+	if req.Format == "zip" {
+		archive, err := h.UseCase.WriteTemplateBundleArchive(bundle)
+		if err != nil {
+			h.Log.Error("[TemplateBundleHandler.ExportTemplateBundle] " + err.Error())
+			utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+			return
+		}
+
+		ctx.Header("Content-Disposition", "attachment; filename="+fileName+".zip")
+		ctx.Data(http.StatusOK, "application/zip", archive)
+		return
+	}
+
+	encoded, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		h.Log.Error("[TemplateBundleHandler.ExportTemplateBundle] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename="+fileName+".json")
+	ctx.Data(http.StatusOK, "application/json", encoded)
+}
+
+// ImportTemplateBundle import a template bundle
+//
+// @Summary Import template bundle
+// @Description Import a JSON or ZIP bundle made by the export. Records get new ids; conflict_strategy SKIP (default) keeps existing records with the same name, CREATE_NEW creates them anyway. With dry_run nothing is written
+// @Tags Template Bundles
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Bundle File"
+// @Param dry_run formData bool false "Dry Run"
+// @Param conflict_strategy formData string false "SKIP or CREATE_NEW"
+// @Success 200 {object} response.TemplateBundleImportResponse
+// @Security BearerAuth
+// @Router /template-bundles/import [post]
+func (h *TemplateBundleHandler) ImportTemplateBundle(ctx *gin.Context) {
+	var req request.ImportTemplateBundleRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		h.Log.Error("[TemplateBundleHandler.ImportTemplateBundle] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[TemplateBundleHandler.ImportTemplateBundle] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ImportTemplateBundle(&req)
+	if err != nil {
+		h.Log.Error("[TemplateBundleHandler.ImportTemplateBundle] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	if len(res.Errors) > 0 && !req.DryRun {
+		utils.BadRequestResponse(ctx, "template bundle has errors, nothing was imported", res)
+		return
+	}
+
+	if req.DryRun {
+		utils.SuccessResponse(ctx, http.StatusOK, "success check template bundle", res)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success import template bundle", res)
+}
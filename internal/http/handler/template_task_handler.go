@@ -24,6 +24,13 @@ type ITemplateTaskHandler interface {
 	DeleteTemplateTask(ctx *gin.Context)
 	FindByID(ctx *gin.Context)
 	FindAllPaginated(ctx *gin.Context)
+	ReplaceTemplateTaskRules(ctx *gin.Context)
+	ReplaceTemplateTaskApprovalSteps(ctx *gin.Context)
+	FindAllVersions(ctx *gin.Context)
+	FindVersionByID(ctx *gin.Context)
+	DiffVersions(ctx *gin.Context)
+	ImportTemplateTasks(ctx *gin.Context)
+	DownloadImportTemplate(ctx *gin.Context)
 }
 
 type TemplateTaskHandler struct {
@@ -104,6 +111,10 @@ func (h *TemplateTaskHandler) CreateTemplateTask(ctx *gin.Context) {
 	files := form.File["template_task_attachments[file]"]          // Matches form-data key from Postman
 	checklistNames := form.Value["template_task_checklists[name]"] // Matches form-data key from Postman
 	checklistIds := form.Value["template_task_checklists[id]"]     // Matches form-data key from Postman
+	checklistSortOrders := form.Value["template_task_checklists[sort_order]"]
+	checklistAssigneeIDs := form.Value["template_task_checklists[assignee_id]"]
+	checklistDueOffsetDays := form.Value["template_task_checklists[due_offset_days]"]
+	checklistIsRequireds := form.Value["template_task_checklists[is_required]"]
 
 	if len(files) > 0 {
 		for _, file := range files {
@@ -131,9 +142,24 @@ func (h *TemplateTaskHandler) CreateTemplateTask(ctx *gin.Context) {
 				checklistId = nil
 			}
 
+			sortOrder, err := checklistFormIntValue(checklistSortOrders, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid template_task_checklists[sort_order]", err.Error())
+				return
+			}
+			dueOffsetDays, err := checklistFormIntValue(checklistDueOffsetDays, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid template_task_checklists[due_offset_days]", err.Error())
+				return
+			}
+
 			req.TemplateTaskChecklists = append(req.TemplateTaskChecklists, request.TemplateTaskChecklistRequest{
-				ID:   checklistId,
-				Name: name,
+				ID:            checklistId,
+				Name:          name,
+				SortOrder:     sortOrder,
+				AssigneeID:    checklistFormValue(checklistAssigneeIDs, i),
+				DueOffsetDays: dueOffsetDays,
+				IsRequired:    checklistFormValue(checklistIsRequireds, i),
 			})
 		}
 	}
@@ -207,6 +233,10 @@ func (h *TemplateTaskHandler) UpdateTemplateTask(ctx *gin.Context) {
 	files := form.File["template_task_attachments[file]"]          // Matches form-data key from Postman
 	checklistNames := form.Value["template_task_checklists[name]"] // Matches form-data key from Postman
 	checklistIds := form.Value["template_task_checklists[id]"]     // Matches form-data key from Postman
+	checklistSortOrders := form.Value["template_task_checklists[sort_order]"]
+	checklistAssigneeIDs := form.Value["template_task_checklists[assignee_id]"]
+	checklistDueOffsetDays := form.Value["template_task_checklists[due_offset_days]"]
+	checklistIsRequireds := form.Value["template_task_checklists[is_required]"]
 
 	if len(files) > 0 {
 		for _, file := range files {
@@ -234,9 +264,24 @@ func (h *TemplateTaskHandler) UpdateTemplateTask(ctx *gin.Context) {
 				checklistId = nil
 			}
 
+			sortOrder, err := checklistFormIntValue(checklistSortOrders, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid template_task_checklists[sort_order]", err.Error())
+				return
+			}
+			dueOffsetDays, err := checklistFormIntValue(checklistDueOffsetDays, i)
+			if err != nil {
+				utils.BadRequestResponse(ctx, "invalid template_task_checklists[due_offset_days]", err.Error())
+				return
+			}
+
 			req.TemplateTaskChecklists = append(req.TemplateTaskChecklists, request.TemplateTaskChecklistRequest{
-				ID:   checklistId,
-				Name: name,
+				ID:            checklistId,
+				Name:          name,
+				SortOrder:     sortOrder,
+				AssigneeID:    checklistFormValue(checklistAssigneeIDs, i),
+				DueOffsetDays: dueOffsetDays,
+				IsRequired:    checklistFormValue(checklistIsRequireds, i),
 			})
 		}
 	}
@@ -348,6 +393,8 @@ func (h *TemplateTaskHandler) FindByID(ctx *gin.Context) {
 // @Param page_size query int false "Page Size"
 // @Param search query string false "Search"
 // @Param created_at query string false "Created At"
+// @Param status query string false "Status"
+// @Param source query string false "ONBOARDING or OFFBOARDING"
 // @Success 200 {object} response.TemplateTaskResponse
 // @Security BearerAuth
 // @Router /template-tasks [get]
@@ -377,10 +424,12 @@ func (h *TemplateTaskHandler) FindAllPaginated(ctx *gin.Context) {
 		status = ""
 	}
 
+	source := ctx.Query("source")
+
 	sort := map[string]interface{}{
 		"created_at": createdAt,
 	}
-	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, search, sort, entity.TemplateTaskStatusEnum(status))
+	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, search, sort, entity.TemplateTaskStatusEnum(status), source)
 	if err != nil {
 		h.Log.Error("[TemplateTaskHandler.FindAllPaginated] " + err.Error())
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
@@ -394,3 +443,239 @@ func (h *TemplateTaskHandler) FindAllPaginated(ctx *gin.Context) {
 		},
 	})
 }
+
+// ReplaceTemplateTaskRules replace the targeting rules of a template task
+//
+// @Summary Replace template task rules
+// @Description Replace the targeting rules of a template task. Rules with the same group_number are combined with AND, groups are combined with OR
+// @Tags Template Tasks
+// @Accept json
+// @Produce json
+// @Param body body request.ReplaceTemplateTaskRulesRequest true "Template Task Rules"
+// @Success 200 {object} response.TemplateTaskResponse
+// @Security BearerAuth
+// @Router /template-tasks/rules [put]
+func (h *TemplateTaskHandler) ReplaceTemplateTaskRules(ctx *gin.Context) {
+	var req request.ReplaceTemplateTaskRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskRules] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskRules] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ReplaceTemplateTaskRules(&req)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskRules] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success replace template task rules", res)
+}
+
+// ReplaceTemplateTaskApprovalSteps replace the approval chain of a template task
+//
+// @Summary Replace template task approval steps
+// @Description Replace the sequential approval chain of a template task. Steps are approved in the given order; DIRECT_MANAGER, HR and DEPARTMENT_HEAD are resolved from the organization structure, EMPLOYEE steps name the approver
+// @Tags Template Tasks
+// @Accept json
+// @Produce json
+// @Param body body request.ReplaceTemplateTaskApprovalStepsRequest true "Template Task Approval Steps"
+// @Success 200 {object} response.TemplateTaskResponse
+// @Security BearerAuth
+// @Router /template-tasks/approval-steps [put]
+func (h *TemplateTaskHandler) ReplaceTemplateTaskApprovalSteps(ctx *gin.Context) {
+	var req request.ReplaceTemplateTaskApprovalStepsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ReplaceTemplateTaskApprovalSteps(&req)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success replace template task approval steps", res)
+}
+
+// FindAllVersions find all versions of a template task
+//
+// @Summary Find all versions of a template task
+// @Description Find all versions of a template task, latest first
+// @Tags Template Tasks
+// @Produce json
+// @Param id path string true "Template Task ID"
+// @Success 200 {object} response.TemplateTaskVersionResponse
+// @Security BearerAuth
+// @Router /template-tasks/{id}/versions [get]
+func (h *TemplateTaskHandler) FindAllVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	parsedId, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.FindAllVersions] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.FindAllVersionsByTemplateTaskID(parsedId)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.FindAllVersions] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success get template task versions", res)
+}
+
+// FindVersionByID find template task version by id
+//
+// @Summary Find template task version by id
+// @Description Find template task version by id, including the template task snapshot
+// @Tags Template Tasks
+// @Produce json
+// @Param version_id path string true "Template Task Version ID"
+// @Success 200 {object} response.TemplateTaskVersionResponse
+// @Security BearerAuth
+// @Router /template-tasks/versions/{version_id} [get]
+func (h *TemplateTaskHandler) FindVersionByID(ctx *gin.Context) {
+	id := ctx.Param("version_id")
+	parsedId, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.FindVersionByID] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.FindVersionByID(parsedId)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.FindVersionByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success get template task version", res)
+}
+
+// DiffVersions compare two versions of a template task
+//
+// @Summary Compare two versions of a template task
+// @Description Compare two versions of a template task. Without to the latest version is used, without from the version before to
+// @Tags Template Tasks
+// @Produce json
+// @Param id path string true "Template Task ID"
+// @Param from query int false "From Version Number"
+// @Param to query int false "To Version Number"
+// @Success 200 {object} response.TemplateTaskVersionDiffResponse
+// @Security BearerAuth
+// @Router /template-tasks/{id}/versions/diff [get]
+func (h *TemplateTaskHandler) DiffVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	parsedId, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.DiffVersions] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	var fromVersion, toVersion int
+	if from := ctx.Query("from"); from != "" {
+		fromVersion, err = strconv.Atoi(from)
+		if err != nil || fromVersion < 1 {
+			utils.BadRequestResponse(ctx, "invalid from version", "from must be a positive version number")
+			return
+		}
+	}
+	if to := ctx.Query("to"); to != "" {
+		toVersion, err = strconv.Atoi(to)
+		if err != nil || toVersion < 1 {
+			utils.BadRequestResponse(ctx, "invalid to version", "to must be a positive version number")
+			return
+		}
+	}
+
+	res, err := h.UseCase.DiffVersions(parsedId, fromVersion, toVersion)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.DiffVersions] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success compare template task versions", res)
+}
+
+// ImportTemplateTasks import template tasks from a spreadsheet
+//
+// @Summary Import template tasks from a spreadsheet
+// @Description Create template tasks and checklists from an XLSX workbook with a Tasks sheet (Key, Name, Description, Priority, Due Duration, Status, Organization Type, Survey Template, Cover Path) and a Checklists sheet (Task Key, Checklist Name). Nothing is created when a row is invalid; error_report_url then points to a copy of the workbook with the errors marked
+// @Tags Template Tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "XLSX Workbook"
+// @Success 201 {object} response.ImportTemplateTaskResponse
+// @Security BearerAuth
+// @Router /template-tasks/import [post]
+func (h *TemplateTaskHandler) ImportTemplateTasks(ctx *gin.Context) {
+	var req request.ImportTemplateTasksRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ImportTemplateTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[TemplateTaskHandler.ImportTemplateTasks] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	res, err := h.UseCase.ImportTemplateTasks(&req)
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.ImportTemplateTasks] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	if len(res.Errors) > 0 {
+		utils.BadRequestResponse(ctx, "spreadsheet has errors, nothing was imported", res)
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success import template tasks", res)
+}
+
+// DownloadImportTemplate download the template task import spreadsheet
+//
+// @Summary Download the template task import spreadsheet
+// @Description Download an empty XLSX workbook with the sheets and columns expected by the import
+// @Tags Template Tasks
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file
+// @Security BearerAuth
+// @Router /template-tasks/import/template [get]
+func (h *TemplateTaskHandler) DownloadImportTemplate(ctx *gin.Context) {
+	res, err := h.UseCase.WriteTemplateTaskImportTemplate()
+	if err != nil {
+		h.Log.Error("[TemplateTaskHandler.DownloadImportTemplate] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=template_task_import.xlsx")
+	ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", res)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/helper"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IVerifierDelegationHandler interface {
+	CreateVerifierDelegation(ctx *gin.Context)
+	RevokeVerifierDelegation(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+}
+
+type VerifierDelegationHandler struct {
+	Log        *logrus.Logger
+	Viper      *viper.Viper
+	Validate   *validator.Validate
+	UseCase    usecase.IVerifierDelegationUseCase
+	UserHelper helper.IUserHelper
+}
+
+func NewVerifierDelegationHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.IVerifierDelegationUseCase,
+	userHelper helper.IUserHelper,
+) IVerifierDelegationHandler {
+	return &VerifierDelegationHandler{
+		Log:        log,
+		Viper:      viper,
+		Validate:   validate,
+		UseCase:    useCase,
+		UserHelper: userHelper,
+	}
+}
+
+func VerifierDelegationHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) IVerifierDelegationHandler {
+	useCase := usecase.VerifierDelegationUseCaseFactory(log, viper)
+	validate := config.NewValidator(viper)
+	userHelper := helper.UserHelperFactory(log)
+	return NewVerifierDelegationHandler(log, viper, validate, useCase, userHelper)
+}
+
+// CreateVerifierDelegation delegate verification to another employee
+//
+// @Summary Create verifier delegation
+// @Description Let another employee verify the tasks of the verifier from start_date to end_date. Only the verifier or an admin may delegate
+// @Tags Verifier Delegations
+// @Accept json
+// @Produce json
+// @Param body body request.CreateVerifierDelegationRequest true "Create Verifier Delegation"
+// @Success 201 {object} response.VerifierDelegationResponse
+// @Security BearerAuth
+// @Router /verifier-delegations [post]
+func (h *VerifierDelegationHandler) CreateVerifierDelegation(ctx *gin.Context) {
+	var req request.CreateVerifierDelegationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[VerifierDelegationHandler.CreateVerifierDelegation] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[VerifierDelegationHandler.CreateVerifierDelegation] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.CreateVerifierDelegation] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.CreateVerifierDelegation(&req)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.CreateVerifierDelegation] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "success create verifier delegation", res)
+}
+
+// RevokeVerifierDelegation end a verifier delegation early
+//
+// @Summary Revoke verifier delegation
+// @Description End a verifier delegation now. Only the verifier or an admin may revoke it
+// @Tags Verifier Delegations
+// @Accept json
+// @Produce json
+// @Param id path string true "Verifier Delegation ID"
+// @Success 200 {object} response.VerifierDelegationResponse
+// @Security BearerAuth
+// @Router /verifier-delegations/{id}/revoke [post]
+func (h *VerifierDelegationHandler) RevokeVerifierDelegation(ctx *gin.Context) {
+	req := request.RevokeVerifierDelegationRequest{
+		ID: ctx.Param("id"),
+	}
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[VerifierDelegationHandler.RevokeVerifierDelegation] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.RevokeVerifierDelegation] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+	req.Actor = actor
+
+	res, err := h.UseCase.RevokeVerifierDelegation(&req)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.RevokeVerifierDelegation] " + err.Error())
+		if errors.Is(err, usecase.ErrTaskActorForbidden) {
+			utils.ErrorResponse(ctx, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success revoke verifier delegation", res)
+}
+
+// FindAllPaginated find all verifier delegations paginated
+//
+// @Summary Find all verifier delegations paginated
+// @Description Admins see every delegation, optionally of one employee. Other employees only see the delegations they gave or received
+// @Tags Verifier Delegations
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param employee_id query string false "Employee ID"
+// @Param created_at query string false "Created At"
+// @Success 200 {object} response.VerifierDelegationResponse
+// @Security BearerAuth
+// @Router /verifier-delegations [get]
+func (h *VerifierDelegationHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	var employeeID *uuid.UUID
+	if ctx.Query("employee_id") != "" {
+		parsedEmployeeID, err := uuid.Parse(ctx.Query("employee_id"))
+		if err != nil {
+			utils.BadRequestResponse(ctx, "invalid employee_id", "invalid employee_id")
+			return
+		}
+		employeeID = &parsedEmployeeID
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+
+	actor, err := resolveTaskActor(ctx, h.Log, h.Viper, h.UserHelper)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "unauthorized", err.Error())
+		return
+	}
+
+	res, total, err := h.UseCase.FindAllPaginated(page, pageSize, employeeID, actor, sort)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find all verifier delegations", gin.H{
+		"verifier_delegations": res,
+		"total":                total,
+	})
+}
+
+// FindByID find verifier delegation by id
+//
+// @Summary Find verifier delegation by id
+// @Description Find verifier delegation by id
+// @Tags Verifier Delegations
+// @Accept json
+// @Produce json
+// @Param id path string true "Verifier Delegation ID"
+// @Success 200 {object} response.VerifierDelegationResponse
+// @Security BearerAuth
+// @Router /verifier-delegations/{id} [get]
+func (h *VerifierDelegationHandler) FindByID(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		utils.BadRequestResponse(ctx, "invalid id", "invalid id")
+		return
+	}
+
+	res, err := h.UseCase.FindByID(id)
+	if err != nil {
+		h.Log.Error("[VerifierDelegationHandler.FindByID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "internal server error", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "success find verifier delegation", res)
+}
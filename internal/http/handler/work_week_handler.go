@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/IlhamSetiaji/julong-onboarding-be/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IWorkWeekHandler interface {
+	CreateOrUpdateWorkWeek(ctx *gin.Context)
+	DeleteWorkWeek(ctx *gin.Context)
+	FindAllPaginated(ctx *gin.Context)
+	FindByOrganizationID(ctx *gin.Context)
+}
+
+type WorkWeekHandler struct {
+	Log      *logrus.Logger
+	Viper    *viper.Viper
+	Validate *validator.Validate
+	UseCase  usecase.IWorkWeekUseCase
+}
+
+func NewWorkWeekHandler(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	useCase usecase.IWorkWeekUseCase,
+) IWorkWeekHandler {
+	return &WorkWeekHandler{
+		Log:      log,
+		Viper:    viper,
+		Validate: validate,
+		UseCase:  useCase,
+	}
+}
+
+func WorkWeekHandlerFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) IWorkWeekHandler {
+	validate := config.NewValidator(viper)
+	useCase := usecase.WorkWeekUseCaseFactory(log, viper)
+	return NewWorkWeekHandler(log, viper, validate, useCase)
+}
+
+// CreateOrUpdateWorkWeek creates or updates the work week of an organization
+//
+// @Summary Create or update a work week
+// @Description Create or update the working weekdays of an organization
+// @Tags Work Weeks
+// @Accept json
+// @Produce json
+// @Param work_week body request.CreateOrUpdateWorkWeekRequest true "Work week data"
+// @Security BearerAuth
+// @Success 200 {object} response.WorkWeekResponse
+// @Router /work-weeks [post]
+func (h *WorkWeekHandler) CreateOrUpdateWorkWeek(ctx *gin.Context) {
+	var req request.CreateOrUpdateWorkWeekRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.Log.Error("[WorkWeekHandler.CreateOrUpdateWorkWeek] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	if err := h.Validate.Struct(req); err != nil {
+		h.Log.Error("[WorkWeekHandler.CreateOrUpdateWorkWeek] " + err.Error())
+		utils.BadRequestResponse(ctx, err.Error(), err.Error())
+		return
+	}
+
+	workWeek, err := h.UseCase.CreateOrUpdateWorkWeek(&req)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.CreateOrUpdateWorkWeek] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to save work week", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Work week saved", workWeek)
+}
+
+// DeleteWorkWeek deletes a work week
+//
+// @Summary Delete a work week
+// @Description Delete a work week, the organization falls back to Monday to Friday
+// @Tags Work Weeks
+// @Accept json
+// @Produce json
+// @Param id path string true "Work week ID"
+// @Security BearerAuth
+// @Success 204 "Work week deleted"
+// @Router /work-weeks/{id} [delete]
+func (h *WorkWeekHandler) DeleteWorkWeek(ctx *gin.Context) {
+	id := ctx.Param("id")
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.DeleteWorkWeek] " + err.Error())
+		utils.BadRequestResponse(ctx, "Invalid ID", "Invalid ID")
+		return
+	}
+
+	err = h.UseCase.DeleteWorkWeek(parsedID)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.DeleteWorkWeek] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to delete work week", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusNoContent, "Work week deleted", nil)
+}
+
+// FindAllPaginated finds all work weeks with pagination
+//
+// @Summary Find all work weeks with pagination
+// @Description Find all work weeks with pagination
+// @Tags Work Weeks
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param page_size query int false "Page Size"
+// @Param created_at query string false "Created At"
+// @Security BearerAuth
+// @Success 200 {object} response.WorkWeekResponse
+// @Router /work-weeks [get]
+func (h *WorkWeekHandler) FindAllPaginated(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(ctx.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	createdAt := ctx.Query("created_at")
+	if createdAt == "" {
+		createdAt = "DESC"
+	}
+
+	sort := map[string]interface{}{
+		"created_at": createdAt,
+	}
+	workWeeks, total, err := h.UseCase.FindAllPaginated(page, pageSize, sort)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.FindAllPaginated] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to find work weeks", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Work weeks found", gin.H{
+		"work_weeks": workWeeks,
+		"total":      total,
+	})
+}
+
+// FindByOrganizationID finds the work week of an organization
+//
+// @Summary Find a work week by organization ID
+// @Description Find a work week by organization ID
+// @Tags Work Weeks
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Security BearerAuth
+// @Success 200 {object} response.WorkWeekResponse
+// @Router /work-weeks/organization/{organization_id} [get]
+func (h *WorkWeekHandler) FindByOrganizationID(ctx *gin.Context) {
+	organizationID := ctx.Param("organization_id")
+	parsedOrgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.FindByOrganizationID] " + err.Error())
+		utils.BadRequestResponse(ctx, "Invalid organization ID", "Invalid organization ID")
+		return
+	}
+
+	workWeek, err := h.UseCase.FindByOrganizationID(parsedOrgID)
+	if err != nil {
+		h.Log.Error("[WorkWeekHandler.FindByOrganizationID] " + err.Error())
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to find work week", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Work week found", workWeek)
+}
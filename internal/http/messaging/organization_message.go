@@ -20,6 +20,7 @@ type IOrganizationMessage interface {
 	SendFindAllOrganizationMessage(includedIDs []string) (*[]orgResponse.OrganizationResponse, error)
 	SendFindAllOrganizationLocationsMessage(includedIDs []string) (*[]orgResponse.OrganizationLocationResponse, error)
 	SendFindAllOrgStructureChildrenIDsMessage(parentID string) (*[]string, error)
+	SendFindEmployeeApproverMessage(req request.SendFindEmployeeApproverMessageRequest) (*orgResponse.SendFindEmployeeApproverMessageResponse, error)
 }
 
 type OrganizationMessage struct {
@@ -378,6 +379,58 @@ func (m *OrganizationMessage) SendFindAllOrgStructureChildrenIDsMessage(parentID
 	return &ids, nil
 }
 
+// SendFindEmployeeApproverMessage walks the organization structure of the employee to find the
+// approver of the given type. It returns nil when the structure has no such approver.
+func (m *OrganizationMessage) SendFindEmployeeApproverMessage(req request.SendFindEmployeeApproverMessageRequest) (*orgResponse.SendFindEmployeeApproverMessageResponse, error) {
+	payload := map[string]interface{}{
+		"employee_id":   req.EmployeeID,
+		"approver_type": req.ApproverType,
+	}
+
+	docMsg := &request.RabbitMQRequest{
+		ID:          uuid.New().String(),
+		MessageType: "find_employee_approver",
+		MessageData: payload,
+		ReplyTo:     "julong_onboarding",
+	}
+
+	log.Printf("INFO: document message: %v", docMsg)
+
+	// create channel and add to rchans with uid
+	rchan := make(chan response.RabbitMQResponse)
+	utils.Rchans[docMsg.ID] = rchan
+
+	// publish rabbit message
+	msg := utils.RabbitMsgPublisher{
+		QueueName: "julong_sso",
+		Message:   *docMsg,
+	}
+	utils.Pchan <- msg
+
+	// wait for reply
+	resp, err := waitReply(docMsg.ID, rchan)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("INFO: response: %v", resp)
+
+	if errMsg, ok := resp.MessageData["error"].(string); ok && errMsg != "" {
+		return nil, errors.New("[SendFindEmployeeApproverMessage] " + errMsg)
+	}
+
+	employeeID, _ := resp.MessageData["employee_id"].(string)
+	if employeeID == "" {
+		return nil, nil
+	}
+	name, _ := resp.MessageData["name"].(string)
+
+	return &orgResponse.SendFindEmployeeApproverMessageResponse{
+		EmployeeID: employeeID,
+		Name:       name,
+	}, nil
+}
+
 func OrganizationMessageFactory(log *logrus.Logger) IOrganizationMessage {
 	return NewOrganizationMessage(log)
 }
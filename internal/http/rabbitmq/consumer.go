@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
@@ -258,16 +259,35 @@ func handleMsg(docMsg *request.RabbitMQRequest, log *logrus.Logger, viper *viper
 			break
 		}
 
+		// optional, used to pick the organization's working-day calendar and to
+		// evaluate template task rules
+		organizationID, _ := docMsg.MessageData["organization_id"].(string)
+		jobID, _ := docMsg.MessageData["job_id"].(string)
+		jobLevelID, _ := docMsg.MessageData["job_level_id"].(string)
+		organizationLocationID, _ := docMsg.MessageData["organization_location_id"].(string)
+		organizationStructureID, _ := docMsg.MessageData["organization_structure_id"].(string)
+		employmentType, _ := docMsg.MessageData["employment_type"].(string)
+		jobLevel := messageJobLevel(docMsg.MessageData)
+		organizationStructureParentIDs := messageStringList(docMsg.MessageData, "organization_structure_parent_ids")
+
 		templateTaskUseCaseFactory := usecase.EmployeeTaskUseCaseFactory(log, viper)
 		err := templateTaskUseCaseFactory.CreateEmployeeTasksForRecruitment(&request.CreateEmployeeTasksForRecruitment{
-			EmployeeID:            employeeID,
-			JoinedDate:            joinedDate,
-			OrganizationType:      organizationType,
-			EmployeeMidsuitID:     employeeMidsuitID,
-			JobMidsuitID:          jobMidsuitID,
-			JobLevelMidsuitID:     jobLevelMidsuitID,
-			OrgMidsuitID:          orgMidsuitID,
-			OrgStructureMidsuitID: orgStructureMidsuitID,
+			EmployeeID:                     employeeID,
+			JoinedDate:                     joinedDate,
+			OrganizationType:               organizationType,
+			OrganizationID:                 organizationID,
+			EmployeeMidsuitID:              employeeMidsuitID,
+			JobMidsuitID:                   jobMidsuitID,
+			JobLevelMidsuitID:              jobLevelMidsuitID,
+			OrgMidsuitID:                   orgMidsuitID,
+			OrgStructureMidsuitID:          orgStructureMidsuitID,
+			JobID:                          jobID,
+			JobLevelID:                     jobLevelID,
+			OrganizationLocationID:         organizationLocationID,
+			OrganizationStructureID:        organizationStructureID,
+			EmploymentType:                 employmentType,
+			JobLevel:                       jobLevel,
+			OrganizationStructureParentIDs: organizationStructureParentIDs,
 		})
 		if err != nil {
 			log.Errorf("ERROR: fail create employee tasks: %s", err.Error())
@@ -280,6 +300,123 @@ func handleMsg(docMsg *request.RabbitMQRequest, log *logrus.Logger, viper *viper
 		msgData = map[string]interface{}{
 			"message": "success",
 		}
+	case "create_offboarding_tasks":
+		employeeID, ok := docMsg.MessageData["employee_id"].(string)
+		if !ok {
+			log.Errorf("Invalid request format: missing 'employee_id'")
+			msgData = map[string]interface{}{
+				"error": errors.New("missing 'employee_id'").Error(),
+			}
+			break
+		}
+		lastWorkingDate, ok := docMsg.MessageData["last_working_date"].(string)
+		if !ok {
+			log.Errorf("Invalid request format: missing 'last_working_date'")
+			msgData = map[string]interface{}{
+				"error": errors.New("missing 'last_working_date'").Error(),
+			}
+			break
+		}
+		organizationType, ok := docMsg.MessageData["organization_type"].(string)
+		if !ok {
+			log.Errorf("Invalid request format: missing 'organization_type'")
+			msgData = map[string]interface{}{
+				"error": errors.New("missing 'organization_type'").Error(),
+			}
+			break
+		}
+
+		// optional, tasks start today when no start date is given and the midsuit ids are
+		// only needed when midsuit sync is active
+		startDate, _ := docMsg.MessageData["start_date"].(string)
+		reason, _ := docMsg.MessageData["reason"].(string)
+		organizationID, _ := docMsg.MessageData["organization_id"].(string)
+		employeeMidsuitID, _ := docMsg.MessageData["employee_midsuit_id"].(string)
+		jobMidsuitID, _ := docMsg.MessageData["job_midsuit_id"].(string)
+		jobLevelMidsuitID, _ := docMsg.MessageData["job_level_midsuit_id"].(string)
+		orgMidsuitID, _ := docMsg.MessageData["org_midsuit_id"].(string)
+		orgStructureMidsuitID, _ := docMsg.MessageData["org_structure_midsuit_id"].(string)
+		jobID, _ := docMsg.MessageData["job_id"].(string)
+		jobLevelID, _ := docMsg.MessageData["job_level_id"].(string)
+		organizationLocationID, _ := docMsg.MessageData["organization_location_id"].(string)
+		organizationStructureID, _ := docMsg.MessageData["organization_structure_id"].(string)
+		employmentType, _ := docMsg.MessageData["employment_type"].(string)
+		jobLevel := messageJobLevel(docMsg.MessageData)
+		organizationStructureParentIDs := messageStringList(docMsg.MessageData, "organization_structure_parent_ids")
+
+		employeeTaskUseCaseFactory := usecase.EmployeeTaskUseCaseFactory(log, viper)
+		err := employeeTaskUseCaseFactory.CreateEmployeeTasksForOffboarding(&request.CreateEmployeeTasksForOffboarding{
+			EmployeeID:                     employeeID,
+			StartDate:                      startDate,
+			LastWorkingDate:                lastWorkingDate,
+			OrganizationType:               organizationType,
+			Reason:                         reason,
+			OrganizationID:                 organizationID,
+			EmployeeMidsuitID:              employeeMidsuitID,
+			JobMidsuitID:                   jobMidsuitID,
+			JobLevelMidsuitID:              jobLevelMidsuitID,
+			OrgMidsuitID:                   orgMidsuitID,
+			OrgStructureMidsuitID:          orgStructureMidsuitID,
+			JobID:                          jobID,
+			JobLevelID:                     jobLevelID,
+			OrganizationLocationID:         organizationLocationID,
+			OrganizationStructureID:        organizationStructureID,
+			EmploymentType:                 employmentType,
+			JobLevel:                       jobLevel,
+			OrganizationStructureParentIDs: organizationStructureParentIDs,
+		})
+		if err != nil {
+			log.Errorf("ERROR: fail create offboarding tasks: %s", err.Error())
+			msgData = map[string]interface{}{
+				"error": err.Error(),
+			}
+			break
+		}
+
+		msgData = map[string]interface{}{
+			"message": "success",
+		}
+	case "cancel_onboarding", "pause_onboarding", "resume_onboarding":
+		employeeID, ok := docMsg.MessageData["employee_id"].(string)
+		if !ok {
+			log.Errorf("Invalid request format: missing 'employee_id'")
+			msgData = map[string]interface{}{
+				"error": errors.New("missing 'employee_id'").Error(),
+			}
+			break
+		}
+		reason, _ := docMsg.MessageData["reason"].(string)
+
+		changeReq := &request.ChangeOnboardingStatusRequest{
+			EmployeeID: employeeID,
+			Reason:     reason,
+		}
+		employeeTaskUseCaseFactory := usecase.EmployeeTaskUseCaseFactory(log, viper)
+		var resp *response.OnboardingStatusResponse
+		var err error
+		switch docMsg.MessageType {
+		case "cancel_onboarding":
+			resp, err = employeeTaskUseCaseFactory.CancelOnboarding(changeReq)
+		case "pause_onboarding":
+			resp, err = employeeTaskUseCaseFactory.PauseOnboarding(changeReq)
+		default:
+			resp, err = employeeTaskUseCaseFactory.ResumeOnboarding(changeReq)
+		}
+		if err != nil {
+			log.Errorf("ERROR: fail %s: %s", docMsg.MessageType, err.Error())
+			msgData = map[string]interface{}{
+				"error": err.Error(),
+			}
+			break
+		}
+
+		msgData = map[string]interface{}{
+			"employee_id":     employeeID,
+			"status":          resp.Status,
+			"updated_tasks":   resp.UpdatedTasks,
+			"detached_events": resp.DetachedEvents,
+			"midsuit_errors":  resp.MidsuitErrors,
+		}
 	case "count_kanban_progress_by_employee_id":
 		employeeID, ok := docMsg.MessageData["employee_id"].(string)
 		if !ok {
@@ -298,8 +435,14 @@ func handleMsg(docMsg *request.RabbitMQRequest, log *logrus.Logger, viper *viper
 			break
 		}
 
+		// optional, onboarding unless the offboarding progress is asked for
+		source, _ := docMsg.MessageData["source"].(string)
+		if source == "" {
+			source = entity.TASK_SOURCE_ONBOARDING
+		}
+
 		employeeTaskUseCaseFactory := usecase.EmployeeTaskUseCaseFactory(log, viper)
-		resp, err := employeeTaskUseCaseFactory.CountKanbanProgressByEmployeeID(parsedEmployeeUUID)
+		resp, err := employeeTaskUseCaseFactory.CountKanbanProgressByEmployeeID(parsedEmployeeUUID, source)
 		if err != nil {
 			log.Errorf("ERROR: fail count kanban progress by employee id: %s", err.Error())
 			msgData = map[string]interface{}{
@@ -312,6 +455,7 @@ func handleMsg(docMsg *request.RabbitMQRequest, log *logrus.Logger, viper *viper
 
 		msgData = map[string]interface{}{
 			"employee_id": employeeID,
+			"source":      source,
 			"total_task":  resp.TotalTask,
 			"to_do":       resp.ToDo,
 			"in_progress": resp.InProgress,
@@ -338,3 +482,28 @@ func handleMsg(docMsg *request.RabbitMQRequest, log *logrus.Logger, viper *viper
 	}
 	utils.Rchan <- msg
 }
+
+// messageJobLevel reads the numeric job level of the hire, nil when it is not sent.
+func messageJobLevel(messageData map[string]interface{}) *float64 {
+	jobLevel, ok := messageData["job_level"].(float64)
+	if !ok {
+		return nil
+	}
+	return &jobLevel
+}
+
+// messageStringList reads a list of strings, nil when the key is not sent so that an empty
+// list can still be told apart from a missing one.
+func messageStringList(messageData map[string]interface{}, key string) []string {
+	values, ok := messageData[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			list = append(list, str)
+		}
+	}
+	return list
+}
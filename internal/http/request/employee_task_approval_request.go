@@ -0,0 +1,13 @@
+package request
+
+type ApproveEmployeeTaskRequest struct {
+	EmployeeTaskID string    `json:"employee_task_id" validate:"required,uuid"`
+	Reason         string    `json:"reason" validate:"omitempty"`
+	Actor          TaskActor `json:"-"`
+}
+
+type RejectEmployeeTaskRequest struct {
+	EmployeeTaskID string    `json:"employee_task_id" validate:"required,uuid"`
+	Reason         string    `json:"reason" validate:"required"`
+	Actor          TaskActor `json:"-"`
+}
@@ -0,0 +1,29 @@
+package request
+
+type BulkAssignEmployeeTaskRequest struct {
+	TemplateTaskID string   `json:"template_task_id" validate:"required,uuid"`
+	EmployeeIDs    []string `json:"employee_ids" validate:"required,min=1,dive,uuid"`
+	StartDate      string   `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	EndDate        string   `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	VerifiedBy     *string  `json:"verified_by" validate:"omitempty,uuid"`
+}
+
+type BulkMoveEmployeeTaskRequest struct {
+	IDs    []string `json:"ids" validate:"required,min=1,dive,uuid"`
+	Kanban string   `json:"kanban" validate:"required,employee_task_kanban_validation"`
+}
+
+type BulkVerifyEmployeeTaskRequest struct {
+	IDs        []string `json:"ids" validate:"required,min=1,dive,uuid"`
+	VerifiedBy string   `json:"verified_by" validate:"required,uuid"`
+	Notes      string   `json:"notes" validate:"omitempty"`
+}
+
+type BulkReassignVerifierRequest struct {
+	IDs        []string `json:"ids" validate:"required,min=1,dive,uuid"`
+	VerifiedBy string   `json:"verified_by" validate:"required,uuid"`
+}
+
+type BulkDeleteEmployeeTaskRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,dive,uuid"`
+}
@@ -5,6 +5,10 @@ type EmployeeTaskChecklistRequest struct {
 	Name       string  `form:"name" validate:"required"`
 	IsChecked  *string `form:"is_checked" validate:"omitempty"`
 	VerifiedBy *string `form:"verified_by" validate:"omitempty,uuid"`
+	SortOrder  *int    `form:"sort_order" validate:"omitempty,min=0"`
+	AssigneeID *string `form:"assignee_id" validate:"omitempty,uuid"`
+	DueDate    *string `form:"due_date" validate:"omitempty,datetime=2006-01-02"`
+	IsRequired *string `form:"is_required" validate:"omitempty,oneof=YES NO"`
 }
 
 type EmployeeTaskChecklistMidsuitRequest struct {
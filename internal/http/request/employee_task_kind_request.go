@@ -0,0 +1,34 @@
+package request
+
+import "mime/multipart"
+
+type UploadEmployeeTaskFileRequest struct {
+	EmployeeTaskID string                `form:"employee_task_id" validate:"required,uuid"`
+	File           *multipart.FileHeader `form:"file" validate:"required"`
+	Path           string                `form:"-"`
+	Actor          TaskActor             `form:"-"`
+}
+
+type ReviewEmployeeTaskFileRequest struct {
+	ID     string    `json:"id" validate:"required,uuid"`
+	Status string    `json:"status" validate:"required,employee_task_file_status_validation"`
+	Notes  string    `json:"notes" validate:"omitempty"`
+	Actor  TaskActor `json:"-"`
+}
+
+type AcknowledgeEmployeeTaskRequest struct {
+	EmployeeTaskID string    `json:"employee_task_id" validate:"required,uuid"`
+	Actor          TaskActor `json:"-"`
+}
+
+type RecordEmployeeTaskQuizScoreRequest struct {
+	EmployeeTaskID string    `json:"employee_task_id" validate:"required,uuid"`
+	Score          *int      `json:"score" validate:"required,min=0,max=100"`
+	Actor          TaskActor `json:"-"`
+}
+
+type CheckInEventRequest struct {
+	EventID    string    `json:"event_id" validate:"required,uuid"`
+	EmployeeID string    `json:"employee_id" validate:"required,uuid"`
+	Actor      TaskActor `json:"-"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type ReassignEmployeeTaskRequest struct {
+	ID         string    `json:"id" validate:"required,uuid"`
+	EmployeeID string    `json:"employee_id" validate:"required,uuid"`
+	Reason     string    `json:"reason" validate:"omitempty"`
+	Actor      TaskActor `json:"-"`
+}
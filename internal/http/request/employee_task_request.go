@@ -1,6 +1,10 @@
 package request
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+
+	"github.com/google/uuid"
+)
 
 type CreateEmployeeTaskRequest struct {
 	CoverPath               *string                         `form:"cover_path" validate:"required"`
@@ -12,6 +16,8 @@ type CreateEmployeeTaskRequest struct {
 	Description             string                          `form:"description" validate:"omitempty"`
 	StartDate               string                          `form:"start_date" validate:"required,datetime=2006-01-02"`
 	EndDate                 string                          `form:"end_date" validate:"required,datetime=2006-01-02"`
+	Kind                    string                          `form:"kind" validate:"omitempty,task_kind_validation"`
+	PassingScore            *int                            `form:"passing_score" validate:"omitempty,min=0,max=100"`
 	EmployeeTaskAttachments []EmployeeTaskAttachmentRequest `form:"employee_task_attachments" validate:"omitempty,dive"`
 	EmployeeTaskChecklists  []EmployeeTaskChecklistRequest  `form:"employee_task_checklists" validate:"omitempty,dive"`
 }
@@ -48,8 +54,12 @@ type UpdateEmployeeTaskRequest struct {
 	Status                  string                          `form:"status" validate:"omitempty,employee_task_status_validation"`
 	Kanban                  string                          `form:"kanban" validate:"omitempty,employee_task_kanban_validation"`
 	Notes                   string                          `form:"notes" validate:"omitempty"`
+	Kind                    string                          `form:"kind" validate:"omitempty,task_kind_validation"`
+	PassingScore            *int                            `form:"passing_score" validate:"omitempty,min=0,max=100"`
 	EmployeeTaskAttachments []EmployeeTaskAttachmentRequest `form:"employee_task_attachments" validate:"omitempty,dive"`
 	EmployeeTaskChecklists  []EmployeeTaskChecklistRequest  `form:"employee_task_checklists" validate:"omitempty,dive"`
+	// ActorEmployeeID is the logged in employee, stamped on the checklist items this update checks
+	ActorEmployeeID *uuid.UUID `form:"-" json:"-"`
 }
 
 type UpdateEmployeeTaskMidsuitRequest struct {
@@ -84,11 +94,50 @@ type CreateEmployeeTasksForRecruitment struct {
 	EmployeeID            string `json:"employee_id" validate:"required,uuid"`
 	JoinedDate            string `json:"joined_date" validate:"required,datetime=2006-01-02"`
 	OrganizationType      string `json:"organization_type" validate:"required"`
+	OrganizationID        string `json:"organization_id" validate:"omitempty,uuid"`
+	EmployeeMidsuitID     string `json:"employee_midsuit_id" validate:"omitempty"`
+	JobMidsuitID          string `json:"job_midsuit_id" validate:"omitempty"`
+	JobLevelMidsuitID     string `json:"job_level_midsuit_id" validate:"omitempty"`
+	OrgMidsuitID          string `json:"org_midsuit_id" validate:"omitempty"`
+	OrgStructureMidsuitID string `json:"org_structure_midsuit_id" validate:"omitempty"`
+
+	// hire attributes used by template task rules. Tasks are generated from these alone, rules
+	// on an attribute that is not sent fail the generation instead of being looked up.
+	JobID                   string `json:"job_id" validate:"omitempty,uuid"`
+	JobLevelID              string `json:"job_level_id" validate:"omitempty,uuid"`
+	OrganizationLocationID  string `json:"organization_location_id" validate:"omitempty,uuid"`
+	OrganizationStructureID string `json:"organization_structure_id" validate:"omitempty,uuid"`
+	EmploymentType          string `json:"employment_type" validate:"omitempty"`
+	// JobLevel is the numeric level of job_level_id, needed by job level comparisons
+	JobLevel *float64 `json:"job_level" validate:"omitempty"`
+	// OrganizationStructureParentIDs are the structures above organization_structure_id, needed
+	// by subtree rules
+	OrganizationStructureParentIDs []string `json:"organization_structure_parent_ids" validate:"omitempty,dive,uuid"`
+}
+
+// CreateEmployeeTasksForOffboarding is sent by the HR service when an employee resigns or is
+// terminated. The offboarding tasks start on start_date and are due by the last working date.
+type CreateEmployeeTasksForOffboarding struct {
+	EmployeeID            string `json:"employee_id" validate:"required,uuid"`
+	StartDate             string `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	LastWorkingDate       string `json:"last_working_date" validate:"required,datetime=2006-01-02"`
+	OrganizationType      string `json:"organization_type" validate:"required"`
+	Reason                string `json:"reason" validate:"omitempty"`
+	OrganizationID        string `json:"organization_id" validate:"omitempty,uuid"`
 	EmployeeMidsuitID     string `json:"employee_midsuit_id" validate:"omitempty"`
 	JobMidsuitID          string `json:"job_midsuit_id" validate:"omitempty"`
 	JobLevelMidsuitID     string `json:"job_level_midsuit_id" validate:"omitempty"`
 	OrgMidsuitID          string `json:"org_midsuit_id" validate:"omitempty"`
 	OrgStructureMidsuitID string `json:"org_structure_midsuit_id" validate:"omitempty"`
+
+	// hire attributes used by template task rules, as for CreateEmployeeTasksForRecruitment
+	JobID                          string   `json:"job_id" validate:"omitempty,uuid"`
+	JobLevelID                     string   `json:"job_level_id" validate:"omitempty,uuid"`
+	OrganizationLocationID         string   `json:"organization_location_id" validate:"omitempty,uuid"`
+	OrganizationStructureID        string   `json:"organization_structure_id" validate:"omitempty,uuid"`
+	EmploymentType                 string   `json:"employment_type" validate:"omitempty"`
+	JobLevel                       *float64 `json:"job_level" validate:"omitempty"`
+	OrganizationStructureParentIDs []string `json:"organization_structure_parent_ids" validate:"omitempty,dive,uuid"`
 }
 
 type AdOrgId struct {
@@ -132,6 +181,11 @@ type SyncEmployeeTaskMidsuitRequest struct {
 	HCApproverUserID HcApproverUserId `json:"HC_ApproverUser_ID" binding:"omitempty"`
 }
 
+// SyncEmployeeTaskActiveMidsuitRequest only changes whether the Midsuit task is active.
+type SyncEmployeeTaskActiveMidsuitRequest struct {
+	IsActive bool `json:"IsActive"`
+}
+
 type TaskCategory struct {
 	PropertyLabel string `json:"propertyLabel" binding:"omitempty"`
 	ID            string `json:"id" binding:"omitempty"`
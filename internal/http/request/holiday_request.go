@@ -0,0 +1,22 @@
+package request
+
+type CreateHolidayRequest struct {
+	OrganizationID string `json:"organization_id" validate:"omitempty,uuid"`
+	Name           string `json:"name" validate:"required"`
+	Date           string `json:"date" validate:"required,datetime=2006-01-02"`
+	Type           string `json:"type" validate:"omitempty,holiday_type_validation"`
+	Description    string `json:"description" validate:"omitempty"`
+}
+
+type UpdateHolidayRequest struct {
+	ID             string `json:"id" validate:"required,uuid"`
+	OrganizationID string `json:"organization_id" validate:"omitempty,uuid"`
+	Name           string `json:"name" validate:"required"`
+	Date           string `json:"date" validate:"required,datetime=2006-01-02"`
+	Type           string `json:"type" validate:"omitempty,holiday_type_validation"`
+	Description    string `json:"description" validate:"omitempty"`
+}
+
+type ImportHolidayRequest struct {
+	Holidays []CreateHolidayRequest `json:"holidays" validate:"required,min=1,dive"`
+}
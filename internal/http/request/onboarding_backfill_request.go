@@ -0,0 +1,23 @@
+package request
+
+type PreviewOnboardingBackfillRequest struct {
+	OrganizationType string   `json:"organization_type" validate:"required"`
+	TemplateTaskIDs  []string `json:"template_task_ids" validate:"omitempty,dive,uuid"`
+	EmployeeIDs      []string `json:"employee_ids" validate:"omitempty,dive,uuid"`
+}
+
+// OnboardingBackfillSelectionRequest picks changes from the preview. An empty
+// change_type selects every change of the employee for the template task.
+type OnboardingBackfillSelectionRequest struct {
+	EmployeeID     string `json:"employee_id" validate:"required,uuid"`
+	TemplateTaskID string `json:"template_task_id" validate:"required,uuid"`
+	ChangeType     string `json:"change_type" validate:"omitempty,onboarding_backfill_change_type_validation"`
+	ChecklistName  string `json:"checklist_name" validate:"omitempty"`
+}
+
+type ApplyOnboardingBackfillRequest struct {
+	OrganizationType string                               `json:"organization_type" validate:"required"`
+	TemplateTaskIDs  []string                             `json:"template_task_ids" validate:"omitempty,dive,uuid"`
+	EmployeeIDs      []string                             `json:"employee_ids" validate:"omitempty,dive,uuid"`
+	Selections       []OnboardingBackfillSelectionRequest `json:"selections" validate:"omitempty,dive"`
+}
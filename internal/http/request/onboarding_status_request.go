@@ -0,0 +1,6 @@
+package request
+
+type ChangeOnboardingStatusRequest struct {
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
+	Reason     string `json:"reason" validate:"omitempty"`
+}
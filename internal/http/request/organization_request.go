@@ -11,3 +11,10 @@ type SendFindOrganizationLocationByIDMessageRequest struct {
 type SendFindOrganizationStructureByIDMessageRequest struct {
 	ID string `json:"id"`
 }
+
+// SendFindEmployeeApproverMessageRequest asks the organization structure who approves for the
+// employee in the given role, e.g. DIRECT_MANAGER, HR or DEPARTMENT_HEAD.
+type SendFindEmployeeApproverMessageRequest struct {
+	EmployeeID   string `json:"employee_id"`
+	ApproverType string `json:"approver_type"`
+}
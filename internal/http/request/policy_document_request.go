@@ -0,0 +1,38 @@
+package request
+
+import "mime/multipart"
+
+type CreatePolicyDocumentRequest struct {
+	Name        string                `form:"name" validate:"required"`
+	Description string                `form:"description" validate:"omitempty"`
+	ChangeNote  string                `form:"change_note" validate:"omitempty"`
+	File        *multipart.FileHeader `form:"file" validate:"required"`
+	Path        string                `form:"-"`
+	Actor       TaskActor             `form:"-"`
+}
+
+type UpdatePolicyDocumentRequest struct {
+	ID          string    `json:"id" validate:"required,uuid"`
+	Name        string    `json:"name" validate:"required"`
+	Description string    `json:"description" validate:"omitempty"`
+	Status      string    `json:"status" validate:"required,policy_document_status_validation"`
+	Actor       TaskActor `json:"-"`
+}
+
+type CreatePolicyDocumentVersionRequest struct {
+	PolicyDocumentID string                `form:"-" validate:"required,uuid"`
+	ChangeNote       string                `form:"change_note" validate:"omitempty"`
+	File             *multipart.FileHeader `form:"file" validate:"required"`
+	Path             string                `form:"-"`
+	Actor            TaskActor             `form:"-"`
+}
+
+// AcknowledgePolicyDocumentRequest accepts the version the employee has read. IPAddress and
+// UserAgent are taken from the request by the handler.
+type AcknowledgePolicyDocumentRequest struct {
+	PolicyDocumentID string    `json:"-" validate:"required,uuid"`
+	VersionNumber    int       `json:"version_number" validate:"required,min=1"`
+	IPAddress        string    `json:"-"`
+	UserAgent        string    `json:"-"`
+	Actor            TaskActor `json:"-"`
+}
@@ -5,20 +5,54 @@ import "mime/multipart"
 type CreateOrUpdateQuestions struct {
 	SurveyTemplateID   string            `json:"survey_template_id" validate:"omitempty,uuid"`
 	Title              string            `json:"title" validate:"required"`
+	IsQuiz             bool              `json:"is_quiz" validate:"omitempty"`
+	PassingScore       *int              `json:"passing_score" validate:"omitempty,min=0,max=100"`
+	MaxAttempts        *int              `json:"max_attempts" validate:"omitempty,min=1"`
+	Anonymity          string            `json:"anonymity" validate:"omitempty,survey_template_anonymity_validation"`
+	MinGroupSize       *int              `json:"min_group_size" validate:"omitempty,min=2"`
 	Questions          []QuestionRequest `json:"questions" validate:"omitempty,dive"`
 	DeletedQuestionIDs []string          `json:"deleted_question_ids" validate:"omitempty,dive,uuid"`
 }
 
 type QuestionRequest struct {
-	ID              string                  `json:"id" validate:"omitempty,uuid"`
-	AnswerTypeID    string                  `json:"answer_type_id" validate:"required,uuid"`
-	Question        string                  `json:"question" validate:"omitempty"`
-	MaxStars        int                     `json:"max_stars" validate:"omitempty"`
-	Attachment      *multipart.FileHeader   `json:"attachment" validate:"omitempty"`
-	AttachmentPath  string                  `json:"attachment_path" validate:"omitempty"`
-	QuestionOptions []QuestionOptionRequest `json:"question_options" validate:"omitempty,dive"`
+	ID                string                     `json:"id" validate:"omitempty,uuid"`
+	AnswerTypeID      string                     `json:"answer_type_id" validate:"required,uuid"`
+	Question          string                     `json:"question" validate:"omitempty"`
+	MaxStars          int                        `json:"max_stars" validate:"omitempty"`
+	Points            int                        `json:"points" validate:"omitempty,min=1"`
+	IsRequired        bool                       `json:"is_required" validate:"omitempty"`
+	MinLength         *int                       `json:"min_length" validate:"omitempty,min=0"`
+	MaxLength         *int                       `json:"max_length" validate:"omitempty,min=1"`
+	Pattern           *string                    `json:"pattern" validate:"omitempty"`
+	AllowedFileTypes  []string                   `json:"allowed_file_types" validate:"omitempty,dive,required"`
+	MaxFileSizeKB     *int                       `json:"max_file_size_kb" validate:"omitempty,min=1"`
+	MinSelections     *int                       `json:"min_selections" validate:"omitempty,min=0"`
+	MaxSelections     *int                       `json:"max_selections" validate:"omitempty,min=1"`
+	MinValue          *float64                   `json:"min_value" validate:"omitempty"`
+	MaxValue          *float64                   `json:"max_value" validate:"omitempty"`
+	MinDate           *string                    `json:"min_date" validate:"omitempty,datetime=2006-01-02"`
+	MaxDate           *string                    `json:"max_date" validate:"omitempty,datetime=2006-01-02"`
+	Attachment        *multipart.FileHeader      `json:"attachment" validate:"omitempty"`
+	AttachmentPath    string                     `json:"attachment_path" validate:"omitempty"`
+	QuestionOptions   []QuestionOptionRequest    `json:"question_options" validate:"omitempty,dive"`
+	MatrixRows        []QuestionMatrixRowRequest `json:"matrix_rows" validate:"omitempty,dive"`
+	ConditionMatch    string                     `json:"condition_match" validate:"omitempty,question_condition_match_validation"`
+	DisplayConditions []QuestionConditionRequest `json:"display_conditions" validate:"omitempty,dive"`
 }
 
 type QuestionOptionRequest struct {
 	OptionText string `json:"option_text" validate:"required"`
+	IsCorrect  bool   `json:"is_correct" validate:"omitempty"`
+}
+
+type QuestionMatrixRowRequest struct {
+	RowText string `json:"row_text" validate:"required"`
+}
+
+// QuestionConditionRequest points at its source question by number, the position of the
+// question in the request, as new questions have no id yet.
+type QuestionConditionRequest struct {
+	SourceQuestionNumber int    `json:"source_question_number" validate:"required,min=1"`
+	Operator             string `json:"operator" validate:"required,question_condition_operator_validation"`
+	Value                string `json:"value" validate:"omitempty"`
 }
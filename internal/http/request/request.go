@@ -103,6 +103,20 @@ func TemplateTaskStatusValidation(fl validator.FieldLevel) bool {
 	}
 }
 
+func TaskSourceValidation(fl validator.FieldLevel) bool {
+	source := fl.Field().String()
+	if source == "" {
+		return true
+	}
+	switch source {
+	case entity.TASK_SOURCE_ONBOARDING,
+		entity.TASK_SOURCE_OFFBOARDING:
+		return true
+	default:
+		return false
+	}
+}
+
 func EmployeeTaskPriorityValidation(fl validator.FieldLevel) bool {
 	priority := fl.Field().String()
 	if priority == "" {
@@ -176,3 +190,239 @@ func SurveyTemplateStatusValidation(fl validator.FieldLevel) bool {
 		return false
 	}
 }
+
+func HolidayTypeValidation(fl validator.FieldLevel) bool {
+	holidayType := fl.Field().String()
+	if holidayType == "" {
+		return true
+	}
+	switch entity.HolidayTypeEnum(holidayType) {
+	case entity.HOLIDAY_TYPE_ENUM_NATIONAL,
+		entity.HOLIDAY_TYPE_ENUM_COLLECTIVE_LEAVE,
+		entity.HOLIDAY_TYPE_ENUM_COMPANY:
+		return true
+	default:
+		return false
+	}
+}
+
+func TemplateTaskRuleCriterionValidation(fl validator.FieldLevel) bool {
+	criterion := fl.Field().String()
+	if criterion == "" {
+		return true
+	}
+	switch entity.TemplateTaskRuleCriterionEnum(criterion) {
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_LOCATION,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE:
+		return true
+	default:
+		return false
+	}
+}
+
+func TemplateTaskRuleOperatorValidation(fl validator.FieldLevel) bool {
+	operator := fl.Field().String()
+	if operator == "" {
+		return true
+	}
+	switch entity.TemplateTaskRuleOperatorEnum(operator) {
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_EQUALS,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE:
+		return true
+	default:
+		return false
+	}
+}
+
+func OnboardingBackfillChangeTypeValidation(fl validator.FieldLevel) bool {
+	changeType := fl.Field().String()
+	if changeType == "" {
+		return true
+	}
+	switch entity.OnboardingBackfillChangeTypeEnum(changeType) {
+	case entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_TASK,
+		entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_CHECKLIST,
+		entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+		entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_CHECKLIST:
+		return true
+	default:
+		return false
+	}
+}
+
+func TemplateTaskVersionPropagationValidation(fl validator.FieldLevel) bool {
+	propagation := fl.Field().String()
+	if propagation == "" {
+		return true
+	}
+	switch entity.TemplateTaskVersionPropagationEnum(propagation) {
+	case entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY,
+		entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_UPDATE_OPEN_TASKS:
+		return true
+	default:
+		return false
+	}
+}
+
+func TemplateBundleConflictStrategyValidation(fl validator.FieldLevel) bool {
+	strategy := fl.Field().String()
+	if strategy == "" {
+		return true
+	}
+	switch strategy {
+	case "SKIP", "CREATE_NEW":
+		return true
+	default:
+		return false
+	}
+}
+
+func ApprovalApproverTypeValidation(fl validator.FieldLevel) bool {
+	approverType := fl.Field().String()
+	if approverType == "" {
+		return true
+	}
+	switch entity.ApprovalApproverTypeEnum(approverType) {
+	case entity.APPROVAL_APPROVER_TYPE_ENUM_DIRECT_MANAGER,
+		entity.APPROVAL_APPROVER_TYPE_ENUM_HR,
+		entity.APPROVAL_APPROVER_TYPE_ENUM_DEPARTMENT_HEAD,
+		entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE:
+		return true
+	default:
+		return false
+	}
+}
+
+func TaskKindValidation(fl validator.FieldLevel) bool {
+	kind := fl.Field().String()
+	if kind == "" {
+		return true
+	}
+	switch entity.TaskKindEnum(kind) {
+	case entity.TASK_KIND_ENUM_GENERIC,
+		entity.TASK_KIND_ENUM_SURVEY,
+		entity.TASK_KIND_ENUM_DOCUMENT_UPLOAD,
+		entity.TASK_KIND_ENUM_ACKNOWLEDGEMENT,
+		entity.TASK_KIND_ENUM_QUIZ,
+		entity.TASK_KIND_ENUM_EVENT_ATTENDANCE:
+		return true
+	default:
+		return false
+	}
+}
+
+func EmployeeTaskFileStatusValidation(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	if status == "" {
+		return true
+	}
+	switch entity.EmployeeTaskFileStatusEnum(status) {
+	case entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_ACCEPTED,
+		entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_REJECTED:
+		return true
+	default:
+		return false
+	}
+}
+
+func PolicyDocumentStatusValidation(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	if status == "" {
+		return true
+	}
+	switch entity.PolicyDocumentStatusEnum(status) {
+	case entity.POLICY_DOCUMENT_STATUS_ENUM_ACTIVE,
+		entity.POLICY_DOCUMENT_STATUS_ENUM_INACTIVE:
+		return true
+	default:
+		return false
+	}
+}
+
+func SurveyExportFormatValidation(fl validator.FieldLevel) bool {
+	format := fl.Field().String()
+	if format == "" {
+		return true
+	}
+	switch entity.SurveyExportFormatEnum(format) {
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_XLSX,
+		entity.SURVEY_EXPORT_FORMAT_ENUM_CSV,
+		entity.SURVEY_EXPORT_FORMAT_ENUM_JSONL:
+		return true
+	default:
+		return false
+	}
+}
+
+func QuestionConditionOperatorValidation(fl validator.FieldLevel) bool {
+	operator := fl.Field().String()
+	if operator == "" {
+		return true
+	}
+	switch entity.QuestionConditionOperatorEnum(operator) {
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_EQUALS,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_NOT_EQUALS,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_CONTAINS,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN_OR_EQUAL,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_ANSWERED,
+		entity.QUESTION_CONDITION_OPERATOR_ENUM_NOT_ANSWERED:
+		return true
+	default:
+		return false
+	}
+}
+
+func QuestionConditionMatchValidation(fl validator.FieldLevel) bool {
+	match := fl.Field().String()
+	if match == "" {
+		return true
+	}
+	switch entity.QuestionConditionMatchEnum(match) {
+	case entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+		entity.QUESTION_CONDITION_MATCH_ENUM_ANY:
+		return true
+	default:
+		return false
+	}
+}
+
+func SurveyTemplateAnonymityValidation(fl validator.FieldLevel) bool {
+	anonymity := fl.Field().String()
+	if anonymity == "" {
+		return true
+	}
+	switch entity.SurveyTemplateAnonymityEnum(anonymity) {
+	case entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE,
+		entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS,
+		entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL:
+		return true
+	default:
+		return false
+	}
+}
+
+func SurveyCampaignStatusValidation(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	if status == "" {
+		return true
+	}
+	switch entity.SurveyCampaignStatusEnum(status) {
+	case entity.SURVEY_CAMPAIGN_STATUS_ENUM_ACTIVE,
+		entity.SURVEY_CAMPAIGN_STATUS_ENUM_INACTIVE:
+		return true
+	default:
+		return false
+	}
+}
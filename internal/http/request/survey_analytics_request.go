@@ -0,0 +1,13 @@
+package request
+
+// SurveyAnalyticsRequest narrows the employee tasks counted by the survey analytics. Joined
+// dates are compared with the hiring date of the employee, submitted dates with the last time
+// an answer was saved.
+type SurveyAnalyticsRequest struct {
+	SurveyTemplateID string `form:"-" validate:"required,uuid"`
+	OrganizationID   string `form:"organization_id" validate:"omitempty,uuid"`
+	JoinedFrom       string `form:"joined_from" validate:"omitempty,datetime=2006-01-02"`
+	JoinedTo         string `form:"joined_to" validate:"omitempty,datetime=2006-01-02"`
+	SubmittedFrom    string `form:"submitted_from" validate:"omitempty,datetime=2006-01-02"`
+	SubmittedTo      string `form:"submitted_to" validate:"omitempty,datetime=2006-01-02"`
+}
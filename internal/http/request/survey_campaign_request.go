@@ -0,0 +1,27 @@
+package request
+
+// CreateSurveyCampaignRequest sends the survey template OffsetDays days after the hiring date
+// of the hires matching the rules. StartDate defaults to today.
+type CreateSurveyCampaignRequest struct {
+	Name             string                    `json:"name" validate:"required"`
+	SurveyTemplateID string                    `json:"survey_template_id" validate:"required,uuid"`
+	Status           string                    `json:"status" validate:"omitempty,survey_campaign_status_validation"`
+	StartDate        string                    `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	ResponseDays     int                       `json:"response_days" validate:"required,min=1"`
+	ReminderDays     *int                      `json:"reminder_days" validate:"omitempty,min=1"`
+	OffsetDays       []int                     `json:"offset_days" validate:"required,min=1,dive,min=0"`
+	Rules            []TemplateTaskRuleRequest `json:"rules" validate:"omitempty,dive"`
+	Actor            TaskActor                 `json:"-"`
+}
+
+type UpdateSurveyCampaignRequest struct {
+	ID               string                    `json:"id" validate:"required,uuid"`
+	Name             string                    `json:"name" validate:"required"`
+	SurveyTemplateID string                    `json:"survey_template_id" validate:"required,uuid"`
+	Status           string                    `json:"status" validate:"required,survey_campaign_status_validation"`
+	StartDate        string                    `json:"start_date" validate:"required,datetime=2006-01-02"`
+	ResponseDays     int                       `json:"response_days" validate:"required,min=1"`
+	ReminderDays     *int                      `json:"reminder_days" validate:"omitempty,min=1"`
+	OffsetDays       []int                     `json:"offset_days" validate:"required,min=1,dive,min=0"`
+	Rules            []TemplateTaskRuleRequest `json:"rules" validate:"omitempty,dive"`
+}
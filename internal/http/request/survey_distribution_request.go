@@ -0,0 +1,25 @@
+package request
+
+// CreateSurveyDistributionRequest sends the survey template to the listed employees and to the
+// hires matching the rules, at least one of the two is needed. OpensOn defaults to today and a
+// distribution without ClosesOn stays open until it is closed.
+type CreateSurveyDistributionRequest struct {
+	Name             string                    `json:"name" validate:"required"`
+	SurveyTemplateID string                    `json:"survey_template_id" validate:"required,uuid"`
+	OpensOn          string                    `json:"opens_on" validate:"omitempty,datetime=2006-01-02"`
+	ClosesOn         string                    `json:"closes_on" validate:"omitempty,datetime=2006-01-02"`
+	EmployeeIDs      []string                  `json:"employee_ids" validate:"omitempty,dive,uuid"`
+	Rules            []TemplateTaskRuleRequest `json:"rules" validate:"omitempty,dive"`
+	Actor            TaskActor                 `json:"-"`
+}
+
+// UpdateSurveyDistributionRequest keeps the survey template and the recipients of the
+// distribution, the employees listed and matched now are added to them.
+type UpdateSurveyDistributionRequest struct {
+	ID          string                    `json:"id" validate:"required,uuid"`
+	Name        string                    `json:"name" validate:"required"`
+	OpensOn     string                    `json:"opens_on" validate:"required,datetime=2006-01-02"`
+	ClosesOn    string                    `json:"closes_on" validate:"omitempty,datetime=2006-01-02"`
+	EmployeeIDs []string                  `json:"employee_ids" validate:"omitempty,dive,uuid"`
+	Rules       []TemplateTaskRuleRequest `json:"rules" validate:"omitempty,dive"`
+}
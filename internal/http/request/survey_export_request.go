@@ -0,0 +1,14 @@
+package request
+
+// SurveyExportRequest filters the survey responses to export. It is read from the query of a
+// direct export and from the body of an export job. Submitted dates are compared with the last
+// time an answer of the employee task was saved. AllVersions exports every version of the
+// survey of the survey template.
+type SurveyExportRequest struct {
+	Format           string `form:"format" json:"format" validate:"omitempty,survey_export_format_validation"`
+	SurveyTemplateID string `form:"survey_template_id" json:"survey_template_id" validate:"omitempty,uuid"`
+	AllVersions      bool   `form:"all_versions" json:"all_versions" validate:"omitempty"`
+	OrganizationID   string `form:"organization_id" json:"organization_id" validate:"omitempty,uuid"`
+	SubmittedFrom    string `form:"submitted_from" json:"submitted_from" validate:"omitempty,datetime=2006-01-02"`
+	SubmittedTo      string `form:"submitted_to" json:"submitted_to" validate:"omitempty,datetime=2006-01-02"`
+}
@@ -6,6 +6,7 @@ type SurveyResponseRequest struct {
 	QuestionID string          `form:"question_id" validate:"required,uuid"`
 	Answers    []AnswerRequest `form:"answers" validate:"omitempty,dive"`
 	// DeletedAnswerIDs []string        `form:"deleted_answer_ids" validate:"omitempty,dive,uuid"`
+	Actor TaskActor `form:"-"`
 }
 
 type AnswerRequest struct {
@@ -22,6 +23,7 @@ type SurveyResponseBulkRequest struct {
 	Kanban           string              `form:"kanban" validate:"required"`
 	EmployeeTaskID   string              `form:"employee_task_id" validate:"required,uuid"`
 	Answers          []AnswerBulkRequest `form:"answers" validate:"omitempty,dive"`
+	Actor            TaskActor           `form:"-"`
 }
 
 type AnswerBulkRequest struct {
@@ -31,3 +33,12 @@ type AnswerBulkRequest struct {
 	AnswerFile *multipart.FileHeader `form:"answer_file" validate:"omitempty"`
 	AnswerPath string                `form:"answer_path" validate:"omitempty"`
 }
+
+// SurveyDistributionResponseRequest saves the answers of the employee to a survey
+// distribution. Submit hands the survey in, which needs every required question answered.
+type SurveyDistributionResponseRequest struct {
+	SurveyDistributionID string              `form:"survey_distribution_id" validate:"required,uuid"`
+	Submit               bool                `form:"submit"`
+	Answers              []AnswerBulkRequest `form:"answers" validate:"omitempty,dive"`
+	Actor                TaskActor           `form:"-"`
+}
@@ -0,0 +1,18 @@
+package request
+
+import "mime/multipart"
+
+// ExportTemplateBundleRequest selects what goes into a bundle. Survey templates linked to the
+// selected template tasks are always included.
+type ExportTemplateBundleRequest struct {
+	TemplateTaskIDs   []string `json:"template_task_ids" validate:"omitempty,dive,uuid"`
+	SurveyTemplateIDs []string `json:"survey_template_ids" validate:"omitempty,dive,uuid"`
+	OrganizationType  string   `json:"organization_type" validate:"omitempty"`
+	Format            string   `json:"format" validate:"omitempty,oneof=json zip"`
+}
+
+type ImportTemplateBundleRequest struct {
+	File             *multipart.FileHeader `form:"file" validate:"required"`
+	DryRun           bool                  `form:"dry_run"`
+	ConflictStrategy string                `form:"conflict_strategy" validate:"omitempty,template_bundle_conflict_strategy_validation"`
+}
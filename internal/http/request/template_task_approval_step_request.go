@@ -0,0 +1,13 @@
+package request
+
+type TemplateTaskApprovalStepRequest struct {
+	ApproverType       string `json:"approver_type" validate:"required,approval_approver_type_validation"`
+	ApproverEmployeeID string `json:"approver_employee_id" validate:"omitempty,uuid"`
+}
+
+// ReplaceTemplateTaskApprovalStepsRequest sets the approval chain of a template task. Steps are
+// approved in the order they are given.
+type ReplaceTemplateTaskApprovalStepsRequest struct {
+	TemplateTaskID string                            `json:"template_task_id" validate:"required,uuid"`
+	Steps          []TemplateTaskApprovalStepRequest `json:"steps" validate:"omitempty,dive"`
+}
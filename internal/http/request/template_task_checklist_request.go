@@ -1,6 +1,10 @@
 package request
 
 type TemplateTaskChecklistRequest struct {
-	ID   *string `form:"id" validate:"omitempty"`
-	Name string  `form:"name" validate:"required"`
+	ID            *string `form:"id" validate:"omitempty"`
+	Name          string  `form:"name" validate:"required"`
+	SortOrder     *int    `form:"sort_order" validate:"omitempty,min=0"`
+	AssigneeID    *string `form:"assignee_id" validate:"omitempty,uuid"`
+	DueOffsetDays *int    `form:"due_offset_days" validate:"omitempty,min=0"`
+	IsRequired    *string `form:"is_required" validate:"omitempty,oneof=YES NO"`
 }
@@ -1,5 +1,7 @@
 package request
 
+import "mime/multipart"
+
 type CreateTemplateTaskRequest struct {
 	// CoverFile               *multipart.FileHeader           `form:"cover_file" validate:"required"`
 	CoverPath               string                          `form:"cover_path" validate:"required"`
@@ -10,6 +12,9 @@ type CreateTemplateTaskRequest struct {
 	Status                  string                          `form:"status" validate:"required,template_task_status_validation"`
 	Description             string                          `form:"description" validate:"omitempty"`
 	OrganizationType        string                          `form:"organization_type" validate:"required"`
+	Source                  string                          `form:"source" validate:"omitempty,task_source_validation"`
+	Kind                    string                          `form:"kind" validate:"omitempty,task_kind_validation"`
+	PassingScore            *int                            `form:"passing_score" validate:"omitempty,min=0,max=100"`
 	TemplateTaskAttachments []TemplateTaskAttachmentRequest `form:"template_task_attachments" validate:"omitempty,dive"`
 	TemplateTaskChecklists  []TemplateTaskChecklistRequest  `form:"template_task_checklists" validate:"omitempty,dive"`
 }
@@ -25,6 +30,16 @@ type UpdateTemplateTaskRequest struct {
 	Status                  string                          `form:"status" validate:"required,template_task_status_validation"`
 	Description             string                          `form:"description" validate:"omitempty"`
 	OrganizationType        string                          `form:"organization_type" validate:"required"`
+	Source                  string                          `form:"source" validate:"omitempty,task_source_validation"`
+	Kind                    string                          `form:"kind" validate:"omitempty,task_kind_validation"`
+	PassingScore            *int                            `form:"passing_score" validate:"omitempty,min=0,max=100"`
 	TemplateTaskAttachments []TemplateTaskAttachmentRequest `form:"template_task_attachments" validate:"omitempty,dive"`
 	TemplateTaskChecklists  []TemplateTaskChecklistRequest  `form:"template_task_checklists" validate:"omitempty,dive"`
+	// Propagation decides whether open employee tasks follow the change, defaults to NEW_HIRES_ONLY
+	Propagation string `form:"propagation" validate:"omitempty,template_task_version_propagation_validation"`
+	ChangeNote  string `form:"change_note" validate:"omitempty"`
+}
+
+type ImportTemplateTasksRequest struct {
+	File *multipart.FileHeader `form:"file" validate:"required"`
 }
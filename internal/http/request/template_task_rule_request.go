@@ -0,0 +1,13 @@
+package request
+
+type TemplateTaskRuleRequest struct {
+	GroupNumber int    `json:"group_number" validate:"required,min=1"`
+	Criterion   string `json:"criterion" validate:"required,template_task_rule_criterion_validation"`
+	Operator    string `json:"operator" validate:"required,template_task_rule_operator_validation"`
+	Value       string `json:"value" validate:"required"`
+}
+
+type ReplaceTemplateTaskRulesRequest struct {
+	TemplateTaskID string                    `json:"template_task_id" validate:"required,uuid"`
+	Rules          []TemplateTaskRuleRequest `json:"rules" validate:"omitempty,dive"`
+}
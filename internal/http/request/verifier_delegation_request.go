@@ -0,0 +1,24 @@
+package request
+
+import "github.com/google/uuid"
+
+// TaskActor is the logged in employee acting on employee tasks or delegations. It is filled
+// by the handler, never from the request body.
+type TaskActor struct {
+	EmployeeID uuid.UUID `json:"-"`
+	IsAdmin    bool      `json:"-"`
+}
+
+type CreateVerifierDelegationRequest struct {
+	DelegatorID string    `json:"delegator_id" validate:"required,uuid"`
+	DelegateID  string    `json:"delegate_id" validate:"required,uuid"`
+	StartDate   string    `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string    `json:"end_date" validate:"required,datetime=2006-01-02"`
+	Reason      string    `json:"reason" validate:"omitempty"`
+	Actor       TaskActor `json:"-"`
+}
+
+type RevokeVerifierDelegationRequest struct {
+	ID    string    `json:"id" validate:"required,uuid"`
+	Actor TaskActor `json:"-"`
+}
@@ -0,0 +1,12 @@
+package request
+
+type CreateOrUpdateWorkWeekRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required,uuid"`
+	Monday         string `json:"monday" validate:"required,oneof=YES NO"`
+	Tuesday        string `json:"tuesday" validate:"required,oneof=YES NO"`
+	Wednesday      string `json:"wednesday" validate:"required,oneof=YES NO"`
+	Thursday       string `json:"thursday" validate:"required,oneof=YES NO"`
+	Friday         string `json:"friday" validate:"required,oneof=YES NO"`
+	Saturday       string `json:"saturday" validate:"required,oneof=YES NO"`
+	Sunday         string `json:"sunday" validate:"required,oneof=YES NO"`
+}
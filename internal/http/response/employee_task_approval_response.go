@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type EmployeeTaskApprovalResponse struct {
+	ID             uuid.UUID                             `json:"id"`
+	EmployeeTaskID uuid.UUID                             `json:"employee_task_id"`
+	Round          int                                   `json:"round"`
+	StepOrder      int                                   `json:"step_order"`
+	ApproverType   entity.ApprovalApproverTypeEnum       `json:"approver_type"`
+	ApproverID     *uuid.UUID                            `json:"approver_id"`
+	ApproverName   string                                `json:"approver_name"`
+	Status         entity.EmployeeTaskApprovalStatusEnum `json:"status"`
+	ActedBy        *uuid.UUID                            `json:"acted_by"`
+	ActedByName    string                                `json:"acted_by_name"`
+	ActedAt        *time.Time                            `json:"acted_at"`
+	Reason         string                                `json:"reason"`
+	CreatedAt      time.Time                             `json:"created_at"`
+	UpdatedAt      time.Time                             `json:"updated_at"`
+}
+
+// EmployeeTaskApprovalChainResponse sums up the current round of the approval chain of an
+// employee task. EmployeeTaskApprovals holds the steps of every round.
+type EmployeeTaskApprovalChainResponse struct {
+	EmployeeTaskID        uuid.UUID                             `json:"employee_task_id"`
+	Round                 int                                   `json:"round"`
+	Status                entity.EmployeeTaskApprovalStatusEnum `json:"status"`
+	CurrentStepOrder      *int                                  `json:"current_step_order"`
+	EmployeeTaskApprovals []EmployeeTaskApprovalResponse        `json:"employee_task_approvals"`
+}
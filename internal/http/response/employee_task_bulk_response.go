@@ -0,0 +1,18 @@
+package response
+
+// BulkEmployeeTaskItemResponse is the outcome of a bulk operation for one employee task,
+// or for one employee when assigning.
+type BulkEmployeeTaskItemResponse struct {
+	ID           string                `json:"id"`
+	Status       string                `json:"status"`
+	Message      string                `json:"message,omitempty"`
+	EmployeeTask *EmployeeTaskResponse `json:"employee_task,omitempty"`
+}
+
+type BulkEmployeeTaskResponse struct {
+	Total     int                            `json:"total"`
+	Succeeded int                            `json:"succeeded"`
+	Failed    int                            `json:"failed"`
+	Skipped   int                            `json:"skipped"`
+	Items     []BulkEmployeeTaskItemResponse `json:"items"`
+}
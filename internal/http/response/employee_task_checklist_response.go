@@ -13,8 +13,15 @@ type EmployeeTaskChecklistResponse struct {
 	IsChecked      string     `json:"is_checked"`
 	VerifiedBy     *uuid.UUID `json:"verified_by"`
 	MidsuitID      *string    `json:"midsuit_id"`
+	SortOrder      int        `json:"sort_order"`
+	AssigneeID     *uuid.UUID `json:"assignee_id"`
+	DueDate        *time.Time `json:"due_date"`
+	IsRequired     string     `json:"is_required"`
+	CheckedAt      *time.Time `json:"checked_at"`
+	CheckedBy      *uuid.UUID `json:"checked_by"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 
 	VerifiedByName string `json:"verified_by_name"`
+	AssigneeName   string `json:"assignee_name"`
 }
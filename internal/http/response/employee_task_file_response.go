@@ -0,0 +1,21 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type EmployeeTaskFileResponse struct {
+	ID             uuid.UUID                         `json:"id"`
+	EmployeeTaskID uuid.UUID                         `json:"employee_task_id"`
+	Path           string                            `json:"path"`
+	PathOrigin     string                            `json:"path_origin"`
+	Status         entity.EmployeeTaskFileStatusEnum `json:"status"`
+	ReviewedBy     *uuid.UUID                        `json:"reviewed_by"`
+	ReviewedAt     *time.Time                        `json:"reviewed_at"`
+	Notes          string                            `json:"notes"`
+	CreatedAt      time.Time                         `json:"created_at"`
+	UpdatedAt      time.Time                         `json:"updated_at"`
+}
@@ -8,38 +8,53 @@ import (
 )
 
 type EmployeeTaskResponse struct {
-	ID               uuid.UUID                       `json:"id"`
-	CoverPath        *string                         `json:"cover_path"`
-	CoverPathOrigin  *string                         `json:"cover_path_origin"`
-	EmployeeID       *uuid.UUID                      `json:"employee_id"`
-	TemplateTaskID   *uuid.UUID                      `json:"template_task_id"`
-	SurveyTemplateID *uuid.UUID                      `json:"survey_template_id"`
-	VerifiedBy       *uuid.UUID                      `json:"verified_by"`
-	Name             string                          `json:"name"`
-	Priority         entity.EmployeeTaskPriorityEnum `json:"priority"`
-	Description      string                          `json:"description"`
-	StartDate        time.Time                       `json:"start_date"`
-	EndDate          time.Time                       `json:"end_date"`
-	IsDone           string                          `json:"is_done"`
-	Proof            *string                         `json:"proof"`
-	Status           entity.EmployeeTaskStatusEnum   `json:"status"`
-	Kanban           entity.EmployeeTaskKanbanEnum   `json:"kanban"`
-	Notes            string                          `json:"notes"`
-	Source           string                          `json:"source"`
-	IsChecklist      string                          `json:"is_checklist"`
-	Progress         int                             `json:"progress"`
-	ProgressVerified int                             `json:"progress_verified"`
-	MidsuitID        *string                         `json:"midsuit_id"`
-	CreatedAt        time.Time                       `json:"created_at"`
-	UpdatedAt        time.Time                       `json:"updated_at"`
+	ID                    uuid.UUID                       `json:"id"`
+	CoverPath             *string                         `json:"cover_path"`
+	CoverPathOrigin       *string                         `json:"cover_path_origin"`
+	EmployeeID            *uuid.UUID                      `json:"employee_id"`
+	TemplateTaskID        *uuid.UUID                      `json:"template_task_id"`
+	TemplateTaskVersionID *uuid.UUID                      `json:"template_task_version_id"`
+	SurveyTemplateID      *uuid.UUID                      `json:"survey_template_id"`
+	VerifiedBy            *uuid.UUID                      `json:"verified_by"`
+	Name                  string                          `json:"name"`
+	Priority              entity.EmployeeTaskPriorityEnum `json:"priority"`
+	Description           string                          `json:"description"`
+	StartDate             time.Time                       `json:"start_date"`
+	EndDate               time.Time                       `json:"end_date"`
+	IsDone                string                          `json:"is_done"`
+	Proof                 *string                         `json:"proof"`
+	Status                entity.EmployeeTaskStatusEnum   `json:"status"`
+	Kanban                entity.EmployeeTaskKanbanEnum   `json:"kanban"`
+	Notes                 string                          `json:"notes"`
+	Source                string                          `json:"source"`
+	IsChecklist           string                          `json:"is_checklist"`
+	Progress              int                             `json:"progress"`
+	ProgressVerified      int                             `json:"progress_verified"`
+	MidsuitID             *string                         `json:"midsuit_id"`
+	Kind                  entity.TaskKindEnum             `json:"kind"`
+	PassingScore          *int                            `json:"passing_score"`
+	QuizScore             *int                            `json:"quiz_score"`
+	AcknowledgedAt        *time.Time                      `json:"acknowledged_at"`
+	ClosedAt              *time.Time                      `json:"closed_at"`
+	// KindRequirement tells what the kind of the task still waits for, empty once it is met
+	KindRequirement string    `json:"kind_requirement"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 
 	VerifiedByName    string  `json:"verified_by_name"`
 	EmployeeName      string  `json:"employee_name"`
 	EmployeeMidsuitID *string `json:"employee_midsuit_id"`
 
+	// EffectiveVerifiedBy is the delegate of the verifier while a delegation is active,
+	// otherwise the verifier.
+	EffectiveVerifiedBy     *uuid.UUID `json:"effective_verified_by"`
+	EffectiveVerifiedByName string     `json:"effective_verified_by_name"`
+	VerifierDelegationID    *uuid.UUID `json:"verifier_delegation_id"`
+
 	TemplateTask            *TemplateTaskResponse            `json:"template_task"`
 	EmployeeTaskAttachments []EmployeeTaskAttachmentResponse `json:"employee_task_attachments"`
 	EmployeeTaskChecklists  []EmployeeTaskChecklistResponse  `json:"employee_task_checklists"`
+	EmployeeTaskFiles       []EmployeeTaskFileResponse       `json:"employee_task_files"`
 	SurveyTemplate          *SurveyTemplateResponse          `json:"survey_template"`
 }
 
@@ -52,6 +67,7 @@ type EmployeeTaskKanbanResponse struct {
 
 type EmployeeTaskProgressResponse struct {
 	EmployeeID uuid.UUID `json:"employee_id"`
+	Source     string    `json:"source"`
 	TotalTask  int       `json:"total_task"`
 	ToDo       int       `json:"to_do"`
 	InProgress int       `json:"in_progress"`
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type HolidayResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	OrganizationID *uuid.UUID             `json:"organization_id"`
+	Name           string                 `json:"name"`
+	Date           time.Time              `json:"date"`
+	Type           entity.HolidayTypeEnum `json:"type"`
+	Description    string                 `json:"description"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+type ImportHolidayResponse struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+type WorkingDayResponse struct {
+	StartDate   string `json:"start_date"`
+	WorkingDays int    `json:"working_days"`
+	DueDate     string `json:"due_date"`
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type OnboardingBackfillFieldChangeResponse struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type OnboardingBackfillChangeResponse struct {
+	ChangeType       entity.OnboardingBackfillChangeTypeEnum `json:"change_type"`
+	TemplateTaskID   uuid.UUID                               `json:"template_task_id"`
+	TemplateTaskName string                                  `json:"template_task_name"`
+	EmployeeTaskID   *uuid.UUID                              `json:"employee_task_id"`
+	ChecklistName    string                                  `json:"checklist_name,omitempty"`
+	StartDate        string                                  `json:"start_date,omitempty"`
+	EndDate          string                                  `json:"end_date,omitempty"`
+	Fields           []OnboardingBackfillFieldChangeResponse `json:"fields,omitempty"`
+}
+
+type OnboardingBackfillEmployeeResponse struct {
+	EmployeeID uuid.UUID                          `json:"employee_id"`
+	HiringDate string                             `json:"hiring_date"`
+	Changes    []OnboardingBackfillChangeResponse `json:"changes"`
+	Warnings   []string                           `json:"warnings"`
+}
+
+type OnboardingBackfillPreviewResponse struct {
+	OrganizationType string                               `json:"organization_type"`
+	TotalEmployees   int                                  `json:"total_employees"`
+	TotalChanges     int                                  `json:"total_changes"`
+	Employees        []OnboardingBackfillEmployeeResponse `json:"employees"`
+}
+
+type OnboardingBackfillItemResponse struct {
+	ID             uuid.UUID                               `json:"id"`
+	EmployeeID     uuid.UUID                               `json:"employee_id"`
+	TemplateTaskID uuid.UUID                               `json:"template_task_id"`
+	EmployeeTaskID *uuid.UUID                              `json:"employee_task_id"`
+	ChangeType     entity.OnboardingBackfillChangeTypeEnum `json:"change_type"`
+	ChecklistName  string                                  `json:"checklist_name"`
+	Status         entity.OnboardingBackfillItemStatusEnum `json:"status"`
+	Message        string                                  `json:"message"`
+}
+
+type OnboardingBackfillResponse struct {
+	ID                    uuid.UUID                           `json:"id"`
+	OrganizationType      string                              `json:"organization_type"`
+	TemplateTaskVersionID *uuid.UUID                          `json:"template_task_version_id"`
+	Status                entity.OnboardingBackfillStatusEnum `json:"status"`
+	Total                 int                                 `json:"total"`
+	Processed             int                                 `json:"processed"`
+	Succeeded             int                                 `json:"succeeded"`
+	Failed                int                                 `json:"failed"`
+	Skipped               int                                 `json:"skipped"`
+	Progress              float64                             `json:"progress"`
+	StartedAt             *time.Time                          `json:"started_at"`
+	FinishedAt            *time.Time                          `json:"finished_at"`
+	CreatedAt             time.Time                           `json:"created_at"`
+	UpdatedAt             time.Time                           `json:"updated_at"`
+
+	OnboardingBackfillItems []OnboardingBackfillItemResponse `json:"onboarding_backfill_items"`
+}
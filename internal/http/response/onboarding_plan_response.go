@@ -0,0 +1,38 @@
+package response
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type OnboardingPlanResponse struct {
+	EmployeeID       uuid.UUID                           `json:"employee_id"`
+	OrganizationID   *uuid.UUID                          `json:"organization_id"`
+	OrganizationType string                              `json:"organization_type"`
+	JoinedDate       string                              `json:"joined_date"`
+	Source           string                              `json:"source"`
+	LastWorkingDate  string                              `json:"last_working_date,omitempty"`
+	Tasks            []OnboardingPlanTaskResponse        `json:"tasks"`
+	SkippedTasks     []OnboardingPlanSkippedTaskResponse `json:"skipped_tasks"`
+	Warnings         []string                            `json:"warnings"`
+}
+
+type OnboardingPlanTaskResponse struct {
+	TemplateTaskID      uuid.UUID                       `json:"template_task_id"`
+	Name                string                          `json:"name"`
+	Description         string                          `json:"description"`
+	Priority            entity.EmployeeTaskPriorityEnum `json:"priority"`
+	StartDate           string                          `json:"start_date"`
+	EndDate             string                          `json:"end_date"`
+	DueDuration         *int                            `json:"due_duration"`
+	SurveyTemplateID    *uuid.UUID                      `json:"survey_template_id"`
+	SurveyTemplateTitle string                          `json:"survey_template_title"`
+	Checklists          []string                        `json:"checklists"`
+	Attachments         []string                        `json:"attachments"`
+}
+
+type OnboardingPlanSkippedTaskResponse struct {
+	TemplateTaskID uuid.UUID `json:"template_task_id"`
+	Name           string    `json:"name"`
+	Reason         string    `json:"reason"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OnboardingStatusResponse is the outcome of cancelling, pausing or resuming the onboarding
+// of an employee. MidsuitErrors lists the tasks whose change could not be pushed to Midsuit.
+type OnboardingStatusResponse struct {
+	EmployeeID      uuid.UUID  `json:"employee_id"`
+	HiringID        uuid.UUID  `json:"hiring_id"`
+	Status          string     `json:"status"`
+	StatusReason    string     `json:"status_reason"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+	UpdatedTasks    int        `json:"updated_tasks"`
+	DetachedEvents  int64      `json:"detached_events"`
+	MidsuitErrors   []string   `json:"midsuit_errors"`
+}
@@ -23,6 +23,11 @@ type SendFindOrganizationStructureByIDMessageResponse struct {
 	MidsuitID               string `json:"midsuit_id"`
 }
 
+type SendFindEmployeeApproverMessageResponse struct {
+	EmployeeID string `json:"employee_id"`
+	Name       string `json:"name"`
+}
+
 type OrganizationResponse struct {
 	ID                 uuid.UUID `json:"id"`
 	OrganizationTypeID uuid.UUID `json:"organization_type_id"`
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type PolicyDocumentResponse struct {
+	ID                     uuid.UUID                       `json:"id"`
+	Name                   string                          `json:"name"`
+	Description            string                          `json:"description"`
+	Status                 entity.PolicyDocumentStatusEnum `json:"status"`
+	CurrentVersionNumber   int                             `json:"current_version_number"`
+	CurrentVersion         *PolicyDocumentVersionResponse  `json:"current_version"`
+	PolicyDocumentVersions []PolicyDocumentVersionResponse `json:"policy_document_versions"`
+	CreatedAt              time.Time                       `json:"created_at"`
+	UpdatedAt              time.Time                       `json:"updated_at"`
+}
+
+type PolicyDocumentVersionResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	PolicyDocumentID uuid.UUID  `json:"policy_document_id"`
+	VersionNumber    int        `json:"version_number"`
+	Path             string     `json:"path"`
+	PathOrigin       string     `json:"path_origin"`
+	ContentHash      string     `json:"content_hash"`
+	ChangeNote       string     `json:"change_note"`
+	CreatedBy        *uuid.UUID `json:"created_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type PolicyAcknowledgementResponse struct {
+	ID                      uuid.UUID `json:"id"`
+	Sequence                int64     `json:"sequence"`
+	PolicyDocumentID        uuid.UUID `json:"policy_document_id"`
+	PolicyDocumentName      string    `json:"policy_document_name"`
+	PolicyDocumentVersionID uuid.UUID `json:"policy_document_version_id"`
+	VersionNumber           int       `json:"version_number"`
+	EmployeeID              uuid.UUID `json:"employee_id"`
+	EmployeeName            string    `json:"employee_name"`
+	DocumentHash            string    `json:"document_hash"`
+	AcknowledgedAt          time.Time `json:"acknowledged_at"`
+	IPAddress               string    `json:"ip_address"`
+	UserAgent               string    `json:"user_agent"`
+	PreviousHash            string    `json:"previous_hash"`
+	Hash                    string    `json:"hash"`
+}
+
+// PolicyAcknowledgementChainResponse is the result of checking the acknowledgement chain.
+// BrokenAtSequence is the first record that does not match, nil when the chain is intact.
+type PolicyAcknowledgementChainResponse struct {
+	Valid            bool   `json:"valid"`
+	CheckedCount     int64  `json:"checked_count"`
+	LastSequence     int64  `json:"last_sequence"`
+	LastHash         string `json:"last_hash"`
+	BrokenAtSequence *int64 `json:"broken_at_sequence"`
+	Reason           string `json:"reason"`
+}
+
+type PendingPolicyAcknowledgementResponse struct {
+	EmployeeID              uuid.UUID `json:"employee_id"`
+	EmployeeName            string    `json:"employee_name"`
+	HiringDate              time.Time `json:"hiring_date"`
+	LastAcknowledgedVersion *int      `json:"last_acknowledged_version"`
+}
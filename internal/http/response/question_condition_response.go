@@ -0,0 +1,18 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuestionConditionResponse struct {
+	ID                   uuid.UUID `json:"id"`
+	QuestionID           uuid.UUID `json:"question_id"`
+	SourceQuestionID     uuid.UUID `json:"source_question_id"`
+	SourceQuestionNumber int       `json:"source_question_number"`
+	Operator             string    `json:"operator"`
+	Value                string    `json:"value"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
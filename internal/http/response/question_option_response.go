@@ -10,6 +10,8 @@ type QuestionOptionResponse struct {
 	ID         uuid.UUID `json:"id"`
 	QuestionID uuid.UUID `json:"question_id"`
 	OptionText string    `json:"option_text"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	// IsCorrect is left out of the responses shown to the employee answering the survey
+	IsCorrect *bool     `json:"is_correct,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
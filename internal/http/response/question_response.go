@@ -15,10 +15,28 @@ type QuestionResponse struct {
 	IsCompleted      string    `json:"is_completed"`
 	Number           int       `json:"number"`
 	MaxStars         int       `json:"max_stars"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	Points           int       `json:"points"`
+	IsRequired       bool      `json:"is_required"`
+	MinLength        *int      `json:"min_length"`
+	MaxLength        *int      `json:"max_length"`
+	Pattern          *string   `json:"pattern"`
+	AllowedFileTypes []string  `json:"allowed_file_types"`
+	MaxFileSizeKB    *int      `json:"max_file_size_kb"`
+	MinSelections    *int      `json:"min_selections"`
+	MaxSelections    *int      `json:"max_selections"`
+	MinValue         *float64  `json:"min_value"`
+	MaxValue         *float64  `json:"max_value"`
+	MinDate          *string   `json:"min_date"`
+	MaxDate          *string   `json:"max_date"`
+	ConditionMatch   string    `json:"condition_match"`
+	// IsHidden is set when the display conditions are not met by the answers of the employee task
+	IsHidden  bool      `json:"is_hidden"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
-	AnswerType      *AnswerTypeResponse      `json:"answer_type"`
-	QuestionOptions []QuestionOptionResponse `json:"question_options"`
-	SurveyResponses []SurveyResponseResponse `json:"survey_responses"`
+	AnswerType        *AnswerTypeResponse         `json:"answer_type"`
+	QuestionOptions   []QuestionOptionResponse    `json:"question_options"`
+	MatrixRows        []QuestionMatrixRowResponse `json:"matrix_rows"`
+	SurveyResponses   []SurveyResponseResponse    `json:"survey_responses"`
+	DisplayConditions []QuestionConditionResponse `json:"display_conditions"`
 }
@@ -0,0 +1,136 @@
+package response
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+// SurveyAnalyticsResponse aggregates the answers to a survey template. Assigned counts the
+// employee tasks linked to the template that match the filters, Responded the ones with at
+// least one answer in the submitted date range. Suppressed is set when a confidential survey
+// has fewer respondents than MinGroupSize, its questions then only carry the answer counts.
+type SurveyAnalyticsResponse struct {
+	SurveyTemplateID uuid.UUID                          `json:"survey_template_id"`
+	Title            string                             `json:"title"`
+	VersionNumber    int                                `json:"version_number"`
+	Assigned         int                                `json:"assigned"`
+	Responded        int                                `json:"responded"`
+	ResponseRate     float64                            `json:"response_rate"`
+	Anonymity        entity.SurveyTemplateAnonymityEnum `json:"anonymity"`
+	MinGroupSize     *int                               `json:"min_group_size,omitempty"`
+	Suppressed       bool                               `json:"suppressed"`
+	Questions        []SurveyQuestionAnalyticsResponse  `json:"questions"`
+}
+
+// SurveyQuestionAnalyticsResponse holds the figures of one question. ResponseRate is the share
+// of responding employee tasks that answered the question. Options are filled for choice
+// questions, Rating for rating questions, Numeric, Date, Matrix and Ranking for the questions
+// of those answer types.
+type SurveyQuestionAnalyticsResponse struct {
+	QuestionID   uuid.UUID                          `json:"question_id"`
+	Number       int                                `json:"number"`
+	Question     string                             `json:"question"`
+	AnswerType   string                             `json:"answer_type"`
+	Answered     int                                `json:"answered"`
+	ResponseRate float64                            `json:"response_rate"`
+	Options      []SurveyOptionAnalyticsResponse    `json:"options,omitempty"`
+	Rating       *SurveyRatingAnalyticsResponse     `json:"rating,omitempty"`
+	Numeric      *SurveyNumberAnalyticsResponse     `json:"numeric,omitempty"`
+	Date         *SurveyDateAnalyticsResponse       `json:"date,omitempty"`
+	Matrix       []SurveyMatrixRowAnalyticsResponse `json:"matrix,omitempty"`
+	Ranking      []SurveyRankingAnalyticsResponse   `json:"ranking,omitempty"`
+}
+
+// SurveyOptionAnalyticsResponse counts the employee tasks that chose an option. Answers that
+// match no option are counted under an option without id.
+type SurveyOptionAnalyticsResponse struct {
+	OptionID   *uuid.UUID `json:"option_id"`
+	Option     string     `json:"option"`
+	Count      int        `json:"count"`
+	Percentage float64    `json:"percentage"`
+}
+
+type SurveyRatingAnalyticsResponse struct {
+	MaxStars       int                          `json:"max_stars"`
+	Count          int                          `json:"count"`
+	Mean           float64                      `json:"mean"`
+	Median         float64                      `json:"median"`
+	MeanPercentage float64                      `json:"mean_percentage"`
+	Histogram      []SurveyRatingBucketResponse `json:"histogram"`
+	Nps            *SurveyNpsResponse           `json:"nps"`
+}
+
+type SurveyRatingBucketResponse struct {
+	Stars int `json:"stars"`
+	Count int `json:"count"`
+}
+
+// SurveyNpsResponse scores a rating question the way a net promoter score is, after scaling
+// the stars to 0-10. Score ranges from -100 to 100.
+type SurveyNpsResponse struct {
+	Promoters  int     `json:"promoters"`
+	Passives   int     `json:"passives"`
+	Detractors int     `json:"detractors"`
+	Score      float64 `json:"score"`
+}
+
+type SurveyNumberAnalyticsResponse struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// SurveyDateAnalyticsResponse spans the answered dates. AverageDays is the mean length of the
+// answered ranges, both ends included, and only set for date range questions.
+type SurveyDateAnalyticsResponse struct {
+	Count       int      `json:"count"`
+	Earliest    *string  `json:"earliest"`
+	Latest      *string  `json:"latest"`
+	AverageDays *float64 `json:"average_days,omitempty"`
+}
+
+// SurveyMatrixRowAnalyticsResponse is the distribution of the columns chosen for a row.
+type SurveyMatrixRowAnalyticsResponse struct {
+	RowID    *uuid.UUID                      `json:"row_id"`
+	Row      string                          `json:"row"`
+	Answered int                             `json:"answered"`
+	Options  []SurveyOptionAnalyticsResponse `json:"options"`
+}
+
+// SurveyRankingAnalyticsResponse is where an option was ranked, 1 being first. The options are
+// listed from the best average rank.
+type SurveyRankingAnalyticsResponse struct {
+	OptionID    *uuid.UUID `json:"option_id"`
+	Option      string     `json:"option"`
+	Count       int        `json:"count"`
+	AverageRank float64    `json:"average_rank"`
+	FirstPlace  int        `json:"first_place"`
+}
+
+// SurveyVersionAnalyticsResponse compares the versions of a survey. Versions holds the figures
+// of every version with the same filters, Questions lines up the questions of the versions that
+// have the same text.
+type SurveyVersionAnalyticsResponse struct {
+	SurveyNumber string                           `json:"survey_number"`
+	Versions     []SurveyAnalyticsResponse        `json:"versions"`
+	Questions    []SurveyQuestionVersionsResponse `json:"questions"`
+}
+
+type SurveyQuestionVersionsResponse struct {
+	Question string                          `json:"question"`
+	Versions []SurveyQuestionVersionResponse `json:"versions"`
+}
+
+// SurveyQuestionVersionResponse is a question in one version. Mean is the mean rating or
+// number, nil for the other answer types and for suppressed versions.
+type SurveyQuestionVersionResponse struct {
+	VersionNumber int       `json:"version_number"`
+	QuestionID    uuid.UUID `json:"question_id"`
+	Number        int       `json:"number"`
+	AnswerType    string    `json:"answer_type"`
+	Answered      int       `json:"answered"`
+	ResponseRate  float64   `json:"response_rate"`
+	Mean          *float64  `json:"mean"`
+}
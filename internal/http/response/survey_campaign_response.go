@@ -0,0 +1,62 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type SurveyCampaignResponse struct {
+	ID                  uuid.UUID                       `json:"id"`
+	Name                string                          `json:"name"`
+	SurveyTemplateID    uuid.UUID                       `json:"survey_template_id"`
+	SurveyNumber        string                          `json:"survey_number"`
+	SurveyTemplateTitle string                          `json:"survey_template_title"`
+	Status              entity.SurveyCampaignStatusEnum `json:"status"`
+	StartDate           time.Time                       `json:"start_date"`
+	ResponseDays        int                             `json:"response_days"`
+	ReminderDays        *int                            `json:"reminder_days"`
+	OffsetDays          []int                           `json:"offset_days"`
+	Rules               []SurveyCampaignRuleResponse    `json:"rules"`
+	CreatedBy           *uuid.UUID                      `json:"created_by"`
+	CreatedAt           time.Time                       `json:"created_at"`
+	UpdatedAt           time.Time                       `json:"updated_at"`
+}
+
+type SurveyCampaignRuleResponse struct {
+	ID          uuid.UUID                            `json:"id"`
+	GroupNumber int                                  `json:"group_number"`
+	Criterion   entity.TemplateTaskRuleCriterionEnum `json:"criterion"`
+	Operator    entity.TemplateTaskRuleOperatorEnum  `json:"operator"`
+	Value       string                               `json:"value"`
+}
+
+type SurveyCampaignDispatchResponse struct {
+	ID               uuid.UUID                               `json:"id"`
+	SurveyCampaignID uuid.UUID                               `json:"survey_campaign_id"`
+	EmployeeHiringID uuid.UUID                               `json:"employee_hiring_id"`
+	EmployeeID       uuid.UUID                               `json:"employee_id"`
+	EmployeeName     string                                  `json:"employee_name"`
+	EmployeeTaskID   *uuid.UUID                              `json:"employee_task_id"`
+	OffsetDays       int                                     `json:"offset_days"`
+	ScheduledOn      time.Time                               `json:"scheduled_on"`
+	DueDate          *time.Time                              `json:"due_date"`
+	Status           entity.SurveyCampaignDispatchStatusEnum `json:"status"`
+	Message          string                                  `json:"message"`
+	SentAt           *time.Time                              `json:"sent_at"`
+	RemindedAt       *time.Time                              `json:"reminded_at"`
+	ClosedAt         *time.Time                              `json:"closed_at"`
+	CreatedAt        time.Time                               `json:"created_at"`
+	UpdatedAt        time.Time                               `json:"updated_at"`
+}
+
+// SurveyCampaignRunResponse counts what a run of the survey campaigns did.
+type SurveyCampaignRunResponse struct {
+	Sent      int `json:"sent"`
+	Skipped   int `json:"skipped"`
+	Reminded  int `json:"reminded"`
+	Completed int `json:"completed"`
+	Expired   int `json:"expired"`
+	Failed    int `json:"failed"`
+}
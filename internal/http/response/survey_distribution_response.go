@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+// SurveyDistributionResponse counts the recipients of the distribution and the ones who
+// submitted their survey.
+type SurveyDistributionResponse struct {
+	ID                  uuid.UUID                           `json:"id"`
+	Name                string                              `json:"name"`
+	SurveyTemplateID    uuid.UUID                           `json:"survey_template_id"`
+	SurveyNumber        string                              `json:"survey_number"`
+	SurveyTemplateTitle string                              `json:"survey_template_title"`
+	VersionNumber       int                                 `json:"version_number"`
+	Status              entity.SurveyDistributionStatusEnum `json:"status"`
+	OpensOn             time.Time                           `json:"opens_on"`
+	ClosesOn            *time.Time                          `json:"closes_on"`
+	ClosedAt            *time.Time                          `json:"closed_at"`
+	Rules               []SurveyDistributionRuleResponse    `json:"rules"`
+	Recipients          int                                 `json:"recipients"`
+	Submitted           int                                 `json:"submitted"`
+	CreatedBy           *uuid.UUID                          `json:"created_by"`
+	CreatedAt           time.Time                           `json:"created_at"`
+	UpdatedAt           time.Time                           `json:"updated_at"`
+}
+
+type SurveyDistributionRuleResponse struct {
+	ID          uuid.UUID                            `json:"id"`
+	GroupNumber int                                  `json:"group_number"`
+	Criterion   entity.TemplateTaskRuleCriterionEnum `json:"criterion"`
+	Operator    entity.TemplateTaskRuleOperatorEnum  `json:"operator"`
+	Value       string                               `json:"value"`
+}
+
+type SurveyDistributionRecipientResponse struct {
+	ID                   uuid.UUID                                    `json:"id"`
+	SurveyDistributionID uuid.UUID                                    `json:"survey_distribution_id"`
+	EmployeeID           uuid.UUID                                    `json:"employee_id"`
+	EmployeeName         string                                       `json:"employee_name"`
+	Status               entity.SurveyDistributionRecipientStatusEnum `json:"status"`
+	SubmittedAt          *time.Time                                   `json:"submitted_at"`
+	SurveyDistribution   *SurveyDistributionResponse                  `json:"survey_distribution,omitempty"`
+	CreatedAt            time.Time                                    `json:"created_at"`
+	UpdatedAt            time.Time                                    `json:"updated_at"`
+}
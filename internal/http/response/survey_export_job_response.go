@@ -0,0 +1,32 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+// SurveyExportJobResponse reports the progress of an export job. DownloadURL is set once the
+// job completed.
+type SurveyExportJobResponse struct {
+	ID               uuid.UUID                        `json:"id"`
+	Format           entity.SurveyExportFormatEnum    `json:"format"`
+	SurveyTemplateID *uuid.UUID                       `json:"survey_template_id"`
+	AllVersions      bool                             `json:"all_versions"`
+	OrganizationID   *uuid.UUID                       `json:"organization_id"`
+	SubmittedFrom    *time.Time                       `json:"submitted_from"`
+	SubmittedTo      *time.Time                       `json:"submitted_to"`
+	Status           entity.SurveyExportJobStatusEnum `json:"status"`
+	Total            int                              `json:"total"`
+	Processed        int                              `json:"processed"`
+	Rows             int                              `json:"rows"`
+	Progress         float64                          `json:"progress"`
+	DownloadURL      string                           `json:"download_url"`
+	Message          string                           `json:"message"`
+	RequestedBy      *uuid.UUID                       `json:"requested_by"`
+	StartedAt        *time.Time                       `json:"started_at"`
+	FinishedAt       *time.Time                       `json:"finished_at"`
+	CreatedAt        time.Time                        `json:"created_at"`
+	UpdatedAt        time.Time                        `json:"updated_at"`
+}
@@ -0,0 +1,48 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SurveyQuizAttemptResponse struct {
+	ID               uuid.UUID                         `json:"id"`
+	SurveyTemplateID uuid.UUID                         `json:"survey_template_id"`
+	EmployeeTaskID   uuid.UUID                         `json:"employee_task_id"`
+	EmployeeID       *uuid.UUID                        `json:"employee_id"`
+	EmployeeName     string                            `json:"employee_name"`
+	AttemptNumber    int                               `json:"attempt_number"`
+	Score            int                               `json:"score"`
+	MaxScore         int                               `json:"max_score"`
+	Percentage       int                               `json:"percentage"`
+	PassingScore     *int                              `json:"passing_score"`
+	Passed           bool                              `json:"passed"`
+	SubmittedAt      time.Time                         `json:"submitted_at"`
+	Answers          []SurveyQuizAttemptAnswerResponse `json:"answers"`
+}
+
+type SurveyQuizAttemptAnswerResponse struct {
+	ID         uuid.UUID `json:"id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Question   string    `json:"question"`
+	Number     int       `json:"number"`
+	Answer     string    `json:"answer"`
+	IsCorrect  bool      `json:"is_correct"`
+	Points     int       `json:"points"`
+	MaxPoints  int       `json:"max_points"`
+}
+
+// SurveyQuizResultResponse sums up the attempts of an employee task at a quiz. AttemptsLeft is
+// nil when the attempts are unlimited.
+type SurveyQuizResultResponse struct {
+	EmployeeTaskID   uuid.UUID                   `json:"employee_task_id"`
+	SurveyTemplateID uuid.UUID                   `json:"survey_template_id"`
+	PassingScore     *int                        `json:"passing_score"`
+	MaxAttempts      *int                        `json:"max_attempts"`
+	AttemptsUsed     int                         `json:"attempts_used"`
+	AttemptsLeft     *int                        `json:"attempts_left"`
+	Passed           bool                        `json:"passed"`
+	BestPercentage   *int                        `json:"best_percentage"`
+	Attempts         []SurveyQuizAttemptResponse `json:"attempts"`
+}
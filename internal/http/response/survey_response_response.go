@@ -7,12 +7,21 @@ import (
 )
 
 type SurveyResponseResponse struct {
-	ID               uuid.UUID `json:"id"`
-	SurveyTemplateID uuid.UUID `json:"survey_template_id"`
-	EmployeeTaskID   uuid.UUID `json:"employee_task_id"`
-	QuestionID       uuid.UUID `json:"question_id"`
-	Answer           string    `json:"answer"`
-	AnswerFile       string    `json:"answer_file"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID                            uuid.UUID  `json:"id"`
+	SurveyTemplateID              uuid.UUID  `json:"survey_template_id"`
+	EmployeeTaskID                *uuid.UUID `json:"employee_task_id"`
+	SurveyDistributionRecipientID *uuid.UUID `json:"survey_distribution_recipient_id"`
+	QuestionID                    uuid.UUID  `json:"question_id"`
+	Answer                        string     `json:"answer"`
+	AnswerFile                    string     `json:"answer_file"`
+	CreatedAt                     time.Time  `json:"created_at"`
+	UpdatedAt                     time.Time  `json:"updated_at"`
+}
+
+// SurveyAnswerFieldErrorResponse is an answer that broke a rule of its question. Field is the
+// form field of the answer, or "answers" when the rule is about all answers to the question.
+type SurveyAnswerFieldErrorResponse struct {
+	Field      string    `json:"field"`
+	QuestionID uuid.UUID `json:"question_id"`
+	Message    string    `json:"message"`
 }
@@ -8,12 +8,19 @@ import (
 )
 
 type SurveyTemplateResponse struct {
-	ID           uuid.UUID                       `json:"id"`
-	SurveyNumber string                          `json:"survey_number"`
-	Title        string                          `json:"title"`
-	Status       entity.SurveyTemplateStatusEnum `json:"status"`
-	CreatedAt    time.Time                       `json:"created_at"`
-	UpdatedAt    time.Time                       `json:"updated_at"`
+	ID            uuid.UUID                          `json:"id"`
+	SurveyNumber  string                             `json:"survey_number"`
+	Title         string                             `json:"title"`
+	Status        entity.SurveyTemplateStatusEnum    `json:"status"`
+	VersionNumber int                                `json:"version_number"`
+	PublishedAt   *time.Time                         `json:"published_at"`
+	IsQuiz        bool                               `json:"is_quiz"`
+	PassingScore  *int                               `json:"passing_score"`
+	MaxAttempts   *int                               `json:"max_attempts"`
+	Anonymity     entity.SurveyTemplateAnonymityEnum `json:"anonymity"`
+	MinGroupSize  *int                               `json:"min_group_size"`
+	CreatedAt     time.Time                          `json:"created_at"`
+	UpdatedAt     time.Time                          `json:"updated_at"`
 
 	Questions []QuestionResponse `json:"questions"`
 }
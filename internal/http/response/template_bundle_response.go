@@ -0,0 +1,105 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+// TemplateBundleResponse is the portable document written by an export and read by an import.
+// Records reference each other by ref, the id they had in the exporting environment.
+type TemplateBundleResponse struct {
+	FormatVersion   int                                    `json:"format_version"`
+	ExportedAt      time.Time                              `json:"exported_at"`
+	SurveyTemplates []TemplateBundleSurveyTemplateResponse `json:"survey_templates"`
+	TemplateTasks   []TemplateBundleTemplateTaskResponse   `json:"template_tasks"`
+}
+
+type TemplateBundleSurveyTemplateResponse struct {
+	Ref          string                           `json:"ref"`
+	Title        string                           `json:"title"`
+	Status       string                           `json:"status"`
+	IsQuiz       bool                             `json:"is_quiz"`
+	PassingScore *int                             `json:"passing_score"`
+	MaxAttempts  *int                             `json:"max_attempts"`
+	Anonymity    string                           `json:"anonymity"`
+	MinGroupSize *int                             `json:"min_group_size"`
+	Questions    []TemplateBundleQuestionResponse `json:"questions"`
+}
+
+type TemplateBundleQuestionResponse struct {
+	Number            int                                       `json:"number"`
+	Question          string                                    `json:"question"`
+	AnswerType        string                                    `json:"answer_type"`
+	MaxStars          int                                       `json:"max_stars"`
+	Points            int                                       `json:"points"`
+	Attachment        *string                                   `json:"attachment"`
+	Options           []TemplateBundleQuestionOptionResponse    `json:"options"`
+	IsRequired        bool                                      `json:"is_required"`
+	MinLength         *int                                      `json:"min_length"`
+	MaxLength         *int                                      `json:"max_length"`
+	Pattern           *string                                   `json:"pattern"`
+	AllowedFileTypes  []string                                  `json:"allowed_file_types"`
+	MaxFileSizeKB     *int                                      `json:"max_file_size_kb"`
+	MinSelections     *int                                      `json:"min_selections"`
+	MaxSelections     *int                                      `json:"max_selections"`
+	MinValue          *float64                                  `json:"min_value"`
+	MaxValue          *float64                                  `json:"max_value"`
+	MinDate           *string                                   `json:"min_date"`
+	MaxDate           *string                                   `json:"max_date"`
+	MatrixRows        []string                                  `json:"matrix_rows"`
+	ConditionMatch    string                                    `json:"condition_match"`
+	DisplayConditions []TemplateBundleQuestionConditionResponse `json:"display_conditions"`
+}
+
+type TemplateBundleQuestionOptionResponse struct {
+	OptionText string `json:"option_text"`
+	IsCorrect  bool   `json:"is_correct"`
+}
+
+// TemplateBundleQuestionConditionResponse points at its source question by number.
+type TemplateBundleQuestionConditionResponse struct {
+	SourceQuestionNumber int    `json:"source_question_number"`
+	Operator             string `json:"operator"`
+	Value                string `json:"value"`
+}
+
+type TemplateBundleTemplateTaskResponse struct {
+	Ref               string                                        `json:"ref"`
+	Name              string                                        `json:"name"`
+	Description       string                                        `json:"description"`
+	Priority          string                                        `json:"priority"`
+	DueDuration       *int                                          `json:"due_duration"`
+	Status            string                                        `json:"status"`
+	Source            string                                        `json:"source,omitempty"`
+	OrganizationType  string                                        `json:"organization_type"`
+	CoverPath         *string                                       `json:"cover_path"`
+	SurveyTemplateRef string                                        `json:"survey_template_ref"`
+	Checklists        []string                                      `json:"checklists"`
+	Attachments       []string                                      `json:"attachments"`
+	Rules             []entity.TemplateTaskSnapshotRule             `json:"rules"`
+	ApprovalSteps     []entity.TemplateTaskSnapshotApprovalStep     `json:"approval_steps,omitempty"`
+	ChecklistSettings []entity.TemplateTaskSnapshotChecklistSetting `json:"checklist_settings,omitempty"`
+	Kind              string                                        `json:"kind,omitempty"`
+	PassingScore      *int                                          `json:"passing_score,omitempty"`
+}
+
+type TemplateBundleImportItemResponse struct {
+	Ref        string     `json:"ref"`
+	Name       string     `json:"name"`
+	Action     string     `json:"action"`
+	ExistingID *uuid.UUID `json:"existing_id"`
+	NewID      *uuid.UUID `json:"new_id"`
+	Message    string     `json:"message,omitempty"`
+}
+
+type TemplateBundleImportResponse struct {
+	DryRun           bool                               `json:"dry_run"`
+	FormatVersion    int                                `json:"format_version"`
+	ConflictStrategy string                             `json:"conflict_strategy"`
+	SurveyTemplates  []TemplateBundleImportItemResponse `json:"survey_templates"`
+	TemplateTasks    []TemplateBundleImportItemResponse `json:"template_tasks"`
+	Files            []TemplateBundleImportItemResponse `json:"files"`
+	Errors           []string                           `json:"errors"`
+}
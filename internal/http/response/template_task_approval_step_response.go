@@ -0,0 +1,18 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type TemplateTaskApprovalStepResponse struct {
+	ID                 uuid.UUID                       `json:"id"`
+	TemplateTaskID     uuid.UUID                       `json:"template_task_id"`
+	StepOrder          int                             `json:"step_order"`
+	ApproverType       entity.ApprovalApproverTypeEnum `json:"approver_type"`
+	ApproverEmployeeID *uuid.UUID                      `json:"approver_employee_id"`
+	CreatedAt          time.Time                       `json:"created_at"`
+	UpdatedAt          time.Time                       `json:"updated_at"`
+}
@@ -7,9 +7,13 @@ import (
 )
 
 type TemplateTaskChecklistResponse struct {
-	ID             uuid.UUID `json:"id"`
-	TemplateTaskID uuid.UUID `json:"template_task_id"`
-	Name           string    `json:"name"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             uuid.UUID  `json:"id"`
+	TemplateTaskID uuid.UUID  `json:"template_task_id"`
+	Name           string     `json:"name"`
+	SortOrder      int        `json:"sort_order"`
+	AssigneeID     *uuid.UUID `json:"assignee_id"`
+	DueOffsetDays  *int       `json:"due_offset_days"`
+	IsRequired     string     `json:"is_required"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
@@ -19,10 +19,31 @@ type TemplateTaskResponse struct {
 	Description      string                          `json:"description"`
 	Source           string                          `json:"source"`
 	OrganizationType string                          `json:"organization_type"`
+	Kind             entity.TaskKindEnum             `json:"kind"`
+	PassingScore     *int                            `json:"passing_score"`
 	CreatedAt        time.Time                       `json:"created_at"`
 	UpdatedAt        time.Time                       `json:"updated_at"`
 
-	TemplateTaskAttachments []TemplateTaskAttachmentResponse `json:"template_task_attachments"`
-	TemplateTaskChecklists  []TemplateTaskChecklistResponse  `json:"template_task_checklists"`
-	SurveyTemplate          *SurveyTemplateResponse          `json:"survey_template"`
+	TemplateTaskAttachments   []TemplateTaskAttachmentResponse   `json:"template_task_attachments"`
+	TemplateTaskChecklists    []TemplateTaskChecklistResponse    `json:"template_task_checklists"`
+	TemplateTaskRules         []TemplateTaskRuleResponse         `json:"template_task_rules"`
+	TemplateTaskApprovalSteps []TemplateTaskApprovalStepResponse `json:"template_task_approval_steps"`
+	SurveyTemplate            *SurveyTemplateResponse            `json:"survey_template"`
+	TemplateTaskVersion       *TemplateTaskVersionResponse       `json:"template_task_version,omitempty"`
+	OnboardingBackfill        *OnboardingBackfillResponse        `json:"onboarding_backfill,omitempty"`
+}
+
+type ImportTemplateTaskRowErrorResponse struct {
+	Sheet    string   `json:"sheet"`
+	Row      int      `json:"row"`
+	Messages []string `json:"messages"`
+}
+
+type ImportTemplateTaskResponse struct {
+	TotalTasks      int                                  `json:"total_tasks"`
+	TotalChecklists int                                  `json:"total_checklists"`
+	Created         int                                  `json:"created"`
+	Errors          []ImportTemplateTaskRowErrorResponse `json:"errors"`
+	ErrorReportURL  string                               `json:"error_report_url,omitempty"`
+	TemplateTasks   []TemplateTaskResponse               `json:"template_tasks"`
 }
@@ -0,0 +1,19 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type TemplateTaskRuleResponse struct {
+	ID             uuid.UUID                            `json:"id"`
+	TemplateTaskID uuid.UUID                            `json:"template_task_id"`
+	GroupNumber    int                                  `json:"group_number"`
+	Criterion      entity.TemplateTaskRuleCriterionEnum `json:"criterion"`
+	Operator       entity.TemplateTaskRuleOperatorEnum  `json:"operator"`
+	Value          string                               `json:"value"`
+	CreatedAt      time.Time                            `json:"created_at"`
+	UpdatedAt      time.Time                            `json:"updated_at"`
+}
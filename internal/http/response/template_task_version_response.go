@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type TemplateTaskVersionResponse struct {
+	ID             uuid.UUID                                 `json:"id"`
+	TemplateTaskID uuid.UUID                                 `json:"template_task_id"`
+	VersionNumber  int                                       `json:"version_number"`
+	Propagation    entity.TemplateTaskVersionPropagationEnum `json:"propagation"`
+	ChangeNote     string                                    `json:"change_note"`
+	Snapshot       *entity.TemplateTaskSnapshot              `json:"snapshot"`
+	CreatedAt      time.Time                                 `json:"created_at"`
+}
+
+type TemplateTaskVersionFieldChangeResponse struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type TemplateTaskVersionDiffResponse struct {
+	TemplateTaskID     uuid.UUID                                `json:"template_task_id"`
+	FromVersion        int                                      `json:"from_version"`
+	ToVersion          int                                      `json:"to_version"`
+	Fields             []TemplateTaskVersionFieldChangeResponse `json:"fields"`
+	AddedChecklists    []string                                 `json:"added_checklists"`
+	RemovedChecklists  []string                                 `json:"removed_checklists"`
+	AddedAttachments   []string                                 `json:"added_attachments"`
+	RemovedAttachments []string                                 `json:"removed_attachments"`
+	AddedRules         []entity.TemplateTaskSnapshotRule        `json:"added_rules"`
+	RemovedRules       []entity.TemplateTaskSnapshotRule        `json:"removed_rules"`
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VerifierDelegationResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	DelegatorID   uuid.UUID  `json:"delegator_id"`
+	DelegatorName string     `json:"delegator_name"`
+	DelegateID    uuid.UUID  `json:"delegate_id"`
+	DelegateName  string     `json:"delegate_name"`
+	StartDate     time.Time  `json:"start_date"`
+	EndDate       time.Time  `json:"end_date"`
+	Reason        string     `json:"reason"`
+	IsActive      bool       `json:"is_active"`
+	CreatedBy     *uuid.UUID `json:"created_by"`
+	RevokedAt     *time.Time `json:"revoked_at"`
+	RevokedBy     *uuid.UUID `json:"revoked_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type EmployeeTaskHistoryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	EmployeeTaskID uuid.UUID  `json:"employee_task_id"`
+	Action         string     `json:"action"`
+	FromEmployeeID *uuid.UUID `json:"from_employee_id"`
+	ToEmployeeID   *uuid.UUID `json:"to_employee_id"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	Reason         string     `json:"reason"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
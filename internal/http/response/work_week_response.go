@@ -0,0 +1,21 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkWeekResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Monday         string    `json:"monday"`
+	Tuesday        string    `json:"tuesday"`
+	Wednesday      string    `json:"wednesday"`
+	Thursday       string    `json:"thursday"`
+	Friday         string    `json:"friday"`
+	Saturday       string    `json:"saturday"`
+	Sunday         string    `json:"sunday"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -25,6 +25,13 @@ type RouteConfig struct {
 	AnswerTypeHandler             handler.IAnswerTypeHandler
 	SurveyTemplateHandler         handler.ISurveyTemplateHandler
 	SurveyResponseHandler         handler.ISurveyResponseHandler
+	HolidayHandler                handler.IHolidayHandler
+	WorkWeekHandler               handler.IWorkWeekHandler
+	TemplateBundleHandler         handler.ITemplateBundleHandler
+	VerifierDelegationHandler     handler.IVerifierDelegationHandler
+	PolicyDocumentHandler         handler.IPolicyDocumentHandler
+	SurveyCampaignHandler         handler.ISurveyCampaignHandler
+	SurveyDistributionHandler     handler.ISurveyDistributionHandler
 }
 
 func (c *RouteConfig) SetupRoutes() {
@@ -62,9 +69,16 @@ func (c *RouteConfig) SetupAPIRoutes() {
 			templateTaskRoute := apiRoute.Group("/template-tasks")
 			{
 				templateTaskRoute.GET("", c.TemplateTaskHandler.FindAllPaginated)
+				templateTaskRoute.GET("/import/template", c.TemplateTaskHandler.DownloadImportTemplate)
 				templateTaskRoute.GET("/:id", c.TemplateTaskHandler.FindByID)
+				templateTaskRoute.GET("/:id/versions", c.TemplateTaskHandler.FindAllVersions)
+				templateTaskRoute.GET("/:id/versions/diff", c.TemplateTaskHandler.DiffVersions)
+				templateTaskRoute.GET("/versions/:version_id", c.TemplateTaskHandler.FindVersionByID)
 				templateTaskRoute.POST("", c.TemplateTaskHandler.CreateTemplateTask)
+				templateTaskRoute.POST("/import", c.TemplateTaskHandler.ImportTemplateTasks)
 				templateTaskRoute.PUT("/update", c.TemplateTaskHandler.UpdateTemplateTask)
+				templateTaskRoute.PUT("/rules", c.TemplateTaskHandler.ReplaceTemplateTaskRules)
+				templateTaskRoute.PUT("/approval-steps", c.TemplateTaskHandler.ReplaceTemplateTaskApprovalSteps)
 				templateTaskRoute.DELETE("/:id", c.TemplateTaskHandler.DeleteTemplateTask)
 			}
 			// template task attachments
@@ -84,9 +98,34 @@ func (c *RouteConfig) SetupAPIRoutes() {
 				employeeTaskRoute.GET("/count", c.EmployeeTaskHandler.CountByKanbanAndEmployeeID)
 				employeeTaskRoute.GET("/employee-kanban/count", c.EmployeeTaskHandler.CountKanbanProgressByEmployeeID)
 				employeeTaskRoute.GET("/response/:id", c.EmployeeTaskHandler.FindByIDForResponse)
+				employeeTaskRoute.GET("/backfill", c.EmployeeTaskHandler.FindAllOnboardingBackfillsPaginated)
+				employeeTaskRoute.GET("/backfill/:id", c.EmployeeTaskHandler.FindOnboardingBackfillByID)
 				employeeTaskRoute.GET("/:id", c.EmployeeTaskHandler.FindByID)
+				employeeTaskRoute.GET("/:id/histories", c.EmployeeTaskHandler.FindAllHistoriesByEmployeeTaskID)
+				employeeTaskRoute.GET("/:id/approvals", c.EmployeeTaskHandler.FindApprovalsByEmployeeTaskID)
 				employeeTaskRoute.POST("", c.EmployeeTaskHandler.CreateEmployeeTask)
 				employeeTaskRoute.POST("/midsuit", c.EmployeeTaskHandler.CreateEmployeeTaskMidsuit)
+				employeeTaskRoute.POST("/recruitment/preview", c.EmployeeTaskHandler.PreviewEmployeeTasksForRecruitment)
+				employeeTaskRoute.POST("/backfill/preview", c.EmployeeTaskHandler.PreviewOnboardingBackfill)
+				employeeTaskRoute.POST("/backfill", c.EmployeeTaskHandler.ApplyOnboardingBackfill)
+				employeeTaskRoute.POST("/bulk/assign", c.EmployeeTaskHandler.BulkAssignEmployeeTasks)
+				employeeTaskRoute.POST("/bulk/kanban", c.EmployeeTaskHandler.BulkMoveEmployeeTasks)
+				employeeTaskRoute.POST("/bulk/verify", c.EmployeeTaskHandler.BulkVerifyEmployeeTasks)
+				employeeTaskRoute.POST("/bulk/verifier", c.EmployeeTaskHandler.BulkReassignVerifier)
+				employeeTaskRoute.POST("/bulk/delete", c.EmployeeTaskHandler.BulkDeleteEmployeeTasks)
+				employeeTaskRoute.POST("/offboarding", c.EmployeeTaskHandler.CreateEmployeeTasksForOffboarding)
+				employeeTaskRoute.POST("/offboarding/preview", c.EmployeeTaskHandler.PreviewEmployeeTasksForOffboarding)
+				employeeTaskRoute.POST("/onboarding/cancel", c.EmployeeTaskHandler.CancelOnboarding)
+				employeeTaskRoute.POST("/onboarding/pause", c.EmployeeTaskHandler.PauseOnboarding)
+				employeeTaskRoute.POST("/onboarding/resume", c.EmployeeTaskHandler.ResumeOnboarding)
+				employeeTaskRoute.POST("/reassign", c.EmployeeTaskHandler.ReassignEmployeeTask)
+				employeeTaskRoute.POST("/approvals/approve", c.EmployeeTaskHandler.ApproveEmployeeTask)
+				employeeTaskRoute.POST("/approvals/reject", c.EmployeeTaskHandler.RejectEmployeeTask)
+				employeeTaskRoute.POST("/files", c.EmployeeTaskHandler.UploadEmployeeTaskFile)
+				employeeTaskRoute.POST("/files/review", c.EmployeeTaskHandler.ReviewEmployeeTaskFile)
+				employeeTaskRoute.POST("/acknowledge", c.EmployeeTaskHandler.AcknowledgeEmployeeTask)
+				employeeTaskRoute.POST("/quiz-score", c.EmployeeTaskHandler.RecordEmployeeTaskQuizScore)
+				employeeTaskRoute.POST("/event-check-in", c.EmployeeTaskHandler.CheckInEvent)
 				employeeTaskRoute.PUT("/update", c.EmployeeTaskHandler.UpdateEmployeeTask)
 				employeeTaskRoute.PUT("/update-midsuit", c.EmployeeTaskHandler.UpdateEmployeeTaskMidsuit)
 				employeeTaskRoute.DELETE("/:id", c.EmployeeTaskHandler.DeleteEmployeeTask)
@@ -116,16 +155,101 @@ func (c *RouteConfig) SetupAPIRoutes() {
 			{
 				surveyTemplateRoute.GET("", c.SurveyTemplateHandler.FindAllSurveyTemplatesPaginated)
 				surveyTemplateRoute.GET("/:id", c.SurveyTemplateHandler.FindSurveyTemplateByID)
+				surveyTemplateRoute.GET("/:id/analytics", c.SurveyTemplateHandler.FindSurveyAnalytics)
+				surveyTemplateRoute.GET("/:id/analytics/versions", c.SurveyTemplateHandler.FindSurveyVersionAnalytics)
+				surveyTemplateRoute.GET("/:id/versions", c.SurveyTemplateHandler.FindAllSurveyTemplateVersions)
+				surveyTemplateRoute.POST("/:id/publish", c.SurveyTemplateHandler.PublishSurveyTemplate)
 				surveyTemplateRoute.POST("", c.SurveyTemplateHandler.CreateSurveyTemplate)
 				surveyTemplateRoute.PUT("/update", c.SurveyTemplateHandler.UpdateSurveyTemplate)
 				surveyTemplateRoute.DELETE("/:id", c.SurveyTemplateHandler.DeleteSurveyTemplate)
 			}
+			// survey campaigns
+			surveyCampaignRoute := apiRoute.Group("/survey-campaigns")
+			{
+				surveyCampaignRoute.GET("", c.SurveyCampaignHandler.FindAllPaginated)
+				surveyCampaignRoute.GET("/:id", c.SurveyCampaignHandler.FindByID)
+				surveyCampaignRoute.GET("/:id/dispatches", c.SurveyCampaignHandler.FindAllDispatchesPaginated)
+				surveyCampaignRoute.POST("", c.SurveyCampaignHandler.CreateSurveyCampaign)
+				surveyCampaignRoute.POST("/run", c.SurveyCampaignHandler.RunSurveyCampaigns)
+				surveyCampaignRoute.PUT("/update", c.SurveyCampaignHandler.UpdateSurveyCampaign)
+				surveyCampaignRoute.DELETE("/:id", c.SurveyCampaignHandler.DeleteSurveyCampaign)
+			}
+			// survey distributions
+			surveyDistributionRoute := apiRoute.Group("/survey-distributions")
+			{
+				surveyDistributionRoute.GET("", c.SurveyDistributionHandler.FindAllPaginated)
+				surveyDistributionRoute.GET("/me", c.SurveyDistributionHandler.FindAllMine)
+				surveyDistributionRoute.GET("/:id", c.SurveyDistributionHandler.FindByID)
+				surveyDistributionRoute.GET("/:id/recipients", c.SurveyDistributionHandler.FindAllRecipientsPaginated)
+				surveyDistributionRoute.GET("/:id/analytics", c.SurveyDistributionHandler.FindSurveyDistributionAnalytics)
+				surveyDistributionRoute.POST("", c.SurveyDistributionHandler.CreateSurveyDistribution)
+				surveyDistributionRoute.POST("/:id/close", c.SurveyDistributionHandler.CloseSurveyDistribution)
+				surveyDistributionRoute.PUT("/update", c.SurveyDistributionHandler.UpdateSurveyDistribution)
+				surveyDistributionRoute.DELETE("/:id", c.SurveyDistributionHandler.DeleteSurveyDistribution)
+			}
 			// survey responses
 			surveyResponseRoute := apiRoute.Group("/survey-responses")
 			{
 				surveyResponseRoute.GET("/export", c.SurveyResponseHandler.ExportSurveyResponses)
+				surveyResponseRoute.GET("/exports", c.SurveyResponseHandler.FindAllSurveyExportJobsPaginated)
+				surveyResponseRoute.GET("/exports/:id", c.SurveyResponseHandler.FindSurveyExportJobByID)
+				surveyResponseRoute.POST("/exports", c.SurveyResponseHandler.CreateSurveyExportJob)
+				surveyResponseRoute.GET("/quiz-result", c.SurveyResponseHandler.FindSurveyQuizResult)
+				surveyResponseRoute.GET("/quiz-attempts", c.SurveyResponseHandler.FindAllSurveyQuizAttemptsPaginated)
 				surveyResponseRoute.POST("", c.SurveyResponseHandler.CreateOrUpdateSurveyResponses)
 				surveyResponseRoute.POST("/bulk", c.SurveyResponseHandler.CreateOrUpdateSurveyResponsesBulk)
+				surveyResponseRoute.GET("/distribution/:survey_distribution_id", c.SurveyResponseHandler.FindSurveyDistributionResponses)
+				surveyResponseRoute.POST("/distribution", c.SurveyResponseHandler.CreateOrUpdateSurveyDistributionResponses)
+			}
+			// holidays
+			holidayRoute := apiRoute.Group("/holidays")
+			{
+				holidayRoute.GET("", c.HolidayHandler.FindAllPaginated)
+				holidayRoute.GET("/due-date", c.HolidayHandler.CalculateDueDate)
+				holidayRoute.GET("/:id", c.HolidayHandler.FindByID)
+				holidayRoute.POST("", c.HolidayHandler.CreateHoliday)
+				holidayRoute.POST("/import", c.HolidayHandler.ImportHolidays)
+				holidayRoute.PUT("/update", c.HolidayHandler.UpdateHoliday)
+				holidayRoute.DELETE("/:id", c.HolidayHandler.DeleteHoliday)
+			}
+			// work weeks
+			workWeekRoute := apiRoute.Group("/work-weeks")
+			{
+				workWeekRoute.GET("", c.WorkWeekHandler.FindAllPaginated)
+				workWeekRoute.GET("/organization/:organization_id", c.WorkWeekHandler.FindByOrganizationID)
+				workWeekRoute.POST("", c.WorkWeekHandler.CreateOrUpdateWorkWeek)
+				workWeekRoute.DELETE("/:id", c.WorkWeekHandler.DeleteWorkWeek)
+			}
+			// template bundles
+			templateBundleRoute := apiRoute.Group("/template-bundles")
+			{
+				templateBundleRoute.POST("/export", c.TemplateBundleHandler.ExportTemplateBundle)
+				templateBundleRoute.POST("/import", c.TemplateBundleHandler.ImportTemplateBundle)
+			}
+			// verifier delegations
+			verifierDelegationRoute := apiRoute.Group("/verifier-delegations")
+			{
+				verifierDelegationRoute.GET("", c.VerifierDelegationHandler.FindAllPaginated)
+				verifierDelegationRoute.GET("/:id", c.VerifierDelegationHandler.FindByID)
+				verifierDelegationRoute.POST("", c.VerifierDelegationHandler.CreateVerifierDelegation)
+				verifierDelegationRoute.POST("/:id/revoke", c.VerifierDelegationHandler.RevokeVerifierDelegation)
+			}
+			// policy documents
+			policyDocumentRoute := apiRoute.Group("/policy-documents")
+			{
+				policyDocumentRoute.GET("", c.PolicyDocumentHandler.FindAllPaginated)
+				policyDocumentRoute.GET("/:id", c.PolicyDocumentHandler.FindByID)
+				policyDocumentRoute.GET("/:id/pending-acknowledgements", c.PolicyDocumentHandler.FindPendingAcknowledgements)
+				policyDocumentRoute.POST("", c.PolicyDocumentHandler.CreatePolicyDocument)
+				policyDocumentRoute.PUT("/:id", c.PolicyDocumentHandler.UpdatePolicyDocument)
+				policyDocumentRoute.POST("/:id/versions", c.PolicyDocumentHandler.CreatePolicyDocumentVersion)
+				policyDocumentRoute.POST("/:id/acknowledge", c.PolicyDocumentHandler.AcknowledgePolicyDocument)
+			}
+			// policy acknowledgements
+			policyAcknowledgementRoute := apiRoute.Group("/policy-acknowledgements")
+			{
+				policyAcknowledgementRoute.GET("", c.PolicyDocumentHandler.FindAllAcknowledgementsPaginated)
+				policyAcknowledgementRoute.GET("/verify", c.PolicyDocumentHandler.VerifyPolicyAcknowledgementChain)
 			}
 		}
 	}
@@ -143,6 +267,13 @@ func NewRouteConfig(app *gin.Engine, viper *viper.Viper, log *logrus.Logger) *Ro
 	answerTypeHandler := handler.AnswerTypeHandlerFactory(log, viper)
 	surveyTemplateHandler := handler.SurveyTemplateHandlerFactory(log, viper)
 	surveyResponseHandler := handler.SurveyResponseHandlerFactory(log, viper)
+	holidayHandler := handler.HolidayHandlerFactory(log, viper)
+	workWeekHandler := handler.WorkWeekHandlerFactory(log, viper)
+	templateBundleHandler := handler.TemplateBundleHandlerFactory(log, viper)
+	verifierDelegationHandler := handler.VerifierDelegationHandlerFactory(log, viper)
+	policyDocumentHandler := handler.PolicyDocumentHandlerFactory(log, viper)
+	surveyCampaignHandler := handler.SurveyCampaignHandlerFactory(log, viper)
+	surveyDistributionHandler := handler.SurveyDistributionHandlerFactory(log, viper)
 	return &RouteConfig{
 		App:                           app,
 		Log:                           log,
@@ -158,5 +289,12 @@ func NewRouteConfig(app *gin.Engine, viper *viper.Viper, log *logrus.Logger) *Ro
 		AnswerTypeHandler:             answerTypeHandler,
 		SurveyTemplateHandler:         surveyTemplateHandler,
 		SurveyResponseHandler:         surveyResponseHandler,
+		HolidayHandler:                holidayHandler,
+		WorkWeekHandler:               workWeekHandler,
+		TemplateBundleHandler:         templateBundleHandler,
+		VerifierDelegationHandler:     verifierDelegationHandler,
+		PolicyDocumentHandler:         policyDocumentHandler,
+		SurveyCampaignHandler:         surveyCampaignHandler,
+		SurveyDistributionHandler:     surveyDistributionHandler,
 	}
 }
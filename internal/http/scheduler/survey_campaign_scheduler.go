@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/usecase"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// InitSurveyCampaignScheduler runs the survey campaigns every
+// scheduler.survey_campaign_interval minutes, hourly when it is not set.
+func InitSurveyCampaignScheduler(viper *viper.Viper, log *logrus.Logger) {
+	interval := viper.GetInt("scheduler.survey_campaign_interval")
+	if interval <= 0 {
+		interval = 60
+	}
+
+	useCase := usecase.SurveyCampaignUseCaseFactory(log, viper)
+	ticker := time.NewTicker(time.Duration(interval) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("INFO: survey campaign scheduler runs every %d minutes", interval)
+
+	for range ticker.C {
+		res, err := useCase.RunSurveyCampaigns(time.Now())
+		if err != nil {
+			log.Error("[InitSurveyCampaignScheduler] error when running survey campaigns: ", err)
+			continue
+		}
+
+		log.Printf("INFO: survey campaigns run, sent %d, skipped %d, reminded %d, completed %d, expired %d, failed %d",
+			res.Sent, res.Skipped, res.Reminded, res.Completed, res.Expired, res.Failed)
+	}
+}
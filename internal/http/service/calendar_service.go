@@ -0,0 +1,152 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ICalendarService interface {
+	AddWorkingDays(organizationID *uuid.UUID, startDate time.Time, days int) (time.Time, error)
+	IsWorkingDay(organizationID *uuid.UUID, date time.Time) (bool, error)
+}
+
+type CalendarService struct {
+	Log                *logrus.Logger
+	HolidayRepository  repository.IHolidayRepository
+	WorkWeekRepository repository.IWorkWeekRepository
+}
+
+func NewCalendarService(
+	log *logrus.Logger,
+	holidayRepository repository.IHolidayRepository,
+	workWeekRepository repository.IWorkWeekRepository,
+) ICalendarService {
+	return &CalendarService{
+		Log:                log,
+		HolidayRepository:  holidayRepository,
+		WorkWeekRepository: workWeekRepository,
+	}
+}
+
+func CalendarServiceFactory(log *logrus.Logger) ICalendarService {
+	holidayRepository := repository.HolidayRepositoryFactory(log)
+	workWeekRepository := repository.WorkWeekRepositoryFactory(log)
+	return NewCalendarService(log, holidayRepository, workWeekRepository)
+}
+
+// defaultWorkWeek is used for organizations that have no work week configured.
+func defaultWorkWeek() *entity.WorkWeek {
+	return &entity.WorkWeek{
+		Monday:    "YES",
+		Tuesday:   "YES",
+		Wednesday: "YES",
+		Thursday:  "YES",
+		Friday:    "YES",
+		Saturday:  "NO",
+		Sunday:    "NO",
+	}
+}
+
+func (s *CalendarService) findWorkWeek(organizationID *uuid.UUID) (*entity.WorkWeek, error) {
+	if organizationID == nil {
+		return defaultWorkWeek(), nil
+	}
+
+	workWeek, err := s.WorkWeekRepository.FindByOrganizationID(*organizationID)
+	if err != nil {
+		s.Log.Error("[CalendarService.findWorkWeek] error when finding work week: ", err)
+		return nil, err
+	}
+	if workWeek == nil {
+		return defaultWorkWeek(), nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if workWeek.IsWorkingWeekday(day) {
+			return workWeek, nil
+		}
+	}
+
+	return nil, errors.New("work week has no working days")
+}
+
+func (s *CalendarService) findHolidayDates(organizationID *uuid.UUID, startDate, endDate time.Time) (map[string]bool, error) {
+	holidays, err := s.HolidayRepository.FindAllBetweenDates(organizationID, startDate, endDate)
+	if err != nil {
+		s.Log.Error("[CalendarService.findHolidayDates] error when finding holidays: ", err)
+		return nil, err
+	}
+
+	dates := make(map[string]bool, len(*holidays))
+	for _, holiday := range *holidays {
+		dates[holiday.Date.Format("2006-01-02")] = true
+	}
+
+	return dates, nil
+}
+
+// AddWorkingDays moves startDate forward by the given number of working days,
+// skipping non-working weekdays and holidays. The start date itself is not counted.
+func (s *CalendarService) AddWorkingDays(organizationID *uuid.UUID, startDate time.Time, days int) (time.Time, error) {
+	if days <= 0 {
+		return startDate, nil
+	}
+
+	workWeek, err := s.findWorkWeek(organizationID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// holidays are fetched in windows so that long breaks such as Lebaran
+	// do not require loading the whole table
+	windowSize := days*2 + 30
+	windowStart := startDate.AddDate(0, 0, 1)
+	windowEnd := startDate.AddDate(0, 0, windowSize)
+	holidayDates, err := s.findHolidayDates(organizationID, windowStart, windowEnd)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	current := startDate
+	remaining := days
+	for remaining > 0 {
+		current = current.AddDate(0, 0, 1)
+		if current.After(windowEnd) {
+			windowStart = current
+			windowEnd = current.AddDate(0, 0, windowSize)
+			holidayDates, err = s.findHolidayDates(organizationID, windowStart, windowEnd)
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+
+		if !workWeek.IsWorkingWeekday(current.Weekday()) || holidayDates[current.Format("2006-01-02")] {
+			continue
+		}
+		remaining--
+	}
+
+	return current, nil
+}
+
+func (s *CalendarService) IsWorkingDay(organizationID *uuid.UUID, date time.Time) (bool, error) {
+	workWeek, err := s.findWorkWeek(organizationID)
+	if err != nil {
+		return false, err
+	}
+	if !workWeek.IsWorkingWeekday(date.Weekday()) {
+		return false, nil
+	}
+
+	holidayDates, err := s.findHolidayDates(organizationID, date, date)
+	if err != nil {
+		return false, err
+	}
+
+	return !holidayDates[date.Format("2006-01-02")], nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeHolidayRepository struct {
+	repository.IHolidayRepository
+	holidays []entity.Holiday
+}
+
+func (r *fakeHolidayRepository) FindAllBetweenDates(organizationID *uuid.UUID, startDate, endDate time.Time) (*[]entity.Holiday, error) {
+	holidays := make([]entity.Holiday, 0)
+	for _, holiday := range r.holidays {
+		if holiday.OrganizationID != nil && (organizationID == nil || *holiday.OrganizationID != *organizationID) {
+			continue
+		}
+		if holiday.Date.Before(startDate) || holiday.Date.After(endDate) {
+			continue
+		}
+		holidays = append(holidays, holiday)
+	}
+	return &holidays, nil
+}
+
+type fakeWorkWeekRepository struct {
+	repository.IWorkWeekRepository
+	workWeeks map[uuid.UUID]*entity.WorkWeek
+}
+
+func (r *fakeWorkWeekRepository) FindByOrganizationID(organizationID uuid.UUID) (*entity.WorkWeek, error) {
+	return r.workWeeks[organizationID], nil
+}
+
+func date(value string) time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func TestCalendarServiceAddWorkingDays(t *testing.T) {
+	sixDayOrganizationID := uuid.New()
+	otherOrganizationID := uuid.New()
+
+	calendarService := NewCalendarService(
+		logrus.New(),
+		&fakeHolidayRepository{holidays: []entity.Holiday{
+			{Date: date("2024-01-03")},
+			{Date: date("2024-01-10"), OrganizationID: &sixDayOrganizationID},
+		}},
+		&fakeWorkWeekRepository{workWeeks: map[uuid.UUID]*entity.WorkWeek{
+			sixDayOrganizationID: {Monday: "YES", Tuesday: "YES", Wednesday: "YES", Thursday: "YES", Friday: "YES", Saturday: "YES", Sunday: "NO"},
+		}},
+	)
+
+	// 2024-01-01 is a Monday
+	tests := []struct {
+		name           string
+		organizationID *uuid.UUID
+		startDate      string
+		days           int
+		want           string
+	}{
+		{"zero days keeps the start date", nil, "2024-01-01", 0, "2024-01-01"},
+		{"start date is not counted", nil, "2024-01-01", 1, "2024-01-02"},
+		{"global holiday is skipped", nil, "2024-01-01", 2, "2024-01-04"},
+		{"weekend is skipped", nil, "2024-01-04", 2, "2024-01-08"},
+		{"start on a weekend", nil, "2024-01-06", 1, "2024-01-08"},
+		{"organization work week", &sixDayOrganizationID, "2024-01-04", 2, "2024-01-06"},
+		{"organization holiday", &sixDayOrganizationID, "2024-01-08", 2, "2024-01-11"},
+		{"holiday of another organization", &otherOrganizationID, "2024-01-08", 2, "2024-01-10"},
+		{"beyond the first holiday window", nil, "2024-01-01", 40, "2024-02-27"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calendarService.AddWorkingDays(tt.organizationID, date(tt.startDate), tt.days)
+			if err != nil {
+				t.Fatalf("AddWorkingDays() error = %v", err)
+			}
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("AddWorkingDays() = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarServiceIsWorkingDay(t *testing.T) {
+	calendarService := NewCalendarService(
+		logrus.New(),
+		&fakeHolidayRepository{holidays: []entity.Holiday{{Date: date("2024-01-03")}}},
+		&fakeWorkWeekRepository{workWeeks: map[uuid.UUID]*entity.WorkWeek{}},
+	)
+
+	tests := []struct {
+		name string
+		date string
+		want bool
+	}{
+		{"weekday", "2024-01-02", true},
+		{"holiday", "2024-01-03", false},
+		{"saturday", "2024-01-06", false},
+		{"sunday", "2024-01-07", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calendarService.IsWorkingDay(nil, date(tt.date))
+			if err != nil {
+				t.Fatalf("IsWorkingDay() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsWorkingDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarServiceWorkWeekWithoutWorkingDays(t *testing.T) {
+	organizationID := uuid.New()
+	calendarService := NewCalendarService(
+		logrus.New(),
+		&fakeHolidayRepository{},
+		&fakeWorkWeekRepository{workWeeks: map[uuid.UUID]*entity.WorkWeek{
+			organizationID: {Monday: "NO", Tuesday: "NO", Wednesday: "NO", Thursday: "NO", Friday: "NO", Saturday: "NO", Sunday: "NO"},
+		}},
+	)
+
+	if _, err := calendarService.AddWorkingDays(&organizationID, date("2024-01-01"), 1); err == nil {
+		t.Error("AddWorkingDays() error = nil, want an error for a work week without working days")
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type IEmployeeTaskApprovalService interface {
+	StartApprovalRound(employeeTaskID uuid.UUID, employeeID uuid.UUID, round int, steps []entity.TemplateTaskApprovalStep) (*[]entity.EmployeeTaskApproval, error)
+	ResolveApprover(employeeID uuid.UUID, step entity.TemplateTaskApprovalStep) *uuid.UUID
+	AssignApprover(employeeID uuid.UUID, approval *entity.EmployeeTaskApproval) error
+	CanAct(approval *entity.EmployeeTaskApproval, actor request.TaskActor) (bool, error)
+}
+
+type EmployeeTaskApprovalService struct {
+	Log                       *logrus.Logger
+	Repository                repository.IEmployeeTaskApprovalRepository
+	OrganizationMessage       messaging.IOrganizationMessage
+	VerifierDelegationService IVerifierDelegationService
+}
+
+func NewEmployeeTaskApprovalService(
+	log *logrus.Logger,
+	repo repository.IEmployeeTaskApprovalRepository,
+	organizationMessage messaging.IOrganizationMessage,
+	verifierDelegationService IVerifierDelegationService,
+) IEmployeeTaskApprovalService {
+	return &EmployeeTaskApprovalService{
+		Log:                       log,
+		Repository:                repo,
+		OrganizationMessage:       organizationMessage,
+		VerifierDelegationService: verifierDelegationService,
+	}
+}
+
+func EmployeeTaskApprovalServiceFactory(log *logrus.Logger) IEmployeeTaskApprovalService {
+	repo := repository.EmployeeTaskApprovalRepositoryFactory(log)
+	organizationMessage := messaging.OrganizationMessageFactory(log)
+	verifierDelegationService := VerifierDelegationServiceFactory(log)
+	return NewEmployeeTaskApprovalService(log, repo, organizationMessage, verifierDelegationService)
+}
+
+// StartApprovalRound creates a pending approval for every step. Only EMPLOYEE steps get their
+// approver here, the others are resolved by AssignApprover once the step is reached, as the
+// round may be started while a RabbitMQ message is handled.
+func (s *EmployeeTaskApprovalService) StartApprovalRound(employeeTaskID uuid.UUID, employeeID uuid.UUID, round int, steps []entity.TemplateTaskApprovalStep) (*[]entity.EmployeeTaskApproval, error) {
+	approvals := make([]entity.EmployeeTaskApproval, 0, len(steps))
+	for _, step := range steps {
+		var approverID *uuid.UUID
+		if step.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+			approverID = step.ApproverEmployeeID
+		}
+		approval, err := s.Repository.CreateEmployeeTaskApproval(&entity.EmployeeTaskApproval{
+			EmployeeTaskID: employeeTaskID,
+			Round:          round,
+			StepOrder:      step.StepOrder,
+			ApproverType:   step.ApproverType,
+			ApproverID:     approverID,
+			Status:         entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING,
+		})
+		if err != nil {
+			s.Log.Error("[EmployeeTaskApprovalService.StartApprovalRound] error creating employee task approval: ", err)
+			return nil, err
+		}
+		approvals = append(approvals, *approval)
+	}
+
+	return &approvals, nil
+}
+
+// ResolveApprover finds the approver of the step for the employee. A step whose approver
+// cannot be found is left without one so that only admins can act on it.
+func (s *EmployeeTaskApprovalService) ResolveApprover(employeeID uuid.UUID, step entity.TemplateTaskApprovalStep) *uuid.UUID {
+	if step.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+		return step.ApproverEmployeeID
+	}
+
+	approver, err := s.OrganizationMessage.SendFindEmployeeApproverMessage(request.SendFindEmployeeApproverMessageRequest{
+		EmployeeID:   employeeID.String(),
+		ApproverType: string(step.ApproverType),
+	})
+	if err != nil {
+		s.Log.Warn("[EmployeeTaskApprovalService.ResolveApprover] error finding approver: ", err)
+		return nil
+	}
+	if approver == nil {
+		s.Log.Warnf("[EmployeeTaskApprovalService.ResolveApprover] no %s found for employee %s", step.ApproverType, employeeID)
+		return nil
+	}
+
+	approverID, err := uuid.Parse(approver.EmployeeID)
+	if err != nil {
+		s.Log.Warn("[EmployeeTaskApprovalService.ResolveApprover] error parsing approver id: ", err)
+		return nil
+	}
+
+	return &approverID
+}
+
+// AssignApprover resolves the approver of a pending step that has none yet, for the employee at
+// this moment, and stores it. A step whose approver cannot be found is tried again next time.
+func (s *EmployeeTaskApprovalService) AssignApprover(employeeID uuid.UUID, approval *entity.EmployeeTaskApproval) error {
+	if approval.ApproverID != nil || approval.Status != entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING ||
+		approval.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+		return nil
+	}
+
+	approverID := s.ResolveApprover(employeeID, entity.TemplateTaskApprovalStep{
+		StepOrder:    approval.StepOrder,
+		ApproverType: approval.ApproverType,
+	})
+	if approverID == nil {
+		return nil
+	}
+
+	approval.ApproverID = approverID
+	if _, err := s.Repository.UpdateEmployeeTaskApproval(approval); err != nil {
+		s.Log.Error("[EmployeeTaskApprovalService.AssignApprover] error updating employee task approval: ", err)
+		approval.ApproverID = nil
+		return err
+	}
+
+	return nil
+}
+
+// CanAct tells whether the actor may approve or reject the step: admins, the approver and the
+// delegate of the approver.
+func (s *EmployeeTaskApprovalService) CanAct(approval *entity.EmployeeTaskApproval, actor request.TaskActor) (bool, error) {
+	if actor.IsAdmin {
+		return true, nil
+	}
+	if approval.ApproverID == nil {
+		return false, nil
+	}
+	if actor.EmployeeID == *approval.ApproverID {
+		return true, nil
+	}
+
+	delegateID, _, err := s.VerifierDelegationService.ResolveVerifier(*approval.ApproverID, time.Now())
+	if err != nil {
+		s.Log.Error("[EmployeeTaskApprovalService.CanAct] error resolving verifier delegation: ", err)
+		return false, err
+	}
+
+	return actor.EmployeeID == delegateID, nil
+}
+
+// CurrentApprovalRound returns the latest round of the approvals, ordered by round and step, and
+// its steps.
+func CurrentApprovalRound(approvals []entity.EmployeeTaskApproval) (int, []entity.EmployeeTaskApproval) {
+	round := 0
+	for _, approval := range approvals {
+		if approval.Round > round {
+			round = approval.Round
+		}
+	}
+
+	steps := make([]entity.EmployeeTaskApproval, 0)
+	for _, approval := range approvals {
+		if approval.Round == round {
+			steps = append(steps, approval)
+		}
+	}
+
+	return round, steps
+}
+
+// ValidateApprovalSteps checks that only EMPLOYEE steps name their approver. The other steps are
+// resolved from the organization structure.
+func ValidateApprovalSteps(steps []request.TemplateTaskApprovalStepRequest) error {
+	for i, step := range steps {
+		position := strconv.Itoa(i + 1)
+		if entity.ApprovalApproverTypeEnum(step.ApproverType) == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+			if step.ApproverEmployeeID == "" {
+				return errors.New("approval step " + position + " needs an approver employee id")
+			}
+			continue
+		}
+		if step.ApproverEmployeeID != "" {
+			return errors.New("approval step " + position + " is resolved from the organization structure and cannot name an approver employee id")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeEmployeeTaskApprovalRepository struct {
+	repository.IEmployeeTaskApprovalRepository
+	updated int
+}
+
+func (r *fakeEmployeeTaskApprovalRepository) CreateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error) {
+	return ent, nil
+}
+
+func (r *fakeEmployeeTaskApprovalRepository) UpdateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error) {
+	r.updated++
+	return ent, nil
+}
+
+type fakeApproverOrganizationMessage struct {
+	messaging.IOrganizationMessage
+	approvers map[string]string
+	calls     int
+}
+
+func (m *fakeApproverOrganizationMessage) SendFindEmployeeApproverMessage(req request.SendFindEmployeeApproverMessageRequest) (*response.SendFindEmployeeApproverMessageResponse, error) {
+	m.calls++
+	approverID, ok := m.approvers[req.ApproverType]
+	if !ok {
+		return nil, nil
+	}
+	return &response.SendFindEmployeeApproverMessageResponse{EmployeeID: approverID}, nil
+}
+
+func TestEmployeeTaskApprovalServiceStartApprovalRound(t *testing.T) {
+	organizationMessage := &fakeApproverOrganizationMessage{approvers: map[string]string{"HR": uuid.NewString()}}
+	approvalService := NewEmployeeTaskApprovalService(logrus.New(), &fakeEmployeeTaskApprovalRepository{}, organizationMessage, nil)
+
+	approverEmployeeID := uuid.New()
+	approvals, err := approvalService.StartApprovalRound(uuid.New(), uuid.New(), 1, []entity.TemplateTaskApprovalStep{
+		{StepOrder: 1, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE, ApproverEmployeeID: &approverEmployeeID},
+		{StepOrder: 2, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR},
+	})
+	if err != nil {
+		t.Fatalf("StartApprovalRound() error = %v", err)
+	}
+	if organizationMessage.calls != 0 {
+		t.Errorf("StartApprovalRound() looked up %d approvers, want none", organizationMessage.calls)
+	}
+	if got := (*approvals)[0].ApproverID; got == nil || *got != approverEmployeeID {
+		t.Errorf("StartApprovalRound() employee step approver = %v, want %s", got, approverEmployeeID)
+	}
+	if got := (*approvals)[1].ApproverID; got != nil {
+		t.Errorf("StartApprovalRound() HR step approver = %s, want none until the step is reached", got)
+	}
+}
+
+func TestEmployeeTaskApprovalServiceAssignApprover(t *testing.T) {
+	hrID := uuid.New()
+	assignedID := uuid.New()
+
+	tests := []struct {
+		name        string
+		approval    entity.EmployeeTaskApproval
+		wantCalls   int
+		wantUpdated int
+		want        *uuid.UUID
+	}{
+		{"pending step without approver", entity.EmployeeTaskApproval{
+			ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR,
+			Status:       entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING,
+		}, 1, 1, &hrID},
+		{"approver not found", entity.EmployeeTaskApproval{
+			ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_DIRECT_MANAGER,
+			Status:       entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING,
+		}, 1, 0, nil},
+		{"approver already assigned", entity.EmployeeTaskApproval{
+			ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR,
+			ApproverID:   &assignedID,
+			Status:       entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING,
+		}, 0, 0, &assignedID},
+		{"employee step", entity.EmployeeTaskApproval{
+			ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE,
+			Status:       entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING,
+		}, 0, 0, nil},
+		{"step already acted on", entity.EmployeeTaskApproval{
+			ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR,
+			Status:       entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_APPROVED,
+		}, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvalRepository := &fakeEmployeeTaskApprovalRepository{}
+			organizationMessage := &fakeApproverOrganizationMessage{approvers: map[string]string{"HR": hrID.String()}}
+			approvalService := NewEmployeeTaskApprovalService(logrus.New(), approvalRepository, organizationMessage, nil)
+
+			approval := tt.approval
+			if err := approvalService.AssignApprover(uuid.New(), &approval); err != nil {
+				t.Fatalf("AssignApprover() error = %v", err)
+			}
+			if organizationMessage.calls != tt.wantCalls {
+				t.Errorf("AssignApprover() looked up %d approvers, want %d", organizationMessage.calls, tt.wantCalls)
+			}
+			if approvalRepository.updated != tt.wantUpdated {
+				t.Errorf("AssignApprover() stored %d approvals, want %d", approvalRepository.updated, tt.wantUpdated)
+			}
+			if (approval.ApproverID == nil) != (tt.want == nil) || (tt.want != nil && *approval.ApproverID != *tt.want) {
+				t.Errorf("AssignApprover() approver = %v, want %v", approval.ApproverID, tt.want)
+			}
+		})
+	}
+}
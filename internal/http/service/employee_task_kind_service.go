@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type IEmployeeTaskKindService interface {
+	MissingRequirement(employeeTask *entity.EmployeeTask) (string, error)
+}
+
+type EmployeeTaskKindService struct {
+	Log                         *logrus.Logger
+	EmployeeTaskFileRepository  repository.IEmployeeTaskFileRepository
+	EventEmployeeRepository     repository.IEventEmployeeRepository
+	SurveyTemplateRepository    repository.ISurveyTemplateRepository
+	SurveyQuizAttemptRepository repository.ISurveyQuizAttemptRepository
+}
+
+func NewEmployeeTaskKindService(
+	log *logrus.Logger,
+	etfRepo repository.IEmployeeTaskFileRepository,
+	eeRepo repository.IEventEmployeeRepository,
+	stRepo repository.ISurveyTemplateRepository,
+	sqaRepo repository.ISurveyQuizAttemptRepository,
+) IEmployeeTaskKindService {
+	return &EmployeeTaskKindService{
+		Log:                         log,
+		EmployeeTaskFileRepository:  etfRepo,
+		EventEmployeeRepository:     eeRepo,
+		SurveyTemplateRepository:    stRepo,
+		SurveyQuizAttemptRepository: sqaRepo,
+	}
+}
+
+func EmployeeTaskKindServiceFactory(log *logrus.Logger) IEmployeeTaskKindService {
+	etfRepo := repository.EmployeeTaskFileRepositoryFactory(log)
+	eeRepo := repository.EventEmployeeRepositoryFactory(log)
+	stRepo := repository.SurveyTemplateRepositoryFactory(log)
+	sqaRepo := repository.SurveyQuizAttemptRepositoryFactory(log)
+	return NewEmployeeTaskKindService(log, etfRepo, eeRepo, stRepo, sqaRepo)
+}
+
+// MissingRequirement describes what the kind of the task still waits for before the task may be
+// completed. It is empty once the requirement is met and for kinds without one. A task answered
+// through a quiz survey template also waits for a passed attempt, whatever its kind.
+func (s *EmployeeTaskKindService) MissingRequirement(employeeTask *entity.EmployeeTask) (string, error) {
+	missing, err := s.missingSurveyQuizPass(employeeTask)
+	if err != nil || missing != "" {
+		return missing, err
+	}
+
+	switch employeeTask.TaskKind() {
+	case entity.TASK_KIND_ENUM_DOCUMENT_UPLOAD:
+		accepted, err := s.EmployeeTaskFileRepository.CountByEmployeeTaskIDAndStatus(employeeTask.ID, entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_ACCEPTED)
+		if err != nil {
+			s.Log.Error("[EmployeeTaskKindService.MissingRequirement] error counting accepted files: ", err)
+			return "", err
+		}
+		if accepted == 0 {
+			return "an uploaded document has to be accepted", nil
+		}
+	case entity.TASK_KIND_ENUM_ACKNOWLEDGEMENT:
+		if employeeTask.AcknowledgedAt == nil {
+			return "the employee has to acknowledge the task", nil
+		}
+	case entity.TASK_KIND_ENUM_QUIZ:
+		if employeeTask.QuizScore == nil {
+			return "the quiz has no score yet", nil
+		}
+		if !QuizPassed(employeeTask) {
+			return "the quiz score " + strconv.Itoa(*employeeTask.QuizScore) + " is below the passing score " + strconv.Itoa(*employeeTask.PassingScore), nil
+		}
+	case entity.TASK_KIND_ENUM_EVENT_ATTENDANCE:
+		if employeeTask.EmployeeID == nil || employeeTask.TemplateTaskID == nil {
+			return "the employee has to check in at an event of the task", nil
+		}
+		checkIn, err := s.EventEmployeeRepository.FindCheckedInByEmployeeIDAndTemplateTaskID(*employeeTask.EmployeeID, *employeeTask.TemplateTaskID)
+		if err != nil {
+			s.Log.Error("[EmployeeTaskKindService.MissingRequirement] error finding event check in: ", err)
+			return "", err
+		}
+		if checkIn == nil {
+			return "the employee has to check in at an event of the task", nil
+		}
+	}
+
+	return "", nil
+}
+
+// QuizPassed tells whether the recorded score reaches the passing score. A quiz without a passing
+// score passes with any score.
+func QuizPassed(employeeTask *entity.EmployeeTask) bool {
+	if employeeTask.QuizScore == nil {
+		return false
+	}
+	if employeeTask.PassingScore == nil {
+		return true
+	}
+	return *employeeTask.QuizScore >= *employeeTask.PassingScore
+}
+
+// ValidateTaskKind checks that a task has what its kind needs, survey and quiz tasks are answered
+// through a survey template.
+func ValidateTaskKind(kind string, surveyTemplateID *string, passingScore *int) error {
+	hasSurveyTemplate := surveyTemplateID != nil && *surveyTemplateID != "" && *surveyTemplateID != "null"
+	switch entity.TaskKindEnum(kind) {
+	case entity.TASK_KIND_ENUM_SURVEY:
+		if !hasSurveyTemplate {
+			return errors.New("survey tasks need a survey template")
+		}
+	case entity.TASK_KIND_ENUM_QUIZ:
+		if !hasSurveyTemplate {
+			return errors.New("quiz tasks need a survey template")
+		}
+		if passingScore == nil {
+			return errors.New("quiz tasks need a passing score")
+		}
+	}
+	if passingScore != nil && (*passingScore < 0 || *passingScore > 100) {
+		return errors.New("passing score must be between 0 and 100")
+	}
+
+	return nil
+}
+
+func (s *EmployeeTaskKindService) missingSurveyQuizPass(employeeTask *entity.EmployeeTask) (string, error) {
+	if employeeTask.SurveyTemplateID == nil {
+		return "", nil
+	}
+
+	surveyTemplate := employeeTask.SurveyTemplate
+	if surveyTemplate == nil {
+		var err error
+		surveyTemplate, err = s.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+			"id": *employeeTask.SurveyTemplateID,
+		})
+		if err != nil {
+			s.Log.Error("[EmployeeTaskKindService.missingSurveyQuizPass] error finding survey template: ", err)
+			return "", err
+		}
+	}
+	if surveyTemplate == nil || !surveyTemplate.IsQuiz {
+		return "", nil
+	}
+
+	latest, err := s.SurveyQuizAttemptRepository.FindLatestByEmployeeTaskID(employeeTask.ID)
+	if err != nil {
+		s.Log.Error("[EmployeeTaskKindService.missingSurveyQuizPass] error finding latest quiz attempt: ", err)
+		return "", err
+	}
+	if latest == nil {
+		return "the quiz has not been submitted yet", nil
+	}
+	if !latest.Passed {
+		return "the last quiz attempt scored " + strconv.Itoa(latest.Percentage) + "%, below the passing score", nil
+	}
+
+	return "", nil
+}
@@ -22,6 +22,7 @@ type IMidsuitService interface {
 	SyncEmployeeTaskChecklistMidsuit(payload request.SyncEmployeeTaskChecklistMidsuitRequest, jwtToken string) (*string, error)
 	SyncEmployeeTaskAttachmentMidsuit(midsuitID int, payload request.SyncEmployeeTaskAttachmentMidsuitRequest, jwtToken string) (*string, error)
 	SyncUpdateEmployeeTaskMidsuit(midsuitID int, payload request.SyncEmployeeTaskMidsuitRequest, jwtToken string) (*string, error)
+	SyncEmployeeTaskActiveMidsuit(midsuitID int, isActive bool, jwtToken string) error
 }
 
 type MidsuitService struct {
@@ -317,3 +318,47 @@ func (s *MidsuitService) SyncUpdateEmployeeTaskMidsuit(midsuitID int, payload re
 	idStr := strconv.Itoa(syncResponse.ID)
 	return &idStr, nil
 }
+
+// SyncEmployeeTaskActiveMidsuit activates or deactivates a task in Midsuit without touching
+// its other fields.
+func (s *MidsuitService) SyncEmployeeTaskActiveMidsuit(midsuitID int, isActive bool, jwtToken string) error {
+	url := s.Viper.GetString("midsuit.url") + s.Viper.GetString("midsuit.api_endpoint") + "/models/HC_Task/" + strconv.Itoa(midsuitID)
+	method := "PUT"
+
+	payloadBytes, err := json.Marshal(request.SyncEmployeeTaskActiveMidsuitRequest{
+		IsActive: isActive,
+	})
+	if err != nil {
+		s.Log.Error(err)
+		return errors.New("[MidsuitService.SyncEmployeeTaskActiveMidsuit] Error when marshalling payload: " + err.Error())
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		s.Log.Error(err)
+		return errors.New("[MidsuitService.SyncEmployeeTaskActiveMidsuit] Error when creating request: " + err.Error())
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Authorization", "Bearer "+jwtToken)
+
+	res, err := client.Do(req)
+	if err != nil {
+		s.Log.Error(err)
+		return errors.New("[MidsuitService.SyncEmployeeTaskActiveMidsuit] Error when fetching response: " + err.Error())
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return errors.New("[MidsuitService.SyncEmployeeTaskActiveMidsuit] Error when fetching response: " + string(bodyBytes))
+	}
+
+	return nil
+}
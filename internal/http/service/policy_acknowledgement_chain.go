@@ -0,0 +1,80 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+)
+
+// PolicyAcknowledgementGenesisHash is the previous hash of the first acknowledgement.
+var PolicyAcknowledgementGenesisHash = strings.Repeat("0", 64)
+
+// policyAcknowledgementPayload lists the fields covered by the hash of an acknowledgement. The
+// order of the fields is part of the format, do not reorder them.
+type policyAcknowledgementPayload struct {
+	Sequence                int64  `json:"sequence"`
+	PreviousHash            string `json:"previous_hash"`
+	PolicyDocumentID        string `json:"policy_document_id"`
+	PolicyDocumentVersionID string `json:"policy_document_version_id"`
+	VersionNumber           int    `json:"version_number"`
+	EmployeeID              string `json:"employee_id"`
+	DocumentHash            string `json:"document_hash"`
+	AcknowledgedAt          string `json:"acknowledged_at"`
+	IPAddress               string `json:"ip_address"`
+	UserAgent               string `json:"user_agent"`
+}
+
+// PolicyAcknowledgementHash computes the hash of the acknowledgement from its fields and the
+// hash of the record before it.
+func PolicyAcknowledgementHash(ent *entity.PolicyAcknowledgement) string {
+	payload, _ := json.Marshal(policyAcknowledgementPayload{
+		Sequence:                ent.Sequence,
+		PreviousHash:            ent.PreviousHash,
+		PolicyDocumentID:        ent.PolicyDocumentID.String(),
+		PolicyDocumentVersionID: ent.PolicyDocumentVersionID.String(),
+		VersionNumber:           ent.VersionNumber,
+		EmployeeID:              ent.EmployeeID.String(),
+		DocumentHash:            ent.DocumentHash,
+		AcknowledgedAt:          ent.AcknowledgedAt.UTC().Format(time.RFC3339Nano),
+		IPAddress:               ent.IPAddress,
+		UserAgent:               ent.UserAgent,
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// SealPolicyAcknowledgement links the acknowledgement after previous, nil for the first one,
+// and sets its hash. The acknowledged time is cut to microseconds, the precision the database
+// keeps, so the hash can be recomputed from the stored record.
+func SealPolicyAcknowledgement(ent *entity.PolicyAcknowledgement, previous *entity.PolicyAcknowledgement) {
+	ent.Sequence = 1
+	ent.PreviousHash = PolicyAcknowledgementGenesisHash
+	if previous != nil {
+		ent.Sequence = previous.Sequence + 1
+		ent.PreviousHash = previous.Hash
+	}
+	ent.AcknowledgedAt = ent.AcknowledgedAt.Truncate(time.Microsecond)
+	ent.Hash = PolicyAcknowledgementHash(ent)
+}
+
+// FileContentHash returns the hex sha256 of the file at path.
+func FileContentHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+func newPolicyAcknowledgement() *entity.PolicyAcknowledgement {
+	return &entity.PolicyAcknowledgement{
+		PolicyDocumentID:        uuid.New(),
+		PolicyDocumentVersionID: uuid.New(),
+		VersionNumber:           1,
+		EmployeeID:              uuid.New(),
+		DocumentHash:            "5d41402abc4b2a76b9719d911017c592",
+		AcknowledgedAt:          time.Date(2024, 1, 1, 9, 30, 0, 123456789, time.UTC),
+		IPAddress:               "10.0.0.1",
+		UserAgent:               "Mozilla/5.0",
+	}
+}
+
+func TestSealPolicyAcknowledgement(t *testing.T) {
+	first := newPolicyAcknowledgement()
+	SealPolicyAcknowledgement(first, nil)
+
+	second := newPolicyAcknowledgement()
+	SealPolicyAcknowledgement(second, first)
+
+	tests := []struct {
+		name             string
+		ent              *entity.PolicyAcknowledgement
+		wantSequence     int64
+		wantPreviousHash string
+	}{
+		{"first acknowledgement", first, 1, PolicyAcknowledgementGenesisHash},
+		{"next acknowledgement", second, 2, first.Hash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.ent.Sequence != tt.wantSequence {
+				t.Errorf("SealPolicyAcknowledgement() sequence = %d, want %d", tt.ent.Sequence, tt.wantSequence)
+			}
+			if tt.ent.PreviousHash != tt.wantPreviousHash {
+				t.Errorf("SealPolicyAcknowledgement() previous hash = %s, want %s", tt.ent.PreviousHash, tt.wantPreviousHash)
+			}
+			if tt.ent.AcknowledgedAt.Nanosecond()%int(time.Microsecond) != 0 {
+				t.Errorf("SealPolicyAcknowledgement() acknowledged at = %s, want it cut to microseconds", tt.ent.AcknowledgedAt)
+			}
+			if len(tt.ent.Hash) != 64 || PolicyAcknowledgementHash(tt.ent) != tt.ent.Hash {
+				t.Errorf("SealPolicyAcknowledgement() hash = %s, want the hash of the record", tt.ent.Hash)
+			}
+		})
+	}
+}
+
+func TestPolicyAcknowledgementHashDetectsChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ent *entity.PolicyAcknowledgement)
+	}{
+		{"employee", func(ent *entity.PolicyAcknowledgement) { ent.EmployeeID = uuid.New() }},
+		{"document hash", func(ent *entity.PolicyAcknowledgement) { ent.DocumentHash = "" }},
+		{"acknowledged at", func(ent *entity.PolicyAcknowledgement) { ent.AcknowledgedAt = ent.AcknowledgedAt.Add(time.Second) }},
+		{"ip address", func(ent *entity.PolicyAcknowledgement) { ent.IPAddress = "10.0.0.2" }},
+		{"sequence", func(ent *entity.PolicyAcknowledgement) { ent.Sequence++ }},
+		{"previous hash", func(ent *entity.PolicyAcknowledgement) { ent.PreviousHash = ent.Hash }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ent := newPolicyAcknowledgement()
+			SealPolicyAcknowledgement(ent, nil)
+
+			tt.change(ent)
+			if PolicyAcknowledgementHash(ent) == ent.Hash {
+				t.Errorf("PolicyAcknowledgementHash() = %s, want a different hash after changing the %s", ent.Hash, tt.name)
+			}
+		})
+	}
+}
+
+func TestPolicyAcknowledgementHashIgnoresTimeZone(t *testing.T) {
+	ent := newPolicyAcknowledgement()
+	SealPolicyAcknowledgement(ent, nil)
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	ent.AcknowledgedAt = ent.AcknowledgedAt.In(loc)
+	if PolicyAcknowledgementHash(ent) != ent.Hash {
+		t.Error("PolicyAcknowledgementHash() changed when the acknowledged time was read in another time zone")
+	}
+}
+
+func TestFileContentHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+
+	got, err := FileContentHash(path)
+	if err != nil {
+		t.Fatalf("FileContentHash() error = %v", err)
+	}
+	if want := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; got != want {
+		t.Errorf("FileContentHash() = %s, want %s", got, want)
+	}
+
+	if _, err := FileContentHash(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("FileContentHash() error = nil, want an error for a missing file")
+	}
+}
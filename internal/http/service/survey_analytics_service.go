@@ -0,0 +1,431 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/google/uuid"
+)
+
+// BuildSurveyAnalytics aggregates the responses of the given respondents, employee tasks or
+// distribution recipients, to a survey template, which has to be loaded with its questions,
+// their options and answer types. Responses of respondents outside respondentIDs are ignored.
+// The answers of a confidential survey are only aggregated when enough respondents responded.
+func BuildSurveyAnalytics(surveyTemplate *entity.SurveyTemplate, respondentIDs []uuid.UUID, surveyResponses []entity.SurveyResponse) *response.SurveyAnalyticsResponse {
+	assigned := make(map[uuid.UUID]bool, len(respondentIDs))
+	for _, respondentID := range respondentIDs {
+		assigned[respondentID] = true
+	}
+
+	// answers per question per respondent, a checkbox question has a response per option
+	answers := make(map[uuid.UUID]map[uuid.UUID][]string)
+	responded := make(map[uuid.UUID]bool)
+	for _, surveyResponse := range surveyResponses {
+		respondentID := surveyResponse.RespondentID()
+		if !assigned[respondentID] {
+			continue
+		}
+		answer := strings.TrimSpace(surveyResponse.Answer)
+		if answer == "" && surveyResponse.AnswerFile != "" {
+			answer = surveyResponse.AnswerFile
+		}
+		if answer == "" {
+			continue
+		}
+		if answers[surveyResponse.QuestionID] == nil {
+			answers[surveyResponse.QuestionID] = make(map[uuid.UUID][]string)
+		}
+		answers[surveyResponse.QuestionID][respondentID] = append(answers[surveyResponse.QuestionID][respondentID], answer)
+		responded[respondentID] = true
+	}
+
+	analytics := &response.SurveyAnalyticsResponse{
+		SurveyTemplateID: surveyTemplate.ID,
+		Title:            surveyTemplate.Title,
+		VersionNumber:    surveyTemplate.VersionNumber,
+		Assigned:         len(assigned),
+		Responded:        len(responded),
+		ResponseRate:     surveyAnalyticsPercentage(len(responded), len(assigned)),
+		Anonymity:        surveyTemplate.Anonymity,
+		Questions:        []response.SurveyQuestionAnalyticsResponse{},
+	}
+	if surveyTemplate.Anonymity == entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL {
+		minGroupSize := surveyTemplate.ReportingGroupSize()
+		analytics.MinGroupSize = &minGroupSize
+		analytics.Suppressed = len(responded) < minGroupSize
+	}
+
+	questions := append([]entity.Question(nil), surveyTemplate.Questions...)
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Number < questions[j].Number
+	})
+	for _, question := range questions {
+		answerType := ""
+		if question.AnswerType != nil {
+			answerType = question.AnswerType.Name
+		}
+
+		questionAnswers := answers[question.ID]
+		questionAnalytics := response.SurveyQuestionAnalyticsResponse{
+			QuestionID:   question.ID,
+			Number:       question.Number,
+			Question:     question.Question,
+			AnswerType:   answerType,
+			Answered:     len(questionAnswers),
+			ResponseRate: surveyAnalyticsPercentage(len(questionAnswers), len(responded)),
+		}
+
+		if analytics.Suppressed {
+			analytics.Questions = append(analytics.Questions, questionAnalytics)
+			continue
+		}
+
+		switch answerType {
+		case entity.ANSWER_TYPE_MULTIPLE_CHOICE, entity.ANSWER_TYPE_CHECKBOX, entity.ANSWER_TYPE_DROPDOWN:
+			questionAnalytics.Options = surveyOptionDistribution(question, questionAnswers)
+		case entity.ANSWER_TYPE_RATING:
+			questionAnalytics.Rating = surveyRatingAnalytics(question, questionAnswers)
+		case entity.ANSWER_TYPE_NUMBER:
+			questionAnalytics.Numeric = surveyNumberAnalytics(questionAnswers)
+		case entity.ANSWER_TYPE_DATE, entity.ANSWER_TYPE_DATE_RANGE:
+			questionAnalytics.Date = surveyDateAnalytics(answerType == entity.ANSWER_TYPE_DATE_RANGE, questionAnswers)
+		case entity.ANSWER_TYPE_MATRIX:
+			questionAnalytics.Matrix = surveyMatrixAnalytics(question, questionAnswers)
+		case entity.ANSWER_TYPE_RANKING:
+			questionAnalytics.Ranking = surveyRankingAnalytics(question, questionAnswers)
+		}
+
+		analytics.Questions = append(analytics.Questions, questionAnalytics)
+	}
+
+	return analytics
+}
+
+// BuildSurveyVersionAnalytics lines up the questions of the versions of a survey by their
+// text, in the order they first appear.
+func BuildSurveyVersionAnalytics(surveyNumber string, versions []response.SurveyAnalyticsResponse) *response.SurveyVersionAnalyticsResponse {
+	analytics := &response.SurveyVersionAnalyticsResponse{
+		SurveyNumber: surveyNumber,
+		Versions:     versions,
+		Questions:    []response.SurveyQuestionVersionsResponse{},
+	}
+
+	questionIndex := make(map[string]int)
+	for _, version := range versions {
+		for _, question := range version.Questions {
+			key := strings.ToLower(strings.TrimSpace(question.Question))
+			index, ok := questionIndex[key]
+			if !ok {
+				index = len(analytics.Questions)
+				questionIndex[key] = index
+				analytics.Questions = append(analytics.Questions, response.SurveyQuestionVersionsResponse{
+					Question: strings.TrimSpace(question.Question),
+				})
+			}
+
+			questionVersion := response.SurveyQuestionVersionResponse{
+				VersionNumber: version.VersionNumber,
+				QuestionID:    question.QuestionID,
+				Number:        question.Number,
+				AnswerType:    question.AnswerType,
+				Answered:      question.Answered,
+				ResponseRate:  question.ResponseRate,
+			}
+			if question.Rating != nil {
+				mean := question.Rating.Mean
+				questionVersion.Mean = &mean
+			} else if question.Numeric != nil {
+				mean := question.Numeric.Mean
+				questionVersion.Mean = &mean
+			}
+			analytics.Questions[index].Versions = append(analytics.Questions[index].Versions, questionVersion)
+		}
+	}
+
+	return analytics
+}
+
+// surveyOptionDistribution counts every option once per respondent, so the percentages of
+// a checkbox question can add up to more than 100.
+func surveyOptionDistribution(question entity.Question, answers map[uuid.UUID][]string) []response.SurveyOptionAnalyticsResponse {
+	options := make([]response.SurveyOptionAnalyticsResponse, 0, len(question.QuestionOptions))
+	optionIndexes := make(map[string]int)
+	for _, questionOption := range question.QuestionOptions {
+		optionID := questionOption.ID
+		optionIndexes[optionID.String()] = len(options)
+		optionIndexes[strings.TrimSpace(questionOption.OptionText)] = len(options)
+		options = append(options, response.SurveyOptionAnalyticsResponse{
+			OptionID: &optionID,
+			Option:   questionOption.OptionText,
+		})
+	}
+
+	for _, employeeTaskAnswers := range answers {
+		chosen := make(map[int]bool)
+		for _, answer := range employeeTaskAnswers {
+			index, ok := optionIndexes[answer]
+			if !ok {
+				index = len(options)
+				optionIndexes[answer] = index
+				options = append(options, response.SurveyOptionAnalyticsResponse{
+					Option: answer,
+				})
+			}
+			if chosen[index] {
+				continue
+			}
+			chosen[index] = true
+			options[index].Count++
+		}
+	}
+
+	// answers that match no option come after the options, in a stable order
+	unmatched := options[len(question.QuestionOptions):]
+	sort.SliceStable(unmatched, func(i, j int) bool {
+		return unmatched[i].Option < unmatched[j].Option
+	})
+
+	for i := range options {
+		options[i].Percentage = surveyAnalyticsPercentage(options[i].Count, len(answers))
+	}
+
+	return options
+}
+
+// surveyRatingAnalytics reads the answers as a number of stars. Answers that are not a whole
+// number between 1 and the max stars of the question are left out.
+func surveyRatingAnalytics(question entity.Question, answers map[uuid.UUID][]string) *response.SurveyRatingAnalyticsResponse {
+	rating := &response.SurveyRatingAnalyticsResponse{
+		MaxStars:  question.MaxStars,
+		Histogram: make([]response.SurveyRatingBucketResponse, 0, question.MaxStars),
+	}
+	for stars := 1; stars <= question.MaxStars; stars++ {
+		rating.Histogram = append(rating.Histogram, response.SurveyRatingBucketResponse{Stars: stars})
+	}
+	if question.MaxStars < 1 {
+		return rating
+	}
+
+	values := make([]int, 0, len(answers))
+	for _, employeeTaskAnswers := range answers {
+		stars, err := strconv.Atoi(employeeTaskAnswers[0])
+		if err != nil || stars < 1 || stars > question.MaxStars {
+			continue
+		}
+		values = append(values, stars)
+	}
+	if len(values) == 0 {
+		return rating
+	}
+	sort.Ints(values)
+
+	nps := &response.SurveyNpsResponse{}
+	total := 0
+	for _, stars := range values {
+		total += stars
+		rating.Histogram[stars-1].Count++
+
+		scaled := float64(stars) * 10 / float64(question.MaxStars)
+		switch {
+		case scaled >= 9:
+			nps.Promoters++
+		case scaled >= 7:
+			nps.Passives++
+		default:
+			nps.Detractors++
+		}
+	}
+
+	middle := len(values) / 2
+	median := float64(values[middle])
+	if len(values)%2 == 0 {
+		median = float64(values[middle-1]+values[middle]) / 2
+	}
+
+	rating.Count = len(values)
+	rating.Mean = roundSurveyAnalytics(float64(total) / float64(len(values)))
+	rating.Median = median
+	rating.MeanPercentage = roundSurveyAnalytics(float64(total) * 100 / float64(len(values)*question.MaxStars))
+	nps.Score = roundSurveyAnalytics(float64(nps.Promoters-nps.Detractors) * 100 / float64(len(values)))
+	rating.Nps = nps
+
+	return rating
+}
+
+// surveyNumberAnalytics leaves out answers that are not a number.
+func surveyNumberAnalytics(answers map[uuid.UUID][]string) *response.SurveyNumberAnalyticsResponse {
+	values := make([]float64, 0, len(answers))
+	for _, employeeTaskAnswers := range answers {
+		value, err := strconv.ParseFloat(strings.TrimSpace(employeeTaskAnswers[0]), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		values = append(values, value)
+	}
+
+	number := &response.SurveyNumberAnalyticsResponse{Count: len(values)}
+	if len(values) == 0 {
+		return number
+	}
+	sort.Float64s(values)
+
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	number.Mean = roundSurveyAnalytics(total / float64(len(values)))
+	number.Median = roundSurveyAnalytics(surveyAnalyticsMedian(values))
+	number.Min = values[0]
+	number.Max = values[len(values)-1]
+
+	return number
+}
+
+// surveyDateAnalytics leaves out answers that are not a date, or a range, of the question.
+func surveyDateAnalytics(isRange bool, answers map[uuid.UUID][]string) *response.SurveyDateAnalyticsResponse {
+	date := &response.SurveyDateAnalyticsResponse{}
+	var earliest, latest string
+	totalDays := 0
+	for _, employeeTaskAnswers := range answers {
+		var start, end string
+		if isRange {
+			startDate, endDate, err := ParseSurveyDateRangeAnswer(employeeTaskAnswers[0])
+			if err != nil || endDate.Before(startDate) {
+				continue
+			}
+			start, end = startDate.Format("2006-01-02"), endDate.Format("2006-01-02")
+			totalDays += int(endDate.Sub(startDate).Hours()/24) + 1
+		} else {
+			answeredDate, err := ParseSurveyDateAnswer(employeeTaskAnswers[0])
+			if err != nil {
+				continue
+			}
+			start = answeredDate.Format("2006-01-02")
+			end = start
+		}
+
+		date.Count++
+		if earliest == "" || start < earliest {
+			earliest = start
+		}
+		if latest == "" || end > latest {
+			latest = end
+		}
+	}
+
+	if date.Count == 0 {
+		return date
+	}
+	date.Earliest = &earliest
+	date.Latest = &latest
+	if isRange {
+		averageDays := roundSurveyAnalytics(float64(totalDays) / float64(date.Count))
+		date.AverageDays = &averageDays
+	}
+
+	return date
+}
+
+// surveyMatrixAnalytics distributes the columns per row. The percentages of a row are of the
+// respondents that answered that row.
+func surveyMatrixAnalytics(question entity.Question, answers map[uuid.UUID][]string) []response.SurveyMatrixRowAnalyticsResponse {
+	rowAnswers := make(map[string]map[uuid.UUID][]string)
+	for employeeTaskID, employeeTaskAnswers := range answers {
+		matrix, err := ParseSurveyMatrixAnswer(employeeTaskAnswers[0])
+		if err != nil {
+			continue
+		}
+		for row, column := range matrix {
+			row = surveyMatrixRowText(&question, row)
+			if rowAnswers[row] == nil {
+				rowAnswers[row] = make(map[uuid.UUID][]string)
+			}
+			rowAnswers[row][employeeTaskID] = []string{strings.TrimSpace(column)}
+		}
+	}
+
+	rows := make([]response.SurveyMatrixRowAnalyticsResponse, 0, len(question.MatrixRows))
+	for _, matrixRow := range question.MatrixRows {
+		rowID := matrixRow.ID
+		answered := rowAnswers[strings.TrimSpace(matrixRow.RowText)]
+		rows = append(rows, response.SurveyMatrixRowAnalyticsResponse{
+			RowID:    &rowID,
+			Row:      matrixRow.RowText,
+			Answered: len(answered),
+			Options:  surveyOptionDistribution(question, answered),
+		})
+	}
+
+	return rows
+}
+
+// surveyRankingAnalytics averages the rank of every option over the rankings it is part of.
+func surveyRankingAnalytics(question entity.Question, answers map[uuid.UUID][]string) []response.SurveyRankingAnalyticsResponse {
+	options := make([]response.SurveyRankingAnalyticsResponse, 0, len(question.QuestionOptions))
+	optionIndexes := make(map[string]int, len(question.QuestionOptions))
+	for _, questionOption := range question.QuestionOptions {
+		optionID := questionOption.ID
+		optionIndexes[strings.TrimSpace(questionOption.OptionText)] = len(options)
+		options = append(options, response.SurveyRankingAnalyticsResponse{
+			OptionID: &optionID,
+			Option:   questionOption.OptionText,
+		})
+	}
+
+	totalRanks := make([]int, len(options))
+	for _, employeeTaskAnswers := range answers {
+		ranking, err := ParseSurveyRankingAnswer(employeeTaskAnswers[0])
+		if err != nil {
+			continue
+		}
+		for rank, option := range ranking {
+			index, ok := optionIndexes[surveyOptionText(&question, option)]
+			if !ok {
+				continue
+			}
+			options[index].Count++
+			totalRanks[index] += rank + 1
+			if rank == 0 {
+				options[index].FirstPlace++
+			}
+		}
+	}
+
+	for i := range options {
+		if options[i].Count > 0 {
+			options[i].AverageRank = roundSurveyAnalytics(float64(totalRanks[i]) / float64(options[i].Count))
+		}
+	}
+	// options that were never ranked come last
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].Count == 0 || options[j].Count == 0 {
+			return options[i].Count > 0 && options[j].Count == 0
+		}
+		return options[i].AverageRank < options[j].AverageRank
+	})
+
+	return options
+}
+
+func surveyAnalyticsMedian(sorted []float64) float64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func surveyAnalyticsPercentage(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundSurveyAnalytics(float64(count) * 100 / float64(total))
+}
+
+func roundSurveyAnalytics(value float64) float64 {
+	return math.Round(value*100) / 100
+}
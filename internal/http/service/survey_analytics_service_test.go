@@ -0,0 +1,232 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/google/uuid"
+)
+
+func TestBuildSurveyAnalytics(t *testing.T) {
+	questionID := uuid.New()
+	firstTaskID := uuid.New()
+	secondTaskID := uuid.New()
+	thirdTaskID := uuid.New()
+	outsiderTaskID := uuid.New()
+
+	surveyResponses := []entity.SurveyResponse{
+		{EmployeeTaskID: &firstTaskID, QuestionID: questionID, Answer: "Yes"},
+		{EmployeeTaskID: &secondTaskID, QuestionID: questionID, Answer: "No"},
+		{EmployeeTaskID: &thirdTaskID, QuestionID: questionID, Answer: " "},
+		{EmployeeTaskID: &outsiderTaskID, QuestionID: questionID, Answer: "Yes"},
+	}
+
+	minGroupSize := func(size int) *int {
+		return &size
+	}
+
+	tests := []struct {
+		name           string
+		anonymity      entity.SurveyTemplateAnonymityEnum
+		minGroupSize   *int
+		wantSuppressed bool
+	}{
+		{"named survey", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE, nil, false},
+		{"anonymous survey", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, minGroupSize(5), false},
+		{"confidential survey under the group size", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, minGroupSize(3), true},
+		{"confidential survey at the group size", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, minGroupSize(2), false},
+		{"confidential survey with the default group size", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surveyTemplate := &entity.SurveyTemplate{
+				ID:           uuid.New(),
+				Anonymity:    tt.anonymity,
+				MinGroupSize: tt.minGroupSize,
+				Questions: []entity.Question{
+					{
+						ID:         questionID,
+						Number:     1,
+						AnswerType: &entity.AnswerType{Name: entity.ANSWER_TYPE_MULTIPLE_CHOICE},
+						QuestionOptions: []entity.QuestionOption{
+							{ID: uuid.New(), OptionText: "Yes"},
+							{ID: uuid.New(), OptionText: "No"},
+						},
+					},
+				},
+			}
+
+			analytics := BuildSurveyAnalytics(surveyTemplate, []uuid.UUID{firstTaskID, secondTaskID, thirdTaskID}, surveyResponses)
+			if analytics.Assigned != 3 || analytics.Responded != 2 {
+				t.Errorf("BuildSurveyAnalytics() assigned = %d, responded = %d, want 3 and 2", analytics.Assigned, analytics.Responded)
+			}
+			if analytics.ResponseRate != 66.67 {
+				t.Errorf("BuildSurveyAnalytics() response rate = %v, want 66.67", analytics.ResponseRate)
+			}
+			if analytics.Suppressed != tt.wantSuppressed {
+				t.Errorf("BuildSurveyAnalytics() suppressed = %v, want %v", analytics.Suppressed, tt.wantSuppressed)
+			}
+			if (analytics.MinGroupSize != nil) != (tt.anonymity == entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL) {
+				t.Errorf("BuildSurveyAnalytics() min group size = %v, want it only for confidential surveys", analytics.MinGroupSize)
+			}
+
+			if len(analytics.Questions) != 1 {
+				t.Fatalf("BuildSurveyAnalytics() returned %d questions, want 1", len(analytics.Questions))
+			}
+			question := analytics.Questions[0]
+			if question.Answered != 2 {
+				t.Errorf("BuildSurveyAnalytics() answered = %d, want 2", question.Answered)
+			}
+			if tt.wantSuppressed && question.Options != nil {
+				t.Errorf("BuildSurveyAnalytics() options = %+v, want none while suppressed", question.Options)
+			}
+			if !tt.wantSuppressed && (len(question.Options) != 2 || question.Options[0].Count != 1 || question.Options[1].Count != 1) {
+				t.Errorf("BuildSurveyAnalytics() options = %+v, want one answer for each option", question.Options)
+			}
+		})
+	}
+}
+
+func TestSurveyOptionDistribution(t *testing.T) {
+	redID := uuid.New()
+	blueID := uuid.New()
+	question := entity.Question{
+		QuestionOptions: []entity.QuestionOption{
+			{ID: redID, OptionText: "Red"},
+			{ID: blueID, OptionText: "Blue"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		answers map[uuid.UUID][]string
+		want    []response.SurveyOptionAnalyticsResponse
+	}{
+		{
+			name:    "no answers",
+			answers: map[uuid.UUID][]string{},
+			want: []response.SurveyOptionAnalyticsResponse{
+				{OptionID: &redID, Option: "Red"},
+				{OptionID: &blueID, Option: "Blue"},
+			},
+		},
+		{
+			name: "options by text and id, counted once per respondent",
+			answers: map[uuid.UUID][]string{
+				uuid.New(): {"Red", "Blue"},
+				uuid.New(): {"Red", "Red"},
+				uuid.New(): {blueID.String()},
+				uuid.New(): {"Red"},
+			},
+			want: []response.SurveyOptionAnalyticsResponse{
+				{OptionID: &redID, Option: "Red", Count: 3, Percentage: 75},
+				{OptionID: &blueID, Option: "Blue", Count: 2, Percentage: 50},
+			},
+		},
+		{
+			name: "answers without option come last in order",
+			answers: map[uuid.UUID][]string{
+				uuid.New(): {"Red", "Purple"},
+				uuid.New(): {"Orange"},
+			},
+			want: []response.SurveyOptionAnalyticsResponse{
+				{OptionID: &redID, Option: "Red", Count: 1, Percentage: 50},
+				{OptionID: &blueID, Option: "Blue"},
+				{Option: "Orange", Count: 1, Percentage: 50},
+				{Option: "Purple", Count: 1, Percentage: 50},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := surveyOptionDistribution(question, tt.answers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("surveyOptionDistribution() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurveyRatingAnalytics(t *testing.T) {
+	question := entity.Question{MaxStars: 5}
+
+	tests := []struct {
+		name    string
+		answers []string
+		want    *response.SurveyRatingAnalyticsResponse
+	}{
+		{
+			name:    "no valid answers",
+			answers: []string{"0", "6", "many"},
+			want: &response.SurveyRatingAnalyticsResponse{
+				MaxStars:  5,
+				Histogram: []response.SurveyRatingBucketResponse{{Stars: 1}, {Stars: 2}, {Stars: 3}, {Stars: 4}, {Stars: 5}},
+			},
+		},
+		{
+			name:    "odd number of answers",
+			answers: []string{"5", "4", "2", "9"},
+			want: &response.SurveyRatingAnalyticsResponse{
+				MaxStars:       5,
+				Count:          3,
+				Mean:           3.67,
+				Median:         4,
+				MeanPercentage: 73.33,
+				Histogram:      []response.SurveyRatingBucketResponse{{Stars: 1}, {Stars: 2, Count: 1}, {Stars: 3}, {Stars: 4, Count: 1}, {Stars: 5, Count: 1}},
+				Nps:            &response.SurveyNpsResponse{Promoters: 1, Passives: 1, Detractors: 1},
+			},
+		},
+		{
+			name:    "even number of answers",
+			answers: []string{"5", "5", "5", "1"},
+			want: &response.SurveyRatingAnalyticsResponse{
+				MaxStars:       5,
+				Count:          4,
+				Mean:           4,
+				Median:         5,
+				MeanPercentage: 80,
+				Histogram:      []response.SurveyRatingBucketResponse{{Stars: 1, Count: 1}, {Stars: 2}, {Stars: 3}, {Stars: 4}, {Stars: 5, Count: 3}},
+				Nps:            &response.SurveyNpsResponse{Promoters: 3, Detractors: 1, Score: 50},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make(map[uuid.UUID][]string, len(tt.answers))
+			for _, answer := range tt.answers {
+				answers[uuid.New()] = []string{answer}
+			}
+			if got := surveyRatingAnalytics(question, answers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("surveyRatingAnalytics() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurveyNumberAnalytics(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		want    response.SurveyNumberAnalyticsResponse
+	}{
+		{"no valid answers", []string{"abc", "NaN", "Inf"}, response.SurveyNumberAnalyticsResponse{}},
+		{"odd number of answers", []string{"1.5", " 3 ", "-2", "abc"}, response.SurveyNumberAnalyticsResponse{Count: 3, Mean: 0.83, Median: 1.5, Min: -2, Max: 3}},
+		{"even number of answers", []string{"1", "2", "3", "10"}, response.SurveyNumberAnalyticsResponse{Count: 4, Mean: 4, Median: 2.5, Min: 1, Max: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make(map[uuid.UUID][]string, len(tt.answers))
+			for _, answer := range tt.answers {
+				answers[uuid.New()] = []string{answer}
+			}
+			if got := surveyNumberAnalytics(answers); *got != tt.want {
+				t.Errorf("surveyNumberAnalytics() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
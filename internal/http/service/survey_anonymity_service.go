@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/google/uuid"
+)
+
+// ValidateSurveyAnonymity checks the anonymity settings of a survey template before its
+// questions are saved.
+func ValidateSurveyAnonymity(req *request.CreateOrUpdateQuestions) error {
+	if req.MinGroupSize != nil && entity.SurveyTemplateAnonymityEnum(req.Anonymity) != entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL {
+		return errors.New("a minimum group size is only used by confidential survey templates")
+	}
+
+	return nil
+}
+
+// SurveyAnonymitySettings applies the anonymity of the request to a survey template. A request
+// without anonymity keeps the current settings.
+func SurveyAnonymitySettings(req *request.CreateOrUpdateQuestions, current *entity.SurveyTemplate) *entity.SurveyTemplate {
+	settings := &entity.SurveyTemplate{
+		Anonymity: entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE,
+	}
+	if current != nil {
+		settings.ID = current.ID
+		if current.Anonymity != "" {
+			settings.Anonymity = current.Anonymity
+		}
+		settings.MinGroupSize = current.MinGroupSize
+	}
+	if req.Anonymity != "" {
+		settings.Anonymity = entity.SurveyTemplateAnonymityEnum(req.Anonymity)
+		settings.MinGroupSize = req.MinGroupSize
+	}
+	if settings.Anonymity != entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL {
+		settings.MinGroupSize = nil
+	}
+
+	return settings
+}
+
+// ValidateSurveyAnonymitySettings checks the settings a survey template gets. Quiz scores are
+// kept per employee, so a quiz cannot be anonymous. The responses of a published version keep
+// its settings, as editing it makes a new version.
+func ValidateSurveyAnonymitySettings(isQuiz bool, settings *entity.SurveyTemplate) error {
+	if isQuiz && settings.IsAnonymous() {
+		return errors.New("quiz survey templates cannot be anonymous or confidential")
+	}
+
+	return nil
+}
+
+// SurveyResponsesHiddenFrom tells whether the answers of a respondent to a survey template must
+// be left out for the viewer. Answers to an anonymous or confidential survey come with the task
+// of the employee who gave them, so only that employee may read them.
+func SurveyResponsesHiddenFrom(surveyTemplate *entity.SurveyTemplate, respondentEmployeeID *uuid.UUID, viewerEmployeeID uuid.UUID) bool {
+	if surveyTemplate == nil || !surveyTemplate.IsAnonymous() {
+		return false
+	}
+
+	return respondentEmployeeID == nil || *respondentEmployeeID != viewerEmployeeID
+}
+
+// HideSurveyResponses removes the answers from the questions.
+func HideSurveyResponses(questions []entity.Question) {
+	for i := range questions {
+		questions[i].SurveyResponses = []entity.SurveyResponse{}
+	}
+}
+
+// HideAnonymousEmployeeTaskResponses removes the answers of an employee task to its survey when
+// they must be left out for the viewer.
+func HideAnonymousEmployeeTaskResponses(employeeTask *entity.EmployeeTask, viewerEmployeeID uuid.UUID) {
+	if employeeTask.SurveyTemplate == nil || !SurveyResponsesHiddenFrom(employeeTask.SurveyTemplate, employeeTask.EmployeeID, viewerEmployeeID) {
+		return
+	}
+
+	HideSurveyResponses(employeeTask.SurveyTemplate.Questions)
+}
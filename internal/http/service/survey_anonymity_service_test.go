@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestSurveyResponsesHiddenFrom(t *testing.T) {
+	respondentID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name         string
+		anonymity    entity.SurveyTemplateAnonymityEnum
+		respondentID *uuid.UUID
+		viewerID     uuid.UUID
+		want         bool
+	}{
+		{"named survey read by someone else", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE, &respondentID, otherID, false},
+		{"anonymous survey read by the respondent", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, &respondentID, respondentID, false},
+		{"anonymous survey read by someone else", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, &respondentID, otherID, true},
+		{"confidential survey read by someone else", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, &respondentID, otherID, true},
+		{"anonymous survey without respondent", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, nil, otherID, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surveyTemplate := &entity.SurveyTemplate{Anonymity: tt.anonymity}
+			if got := SurveyResponsesHiddenFrom(surveyTemplate, tt.respondentID, tt.viewerID); got != tt.want {
+				t.Errorf("SurveyResponsesHiddenFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newAnsweredEmployeeTask(employeeID uuid.UUID, anonymity entity.SurveyTemplateAnonymityEnum) *entity.EmployeeTask {
+	return &entity.EmployeeTask{
+		EmployeeID: &employeeID,
+		SurveyTemplate: &entity.SurveyTemplate{
+			Anonymity: anonymity,
+			Questions: []entity.Question{
+				{SurveyResponses: []entity.SurveyResponse{{Answer: "my manager ignores me"}}},
+				{SurveyResponses: []entity.SurveyResponse{{Answer: "3"}}},
+			},
+		},
+	}
+}
+
+func countSurveyResponses(employeeTask *entity.EmployeeTask) int {
+	count := 0
+	for _, question := range employeeTask.SurveyTemplate.Questions {
+		count += len(question.SurveyResponses)
+	}
+	return count
+}
+
+// The task of an employee names them, so anyone else opening it must not get the answers the
+// employee gave to an anonymous survey.
+func TestHideAnonymousEmployeeTaskResponses(t *testing.T) {
+	employeeID := uuid.New()
+	adminID := uuid.New()
+
+	tests := []struct {
+		name      string
+		anonymity entity.SurveyTemplateAnonymityEnum
+		viewerID  uuid.UUID
+		want      int
+	}{
+		{"anonymous survey read by an admin", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, adminID, 0},
+		{"confidential survey read by an admin", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, adminID, 0},
+		{"anonymous survey read by the employee", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, employeeID, 2},
+		{"named survey read by an admin", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE, adminID, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employeeTask := newAnsweredEmployeeTask(employeeID, tt.anonymity)
+			HideAnonymousEmployeeTaskResponses(employeeTask, tt.viewerID)
+			if got := countSurveyResponses(employeeTask); got != tt.want {
+				t.Errorf("%d answers left, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+)
+
+// surveyDateRangeSeparator splits the start and end of a date range answer, as in ISO 8601
+const surveyDateRangeSeparator = "/"
+
+// ParseSurveyDateAnswer reads a date answer, written as YYYY-MM-DD.
+func ParseSurveyDateAnswer(answer string) (time.Time, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	return time.ParseInLocation("2006-01-02", strings.TrimSpace(answer), loc)
+}
+
+// ParseSurveyDateRangeAnswer reads a date range answer, written as YYYY-MM-DD/YYYY-MM-DD.
+func ParseSurveyDateRangeAnswer(answer string) (time.Time, time.Time, error) {
+	dates := strings.Split(strings.TrimSpace(answer), surveyDateRangeSeparator)
+	if len(dates) != 2 {
+		return time.Time{}, time.Time{}, errors.New("a date range is written as start/end")
+	}
+
+	start, err := ParseSurveyDateAnswer(dates[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := ParseSurveyDateAnswer(dates[1])
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return start, end, nil
+}
+
+// ParseSurveyMatrixAnswer reads a matrix answer, a JSON object with the chosen column of every
+// answered row.
+func ParseSurveyMatrixAnswer(answer string) (map[string]string, error) {
+	var matrix map[string]string
+	if err := json.Unmarshal([]byte(answer), &matrix); err != nil {
+		return nil, errors.New("a matrix answer is a JSON object of a column per row")
+	}
+	return matrix, nil
+}
+
+// ParseSurveyRankingAnswer reads a ranking answer, a JSON array of the options from first to last.
+func ParseSurveyRankingAnswer(answer string) ([]string, error) {
+	var ranking []string
+	if err := json.Unmarshal([]byte(answer), &ranking); err != nil {
+		return nil, errors.New("a ranking answer is a JSON array of the options in order")
+	}
+	return ranking, nil
+}
+
+// NormalizeSurveyAnswer stores the rows and options of matrix and ranking answers by their
+// text, as their ids change every time the questions of a template are saved. Answers that
+// cannot be read are stored as sent, they are refused by the validation before.
+func NormalizeSurveyAnswer(question *entity.Question, answer string) string {
+	if question == nil || question.AnswerType == nil {
+		return answer
+	}
+
+	switch question.AnswerType.Name {
+	case entity.ANSWER_TYPE_MATRIX:
+		matrix, err := ParseSurveyMatrixAnswer(answer)
+		if err != nil {
+			return answer
+		}
+		normalized := make(map[string]string, len(matrix))
+		for row, column := range matrix {
+			normalized[surveyMatrixRowText(question, row)] = surveyOptionText(question, column)
+		}
+		encoded, err := json.Marshal(normalized)
+		if err != nil {
+			return answer
+		}
+		return string(encoded)
+	case entity.ANSWER_TYPE_RANKING:
+		ranking, err := ParseSurveyRankingAnswer(answer)
+		if err != nil {
+			return answer
+		}
+		normalized := make([]string, 0, len(ranking))
+		for _, option := range ranking {
+			normalized = append(normalized, surveyOptionText(question, option))
+		}
+		encoded, err := json.Marshal(normalized)
+		if err != nil {
+			return answer
+		}
+		return string(encoded)
+	case entity.ANSWER_TYPE_NUMBER, entity.ANSWER_TYPE_DATE, entity.ANSWER_TYPE_DATE_RANGE:
+		return strings.TrimSpace(answer)
+	default:
+		return answer
+	}
+}
+
+// surveyOptionText returns the text of the option with the id or text, or the value itself
+// when no option matches.
+func surveyOptionText(question *entity.Question, value string) string {
+	value = strings.TrimSpace(value)
+	for _, questionOption := range question.QuestionOptions {
+		if questionOption.ID.String() == value || strings.TrimSpace(questionOption.OptionText) == value {
+			return strings.TrimSpace(questionOption.OptionText)
+		}
+	}
+	return value
+}
+
+func surveyMatrixRowText(question *entity.Question, value string) string {
+	value = strings.TrimSpace(value)
+	for _, matrixRow := range question.MatrixRows {
+		if matrixRow.ID.String() == value || strings.TrimSpace(matrixRow.RowText) == value {
+			return strings.TrimSpace(matrixRow.RowText)
+		}
+	}
+	return value
+}
@@ -0,0 +1,427 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/google/uuid"
+)
+
+// Attachment answers of questions without their own rules are held to these
+var (
+	defaultSurveyAnswerFileTypes  = []string{"pdf", "jpg", "jpeg", "png", "doc", "docx", "xls", "xlsx"}
+	defaultSurveyAnswerFileSizeKB = 10 * 1024
+)
+
+// SurveyAnswerValidationError lists every answer that broke a rule of its question.
+type SurveyAnswerValidationError struct {
+	Errors []response.SurveyAnswerFieldErrorResponse
+}
+
+func (e *SurveyAnswerValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Field+": "+fieldError.Message)
+	}
+	return "invalid answers: " + strings.Join(messages, "; ")
+}
+
+// SurveyAnswerInput is an answer as it is sent. Field names the form field it came from in
+// the errors. An attachment answer with an ID keeps its stored file when none is sent.
+type SurveyAnswerInput struct {
+	Field    string
+	ID       *string
+	Answer   string
+	File     *multipart.FileHeader
+	FilePath string
+}
+
+func (a SurveyAnswerInput) hasFile() bool {
+	return a.File != nil || a.FilePath != ""
+}
+
+func (a SurveyAnswerInput) isEmpty() bool {
+	return strings.TrimSpace(a.Answer) == "" && !a.hasFile()
+}
+
+// ValidateSurveyQuestionRules checks the answer rules of the questions before they are saved.
+func ValidateSurveyQuestionRules(req *request.CreateOrUpdateQuestions) error {
+	for i, question := range req.Questions {
+		if question.MinLength != nil && question.MaxLength != nil && *question.MinLength > *question.MaxLength {
+			return fmt.Errorf("question %d: min_length is greater than max_length", i+1)
+		}
+		if question.MinSelections != nil && question.MaxSelections != nil && *question.MinSelections > *question.MaxSelections {
+			return fmt.Errorf("question %d: min_selections is greater than max_selections", i+1)
+		}
+		if question.Pattern != nil && *question.Pattern != "" {
+			if _, err := regexp.Compile(*question.Pattern); err != nil {
+				return fmt.Errorf("question %d: invalid pattern: %w", i+1, err)
+			}
+		}
+		if question.MinValue != nil && question.MaxValue != nil && *question.MinValue > *question.MaxValue {
+			return fmt.Errorf("question %d: min_value is greater than max_value", i+1)
+		}
+		// dates are validated as YYYY-MM-DD, which compares the same as text
+		if question.MinDate != nil && question.MaxDate != nil && *question.MinDate > *question.MaxDate {
+			return fmt.Errorf("question %d: min_date is after max_date", i+1)
+		}
+	}
+
+	return nil
+}
+
+// SurveyAnswerFileTypes normalizes allowed file types to lower case extensions without a dot.
+func SurveyAnswerFileTypes(fileTypes []string) []string {
+	normalized := make([]string, 0, len(fileTypes))
+	for _, fileType := range fileTypes {
+		fileType = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(fileType), "."))
+		if fileType != "" {
+			normalized = append(normalized, fileType)
+		}
+	}
+	return normalized
+}
+
+// ValidateSurveyAnswers checks the answers of an employee task to a question, which has to be
+// loaded with its answer type, options and matrix rows. An unanswered required question only fails when
+// requireAnswer is set, so that answers can be saved before the survey is submitted.
+func ValidateSurveyAnswers(question *entity.Question, answers []SurveyAnswerInput, requireAnswer bool) []response.SurveyAnswerFieldErrorResponse {
+	fieldErrors := make([]response.SurveyAnswerFieldErrorResponse, 0)
+	addError := func(field, message string) {
+		fieldErrors = append(fieldErrors, response.SurveyAnswerFieldErrorResponse{
+			Field:      field,
+			QuestionID: question.ID,
+			Message:    message,
+		})
+	}
+
+	answerType := ""
+	if question.AnswerType != nil {
+		answerType = question.AnswerType.Name
+	}
+
+	given := make([]SurveyAnswerInput, 0, len(answers))
+	for _, answer := range answers {
+		if !answer.isEmpty() || (answerType == entity.ANSWER_TYPE_ATTACHMENT && answer.ID != nil) {
+			given = append(given, answer)
+		}
+	}
+
+	questionField := "answers"
+	if len(given) == 0 {
+		if question.IsRequired && requireAnswer {
+			addError(questionField, "an answer is required")
+		}
+		return fieldErrors
+	}
+
+	if answerType != entity.ANSWER_TYPE_ATTACHMENT {
+		for _, answer := range given {
+			if answer.hasFile() {
+				addError(answer.Field, "a file can only answer an attachment question")
+			}
+		}
+	}
+
+	switch answerType {
+	case entity.ANSWER_TYPE_MULTIPLE_CHOICE, entity.ANSWER_TYPE_DROPDOWN:
+		if len(given) > 1 {
+			addError(questionField, "only one option can be chosen")
+		}
+		validateSurveyAnswerOptions(question, given, addError)
+	case entity.ANSWER_TYPE_CHECKBOX:
+		validateSurveyAnswerOptions(question, given, addError)
+		if question.MinSelections != nil && len(given) < *question.MinSelections {
+			addError(questionField, fmt.Sprintf("choose at least %d options", *question.MinSelections))
+		}
+		if question.MaxSelections != nil && len(given) > *question.MaxSelections {
+			addError(questionField, fmt.Sprintf("choose at most %d options", *question.MaxSelections))
+		}
+	case entity.ANSWER_TYPE_RATING:
+		for _, answer := range given {
+			stars, err := strconv.Atoi(strings.TrimSpace(answer.Answer))
+			if err != nil {
+				addError(answer.Field, "the rating has to be a whole number")
+				continue
+			}
+			if stars < 1 || (question.MaxStars > 0 && stars > question.MaxStars) {
+				addError(answer.Field, fmt.Sprintf("the rating has to be between 1 and %d", question.MaxStars))
+			}
+		}
+	case entity.ANSWER_TYPE_LINK:
+		for _, answer := range given {
+			if !isSurveyAnswerURL(answer.Answer) {
+				addError(answer.Field, "the answer has to be an http or https url")
+				continue
+			}
+			validateSurveyAnswerText(question, answer, addError)
+		}
+	case entity.ANSWER_TYPE_ATTACHMENT:
+		for _, answer := range given {
+			validateSurveyAnswerFile(question, answer, addError)
+		}
+	case entity.ANSWER_TYPE_NUMBER, entity.ANSWER_TYPE_DATE, entity.ANSWER_TYPE_DATE_RANGE, entity.ANSWER_TYPE_MATRIX, entity.ANSWER_TYPE_RANKING:
+		// these answers are a single value, a matrix and a ranking being encoded as JSON
+		if len(given) > 1 {
+			addError(questionField, "only one answer can be given")
+		}
+		for _, answer := range given {
+			switch answerType {
+			case entity.ANSWER_TYPE_NUMBER:
+				validateSurveyAnswerNumber(question, answer, addError)
+			case entity.ANSWER_TYPE_DATE:
+				validateSurveyAnswerDate(question, answer, addError)
+			case entity.ANSWER_TYPE_DATE_RANGE:
+				validateSurveyAnswerDateRange(question, answer, addError)
+			case entity.ANSWER_TYPE_MATRIX:
+				validateSurveyAnswerMatrix(question, answer, requireAnswer, addError)
+			case entity.ANSWER_TYPE_RANKING:
+				validateSurveyAnswerRanking(question, answer, addError)
+			}
+		}
+	default:
+		for _, answer := range given {
+			validateSurveyAnswerText(question, answer, addError)
+		}
+	}
+
+	return fieldErrors
+}
+
+// validateSurveyAnswerOptions accepts an option by its text or id, each option at most once.
+func validateSurveyAnswerOptions(question *entity.Question, answers []SurveyAnswerInput, addError func(field, message string)) {
+	options := make(map[string]uuid.UUID)
+	for _, questionOption := range question.QuestionOptions {
+		options[questionOption.ID.String()] = questionOption.ID
+		options[strings.TrimSpace(questionOption.OptionText)] = questionOption.ID
+	}
+
+	chosen := make(map[uuid.UUID]bool)
+	for _, answer := range answers {
+		optionID, ok := options[strings.TrimSpace(answer.Answer)]
+		if !ok {
+			addError(answer.Field, "the answer is not one of the options")
+			continue
+		}
+		if chosen[optionID] {
+			addError(answer.Field, "the option is chosen more than once")
+		}
+		chosen[optionID] = true
+	}
+}
+
+func validateSurveyAnswerText(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	length := utf8.RuneCountInString(answer.Answer)
+	if question.MinLength != nil && length < *question.MinLength {
+		addError(answer.Field, fmt.Sprintf("the answer has to be at least %d characters", *question.MinLength))
+	}
+	if question.MaxLength != nil && length > *question.MaxLength {
+		addError(answer.Field, fmt.Sprintf("the answer has to be at most %d characters", *question.MaxLength))
+	}
+	if question.Pattern != nil && *question.Pattern != "" {
+		pattern, err := regexp.Compile(*question.Pattern)
+		if err == nil && !pattern.MatchString(answer.Answer) {
+			addError(answer.Field, "the answer does not match the expected format")
+		}
+	}
+}
+
+// validateSurveyAnswerFile checks an uploaded file. An answer that keeps its stored file has
+// nothing to check.
+func validateSurveyAnswerFile(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	if answer.File == nil {
+		if answer.FilePath == "" && answer.ID == nil {
+			addError(answer.Field, "a file is required")
+		}
+		return
+	}
+
+	fileTypes := defaultSurveyAnswerFileTypes
+	if question.AllowedFileTypes != nil && *question.AllowedFileTypes != "" {
+		fileTypes = strings.Split(*question.AllowedFileTypes, ",")
+	}
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(answer.File.Filename), "."))
+	allowed := false
+	for _, fileType := range fileTypes {
+		if fileType == extension {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		addError(answer.Field, "the file has to be one of "+strings.Join(fileTypes, ", "))
+	}
+
+	maxFileSizeKB := defaultSurveyAnswerFileSizeKB
+	if question.MaxFileSizeKB != nil {
+		maxFileSizeKB = *question.MaxFileSizeKB
+	}
+	if answer.File.Size > int64(maxFileSizeKB)*1024 {
+		addError(answer.Field, fmt.Sprintf("the file has to be at most %d KB", maxFileSizeKB))
+	}
+}
+
+func validateSurveyAnswerNumber(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	number, err := strconv.ParseFloat(strings.TrimSpace(answer.Answer), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		addError(answer.Field, "the answer has to be a number")
+		return
+	}
+	if question.MinValue != nil && number < *question.MinValue {
+		addError(answer.Field, fmt.Sprintf("the answer has to be at least %s", strconv.FormatFloat(*question.MinValue, 'f', -1, 64)))
+	}
+	if question.MaxValue != nil && number > *question.MaxValue {
+		addError(answer.Field, fmt.Sprintf("the answer has to be at most %s", strconv.FormatFloat(*question.MaxValue, 'f', -1, 64)))
+	}
+}
+
+func validateSurveyAnswerDate(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	date, err := ParseSurveyDateAnswer(answer.Answer)
+	if err != nil {
+		addError(answer.Field, "the answer has to be a date as YYYY-MM-DD")
+		return
+	}
+	validateSurveyAnswerDateBounds(question, answer.Field, date, addError)
+}
+
+func validateSurveyAnswerDateRange(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	start, end, err := ParseSurveyDateRangeAnswer(answer.Answer)
+	if err != nil {
+		addError(answer.Field, "the answer has to be a date range as YYYY-MM-DD/YYYY-MM-DD")
+		return
+	}
+	if end.Before(start) {
+		addError(answer.Field, "the end of the range is before its start")
+		return
+	}
+	validateSurveyAnswerDateBounds(question, answer.Field, start, addError)
+	validateSurveyAnswerDateBounds(question, answer.Field, end, addError)
+}
+
+// validateSurveyAnswerDateBounds compares calendar dates, the bounds being stored without a time.
+func validateSurveyAnswerDateBounds(question *entity.Question, field string, date time.Time, addError func(field, message string)) {
+	day := date.Format("2006-01-02")
+	if question.MinDate != nil && day < question.MinDate.Format("2006-01-02") {
+		addError(field, "the date has to be on or after "+question.MinDate.Format("2006-01-02"))
+	}
+	if question.MaxDate != nil && day > question.MaxDate.Format("2006-01-02") {
+		addError(field, "the date has to be on or before "+question.MaxDate.Format("2006-01-02"))
+	}
+}
+
+// validateSurveyAnswerMatrix accepts rows and columns by their text or id. A required matrix
+// needs every row answered once the survey is submitted.
+func validateSurveyAnswerMatrix(question *entity.Question, answer SurveyAnswerInput, requireAnswer bool, addError func(field, message string)) {
+	matrix, err := ParseSurveyMatrixAnswer(answer.Answer)
+	if err != nil {
+		addError(answer.Field, err.Error())
+		return
+	}
+
+	rows := make(map[string]uuid.UUID, len(question.MatrixRows)*2)
+	for _, matrixRow := range question.MatrixRows {
+		rows[matrixRow.ID.String()] = matrixRow.ID
+		rows[strings.TrimSpace(matrixRow.RowText)] = matrixRow.ID
+	}
+	columns := make(map[string]bool, len(question.QuestionOptions)*2)
+	for _, questionOption := range question.QuestionOptions {
+		columns[questionOption.ID.String()] = true
+		columns[strings.TrimSpace(questionOption.OptionText)] = true
+	}
+
+	keys := make([]string, 0, len(matrix))
+	for row := range matrix {
+		keys = append(keys, row)
+	}
+	sort.Strings(keys)
+
+	answered := make(map[uuid.UUID]bool, len(matrix))
+	for _, row := range keys {
+		rowID, ok := rows[strings.TrimSpace(row)]
+		if !ok {
+			addError(answer.Field, fmt.Sprintf("%q is not one of the rows", row))
+			continue
+		}
+		if answered[rowID] {
+			addError(answer.Field, fmt.Sprintf("the row %q is answered more than once", row))
+		}
+		answered[rowID] = true
+		if !columns[strings.TrimSpace(matrix[row])] {
+			addError(answer.Field, fmt.Sprintf("%q is not one of the columns", matrix[row]))
+		}
+	}
+
+	if question.IsRequired && requireAnswer && len(answered) < len(question.MatrixRows) {
+		addError(answer.Field, "every row has to be answered")
+	}
+}
+
+// validateSurveyAnswerRanking needs every option ranked exactly once.
+func validateSurveyAnswerRanking(question *entity.Question, answer SurveyAnswerInput, addError func(field, message string)) {
+	ranking, err := ParseSurveyRankingAnswer(answer.Answer)
+	if err != nil {
+		addError(answer.Field, err.Error())
+		return
+	}
+
+	options := make(map[string]uuid.UUID, len(question.QuestionOptions)*2)
+	for _, questionOption := range question.QuestionOptions {
+		options[questionOption.ID.String()] = questionOption.ID
+		options[strings.TrimSpace(questionOption.OptionText)] = questionOption.ID
+	}
+
+	ranked := make(map[uuid.UUID]bool, len(ranking))
+	for _, option := range ranking {
+		optionID, ok := options[strings.TrimSpace(option)]
+		if !ok {
+			addError(answer.Field, fmt.Sprintf("%q is not one of the options", option))
+			continue
+		}
+		if ranked[optionID] {
+			addError(answer.Field, fmt.Sprintf("the option %q is ranked more than once", option))
+		}
+		ranked[optionID] = true
+	}
+
+	if len(ranked) != len(question.QuestionOptions) {
+		addError(answer.Field, "every option has to be ranked")
+	}
+}
+
+func isSurveyAnswerURL(answer string) bool {
+	parsedURL, err := url.ParseRequestURI(strings.TrimSpace(answer))
+	if err != nil {
+		return false
+	}
+	return (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
+
+// NewSurveyAnswerValidationError returns nil when there are no field errors.
+func NewSurveyAnswerValidationError(fieldErrors []response.SurveyAnswerFieldErrorResponse) error {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return &SurveyAnswerValidationError{Errors: fieldErrors}
+}
+
+// IsSurveyAnswerValidationError returns the field errors of err, if it is a validation error.
+func IsSurveyAnswerValidationError(err error) (*SurveyAnswerValidationError, bool) {
+	var validationErr *SurveyAnswerValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
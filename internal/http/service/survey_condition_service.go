@@ -0,0 +1,242 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/google/uuid"
+)
+
+// ValidateSurveyQuestionConditions checks the display conditions of the questions before they
+// are saved. A condition has to point at another question of the request and the conditions
+// may not depend on each other in a circle.
+func ValidateSurveyQuestionConditions(req *request.CreateOrUpdateQuestions) error {
+	sources := make([][]int, len(req.Questions))
+	for i, question := range req.Questions {
+		for _, condition := range question.DisplayConditions {
+			if condition.SourceQuestionNumber > len(req.Questions) {
+				return fmt.Errorf("question %d: display condition on question %d that does not exist", i+1, condition.SourceQuestionNumber)
+			}
+			if condition.SourceQuestionNumber == i+1 {
+				return fmt.Errorf("question %d: display condition on itself", i+1)
+			}
+
+			switch entity.QuestionConditionOperatorEnum(condition.Operator) {
+			case entity.QUESTION_CONDITION_OPERATOR_ENUM_ANSWERED, entity.QUESTION_CONDITION_OPERATOR_ENUM_NOT_ANSWERED:
+			case entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN,
+				entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN_OR_EQUAL,
+				entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN,
+				entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL:
+				if _, err := strconv.ParseFloat(strings.TrimSpace(condition.Value), 64); err != nil {
+					return fmt.Errorf("question %d: display condition %s needs a number", i+1, condition.Operator)
+				}
+			default:
+				if strings.TrimSpace(condition.Value) == "" {
+					return fmt.Errorf("question %d: display condition %s needs a value", i+1, condition.Operator)
+				}
+			}
+
+			sources[i] = append(sources[i], condition.SourceQuestionNumber-1)
+		}
+	}
+
+	// depth first search, a question met again while its sources are visited closes a circle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(req.Questions))
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		states[i] = visiting
+		for _, source := range sources[i] {
+			if states[source] == visiting {
+				return false
+			}
+			if states[source] == unvisited && !visit(source) {
+				return false
+			}
+		}
+		states[i] = visited
+		return true
+	}
+	for i := range req.Questions {
+		if states[i] == unvisited && !visit(i) {
+			return fmt.Errorf("question %d: display conditions are circular", i+1)
+		}
+	}
+
+	return nil
+}
+
+// SurveyQuestionAnswers collects the answers to each question from their survey responses.
+func SurveyQuestionAnswers(surveyResponses []entity.SurveyResponse) map[uuid.UUID][]string {
+	answers := make(map[uuid.UUID][]string)
+	for _, surveyResponse := range surveyResponses {
+		answer := strings.TrimSpace(surveyResponse.Answer)
+		if answer == "" {
+			answer = surveyResponse.AnswerFile
+		}
+		if answer == "" {
+			continue
+		}
+		answers[surveyResponse.QuestionID] = append(answers[surveyResponse.QuestionID], answer)
+	}
+	return answers
+}
+
+// HiddenSurveyQuestions returns the questions whose display conditions are not met by the
+// answers. The questions have to be loaded with their display conditions and options. The
+// answers to a hidden question do not count, so the questions depending on it see it as
+// unanswered.
+func HiddenSurveyQuestions(questions []entity.Question, answers map[uuid.UUID][]string) map[uuid.UUID]bool {
+	questionsByID := make(map[uuid.UUID]*entity.Question, len(questions))
+	for i := range questions {
+		questionsByID[questions[i].ID] = &questions[i]
+	}
+
+	visible := make(map[uuid.UUID]bool, len(questions))
+	evaluating := make(map[uuid.UUID]bool)
+	var isVisible func(question *entity.Question) bool
+	isVisible = func(question *entity.Question) bool {
+		if shown, ok := visible[question.ID]; ok {
+			return shown
+		}
+		if len(question.DisplayConditions) == 0 {
+			visible[question.ID] = true
+			return true
+		}
+		// circles are refused when the template is saved, this only guards older data
+		if evaluating[question.ID] {
+			return false
+		}
+		evaluating[question.ID] = true
+
+		matchAny := question.ConditionMatch == entity.QUESTION_CONDITION_MATCH_ENUM_ANY
+		shown := !matchAny
+		for _, condition := range question.DisplayConditions {
+			var sourceAnswers []string
+			source, ok := questionsByID[condition.SourceQuestionID]
+			if ok && isVisible(source) {
+				sourceAnswers = surveyConditionAnswers(source, answers[source.ID])
+			}
+
+			matched := surveyConditionMatches(condition, sourceAnswers)
+			if matchAny && matched {
+				shown = true
+				break
+			}
+			if !matchAny && !matched {
+				shown = false
+				break
+			}
+		}
+
+		delete(evaluating, question.ID)
+		visible[question.ID] = shown
+		return shown
+	}
+
+	hidden := make(map[uuid.UUID]bool)
+	for i := range questions {
+		if !isVisible(&questions[i]) {
+			hidden[questions[i].ID] = true
+		}
+	}
+	return hidden
+}
+
+// MarkHiddenSurveyQuestions flags the hidden questions of a survey template response.
+func MarkHiddenSurveyQuestions(resp *response.SurveyTemplateResponse, hidden map[uuid.UUID]bool) {
+	if resp == nil {
+		return
+	}
+	for i := range resp.Questions {
+		resp.Questions[i].IsHidden = hidden[resp.Questions[i].ID]
+	}
+}
+
+// surveyConditionAnswers reads an option chosen by its id as the text of the option, which is
+// what conditions compare against.
+func surveyConditionAnswers(question *entity.Question, answers []string) []string {
+	options := make(map[string]string, len(question.QuestionOptions))
+	for _, questionOption := range question.QuestionOptions {
+		options[questionOption.ID.String()] = questionOption.OptionText
+	}
+
+	normalized := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		answer = strings.TrimSpace(answer)
+		if optionText, ok := options[answer]; ok {
+			answer = strings.TrimSpace(optionText)
+		}
+		if answer != "" {
+			normalized = append(normalized, answer)
+		}
+	}
+	return normalized
+}
+
+// surveyConditionMatches compares text case insensitively. The number operators read the first
+// answer, which is the only one of a rating question.
+func surveyConditionMatches(condition entity.QuestionCondition, answers []string) bool {
+	value := strings.TrimSpace(condition.Value)
+
+	switch condition.Operator {
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_ANSWERED:
+		return len(answers) > 0
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_NOT_ANSWERED:
+		return len(answers) == 0
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_EQUALS:
+		for _, answer := range answers {
+			if strings.EqualFold(answer, value) {
+				return true
+			}
+		}
+		return false
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_NOT_EQUALS:
+		for _, answer := range answers {
+			if strings.EqualFold(answer, value) {
+				return false
+			}
+		}
+		return len(answers) > 0
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_CONTAINS:
+		for _, answer := range answers {
+			if strings.Contains(strings.ToLower(answer), strings.ToLower(value)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(answers) == 0 {
+		return false
+	}
+	answerNumber, err := strconv.ParseFloat(answers[0], 64)
+	if err != nil {
+		return false
+	}
+	valueNumber, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+
+	switch condition.Operator {
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN:
+		return answerNumber < valueNumber
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_LESS_THAN_OR_EQUAL:
+		return answerNumber <= valueNumber
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN:
+		return answerNumber > valueNumber
+	case entity.QUESTION_CONDITION_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL:
+		return answerNumber >= valueNumber
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+)
+
+const surveyExportSheet = "Survey Responses"
+
+// surveyExportFixedColumns come before the question columns in the tabular formats
+var surveyExportFixedColumns = []string{"Employee Task Name", "Employee Name", "Survey Number", "Survey Name", "Survey Version", "Submitted At"}
+
+// surveyExportAnonymousName stands in for the employee on the rows of anonymous surveys
+const surveyExportAnonymousName = "Anonymous"
+
+// SurveyExportRow is the export of one employee task. Answers follow the question columns
+// the writer was made with and are empty for questions that were not answered. An Anonymous
+// row is written without its employee task and employee, and only the day of SubmittedAt.
+type SurveyExportRow struct {
+	EmployeeTaskID   uuid.UUID
+	EmployeeTaskName string
+	EmployeeID       *uuid.UUID
+	EmployeeName     string
+	SurveyNumber     string
+	SurveyTitle      string
+	SurveyVersion    int
+	SubmittedAt      *time.Time
+	Anonymous        bool
+	Answers          []string
+}
+
+// AnonymizeSurveyExportRow drops what links a row to its employee, the submission time
+// included as it can be matched with the task history.
+func AnonymizeSurveyExportRow(row SurveyExportRow) SurveyExportRow {
+	row.EmployeeTaskID = uuid.Nil
+	row.EmployeeTaskName = ""
+	row.EmployeeID = nil
+	row.EmployeeName = surveyExportAnonymousName
+	row.Anonymous = true
+	if row.SubmittedAt != nil {
+		submittedOn := time.Date(row.SubmittedAt.Year(), row.SubmittedAt.Month(), row.SubmittedAt.Day(), 0, 0, 0, 0, row.SubmittedAt.Location())
+		row.SubmittedAt = &submittedOn
+	}
+
+	return row
+}
+
+// ISurveyExportWriter writes rows as they come so that an export never holds more than a
+// batch in memory. Close has to be called to finish the file.
+type ISurveyExportWriter interface {
+	WriteRow(row SurveyExportRow) error
+	Close() error
+}
+
+// NewSurveyExportWriter starts an export in the format on w, writing the header right away.
+func NewSurveyExportWriter(format entity.SurveyExportFormatEnum, w io.Writer, questions []string) (ISurveyExportWriter, error) {
+	switch format {
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_CSV:
+		return newSurveyExportCSVWriter(w, questions)
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_JSONL:
+		return &surveyExportJSONLWriter{encoder: json.NewEncoder(w), questions: questions}, nil
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_XLSX, "":
+		return newSurveyExportXLSXWriter(w, questions)
+	default:
+		return nil, fmt.Errorf("unsupported export format %s", format)
+	}
+}
+
+// SurveyExportContentType and SurveyExportExtension describe the file of a format.
+func SurveyExportContentType(format entity.SurveyExportFormatEnum) string {
+	switch format {
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_CSV:
+		return "text/csv"
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_JSONL:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+}
+
+func SurveyExportExtension(format entity.SurveyExportFormatEnum) string {
+	switch format {
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_CSV:
+		return ".csv"
+	case entity.SURVEY_EXPORT_FORMAT_ENUM_JSONL:
+		return ".jsonl"
+	default:
+		return ".xlsx"
+	}
+}
+
+func surveyExportRecord(row SurveyExportRow) []string {
+	submittedAt := ""
+	if row.SubmittedAt != nil && row.Anonymous {
+		submittedAt = row.SubmittedAt.Format("2006-01-02")
+	} else if row.SubmittedAt != nil {
+		submittedAt = row.SubmittedAt.Format("2006-01-02 15:04:05")
+	}
+
+	surveyVersion := ""
+	if row.SurveyVersion > 0 {
+		surveyVersion = strconv.Itoa(row.SurveyVersion)
+	}
+
+	record := []string{row.EmployeeTaskName, row.EmployeeName, row.SurveyNumber, row.SurveyTitle, surveyVersion, submittedAt}
+	return append(record, row.Answers...)
+}
+
+type surveyExportCSVWriter struct {
+	writer *csv.Writer
+}
+
+func newSurveyExportCSVWriter(w io.Writer, questions []string) (*surveyExportCSVWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string{}, surveyExportFixedColumns...), questions...)); err != nil {
+		return nil, err
+	}
+
+	return &surveyExportCSVWriter{writer: writer}, nil
+}
+
+func (c *surveyExportCSVWriter) WriteRow(row SurveyExportRow) error {
+	return c.writer.Write(surveyExportRecord(row))
+}
+
+func (c *surveyExportCSVWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type surveyExportJSONLAnswer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+type surveyExportJSONLRow struct {
+	EmployeeTaskID   *uuid.UUID                `json:"employee_task_id"`
+	EmployeeTaskName string                    `json:"employee_task_name"`
+	EmployeeID       *uuid.UUID                `json:"employee_id"`
+	EmployeeName     string                    `json:"employee_name"`
+	SurveyNumber     string                    `json:"survey_number"`
+	SurveyTitle      string                    `json:"survey_title"`
+	SurveyVersion    int                       `json:"survey_version"`
+	SubmittedAt      *time.Time                `json:"submitted_at"`
+	Anonymous        bool                      `json:"anonymous"`
+	Answers          []surveyExportJSONLAnswer `json:"answers"`
+}
+
+// surveyExportJSONLWriter writes a JSON object per line. Only the answered questions are
+// listed, as a row does not have the columns of the other survey templates.
+type surveyExportJSONLWriter struct {
+	encoder   *json.Encoder
+	questions []string
+}
+
+func (j *surveyExportJSONLWriter) WriteRow(row SurveyExportRow) error {
+	answers := make([]surveyExportJSONLAnswer, 0)
+	for i, answer := range row.Answers {
+		if answer == "" || i >= len(j.questions) {
+			continue
+		}
+		answers = append(answers, surveyExportJSONLAnswer{Question: j.questions[i], Answer: answer})
+	}
+
+	var employeeTaskID *uuid.UUID
+	if !row.Anonymous {
+		employeeTaskID = &row.EmployeeTaskID
+	}
+
+	return j.encoder.Encode(surveyExportJSONLRow{
+		EmployeeTaskID:   employeeTaskID,
+		EmployeeTaskName: row.EmployeeTaskName,
+		EmployeeID:       row.EmployeeID,
+		EmployeeName:     row.EmployeeName,
+		SurveyNumber:     row.SurveyNumber,
+		SurveyTitle:      row.SurveyTitle,
+		SurveyVersion:    row.SurveyVersion,
+		SubmittedAt:      row.SubmittedAt,
+		Anonymous:        row.Anonymous,
+		Answers:          answers,
+	})
+}
+
+func (j *surveyExportJSONLWriter) Close() error {
+	return nil
+}
+
+// surveyExportXLSXWriter uses the excelize stream writer, which keeps the rows in a temporary
+// file until the workbook is written out on Close.
+type surveyExportXLSXWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newSurveyExportXLSXWriter(w io.Writer, questions []string) (*surveyExportXLSXWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", surveyExportSheet); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold:  true,
+			Size:  12,
+			Color: "#000000",
+		},
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"#90EE90"},
+			Pattern: 1,
+		},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	stream, err := f.NewStreamWriter(surveyExportSheet)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// column widths have to be set before the first row is written
+	if err := stream.SetColWidth(1, len(surveyExportFixedColumns), 20); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if len(questions) > 0 {
+		if err := stream.SetColWidth(len(surveyExportFixedColumns)+1, len(surveyExportFixedColumns)+len(questions), 30); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	headers := append(append([]string{}, surveyExportFixedColumns...), questions...)
+	header := make([]interface{}, 0, len(headers))
+	for _, title := range headers {
+		header = append(header, excelize.Cell{StyleID: headerStyle, Value: title})
+	}
+
+	writer := &surveyExportXLSXWriter{w: w, file: f, stream: stream, row: 1}
+	if err := writer.setRow(header); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (x *surveyExportXLSXWriter) setRow(values []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	if err := x.stream.SetRow(cell, values); err != nil {
+		return err
+	}
+	x.row++
+
+	return nil
+}
+
+func (x *surveyExportXLSXWriter) WriteRow(row SurveyExportRow) error {
+	record := surveyExportRecord(row)
+	values := make([]interface{}, 0, len(record))
+	for _, value := range record {
+		values = append(values, value)
+	}
+
+	return x.setRow(values)
+}
+
+func (x *surveyExportXLSXWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+
+	return x.file.Write(x.w)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+)
+
+// ValidateSurveyQuiz checks the quiz settings of a survey template before its questions are
+// saved. A quiz needs a passing score and at least one question with a correct option.
+func ValidateSurveyQuiz(req *request.CreateOrUpdateQuestions) error {
+	if !req.IsQuiz {
+		return nil
+	}
+	if req.PassingScore == nil {
+		return errors.New("quiz survey templates need a passing score")
+	}
+
+	for _, question := range req.Questions {
+		for _, questionOption := range question.QuestionOptions {
+			if questionOption.IsCorrect {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("quiz survey templates need at least one question with a correct option")
+}
+
+// SurveyQuizPassingScore is the percentage an attempt needs: the passing score of the task when
+// it has one, otherwise the one of the survey template.
+func SurveyQuizPassingScore(surveyTemplate *entity.SurveyTemplate, employeeTask *entity.EmployeeTask) *int {
+	if employeeTask.PassingScore != nil {
+		return employeeTask.PassingScore
+	}
+	return surveyTemplate.PassingScore
+}
+
+// GradeSurveyQuiz scores the responses of an employee task against the survey template, which
+// has to be loaded with its questions and their options. Only questions with a correct option
+// are scored. An answer is correct when the chosen options, by text or id, are exactly the
+// correct options of the question.
+func GradeSurveyQuiz(surveyTemplate *entity.SurveyTemplate, employeeTask *entity.EmployeeTask, surveyResponses []entity.SurveyResponse) *entity.SurveyQuizAttempt {
+	answersByQuestion := make(map[string][]string)
+	for _, surveyResponse := range surveyResponses {
+		if strings.TrimSpace(surveyResponse.Answer) == "" {
+			continue
+		}
+		questionID := surveyResponse.QuestionID.String()
+		answersByQuestion[questionID] = append(answersByQuestion[questionID], strings.TrimSpace(surveyResponse.Answer))
+	}
+
+	attempt := &entity.SurveyQuizAttempt{
+		SurveyTemplateID: surveyTemplate.ID,
+		EmployeeTaskID:   employeeTask.ID,
+		EmployeeID:       employeeTask.EmployeeID,
+		PassingScore:     SurveyQuizPassingScore(surveyTemplate, employeeTask),
+		SubmittedAt:      time.Now(),
+	}
+
+	questions := append([]entity.Question(nil), surveyTemplate.Questions...)
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Number < questions[j].Number
+	})
+	for _, question := range questions {
+		correct := make(map[string]bool)
+		optionTexts := make(map[string]string)
+		for _, questionOption := range question.QuestionOptions {
+			optionTexts[questionOption.ID.String()] = strings.TrimSpace(questionOption.OptionText)
+			if questionOption.IsCorrect {
+				correct[strings.TrimSpace(questionOption.OptionText)] = true
+			}
+		}
+		if len(correct) == 0 {
+			continue
+		}
+
+		answers := answersByQuestion[question.ID.String()]
+		chosen := make(map[string]bool)
+		for _, answer := range answers {
+			if optionText, ok := optionTexts[answer]; ok {
+				answer = optionText
+			}
+			chosen[answer] = true
+		}
+
+		isCorrect := len(chosen) == len(correct)
+		for answer := range chosen {
+			if !correct[answer] {
+				isCorrect = false
+				break
+			}
+		}
+
+		points := question.Points
+		if points < 1 {
+			points = 1
+		}
+		answer := &entity.SurveyQuizAttemptAnswer{
+			QuestionID: question.ID,
+			Answer:     strings.Join(answers, ", "),
+			IsCorrect:  isCorrect,
+			MaxPoints:  points,
+		}
+		if isCorrect {
+			answer.Points = points
+		}
+
+		attempt.Score += answer.Points
+		attempt.MaxScore += answer.MaxPoints
+		attempt.Answers = append(attempt.Answers, *answer)
+	}
+
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	attempt.Passed = attempt.PassingScore == nil || attempt.Percentage >= *attempt.PassingScore
+
+	return attempt
+}
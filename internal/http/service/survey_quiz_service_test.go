@@ -0,0 +1,161 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/google/uuid"
+)
+
+func TestValidateSurveyQuiz(t *testing.T) {
+	passingScore := 70
+
+	tests := []struct {
+		name    string
+		req     request.CreateOrUpdateQuestions
+		wantErr bool
+	}{
+		{"not a quiz", request.CreateOrUpdateQuestions{}, false},
+		{"quiz without passing score", request.CreateOrUpdateQuestions{
+			IsQuiz: true,
+			Questions: []request.QuestionRequest{
+				{QuestionOptions: []request.QuestionOptionRequest{{OptionText: "A", IsCorrect: true}}},
+			},
+		}, true},
+		{"quiz without correct option", request.CreateOrUpdateQuestions{
+			IsQuiz:       true,
+			PassingScore: &passingScore,
+			Questions: []request.QuestionRequest{
+				{QuestionOptions: []request.QuestionOptionRequest{{OptionText: "A"}}},
+			},
+		}, true},
+		{"quiz with correct option", request.CreateOrUpdateQuestions{
+			IsQuiz:       true,
+			PassingScore: &passingScore,
+			Questions: []request.QuestionRequest{
+				{QuestionOptions: []request.QuestionOptionRequest{{OptionText: "A"}}},
+				{QuestionOptions: []request.QuestionOptionRequest{{OptionText: "B", IsCorrect: true}}},
+			},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSurveyQuiz(&tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSurveyQuiz() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSurveyQuizPassingScore(t *testing.T) {
+	templateScore := 60
+	taskScore := 80
+
+	tests := []struct {
+		name           string
+		surveyTemplate entity.SurveyTemplate
+		employeeTask   entity.EmployeeTask
+		want           *int
+	}{
+		{"task score wins", entity.SurveyTemplate{PassingScore: &templateScore}, entity.EmployeeTask{PassingScore: &taskScore}, &taskScore},
+		{"template score", entity.SurveyTemplate{PassingScore: &templateScore}, entity.EmployeeTask{}, &templateScore},
+		{"no score", entity.SurveyTemplate{}, entity.EmployeeTask{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SurveyQuizPassingScore(&tt.surveyTemplate, &tt.employeeTask); got != tt.want {
+				t.Errorf("SurveyQuizPassingScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGradeSurveyQuiz(t *testing.T) {
+	singleQuestionID := uuid.New()
+	multipleQuestionID := uuid.New()
+	openQuestionID := uuid.New()
+	rightOptionID := uuid.New()
+
+	passingScore := 50
+	surveyTemplate := &entity.SurveyTemplate{
+		ID:           uuid.New(),
+		PassingScore: &passingScore,
+		Questions: []entity.Question{
+			{ID: multipleQuestionID, Number: 2, Points: 3, QuestionOptions: []entity.QuestionOption{
+				{ID: uuid.New(), OptionText: "Red", IsCorrect: true},
+				{ID: uuid.New(), OptionText: "Blue", IsCorrect: true},
+				{ID: uuid.New(), OptionText: "Green"},
+			}},
+			{ID: singleQuestionID, Number: 1, QuestionOptions: []entity.QuestionOption{
+				{ID: rightOptionID, OptionText: "Right", IsCorrect: true},
+				{ID: uuid.New(), OptionText: "Wrong"},
+			}},
+			{ID: openQuestionID, Number: 3},
+		},
+	}
+	employeeTask := &entity.EmployeeTask{ID: uuid.New()}
+
+	response := func(questionID uuid.UUID, answer string) entity.SurveyResponse {
+		return entity.SurveyResponse{QuestionID: questionID, Answer: answer}
+	}
+
+	tests := []struct {
+		name           string
+		responses      []entity.SurveyResponse
+		wantScore      int
+		wantPercentage int
+		wantPassed     bool
+	}{
+		{"all correct", []entity.SurveyResponse{
+			response(singleQuestionID, "Right"),
+			response(multipleQuestionID, "Red"),
+			response(multipleQuestionID, "Blue"),
+			response(openQuestionID, "anything"),
+		}, 4, 100, true},
+		{"answer by option id", []entity.SurveyResponse{
+			response(singleQuestionID, rightOptionID.String()),
+		}, 1, 25, false},
+		{"missing one of the multiple options", []entity.SurveyResponse{
+			response(singleQuestionID, "Right"),
+			response(multipleQuestionID, "Red"),
+		}, 1, 25, false},
+		{"extra option on the multiple question", []entity.SurveyResponse{
+			response(multipleQuestionID, "Red"),
+			response(multipleQuestionID, "Blue"),
+			response(multipleQuestionID, "Green"),
+		}, 0, 0, false},
+		{"points of the multiple question", []entity.SurveyResponse{
+			response(singleQuestionID, "Wrong"),
+			response(multipleQuestionID, " Blue "),
+			response(multipleQuestionID, "Red"),
+		}, 3, 75, true},
+		{"blank answers are ignored", []entity.SurveyResponse{
+			response(singleQuestionID, "Right"),
+			response(singleQuestionID, "  "),
+		}, 1, 25, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := GradeSurveyQuiz(surveyTemplate, employeeTask, tt.responses)
+			if attempt.MaxScore != 4 {
+				t.Errorf("GradeSurveyQuiz() max score = %d, want 4", attempt.MaxScore)
+			}
+			if attempt.Score != tt.wantScore {
+				t.Errorf("GradeSurveyQuiz() score = %d, want %d", attempt.Score, tt.wantScore)
+			}
+			if attempt.Percentage != tt.wantPercentage {
+				t.Errorf("GradeSurveyQuiz() percentage = %d, want %d", attempt.Percentage, tt.wantPercentage)
+			}
+			if attempt.Passed != tt.wantPassed {
+				t.Errorf("GradeSurveyQuiz() passed = %v, want %v", attempt.Passed, tt.wantPassed)
+			}
+			if len(attempt.Answers) != 2 || attempt.Answers[0].QuestionID != singleQuestionID {
+				t.Errorf("GradeSurveyQuiz() answers = %+v, want the two graded questions in order", attempt.Answers)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// HireProfile holds the attributes of a hire that template task rules are evaluated against.
+type HireProfile struct {
+	EmployeeID              string
+	OrganizationID          *uuid.UUID
+	OrganizationType        string
+	JobID                   string
+	JobLevelID              string
+	OrganizationLocationID  string
+	OrganizationStructureID string
+	EmploymentType          string
+	// JobLevel is the numeric level of JobLevelID that JOB_LEVEL comparisons use
+	JobLevel *float64
+	// OrganizationStructureParentIDs are the structures above OrganizationStructureID, nil
+	// when they are not known
+	OrganizationStructureParentIDs []string
+
+	// lookups lets attributes that are not in the profile be looked up over RabbitMQ
+	lookups           bool
+	structureChildren map[string]map[string]bool
+}
+
+// MissingHireAttributeError is returned when a rule depends on an attribute the hire has no
+// value for. Such a rule can neither match nor be skipped.
+type MissingHireAttributeError struct {
+	Attribute string
+}
+
+func (e *MissingHireAttributeError) Error() string {
+	return "hire has no " + e.Attribute + ", which template task rules depend on"
+}
+
+type ITemplateTaskRuleService interface {
+	HireProfileFromRequest(req *request.CreateEmployeeTasksForRecruitment) (*HireProfile, error)
+	ResolveHireProfile(req *request.CreateEmployeeTasksForRecruitment) (*HireProfile, error)
+	MatchTemplateTask(profile *HireProfile, templateTask *entity.TemplateTask) (bool, error)
+	MatchRules(profile *HireProfile, rules []entity.TemplateTaskRule) (bool, error)
+	ValidateRules(rules []request.TemplateTaskRuleRequest) error
+}
+
+type TemplateTaskRuleService struct {
+	Log                 *logrus.Logger
+	EmployeeMessage     messaging.IEmployeeMessage
+	OrganizationMessage messaging.IOrganizationMessage
+	JobPlafonMessage    messaging.IJobPlafonMessage
+}
+
+func NewTemplateTaskRuleService(
+	log *logrus.Logger,
+	employeeMessage messaging.IEmployeeMessage,
+	organizationMessage messaging.IOrganizationMessage,
+	jobPlafonMessage messaging.IJobPlafonMessage,
+) ITemplateTaskRuleService {
+	return &TemplateTaskRuleService{
+		Log:                 log,
+		EmployeeMessage:     employeeMessage,
+		OrganizationMessage: organizationMessage,
+		JobPlafonMessage:    jobPlafonMessage,
+	}
+}
+
+func TemplateTaskRuleServiceFactory(log *logrus.Logger) ITemplateTaskRuleService {
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	organizationMessage := messaging.OrganizationMessageFactory(log)
+	jobPlafonMessage := messaging.JobPlafonMessageFactory(log)
+	return NewTemplateTaskRuleService(log, employeeMessage, organizationMessage, jobPlafonMessage)
+}
+
+// HireProfileFromRequest builds the profile from the request alone, nothing is looked up. It is
+// used while a RabbitMQ message is handled, as the replies to lookups arrive through the same
+// consumer and could only be read once the handler returns.
+func (s *TemplateTaskRuleService) HireProfileFromRequest(req *request.CreateEmployeeTasksForRecruitment) (*HireProfile, error) {
+	profile := &HireProfile{
+		EmployeeID:                     req.EmployeeID,
+		OrganizationType:               req.OrganizationType,
+		JobID:                          req.JobID,
+		JobLevelID:                     req.JobLevelID,
+		OrganizationLocationID:         req.OrganizationLocationID,
+		OrganizationStructureID:        req.OrganizationStructureID,
+		EmploymentType:                 req.EmploymentType,
+		JobLevel:                       req.JobLevel,
+		OrganizationStructureParentIDs: req.OrganizationStructureParentIDs,
+		structureChildren:              map[string]map[string]bool{},
+	}
+
+	if req.OrganizationID != "" {
+		parsedOrganizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			s.Log.Error("[TemplateTaskRuleService.HireProfileFromRequest] error parsing organization id: ", err)
+			return nil, err
+		}
+		profile.OrganizationID = &parsedOrganizationID
+	}
+
+	return profile, nil
+}
+
+// ResolveHireProfile builds the profile from the request and fills any missing
+// attribute from the employee's current job.
+func (s *TemplateTaskRuleService) ResolveHireProfile(req *request.CreateEmployeeTasksForRecruitment) (*HireProfile, error) {
+	profile, err := s.HireProfileFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	profile.lookups = true
+
+	if profile.OrganizationID != nil && profile.JobID != "" && profile.JobLevelID != "" &&
+		profile.OrganizationLocationID != "" && profile.OrganizationStructureID != "" && profile.EmploymentType != "" {
+		return profile, nil
+	}
+
+	empResp, err := s.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: req.EmployeeID,
+	})
+	if err != nil {
+		// rules that depend on the missing attributes report them when they are matched
+		s.Log.Warnf("[TemplateTaskRuleService.ResolveHireProfile] error finding employee %s: %s", req.EmployeeID, err.Error())
+		return profile, nil
+	}
+
+	if profile.OrganizationID == nil && empResp.OrganizationID != uuid.Nil {
+		profile.OrganizationID = &empResp.OrganizationID
+	}
+
+	fill := func(target *string, key string) {
+		if *target != "" {
+			return
+		}
+		if value, ok := empResp.EmployeeJob[key].(string); ok {
+			*target = value
+		}
+	}
+	fill(&profile.JobID, "job_id")
+	fill(&profile.JobLevelID, "job_level_id")
+	fill(&profile.OrganizationLocationID, "organization_location_id")
+	fill(&profile.OrganizationStructureID, "organization_structure_id")
+	fill(&profile.EmploymentType, "employment_type")
+
+	return profile, nil
+}
+
+// MatchTemplateTask reports whether the template task applies to the hire.
+func (s *TemplateTaskRuleService) MatchTemplateTask(profile *HireProfile, templateTask *entity.TemplateTask) (bool, error) {
+	return s.MatchRules(profile, templateTask.TemplateTaskRules)
+}
+
+// MatchRules reports whether the hire matches the rules. Rules within a group are combined
+// with AND and the groups with OR, no rules match every hire. A *MissingHireAttributeError is
+// returned when the outcome depends on an attribute the hire has no value for.
+func (s *TemplateTaskRuleService) MatchRules(profile *HireProfile, rules []entity.TemplateTaskRule) (bool, error) {
+	if len(rules) == 0 {
+		return true, nil
+	}
+
+	groups := make(map[int][]entity.TemplateTaskRule)
+	groupOrder := make([]int, 0)
+	for _, rule := range rules {
+		if _, ok := groups[rule.GroupNumber]; !ok {
+			groupOrder = append(groupOrder, rule.GroupNumber)
+		}
+		groups[rule.GroupNumber] = append(groups[rule.GroupNumber], rule)
+	}
+
+	var missing error
+	for _, groupNumber := range groupOrder {
+		groupMatched := true
+		var groupMissing error
+		for _, rule := range groups[groupNumber] {
+			matched, err := s.matchRule(profile, &rule)
+			var missingErr *MissingHireAttributeError
+			if errors.As(err, &missingErr) {
+				// the group can still fail on one of its other rules
+				groupMissing = err
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			if !matched {
+				groupMatched = false
+				break
+			}
+		}
+		if !groupMatched {
+			continue
+		}
+		if groupMissing != nil {
+			missing = groupMissing
+			continue
+		}
+		return true, nil
+	}
+
+	return false, missing
+}
+
+func (s *TemplateTaskRuleService) matchRule(profile *HireProfile, rule *entity.TemplateTaskRule) (bool, error) {
+	var actual string
+	switch rule.Criterion {
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL:
+		actual = profile.JobLevelID
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB:
+		actual = profile.JobID
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_LOCATION:
+		actual = profile.OrganizationLocationID
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE:
+		actual = profile.OrganizationStructureID
+	case entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE:
+		actual = profile.EmploymentType
+	default:
+		return false, errors.New("unknown template task rule criterion: " + string(rule.Criterion))
+	}
+
+	if actual == "" {
+		return false, &MissingHireAttributeError{Attribute: string(rule.Criterion)}
+	}
+
+	switch rule.Operator {
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS:
+		return strings.EqualFold(actual, strings.TrimSpace(rule.Value)), nil
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_EQUALS:
+		return !strings.EqualFold(actual, strings.TrimSpace(rule.Value)), nil
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN:
+		return containsValue(rule.Value, actual), nil
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN:
+		return !containsValue(rule.Value, actual), nil
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL,
+		entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL:
+		expected, err := strconv.ParseFloat(strings.TrimSpace(rule.Value), 64)
+		if err != nil {
+			return false, errors.New("invalid job level value in rule " + rule.ID.String())
+		}
+		level, err := s.findJobLevel(profile)
+		if err != nil {
+			return false, err
+		}
+		if rule.Operator == entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL {
+			return level >= expected, nil
+		}
+		return level <= expected, nil
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE:
+		return s.isInStructureSubtree(profile, strings.TrimSpace(rule.Value))
+	case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE:
+		inSubtree, err := s.isInStructureSubtree(profile, strings.TrimSpace(rule.Value))
+		return !inSubtree, err
+	}
+
+	return false, errors.New("unknown template task rule operator: " + string(rule.Operator))
+}
+
+func containsValue(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(item), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TemplateTaskRuleService) findJobLevel(profile *HireProfile) (float64, error) {
+	if profile.JobLevel != nil {
+		return *profile.JobLevel, nil
+	}
+	if !profile.lookups {
+		return 0, &MissingHireAttributeError{Attribute: "job level"}
+	}
+
+	jobLevelResp, err := s.JobPlafonMessage.SendFindJobLevelByIDMessage(request.SendFindJobLevelByIDMessageRequest{
+		ID: profile.JobLevelID,
+	})
+	if err != nil {
+		s.Log.Error("[TemplateTaskRuleService.findJobLevel] error finding job level: ", err)
+		return 0, err
+	}
+
+	profile.JobLevel = &jobLevelResp.Level
+	return jobLevelResp.Level, nil
+}
+
+func (s *TemplateTaskRuleService) isInStructureSubtree(profile *HireProfile, parentID string) (bool, error) {
+	if strings.EqualFold(profile.OrganizationStructureID, parentID) {
+		return true, nil
+	}
+	if profile.OrganizationStructureParentIDs != nil {
+		for _, structureParentID := range profile.OrganizationStructureParentIDs {
+			if strings.EqualFold(strings.TrimSpace(structureParentID), parentID) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if !profile.lookups {
+		return false, &MissingHireAttributeError{Attribute: "organization structure parents"}
+	}
+
+	children, ok := profile.structureChildren[parentID]
+	if !ok {
+		childrenIDs, err := s.OrganizationMessage.SendFindAllOrgStructureChildrenIDsMessage(parentID)
+		if err != nil {
+			s.Log.Error("[TemplateTaskRuleService.isInStructureSubtree] error finding org structure children: ", err)
+			return false, err
+		}
+
+		children = make(map[string]bool, len(*childrenIDs))
+		for _, childID := range *childrenIDs {
+			children[strings.ToLower(childID)] = true
+		}
+		profile.structureChildren[parentID] = children
+	}
+
+	return children[strings.ToLower(profile.OrganizationStructureID)], nil
+}
+
+// ValidateRules rejects operator and criterion combinations that cannot be evaluated.
+func (s *TemplateTaskRuleService) ValidateRules(rules []request.TemplateTaskRuleRequest) error {
+	for i, rule := range rules {
+		position := strconv.Itoa(i + 1)
+		criterion := entity.TemplateTaskRuleCriterionEnum(rule.Criterion)
+		operator := entity.TemplateTaskRuleOperatorEnum(rule.Operator)
+
+		switch operator {
+		case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL,
+			entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL:
+			if criterion != entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL {
+				return errors.New("rule " + position + ": " + rule.Operator + " can only be used with JOB_LEVEL")
+			}
+			if _, err := strconv.ParseFloat(strings.TrimSpace(rule.Value), 64); err != nil {
+				return errors.New("rule " + position + ": value must be a numeric job level")
+			}
+		case entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE,
+			entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE:
+			if criterion != entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE {
+				return errors.New("rule " + position + ": " + rule.Operator + " can only be used with ORGANIZATION_STRUCTURE")
+			}
+			if _, err := uuid.Parse(strings.TrimSpace(rule.Value)); err != nil {
+				return errors.New("rule " + position + ": value must be an organization structure id")
+			}
+		}
+	}
+
+	return nil
+}
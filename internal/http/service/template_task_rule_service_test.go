@@ -0,0 +1,262 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeJobPlafonMessage struct {
+	messaging.IJobPlafonMessage
+	levels map[string]float64
+}
+
+func (m *fakeJobPlafonMessage) SendFindJobLevelByIDMessage(req request.SendFindJobLevelByIDMessageRequest) (*response.SendFindJobLevelByIDMessageResponse, error) {
+	return &response.SendFindJobLevelByIDMessageResponse{Level: m.levels[req.ID]}, nil
+}
+
+type fakeOrganizationMessage struct {
+	messaging.IOrganizationMessage
+	children map[string][]string
+}
+
+func (m *fakeOrganizationMessage) SendFindAllOrgStructureChildrenIDsMessage(parentID string) (*[]string, error) {
+	children := m.children[parentID]
+	return &children, nil
+}
+
+// unreachableEmployeeMessage fails the test when an employee is looked up.
+type unreachableEmployeeMessage struct {
+	messaging.IEmployeeMessage
+	t *testing.T
+}
+
+func (m *unreachableEmployeeMessage) SendFindEmployeeByIDMessage(req request.SendFindEmployeeByIDMessageRequest) (*response.EmployeeResponse, error) {
+	m.t.Fatalf("SendFindEmployeeByIDMessage(%s) called, want no lookup", req.ID)
+	return nil, nil
+}
+
+// unreachableOrganizationMessage fails the test when structure children are looked up.
+type unreachableOrganizationMessage struct {
+	messaging.IOrganizationMessage
+	t *testing.T
+}
+
+func (m *unreachableOrganizationMessage) SendFindAllOrgStructureChildrenIDsMessage(parentID string) (*[]string, error) {
+	m.t.Fatalf("SendFindAllOrgStructureChildrenIDsMessage(%s) called, want no lookup", parentID)
+	return nil, nil
+}
+
+// unreachableJobPlafonMessage fails the test when a job level is looked up.
+type unreachableJobPlafonMessage struct {
+	messaging.IJobPlafonMessage
+	t *testing.T
+}
+
+func (m *unreachableJobPlafonMessage) SendFindJobLevelByIDMessage(req request.SendFindJobLevelByIDMessageRequest) (*response.SendFindJobLevelByIDMessageResponse, error) {
+	m.t.Fatalf("SendFindJobLevelByIDMessage(%s) called, want no lookup", req.ID)
+	return nil, nil
+}
+
+const (
+	parentStructureID = "7b0f2c38-5d4e-4a8e-9f21-3c1d2e4f5a60"
+	childStructureID  = "9c1e3d49-6e5f-4b9f-8a32-4d2e3f5a6b71"
+	otherStructureID  = "ad2f4e5a-7f60-4caf-9b43-5e3f4a6b7c82"
+)
+
+func TestTemplateTaskRuleServiceMatchRules(t *testing.T) {
+	ruleService := NewTemplateTaskRuleService(
+		logrus.New(),
+		nil,
+		&fakeOrganizationMessage{children: map[string][]string{
+			parentStructureID: {childStructureID},
+		}},
+		&fakeJobPlafonMessage{levels: map[string]float64{"staff": 2, "manager": 5}},
+	)
+
+	rule := func(groupNumber int, criterion entity.TemplateTaskRuleCriterionEnum, operator entity.TemplateTaskRuleOperatorEnum, value string) entity.TemplateTaskRule {
+		return entity.TemplateTaskRule{GroupNumber: groupNumber, Criterion: criterion, Operator: operator, Value: value}
+	}
+
+	tests := []struct {
+		name    string
+		profile HireProfile
+		rules   []entity.TemplateTaskRule
+		want    bool
+		wantErr bool
+	}{
+		{"no rules", HireProfile{}, nil, true, false},
+		{"equals ignores case and spaces", HireProfile{EmploymentType: "Permanent"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, " permanent "),
+		}, true, false},
+		{"not equals", HireProfile{EmploymentType: "Permanent"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_EQUALS, "Permanent"),
+		}, false, false},
+		{"in list", HireProfile{JobID: "b"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN, "a, b, c"),
+		}, true, false},
+		{"not in list", HireProfile{OrganizationLocationID: "b"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_LOCATION, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN, "a,b"),
+		}, false, false},
+		{"missing attribute cannot be decided", HireProfile{}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_EQUALS, "a"),
+		}, false, true},
+		{"job level at least", HireProfile{JobLevelID: "manager"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL, "5"),
+		}, true, false},
+		{"job level at most", HireProfile{JobLevelID: "manager"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL, "3"),
+		}, false, false},
+		{"job level that is not a number", HireProfile{JobLevelID: "staff"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_LESS_THAN_OR_EQUAL, "senior"),
+		}, false, true},
+		{"structure itself is in its subtree", HireProfile{OrganizationStructureID: parentStructureID}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE, parentStructureID),
+		}, true, false},
+		{"child structure is in the subtree", HireProfile{OrganizationStructureID: childStructureID}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE, parentStructureID),
+		}, true, false},
+		{"other structure is not in the subtree", HireProfile{OrganizationStructureID: otherStructureID}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE, parentStructureID),
+		}, true, false},
+		{"rules of a group are combined with and", HireProfile{JobID: "a", EmploymentType: "Contract"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+		}, false, false},
+		{"groups are combined with or", HireProfile{JobID: "a", EmploymentType: "Contract"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+			rule(2, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Contract"),
+		}, true, false},
+		{"unknown criterion", HireProfile{}, []entity.TemplateTaskRule{
+			rule(1, "SALARY", entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+		}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			profile.lookups = true
+			profile.structureChildren = map[string]map[string]bool{}
+
+			got, err := ruleService.MatchRules(&profile, tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MatchRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateTaskRuleServiceMatchRulesFromRequest(t *testing.T) {
+	ruleService := NewTemplateTaskRuleService(
+		logrus.New(),
+		&unreachableEmployeeMessage{t: t},
+		&unreachableOrganizationMessage{t: t},
+		&unreachableJobPlafonMessage{t: t},
+	)
+
+	rule := func(groupNumber int, criterion entity.TemplateTaskRuleCriterionEnum, operator entity.TemplateTaskRuleOperatorEnum, value string) entity.TemplateTaskRule {
+		return entity.TemplateTaskRule{GroupNumber: groupNumber, Criterion: criterion, Operator: operator, Value: value}
+	}
+	jobLevel := 5.0
+
+	tests := []struct {
+		name        string
+		req         request.CreateEmployeeTasksForRecruitment
+		rules       []entity.TemplateTaskRule
+		want        bool
+		wantMissing bool
+	}{
+		{"job level from the payload", request.CreateEmployeeTasksForRecruitment{JobLevelID: "manager", JobLevel: &jobLevel}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL, "5"),
+		}, true, false},
+		{"job level not in the payload", request.CreateEmployeeTasksForRecruitment{JobLevelID: "manager"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL, "5"),
+		}, false, true},
+		{"parent structure from the payload", request.CreateEmployeeTasksForRecruitment{
+			OrganizationStructureID:        childStructureID,
+			OrganizationStructureParentIDs: []string{parentStructureID},
+		}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE, parentStructureID),
+		}, true, false},
+		{"top structure has no parents", request.CreateEmployeeTasksForRecruitment{
+			OrganizationStructureID:        otherStructureID,
+			OrganizationStructureParentIDs: []string{},
+		}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_IN_SUBTREE, parentStructureID),
+		}, false, false},
+		{"parent structures not in the payload", request.CreateEmployeeTasksForRecruitment{OrganizationStructureID: childStructureID}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_NOT_IN_SUBTREE, parentStructureID),
+		}, false, true},
+		{"attribute not in the payload", request.CreateEmployeeTasksForRecruitment{}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+		}, false, true},
+		{"other rule of the group fails", request.CreateEmployeeTasksForRecruitment{JobID: "b"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+		}, false, false},
+		{"other group matches", request.CreateEmployeeTasksForRecruitment{JobID: "a"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+			rule(2, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+		}, true, false},
+		{"other group fails", request.CreateEmployeeTasksForRecruitment{JobID: "b"}, []entity.TemplateTaskRule{
+			rule(1, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "a"),
+			rule(2, entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, "Permanent"),
+		}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := ruleService.HireProfileFromRequest(&tt.req)
+			if err != nil {
+				t.Fatalf("HireProfileFromRequest() error = %v", err)
+			}
+
+			got, err := ruleService.MatchRules(profile, tt.rules)
+			var missingErr *MissingHireAttributeError
+			if errors.As(err, &missingErr) != tt.wantMissing {
+				t.Fatalf("MatchRules() error = %v, wantMissing %v", err, tt.wantMissing)
+			}
+			if !tt.wantMissing && err != nil {
+				t.Fatalf("MatchRules() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MatchRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateTaskRuleServiceValidateRules(t *testing.T) {
+	ruleService := NewTemplateTaskRuleService(logrus.New(), nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		rule    request.TemplateTaskRuleRequest
+		wantErr bool
+	}{
+		{"equals on any criterion", request.TemplateTaskRuleRequest{Criterion: "JOB", Operator: "EQUALS", Value: "a"}, false},
+		{"job level comparison", request.TemplateTaskRuleRequest{Criterion: "JOB_LEVEL", Operator: "GREATER_THAN_OR_EQUAL", Value: "3"}, false},
+		{"comparison on another criterion", request.TemplateTaskRuleRequest{Criterion: "JOB", Operator: "LESS_THAN_OR_EQUAL", Value: "3"}, true},
+		{"comparison with a text value", request.TemplateTaskRuleRequest{Criterion: "JOB_LEVEL", Operator: "LESS_THAN_OR_EQUAL", Value: "high"}, true},
+		{"subtree of a structure", request.TemplateTaskRuleRequest{Criterion: "ORGANIZATION_STRUCTURE", Operator: "IN_SUBTREE", Value: parentStructureID}, false},
+		{"subtree on another criterion", request.TemplateTaskRuleRequest{Criterion: "JOB", Operator: "NOT_IN_SUBTREE", Value: parentStructureID}, true},
+		{"subtree of a value that is not an id", request.TemplateTaskRuleRequest{Criterion: "ORGANIZATION_STRUCTURE", Operator: "IN_SUBTREE", Value: "head office"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ruleService.ValidateRules([]request.TemplateTaskRuleRequest{tt.rule}); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+type ITemplateTaskVersionService interface {
+	Snapshot(templateTask *entity.TemplateTask) entity.TemplateTaskSnapshot
+	CreateVersion(templateTask *entity.TemplateTask, propagation entity.TemplateTaskVersionPropagationEnum, changeNote string) (*entity.TemplateTaskVersion, error)
+	EnsureCurrentVersion(templateTask *entity.TemplateTask) (*entity.TemplateTaskVersion, error)
+	DecodeSnapshot(version *entity.TemplateTaskVersion) (*entity.TemplateTaskSnapshot, error)
+	DiffVersions(from, to *entity.TemplateTaskVersion) (*response.TemplateTaskVersionDiffResponse, error)
+}
+
+type TemplateTaskVersionService struct {
+	Log        *logrus.Logger
+	Repository repository.ITemplateTaskVersionRepository
+}
+
+func NewTemplateTaskVersionService(
+	log *logrus.Logger,
+	repo repository.ITemplateTaskVersionRepository,
+) ITemplateTaskVersionService {
+	return &TemplateTaskVersionService{
+		Log:        log,
+		Repository: repo,
+	}
+}
+
+func TemplateTaskVersionServiceFactory(log *logrus.Logger) ITemplateTaskVersionService {
+	repo := repository.TemplateTaskVersionRepositoryFactory(log)
+	return NewTemplateTaskVersionService(log, repo)
+}
+
+// Snapshot copies the versioned content of a template task. Checklists, attachments and
+// rules are sorted so that the same content always encodes the same way.
+func (s *TemplateTaskVersionService) Snapshot(templateTask *entity.TemplateTask) entity.TemplateTaskSnapshot {
+	snapshot := entity.TemplateTaskSnapshot{
+		Name:             templateTask.Name,
+		Description:      templateTask.Description,
+		Priority:         string(templateTask.Priority),
+		DueDuration:      templateTask.DueDuration,
+		Status:           string(templateTask.Status),
+		CoverPath:        templateTask.CoverPath,
+		SurveyTemplateID: templateTask.SurveyTemplateID,
+		OrganizationType: templateTask.OrganizationType,
+		Kind:             string(templateTask.Kind),
+		PassingScore:     templateTask.PassingScore,
+		Checklists:       make([]string, 0, len(templateTask.TemplateTaskChecklists)),
+		Attachments:      make([]string, 0, len(templateTask.TemplateTaskAttachments)),
+		Rules:            make([]entity.TemplateTaskSnapshotRule, 0, len(templateTask.TemplateTaskRules)),
+	}
+
+	for _, checklist := range templateTask.TemplateTaskChecklists {
+		snapshot.Checklists = append(snapshot.Checklists, checklist.Name)
+	}
+	sort.Strings(snapshot.Checklists)
+
+	snapshot.ChecklistSettings = ChecklistSettings(templateTask.TemplateTaskChecklists)
+
+	for _, attachment := range templateTask.TemplateTaskAttachments {
+		snapshot.Attachments = append(snapshot.Attachments, attachment.Path)
+	}
+	sort.Strings(snapshot.Attachments)
+
+	for _, rule := range templateTask.TemplateTaskRules {
+		snapshot.Rules = append(snapshot.Rules, entity.TemplateTaskSnapshotRule{
+			GroupNumber: rule.GroupNumber,
+			Criterion:   string(rule.Criterion),
+			Operator:    string(rule.Operator),
+			Value:       rule.Value,
+		})
+	}
+	sort.Slice(snapshot.Rules, func(i, j int) bool {
+		return snapshotRuleKey(snapshot.Rules[i]) < snapshotRuleKey(snapshot.Rules[j])
+	})
+
+	for _, step := range templateTask.TemplateTaskApprovalSteps {
+		snapshot.ApprovalSteps = append(snapshot.ApprovalSteps, entity.TemplateTaskSnapshotApprovalStep{
+			StepOrder:          step.StepOrder,
+			ApproverType:       string(step.ApproverType),
+			ApproverEmployeeID: step.ApproverEmployeeID,
+		})
+	}
+	sort.Slice(snapshot.ApprovalSteps, func(i, j int) bool {
+		return snapshot.ApprovalSteps[i].StepOrder < snapshot.ApprovalSteps[j].StepOrder
+	})
+
+	return snapshot
+}
+
+// CreateVersion stores the current content of the template task as its next version.
+// When nothing changed since the latest version, that version is returned instead.
+func (s *TemplateTaskVersionService) CreateVersion(templateTask *entity.TemplateTask, propagation entity.TemplateTaskVersionPropagationEnum, changeNote string) (*entity.TemplateTaskVersion, error) {
+	encoded, err := json.Marshal(s.Snapshot(templateTask))
+	if err != nil {
+		s.Log.Error("[TemplateTaskVersionService.CreateVersion] error encoding snapshot: ", err)
+		return nil, err
+	}
+
+	latest, err := s.Repository.FindLatestByTemplateTaskID(templateTask.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	versionNumber := 1
+	if latest != nil {
+		if latest.Snapshot == string(encoded) {
+			return latest, nil
+		}
+		versionNumber = latest.VersionNumber + 1
+	}
+
+	if propagation == "" {
+		propagation = entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY
+	}
+
+	return s.Repository.CreateTemplateTaskVersion(&entity.TemplateTaskVersion{
+		TemplateTaskID: templateTask.ID,
+		VersionNumber:  versionNumber,
+		Snapshot:       string(encoded),
+		Propagation:    propagation,
+		ChangeNote:     changeNote,
+	})
+}
+
+// EnsureCurrentVersion returns the version matching the template task as it is now, creating
+// one for templates that predate versioning or were changed without going through the API.
+func (s *TemplateTaskVersionService) EnsureCurrentVersion(templateTask *entity.TemplateTask) (*entity.TemplateTaskVersion, error) {
+	return s.CreateVersion(templateTask, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "")
+}
+
+func (s *TemplateTaskVersionService) DecodeSnapshot(version *entity.TemplateTaskVersion) (*entity.TemplateTaskSnapshot, error) {
+	var snapshot entity.TemplateTaskSnapshot
+	if err := json.Unmarshal([]byte(version.Snapshot), &snapshot); err != nil {
+		s.Log.Error("[TemplateTaskVersionService.DecodeSnapshot] error decoding snapshot: ", err)
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+func (s *TemplateTaskVersionService) DiffVersions(from, to *entity.TemplateTaskVersion) (*response.TemplateTaskVersionDiffResponse, error) {
+	if from.TemplateTaskID != to.TemplateTaskID {
+		return nil, errors.New("versions belong to different template tasks")
+	}
+
+	fromSnapshot, err := s.DecodeSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := s.DecodeSnapshot(to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.TemplateTaskVersionDiffResponse{
+		TemplateTaskID: to.TemplateTaskID,
+		FromVersion:    from.VersionNumber,
+		ToVersion:      to.VersionNumber,
+		Fields:         make([]response.TemplateTaskVersionFieldChangeResponse, 0),
+	}
+
+	fields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{"name", fromSnapshot.Name, toSnapshot.Name},
+		{"description", fromSnapshot.Description, toSnapshot.Description},
+		{"priority", fromSnapshot.Priority, toSnapshot.Priority},
+		{"due_duration", snapshotIntValue(fromSnapshot.DueDuration), snapshotIntValue(toSnapshot.DueDuration)},
+		{"status", fromSnapshot.Status, toSnapshot.Status},
+		{"cover_path", snapshotStringValue(fromSnapshot.CoverPath), snapshotStringValue(toSnapshot.CoverPath)},
+		{"organization_type", fromSnapshot.OrganizationType, toSnapshot.OrganizationType},
+		{"kind", fromSnapshot.Kind, toSnapshot.Kind},
+		{"passing_score", snapshotIntValue(fromSnapshot.PassingScore), snapshotIntValue(toSnapshot.PassingScore)},
+	}
+	var fromSurveyTemplateID, toSurveyTemplateID string
+	if fromSnapshot.SurveyTemplateID != nil {
+		fromSurveyTemplateID = fromSnapshot.SurveyTemplateID.String()
+	}
+	if toSnapshot.SurveyTemplateID != nil {
+		toSurveyTemplateID = toSnapshot.SurveyTemplateID.String()
+	}
+	fields = append(fields, struct {
+		name     string
+		oldValue string
+		newValue string
+	}{"survey_template_id", fromSurveyTemplateID, toSurveyTemplateID}, struct {
+		name     string
+		oldValue string
+		newValue string
+	}{"approval_steps", snapshotApprovalStepsValue(fromSnapshot.ApprovalSteps), snapshotApprovalStepsValue(toSnapshot.ApprovalSteps)}, struct {
+		name     string
+		oldValue string
+		newValue string
+	}{"checklist_settings", snapshotChecklistSettingsValue(fromSnapshot.ChecklistSettings), snapshotChecklistSettingsValue(toSnapshot.ChecklistSettings)})
+
+	for _, field := range fields {
+		if field.oldValue != field.newValue {
+			res.Fields = append(res.Fields, response.TemplateTaskVersionFieldChangeResponse{
+				Field:    field.name,
+				OldValue: field.oldValue,
+				NewValue: field.newValue,
+			})
+		}
+	}
+
+	res.AddedChecklists, res.RemovedChecklists = diffStrings(fromSnapshot.Checklists, toSnapshot.Checklists)
+	res.AddedAttachments, res.RemovedAttachments = diffStrings(fromSnapshot.Attachments, toSnapshot.Attachments)
+
+	fromRules := make(map[string]bool)
+	for _, rule := range fromSnapshot.Rules {
+		fromRules[snapshotRuleKey(rule)] = true
+	}
+	toRules := make(map[string]bool)
+	for _, rule := range toSnapshot.Rules {
+		toRules[snapshotRuleKey(rule)] = true
+	}
+	res.AddedRules = make([]entity.TemplateTaskSnapshotRule, 0)
+	for _, rule := range toSnapshot.Rules {
+		if !fromRules[snapshotRuleKey(rule)] {
+			res.AddedRules = append(res.AddedRules, rule)
+		}
+	}
+	res.RemovedRules = make([]entity.TemplateTaskSnapshotRule, 0)
+	for _, rule := range fromSnapshot.Rules {
+		if !toRules[snapshotRuleKey(rule)] {
+			res.RemovedRules = append(res.RemovedRules, rule)
+		}
+	}
+
+	return res, nil
+}
+
+func snapshotRuleKey(rule entity.TemplateTaskSnapshotRule) string {
+	return strconv.Itoa(rule.GroupNumber) + "|" + rule.Criterion + "|" + rule.Operator + "|" + rule.Value
+}
+
+// snapshotApprovalStepsValue writes the approval chain as "1:DIRECT_MANAGER, 2:HR".
+func snapshotApprovalStepsValue(steps []entity.TemplateTaskSnapshotApprovalStep) string {
+	values := make([]string, 0, len(steps))
+	for _, step := range steps {
+		value := strconv.Itoa(step.StepOrder) + ":" + step.ApproverType
+		if step.ApproverEmployeeID != nil {
+			value += "(" + step.ApproverEmployeeID.String() + ")"
+		}
+		values = append(values, value)
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// ChecklistSettings lists the checklist items whose settings differ from the defaults, ordered
+// by sort order and name.
+func ChecklistSettings(checklists []entity.TemplateTaskChecklist) []entity.TemplateTaskSnapshotChecklistSetting {
+	var settings []entity.TemplateTaskSnapshotChecklistSetting
+	for _, checklist := range checklists {
+		if checklist.SortOrder == 0 && checklist.AssigneeID == nil && checklist.DueOffsetDays == nil && checklist.IsRequired != "YES" {
+			continue
+		}
+		settings = append(settings, entity.TemplateTaskSnapshotChecklistSetting{
+			Name:          checklist.Name,
+			SortOrder:     checklist.SortOrder,
+			AssigneeID:    checklist.AssigneeID,
+			DueOffsetDays: checklist.DueOffsetDays,
+			IsRequired:    checklist.IsRequired,
+		})
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		if settings[i].SortOrder != settings[j].SortOrder {
+			return settings[i].SortOrder < settings[j].SortOrder
+		}
+		return settings[i].Name < settings[j].Name
+	})
+
+	return settings
+}
+
+// snapshotChecklistSettingsValue writes checklist settings as "1:Laptop(required, +3d, <assignee id>)".
+func snapshotChecklistSettingsValue(settings []entity.TemplateTaskSnapshotChecklistSetting) string {
+	values := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		details := make([]string, 0, 3)
+		if setting.IsRequired == "YES" {
+			details = append(details, "required")
+		}
+		if setting.DueOffsetDays != nil {
+			details = append(details, "+"+strconv.Itoa(*setting.DueOffsetDays)+"d")
+		}
+		if setting.AssigneeID != nil {
+			details = append(details, setting.AssigneeID.String())
+		}
+		value := strconv.Itoa(setting.SortOrder) + ":" + setting.Name
+		if len(details) > 0 {
+			value += "(" + strings.Join(details, ", ") + ")"
+		}
+		values = append(values, value)
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func snapshotIntValue(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func snapshotStringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// diffStrings returns the values only present in to and the values only present in from.
+func diffStrings(from, to []string) ([]string, []string) {
+	fromSet := make(map[string]bool)
+	for _, value := range from {
+		fromSet[value] = true
+	}
+	toSet := make(map[string]bool)
+	for _, value := range to {
+		toSet[value] = true
+	}
+
+	added := make([]string, 0)
+	for _, value := range to {
+		if !fromSet[value] {
+			added = append(added, value)
+		}
+	}
+	removed := make([]string, 0)
+	for _, value := range from {
+		if !toSet[value] {
+			removed = append(removed, value)
+		}
+	}
+
+	return added, removed
+}
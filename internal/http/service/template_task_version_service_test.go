@@ -0,0 +1,230 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeTemplateTaskVersionRepository struct {
+	repository.ITemplateTaskVersionRepository
+	versions []entity.TemplateTaskVersion
+}
+
+func (r *fakeTemplateTaskVersionRepository) CreateTemplateTaskVersion(ent *entity.TemplateTaskVersion) (*entity.TemplateTaskVersion, error) {
+	r.versions = append(r.versions, *ent)
+	return ent, nil
+}
+
+func (r *fakeTemplateTaskVersionRepository) FindLatestByTemplateTaskID(templateTaskID uuid.UUID) (*entity.TemplateTaskVersion, error) {
+	var latest *entity.TemplateTaskVersion
+	for i := range r.versions {
+		if r.versions[i].TemplateTaskID == templateTaskID && (latest == nil || r.versions[i].VersionNumber > latest.VersionNumber) {
+			latest = &r.versions[i]
+		}
+	}
+	return latest, nil
+}
+
+// sameStrings compares slices without telling nil and empty apart.
+func sameStrings(a, b []string) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+func newVersionedTemplateTask() *entity.TemplateTask {
+	dueDuration := 5
+	return &entity.TemplateTask{
+		ID:          uuid.New(),
+		Name:        "Prepare the workstation",
+		Priority:    entity.TEMPLATE_TASK_PRIORITY_ENUM_MEDIUM,
+		DueDuration: &dueDuration,
+		TemplateTaskChecklists: []entity.TemplateTaskChecklist{
+			{Name: "Monitor"},
+			{Name: "Laptop", SortOrder: 1, IsRequired: "YES"},
+		},
+		TemplateTaskAttachments: []entity.TemplateTaskAttachment{
+			{Path: "storage/b.pdf"},
+			{Path: "storage/a.pdf"},
+		},
+		TemplateTaskRules: []entity.TemplateTaskRule{
+			{GroupNumber: 2, Criterion: entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, Operator: entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, Value: "a"},
+			{GroupNumber: 1, Criterion: entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, Operator: entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, Value: "Permanent"},
+		},
+		TemplateTaskApprovalSteps: []entity.TemplateTaskApprovalStep{
+			{StepOrder: 2, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR},
+			{StepOrder: 1, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_DIRECT_MANAGER},
+		},
+	}
+}
+
+func TestTemplateTaskVersionServiceSnapshot(t *testing.T) {
+	versionService := NewTemplateTaskVersionService(logrus.New(), nil)
+
+	templateTask := newVersionedTemplateTask()
+	snapshot := versionService.Snapshot(templateTask)
+
+	reordered := newVersionedTemplateTask()
+	reordered.TemplateTaskChecklists[0], reordered.TemplateTaskChecklists[1] = reordered.TemplateTaskChecklists[1], reordered.TemplateTaskChecklists[0]
+	reordered.TemplateTaskAttachments[0], reordered.TemplateTaskAttachments[1] = reordered.TemplateTaskAttachments[1], reordered.TemplateTaskAttachments[0]
+	reordered.TemplateTaskRules[0], reordered.TemplateTaskRules[1] = reordered.TemplateTaskRules[1], reordered.TemplateTaskRules[0]
+	reordered.TemplateTaskApprovalSteps[0], reordered.TemplateTaskApprovalSteps[1] = reordered.TemplateTaskApprovalSteps[1], reordered.TemplateTaskApprovalSteps[0]
+
+	encoded, _ := json.Marshal(snapshot)
+	reorderedEncoded, _ := json.Marshal(versionService.Snapshot(reordered))
+	if string(encoded) != string(reorderedEncoded) {
+		t.Errorf("Snapshot() = %s, want %s for the same content in another order", reorderedEncoded, encoded)
+	}
+
+	if !reflect.DeepEqual(snapshot.Checklists, []string{"Laptop", "Monitor"}) {
+		t.Errorf("Snapshot() checklists = %v, want sorted names", snapshot.Checklists)
+	}
+	if len(snapshot.ChecklistSettings) != 1 || snapshot.ChecklistSettings[0].Name != "Laptop" {
+		t.Errorf("Snapshot() checklist settings = %+v, want only the checklist with settings", snapshot.ChecklistSettings)
+	}
+	if snapshot.ApprovalSteps[0].StepOrder != 1 {
+		t.Errorf("Snapshot() approval steps = %+v, want them by step order", snapshot.ApprovalSteps)
+	}
+}
+
+func TestTemplateTaskVersionServiceDiffVersions(t *testing.T) {
+	versionService := NewTemplateTaskVersionService(logrus.New(), nil)
+
+	tests := []struct {
+		name              string
+		change            func(templateTask *entity.TemplateTask)
+		wantFields        []string
+		wantAddedChecks   []string
+		wantRemovedChecks []string
+		wantAddedRules    int
+		wantRemovedRules  int
+	}{
+		{
+			name:   "same content",
+			change: func(templateTask *entity.TemplateTask) {},
+		},
+		{
+			name: "name and due duration",
+			change: func(templateTask *entity.TemplateTask) {
+				templateTask.Name = "Set up the workstation"
+				templateTask.DueDuration = nil
+			},
+			wantFields: []string{"name", "due_duration"},
+		},
+		{
+			name: "checklist renamed",
+			change: func(templateTask *entity.TemplateTask) {
+				templateTask.TemplateTaskChecklists[0].Name = "Keyboard"
+			},
+			wantAddedChecks:   []string{"Keyboard"},
+			wantRemovedChecks: []string{"Monitor"},
+		},
+		{
+			name: "checklist settings",
+			change: func(templateTask *entity.TemplateTask) {
+				templateTask.TemplateTaskChecklists[1].IsRequired = "NO"
+			},
+			wantFields: []string{"checklist_settings"},
+		},
+		{
+			name: "approval chain",
+			change: func(templateTask *entity.TemplateTask) {
+				templateTask.TemplateTaskApprovalSteps = templateTask.TemplateTaskApprovalSteps[:1]
+			},
+			wantFields: []string{"approval_steps"},
+		},
+		{
+			name: "rule value",
+			change: func(templateTask *entity.TemplateTask) {
+				templateTask.TemplateTaskRules[0].Value = "b"
+			},
+			wantAddedRules:   1,
+			wantRemovedRules: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateTask := newVersionedTemplateTask()
+			fromSnapshot, _ := json.Marshal(versionService.Snapshot(templateTask))
+			tt.change(templateTask)
+			toSnapshot, _ := json.Marshal(versionService.Snapshot(templateTask))
+
+			diff, err := versionService.DiffVersions(
+				&entity.TemplateTaskVersion{TemplateTaskID: templateTask.ID, VersionNumber: 1, Snapshot: string(fromSnapshot)},
+				&entity.TemplateTaskVersion{TemplateTaskID: templateTask.ID, VersionNumber: 2, Snapshot: string(toSnapshot)},
+			)
+			if err != nil {
+				t.Fatalf("DiffVersions() error = %v", err)
+			}
+
+			fields := make([]string, 0, len(diff.Fields))
+			for _, field := range diff.Fields {
+				fields = append(fields, field.Field)
+			}
+			if !sameStrings(fields, tt.wantFields) {
+				t.Errorf("DiffVersions() fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !sameStrings(diff.AddedChecklists, tt.wantAddedChecks) {
+				t.Errorf("DiffVersions() added checklists = %v, want %v", diff.AddedChecklists, tt.wantAddedChecks)
+			}
+			if !sameStrings(diff.RemovedChecklists, tt.wantRemovedChecks) {
+				t.Errorf("DiffVersions() removed checklists = %v, want %v", diff.RemovedChecklists, tt.wantRemovedChecks)
+			}
+			if len(diff.AddedRules) != tt.wantAddedRules || len(diff.RemovedRules) != tt.wantRemovedRules {
+				t.Errorf("DiffVersions() rules = +%d -%d, want +%d -%d", len(diff.AddedRules), len(diff.RemovedRules), tt.wantAddedRules, tt.wantRemovedRules)
+			}
+		})
+	}
+}
+
+func TestTemplateTaskVersionServiceDiffVersionsOfOtherTasks(t *testing.T) {
+	versionService := NewTemplateTaskVersionService(logrus.New(), nil)
+
+	_, err := versionService.DiffVersions(
+		&entity.TemplateTaskVersion{TemplateTaskID: uuid.New(), Snapshot: "{}"},
+		&entity.TemplateTaskVersion{TemplateTaskID: uuid.New(), Snapshot: "{}"},
+	)
+	if err == nil {
+		t.Error("DiffVersions() error = nil, want an error for versions of different template tasks")
+	}
+}
+
+func TestTemplateTaskVersionServiceCreateVersion(t *testing.T) {
+	versionRepository := &fakeTemplateTaskVersionRepository{}
+	versionService := NewTemplateTaskVersionService(logrus.New(), versionRepository)
+	templateTask := newVersionedTemplateTask()
+
+	tests := []struct {
+		name        string
+		change      func(templateTask *entity.TemplateTask)
+		wantVersion int
+	}{
+		{"first version", func(templateTask *entity.TemplateTask) {}, 1},
+		{"unchanged content keeps the version", func(templateTask *entity.TemplateTask) {}, 1},
+		{"changed content", func(templateTask *entity.TemplateTask) { templateTask.Description = "Desk and laptop" }, 2},
+		{"reordered content keeps the version", func(templateTask *entity.TemplateTask) {
+			templateTask.TemplateTaskAttachments[0], templateTask.TemplateTaskAttachments[1] = templateTask.TemplateTaskAttachments[1], templateTask.TemplateTaskAttachments[0]
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change(templateTask)
+			version, err := versionService.EnsureCurrentVersion(templateTask)
+			if err != nil {
+				t.Fatalf("EnsureCurrentVersion() error = %v", err)
+			}
+			if version.VersionNumber != tt.wantVersion {
+				t.Errorf("EnsureCurrentVersion() version = %d, want %d", version.VersionNumber, tt.wantVersion)
+			}
+			if len(versionRepository.versions) != tt.wantVersion {
+				t.Errorf("EnsureCurrentVersion() stored %d versions, want %d", len(versionRepository.versions), tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type IVerifierDelegationService interface {
+	ResolveVerifier(verifierID uuid.UUID, on time.Time) (uuid.UUID, *entity.VerifierDelegation, error)
+}
+
+type VerifierDelegationService struct {
+	Log        *logrus.Logger
+	Repository repository.IVerifierDelegationRepository
+}
+
+func NewVerifierDelegationService(
+	log *logrus.Logger,
+	repo repository.IVerifierDelegationRepository,
+) IVerifierDelegationService {
+	return &VerifierDelegationService{
+		Log:        log,
+		Repository: repo,
+	}
+}
+
+func VerifierDelegationServiceFactory(log *logrus.Logger) IVerifierDelegationService {
+	repo := repository.VerifierDelegationRepositoryFactory(log)
+	return NewVerifierDelegationService(log, repo)
+}
+
+// ResolveVerifier returns who verifies on behalf of the verifier on the given day, with the
+// delegation that applies. Delegations are not followed further, the delegate of a delegate is
+// not used.
+func (s *VerifierDelegationService) ResolveVerifier(verifierID uuid.UUID, on time.Time) (uuid.UUID, *entity.VerifierDelegation, error) {
+	verifierDelegation, err := s.Repository.FindActiveByDelegatorID(verifierID, on)
+	if err != nil {
+		s.Log.Error("[VerifierDelegationService.ResolveVerifier] error finding active verifier delegation: ", err)
+		return uuid.Nil, nil, err
+	}
+	if verifierDelegation == nil {
+		return verifierID, nil, nil
+	}
+
+	return verifierDelegation.DelegateID, verifierDelegation, nil
+}
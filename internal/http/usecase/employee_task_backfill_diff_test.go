@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+// calendarDueDate counts calendar days, the tests only need the diff to use the function it is given.
+func calendarDueDate(startDate time.Time, dueDuration *int) (time.Time, error) {
+	if dueDuration == nil {
+		return startDate, nil
+	}
+	return startDate.AddDate(0, 0, *dueDuration), nil
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func newMatchingTemplateAndTask() (entity.TemplateTask, *entity.EmployeeTask, []entity.EmployeeTaskApproval) {
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	surveyTemplateID := uuid.New()
+	approverID := uuid.New()
+
+	templateTask := entity.TemplateTask{
+		ID:               uuid.New(),
+		Name:             "Sign the contract",
+		Description:      "Sign and upload the contract",
+		Priority:         entity.TEMPLATE_TASK_PRIORITY_ENUM_HIGH,
+		DueDuration:      intPointer(5),
+		Kind:             entity.TASK_KIND_ENUM_QUIZ,
+		PassingScore:     intPointer(70),
+		SurveyTemplateID: &surveyTemplateID,
+		SurveyTemplate:   &entity.SurveyTemplate{ID: surveyTemplateID, SurveyNumber: "SRV-1", VersionNumber: 1},
+		TemplateTaskAttachments: []entity.TemplateTaskAttachment{
+			{Path: "storage/contract.pdf"},
+		},
+		TemplateTaskChecklists: []entity.TemplateTaskChecklist{
+			{Name: "Read", SortOrder: 1, DueOffsetDays: intPointer(2), IsRequired: "YES"},
+		},
+		TemplateTaskApprovalSteps: []entity.TemplateTaskApprovalStep{
+			{StepOrder: 1, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR},
+			{StepOrder: 2, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE, ApproverEmployeeID: &approverID},
+		},
+	}
+
+	checklistDueDate := startDate.AddDate(0, 0, 2)
+	employeeTask := &entity.EmployeeTask{
+		ID:               uuid.New(),
+		Name:             templateTask.Name,
+		Description:      templateTask.Description,
+		Priority:         entity.EMPLOYEE_TASK_PRIORITY_ENUM_HIGH,
+		StartDate:        startDate,
+		EndDate:          startDate.AddDate(0, 0, 5),
+		Kind:             entity.TASK_KIND_ENUM_QUIZ,
+		PassingScore:     intPointer(70),
+		SurveyTemplateID: &surveyTemplateID,
+		SurveyTemplate:   templateTask.SurveyTemplate,
+		EmployeeTaskAttachments: []entity.EmployeeTaskAttachment{
+			{Path: "storage/contract.pdf"},
+		},
+		EmployeeTaskChecklists: []entity.EmployeeTaskChecklist{
+			{Name: "Read", SortOrder: 1, DueDate: &checklistDueDate, IsRequired: "YES"},
+		},
+	}
+
+	approvals := []entity.EmployeeTaskApproval{
+		{Round: 1, StepOrder: 1, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_HR},
+		{Round: 1, StepOrder: 2, ApproverType: entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE, ApproverID: &approverID},
+	}
+
+	return templateTask, employeeTask, approvals
+}
+
+func TestDiffEmployeeTaskWithTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask)
+		wantType   entity.OnboardingBackfillChangeTypeEnum
+		wantFields []string
+	}{
+		{
+			name:   "matching task",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {},
+		},
+		{
+			name: "due duration",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.DueDuration = intPointer(10)
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+			wantFields: []string{"end_date"},
+		},
+		{
+			name: "kind and passing score",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.Kind = entity.TASK_KIND_ENUM_SURVEY
+				templateTask.PassingScore = nil
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+			wantFields: []string{"kind", "passing_score"},
+		},
+		{
+			name: "new attachment",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.TemplateTaskAttachments = append(templateTask.TemplateTaskAttachments, entity.TemplateTaskAttachment{Path: "storage/handbook.pdf"})
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+			wantFields: []string{"attachments"},
+		},
+		{
+			name: "attachment added to the employee task only",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				employeeTask.EmployeeTaskAttachments = append(employeeTask.EmployeeTaskAttachments, entity.EmployeeTaskAttachment{Path: "storage/signed.pdf"})
+			},
+		},
+		{
+			name: "other survey",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				surveyTemplateID := uuid.New()
+				templateTask.SurveyTemplateID = &surveyTemplateID
+				templateTask.SurveyTemplate = &entity.SurveyTemplate{ID: surveyTemplateID, SurveyNumber: "SRV-2", VersionNumber: 1}
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+			wantFields: []string{"survey_template_id"},
+		},
+		{
+			name: "newer version of the same survey",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				surveyTemplateID := uuid.New()
+				templateTask.SurveyTemplateID = &surveyTemplateID
+				templateTask.SurveyTemplate = &entity.SurveyTemplate{ID: surveyTemplateID, SurveyNumber: "SRV-1", VersionNumber: 2}
+			},
+		},
+		{
+			name: "approval steps",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.TemplateTaskApprovalSteps = templateTask.TemplateTaskApprovalSteps[:1]
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+			wantFields: []string{"approval_steps"},
+		},
+		{
+			name: "checklist settings",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.TemplateTaskChecklists[0].IsRequired = "NO"
+				templateTask.TemplateTaskChecklists[0].DueOffsetDays = intPointer(3)
+			},
+			wantType:   entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_CHECKLIST,
+			wantFields: []string{"is_required", "due_date"},
+		},
+		{
+			name: "new checklist",
+			change: func(templateTask *entity.TemplateTask, employeeTask *entity.EmployeeTask) {
+				templateTask.TemplateTaskChecklists = append(templateTask.TemplateTaskChecklists, entity.TemplateTaskChecklist{Name: "Sign"})
+			},
+			wantType: entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_CHECKLIST,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateTask, employeeTask, approvals := newMatchingTemplateAndTask()
+			tt.change(&templateTask, employeeTask)
+
+			changes, err := diffEmployeeTaskWithTemplate(employeeTask, approvals, templateTask, calendarDueDate)
+			if err != nil {
+				t.Fatalf("diffEmployeeTaskWithTemplate() error = %v", err)
+			}
+			if tt.wantType == "" {
+				if len(changes) != 0 {
+					t.Fatalf("diffEmployeeTaskWithTemplate() = %+v, want no changes", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("diffEmployeeTaskWithTemplate() returned %d changes, want 1", len(changes))
+			}
+			if changes[0].ChangeType != tt.wantType {
+				t.Errorf("change type = %s, want %s", changes[0].ChangeType, tt.wantType)
+			}
+			if len(changes[0].Fields) != len(tt.wantFields) {
+				t.Fatalf("fields = %+v, want %v", changes[0].Fields, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if changes[0].Fields[i].Field != field {
+					t.Errorf("field %d = %s, want %s", i, changes[0].Fields[i].Field, field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type fakeDeletedEmployeeTaskRepository struct {
+	repository.IEmployeeTaskRepository
+	employeeTasks map[uuid.UUID]*entity.EmployeeTask
+	deleted       map[uuid.UUID]bool
+}
+
+func (r *fakeDeletedEmployeeTaskRepository) FindByID(id uuid.UUID) (*entity.EmployeeTask, error) {
+	return r.employeeTasks[id], nil
+}
+
+func (r *fakeDeletedEmployeeTaskRepository) DeleteEmployeeTask(ent *entity.EmployeeTask) error {
+	r.deleted[ent.ID] = true
+	return nil
+}
+
+type fakeMidsuitService struct {
+	service.IMidsuitService
+	failing     map[int]bool
+	deactivated []int
+	auths       int
+}
+
+func (s *fakeMidsuitService) AuthOneStep() (*service.AuthOneStepResponse, error) {
+	s.auths++
+	return &service.AuthOneStepResponse{Token: "token"}, nil
+}
+
+func (s *fakeMidsuitService) SyncEmployeeTaskActiveMidsuit(midsuitID int, isActive bool, jwtToken string) error {
+	if s.failing[midsuitID] {
+		return errors.New("midsuit is down")
+	}
+	if !isActive {
+		s.deactivated = append(s.deactivated, midsuitID)
+	}
+	return nil
+}
+
+func TestBulkDeleteEmployeeTasksSyncsMidsuit(t *testing.T) {
+	syncedID := uuid.New()
+	failingID := uuid.New()
+	localID := uuid.New()
+	syncedMidsuitID := "101"
+	failingMidsuitID := "102"
+
+	tests := []struct {
+		name           string
+		sync           string
+		wantDeleted    []uuid.UUID
+		wantFailed     []uuid.UUID
+		wantDeactivate int
+	}{
+		{"midsuit sync active", "ACTIVE", []uuid.UUID{syncedID, localID}, []uuid.UUID{failingID}, 1},
+		{"midsuit sync inactive", "INACTIVE", []uuid.UUID{syncedID, failingID, localID}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employeeTaskRepository := &fakeDeletedEmployeeTaskRepository{
+				employeeTasks: map[uuid.UUID]*entity.EmployeeTask{
+					syncedID:  {ID: syncedID, MidsuitID: &syncedMidsuitID},
+					failingID: {ID: failingID, MidsuitID: &failingMidsuitID},
+					localID:   {ID: localID},
+				},
+				deleted: make(map[uuid.UUID]bool),
+			}
+			midsuitService := &fakeMidsuitService{failing: map[int]bool{102: true}}
+			config := viper.New()
+			config.Set("midsuit.sync", tt.sync)
+			uc := &EmployeeTaskUseCase{
+				Log:            logrus.New(),
+				Viper:          config,
+				Repository:     employeeTaskRepository,
+				MidsuitService: midsuitService,
+			}
+
+			res, err := uc.BulkDeleteEmployeeTasks(&request.BulkDeleteEmployeeTaskRequest{
+				IDs: []string{syncedID.String(), failingID.String(), localID.String()},
+			})
+			if err != nil {
+				t.Fatalf("BulkDeleteEmployeeTasks() error = %v", err)
+			}
+
+			for _, id := range tt.wantDeleted {
+				if !employeeTaskRepository.deleted[id] {
+					t.Errorf("BulkDeleteEmployeeTasks() kept %s, want it deleted", id)
+				}
+			}
+			for _, id := range tt.wantFailed {
+				if employeeTaskRepository.deleted[id] {
+					t.Errorf("BulkDeleteEmployeeTasks() deleted %s, want it kept after the midsuit failure", id)
+				}
+			}
+			if res.Failed != len(tt.wantFailed) {
+				t.Errorf("BulkDeleteEmployeeTasks() failed = %d, want %d", res.Failed, len(tt.wantFailed))
+			}
+			if len(midsuitService.deactivated) != tt.wantDeactivate {
+				t.Errorf("BulkDeleteEmployeeTasks() deactivated %v in midsuit, want %d tasks", midsuitService.deactivated, tt.wantDeactivate)
+			}
+			if midsuitService.auths > 1 {
+				t.Errorf("BulkDeleteEmployeeTasks() authenticated %d times, want at most once", midsuitService.auths)
+			}
+		})
+	}
+}
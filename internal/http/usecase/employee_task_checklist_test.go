@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/google/uuid"
+)
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func TestEnsureRequiredChecklistsChecked(t *testing.T) {
+	requiredID := uuid.New()
+	optionalID := uuid.New()
+	employeeTask := &entity.EmployeeTask{
+		EmployeeTaskChecklists: []entity.EmployeeTaskChecklist{
+			{ID: requiredID, Name: "Sign the contract", IsRequired: "YES"},
+			{ID: optionalID, Name: "Meet the team", IsRequired: "NO"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		checklists []request.EmployeeTaskChecklistRequest
+		wantErr    bool
+	}{
+		{"required item checked", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(requiredID.String()), Name: "Sign the contract", IsChecked: stringPointer("YES")},
+			{ID: stringPointer(optionalID.String()), Name: "Meet the team"},
+		}, false},
+		{"required item unchecked", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(requiredID.String()), Name: "Sign the contract", IsChecked: stringPointer("NO")},
+		}, true},
+		{"required item left out", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(optionalID.String()), Name: "Meet the team", IsChecked: stringPointer("YES")},
+		}, true},
+		{"required flag cleared by the request", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(requiredID.String()), Name: "Sign the contract", IsRequired: stringPointer("NO")},
+		}, true},
+		{"stored item made required unchecked", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(requiredID.String()), Name: "Sign the contract", IsChecked: stringPointer("YES")},
+			{ID: stringPointer(optionalID.String()), Name: "Meet the team", IsRequired: stringPointer("YES")},
+		}, true},
+		{"new required item unchecked", []request.EmployeeTaskChecklistRequest{
+			{ID: stringPointer(requiredID.String()), Name: "Sign the contract", IsChecked: stringPointer("YES")},
+			{Name: "Collect the laptop", IsRequired: stringPointer("YES")},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ensureRequiredChecklistsChecked(employeeTask, tt.checklists)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ensureRequiredChecklistsChecked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type fakeTemplateTaskRepository struct {
+	repository.ITemplateTaskRepository
+	templateTasks []entity.TemplateTask
+}
+
+func (r *fakeTemplateTaskRepository) FindAllByKeysWithDetails(keys map[string]interface{}) (*[]entity.TemplateTask, error) {
+	return &r.templateTasks, nil
+}
+
+// fakeEmployeeTaskRepository has no employee tasks and fails the test when one is written.
+type fakeEmployeeTaskRepository struct {
+	repository.IEmployeeTaskRepository
+	t *testing.T
+}
+
+func (r *fakeEmployeeTaskRepository) FindByKeys(keys map[string]interface{}) (*entity.EmployeeTask, error) {
+	return nil, nil
+}
+
+func (r *fakeEmployeeTaskRepository) CreateEmployeeTask(ent *entity.EmployeeTask) (*entity.EmployeeTask, error) {
+	r.t.Fatalf("CreateEmployeeTask(%s) called, want nothing written", ent.Name)
+	return nil, nil
+}
+
+// unreachableEmployeeMessage fails the test when the hire is looked up over RabbitMQ.
+type unreachableEmployeeMessage struct {
+	messaging.IEmployeeMessage
+	t *testing.T
+}
+
+func (m *unreachableEmployeeMessage) SendFindEmployeeByIDMessage(req request.SendFindEmployeeByIDMessageRequest) (*response.EmployeeResponse, error) {
+	m.t.Fatalf("SendFindEmployeeByIDMessage(%s) called, want no lookup", req.ID)
+	return nil, nil
+}
+
+// unreachableJobPlafonMessage fails the test when a job level is looked up over RabbitMQ.
+type unreachableJobPlafonMessage struct {
+	messaging.IJobPlafonMessage
+	t *testing.T
+}
+
+func (m *unreachableJobPlafonMessage) SendFindJobLevelByIDMessage(req request.SendFindJobLevelByIDMessageRequest) (*response.SendFindJobLevelByIDMessageResponse, error) {
+	m.t.Fatalf("SendFindJobLevelByIDMessage(%s) called, want no lookup", req.ID)
+	return nil, nil
+}
+
+func TestEmployeeTasksForRecruitmentWithMissingHireAttributes(t *testing.T) {
+	log := logrus.New()
+	uc := &EmployeeTaskUseCase{
+		Log:        log,
+		Viper:      viper.New(),
+		Repository: &fakeEmployeeTaskRepository{t: t},
+		TemplateTaskRepository: &fakeTemplateTaskRepository{templateTasks: []entity.TemplateTask{
+			{ID: uuid.New(), Name: "Permanent staff induction", TemplateTaskRules: []entity.TemplateTaskRule{
+				{GroupNumber: 1, Criterion: entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE, Operator: entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, Value: "Permanent"},
+			}},
+			{ID: uuid.New(), Name: "Manager briefing", TemplateTaskRules: []entity.TemplateTaskRule{
+				{GroupNumber: 1, Criterion: entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL, Operator: entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_GREATER_THAN_OR_EQUAL, Value: "5"},
+			}},
+			{ID: uuid.New(), Name: "Sales kickoff", TemplateTaskRules: []entity.TemplateTaskRule{
+				{GroupNumber: 1, Criterion: entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB, Operator: entity.TEMPLATE_TASK_RULE_OPERATOR_ENUM_EQUALS, Value: "sales"},
+			}},
+		}},
+		TemplateTaskRuleService: service.NewTemplateTaskRuleService(log, &unreachableEmployeeMessage{t: t}, nil, &unreachableJobPlafonMessage{t: t}),
+	}
+
+	// the job level id is sent without the numeric level and the employment type is not sent
+	req := &request.CreateEmployeeTasksForRecruitment{
+		EmployeeID:       uuid.New().String(),
+		JoinedDate:       "2024-01-01",
+		OrganizationType: "Company",
+		JobID:            "finance",
+		JobLevelID:       uuid.New().String(),
+	}
+
+	preview, err := uc.PreviewEmployeeTasksForRecruitment(req)
+	if err != nil {
+		t.Fatalf("PreviewEmployeeTasksForRecruitment() error = %v", err)
+	}
+	if len(preview.Tasks) != 0 {
+		t.Errorf("PreviewEmployeeTasksForRecruitment() tasks = %+v, want none", preview.Tasks)
+	}
+	reasons := make(map[string]string, len(preview.SkippedTasks))
+	for _, skipped := range preview.SkippedTasks {
+		reasons[skipped.Name] = skipped.Reason
+	}
+	tests := []struct {
+		name       string
+		wantReason string
+	}{
+		{"Permanent staff induction", "hire has no EMPLOYMENT_TYPE"},
+		{"Manager briefing", "hire has no job level"},
+		{"Sales kickoff", "excluded by targeting rules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(reasons[tt.name], tt.wantReason) {
+				t.Errorf("PreviewEmployeeTasksForRecruitment() skipped reason = %q, want %q", reasons[tt.name], tt.wantReason)
+			}
+		})
+	}
+
+	err = uc.CreateEmployeeTasksForRecruitment(req)
+	if err == nil {
+		t.Fatal("CreateEmployeeTasksForRecruitment() error = nil, want the undecided template tasks")
+	}
+	if !strings.Contains(err.Error(), "Permanent staff induction") || !strings.Contains(err.Error(), "Manager briefing") || strings.Contains(err.Error(), "Sales kickoff") {
+		t.Errorf("CreateEmployeeTasksForRecruitment() error = %v, want only the undecided template tasks", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
@@ -29,15 +30,42 @@ type IEmployeeTaskUseCase interface {
 	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error)
 	FindAllPaginatedByEmployeeID(employeeID uuid.UUID, page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error)
 	FindByID(id uuid.UUID) (*response.EmployeeTaskResponse, error)
-	CountByKanbanAndEmployeeID(kanban entity.EmployeeTaskKanbanEnum, employeeID uuid.UUID) (int64, error)
-	FindAllByEmployeeID(employeeID uuid.UUID) (*response.EmployeeTaskKanbanResponse, error)
-	FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error)
+	CountByKanbanAndEmployeeID(kanban entity.EmployeeTaskKanbanEnum, employeeID uuid.UUID, source string) (int64, error)
+	FindAllByEmployeeID(employeeID uuid.UUID, source string) (*response.EmployeeTaskKanbanResponse, error)
+	FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, source string, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error)
 	UpdateEmployeeTaskOnly(req *request.UpdateEmployeeTaskOnlyRequest) (*response.EmployeeTaskResponse, error)
 	CreateEmployeeTasksForRecruitment(req *request.CreateEmployeeTasksForRecruitment) error
-	CountKanbanProgressByEmployeeID(employeeID uuid.UUID) (*response.EmployeeTaskProgressResponse, error)
-	FindByIDForResponse(id string) (*response.EmployeeTaskResponse, error)
+	PreviewEmployeeTasksForRecruitment(req *request.CreateEmployeeTasksForRecruitment) (*response.OnboardingPlanResponse, error)
+	CreateEmployeeTasksForOffboarding(req *request.CreateEmployeeTasksForOffboarding) error
+	PreviewEmployeeTasksForOffboarding(req *request.CreateEmployeeTasksForOffboarding) (*response.OnboardingPlanResponse, error)
+	CancelOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error)
+	PauseOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error)
+	ResumeOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error)
+	ReassignEmployeeTask(req *request.ReassignEmployeeTaskRequest) (*response.EmployeeTaskResponse, error)
+	FindAllHistoriesByEmployeeTaskID(id uuid.UUID) (*[]response.EmployeeTaskHistoryResponse, error)
+	ApproveEmployeeTask(req *request.ApproveEmployeeTaskRequest) (*response.EmployeeTaskApprovalChainResponse, error)
+	RejectEmployeeTask(req *request.RejectEmployeeTaskRequest) (*response.EmployeeTaskApprovalChainResponse, error)
+	FindApprovalsByEmployeeTaskID(id uuid.UUID) (*response.EmployeeTaskApprovalChainResponse, error)
+	EnsureEmployeeTaskCanBeCompleted(employeeTask *entity.EmployeeTask) error
+	UploadEmployeeTaskFile(req *request.UploadEmployeeTaskFileRequest) (*response.EmployeeTaskResponse, error)
+	ReviewEmployeeTaskFile(req *request.ReviewEmployeeTaskFileRequest) (*response.EmployeeTaskResponse, error)
+	AcknowledgeEmployeeTask(req *request.AcknowledgeEmployeeTaskRequest) (*response.EmployeeTaskResponse, error)
+	RecordEmployeeTaskQuizScore(req *request.RecordEmployeeTaskQuizScoreRequest) (*response.EmployeeTaskResponse, error)
+	CheckInEvent(req *request.CheckInEventRequest) (*response.EmployeeTaskResponse, error)
+	CountKanbanProgressByEmployeeID(employeeID uuid.UUID, source string) (*response.EmployeeTaskProgressResponse, error)
+	FindByIDForResponse(id string, viewerEmployeeID uuid.UUID) (*response.EmployeeTaskResponse, error)
 	FindAllPaginatedSurvey(page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error)
 	FindAllSurvey() (*[]response.EmployeeTaskResponse, error)
+	PreviewOnboardingBackfill(req *request.PreviewOnboardingBackfillRequest) (*response.OnboardingBackfillPreviewResponse, error)
+	ApplyOnboardingBackfill(req *request.ApplyOnboardingBackfillRequest) (*response.OnboardingBackfillResponse, error)
+	FindOnboardingBackfillByID(id uuid.UUID) (*response.OnboardingBackfillResponse, error)
+	FindAllOnboardingBackfillsPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.OnboardingBackfillResponse, int64, error)
+	PropagateTemplateTaskVersion(templateTaskID, templateTaskVersionID uuid.UUID) (*response.OnboardingBackfillResponse, error)
+	BulkAssignEmployeeTasks(req *request.BulkAssignEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error)
+	BulkMoveEmployeeTasks(req *request.BulkMoveEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error)
+	BulkVerifyEmployeeTasks(req *request.BulkVerifyEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error)
+	BulkReassignVerifier(req *request.BulkReassignVerifierRequest) (*response.BulkEmployeeTaskResponse, error)
+	BulkDeleteEmployeeTasks(req *request.BulkDeleteEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error)
 }
 
 type EmployeeTaskUseCase struct {
@@ -55,6 +83,21 @@ type EmployeeTaskUseCase struct {
 	OrganizationMessage              messaging.IOrganizationMessage
 	JobPlafonMessage                 messaging.IJobPlafonMessage
 	UserMessage                      messaging.IUserMessage
+	CalendarService                  service.ICalendarService
+	TemplateTaskRuleService          service.ITemplateTaskRuleService
+	OnboardingBackfillRepository     repository.IOnboardingBackfillRepository
+	OnboardingBackfillDTO            dto.IOnboardingBackfillDTO
+	TemplateTaskVersionService       service.ITemplateTaskVersionService
+	EmployeeOffboardingRepository    repository.IEmployeeOffboardingRepository
+	EventEmployeeRepository          repository.IEventEmployeeRepository
+	VerifierDelegationService        service.IVerifierDelegationService
+	EmployeeTaskHistoryRepository    repository.IEmployeeTaskHistoryRepository
+	EmployeeTaskApprovalRepository   repository.IEmployeeTaskApprovalRepository
+	EmployeeTaskApprovalService      service.IEmployeeTaskApprovalService
+	EmployeeTaskApprovalDTO          dto.IEmployeeTaskApprovalDTO
+	EmployeeTaskFileRepository       repository.IEmployeeTaskFileRepository
+	EmployeeTaskKindService          service.IEmployeeTaskKindService
+	EventRepository                  repository.IEventRepository
 }
 
 func NewEmployeeTaskUseCase(
@@ -72,6 +115,21 @@ func NewEmployeeTaskUseCase(
 	organizationMessage messaging.IOrganizationMessage,
 	jobPlafonMessage messaging.IJobPlafonMessage,
 	userMessage messaging.IUserMessage,
+	calendarService service.ICalendarService,
+	templateTaskRuleService service.ITemplateTaskRuleService,
+	obRepo repository.IOnboardingBackfillRepository,
+	obDTO dto.IOnboardingBackfillDTO,
+	templateTaskVersionService service.ITemplateTaskVersionService,
+	eoRepo repository.IEmployeeOffboardingRepository,
+	eeRepo repository.IEventEmployeeRepository,
+	verifierDelegationService service.IVerifierDelegationService,
+	ethRepo repository.IEmployeeTaskHistoryRepository,
+	etapRepo repository.IEmployeeTaskApprovalRepository,
+	employeeTaskApprovalService service.IEmployeeTaskApprovalService,
+	etapDTO dto.IEmployeeTaskApprovalDTO,
+	etfRepo repository.IEmployeeTaskFileRepository,
+	employeeTaskKindService service.IEmployeeTaskKindService,
+	eventRepo repository.IEventRepository,
 ) IEmployeeTaskUseCase {
 	return &EmployeeTaskUseCase{
 		Log:                              log,
@@ -88,6 +146,21 @@ func NewEmployeeTaskUseCase(
 		OrganizationMessage:              organizationMessage,
 		JobPlafonMessage:                 jobPlafonMessage,
 		UserMessage:                      userMessage,
+		CalendarService:                  calendarService,
+		TemplateTaskRuleService:          templateTaskRuleService,
+		OnboardingBackfillRepository:     obRepo,
+		OnboardingBackfillDTO:            obDTO,
+		TemplateTaskVersionService:       templateTaskVersionService,
+		EmployeeOffboardingRepository:    eoRepo,
+		EventEmployeeRepository:          eeRepo,
+		VerifierDelegationService:        verifierDelegationService,
+		EmployeeTaskHistoryRepository:    ethRepo,
+		EmployeeTaskApprovalRepository:   etapRepo,
+		EmployeeTaskApprovalService:      employeeTaskApprovalService,
+		EmployeeTaskApprovalDTO:          etapDTO,
+		EmployeeTaskFileRepository:       etfRepo,
+		EmployeeTaskKindService:          employeeTaskKindService,
+		EventRepository:                  eventRepo,
 	}
 }
 
@@ -104,11 +177,30 @@ func EmployeeTaskUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IEmploye
 	organizationMessage := messaging.OrganizationMessageFactory(log)
 	jobPlafonMessage := messaging.JobPlafonMessageFactory(log)
 	userMessage := messaging.UserMessageFactory(log)
-	return NewEmployeeTaskUseCase(log, etDTO, repo, viper, ttRepository, etaRepo, etcRepo, ehRepo, stRepo, midsuitService, employeeMessage, organizationMessage, jobPlafonMessage, userMessage)
+	calendarService := service.CalendarServiceFactory(log)
+	templateTaskRuleService := service.TemplateTaskRuleServiceFactory(log)
+	obRepo := repository.OnboardingBackfillRepositoryFactory(log)
+	obDTO := dto.OnboardingBackfillDTOFactory(log, viper)
+	templateTaskVersionService := service.TemplateTaskVersionServiceFactory(log)
+	eoRepo := repository.EmployeeOffboardingRepositoryFactory(log)
+	eeRepo := repository.EventEmployeeRepositoryFactory(log)
+	verifierDelegationService := service.VerifierDelegationServiceFactory(log)
+	ethRepo := repository.EmployeeTaskHistoryRepositoryFactory(log)
+	etapRepo := repository.EmployeeTaskApprovalRepositoryFactory(log)
+	employeeTaskApprovalService := service.EmployeeTaskApprovalServiceFactory(log)
+	etapDTO := dto.EmployeeTaskApprovalDTOFactory(log, viper)
+	etfRepo := repository.EmployeeTaskFileRepositoryFactory(log)
+	employeeTaskKindService := service.EmployeeTaskKindServiceFactory(log)
+	eventRepo := repository.EventRepositoryFactory(log)
+	return NewEmployeeTaskUseCase(log, etDTO, repo, viper, ttRepository, etaRepo, etcRepo, ehRepo, stRepo, midsuitService, employeeMessage, organizationMessage, jobPlafonMessage, userMessage, calendarService, templateTaskRuleService, obRepo, obDTO, templateTaskVersionService, eoRepo, eeRepo, verifierDelegationService, ethRepo, etapRepo, employeeTaskApprovalService, etapDTO, etfRepo, employeeTaskKindService, eventRepo)
 }
 
 func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTaskRequest) (*response.EmployeeTaskResponse, error) {
+	kind := req.Kind
+	passingScore := req.PassingScore
 	var templateTaskUUID *uuid.UUID
+	var approvalSteps []entity.TemplateTaskApprovalStep
+	var requiredTemplateChecklists map[string]bool
 	if req.TemplateTaskID != nil && *req.TemplateTaskID != "" {
 		parsedTemplateTaskID, err := uuid.Parse(*req.TemplateTaskID)
 		if err != nil {
@@ -124,7 +216,19 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 			return nil, errors.New("template task not found")
 		}
 
+		// tasks made from a template take its kind unless the request names one
+		if kind == "" {
+			kind = string(templateTask.Kind)
+		}
+		if passingScore == nil {
+			passingScore = templateTask.PassingScore
+		}
 		templateTaskUUID = &parsedTemplateTaskID
+		approvalSteps = templateTask.TemplateTaskApprovalSteps
+		requiredTemplateChecklists = templateRequiredChecklistNames(templateTask)
+	}
+	if err := service.ValidateTaskKind(kind, req.SurveyTemplateID, passingScore); err != nil {
+		return nil, err
 	}
 
 	var surveyTemplateUUID *uuid.UUID
@@ -145,7 +249,11 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 			return nil, errors.New("survey template not found")
 		}
 
-		surveyTemplateUUID = &parsedSurveyTemplateID
+		surveyTemplateUUID, err = uc.latestSurveyTemplateID(parsedSurveyTemplateID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error finding latest survey template version: ", err)
+			return nil, err
+		}
 	}
 
 	parsedEmployeeID, err := uuid.Parse(*req.EmployeeID)
@@ -324,12 +432,22 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 		EndDate:          parsedEndDate,
 		Source:           "ONBOARDING",
 		MidsuitID:        &midsuitID,
+		Kind:             entity.TaskKindEnum(kind),
+		PassingScore:     passingScore,
 	})
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error creating employee task: ", err)
 		return nil, err
 	}
 
+	// a task made from a template goes through the template's approval chain
+	if len(approvalSteps) > 0 {
+		if _, err := uc.EmployeeTaskApprovalService.StartApprovalRound(employeeTask.ID, parsedEmployeeID, 1, approvalSteps); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error starting approval round: ", err)
+			return nil, err
+		}
+	}
+
 	// create employee task attachments
 	for _, attachmentReq := range req.EmployeeTaskAttachments {
 		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
@@ -393,7 +511,20 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 
 	// create employee task checklists
 	var midsuitChecklistID string
-	for _, checklistReq := range req.EmployeeTaskChecklists {
+	for i, checklistReq := range req.EmployeeTaskChecklists {
+		checklist := &entity.EmployeeTaskChecklist{
+			EmployeeTaskID: employeeTask.ID,
+			Name:           checklistReq.Name,
+			SortOrder:      i,
+		}
+		if err := applyEmployeeTaskChecklistSettings(checklist, checklistReq); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error reading checklist settings: ", err)
+			return nil, err
+		}
+		if requiredTemplateChecklists[checklist.Name] {
+			checklist.IsRequired = "YES"
+		}
+
 		// sync emp task checklist midsuit
 		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
 			empResp, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
@@ -484,10 +615,7 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 				return nil, err
 			}
 			if exist == nil {
-				_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					EmployeeTaskID: employeeTask.ID,
-					Name:           checklistReq.Name,
-				})
+				_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(checklist)
 				if err != nil {
 					uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error creating employee task checklist: ", err)
 					return nil, err
@@ -522,11 +650,8 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 				}
 			}
 		} else {
-			_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-				EmployeeTaskID: employeeTask.ID,
-				Name:           checklistReq.Name,
-				MidsuitID:      &midsuitChecklistID,
-			})
+			checklist.MidsuitID = &midsuitChecklistID
+			_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(checklist)
 			if err != nil {
 				uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error creating employee task checklist: ", err)
 				return nil, err
@@ -549,6 +674,7 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTask(req *request.CreateEmployeeTas
 func (uc *EmployeeTaskUseCase) CreateEmployeeTaskMidsuit(req *request.CreateEmployeeTaskMidsuitRequest) (*response.EmployeeTaskResponse, error) {
 	// Implementation for CreateEmployeeTaskMidsuit
 	var templateTaskUUID *uuid.UUID
+	var approvalSteps []entity.TemplateTaskApprovalStep
 	if req.TemplateTaskID != nil && *req.TemplateTaskID != "" {
 		parsedTemplateTaskID, err := uuid.Parse(*req.TemplateTaskID)
 		if err != nil {
@@ -565,6 +691,7 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTaskMidsuit(req *request.CreateEmpl
 		}
 
 		templateTaskUUID = &parsedTemplateTaskID
+		approvalSteps = templateTask.TemplateTaskApprovalSteps
 	}
 
 	var surveyTemplateUUID *uuid.UUID
@@ -585,7 +712,11 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTaskMidsuit(req *request.CreateEmpl
 			return nil, errors.New("survey template not found")
 		}
 
-		surveyTemplateUUID = &parsedSurveyTemplateID
+		surveyTemplateUUID, err = uc.latestSurveyTemplateID(parsedSurveyTemplateID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error finding latest survey template version: ", err)
+			return nil, err
+		}
 	}
 
 	empRespMessage, err := uc.EmployeeMessage.SendFindEmployeeByMidsuitIDMessage(*req.EmployeeMidsuitID)
@@ -775,6 +906,14 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTaskMidsuit(req *request.CreateEmpl
 		return nil, err
 	}
 
+	// a task made from a template goes through the template's approval chain
+	if len(approvalSteps) > 0 {
+		if _, err := uc.EmployeeTaskApprovalService.StartApprovalRound(employeeTask.ID, parsedEmployeeID, 1, approvalSteps); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error starting approval round: ", err)
+			return nil, err
+		}
+	}
+
 	// create employee task attachments
 	for _, attachmentReq := range req.EmployeeTaskAttachments {
 		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
@@ -997,6 +1136,25 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 		return nil, errors.New("employee task not found")
 	}
 
+	if req.Kanban == string(entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED) && empTask.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		if err := uc.ensureEmployeeTaskCanBeCompleted(empTask, req.EmployeeTaskChecklists, req.Kind, req.PassingScore); err != nil {
+			return nil, err
+		}
+	}
+	if req.Kind != "" || req.PassingScore != nil {
+		kind := req.Kind
+		if kind == "" {
+			kind = string(empTask.Kind)
+		}
+		passingScore := req.PassingScore
+		if passingScore == nil {
+			passingScore = empTask.PassingScore
+		}
+		if err := service.ValidateTaskKind(kind, req.SurveyTemplateID, passingScore); err != nil {
+			return nil, err
+		}
+	}
+
 	var templateTaskUUID *uuid.UUID
 	if req.TemplateTaskID != nil && *req.TemplateTaskID != "" {
 		parsedTemplateTaskID, err := uuid.Parse(*req.TemplateTaskID)
@@ -1015,6 +1173,11 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 
 		templateTaskUUID = &parsedTemplateTaskID
 	}
+	requiredTemplateChecklists, err := uc.requiredTemplateChecklistNames(templateTaskUUID, empTask)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error finding template checklists: ", err)
+		return nil, err
+	}
 
 	var surveyTemplateUUID *uuid.UUID
 	if req.SurveyTemplateID != nil && *req.SurveyTemplateID != "" && *req.SurveyTemplateID != "null" {
@@ -1140,6 +1303,12 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error parsing verified by: ", err)
 				return nil, err
 			}
+			// the approver in midsuit is the delegate while the verifier is away
+			parsedVerifiedBy, _, err = uc.VerifierDelegationService.ResolveVerifier(parsedVerifiedBy, time.Now())
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error resolving verifier delegation: ", err)
+				return nil, err
+			}
 
 			empRespVerifiedBy, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
 				ID: parsedVerifiedBy.String(),
@@ -1160,7 +1329,7 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 			}
 
 			verifiedByMidsuitID = &verifiedByMidsuitIDInt
-			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job level menghehe: %d", empRespVerifiedBy.EmployeeJob["job_level_id"].(string))
+			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job level menghehe: %s", empRespVerifiedBy.EmployeeJob["job_level_id"].(string))
 
 			jobLevelResp2, err := uc.JobPlafonMessage.SendFindJobLevelByIDMessage(request.SendFindJobLevelByIDMessageRequest{
 				ID: jobLevelId,
@@ -1192,8 +1361,8 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 				return nil, errors.New("job not found in midsuit")
 			}
 
-			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job level: %d", jobLevelResp2.MidsuitID)
-			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job menghehe: %d", jobResp2.MidsuitID)
+			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job level: %s", jobLevelResp2.MidsuitID)
+			uc.Log.Infof("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] verified By job menghehe: %s", jobResp2.MidsuitID)
 
 			verifiedByJobIDInt, err := strconv.Atoi(jobResp2.MidsuitID)
 			if err != nil {
@@ -1369,6 +1538,8 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 		Status:           entity.EmployeeTaskStatusEnum(req.Status),
 		Kanban:           entity.EmployeeTaskKanbanEnum(req.Kanban),
 		Notes:            req.Notes,
+		Kind:             entity.TaskKindEnum(req.Kind),
+		PassingScore:     req.PassingScore,
 	})
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error creating employee task: ", err)
@@ -1389,67 +1560,87 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 
 	// create employee task checklists
 	var checklistIds []uuid.UUID
-	for _, checklistReq := range req.EmployeeTaskChecklists {
+	for i, checklistReq := range req.EmployeeTaskChecklists {
+		var exist *entity.EmployeeTaskChecklist
 		if checklistReq.ID != nil {
 			parsedChecklistID, err := uuid.Parse(*checklistReq.ID)
 			if err != nil {
 				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error parsing checklist id: ", err)
 				return nil, err
 			}
-			exist, err := uc.EmployeeTaskChecklistRepository.FindByKeys(map[string]interface{}{
+			exist, err = uc.EmployeeTaskChecklistRepository.FindByKeys(map[string]interface{}{
 				"id": parsedChecklistID,
 			})
 			if err != nil {
 				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error finding checklist by id: ", err)
 				return nil, err
 			}
-			if exist == nil {
-				_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					EmployeeTaskID: employeeTask.ID,
-					Name:           checklistReq.Name,
-				})
-				if err != nil {
-					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error creating employee task checklist: ", err)
-					return nil, err
-				}
-			} else {
-				checklistIds = append(checklistIds, parsedChecklistID)
-				var verifiedBy *uuid.UUID
-				if checklistReq.VerifiedBy != nil {
-					parsedVerifiedBy, err := uuid.Parse(*checklistReq.VerifiedBy)
-					if err != nil {
-						uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error parsing verified by: ", err)
-						return nil, err
-					}
-					verifiedBy = &parsedVerifiedBy
-				}
-				var isChecked string
-				if checklistReq.IsChecked != nil {
-					isChecked = *checklistReq.IsChecked
-				} else {
-					isChecked = "NO"
-				}
-				_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					ID:             parsedChecklistID,
-					EmployeeTaskID: employeeTask.ID,
-					Name:           checklistReq.Name,
-					IsChecked:      isChecked,
-					VerifiedBy:     verifiedBy,
-				})
-				if err != nil {
-					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
-					return nil, err
-				}
-			}
-		} else {
-			_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
+		}
+
+		if exist == nil {
+			checklist := &entity.EmployeeTaskChecklist{
 				EmployeeTaskID: employeeTask.ID,
 				Name:           checklistReq.Name,
-			})
+				SortOrder:      i,
+			}
+			if err := applyEmployeeTaskChecklistSettings(checklist, checklistReq); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error reading checklist settings: ", err)
+				return nil, err
+			}
+			if requiredTemplateChecklists[checklist.Name] {
+				checklist.IsRequired = "YES"
+			}
+			created, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(checklist)
 			if err != nil {
 				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error creating employee task checklist: ", err)
 				return nil, err
 			}
+			checklistIds = append(checklistIds, created.ID)
+			continue
+		}
+
+		checklistIds = append(checklistIds, exist.ID)
+		var verifiedBy *uuid.UUID
+		if checklistReq.VerifiedBy != nil {
+			parsedVerifiedBy, err := uuid.Parse(*checklistReq.VerifiedBy)
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error parsing verified by: ", err)
+				return nil, err
+			}
+			verifiedBy = &parsedVerifiedBy
+		}
+		var isChecked string
+		if checklistReq.IsChecked != nil {
+			isChecked = *checklistReq.IsChecked
+		} else {
+			isChecked = "NO"
+		}
+
+		checklist := *exist
+		checklist.EmployeeTaskID = employeeTask.ID
+		checklist.Name = checklistReq.Name
+		checklist.IsChecked = isChecked
+		checklist.VerifiedBy = verifiedBy
+		if err := applyEmployeeTaskChecklistSettings(&checklist, checklistReq); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error reading checklist settings: ", err)
+			return nil, err
+		}
+		if requiredTemplateChecklists[checklist.Name] {
+			checklist.IsRequired = "YES"
+		}
+		// the logged in employee checks the item, the verifier does when the update has no actor
+		checkedBy := req.ActorEmployeeID
+		if checkedBy == nil {
+			checkedBy = verifiedBy
+		}
+		stampEmployeeTaskChecklistCheck(&checklist, exist, checkedBy)
+
+		_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns(&checklist, []string{
+			"employee_task_id", "name", "is_checked", "verified_by", "sort_order", "assignee_id", "due_date", "is_required", "checked_at", "checked_by",
+		})
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
+			return nil, err
 		}
 	}
 
@@ -1472,11 +1663,10 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTask(req *request.UpdateEmployeeTas
 
 		if len(etData.EmployeeTaskChecklists) > 0 {
 			for _, checklist := range etData.EmployeeTaskChecklists {
-				_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					ID:         checklist.ID,
-					IsChecked:  "NO",
-					VerifiedBy: nil,
-				})
+				_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns(&entity.EmployeeTaskChecklist{
+					ID:        checklist.ID,
+					IsChecked: "NO",
+				}, []string{"is_checked", "verified_by", "checked_at", "checked_by"})
 				if err != nil {
 					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
 					return nil, err
@@ -1508,6 +1698,11 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTaskMidsuit(req *request.UpdateEmpl
 	if empTask == nil {
 		return nil, errors.New("employee task not found")
 	}
+	if req.Kanban == string(entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED) && empTask.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		if err := uc.ensureEmployeeTaskCanBeCompleted(empTask, checklistRequestsFromMidsuit(empTask, req.EmployeeTaskChecklists), "", nil); err != nil {
+			return nil, err
+		}
+	}
 
 	var templateTaskUUID *uuid.UUID
 	if req.TemplateTaskID != nil && *req.TemplateTaskID != "" {
@@ -1917,6 +2112,13 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTaskMidsuit(req *request.UpdateEmpl
 					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
 					return nil, err
 				}
+				stamped := entity.EmployeeTaskChecklist{ID: exist.ID, IsChecked: isChecked}
+				stampEmployeeTaskChecklistCheck(&stamped, exist, verifiedBy)
+				_, err = uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns(&stamped, []string{"checked_at", "checked_by"})
+				if err != nil {
+					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
+					return nil, err
+				}
 			}
 		} else {
 			_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
@@ -1950,11 +2152,10 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTaskMidsuit(req *request.UpdateEmpl
 
 		if len(etData.EmployeeTaskChecklists) > 0 {
 			for _, checklist := range etData.EmployeeTaskChecklists {
-				_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					ID:         checklist.ID,
-					IsChecked:  "NO",
-					VerifiedBy: nil,
-				})
+				_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns(&entity.EmployeeTaskChecklist{
+					ID:        checklist.ID,
+					IsChecked: "NO",
+				}, []string{"is_checked", "verified_by", "checked_at", "checked_by"})
 				if err != nil {
 					uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error updating employee task checklist: ", err)
 					return nil, err
@@ -2032,10 +2233,11 @@ func (uc *EmployeeTaskUseCase) FindByID(id uuid.UUID) (*response.EmployeeTaskRes
 	return uc.DTO.ConvertEntityToResponse(employeeTask), nil
 }
 
-func (uc *EmployeeTaskUseCase) CountByKanbanAndEmployeeID(kanban entity.EmployeeTaskKanbanEnum, employeeID uuid.UUID) (int64, error) {
-	count, err := uc.Repository.CountByKeys(map[string]interface{}{
+func (uc *EmployeeTaskUseCase) CountByKanbanAndEmployeeID(kanban entity.EmployeeTaskKanbanEnum, employeeID uuid.UUID, source string) (int64, error) {
+	count, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
 		"kanban":      kanban,
 		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
 	})
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.CountByKanbanAndEmployeeID] error counting by kanban and employee id: ", err)
@@ -2045,8 +2247,8 @@ func (uc *EmployeeTaskUseCase) CountByKanbanAndEmployeeID(kanban entity.Employee
 	return count, nil
 }
 
-func (uc *EmployeeTaskUseCase) FindAllByEmployeeID(employeeID uuid.UUID) (*response.EmployeeTaskKanbanResponse, error) {
-	employeeTasks, err := uc.Repository.FindAllByEmployeeID(employeeID)
+func (uc *EmployeeTaskUseCase) FindAllByEmployeeID(employeeID uuid.UUID, source string) (*response.EmployeeTaskKanbanResponse, error) {
+	employeeTasks, err := uc.Repository.FindAllByEmployeeIDAndSource(employeeID, source)
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.FindAllByEmployeeID] error finding all by employee id: ", err)
 		return nil, err
@@ -2057,8 +2259,8 @@ func (uc *EmployeeTaskUseCase) FindAllByEmployeeID(employeeID uuid.UUID) (*respo
 	return formattedResponse, nil
 }
 
-func (uc *EmployeeTaskUseCase) FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error) {
-	employeeTasks, total, err := uc.Repository.FindAllByEmployeeIDAndKanbanPaginated(employeeID, kanban, page, pageSize, search, sort)
+func (uc *EmployeeTaskUseCase) FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, source string, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error) {
+	employeeTasks, total, err := uc.Repository.FindAllByEmployeeIDAndKanbanPaginated(employeeID, source, kanban, page, pageSize, search, sort)
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.FindAllByEmployeeIDAndKanbanPaginated] error finding all by employee id and kanban: ", err)
 		return nil, 0, err
@@ -2122,298 +2324,191 @@ func (uc *EmployeeTaskUseCase) UpdateEmployeeTaskOnly(req *request.UpdateEmploye
 }
 
 func (uc *EmployeeTaskUseCase) CreateEmployeeTasksForRecruitment(req *request.CreateEmployeeTasksForRecruitment) error {
-	templateTasks, err := uc.TemplateTaskRepository.FindAllByKeys(map[string]interface{}{
-		"organization_type": req.OrganizationType,
-		"status":            entity.TEMPLATE_TASK_STATUS_ENUM_ACTIVE,
-	})
-	if err != nil {
-		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error finding all template tasks: ", err)
-		return err
-	}
-
-	parsedEmployeeID, err := uuid.Parse(req.EmployeeID)
+	plan, err := uc.planRecruitmentTasks(req, entity.TASK_SOURCE_ONBOARDING, false)
 	if err != nil {
-		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error parsing employee id: ", err)
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error planning employee tasks: ", err)
 		return err
 	}
-
-	parsedJoinedDate, err := time.Parse("2006-01-02 15:04:05 -0700 MST", req.JoinedDate)
-	if err != nil {
-		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error parsing joined date: ", err)
+	if err := undecidedTemplateTasksError(plan); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error planning employee tasks: ", err)
 		return err
 	}
 
 	_, err = uc.EmployeeHiringRepository.CreateEmployeeHiring(&entity.EmployeeHiring{
-		EmployeeID: parsedEmployeeID,
-		HiringDate: parsedJoinedDate,
+		EmployeeID: plan.EmployeeID,
+		HiringDate: plan.JoinedDate,
 	})
 	if err != nil {
 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee hiring: ", err)
 	}
 
 	// create employee tasks
-	if len(*templateTasks) > 0 {
-		for _, templateTask := range *templateTasks {
-			empTaskExist, err := uc.Repository.FindByKeys(map[string]interface{}{
-				"employee_id":      parsedEmployeeID,
-				"template_task_id": templateTask.ID,
-			})
-			if err != nil {
-				uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error finding employee task by keys: ", err)
-				continue
-			}
-			if empTaskExist == nil {
-				// post to midsuit
-				var midsuitID string
-				if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
-					midsuitPayload := &request.SyncEmployeeTaskMidsuitRequest{
-						AdOrgId: request.AdOrgId{
-							// ID: orgResp.MidsuitID,
-							// ID: 1000024,
-							ID: func() int {
-								id, err := strconv.Atoi(req.OrgMidsuitID)
-								if err != nil {
-									uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting req.OrgMidsuitID to int: ", err)
-									return 0 // or handle the error appropriately
-								}
-								return id
-							}(),
-						},
-						Name: templateTask.Name,
-						Category: request.TaskCategory{
-							ID: "ON",
-						},
-						StartDate: parsedJoinedDate.String(),
-						EndDate:   parsedJoinedDate.AddDate(0, 0, *templateTask.DueDuration).String(),
-						HCEmployeeID: request.HcEmployeeId{
-							ID: func() int {
-								// id, err := strconv.Atoi(empResp.MidsuitID)
-								id, err := strconv.Atoi(req.EmployeeMidsuitID)
-								if err != nil {
-									uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting empResp.MidsuitID to int: ", err)
-									return 0 // or handle the error appropriately
-								}
-								return id
-							}(),
-							// ID: 1000108,
-						},
-						HCJobID: request.HcJobId{
-							ID: func() int {
-								// id, err := strconv.Atoi(jobResp.MidsuitID)
-								id, err := strconv.Atoi(req.JobMidsuitID)
-								if err != nil {
-									uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting jobResp.MidsuitID to int: ", err)
-									return 0 // or handle the error appropriately
-								}
-								return id
-							}(),
-							// ID: 1000472,
-						},
-						HCJobLevelID: request.HcJobLevelId{
-							ID: func() int {
-								// id, err := strconv.Atoi(jobLevelResp.MidsuitID)
-								id, err := strconv.Atoi(req.JobLevelMidsuitID)
-								if err != nil {
-									uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting jobLevelResp.MidsuitID to int: ", err)
-									return 0 // or handle the error appropriately
-								}
-								return id
-							}(),
-							// ID: 1000095,
-						},
-						HCOrgID: request.HcOrgId{
-							ID: func() int {
-								// id, err := strconv.Atoi(orgStructureResp.MidsuitID)
-								id, err := strconv.Atoi(req.OrgStructureMidsuitID)
-								if err != nil {
-									uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting orgStructureResp.MidsuitID to int: ", err)
-									return 0 // or handle the error appropriately
-								}
-								return id
-							}(),
-							// ID: 1000622,
-						},
+	for _, item := range plan.Items {
+		if err := uc.createEmployeeTaskFromPlanItem(req, plan, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createEmployeeTaskFromPlanItem persists a planned employee task together with its
+// checklists and attachments, syncing each of them to Midsuit when enabled.
+func (uc *EmployeeTaskUseCase) createEmployeeTaskFromPlanItem(req *request.CreateEmployeeTasksForRecruitment, plan *recruitmentPlan, item recruitmentPlanItem) error {
+	templateTask := item.TemplateTask
+	templateTaskVersion, err := uc.TemplateTaskVersionService.EnsureCurrentVersion(&templateTask)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error resolving template task version: ", err)
+		return err
+	}
+	// post to midsuit
+	var midsuitID string
+	if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+		midsuitPayload := &request.SyncEmployeeTaskMidsuitRequest{
+			AdOrgId: request.AdOrgId{
+				// ID: orgResp.MidsuitID,
+				// ID: 1000024,
+				ID: func() int {
+					id, err := strconv.Atoi(req.OrgMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting req.OrgMidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
 					}
-					authResp, err := uc.MidsuitService.AuthOneStep()
+					return id
+				}(),
+			},
+			Name:      templateTask.Name,
+			Category:  uc.midsuitTaskCategory(plan.Source),
+			StartDate: plan.JoinedDate.String(),
+			EndDate:   item.EndDate.String(),
+			HCEmployeeID: request.HcEmployeeId{
+				ID: func() int {
+					// id, err := strconv.Atoi(empResp.MidsuitID)
+					id, err := strconv.Atoi(req.EmployeeMidsuitID)
 					if err != nil {
-						uc.Log.Error("[DocumentSendingUseCase.UpdateDocumentSending] " + err.Error())
-						return err
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting empResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
 					}
-
-					midsuitEmpTask, err := uc.MidsuitService.SyncEmployeeTaskMidsuit(*midsuitPayload, authResp.Token)
+					return id
+				}(),
+				// ID: 1000108,
+			},
+			HCJobID: request.HcJobId{
+				ID: func() int {
+					// id, err := strconv.Atoi(jobResp.MidsuitID)
+					id, err := strconv.Atoi(req.JobMidsuitID)
 					if err != nil {
-						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error syncing employee task to midsuit: ", err)
-						return err
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting jobResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
 					}
-
-					midsuitID = *midsuitEmpTask
-				}
-				createdEmpTask, err := uc.Repository.CreateEmployeeTask(&entity.EmployeeTask{
-					EmployeeID:     &parsedEmployeeID,
-					TemplateTaskID: &templateTask.ID,
-					StartDate:      parsedJoinedDate,
-					EndDate:        parsedJoinedDate.AddDate(0, 0, *templateTask.DueDuration),
-					CoverPath:      templateTask.CoverPath,
-					Name:           templateTask.Name,
-					Description:    templateTask.Description,
-					Status:         entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
-					Kanban:         entity.EMPLOYEE_TASK_KANBAN_ENUM_TODO,
-					Priority:       entity.EmployeeTaskPriorityEnum(templateTask.Priority),
-					IsDone:         "NO",
-					Source:         "ONBOARDING",
-					MidsuitID:      &midsuitID,
-				})
-				if err != nil {
-					uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task: ", err)
-					// continue
-					return err
-				}
-				if len(templateTask.TemplateTaskChecklists) > 0 {
-					// for _, checklist := range templateTask.TemplateTaskChecklists {
-					// 	_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-					// 		EmployeeTaskID: createdEmpTask.ID,
-					// 		Name:           checklist.Name,
-					// 		IsChecked:      "NO",
-					// 	})
-					// 	if err != nil {
-					// 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task checklist: ", err)
-					// 		// continue
-					// 		return err
-					// 	}
-					// }
-
-					var midsuitChecklistID string
-					for _, checklistReq := range templateTask.TemplateTaskChecklists {
-						// sync emp task checklist midsuit
-						if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
-
-							midsuitChecklistPayload := &request.SyncEmployeeTaskChecklistMidsuitRequest{
-								AdOrgId: request.AdOrgId{
-									ID: func() int {
-										id, err := strconv.Atoi(req.OrgMidsuitID)
-										if err != nil {
-											uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting orgResp.MidsuitID to int: ", err)
-											return 0 // or handle the error appropriately
-										}
-										return id
-									}(),
-								},
-								Name:      checklistReq.Name,
-								IsChecked: false,
-								HCTaskID: request.HCTaskID{
-									ID: func() int {
-										id, err := strconv.Atoi(midsuitID)
-										if err != nil {
-											uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting employeeTask.MidsuitID to int: ", err)
-											return 0 // or handle the error appropriately
-										}
-										return id
-									}(),
-								},
-								HCEmployeeID: request.HcEmployeeId{
-									ID: func() int {
-										// id, err := strconv.Atoi(empResp.MidsuitID)
-										id, err := strconv.Atoi(req.EmployeeMidsuitID)
-										if err != nil {
-											uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting empResp.MidsuitID to int: ", err)
-											return 0 // or handle the error appropriately
-										}
-										return id
-									}(),
-									// ID: 1000108,
-								},
-								ModelName: "hc_taskchecklist",
-							}
-
-							authResp, err := uc.MidsuitService.AuthOneStep()
-							if err != nil {
-								uc.Log.Error("[DocumentSendingUseCase.UpdateDocumentSending] " + err.Error())
-								return err
-							}
-
-							respChecklist, err := uc.MidsuitService.SyncEmployeeTaskChecklistMidsuit(*midsuitChecklistPayload, authResp.Token)
-							if err != nil {
-								uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error syncing employee task checklist to midsuit: ", err)
-								return err
-							}
-							midsuitChecklistID = *respChecklist
-						}
-						_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
-							EmployeeTaskID: createdEmpTask.ID,
-							Name:           checklistReq.Name,
-							MidsuitID:      &midsuitChecklistID,
-						})
-						if err != nil {
-							uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error creating employee task checklist: ", err)
-							return err
-						}
+					return id
+				}(),
+				// ID: 1000472,
+			},
+			HCJobLevelID: request.HcJobLevelId{
+				ID: func() int {
+					// id, err := strconv.Atoi(jobLevelResp.MidsuitID)
+					id, err := strconv.Atoi(req.JobLevelMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting jobLevelResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
 					}
-				}
-				if len(templateTask.TemplateTaskAttachments) > 0 {
-					// for _, attachment := range templateTask.TemplateTaskAttachments {
-					// 	_, err := uc.EmployeeTaskAttachmentRepository.CreateEmployeeTaskAttachment(&entity.EmployeeTaskAttachment{
-					// 		EmployeeTaskID: createdEmpTask.ID,
-					// 		Path:           attachment.Path,
-					// 	})
-					// 	if err != nil {
-					// 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task attachment: ", err)
-					// 		// continue
-					// 		return err
-					// 	}
-					// }
-					for _, attachmentReq := range templateTask.TemplateTaskAttachments {
-						if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
-							// Read the file from the given path
-							fileContent, err := os.ReadFile(attachmentReq.Path)
-							if err != nil {
-								uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error reading file: ", err)
-								return err
-							}
-
-							// Extract the file name from the path
-							fileName := filepath.Base(attachmentReq.Path)
-
-							// Encode the file content to base64
-							encodedData := base64.StdEncoding.EncodeToString(fileContent)
-
-							// Create the payload
-							midsuitAttachmentPayload := &request.SyncEmployeeTaskAttachmentMidsuitRequest{
-								Name: fileName,
-								Data: encodedData,
-							}
-
-							// Log the payload for debugging
-							// uc.Log.Info("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] midsuit attachment payload: ", midsuitAttachmentPayload)
-
-							// Sync to midsuit
-							authResp, err := uc.MidsuitService.AuthOneStep()
-							if err != nil {
-								uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] " + err.Error())
-								return err
-							}
-
-							midsuitIDInt, err := strconv.Atoi(midsuitID)
-							if err != nil {
-								uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting midsuitID to int: ", err)
-								return err
-							}
-							_, err = uc.MidsuitService.SyncEmployeeTaskAttachmentMidsuit(midsuitIDInt, *midsuitAttachmentPayload, authResp.Token)
-							if err != nil {
-								uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error syncing employee task attachment to midsuit: ", err)
-								return err
-							}
-						}
-
-						_, err = uc.EmployeeTaskAttachmentRepository.CreateEmployeeTaskAttachment(&entity.EmployeeTaskAttachment{
-							EmployeeTaskID: createdEmpTask.ID,
-							Path:           attachmentReq.Path,
-						})
-						if err != nil {
-							uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error creating employee task attachment: ", err)
-							return err
-						}
+					return id
+				}(),
+				// ID: 1000095,
+			},
+			HCOrgID: request.HcOrgId{
+				ID: func() int {
+					// id, err := strconv.Atoi(orgStructureResp.MidsuitID)
+					id, err := strconv.Atoi(req.OrgStructureMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting orgStructureResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
 					}
-				}
+					return id
+				}(),
+				// ID: 1000622,
+			},
+		}
+		authResp, err := uc.MidsuitService.AuthOneStep()
+		if err != nil {
+			uc.Log.Error("[DocumentSendingUseCase.UpdateDocumentSending] " + err.Error())
+			return err
+		}
+
+		midsuitEmpTask, err := uc.MidsuitService.SyncEmployeeTaskMidsuit(*midsuitPayload, authResp.Token)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error syncing employee task to midsuit: ", err)
+			return err
+		}
+
+		midsuitID = *midsuitEmpTask
+	}
+	createdEmpTask, err := uc.Repository.CreateEmployeeTask(&entity.EmployeeTask{
+		EmployeeID:            &plan.EmployeeID,
+		TemplateTaskID:        &templateTask.ID,
+		TemplateTaskVersionID: &templateTaskVersion.ID,
+		SurveyTemplateID:      item.SurveyTemplateID,
+		StartDate:             plan.JoinedDate,
+		EndDate:               item.EndDate,
+		CoverPath:             templateTask.CoverPath,
+		Name:                  templateTask.Name,
+		Description:           templateTask.Description,
+		Status:                entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
+		Kanban:                entity.EMPLOYEE_TASK_KANBAN_ENUM_TODO,
+		Priority:              entity.EmployeeTaskPriorityEnum(templateTask.Priority),
+		IsDone:                "NO",
+		Source:                plan.Source,
+		MidsuitID:             &midsuitID,
+		Kind:                  templateTask.Kind,
+		PassingScore:          templateTask.PassingScore,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task: ", err)
+		// continue
+		return err
+	}
+	if len(templateTask.TemplateTaskApprovalSteps) > 0 {
+		if _, err := uc.EmployeeTaskApprovalService.StartApprovalRound(createdEmpTask.ID, plan.EmployeeID, 1, templateTask.TemplateTaskApprovalSteps); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task approvals: ", err)
+			return err
+		}
+	}
+	if len(templateTask.TemplateTaskChecklists) > 0 {
+		// for _, checklist := range templateTask.TemplateTaskChecklists {
+		// 	_, err := uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(&entity.EmployeeTaskChecklist{
+		// 		EmployeeTaskID: createdEmpTask.ID,
+		// 		Name:           checklist.Name,
+		// 		IsChecked:      "NO",
+		// 	})
+		// 	if err != nil {
+		// 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task checklist: ", err)
+		// 		// continue
+		// 		return err
+		// 	}
+		// }
+
+		for _, checklistReq := range templateTask.TemplateTaskChecklists {
+			if err := uc.createEmployeeTaskChecklistFromTemplate(req, plan.Profile.OrganizationID, createdEmpTask, midsuitID, checklistReq); err != nil {
+				return err
+			}
+		}
+	}
+	if len(templateTask.TemplateTaskAttachments) > 0 {
+		// for _, attachment := range templateTask.TemplateTaskAttachments {
+		// 	_, err := uc.EmployeeTaskAttachmentRepository.CreateEmployeeTaskAttachment(&entity.EmployeeTaskAttachment{
+		// 		EmployeeTaskID: createdEmpTask.ID,
+		// 		Path:           attachment.Path,
+		// 	})
+		// 	if err != nil {
+		// 		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error creating employee task attachment: ", err)
+		// 		// continue
+		// 		return err
+		// 	}
+		// }
+		for _, attachmentReq := range templateTask.TemplateTaskAttachments {
+			if err := uc.createEmployeeTaskAttachmentFromTemplate(createdEmpTask, midsuitID, attachmentReq); err != nil {
+				return err
 			}
 		}
 	}
@@ -2421,26 +2516,473 @@ func (uc *EmployeeTaskUseCase) CreateEmployeeTasksForRecruitment(req *request.Cr
 	return nil
 }
 
-func (uc *EmployeeTaskUseCase) CountKanbanProgressByEmployeeID(employeeID uuid.UUID) (*response.EmployeeTaskProgressResponse, error) {
-	totalTask, err := uc.Repository.CountByKeys(map[string]interface{}{
-		"employee_id": employeeID,
-	})
-	if err != nil {
-		uc.Log.Error("[EmployeeTaskUseCase.CountKanbanProgressByEmployeeID] error counting total task: ", err)
-		return nil, err
+// createEmployeeTaskAttachmentFromTemplate copies a template attachment onto an employee task. When
+// Midsuit sync is active a missing file is logged and skipped so that it does not stop the other tasks.
+func (uc *EmployeeTaskUseCase) createEmployeeTaskAttachmentFromTemplate(employeeTask *entity.EmployeeTask, midsuitID string, attachmentReq entity.TemplateTaskAttachment) error {
+	if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+		// Read the file from the given path
+		fileContent, err := os.ReadFile(attachmentReq.Path)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error reading file, attachment skipped: ", err)
+			return nil
+		}
+
+		// Extract the file name from the path
+		fileName := filepath.Base(attachmentReq.Path)
+
+		// Encode the file content to base64
+		encodedData := base64.StdEncoding.EncodeToString(fileContent)
+
+		// Create the payload
+		midsuitAttachmentPayload := &request.SyncEmployeeTaskAttachmentMidsuitRequest{
+			Name: fileName,
+			Data: encodedData,
+		}
+
+		// Log the payload for debugging
+		// uc.Log.Info("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] midsuit attachment payload: ", midsuitAttachmentPayload)
+
+		// Sync to midsuit
+		authResp, err := uc.MidsuitService.AuthOneStep()
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] " + err.Error())
+			return err
+		}
+
+		midsuitIDInt, err := strconv.Atoi(midsuitID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting midsuitID to int: ", err)
+			return err
+		}
+		_, err = uc.MidsuitService.SyncEmployeeTaskAttachmentMidsuit(midsuitIDInt, *midsuitAttachmentPayload, authResp.Token)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error syncing employee task attachment to midsuit: ", err)
+			return err
+		}
 	}
 
-	toDo, err := uc.Repository.CountByKeys(map[string]interface{}{
-		"employee_id": employeeID,
-		"kanban":      entity.EMPLOYEE_TASK_KANBAN_ENUM_TODO,
+	_, err := uc.EmployeeTaskAttachmentRepository.CreateEmployeeTaskAttachment(&entity.EmployeeTaskAttachment{
+		EmployeeTaskID: employeeTask.ID,
+		Path:           attachmentReq.Path,
 	})
 	if err != nil {
-		uc.Log.Error("[EmployeeTaskUseCase.CountKanbanProgressByEmployeeID] error counting to do: ", err)
-		return nil, err
+		uc.Log.Error("[EmployeeTaskUseCase.UpdateEmployeeTaskUseCase] error creating employee task attachment: ", err)
+		return err
 	}
 
-	inProgress, err := uc.Repository.CountByKeys(map[string]interface{}{
+	return nil
+}
+
+// createEmployeeTaskChecklistFromTemplate copies a template checklist item onto an employee task,
+// counting its due date on the calendar of the employee's organization.
+func (uc *EmployeeTaskUseCase) createEmployeeTaskChecklistFromTemplate(req *request.CreateEmployeeTasksForRecruitment, organizationID *uuid.UUID, employeeTask *entity.EmployeeTask, employeeTaskMidsuitID string, templateChecklist entity.TemplateTaskChecklist) error {
+	name := templateChecklist.Name
+	var midsuitChecklistID string
+	// sync emp task checklist midsuit
+	if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+		midsuitChecklistPayload := &request.SyncEmployeeTaskChecklistMidsuitRequest{
+			AdOrgId: request.AdOrgId{
+				ID: func() int {
+					id, err := strconv.Atoi(req.OrgMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting orgResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
+					}
+					return id
+				}(),
+			},
+			Name:      name,
+			IsChecked: false,
+			HCTaskID: request.HCTaskID{
+				ID: func() int {
+					id, err := strconv.Atoi(employeeTaskMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error converting employeeTask.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
+					}
+					return id
+				}(),
+			},
+			HCEmployeeID: request.HcEmployeeId{
+				ID: func() int {
+					// id, err := strconv.Atoi(empResp.MidsuitID)
+					id, err := strconv.Atoi(req.EmployeeMidsuitID)
+					if err != nil {
+						uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForRecruitment] error converting empResp.MidsuitID to int: ", err)
+						return 0 // or handle the error appropriately
+					}
+					return id
+				}(),
+				// ID: 1000108,
+			},
+			ModelName: "hc_taskchecklist",
+		}
+
+		authResp, err := uc.MidsuitService.AuthOneStep()
+		if err != nil {
+			uc.Log.Error("[DocumentSendingUseCase.UpdateDocumentSending] " + err.Error())
+			return err
+		}
+
+		respChecklist, err := uc.MidsuitService.SyncEmployeeTaskChecklistMidsuit(*midsuitChecklistPayload, authResp.Token)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error syncing employee task checklist to midsuit: ", err)
+			return err
+		}
+		midsuitChecklistID = *respChecklist
+	}
+	checklist, err := uc.employeeTaskChecklistFromTemplate(organizationID, employeeTask, templateChecklist)
+	if err != nil {
+		return err
+	}
+	checklist.MidsuitID = &midsuitChecklistID
+	_, err = uc.EmployeeTaskChecklistRepository.CreateEmployeeTaskChecklist(checklist)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTaskUseCase] error creating employee task checklist: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// recruitmentPlanItem is an employee task that would be generated from a template task.
+type recruitmentPlanItem struct {
+	TemplateTask     entity.TemplateTask
+	EndDate          time.Time
+	SurveyTemplateID *uuid.UUID
+	// SurveyTemplate is the version of the survey the task answers
+	SurveyTemplate *entity.SurveyTemplate
+}
+
+// latestSurveyTemplateID gives a new task the latest published version of its survey. The tasks
+// made before keep the version they were given.
+func (uc *EmployeeTaskUseCase) latestSurveyTemplateID(surveyTemplateID uuid.UUID) (*uuid.UUID, error) {
+	latest, err := uc.SurveyTemplateRepository.FindLatestPublishedVersion(surveyTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		return &latest.ID, nil
+	}
+
+	return &surveyTemplateID, nil
+}
+
+// recruitmentPlan is the outcome of the generation logic for a hire, shared by
+// CreateEmployeeTasksForRecruitment and its preview.
+type recruitmentPlan struct {
+	EmployeeID   uuid.UUID
+	JoinedDate   time.Time
+	Source       string
+	Profile      *service.HireProfile
+	Items        []recruitmentPlanItem
+	SkippedTasks []response.OnboardingPlanSkippedTaskResponse
+	// UndecidedTasks are template tasks whose rules depend on an attribute the hire has no
+	// value for, they are neither generated nor skipped
+	UndecidedTasks []response.OnboardingPlanSkippedTaskResponse
+	Warnings       []string
+}
+
+// undecidedTemplateTasksError refuses to generate tasks while the rules of a template task
+// cannot be decided, so that a hire never silently misses a task.
+func undecidedTemplateTasksError(plan *recruitmentPlan) error {
+	if len(plan.UndecidedTasks) == 0 {
+		return nil
+	}
+
+	undecided := make([]string, 0, len(plan.UndecidedTasks))
+	for _, task := range plan.UndecidedTasks {
+		undecided = append(undecided, task.Name+" ("+task.Reason+")")
+	}
+	return errors.New("template tasks cannot be decided for the hire: " + strings.Join(undecided, ", "))
+}
+
+func parseRecruitmentJoinedDate(joinedDate string) (time.Time, error) {
+	parsedJoinedDate, err := time.Parse("2006-01-02 15:04:05 -0700 MST", joinedDate)
+	if err == nil {
+		return parsedJoinedDate, nil
+	}
+
+	return time.Parse("2006-01-02", joinedDate)
+}
+
+// planRecruitmentTasks decides which tasks a hire receives and when they are due.
+// It only reads data, nothing is written to the database or Midsuit.
+// Offboarding is planned the same way from the offboarding templates, joined date being the
+// day offboarding starts. Checklists and attachments are loaded as they are copied onto the tasks.
+// Rules are matched against the request alone unless lookups is set, which must not be done
+// while a RabbitMQ message is handled.
+func (uc *EmployeeTaskUseCase) planRecruitmentTasks(req *request.CreateEmployeeTasksForRecruitment, source string, lookups bool) (*recruitmentPlan, error) {
+	templateTasks, err := uc.TemplateTaskRepository.FindAllByKeysWithDetails(map[string]interface{}{
+		"organization_type": req.OrganizationType,
+		"status":            entity.TEMPLATE_TASK_STATUS_ENUM_ACTIVE,
+		"source":            source,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error finding all template tasks: ", err)
+		return nil, err
+	}
+
+	parsedEmployeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error parsing employee id: ", err)
+		return nil, err
+	}
+
+	parsedJoinedDate, err := parseRecruitmentJoinedDate(req.JoinedDate)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error parsing joined date: ", err)
+		return nil, err
+	}
+
+	var hireProfile *service.HireProfile
+	if lookups {
+		hireProfile, err = uc.TemplateTaskRuleService.ResolveHireProfile(req)
+	} else {
+		hireProfile, err = uc.TemplateTaskRuleService.HireProfileFromRequest(req)
+	}
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error resolving hire profile: ", err)
+		return nil, err
+	}
+
+	plan := &recruitmentPlan{
+		EmployeeID:     parsedEmployeeID,
+		JoinedDate:     parsedJoinedDate,
+		Source:         source,
+		Profile:        hireProfile,
+		Items:          make([]recruitmentPlanItem, 0),
+		SkippedTasks:   make([]response.OnboardingPlanSkippedTaskResponse, 0),
+		UndecidedTasks: make([]response.OnboardingPlanSkippedTaskResponse, 0),
+		Warnings:       make([]string, 0),
+	}
+
+	if len(*templateTasks) == 0 {
+		plan.Warnings = append(plan.Warnings, "no active "+strings.ToLower(source)+" template tasks found for organization type "+req.OrganizationType)
+		return plan, nil
+	}
+
+	// without an organization only national holidays and the default work week apply
+	if hireProfile.OrganizationID == nil {
+		plan.Warnings = append(plan.Warnings, "organization of the employee is unknown, due dates use national holidays and a Monday to Friday work week")
+	}
+	plan.Warnings = append(plan.Warnings, missingHireAttributeWarnings(hireProfile, templateTasks)...)
+
+	for _, templateTask := range *templateTasks {
+		empTaskExist, err := uc.Repository.FindByKeys(map[string]interface{}{
+			"employee_id":      parsedEmployeeID,
+			"template_task_id": templateTask.ID,
+		})
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error finding employee task by keys: ", err)
+			continue
+		}
+		if empTaskExist != nil {
+			plan.SkippedTasks = append(plan.SkippedTasks, response.OnboardingPlanSkippedTaskResponse{
+				TemplateTaskID: templateTask.ID,
+				Name:           templateTask.Name,
+				Reason:         "already assigned to the employee",
+			})
+			continue
+		}
+
+		matched, err := uc.TemplateTaskRuleService.MatchTemplateTask(hireProfile, &templateTask)
+		var missingErr *service.MissingHireAttributeError
+		if errors.As(err, &missingErr) {
+			plan.UndecidedTasks = append(plan.UndecidedTasks, response.OnboardingPlanSkippedTaskResponse{
+				TemplateTaskID: templateTask.ID,
+				Name:           templateTask.Name,
+				Reason:         err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error evaluating template task rules: ", err)
+			return nil, err
+		}
+		if !matched {
+			plan.SkippedTasks = append(plan.SkippedTasks, response.OnboardingPlanSkippedTaskResponse{
+				TemplateTaskID: templateTask.ID,
+				Name:           templateTask.Name,
+				Reason:         "excluded by targeting rules",
+			})
+			continue
+		}
+
+		if templateTask.DueDuration == nil || *templateTask.DueDuration == 0 {
+			plan.Warnings = append(plan.Warnings, "template task "+templateTask.Name+" has no due duration, the task is due on the join date")
+		}
+
+		dueDate, err := uc.calculateDueDate(hireProfile.OrganizationID, parsedJoinedDate, templateTask.DueDuration)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error calculating due date: ", err)
+			return nil, err
+		}
+
+		var surveyTemplateID *uuid.UUID
+		surveyTemplate := templateTask.SurveyTemplate
+		if templateTask.SurveyTemplateID != nil {
+			if surveyTemplate == nil {
+				plan.Warnings = append(plan.Warnings, "survey template of template task "+templateTask.Name+" no longer exists, the task is created without a survey")
+			} else {
+				// new tasks answer the latest published version of the survey
+				latest, err := uc.SurveyTemplateRepository.FindLatestPublishedVersion(surveyTemplate.ID)
+				if err != nil {
+					uc.Log.Error("[EmployeeTaskUseCase.planRecruitmentTasks] error finding latest survey template version: ", err)
+					return nil, err
+				}
+				if latest != nil {
+					surveyTemplate = latest
+				}
+				if surveyTemplate.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_DRAFT {
+					plan.Warnings = append(plan.Warnings, "survey template "+surveyTemplate.Title+" of template task "+templateTask.Name+" is still a draft")
+				}
+				surveyTemplateID = &surveyTemplate.ID
+			}
+		}
+
+		plan.Items = append(plan.Items, recruitmentPlanItem{
+			TemplateTask:     templateTask,
+			EndDate:          dueDate,
+			SurveyTemplateID: surveyTemplateID,
+			SurveyTemplate:   surveyTemplate,
+		})
+	}
+
+	if len(plan.Items) > 0 && uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+		midsuitIDs := []struct {
+			key   string
+			value string
+		}{
+			{"employee_midsuit_id", req.EmployeeMidsuitID},
+			{"job_midsuit_id", req.JobMidsuitID},
+			{"job_level_midsuit_id", req.JobLevelMidsuitID},
+			{"org_midsuit_id", req.OrgMidsuitID},
+			{"org_structure_midsuit_id", req.OrgStructureMidsuitID},
+		}
+		for _, midsuitID := range midsuitIDs {
+			if _, err := strconv.Atoi(midsuitID.value); err != nil {
+				plan.Warnings = append(plan.Warnings, midsuitID.key+" is not a valid Midsuit id, the tasks will not be linked correctly in Midsuit")
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// missingHireAttributeWarnings lists hire attributes that rules depend on but were not given.
+func missingHireAttributeWarnings(profile *service.HireProfile, templateTasks *[]entity.TemplateTask) []string {
+	values := map[entity.TemplateTaskRuleCriterionEnum]string{
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB_LEVEL:              profile.JobLevelID,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_JOB:                    profile.JobID,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_LOCATION:  profile.OrganizationLocationID,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_ORGANIZATION_STRUCTURE: profile.OrganizationStructureID,
+		entity.TEMPLATE_TASK_RULE_CRITERION_ENUM_EMPLOYMENT_TYPE:        profile.EmploymentType,
+	}
+
+	warned := make(map[entity.TemplateTaskRuleCriterionEnum]bool)
+	warnings := make([]string, 0)
+	for _, templateTask := range *templateTasks {
+		for _, rule := range templateTask.TemplateTaskRules {
+			if values[rule.Criterion] != "" || warned[rule.Criterion] {
+				continue
+			}
+			warned[rule.Criterion] = true
+			warnings = append(warnings, "hire has no "+string(rule.Criterion)+", template tasks with rules on it cannot be decided")
+		}
+	}
+
+	return warnings
+}
+
+func (uc *EmployeeTaskUseCase) PreviewEmployeeTasksForRecruitment(req *request.CreateEmployeeTasksForRecruitment) (*response.OnboardingPlanResponse, error) {
+	plan, err := uc.planRecruitmentTasks(req, entity.TASK_SOURCE_ONBOARDING, false)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PreviewEmployeeTasksForRecruitment] error planning employee tasks: ", err)
+		return nil, err
+	}
+
+	return uc.convertRecruitmentPlanToResponse(req.OrganizationType, plan), nil
+}
+
+// convertRecruitmentPlanToResponse shows a plan the way the tasks would be created.
+func (uc *EmployeeTaskUseCase) convertRecruitmentPlanToResponse(organizationType string, plan *recruitmentPlan) *response.OnboardingPlanResponse {
+	tasks := make([]response.OnboardingPlanTaskResponse, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		checklists := make([]string, 0, len(item.TemplateTask.TemplateTaskChecklists))
+		for _, checklist := range item.TemplateTask.TemplateTaskChecklists {
+			checklists = append(checklists, checklist.Name)
+		}
+
+		attachments := make([]string, 0, len(item.TemplateTask.TemplateTaskAttachments))
+		for _, attachment := range item.TemplateTask.TemplateTaskAttachments {
+			attachments = append(attachments, uc.Viper.GetString("app.url")+attachment.Path)
+		}
+
+		var surveyTemplateTitle string
+		if item.SurveyTemplateID != nil {
+			surveyTemplateTitle = item.SurveyTemplate.Title
+		}
+
+		tasks = append(tasks, response.OnboardingPlanTaskResponse{
+			TemplateTaskID:      item.TemplateTask.ID,
+			Name:                item.TemplateTask.Name,
+			Description:         item.TemplateTask.Description,
+			Priority:            entity.EmployeeTaskPriorityEnum(item.TemplateTask.Priority),
+			StartDate:           plan.JoinedDate.Format("2006-01-02"),
+			EndDate:             item.EndDate.Format("2006-01-02"),
+			DueDuration:         item.TemplateTask.DueDuration,
+			SurveyTemplateID:    item.SurveyTemplateID,
+			SurveyTemplateTitle: surveyTemplateTitle,
+			Checklists:          checklists,
+			Attachments:         attachments,
+		})
+	}
+
+	// undecided tasks are shown as skipped, creating the tasks fails until they can be decided
+	skippedTasks := append(plan.SkippedTasks, plan.UndecidedTasks...)
+	warnings := plan.Warnings
+	for _, task := range plan.UndecidedTasks {
+		warnings = append(warnings, "template task "+task.Name+" cannot be decided: "+task.Reason)
+	}
+
+	return &response.OnboardingPlanResponse{
+		EmployeeID:       plan.EmployeeID,
+		OrganizationID:   plan.Profile.OrganizationID,
+		OrganizationType: organizationType,
+		JoinedDate:       plan.JoinedDate.Format("2006-01-02"),
+		Source:           plan.Source,
+		Tasks:            tasks,
+		SkippedTasks:     skippedTasks,
+		Warnings:         warnings,
+	}
+}
+
+// CountKanbanProgressByEmployeeID counts the active tasks of the employee per kanban. Tasks of a
+// paused or cancelled onboarding are inactive and not counted.
+func (uc *EmployeeTaskUseCase) CountKanbanProgressByEmployeeID(employeeID uuid.UUID, source string) (*response.EmployeeTaskProgressResponse, error) {
+	totalTask, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
+		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CountKanbanProgressByEmployeeID] error counting total task: ", err)
+		return nil, err
+	}
+
+	toDo, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
+		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
+		"kanban":      entity.EMPLOYEE_TASK_KANBAN_ENUM_TODO,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CountKanbanProgressByEmployeeID] error counting to do: ", err)
+		return nil, err
+	}
+
+	inProgress, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
 		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
 		"kanban":      entity.EPMLOYEE_TASK_KANBAN_ENUM_IN_PROGRESS,
 	})
 	if err != nil {
@@ -2448,8 +2990,9 @@ func (uc *EmployeeTaskUseCase) CountKanbanProgressByEmployeeID(employeeID uuid.U
 		return nil, err
 	}
 
-	needReview, err := uc.Repository.CountByKeys(map[string]interface{}{
+	needReview, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
 		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
 		"kanban":      entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW,
 	})
 	if err != nil {
@@ -2457,8 +3000,9 @@ func (uc *EmployeeTaskUseCase) CountKanbanProgressByEmployeeID(employeeID uuid.U
 		return nil, err
 	}
 
-	completed, err := uc.Repository.CountByKeys(map[string]interface{}{
+	completed, err := uc.Repository.CountBySourceAndKeys(source, map[string]interface{}{
 		"employee_id": employeeID,
+		"status":      entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
 		"kanban":      entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED,
 	})
 	if err != nil {
@@ -2468,6 +3012,7 @@ func (uc *EmployeeTaskUseCase) CountKanbanProgressByEmployeeID(employeeID uuid.U
 
 	return &response.EmployeeTaskProgressResponse{
 		EmployeeID: employeeID,
+		Source:     source,
 		TotalTask:  int(totalTask),
 		ToDo:       int(toDo),
 		InProgress: int(inProgress),
@@ -2491,7 +3036,9 @@ func (uc *EmployeeTaskUseCase) FindAllPaginatedByEmployeeID(employeeID uuid.UUID
 	return &responses, total, nil
 }
 
-func (uc *EmployeeTaskUseCase) FindByIDForResponse(id string) (*response.EmployeeTaskResponse, error) {
+// FindByIDForResponse finds an employee task with its survey answers. The answers to an
+// anonymous survey are only given to the employee of the task.
+func (uc *EmployeeTaskUseCase) FindByIDForResponse(id string, viewerEmployeeID uuid.UUID) (*response.EmployeeTaskResponse, error) {
 	// check if id is uuid or not
 	var modifiedId uuid.UUID
 	parsedId, err := uuid.Parse(id)
@@ -2523,8 +3070,14 @@ func (uc *EmployeeTaskUseCase) FindByIDForResponse(id string) (*response.Employe
 	if employeeTask == nil {
 		return nil, errors.New("employee task not found")
 	}
+	service.HideAnonymousEmployeeTaskResponses(employeeTask, viewerEmployeeID)
 
-	return uc.DTO.ConvertEntityToResponse(employeeTask), nil
+	resp := uc.DTO.ConvertEntityToResponse(employeeTask)
+	if employeeTask.SurveyTemplate != nil {
+		service.MarkHiddenSurveyQuestions(resp.SurveyTemplate, service.HiddenSurveyQuestions(employeeTask.SurveyTemplate.Questions, surveyTemplateAnswers(employeeTask.SurveyTemplate)))
+	}
+
+	return resp, nil
 }
 
 func (uc *EmployeeTaskUseCase) FindAllPaginatedSurvey(page, pageSize int, search string, sort map[string]interface{}) (*[]response.EmployeeTaskResponse, int64, error) {
@@ -2556,3 +3109,2564 @@ func (uc *EmployeeTaskUseCase) FindAllSurvey() (*[]response.EmployeeTaskResponse
 
 	return &responses, nil
 }
+
+// calculateDueDate counts the template due duration in working days from the start date.
+func (uc *EmployeeTaskUseCase) calculateDueDate(organizationID *uuid.UUID, startDate time.Time, dueDuration *int) (time.Time, error) {
+	if dueDuration == nil {
+		return startDate, nil
+	}
+
+	return uc.CalendarService.AddWorkingDays(organizationID, startDate, *dueDuration)
+}
+
+// onboardingBackfillChange is a previewed backfill change together with what is needed to apply it.
+type onboardingBackfillChange struct {
+	response.OnboardingBackfillChangeResponse
+	planItem           *recruitmentPlanItem
+	employeeTask       *entity.EmployeeTask
+	templateTask       entity.TemplateTask
+	templateChecklist  *entity.TemplateTaskChecklist
+	employeeChecklist  *entity.EmployeeTaskChecklist
+	endDate            time.Time
+	checklistDueDate   *time.Time
+	missingAttachments []entity.TemplateTaskAttachment
+}
+
+type onboardingBackfillEmployee struct {
+	Hiring  entity.EmployeeHiring
+	Request *request.CreateEmployeeTasksForRecruitment
+	Plan    *recruitmentPlan
+	// Profile gives the organization whose calendar the due dates are counted on
+	Profile  *service.HireProfile
+	Changes  []onboardingBackfillChange
+	Warnings []string
+}
+
+// diffOnboardingBackfill compares the active template tasks of an organization type with
+// the tasks of employees still onboarding. Missing tasks are planned the same way as
+// CreateEmployeeTasksForRecruitment, counting due dates from the hiring date.
+func (uc *EmployeeTaskUseCase) diffOnboardingBackfill(organizationType string, templateTaskIDs, employeeIDs []string) ([]onboardingBackfillEmployee, error) {
+	templateTasks, err := uc.TemplateTaskRepository.FindAllByKeysWithDetails(map[string]interface{}{
+		"organization_type": organizationType,
+		"status":            entity.TEMPLATE_TASK_STATUS_ENUM_ACTIVE,
+		"source":            entity.TASK_SOURCE_ONBOARDING,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error finding all template tasks: ", err)
+		return nil, err
+	}
+
+	selectedTemplateTasks := make(map[uuid.UUID]entity.TemplateTask)
+	for _, templateTask := range *templateTasks {
+		selectedTemplateTasks[templateTask.ID] = templateTask
+	}
+	if len(templateTaskIDs) > 0 {
+		filteredTemplateTasks := make(map[uuid.UUID]entity.TemplateTask)
+		for _, templateTaskID := range templateTaskIDs {
+			parsedTemplateTaskID, err := uuid.Parse(templateTaskID)
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error parsing template task id: ", err)
+				return nil, err
+			}
+			templateTask, ok := selectedTemplateTasks[parsedTemplateTaskID]
+			if !ok {
+				return nil, errors.New("template task " + templateTaskID + " is not an active template task of organization type " + organizationType)
+			}
+			filteredTemplateTasks[parsedTemplateTaskID] = templateTask
+		}
+		selectedTemplateTasks = filteredTemplateTasks
+	}
+
+	parsedEmployeeIDs := make([]uuid.UUID, 0, len(employeeIDs))
+	for _, employeeID := range employeeIDs {
+		parsedEmployeeID, err := uuid.Parse(employeeID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error parsing employee id: ", err)
+			return nil, err
+		}
+		parsedEmployeeIDs = append(parsedEmployeeIDs, parsedEmployeeID)
+	}
+
+	employeeHirings, err := uc.EmployeeHiringRepository.FindAllInFlightByOrganizationType(organizationType, parsedEmployeeIDs)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error finding in-flight employee hirings: ", err)
+		return nil, err
+	}
+
+	employees := make([]onboardingBackfillEmployee, 0)
+	for _, employeeHiring := range *employeeHirings {
+		employee := onboardingBackfillEmployee{
+			Hiring: employeeHiring,
+			Request: &request.CreateEmployeeTasksForRecruitment{
+				EmployeeID:       employeeHiring.EmployeeID.String(),
+				JoinedDate:       employeeHiring.HiringDate.Format("2006-01-02"),
+				OrganizationType: organizationType,
+			},
+			Changes:  make([]onboardingBackfillChange, 0),
+			Warnings: make([]string, 0),
+		}
+
+		// backfill has no hire attributes of its own, they are looked up from the employee's job
+		plan, err := uc.planRecruitmentTasks(employee.Request, entity.TASK_SOURCE_ONBOARDING, true)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error planning employee tasks: ", err)
+			employee.Warnings = append(employee.Warnings, "onboarding plan could not be built: "+err.Error())
+			employees = append(employees, employee)
+			continue
+		}
+		employee.Plan = plan
+		employee.Profile = plan.Profile
+		for _, task := range plan.UndecidedTasks {
+			if _, ok := selectedTemplateTasks[task.TemplateTaskID]; ok {
+				employee.Warnings = append(employee.Warnings, "template task "+task.Name+" cannot be decided: "+task.Reason)
+			}
+		}
+
+		for i := range plan.Items {
+			item := &plan.Items[i]
+			if _, ok := selectedTemplateTasks[item.TemplateTask.ID]; !ok {
+				continue
+			}
+			employee.Changes = append(employee.Changes, onboardingBackfillChange{
+				OnboardingBackfillChangeResponse: response.OnboardingBackfillChangeResponse{
+					ChangeType:       entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_TASK,
+					TemplateTaskID:   item.TemplateTask.ID,
+					TemplateTaskName: item.TemplateTask.Name,
+					StartDate:        plan.JoinedDate.Format("2006-01-02"),
+					EndDate:          item.EndDate.Format("2006-01-02"),
+				},
+				planItem:     item,
+				templateTask: item.TemplateTask,
+			})
+		}
+
+		employeeTasks, err := uc.Repository.FindAllByEmployeeIDAndSource(employeeHiring.EmployeeID, entity.TASK_SOURCE_ONBOARDING)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error finding employee tasks: ", err)
+			return nil, err
+		}
+		for i := range *employeeTasks {
+			employeeTask := &(*employeeTasks)[i]
+			if employeeTask.TemplateTaskID == nil || employeeTask.Status != entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE || employeeTask.Kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+				continue
+			}
+			templateTask, ok := selectedTemplateTasks[*employeeTask.TemplateTaskID]
+			if !ok {
+				continue
+			}
+			approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(employeeTask.ID)
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error finding employee task approvals: ", err)
+				return nil, err
+			}
+			changes, err := diffEmployeeTaskWithTemplate(employeeTask, *approvals, templateTask, uc.onboardingDueDate(plan.Profile.OrganizationID))
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.diffOnboardingBackfill] error comparing employee task with template: ", err)
+				employee.Warnings = append(employee.Warnings, "employee task "+employeeTask.Name+" could not be compared with its template: "+err.Error())
+				continue
+			}
+			employee.Changes = append(employee.Changes, changes...)
+		}
+
+		if len(employee.Changes) > 0 || len(employee.Warnings) > 0 {
+			employees = append(employees, employee)
+		}
+	}
+
+	return employees, nil
+}
+
+// onboardingDueDateFunc counts a due duration from a start date, see calculateDueDate.
+type onboardingDueDateFunc func(startDate time.Time, dueDuration *int) (time.Time, error)
+
+// onboardingDueDate counts due dates on the calendar of the organization.
+func (uc *EmployeeTaskUseCase) onboardingDueDate(organizationID *uuid.UUID) onboardingDueDateFunc {
+	return func(startDate time.Time, dueDuration *int) (time.Time, error) {
+		return uc.calculateDueDate(organizationID, startDate, dueDuration)
+	}
+}
+
+// diffEmployeeTaskWithTemplate compares an unfinished employee task and its approvals with the
+// template it was generated from, covering every field a template task version records. Checklists
+// and attachments the employee task has on top of the template are left alone.
+func diffEmployeeTaskWithTemplate(employeeTask *entity.EmployeeTask, approvals []entity.EmployeeTaskApproval, templateTask entity.TemplateTask, dueDate onboardingDueDateFunc) ([]onboardingBackfillChange, error) {
+	changes := make([]onboardingBackfillChange, 0)
+
+	endDate, err := dueDate(employeeTask.StartDate, templateTask.DueDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]response.OnboardingBackfillFieldChangeResponse, 0)
+	addField := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			fields = append(fields, response.OnboardingBackfillFieldChangeResponse{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	addField("name", employeeTask.Name, templateTask.Name)
+	addField("description", employeeTask.Description, templateTask.Description)
+	addField("priority", string(employeeTask.Priority), string(templateTask.Priority))
+	addField("cover_path", formatOptionalString(employeeTask.CoverPath), formatOptionalString(templateTask.CoverPath))
+	addField("end_date", employeeTask.EndDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	addField("kind", string(employeeTask.Kind), string(templateTask.Kind))
+	addField("passing_score", formatOptionalInt(employeeTask.PassingScore), formatOptionalInt(templateTask.PassingScore))
+	if !isSameSurvey(employeeTask.SurveyTemplateID, employeeTask.SurveyTemplate, templateTask.SurveyTemplateID, templateTask.SurveyTemplate) {
+		addField("survey_template_id", formatOptionalUUID(employeeTask.SurveyTemplateID), formatOptionalUUID(templateTask.SurveyTemplateID))
+	}
+
+	existingAttachments := make(map[string]bool)
+	attachmentPaths := make([]string, 0, len(employeeTask.EmployeeTaskAttachments))
+	for _, attachment := range employeeTask.EmployeeTaskAttachments {
+		existingAttachments[attachment.Path] = true
+		attachmentPaths = append(attachmentPaths, attachment.Path)
+	}
+	missingAttachments := make([]entity.TemplateTaskAttachment, 0)
+	newAttachmentPaths := append([]string{}, attachmentPaths...)
+	for _, attachment := range templateTask.TemplateTaskAttachments {
+		if existingAttachments[attachment.Path] {
+			continue
+		}
+		existingAttachments[attachment.Path] = true
+		missingAttachments = append(missingAttachments, attachment)
+		newAttachmentPaths = append(newAttachmentPaths, attachment.Path)
+	}
+	addField("attachments", strings.Join(attachmentPaths, ", "), strings.Join(newAttachmentPaths, ", "))
+
+	_, currentApprovals := service.CurrentApprovalRound(approvals)
+	addField("approval_steps", formatEmployeeTaskApprovals(currentApprovals), formatTemplateTaskApprovalSteps(templateTask.TemplateTaskApprovalSteps))
+
+	if len(fields) > 0 {
+		changes = append(changes, onboardingBackfillChange{
+			OnboardingBackfillChangeResponse: response.OnboardingBackfillChangeResponse{
+				ChangeType:       entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK,
+				TemplateTaskID:   templateTask.ID,
+				TemplateTaskName: templateTask.Name,
+				EmployeeTaskID:   &employeeTask.ID,
+				EndDate:          endDate.Format("2006-01-02"),
+				Fields:           fields,
+			},
+			employeeTask:       employeeTask,
+			templateTask:       templateTask,
+			endDate:            endDate,
+			missingAttachments: missingAttachments,
+		})
+	}
+
+	existingChecklists := make(map[string]*entity.EmployeeTaskChecklist)
+	for i := range employeeTask.EmployeeTaskChecklists {
+		existingChecklists[employeeTask.EmployeeTaskChecklists[i].Name] = &employeeTask.EmployeeTaskChecklists[i]
+	}
+	seenChecklists := make(map[string]bool)
+	for _, checklist := range templateTask.TemplateTaskChecklists {
+		if seenChecklists[checklist.Name] {
+			continue
+		}
+		seenChecklists[checklist.Name] = true
+		checklist := checklist
+
+		employeeChecklist, ok := existingChecklists[checklist.Name]
+		if !ok {
+			changes = append(changes, onboardingBackfillChange{
+				OnboardingBackfillChangeResponse: response.OnboardingBackfillChangeResponse{
+					ChangeType:       entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_CHECKLIST,
+					TemplateTaskID:   templateTask.ID,
+					TemplateTaskName: templateTask.Name,
+					EmployeeTaskID:   &employeeTask.ID,
+					ChecklistName:    checklist.Name,
+				},
+				employeeTask:      employeeTask,
+				templateTask:      templateTask,
+				templateChecklist: &checklist,
+			})
+			continue
+		}
+
+		var checklistDueDate *time.Time
+		if checklist.DueOffsetDays != nil {
+			date, err := dueDate(employeeTask.StartDate, checklist.DueOffsetDays)
+			if err != nil {
+				return nil, err
+			}
+			checklistDueDate = &date
+		}
+		isRequired := checklist.IsRequired
+		if isRequired == "" {
+			isRequired = "NO"
+		}
+
+		checklistFields := make([]response.OnboardingBackfillFieldChangeResponse, 0)
+		addChecklistField := func(field, oldValue, newValue string) {
+			if oldValue != newValue {
+				checklistFields = append(checklistFields, response.OnboardingBackfillFieldChangeResponse{Field: field, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+		addChecklistField("sort_order", strconv.Itoa(employeeChecklist.SortOrder), strconv.Itoa(checklist.SortOrder))
+		addChecklistField("assignee_id", formatOptionalUUID(employeeChecklist.AssigneeID), formatOptionalUUID(checklist.AssigneeID))
+		addChecklistField("is_required", employeeChecklist.IsRequired, isRequired)
+		addChecklistField("due_date", formatOptionalDate(employeeChecklist.DueDate), formatOptionalDate(checklistDueDate))
+		if len(checklistFields) == 0 {
+			continue
+		}
+
+		changes = append(changes, onboardingBackfillChange{
+			OnboardingBackfillChangeResponse: response.OnboardingBackfillChangeResponse{
+				ChangeType:       entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_CHECKLIST,
+				TemplateTaskID:   templateTask.ID,
+				TemplateTaskName: templateTask.Name,
+				EmployeeTaskID:   &employeeTask.ID,
+				ChecklistName:    checklist.Name,
+				Fields:           checklistFields,
+			},
+			employeeTask:      employeeTask,
+			templateTask:      templateTask,
+			templateChecklist: &checklist,
+			employeeChecklist: employeeChecklist,
+			checklistDueDate:  checklistDueDate,
+		})
+	}
+
+	return changes, nil
+}
+
+// isSameSurvey tells whether an employee task answers the survey of its template. Any version of
+// the survey counts, tasks keep the version they were given.
+func isSameSurvey(surveyTemplateID *uuid.UUID, surveyTemplate *entity.SurveyTemplate, templateSurveyTemplateID *uuid.UUID, templateSurveyTemplate *entity.SurveyTemplate) bool {
+	if surveyTemplateID == nil || templateSurveyTemplateID == nil {
+		return surveyTemplateID == nil && templateSurveyTemplateID == nil
+	}
+	if *surveyTemplateID == *templateSurveyTemplateID {
+		return true
+	}
+	if surveyTemplate == nil || templateSurveyTemplate == nil {
+		return false
+	}
+
+	return surveyTemplate.SurveyNumber == templateSurveyTemplate.SurveyNumber
+}
+
+// formatEmployeeTaskApprovals and formatTemplateTaskApprovalSteps describe an approval chain the
+// same way so that the two can be compared. Only EMPLOYEE steps name a fixed approver.
+func formatEmployeeTaskApprovals(approvals []entity.EmployeeTaskApproval) string {
+	steps := make([]string, 0, len(approvals))
+	for _, approval := range approvals {
+		step := strconv.Itoa(approval.StepOrder) + ". " + string(approval.ApproverType)
+		if approval.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+			step += " " + formatOptionalUUID(approval.ApproverID)
+		}
+		steps = append(steps, step)
+	}
+
+	return strings.Join(steps, ", ")
+}
+
+func formatTemplateTaskApprovalSteps(approvalSteps []entity.TemplateTaskApprovalStep) string {
+	steps := make([]string, 0, len(approvalSteps))
+	for _, approvalStep := range approvalSteps {
+		step := strconv.Itoa(approvalStep.StepOrder) + ". " + string(approvalStep.ApproverType)
+		if approvalStep.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+			step += " " + formatOptionalUUID(approvalStep.ApproverEmployeeID)
+		}
+		steps = append(steps, step)
+	}
+
+	return strings.Join(steps, ", ")
+}
+
+func formatOptionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatOptionalInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func formatOptionalUUID(value *uuid.UUID) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+func formatOptionalDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format("2006-01-02")
+}
+
+func (uc *EmployeeTaskUseCase) PreviewOnboardingBackfill(req *request.PreviewOnboardingBackfillRequest) (*response.OnboardingBackfillPreviewResponse, error) {
+	employees, err := uc.diffOnboardingBackfill(req.OrganizationType, req.TemplateTaskIDs, req.EmployeeIDs)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PreviewOnboardingBackfill] " + err.Error())
+		return nil, err
+	}
+
+	res := &response.OnboardingBackfillPreviewResponse{
+		OrganizationType: req.OrganizationType,
+		Employees:        make([]response.OnboardingBackfillEmployeeResponse, 0, len(employees)),
+	}
+	for _, employee := range employees {
+		changes := make([]response.OnboardingBackfillChangeResponse, 0, len(employee.Changes))
+		for _, change := range employee.Changes {
+			changes = append(changes, change.OnboardingBackfillChangeResponse)
+		}
+
+		res.Employees = append(res.Employees, response.OnboardingBackfillEmployeeResponse{
+			EmployeeID: employee.Hiring.EmployeeID,
+			HiringDate: employee.Hiring.HiringDate.Format("2006-01-02"),
+			Changes:    changes,
+			Warnings:   employee.Warnings,
+		})
+		res.TotalChanges += len(changes)
+	}
+	res.TotalEmployees = len(res.Employees)
+
+	return res, nil
+}
+
+func isOnboardingBackfillChangeSelected(selections []request.OnboardingBackfillSelectionRequest, employeeID uuid.UUID, change onboardingBackfillChange) bool {
+	if len(selections) == 0 {
+		return true
+	}
+
+	for _, selection := range selections {
+		if !strings.EqualFold(selection.EmployeeID, employeeID.String()) || !strings.EqualFold(selection.TemplateTaskID, change.TemplateTaskID.String()) {
+			continue
+		}
+		if selection.ChangeType != "" && selection.ChangeType != string(change.ChangeType) {
+			continue
+		}
+		if selection.ChecklistName != "" && selection.ChecklistName != change.ChecklistName {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+func onboardingBackfillItemKey(employeeID, templateTaskID uuid.UUID, changeType entity.OnboardingBackfillChangeTypeEnum, checklistName string) string {
+	return employeeID.String() + "|" + templateTaskID.String() + "|" + string(changeType) + "|" + checklistName
+}
+
+// ApplyOnboardingBackfill records the selected changes as a backfill and applies them
+// in the background. Progress is read back with FindOnboardingBackfillByID.
+func (uc *EmployeeTaskUseCase) ApplyOnboardingBackfill(req *request.ApplyOnboardingBackfillRequest) (*response.OnboardingBackfillResponse, error) {
+	employees, err := uc.diffOnboardingBackfill(req.OrganizationType, req.TemplateTaskIDs, req.EmployeeIDs)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ApplyOnboardingBackfill] " + err.Error())
+		return nil, err
+	}
+
+	res, err := uc.startOnboardingBackfill(req.OrganizationType, nil, employees, req.Selections)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ApplyOnboardingBackfill] " + err.Error())
+		return nil, err
+	}
+	if res == nil {
+		return nil, errors.New("no changes to backfill")
+	}
+
+	return res, nil
+}
+
+// startOnboardingBackfill stores the selected changes of the employees as a backfill and
+// starts applying them. It returns nil when none of the changes are selected.
+func (uc *EmployeeTaskUseCase) startOnboardingBackfill(organizationType string, templateTaskVersionID *uuid.UUID, employees []onboardingBackfillEmployee, selections []request.OnboardingBackfillSelectionRequest) (*response.OnboardingBackfillResponse, error) {
+	selectedEmployees := make([]onboardingBackfillEmployee, 0)
+	items := make([]entity.OnboardingBackfillItem, 0)
+	for _, employee := range employees {
+		changes := make([]onboardingBackfillChange, 0)
+		for _, change := range employee.Changes {
+			if !isOnboardingBackfillChangeSelected(selections, employee.Hiring.EmployeeID, change) {
+				continue
+			}
+			changes = append(changes, change)
+			items = append(items, entity.OnboardingBackfillItem{
+				EmployeeID:     employee.Hiring.EmployeeID,
+				TemplateTaskID: change.TemplateTaskID,
+				EmployeeTaskID: change.EmployeeTaskID,
+				ChangeType:     change.ChangeType,
+				ChecklistName:  change.ChecklistName,
+				Status:         entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_PENDING,
+			})
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		employee.Changes = changes
+		selectedEmployees = append(selectedEmployees, employee)
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	onboardingBackfill, err := uc.OnboardingBackfillRepository.CreateOnboardingBackfill(&entity.OnboardingBackfill{
+		OrganizationType:        organizationType,
+		TemplateTaskVersionID:   templateTaskVersionID,
+		Status:                  entity.ONBOARDING_BACKFILL_STATUS_ENUM_PENDING,
+		Total:                   len(items),
+		OnboardingBackfillItems: items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := uc.OnboardingBackfillDTO.ConvertEntityToResponse(onboardingBackfill)
+
+	go uc.runOnboardingBackfill(onboardingBackfill, selectedEmployees)
+
+	return res, nil
+}
+
+func (uc *EmployeeTaskUseCase) runOnboardingBackfill(onboardingBackfill *entity.OnboardingBackfill, employees []onboardingBackfillEmployee) {
+	items := make(map[string]*entity.OnboardingBackfillItem)
+	for i := range onboardingBackfill.OnboardingBackfillItems {
+		item := &onboardingBackfill.OnboardingBackfillItems[i]
+		items[onboardingBackfillItemKey(item.EmployeeID, item.TemplateTaskID, item.ChangeType, item.ChecklistName)] = item
+	}
+
+	startedAt := time.Now()
+	onboardingBackfill.Status = entity.ONBOARDING_BACKFILL_STATUS_ENUM_RUNNING
+	onboardingBackfill.StartedAt = &startedAt
+	if err := uc.OnboardingBackfillRepository.UpdateOnboardingBackfillProgress(onboardingBackfill); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.runOnboardingBackfill] " + err.Error())
+	}
+
+	for _, employee := range employees {
+		var midsuitErr error
+		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+			midsuitErr = uc.resolveRecruitmentMidsuitIDs(employee.Request)
+		}
+
+		// employee tasks are only linked to the propagated version once every change to them was applied,
+		// a skipped change leaves the task as it was
+		employeeTaskIDs := make([]uuid.UUID, 0)
+		unappliedEmployeeTaskIDs := make(map[uuid.UUID]bool)
+
+		for _, change := range employee.Changes {
+			item, ok := items[onboardingBackfillItemKey(employee.Hiring.EmployeeID, change.TemplateTaskID, change.ChangeType, change.ChecklistName)]
+			if !ok {
+				continue
+			}
+
+			if midsuitErr != nil {
+				item.Status = entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_FAILED
+				item.Message = midsuitErr.Error()
+			} else {
+				employeeTaskID, skipReason, err := uc.applyOnboardingBackfillChange(employee, change)
+				if employeeTaskID != nil {
+					item.EmployeeTaskID = employeeTaskID
+				}
+				switch {
+				case err != nil:
+					item.Status = entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_FAILED
+					item.Message = err.Error()
+				case skipReason != "":
+					item.Status = entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_SKIPPED
+					item.Message = skipReason
+				default:
+					item.Status = entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_APPLIED
+				}
+			}
+
+			if change.EmployeeTaskID != nil {
+				employeeTaskIDs = append(employeeTaskIDs, *change.EmployeeTaskID)
+				if item.Status != entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_APPLIED {
+					unappliedEmployeeTaskIDs[*change.EmployeeTaskID] = true
+				}
+			}
+
+			switch item.Status {
+			case entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_APPLIED:
+				onboardingBackfill.Succeeded++
+			case entity.ONBOARDING_BACKFILL_ITEM_STATUS_ENUM_SKIPPED:
+				onboardingBackfill.Skipped++
+			default:
+				onboardingBackfill.Failed++
+			}
+			onboardingBackfill.Processed++
+
+			if err := uc.OnboardingBackfillRepository.UpdateOnboardingBackfillItem(item); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.runOnboardingBackfill] " + err.Error())
+			}
+			if err := uc.OnboardingBackfillRepository.UpdateOnboardingBackfillProgress(onboardingBackfill); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.runOnboardingBackfill] " + err.Error())
+			}
+		}
+
+		if onboardingBackfill.TemplateTaskVersionID != nil {
+			linkedEmployeeTaskIDs := make([]uuid.UUID, 0, len(employeeTaskIDs))
+			for _, employeeTaskID := range employeeTaskIDs {
+				if !unappliedEmployeeTaskIDs[employeeTaskID] {
+					linkedEmployeeTaskIDs = append(linkedEmployeeTaskIDs, employeeTaskID)
+				}
+			}
+			if err := uc.Repository.UpdateTemplateTaskVersionByIDs(linkedEmployeeTaskIDs, *onboardingBackfill.TemplateTaskVersionID); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.runOnboardingBackfill] " + err.Error())
+			}
+		}
+	}
+
+	finishedAt := time.Now()
+	onboardingBackfill.Status = entity.ONBOARDING_BACKFILL_STATUS_ENUM_COMPLETED
+	if onboardingBackfill.Failed == onboardingBackfill.Total {
+		onboardingBackfill.Status = entity.ONBOARDING_BACKFILL_STATUS_ENUM_FAILED
+	}
+	onboardingBackfill.FinishedAt = &finishedAt
+	if err := uc.OnboardingBackfillRepository.UpdateOnboardingBackfillProgress(onboardingBackfill); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.runOnboardingBackfill] " + err.Error())
+	}
+}
+
+// applyOnboardingBackfillChange applies a single change. It returns a skip reason when
+// the change was already made since the preview.
+func (uc *EmployeeTaskUseCase) applyOnboardingBackfillChange(employee onboardingBackfillEmployee, change onboardingBackfillChange) (*uuid.UUID, string, error) {
+	switch change.ChangeType {
+	case entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_TASK:
+		keys := map[string]interface{}{
+			"employee_id":      employee.Hiring.EmployeeID,
+			"template_task_id": change.TemplateTaskID,
+		}
+		empTaskExist, err := uc.Repository.FindByKeys(keys)
+		if err != nil {
+			return nil, "", err
+		}
+		if empTaskExist != nil {
+			return &empTaskExist.ID, "already assigned to the employee", nil
+		}
+
+		if err := uc.createEmployeeTaskFromPlanItem(employee.Request, employee.Plan, *change.planItem); err != nil {
+			return nil, "", err
+		}
+
+		createdEmpTask, err := uc.Repository.FindByKeys(keys)
+		if err != nil || createdEmpTask == nil {
+			return nil, "", err
+		}
+		return &createdEmpTask.ID, "", nil
+	case entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_ADD_CHECKLIST:
+		checklistExist, err := uc.EmployeeTaskChecklistRepository.FindByKeys(map[string]interface{}{
+			"employee_task_id": change.employeeTask.ID,
+			"name":             change.ChecklistName,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if checklistExist != nil {
+			return &change.employeeTask.ID, "checklist already exists on the employee task", nil
+		}
+
+		var employeeTaskMidsuitID string
+		if change.employeeTask.MidsuitID != nil {
+			employeeTaskMidsuitID = *change.employeeTask.MidsuitID
+		}
+		if err := uc.createEmployeeTaskChecklistFromTemplate(employee.Request, employee.Profile.OrganizationID, change.employeeTask, employeeTaskMidsuitID, *change.templateChecklist); err != nil {
+			return nil, "", err
+		}
+		return &change.employeeTask.ID, "", nil
+	case entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_TASK:
+		employeeTask := change.employeeTask
+		changedFields := make(map[string]bool)
+		for _, field := range change.Fields {
+			changedFields[field.Field] = true
+		}
+
+		// an approval chain that was already acted on is not swapped underneath its approvers
+		round := 1
+		if changedFields["approval_steps"] {
+			approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(employeeTask.ID)
+			if err != nil {
+				return nil, "", err
+			}
+			currentRound, currentApprovals := service.CurrentApprovalRound(*approvals)
+			for _, approval := range currentApprovals {
+				if approval.Status != entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING {
+					return nil, "", errors.New("the approval of the employee task is already under way, the new approval steps were not applied")
+				}
+			}
+			if currentRound > 0 {
+				round = currentRound
+			}
+		}
+
+		surveyTemplateID := employeeTask.SurveyTemplateID
+		if changedFields["survey_template_id"] {
+			surveyTemplateID = nil
+			if change.templateTask.SurveyTemplateID != nil {
+				latestSurveyTemplateID, err := uc.latestSurveyTemplateID(*change.templateTask.SurveyTemplateID)
+				if err != nil {
+					return nil, "", err
+				}
+				surveyTemplateID = latestSurveyTemplateID
+			}
+		}
+
+		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" && employeeTask.MidsuitID != nil && *employeeTask.MidsuitID != "" {
+			midsuitIDInt, err := strconv.Atoi(*employeeTask.MidsuitID)
+			if err != nil {
+				return nil, "", err
+			}
+
+			authResp, err := uc.MidsuitService.AuthOneStep()
+			if err != nil {
+				return nil, "", err
+			}
+
+			midsuitPayload := uc.recruitmentMidsuitTaskPayload(employee.Request, employeeTask.Source, change.templateTask.Name, employeeTask.StartDate, change.endDate)
+			if _, err := uc.MidsuitService.SyncUpdateEmployeeTaskMidsuit(midsuitIDInt, *midsuitPayload, authResp.Token); err != nil {
+				return nil, "", err
+			}
+		}
+
+		// the field names of the diff are the column names, attachments and approvals have their own tables
+		columns := make([]string, 0, len(change.Fields))
+		for _, field := range change.Fields {
+			if field.Field != "attachments" && field.Field != "approval_steps" {
+				columns = append(columns, field.Field)
+			}
+		}
+		if len(columns) > 0 {
+			err := uc.Repository.UpdateEmployeeTaskColumns(&entity.EmployeeTask{
+				ID:               employeeTask.ID,
+				Name:             change.templateTask.Name,
+				Description:      change.templateTask.Description,
+				Priority:         entity.EmployeeTaskPriorityEnum(change.templateTask.Priority),
+				CoverPath:        change.templateTask.CoverPath,
+				EndDate:          change.endDate,
+				Kind:             change.templateTask.Kind,
+				PassingScore:     change.templateTask.PassingScore,
+				SurveyTemplateID: surveyTemplateID,
+			}, columns)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		if changedFields["approval_steps"] {
+			if err := uc.EmployeeTaskApprovalRepository.DeletePendingByEmployeeTaskID(employeeTask.ID); err != nil {
+				return nil, "", err
+			}
+			if _, err := uc.EmployeeTaskApprovalService.StartApprovalRound(employeeTask.ID, employee.Hiring.EmployeeID, round, change.templateTask.TemplateTaskApprovalSteps); err != nil {
+				return nil, "", err
+			}
+		}
+
+		var employeeTaskMidsuitID string
+		if employeeTask.MidsuitID != nil {
+			employeeTaskMidsuitID = *employeeTask.MidsuitID
+		}
+		for _, attachment := range change.missingAttachments {
+			if err := uc.createEmployeeTaskAttachmentFromTemplate(employeeTask, employeeTaskMidsuitID, attachment); err != nil {
+				return nil, "", err
+			}
+		}
+		return &employeeTask.ID, "", nil
+	case entity.ONBOARDING_BACKFILL_CHANGE_TYPE_ENUM_UPDATE_CHECKLIST:
+		isRequired := change.templateChecklist.IsRequired
+		if isRequired == "" {
+			isRequired = "NO"
+		}
+		_, err := uc.EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns(&entity.EmployeeTaskChecklist{
+			ID:         change.employeeChecklist.ID,
+			SortOrder:  change.templateChecklist.SortOrder,
+			AssigneeID: change.templateChecklist.AssigneeID,
+			IsRequired: isRequired,
+			DueDate:    change.checklistDueDate,
+		}, []string{"sort_order", "assignee_id", "is_required", "due_date"})
+		if err != nil {
+			return nil, "", err
+		}
+		return &change.employeeTask.ID, "", nil
+	default:
+		return nil, "", errors.New("unknown change type " + string(change.ChangeType))
+	}
+}
+
+// resolveRecruitmentMidsuitIDs looks up the Midsuit ids of the employee's current job.
+// Recruitment messages send these along, backfills have to fetch them.
+func (uc *EmployeeTaskUseCase) resolveRecruitmentMidsuitIDs(req *request.CreateEmployeeTasksForRecruitment) error {
+	empResp, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: req.EmployeeID,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.resolveRecruitmentMidsuitIDs] error sending find employee by id message: ", err)
+		return err
+	}
+	if empResp == nil {
+		return errors.New("employee not found")
+	}
+	req.EmployeeMidsuitID = empResp.MidsuitID
+
+	orgResp, err := uc.OrganizationMessage.SendFindOrganizationByIDMessage(request.SendFindOrganizationByIDMessageRequest{
+		ID: empResp.OrganizationID.String(),
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.resolveRecruitmentMidsuitIDs] error sending find organization by id message: ", err)
+		return err
+	}
+	if orgResp != nil {
+		req.OrgMidsuitID = orgResp.MidsuitID
+	}
+
+	if jobID, ok := empResp.EmployeeJob["job_id"].(string); ok && jobID != "" {
+		jobResp, err := uc.JobPlafonMessage.SendFindJobByIDMessage(request.SendFindJobByIDMessageRequest{
+			ID: jobID,
+		})
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.resolveRecruitmentMidsuitIDs] error sending find job by id message: ", err)
+			return err
+		}
+		req.JobMidsuitID = jobResp.MidsuitID
+	}
+
+	if jobLevelID, ok := empResp.EmployeeJob["job_level_id"].(string); ok && jobLevelID != "" {
+		jobLevelResp, err := uc.JobPlafonMessage.SendFindJobLevelByIDMessage(request.SendFindJobLevelByIDMessageRequest{
+			ID: jobLevelID,
+		})
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.resolveRecruitmentMidsuitIDs] error sending find job level by id message: ", err)
+			return err
+		}
+		req.JobLevelMidsuitID = jobLevelResp.MidsuitID
+	}
+
+	if orgStructureID, ok := empResp.EmployeeJob["organization_structure_id"].(string); ok && orgStructureID != "" {
+		orgStructureResp, err := uc.OrganizationMessage.SendFindOrganizationStructureByIDMessage(request.SendFindOrganizationStructureByIDMessageRequest{
+			ID: orgStructureID,
+		})
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.resolveRecruitmentMidsuitIDs] error sending find organization structure by id message: ", err)
+			return err
+		}
+		if orgStructureResp != nil {
+			req.OrgStructureMidsuitID = orgStructureResp.MidsuitID
+		}
+	}
+
+	return nil
+}
+
+func (uc *EmployeeTaskUseCase) recruitmentMidsuitTaskPayload(req *request.CreateEmployeeTasksForRecruitment, source, name string, startDate, endDate time.Time) *request.SyncEmployeeTaskMidsuitRequest {
+	toInt := func(field, value string) int {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.recruitmentMidsuitTaskPayload] error converting "+field+" to int: ", err)
+			return 0
+		}
+		return id
+	}
+
+	return &request.SyncEmployeeTaskMidsuitRequest{
+		AdOrgId: request.AdOrgId{
+			ID: toInt("org_midsuit_id", req.OrgMidsuitID),
+		},
+		Name:      name,
+		Category:  uc.midsuitTaskCategory(source),
+		StartDate: startDate.String(),
+		EndDate:   endDate.String(),
+		HCEmployeeID: request.HcEmployeeId{
+			ID: toInt("employee_midsuit_id", req.EmployeeMidsuitID),
+		},
+		HCJobID: request.HcJobId{
+			ID: toInt("job_midsuit_id", req.JobMidsuitID),
+		},
+		HCJobLevelID: request.HcJobLevelId{
+			ID: toInt("job_level_midsuit_id", req.JobLevelMidsuitID),
+		},
+		HCOrgID: request.HcOrgId{
+			ID: toInt("org_structure_midsuit_id", req.OrgStructureMidsuitID),
+		},
+	}
+}
+
+func (uc *EmployeeTaskUseCase) FindOnboardingBackfillByID(id uuid.UUID) (*response.OnboardingBackfillResponse, error) {
+	onboardingBackfill, err := uc.OnboardingBackfillRepository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.FindOnboardingBackfillByID] " + err.Error())
+		return nil, err
+	}
+	if onboardingBackfill == nil {
+		return nil, errors.New("onboarding backfill not found")
+	}
+
+	return uc.OnboardingBackfillDTO.ConvertEntityToResponse(onboardingBackfill), nil
+}
+
+func (uc *EmployeeTaskUseCase) FindAllOnboardingBackfillsPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.OnboardingBackfillResponse, int64, error) {
+	onboardingBackfills, total, err := uc.OnboardingBackfillRepository.FindAllPaginated(page, pageSize, sort)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.FindAllOnboardingBackfillsPaginated] " + err.Error())
+		return nil, 0, err
+	}
+
+	onboardingBackfillResponses := make([]response.OnboardingBackfillResponse, 0)
+	for _, onboardingBackfill := range *onboardingBackfills {
+		onboardingBackfillResponses = append(onboardingBackfillResponses, *uc.OnboardingBackfillDTO.ConvertEntityToResponse(&onboardingBackfill))
+	}
+
+	return &onboardingBackfillResponses, total, nil
+}
+
+// PropagateTemplateTaskVersion brings the open employee tasks of a template task in line with
+// the given version. Tasks that already match are linked right away, the others go through an
+// onboarding backfill and are linked once all of their changes are applied. It returns nil when
+// there is nothing to backfill.
+func (uc *EmployeeTaskUseCase) PropagateTemplateTaskVersion(templateTaskID, templateTaskVersionID uuid.UUID) (*response.OnboardingBackfillResponse, error) {
+	templateTask, err := uc.TemplateTaskRepository.FindByID(templateTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] " + err.Error())
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, errors.New("template task not found")
+	}
+
+	employeeTasks, err := uc.Repository.FindAllOpenByTemplateTaskID(templateTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] " + err.Error())
+		return nil, err
+	}
+
+	// tasks that could not be compared keep the version they are linked to
+	unchangedEmployeeTaskIDs := make([]uuid.UUID, 0)
+	employees := make([]onboardingBackfillEmployee, 0)
+	employeeIndexes := make(map[uuid.UUID]int)
+	profiles := make(map[uuid.UUID]*service.HireProfile)
+	for i := range *employeeTasks {
+		employeeTask := &(*employeeTasks)[i]
+		if employeeTask.EmployeeID == nil {
+			continue
+		}
+
+		profile, ok := profiles[*employeeTask.EmployeeID]
+		if !ok {
+			profile, err = uc.TemplateTaskRuleService.ResolveHireProfile(&request.CreateEmployeeTasksForRecruitment{
+				EmployeeID:       employeeTask.EmployeeID.String(),
+				OrganizationType: templateTask.OrganizationType,
+			})
+			if err != nil {
+				uc.Log.Warnf("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] error resolving employee %s: %s", employeeTask.EmployeeID, err.Error())
+				continue
+			}
+			profiles[*employeeTask.EmployeeID] = profile
+		}
+
+		approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(employeeTask.ID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] " + err.Error())
+			return nil, err
+		}
+		changes, err := diffEmployeeTaskWithTemplate(employeeTask, *approvals, *templateTask, uc.onboardingDueDate(profile.OrganizationID))
+		if err != nil {
+			uc.Log.Warnf("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] error comparing employee task %s with its template: %s", employeeTask.ID, err.Error())
+			continue
+		}
+		if len(changes) == 0 {
+			unchangedEmployeeTaskIDs = append(unchangedEmployeeTaskIDs, employeeTask.ID)
+			continue
+		}
+
+		index, ok := employeeIndexes[*employeeTask.EmployeeID]
+		if !ok {
+			employees = append(employees, onboardingBackfillEmployee{
+				Hiring: entity.EmployeeHiring{
+					EmployeeID: *employeeTask.EmployeeID,
+				},
+				Request: &request.CreateEmployeeTasksForRecruitment{
+					EmployeeID:       employeeTask.EmployeeID.String(),
+					OrganizationType: templateTask.OrganizationType,
+				},
+				Profile:  profile,
+				Changes:  make([]onboardingBackfillChange, 0),
+				Warnings: make([]string, 0),
+			})
+			index = len(employees) - 1
+			employeeIndexes[*employeeTask.EmployeeID] = index
+		}
+		employees[index].Changes = append(employees[index].Changes, changes...)
+	}
+
+	if err := uc.Repository.UpdateTemplateTaskVersionByIDs(unchangedEmployeeTaskIDs, templateTaskVersionID); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] " + err.Error())
+		return nil, err
+	}
+
+	res, err := uc.startOnboardingBackfill(templateTask.OrganizationType, &templateTaskVersionID, employees, nil)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PropagateTemplateTaskVersion] " + err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+const (
+	employeeTaskBulkItemStatusSuccess = "SUCCESS"
+	employeeTaskBulkItemStatusFailed  = "FAILED"
+	employeeTaskBulkItemStatusSkipped = "SKIPPED"
+)
+
+// bulkEmployeeTaskResult collects the per-item outcome of a bulk operation. Items fail
+// independently, one bad task does not stop the rest.
+type bulkEmployeeTaskResult struct {
+	res *response.BulkEmployeeTaskResponse
+}
+
+func newBulkEmployeeTaskResult(total int) *bulkEmployeeTaskResult {
+	return &bulkEmployeeTaskResult{
+		res: &response.BulkEmployeeTaskResponse{
+			Total: total,
+			Items: make([]response.BulkEmployeeTaskItemResponse, 0, total),
+		},
+	}
+}
+
+func (r *bulkEmployeeTaskResult) succeed(id string, employeeTask *response.EmployeeTaskResponse) {
+	r.res.Succeeded++
+	r.res.Items = append(r.res.Items, response.BulkEmployeeTaskItemResponse{
+		ID:           id,
+		Status:       employeeTaskBulkItemStatusSuccess,
+		EmployeeTask: employeeTask,
+	})
+}
+
+func (r *bulkEmployeeTaskResult) fail(id string, err error) {
+	r.res.Failed++
+	r.res.Items = append(r.res.Items, response.BulkEmployeeTaskItemResponse{
+		ID:      id,
+		Status:  employeeTaskBulkItemStatusFailed,
+		Message: err.Error(),
+	})
+}
+
+func (r *bulkEmployeeTaskResult) skip(id string, message string) {
+	r.res.Skipped++
+	r.res.Items = append(r.res.Items, response.BulkEmployeeTaskItemResponse{
+		ID:      id,
+		Status:  employeeTaskBulkItemStatusSkipped,
+		Message: message,
+	})
+}
+
+// updateRequestFromEmployeeTask builds an update request that keeps the employee task as it
+// is, so bulk operations can change a few fields and still go through UpdateEmployeeTask,
+// including its Midsuit sync.
+func updateRequestFromEmployeeTask(employeeTask *entity.EmployeeTask) *request.UpdateEmployeeTaskRequest {
+	id := employeeTask.ID.String()
+	req := &request.UpdateEmployeeTaskRequest{
+		ID:                     &id,
+		CoverPath:              employeeTask.CoverPath,
+		Name:                   employeeTask.Name,
+		Priority:               string(employeeTask.Priority),
+		Description:            employeeTask.Description,
+		StartDate:              employeeTask.StartDate.Format("2006-01-02"),
+		EndDate:                employeeTask.EndDate.Format("2006-01-02"),
+		IsDone:                 employeeTask.IsDone,
+		ProofPath:              employeeTask.Proof,
+		Status:                 string(employeeTask.Status),
+		Kanban:                 string(employeeTask.Kanban),
+		Notes:                  employeeTask.Notes,
+		Kind:                   string(employeeTask.Kind),
+		PassingScore:           employeeTask.PassingScore,
+		EmployeeTaskChecklists: make([]request.EmployeeTaskChecklistRequest, 0, len(employeeTask.EmployeeTaskChecklists)),
+	}
+	if employeeTask.EmployeeID != nil {
+		employeeID := employeeTask.EmployeeID.String()
+		req.EmployeeID = &employeeID
+	}
+	if employeeTask.TemplateTaskID != nil {
+		templateTaskID := employeeTask.TemplateTaskID.String()
+		req.TemplateTaskID = &templateTaskID
+	}
+	if employeeTask.SurveyTemplateID != nil {
+		surveyTemplateID := employeeTask.SurveyTemplateID.String()
+		req.SurveyTemplateID = &surveyTemplateID
+	}
+	if employeeTask.VerifiedBy != nil {
+		verifiedBy := employeeTask.VerifiedBy.String()
+		req.VerifiedBy = &verifiedBy
+	}
+
+	for _, checklist := range employeeTask.EmployeeTaskChecklists {
+		checklistID := checklist.ID.String()
+		isChecked := checklist.IsChecked
+		checklistReq := request.EmployeeTaskChecklistRequest{
+			ID:        &checklistID,
+			Name:      checklist.Name,
+			IsChecked: &isChecked,
+		}
+		if checklist.VerifiedBy != nil {
+			verifiedBy := checklist.VerifiedBy.String()
+			checklistReq.VerifiedBy = &verifiedBy
+		}
+		req.EmployeeTaskChecklists = append(req.EmployeeTaskChecklists, checklistReq)
+	}
+
+	return req
+}
+
+// bulkUpdateEmployeeTasks loads each employee task, lets change adjust its update request and
+// saves it with UpdateEmployeeTask. change returns a message to skip the task unchanged.
+func (uc *EmployeeTaskUseCase) bulkUpdateEmployeeTasks(ids []string, change func(employeeTask *entity.EmployeeTask, req *request.UpdateEmployeeTaskRequest) (string, error)) *response.BulkEmployeeTaskResponse {
+	result := newBulkEmployeeTaskResult(len(ids))
+	for _, id := range ids {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			result.fail(id, err)
+			continue
+		}
+		employeeTask, err := uc.Repository.FindByID(parsedID)
+		if err != nil {
+			result.fail(id, err)
+			continue
+		}
+		if employeeTask == nil {
+			result.fail(id, errors.New("employee task not found"))
+			continue
+		}
+
+		req := updateRequestFromEmployeeTask(employeeTask)
+		skipMessage, err := change(employeeTask, req)
+		if err != nil {
+			result.fail(id, err)
+			continue
+		}
+		if skipMessage != "" {
+			result.skip(id, skipMessage)
+			continue
+		}
+
+		// UpdateEmployeeTask pushes tasks with a verifier to Midsuit by their Midsuit id
+		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" && req.VerifiedBy != nil && (employeeTask.MidsuitID == nil || *employeeTask.MidsuitID == "") {
+			result.fail(id, errors.New("employee task has no midsuit id to sync"))
+			continue
+		}
+
+		res, err := uc.UpdateEmployeeTask(req)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.bulkUpdateEmployeeTasks] error updating employee task "+id+": ", err)
+			result.fail(id, err)
+			continue
+		}
+		result.succeed(id, res)
+	}
+
+	return result.res
+}
+
+// BulkAssignEmployeeTasks creates an employee task from a template task for every employee.
+// Employees who already have a task from the template are skipped.
+func (uc *EmployeeTaskUseCase) BulkAssignEmployeeTasks(req *request.BulkAssignEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error) {
+	parsedTemplateTaskID, err := uuid.Parse(req.TemplateTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error parsing template task id: ", err)
+		return nil, err
+	}
+	templateTask, err := uc.TemplateTaskRepository.FindByID(parsedTemplateTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error finding template task by id: ", err)
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, errors.New("template task not found")
+	}
+
+	templateTaskVersion, err := uc.TemplateTaskVersionService.EnsureCurrentVersion(templateTask)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error ensuring template task version: ", err)
+		return nil, err
+	}
+
+	startDate := time.Now()
+	if req.StartDate != "" {
+		startDate, err = time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error parsing start date: ", err)
+			return nil, err
+		}
+	}
+	var requestedEndDate *time.Time
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error parsing end date: ", err)
+			return nil, err
+		}
+		if endDate.Before(startDate) {
+			return nil, errors.New("end date is before start date")
+		}
+		requestedEndDate = &endDate
+	}
+
+	templateTaskID := templateTask.ID.String()
+	var surveyTemplateID *string
+	if templateTask.SurveyTemplateID != nil {
+		id := templateTask.SurveyTemplateID.String()
+		surveyTemplateID = &id
+	}
+	coverPath := templateTask.CoverPath
+	if coverPath == nil {
+		empty := ""
+		coverPath = &empty
+	}
+
+	result := newBulkEmployeeTaskResult(len(req.EmployeeIDs))
+	for _, employeeID := range req.EmployeeIDs {
+		parsedEmployeeID, err := uuid.Parse(employeeID)
+		if err != nil {
+			result.fail(employeeID, err)
+			continue
+		}
+		exist, err := uc.Repository.FindByKeys(map[string]interface{}{
+			"employee_id":      parsedEmployeeID,
+			"template_task_id": templateTask.ID,
+		})
+		if err != nil {
+			result.fail(employeeID, err)
+			continue
+		}
+		if exist != nil {
+			result.skip(employeeID, "template task is already assigned to the employee")
+			continue
+		}
+
+		// due dates count working days on the calendar of the employee's organization
+		profile, err := uc.TemplateTaskRuleService.ResolveHireProfile(&request.CreateEmployeeTasksForRecruitment{
+			EmployeeID: employeeID,
+		})
+		if err != nil {
+			result.fail(employeeID, err)
+			continue
+		}
+		endDate, err := uc.calculateDueDate(profile.OrganizationID, startDate, templateTask.DueDuration)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error calculating due date: ", err)
+			result.fail(employeeID, err)
+			continue
+		}
+		if requestedEndDate != nil {
+			endDate = *requestedEndDate
+		}
+
+		createReq := &request.CreateEmployeeTaskRequest{
+			CoverPath:               coverPath,
+			EmployeeID:              &employeeID,
+			TemplateTaskID:          &templateTaskID,
+			SurveyTemplateID:        surveyTemplateID,
+			Name:                    templateTask.Name,
+			Priority:                string(templateTask.Priority),
+			Description:             templateTask.Description,
+			StartDate:               startDate.Format("2006-01-02"),
+			EndDate:                 endDate.Format("2006-01-02"),
+			EmployeeTaskAttachments: make([]request.EmployeeTaskAttachmentRequest, 0, len(templateTask.TemplateTaskAttachments)),
+			EmployeeTaskChecklists:  make([]request.EmployeeTaskChecklistRequest, 0, len(templateTask.TemplateTaskChecklists)),
+		}
+		for _, attachment := range templateTask.TemplateTaskAttachments {
+			createReq.EmployeeTaskAttachments = append(createReq.EmployeeTaskAttachments, request.EmployeeTaskAttachmentRequest{
+				Path: attachment.Path,
+			})
+		}
+		var checklistErr error
+		for _, checklist := range templateTask.TemplateTaskChecklists {
+			checklistReq, err := uc.employeeTaskChecklistRequestFromTemplate(profile.OrganizationID, checklist, startDate)
+			if err != nil {
+				checklistErr = err
+				break
+			}
+			createReq.EmployeeTaskChecklists = append(createReq.EmployeeTaskChecklists, *checklistReq)
+		}
+		if checklistErr != nil {
+			result.fail(employeeID, checklistErr)
+			continue
+		}
+
+		created, err := uc.CreateEmployeeTask(createReq)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error creating employee task for employee "+employeeID+": ", err)
+			result.fail(employeeID, err)
+			continue
+		}
+
+		if err := uc.Repository.UpdateTemplateTaskVersionByIDs([]uuid.UUID{created.ID}, templateTaskVersion.ID); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.BulkAssignEmployeeTasks] error linking template task version: ", err)
+		}
+
+		// the verifier goes through the update so that it is synced like a manual assignment
+		if req.VerifiedBy != nil && *req.VerifiedBy != "" {
+			res := uc.bulkUpdateEmployeeTasks([]string{created.ID.String()}, func(employeeTask *entity.EmployeeTask, updateReq *request.UpdateEmployeeTaskRequest) (string, error) {
+				updateReq.VerifiedBy = req.VerifiedBy
+				return "", nil
+			})
+			if res.Failed > 0 {
+				result.fail(employeeID, errors.New("employee task was created but the verifier could not be set: "+res.Items[0].Message))
+				continue
+			}
+			created = res.Items[0].EmployeeTask
+		}
+
+		result.succeed(employeeID, created)
+	}
+
+	return result.res, nil
+}
+
+// BulkMoveEmployeeTasks moves employee tasks to another kanban column.
+func (uc *EmployeeTaskUseCase) BulkMoveEmployeeTasks(req *request.BulkMoveEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error) {
+	kanban := entity.EmployeeTaskKanbanEnum(req.Kanban)
+	return uc.bulkUpdateEmployeeTasks(req.IDs, func(employeeTask *entity.EmployeeTask, updateReq *request.UpdateEmployeeTaskRequest) (string, error) {
+		if employeeTask.Kanban == kanban {
+			return "employee task is already in " + req.Kanban, nil
+		}
+		updateReq.Kanban = req.Kanban
+		if kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+			updateReq.IsDone = "YES"
+		} else {
+			updateReq.IsDone = "NO"
+		}
+		return "", nil
+	}), nil
+}
+
+// BulkVerifyEmployeeTasks completes employee tasks waiting for review and checks their
+// checklists on behalf of the verifier.
+func (uc *EmployeeTaskUseCase) BulkVerifyEmployeeTasks(req *request.BulkVerifyEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error) {
+	return uc.bulkUpdateEmployeeTasks(req.IDs, func(employeeTask *entity.EmployeeTask, updateReq *request.UpdateEmployeeTaskRequest) (string, error) {
+		if employeeTask.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW {
+			return "", errors.New("employee task is in " + string(employeeTask.Kanban) + ", only " + string(entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW) + " tasks can be verified")
+		}
+		updateReq.Kanban = string(entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED)
+		updateReq.IsDone = "YES"
+		updateReq.VerifiedBy = &req.VerifiedBy
+		if req.Notes != "" {
+			updateReq.Notes = req.Notes
+		}
+		checked := "YES"
+		for i := range updateReq.EmployeeTaskChecklists {
+			updateReq.EmployeeTaskChecklists[i].IsChecked = &checked
+			updateReq.EmployeeTaskChecklists[i].VerifiedBy = &req.VerifiedBy
+		}
+		return "", nil
+	}), nil
+}
+
+// BulkReassignVerifier hands employee tasks over to another verifier.
+func (uc *EmployeeTaskUseCase) BulkReassignVerifier(req *request.BulkReassignVerifierRequest) (*response.BulkEmployeeTaskResponse, error) {
+	return uc.bulkUpdateEmployeeTasks(req.IDs, func(employeeTask *entity.EmployeeTask, updateReq *request.UpdateEmployeeTaskRequest) (string, error) {
+		if employeeTask.VerifiedBy != nil && employeeTask.VerifiedBy.String() == req.VerifiedBy {
+			return "employee task is already assigned to the verifier", nil
+		}
+		updateReq.VerifiedBy = &req.VerifiedBy
+		return "", nil
+	}), nil
+}
+
+// BulkDeleteEmployeeTasks soft deletes employee tasks.
+// BulkDeleteEmployeeTasks deletes the tasks one by one. With the Midsuit sync active a task is
+// deactivated in Midsuit first, a task Midsuit fails on is kept so that the delete can be retried.
+func (uc *EmployeeTaskUseCase) BulkDeleteEmployeeTasks(req *request.BulkDeleteEmployeeTaskRequest) (*response.BulkEmployeeTaskResponse, error) {
+	result := newBulkEmployeeTaskResult(len(req.IDs))
+	var midsuitToken string
+	for _, id := range req.IDs {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			result.fail(id, err)
+			continue
+		}
+		if uc.Viper.GetString("midsuit.sync") == "ACTIVE" {
+			if err := uc.deactivateEmployeeTaskMidsuit(parsedID, &midsuitToken); err != nil {
+				result.fail(id, err)
+				continue
+			}
+		}
+		if err := uc.DeleteEmployeeTask(parsedID); err != nil {
+			result.fail(id, err)
+			continue
+		}
+		result.succeed(id, nil)
+	}
+
+	return result.res, nil
+}
+
+// deactivateEmployeeTaskMidsuit deactivates the Midsuit task of an employee task that is about to
+// be deleted. The token is fetched once and shared by the tasks of a bulk operation.
+func (uc *EmployeeTaskUseCase) deactivateEmployeeTaskMidsuit(id uuid.UUID, midsuitToken *string) error {
+	employeeTask, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.deactivateEmployeeTaskMidsuit] error finding employee task by id: ", err)
+		return err
+	}
+	// a missing task is reported by the delete
+	if employeeTask == nil || employeeTask.MidsuitID == nil || *employeeTask.MidsuitID == "" {
+		return nil
+	}
+
+	midsuitID, err := strconv.Atoi(*employeeTask.MidsuitID)
+	if err != nil {
+		return errors.New("midsuit: invalid midsuit id " + *employeeTask.MidsuitID)
+	}
+	if *midsuitToken == "" {
+		authResp, err := uc.MidsuitService.AuthOneStep()
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.deactivateEmployeeTaskMidsuit] error authenticating to midsuit: ", err)
+			return errors.New("midsuit: " + err.Error())
+		}
+		*midsuitToken = authResp.Token
+	}
+	if err := uc.MidsuitService.SyncEmployeeTaskActiveMidsuit(midsuitID, false, *midsuitToken); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.deactivateEmployeeTaskMidsuit] error deactivating employee task in midsuit: ", err)
+		return errors.New("midsuit: " + err.Error())
+	}
+
+	return nil
+}
+
+// midsuitTaskCategory is the Midsuit task category of a source. The offboarding category can
+// be configured with midsuit.offboarding_category.
+func (uc *EmployeeTaskUseCase) midsuitTaskCategory(source string) request.TaskCategory {
+	if source == entity.TASK_SOURCE_OFFBOARDING {
+		category := uc.Viper.GetString("midsuit.offboarding_category")
+		if category == "" {
+			category = "OFF"
+		}
+		return request.TaskCategory{
+			ID: category,
+		}
+	}
+
+	return request.TaskCategory{
+		ID: "ON",
+	}
+}
+
+// planOffboardingTasks plans the offboarding tasks of a leaving employee from the offboarding
+// templates. Tasks are counted from the start date and no task is due after the last working day.
+func (uc *EmployeeTaskUseCase) planOffboardingTasks(req *request.CreateEmployeeTasksForOffboarding) (*request.CreateEmployeeTasksForRecruitment, *recruitmentPlan, time.Time, error) {
+	lastWorkingDate, err := time.Parse("2006-01-02", req.LastWorkingDate)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planOffboardingTasks] error parsing last working date: ", err)
+		return nil, nil, time.Time{}, err
+	}
+
+	startDate := req.StartDate
+	if startDate == "" {
+		startDate = time.Now().Format("2006-01-02")
+	}
+	parsedStartDate, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planOffboardingTasks] error parsing start date: ", err)
+		return nil, nil, time.Time{}, err
+	}
+	if parsedStartDate.After(lastWorkingDate) {
+		return nil, nil, time.Time{}, errors.New("start date is after the last working date")
+	}
+
+	recruitmentReq := &request.CreateEmployeeTasksForRecruitment{
+		EmployeeID:                     req.EmployeeID,
+		JoinedDate:                     startDate,
+		OrganizationType:               req.OrganizationType,
+		OrganizationID:                 req.OrganizationID,
+		EmployeeMidsuitID:              req.EmployeeMidsuitID,
+		JobMidsuitID:                   req.JobMidsuitID,
+		JobLevelMidsuitID:              req.JobLevelMidsuitID,
+		OrgMidsuitID:                   req.OrgMidsuitID,
+		OrgStructureMidsuitID:          req.OrgStructureMidsuitID,
+		JobID:                          req.JobID,
+		JobLevelID:                     req.JobLevelID,
+		OrganizationLocationID:         req.OrganizationLocationID,
+		OrganizationStructureID:        req.OrganizationStructureID,
+		EmploymentType:                 req.EmploymentType,
+		JobLevel:                       req.JobLevel,
+		OrganizationStructureParentIDs: req.OrganizationStructureParentIDs,
+	}
+
+	plan, err := uc.planRecruitmentTasks(recruitmentReq, entity.TASK_SOURCE_OFFBOARDING, false)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.planOffboardingTasks] error planning employee tasks: ", err)
+		return nil, nil, time.Time{}, err
+	}
+
+	for i := range plan.Items {
+		if plan.Items[i].EndDate.After(lastWorkingDate) {
+			plan.Warnings = append(plan.Warnings, "template task "+plan.Items[i].TemplateTask.Name+" would be due after the last working date, it is due on the last working date instead")
+			plan.Items[i].EndDate = lastWorkingDate
+		}
+	}
+
+	return recruitmentReq, plan, lastWorkingDate, nil
+}
+
+// CreateEmployeeTasksForOffboarding creates the offboarding tasks of a leaving employee. They
+// are kept apart from the onboarding tasks of the same employee.
+func (uc *EmployeeTaskUseCase) CreateEmployeeTasksForOffboarding(req *request.CreateEmployeeTasksForOffboarding) error {
+	recruitmentReq, plan, lastWorkingDate, err := uc.planOffboardingTasks(req)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForOffboarding] error planning employee tasks: ", err)
+		return err
+	}
+	if err := undecidedTemplateTasksError(plan); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForOffboarding] error planning employee tasks: ", err)
+		return err
+	}
+
+	// the HR service may send the same offboarding more than once
+	existing, err := uc.EmployeeOffboardingRepository.FindLatestByEmployeeID(plan.EmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForOffboarding] error finding employee offboarding: ", err)
+		return err
+	}
+	if existing != nil && existing.LastWorkingDate.Equal(lastWorkingDate) {
+		return errors.New("offboarding of the employee with this last working date was already created")
+	}
+
+	_, err = uc.EmployeeOffboardingRepository.CreateEmployeeOffboarding(&entity.EmployeeOffboarding{
+		EmployeeID:       plan.EmployeeID,
+		OrganizationType: req.OrganizationType,
+		StartDate:        plan.JoinedDate,
+		LastWorkingDate:  lastWorkingDate,
+		Reason:           req.Reason,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CreateEmployeeTasksForOffboarding] error creating employee offboarding: ", err)
+		return err
+	}
+
+	for _, item := range plan.Items {
+		if err := uc.createEmployeeTaskFromPlanItem(recruitmentReq, plan, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *EmployeeTaskUseCase) PreviewEmployeeTasksForOffboarding(req *request.CreateEmployeeTasksForOffboarding) (*response.OnboardingPlanResponse, error) {
+	_, plan, lastWorkingDate, err := uc.planOffboardingTasks(req)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.PreviewEmployeeTasksForOffboarding] error planning employee tasks: ", err)
+		return nil, err
+	}
+
+	res := uc.convertRecruitmentPlanToResponse(req.OrganizationType, plan)
+	res.LastWorkingDate = lastWorkingDate.Format("2006-01-02")
+	return res, nil
+}
+
+// CancelOnboarding stops the onboarding of a withdrawn hire for good. Its tasks become
+// inactive and the hire is taken off every event that has not finished yet.
+func (uc *EmployeeTaskUseCase) CancelOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error) {
+	return uc.changeOnboardingStatus(req, entity.EMPLOYEE_HIRING_STATUS_ENUM_CANCELLED, entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE, entity.EMPLOYEE_HIRING_STATUS_ENUM_PAUSED)
+}
+
+// PauseOnboarding makes the active tasks of the hire inactive until the onboarding is resumed.
+func (uc *EmployeeTaskUseCase) PauseOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error) {
+	return uc.changeOnboardingStatus(req, entity.EMPLOYEE_HIRING_STATUS_ENUM_PAUSED, entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE)
+}
+
+// ResumeOnboarding reactivates the tasks that were made inactive by PauseOnboarding.
+func (uc *EmployeeTaskUseCase) ResumeOnboarding(req *request.ChangeOnboardingStatusRequest) (*response.OnboardingStatusResponse, error) {
+	return uc.changeOnboardingStatus(req, entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE, entity.EMPLOYEE_HIRING_STATUS_ENUM_PAUSED)
+}
+
+// changeOnboardingStatus moves the latest hiring of the employee to status when it is in one of
+// the from statuses, and updates the onboarding tasks to match. Midsuit failures are reported in
+// the response without undoing the change.
+func (uc *EmployeeTaskUseCase) changeOnboardingStatus(req *request.ChangeOnboardingStatusRequest, status entity.EmployeeHiringStatusEnum, from ...entity.EmployeeHiringStatusEnum) (*response.OnboardingStatusResponse, error) {
+	parsedEmployeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error parsing employee id: ", err)
+		return nil, err
+	}
+
+	employeeHiring, err := uc.EmployeeHiringRepository.FindLatestByEmployeeID(parsedEmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error finding employee hiring: ", err)
+		return nil, err
+	}
+	if employeeHiring == nil {
+		return nil, errors.New("employee hiring not found")
+	}
+
+	currentStatus := employeeHiring.Status
+	if currentStatus == "" {
+		currentStatus = entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE
+	}
+	allowed := false
+	for _, fromStatus := range from {
+		if currentStatus == fromStatus {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, errors.New("onboarding is " + strings.ToLower(string(currentStatus)) + ", it cannot be changed to " + strings.ToLower(string(status)))
+	}
+
+	employeeTasks, err := uc.Repository.FindAllByEmployeeIDAndSource(parsedEmployeeID, entity.TASK_SOURCE_ONBOARDING)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error finding employee tasks: ", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	res := &response.OnboardingStatusResponse{
+		EmployeeID:    parsedEmployeeID,
+		HiringID:      employeeHiring.ID,
+		Status:        string(status),
+		StatusReason:  req.Reason,
+		MidsuitErrors: make([]string, 0),
+	}
+
+	var midsuitToken string
+	for _, employeeTask := range *employeeTasks {
+		var taskStatus entity.EmployeeTaskStatusEnum
+		var pausedAt *time.Time
+		switch {
+		case status == entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE && employeeTask.PausedAt != nil:
+			taskStatus = entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE
+		case status == entity.EMPLOYEE_HIRING_STATUS_ENUM_PAUSED && employeeTask.Status == entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE:
+			taskStatus = entity.EMPLOYEE_TASK_STATUS_ENUM_INACTIVE
+			pausedAt = &now
+		case status == entity.EMPLOYEE_HIRING_STATUS_ENUM_CANCELLED && (employeeTask.Status == entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE || employeeTask.PausedAt != nil):
+			taskStatus = entity.EMPLOYEE_TASK_STATUS_ENUM_INACTIVE
+		default:
+			continue
+		}
+
+		if err := uc.Repository.UpdateStatusByID(employeeTask.ID, taskStatus, pausedAt); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error updating employee task status: ", err)
+			return nil, err
+		}
+		res.UpdatedTasks++
+
+		if taskStatus == employeeTask.Status || uc.Viper.GetString("midsuit.sync") != "ACTIVE" || employeeTask.MidsuitID == nil || *employeeTask.MidsuitID == "" {
+			continue
+		}
+		midsuitID, err := strconv.Atoi(*employeeTask.MidsuitID)
+		if err != nil {
+			res.MidsuitErrors = append(res.MidsuitErrors, employeeTask.Name+": invalid midsuit id "+*employeeTask.MidsuitID)
+			continue
+		}
+		if midsuitToken == "" {
+			authResp, err := uc.MidsuitService.AuthOneStep()
+			if err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error authenticating to midsuit: ", err)
+				res.MidsuitErrors = append(res.MidsuitErrors, employeeTask.Name+": "+err.Error())
+				continue
+			}
+			midsuitToken = authResp.Token
+		}
+		if err := uc.MidsuitService.SyncEmployeeTaskActiveMidsuit(midsuitID, taskStatus == entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE, midsuitToken); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error syncing employee task status to midsuit: ", err)
+			res.MidsuitErrors = append(res.MidsuitErrors, employeeTask.Name+": "+err.Error())
+		}
+	}
+
+	if status == entity.EMPLOYEE_HIRING_STATUS_ENUM_CANCELLED {
+		res.DetachedEvents, err = uc.EventEmployeeRepository.DeleteFromOpenEventsByEmployeeID(parsedEmployeeID)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error detaching employee from events: ", err)
+			return nil, err
+		}
+	}
+
+	employeeHiring, err = uc.EmployeeHiringRepository.UpdateEmployeeHiring(&entity.EmployeeHiring{
+		ID:              employeeHiring.ID,
+		Status:          status,
+		StatusReason:    req.Reason,
+		StatusChangedAt: &now,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.changeOnboardingStatus] error updating employee hiring: ", err)
+		return nil, err
+	}
+	res.StatusChangedAt = employeeHiring.StatusChangedAt
+
+	return res, nil
+}
+
+// ReassignEmployeeTask moves an unfinished task to another employee and records who did it.
+// Admins, the verifier of the task and the delegate of the verifier may reassign it.
+func (uc *EmployeeTaskUseCase) ReassignEmployeeTask(req *request.ReassignEmployeeTaskRequest) (*response.EmployeeTaskResponse, error) {
+	parsedID, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error parsing id: ", err)
+		return nil, err
+	}
+	parsedEmployeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error parsing employee id: ", err)
+		return nil, err
+	}
+
+	employeeTask, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error finding employee task by id: ", err)
+		return nil, err
+	}
+	if employeeTask == nil {
+		return nil, errors.New("employee task not found")
+	}
+
+	if err := uc.ensureActorVerifiesEmployeeTask(employeeTask, req.Actor); err != nil {
+		return nil, err
+	}
+
+	if employeeTask.Kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		return nil, errors.New("completed employee tasks cannot be reassigned")
+	}
+	if employeeTask.EmployeeID != nil && *employeeTask.EmployeeID == parsedEmployeeID {
+		return nil, errors.New("employee task is already assigned to the employee")
+	}
+
+	employee, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: parsedEmployeeID.String(),
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error finding employee: ", err)
+		return nil, err
+	}
+	if employee == nil {
+		return nil, errors.New("employee not found")
+	}
+
+	// UpdateEmployeeTask pushes tasks with a verifier to Midsuit by their Midsuit id
+	if uc.Viper.GetString("midsuit.sync") == "ACTIVE" && employeeTask.VerifiedBy != nil && (employeeTask.MidsuitID == nil || *employeeTask.MidsuitID == "") {
+		return nil, errors.New("employee task has no midsuit id to sync")
+	}
+
+	updateReq := updateRequestFromEmployeeTask(employeeTask)
+	employeeID := parsedEmployeeID.String()
+	updateReq.EmployeeID = &employeeID
+	res, err := uc.UpdateEmployeeTask(updateReq)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error updating employee task: ", err)
+		return nil, err
+	}
+
+	actorID := req.Actor.EmployeeID
+	if _, err := uc.EmployeeTaskHistoryRepository.CreateEmployeeTaskHistory(&entity.EmployeeTaskHistory{
+		EmployeeTaskID: employeeTask.ID,
+		Action:         entity.EMPLOYEE_TASK_HISTORY_ACTION_ENUM_REASSIGN,
+		FromEmployeeID: employeeTask.EmployeeID,
+		ToEmployeeID:   &parsedEmployeeID,
+		ActorID:        &actorID,
+		Reason:         req.Reason,
+	}); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReassignEmployeeTask] error creating employee task history: ", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ensureActorVerifiesEmployeeTask lets admins, the verifier of the task and the delegate of the
+// verifier through.
+func (uc *EmployeeTaskUseCase) ensureActorVerifiesEmployeeTask(employeeTask *entity.EmployeeTask, actor request.TaskActor) error {
+	if actor.IsAdmin {
+		return nil
+	}
+	if employeeTask.VerifiedBy == nil {
+		return ErrTaskActorForbidden
+	}
+
+	verifierID, _, err := uc.VerifierDelegationService.ResolveVerifier(*employeeTask.VerifiedBy, time.Now())
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ensureActorVerifiesEmployeeTask] error resolving verifier delegation: ", err)
+		return err
+	}
+	if actor.EmployeeID != *employeeTask.VerifiedBy && actor.EmployeeID != verifierID {
+		return ErrTaskActorForbidden
+	}
+
+	return nil
+}
+
+func (uc *EmployeeTaskUseCase) FindAllHistoriesByEmployeeTaskID(id uuid.UUID) (*[]response.EmployeeTaskHistoryResponse, error) {
+	employeeTaskHistories, err := uc.EmployeeTaskHistoryRepository.FindAllByEmployeeTaskID(id)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.FindAllHistoriesByEmployeeTaskID] error finding employee task histories: ", err)
+		return nil, err
+	}
+
+	responses := make([]response.EmployeeTaskHistoryResponse, 0, len(*employeeTaskHistories))
+	for _, employeeTaskHistory := range *employeeTaskHistories {
+		responses = append(responses, response.EmployeeTaskHistoryResponse{
+			ID:             employeeTaskHistory.ID,
+			EmployeeTaskID: employeeTaskHistory.EmployeeTaskID,
+			Action:         string(employeeTaskHistory.Action),
+			FromEmployeeID: employeeTaskHistory.FromEmployeeID,
+			ToEmployeeID:   employeeTaskHistory.ToEmployeeID,
+			ActorID:        employeeTaskHistory.ActorID,
+			Reason:         employeeTaskHistory.Reason,
+			CreatedAt:      employeeTaskHistory.CreatedAt,
+		})
+	}
+
+	return &responses, nil
+}
+
+// ensureEmployeeTaskCanBeCompleted holds the checks that every way of completing a task goes
+// through: the approval chain, the required checklist items and what the kind of the task waits
+// for. The checklists, kind and passing score are those the update leaves the task with.
+func (uc *EmployeeTaskUseCase) ensureEmployeeTaskCanBeCompleted(employeeTask *entity.EmployeeTask, checklists []request.EmployeeTaskChecklistRequest, kind string, passingScore *int) error {
+	if err := uc.ensureApprovalChainApproved(employeeTask.ID); err != nil {
+		return err
+	}
+	if err := ensureRequiredChecklistsChecked(employeeTask, checklists); err != nil {
+		return err
+	}
+	if err := uc.ensureTaskKindRequirementMet(employeeTask, kind, passingScore); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EnsureEmployeeTaskCanBeCompleted runs the completion checks on the task as it is stored, for
+// the ways of completing a task outside of this use case.
+func (uc *EmployeeTaskUseCase) EnsureEmployeeTaskCanBeCompleted(employeeTask *entity.EmployeeTask) error {
+	return uc.ensureEmployeeTaskCanBeCompleted(employeeTask, updateRequestFromEmployeeTask(employeeTask).EmployeeTaskChecklists, "", nil)
+}
+
+// checklistRequestsFromMidsuit matches the checklist items Midsuit sends to the stored ones by
+// their Midsuit id.
+func checklistRequestsFromMidsuit(employeeTask *entity.EmployeeTask, checklists []request.EmployeeTaskChecklistMidsuitRequest) []request.EmployeeTaskChecklistRequest {
+	checklistReqs := make([]request.EmployeeTaskChecklistRequest, 0, len(checklists))
+	for _, checklistReq := range checklists {
+		converted := request.EmployeeTaskChecklistRequest{
+			Name:      checklistReq.Name,
+			IsChecked: checklistReq.IsChecked,
+		}
+		if checklistReq.MidsuitID != nil {
+			for _, checklist := range employeeTask.EmployeeTaskChecklists {
+				if checklist.MidsuitID != nil && *checklist.MidsuitID == *checklistReq.MidsuitID {
+					id := checklist.ID.String()
+					converted.ID = &id
+					break
+				}
+			}
+		}
+		checklistReqs = append(checklistReqs, converted)
+	}
+
+	return checklistReqs
+}
+
+// ensureApprovalChainApproved keeps tasks with an approval chain from being completed before
+// every step of the current round is approved.
+func (uc *EmployeeTaskUseCase) ensureApprovalChainApproved(employeeTaskID uuid.UUID) error {
+	approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(employeeTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ensureApprovalChainApproved] error finding employee task approvals: ", err)
+		return err
+	}
+
+	_, steps := service.CurrentApprovalRound(*approvals)
+	for _, step := range steps {
+		if step.Status != entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_APPROVED {
+			return errors.New("employee task is waiting for approval step " + strconv.Itoa(step.StepOrder) + ", it is completed by approving its approval chain")
+		}
+	}
+
+	return nil
+}
+
+// ensureRequiredChecklistsChecked looks at the checklist as the update leaves it. Stored items
+// keep the required flag they were saved with, a required item the update leaves out or
+// unchecked holds the task back like one added as required by the update.
+func ensureRequiredChecklistsChecked(employeeTask *entity.EmployeeTask, checklists []request.EmployeeTaskChecklistRequest) error {
+	requested := make(map[string]request.EmployeeTaskChecklistRequest, len(checklists))
+	for _, checklistReq := range checklists {
+		if checklistReq.ID != nil {
+			requested[*checklistReq.ID] = checklistReq
+		}
+	}
+
+	stored := make(map[string]bool, len(employeeTask.EmployeeTaskChecklists))
+	for _, checklist := range employeeTask.EmployeeTaskChecklists {
+		stored[checklist.ID.String()] = true
+		checklistReq, ok := requested[checklist.ID.String()]
+		if checklist.IsRequired != "YES" && (!ok || checklistReq.IsRequired == nil || *checklistReq.IsRequired != "YES") {
+			continue
+		}
+		if !ok {
+			return errors.New("required checklist item " + checklist.Name + " cannot be removed from an employee task that is completed")
+		}
+		if checklistReq.IsChecked == nil || *checklistReq.IsChecked != "YES" {
+			return errors.New("required checklist item " + checklist.Name + " must be checked before the employee task is completed")
+		}
+	}
+
+	for _, checklistReq := range checklists {
+		if checklistReq.ID != nil && stored[*checklistReq.ID] {
+			continue
+		}
+		if checklistReq.IsRequired != nil && *checklistReq.IsRequired == "YES" && (checklistReq.IsChecked == nil || *checklistReq.IsChecked != "YES") {
+			return errors.New("required checklist item " + checklistReq.Name + " must be checked before the employee task is completed")
+		}
+	}
+
+	return nil
+}
+
+// ensureTaskKindRequirementMet keeps a task from being completed before what its kind waits
+// for has happened, with the kind and passing score the update leaves it with.
+func (uc *EmployeeTaskUseCase) ensureTaskKindRequirementMet(employeeTask *entity.EmployeeTask, kind string, passingScore *int) error {
+	updated := *employeeTask
+	if kind != "" {
+		updated.Kind = entity.TaskKindEnum(kind)
+	}
+	if passingScore != nil {
+		updated.PassingScore = passingScore
+	}
+
+	missing, err := uc.EmployeeTaskKindService.MissingRequirement(&updated)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ensureTaskKindRequirementMet] error checking task kind requirement: ", err)
+		return err
+	}
+	if missing != "" {
+		return errors.New(string(updated.TaskKind()) + " employee task cannot be completed yet, " + missing)
+	}
+
+	return nil
+}
+
+// findActionableApproval returns the task and the first pending step of its current approval
+// round, after checking that the actor may act on that step.
+func (uc *EmployeeTaskUseCase) findActionableApproval(employeeTaskID string, actor request.TaskActor) (*entity.EmployeeTask, *entity.EmployeeTaskApproval, []entity.EmployeeTaskApproval, error) {
+	parsedID, err := uuid.Parse(employeeTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.findActionableApproval] error parsing employee task id: ", err)
+		return nil, nil, nil, err
+	}
+
+	employeeTask, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.findActionableApproval] error finding employee task by id: ", err)
+		return nil, nil, nil, err
+	}
+	if employeeTask == nil {
+		return nil, nil, nil, errors.New("employee task not found")
+	}
+	if employeeTask.Status != entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE {
+		return nil, nil, nil, errors.New("employee task is not active")
+	}
+	if employeeTask.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW {
+		return nil, nil, nil, errors.New("employee task is in " + string(employeeTask.Kanban) + ", only " + string(entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW) + " tasks can be approved or rejected")
+	}
+
+	approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(employeeTask.ID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.findActionableApproval] error finding employee task approvals: ", err)
+		return nil, nil, nil, err
+	}
+	if len(*approvals) == 0 {
+		return nil, nil, nil, errors.New("employee task has no approval chain")
+	}
+
+	_, steps := service.CurrentApprovalRound(*approvals)
+	var current *entity.EmployeeTaskApproval
+	for i := range steps {
+		if steps[i].Status == entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING {
+			current = &steps[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, nil, nil, errors.New("approval chain of the employee task is already complete")
+	}
+	if employeeTask.EmployeeID != nil {
+		if err := uc.EmployeeTaskApprovalService.AssignApprover(*employeeTask.EmployeeID, current); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.findActionableApproval] error assigning approver: ", err)
+			return nil, nil, nil, err
+		}
+	}
+
+	allowed, err := uc.EmployeeTaskApprovalService.CanAct(current, actor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !allowed {
+		return nil, nil, nil, ErrTaskActorForbidden
+	}
+
+	return employeeTask, current, steps, nil
+}
+
+// ApproveEmployeeTask approves the current step of the approval chain. Approving the last step
+// completes the task on behalf of its approver.
+func (uc *EmployeeTaskUseCase) ApproveEmployeeTask(req *request.ApproveEmployeeTaskRequest) (*response.EmployeeTaskApprovalChainResponse, error) {
+	employeeTask, current, steps, err := uc.findActionableApproval(req.EmployeeTaskID, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	actorID := req.Actor.EmployeeID
+	current.Status = entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_APPROVED
+	current.ActedBy = &actorID
+	current.ActedAt = &now
+	current.Reason = req.Reason
+	if _, err := uc.EmployeeTaskApprovalRepository.UpdateEmployeeTaskApproval(current); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ApproveEmployeeTask] error updating employee task approval: ", err)
+		return nil, err
+	}
+
+	if current.StepOrder == steps[len(steps)-1].StepOrder {
+		verifiedBy := actorID.String()
+		if current.ApproverID != nil {
+			verifiedBy = current.ApproverID.String()
+		}
+		updateReq := updateRequestFromEmployeeTask(employeeTask)
+		updateReq.Kanban = string(entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED)
+		updateReq.IsDone = "YES"
+		updateReq.VerifiedBy = &verifiedBy
+		updateReq.ActorEmployeeID = &actorID
+		checked := "YES"
+		for i := range updateReq.EmployeeTaskChecklists {
+			updateReq.EmployeeTaskChecklists[i].IsChecked = &checked
+			updateReq.EmployeeTaskChecklists[i].VerifiedBy = &verifiedBy
+		}
+		if _, err := uc.UpdateEmployeeTask(updateReq); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.ApproveEmployeeTask] error completing employee task: ", err)
+			uc.reopenEmployeeTaskApproval(current)
+			return nil, err
+		}
+	}
+
+	return uc.FindApprovalsByEmployeeTaskID(employeeTask.ID)
+}
+
+// RejectEmployeeTask rejects the current step, sends the task back to the employee and starts a
+// new round of the chain with the approvers resolved again.
+func (uc *EmployeeTaskUseCase) RejectEmployeeTask(req *request.RejectEmployeeTaskRequest) (*response.EmployeeTaskApprovalChainResponse, error) {
+	employeeTask, current, steps, err := uc.findActionableApproval(req.EmployeeTaskID, req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	actorID := req.Actor.EmployeeID
+	current.Status = entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_REJECTED
+	current.ActedBy = &actorID
+	current.ActedAt = &now
+	current.Reason = req.Reason
+	if _, err := uc.EmployeeTaskApprovalRepository.UpdateEmployeeTaskApproval(current); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.RejectEmployeeTask] error updating employee task approval: ", err)
+		return nil, err
+	}
+
+	updateReq := updateRequestFromEmployeeTask(employeeTask)
+	updateReq.Kanban = string(entity.EPMLOYEE_TASK_KANBAN_ENUM_IN_PROGRESS)
+	updateReq.IsDone = "NO"
+	if _, err := uc.UpdateEmployeeTask(updateReq); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.RejectEmployeeTask] error sending employee task back: ", err)
+		uc.reopenEmployeeTaskApproval(current)
+		return nil, err
+	}
+
+	nextSteps := make([]entity.TemplateTaskApprovalStep, 0, len(steps))
+	for _, step := range steps {
+		nextStep := entity.TemplateTaskApprovalStep{
+			StepOrder:    step.StepOrder,
+			ApproverType: step.ApproverType,
+		}
+		if step.ApproverType == entity.APPROVAL_APPROVER_TYPE_ENUM_EMPLOYEE {
+			nextStep.ApproverEmployeeID = step.ApproverID
+		}
+		nextSteps = append(nextSteps, nextStep)
+	}
+	if _, err := uc.EmployeeTaskApprovalService.StartApprovalRound(employeeTask.ID, *employeeTask.EmployeeID, current.Round+1, nextSteps); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.RejectEmployeeTask] error starting next approval round: ", err)
+		return nil, err
+	}
+
+	return uc.FindApprovalsByEmployeeTaskID(employeeTask.ID)
+}
+
+// reopenEmployeeTaskApproval puts a step back to pending when the task could not follow it.
+func (uc *EmployeeTaskUseCase) reopenEmployeeTaskApproval(approval *entity.EmployeeTaskApproval) {
+	approval.Status = entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING
+	approval.ActedBy = nil
+	approval.ActedAt = nil
+	approval.Reason = ""
+	if _, err := uc.EmployeeTaskApprovalRepository.UpdateEmployeeTaskApproval(approval); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.reopenEmployeeTaskApproval] error reopening employee task approval: ", err)
+	}
+}
+
+func (uc *EmployeeTaskUseCase) FindApprovalsByEmployeeTaskID(id uuid.UUID) (*response.EmployeeTaskApprovalChainResponse, error) {
+	employeeTask, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.FindApprovalsByEmployeeTaskID] error finding employee task by id: ", err)
+		return nil, err
+	}
+	if employeeTask == nil {
+		return nil, errors.New("employee task not found")
+	}
+
+	approvals, err := uc.EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID(id)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.FindApprovalsByEmployeeTaskID] error finding employee task approvals: ", err)
+		return nil, err
+	}
+
+	// the approver of the step the chain waits for is resolved once the step is reached
+	round, _ := service.CurrentApprovalRound(*approvals)
+	if employeeTask.EmployeeID != nil {
+		for i := range *approvals {
+			approval := &(*approvals)[i]
+			if approval.Round != round || approval.Status != entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING {
+				continue
+			}
+			if err := uc.EmployeeTaskApprovalService.AssignApprover(*employeeTask.EmployeeID, approval); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.FindApprovalsByEmployeeTaskID] error assigning approver: ", err)
+				return nil, err
+			}
+			break
+		}
+	}
+
+	return uc.EmployeeTaskApprovalDTO.ConvertEntitiesToChainResponse(id, *approvals), nil
+}
+
+// applyEmployeeTaskChecklistSettings copies the settings sent for a checklist item. Settings that
+// are left out keep their value, an empty assignee or due date clears it.
+func applyEmployeeTaskChecklistSettings(checklist *entity.EmployeeTaskChecklist, req request.EmployeeTaskChecklistRequest) error {
+	if req.SortOrder != nil {
+		checklist.SortOrder = *req.SortOrder
+	}
+	if req.AssigneeID != nil {
+		checklist.AssigneeID = nil
+		if *req.AssigneeID != "" {
+			assigneeID, err := uuid.Parse(*req.AssigneeID)
+			if err != nil {
+				return errors.New("invalid checklist assignee id: " + err.Error())
+			}
+			checklist.AssigneeID = &assigneeID
+		}
+	}
+	if req.DueDate != nil {
+		checklist.DueDate = nil
+		if *req.DueDate != "" {
+			dueDate, err := time.Parse("2006-01-02", *req.DueDate)
+			if err != nil {
+				return errors.New("invalid checklist due date: " + err.Error())
+			}
+			checklist.DueDate = &dueDate
+		}
+	}
+	if req.IsRequired != nil && *req.IsRequired != "" {
+		if *req.IsRequired != "YES" && *req.IsRequired != "NO" {
+			return errors.New("checklist is_required must be YES or NO")
+		}
+		checklist.IsRequired = *req.IsRequired
+	}
+	if checklist.IsRequired == "" {
+		checklist.IsRequired = "NO"
+	}
+
+	return nil
+}
+
+// templateRequiredChecklistNames lists the checklist items a template task makes required. Items
+// copied from the template are matched by name and stay required whatever a request sends.
+func templateRequiredChecklistNames(templateTask *entity.TemplateTask) map[string]bool {
+	names := make(map[string]bool)
+	for _, checklist := range templateTask.TemplateTaskChecklists {
+		if checklist.IsRequired == "YES" {
+			names[checklist.Name] = true
+		}
+	}
+
+	return names
+}
+
+// requiredTemplateChecklistNames is templateRequiredChecklistNames for the template the update
+// leaves the employee task with.
+func (uc *EmployeeTaskUseCase) requiredTemplateChecklistNames(templateTaskID *uuid.UUID, employeeTask *entity.EmployeeTask) (map[string]bool, error) {
+	if templateTaskID == nil {
+		templateTaskID = employeeTask.TemplateTaskID
+	}
+	if templateTaskID == nil {
+		return nil, nil
+	}
+
+	templateTask, err := uc.TemplateTaskRepository.FindByID(*templateTaskID)
+	if err != nil {
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, nil
+	}
+
+	return templateRequiredChecklistNames(templateTask), nil
+}
+
+// stampEmployeeTaskChecklistCheck records when and by whom an item got checked. An item that
+// stays checked keeps its first stamp and an unchecked item loses it.
+func stampEmployeeTaskChecklistCheck(checklist *entity.EmployeeTaskChecklist, previous *entity.EmployeeTaskChecklist, checkedBy *uuid.UUID) {
+	if checklist.IsChecked != "YES" {
+		checklist.CheckedAt = nil
+		checklist.CheckedBy = nil
+		return
+	}
+	if previous != nil && previous.IsChecked == "YES" {
+		checklist.CheckedAt = previous.CheckedAt
+		checklist.CheckedBy = previous.CheckedBy
+		return
+	}
+
+	now := time.Now()
+	checklist.CheckedAt = &now
+	checklist.CheckedBy = checkedBy
+}
+
+// employeeTaskChecklistFromTemplate copies a template checklist item with its settings. The due
+// date counts the template's offset in working days from the start of the employee task.
+func (uc *EmployeeTaskUseCase) employeeTaskChecklistFromTemplate(organizationID *uuid.UUID, employeeTask *entity.EmployeeTask, templateChecklist entity.TemplateTaskChecklist) (*entity.EmployeeTaskChecklist, error) {
+	checklist := &entity.EmployeeTaskChecklist{
+		EmployeeTaskID: employeeTask.ID,
+		Name:           templateChecklist.Name,
+		SortOrder:      templateChecklist.SortOrder,
+		AssigneeID:     templateChecklist.AssigneeID,
+		IsRequired:     templateChecklist.IsRequired,
+	}
+	if templateChecklist.DueOffsetDays != nil {
+		dueDate, err := uc.calculateDueDate(organizationID, employeeTask.StartDate, templateChecklist.DueOffsetDays)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.employeeTaskChecklistFromTemplate] error calculating checklist due date: ", err)
+			return nil, err
+		}
+		checklist.DueDate = &dueDate
+	}
+	if checklist.IsRequired == "" {
+		checklist.IsRequired = "NO"
+	}
+
+	return checklist, nil
+}
+
+// employeeTaskChecklistRequestFromTemplate is employeeTaskChecklistFromTemplate for tasks that are
+// created through CreateEmployeeTask.
+func (uc *EmployeeTaskUseCase) employeeTaskChecklistRequestFromTemplate(organizationID *uuid.UUID, templateChecklist entity.TemplateTaskChecklist, startDate time.Time) (*request.EmployeeTaskChecklistRequest, error) {
+	checklist, err := uc.employeeTaskChecklistFromTemplate(organizationID, &entity.EmployeeTask{StartDate: startDate}, templateChecklist)
+	if err != nil {
+		return nil, err
+	}
+	checklistReq := request.EmployeeTaskChecklistRequest{
+		Name:       checklist.Name,
+		SortOrder:  &checklist.SortOrder,
+		IsRequired: &checklist.IsRequired,
+	}
+	if checklist.AssigneeID != nil {
+		assigneeID := checklist.AssigneeID.String()
+		checklistReq.AssigneeID = &assigneeID
+	}
+	if checklist.DueDate != nil {
+		dueDate := checklist.DueDate.Format("2006-01-02")
+		checklistReq.DueDate = &dueDate
+	}
+
+	return &checklistReq, nil
+}
+
+// findKindEmployeeTask loads an active, unfinished task of the given kind for one of the kind
+// specific actions.
+func (uc *EmployeeTaskUseCase) findKindEmployeeTask(employeeTaskID string, kind entity.TaskKindEnum) (*entity.EmployeeTask, error) {
+	parsedID, err := uuid.Parse(employeeTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.findKindEmployeeTask] error parsing employee task id: ", err)
+		return nil, err
+	}
+
+	employeeTask, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.findKindEmployeeTask] error finding employee task by id: ", err)
+		return nil, err
+	}
+	if employeeTask == nil {
+		return nil, errors.New("employee task not found")
+	}
+	if employeeTask.TaskKind() != kind {
+		return nil, errors.New("employee task is a " + string(employeeTask.TaskKind()) + " task, not a " + string(kind) + " task")
+	}
+	if employeeTask.Status != entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE {
+		return nil, errors.New("employee task is not active")
+	}
+	if employeeTask.Kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		return nil, errors.New("employee task is already completed")
+	}
+
+	return employeeTask, nil
+}
+
+// moveEmployeeTaskKanban moves an unfinished task to another column through UpdateEmployeeTask.
+func (uc *EmployeeTaskUseCase) moveEmployeeTaskKanban(employeeTask *entity.EmployeeTask, kanban entity.EmployeeTaskKanbanEnum) error {
+	if employeeTask.Kanban == kanban {
+		return nil
+	}
+
+	updateReq := updateRequestFromEmployeeTask(employeeTask)
+	updateReq.Kanban = string(kanban)
+	updateReq.IsDone = "NO"
+	if _, err := uc.UpdateEmployeeTask(updateReq); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// completeEmployeeTaskByKind completes a task once the requirement of its kind is met. Tasks that
+// still wait for their approval chain or for required checklist items go to review instead, the
+// verifier finishes them.
+func (uc *EmployeeTaskUseCase) completeEmployeeTaskByKind(employeeTaskID uuid.UUID, actorID uuid.UUID) error {
+	employeeTask, err := uc.Repository.FindByID(employeeTaskID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.completeEmployeeTaskByKind] error finding employee task by id: ", err)
+		return err
+	}
+	if employeeTask == nil {
+		return errors.New("employee task not found")
+	}
+	if employeeTask.Kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		return nil
+	}
+
+	updateReq := updateRequestFromEmployeeTask(employeeTask)
+	if uc.ensureApprovalChainApproved(employeeTask.ID) != nil || ensureRequiredChecklistsChecked(employeeTask, updateReq.EmployeeTaskChecklists) != nil {
+		return uc.moveEmployeeTaskKanban(employeeTask, entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW)
+	}
+
+	updateReq.Kanban = string(entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED)
+	updateReq.IsDone = "YES"
+	updateReq.ActorEmployeeID = &actorID
+	if _, err := uc.UpdateEmployeeTask(updateReq); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UploadEmployeeTaskFile adds a document to a document upload task and sends the task to review.
+// The employee of the task and admins may upload.
+func (uc *EmployeeTaskUseCase) UploadEmployeeTaskFile(req *request.UploadEmployeeTaskFileRequest) (*response.EmployeeTaskResponse, error) {
+	employeeTask, err := uc.findKindEmployeeTask(req.EmployeeTaskID, entity.TASK_KIND_ENUM_DOCUMENT_UPLOAD)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Actor.IsAdmin && (employeeTask.EmployeeID == nil || *employeeTask.EmployeeID != req.Actor.EmployeeID) {
+		return nil, ErrTaskActorForbidden
+	}
+
+	if _, err := uc.EmployeeTaskFileRepository.CreateEmployeeTaskFile(&entity.EmployeeTaskFiles{
+		EmployeeTaskID: employeeTask.ID,
+		Path:           req.Path,
+		Status:         entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_PENDING,
+	}); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.UploadEmployeeTaskFile] error creating employee task file: ", err)
+		return nil, err
+	}
+
+	if err := uc.moveEmployeeTaskKanban(employeeTask, entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.UploadEmployeeTaskFile] error moving employee task to review: ", err)
+		return nil, err
+	}
+
+	return uc.FindByID(employeeTask.ID)
+}
+
+// ReviewEmployeeTaskFile accepts or rejects an uploaded document. Accepting completes the task,
+// rejecting the last pending document sends the task back to the employee.
+func (uc *EmployeeTaskUseCase) ReviewEmployeeTaskFile(req *request.ReviewEmployeeTaskFileRequest) (*response.EmployeeTaskResponse, error) {
+	parsedID, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error parsing id: ", err)
+		return nil, err
+	}
+
+	employeeTaskFile, err := uc.EmployeeTaskFileRepository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error finding employee task file by id: ", err)
+		return nil, err
+	}
+	if employeeTaskFile == nil {
+		return nil, errors.New("employee task file not found")
+	}
+	if employeeTaskFile.Status != entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_PENDING {
+		return nil, errors.New("employee task file is already " + string(employeeTaskFile.Status))
+	}
+
+	employeeTask, err := uc.findKindEmployeeTask(employeeTaskFile.EmployeeTaskID.String(), entity.TASK_KIND_ENUM_DOCUMENT_UPLOAD)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureActorVerifiesEmployeeTask(employeeTask, req.Actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	actorID := req.Actor.EmployeeID
+	employeeTaskFile.Status = entity.EmployeeTaskFileStatusEnum(req.Status)
+	employeeTaskFile.ReviewedBy = &actorID
+	employeeTaskFile.ReviewedAt = &now
+	employeeTaskFile.Notes = req.Notes
+	if _, err := uc.EmployeeTaskFileRepository.UpdateEmployeeTaskFileReview(employeeTaskFile); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error updating employee task file: ", err)
+		return nil, err
+	}
+
+	if employeeTaskFile.Status == entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_ACCEPTED {
+		if err := uc.completeEmployeeTaskByKind(employeeTask.ID, actorID); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error completing employee task: ", err)
+			return nil, err
+		}
+	} else {
+		pending, err := uc.EmployeeTaskFileRepository.CountByEmployeeTaskIDAndStatus(employeeTask.ID, entity.EMPLOYEE_TASK_FILE_STATUS_ENUM_PENDING)
+		if err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error counting pending employee task files: ", err)
+			return nil, err
+		}
+		if pending == 0 {
+			if err := uc.moveEmployeeTaskKanban(employeeTask, entity.EPMLOYEE_TASK_KANBAN_ENUM_IN_PROGRESS); err != nil {
+				uc.Log.Error("[EmployeeTaskUseCase.ReviewEmployeeTaskFile] error sending employee task back: ", err)
+				return nil, err
+			}
+		}
+	}
+
+	return uc.FindByID(employeeTask.ID)
+}
+
+// AcknowledgeEmployeeTask records the e-acknowledgement of the employee and completes the task.
+// Nobody can acknowledge on behalf of the employee.
+func (uc *EmployeeTaskUseCase) AcknowledgeEmployeeTask(req *request.AcknowledgeEmployeeTaskRequest) (*response.EmployeeTaskResponse, error) {
+	employeeTask, err := uc.findKindEmployeeTask(req.EmployeeTaskID, entity.TASK_KIND_ENUM_ACKNOWLEDGEMENT)
+	if err != nil {
+		return nil, err
+	}
+	if employeeTask.EmployeeID == nil || *employeeTask.EmployeeID != req.Actor.EmployeeID {
+		return nil, ErrTaskActorForbidden
+	}
+
+	if employeeTask.AcknowledgedAt == nil {
+		now := time.Now()
+		if err := uc.Repository.UpdateAcknowledgedAtByID(employeeTask.ID, &now); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.AcknowledgeEmployeeTask] error updating acknowledged at: ", err)
+			return nil, err
+		}
+	}
+
+	if err := uc.completeEmployeeTaskByKind(employeeTask.ID, req.Actor.EmployeeID); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.AcknowledgeEmployeeTask] error completing employee task: ", err)
+		return nil, err
+	}
+
+	return uc.FindByID(employeeTask.ID)
+}
+
+// RecordEmployeeTaskQuizScore stores the score of a quiz task. A passing score completes the
+// task, a failing one sends it back to the employee for another attempt.
+func (uc *EmployeeTaskUseCase) RecordEmployeeTaskQuizScore(req *request.RecordEmployeeTaskQuizScoreRequest) (*response.EmployeeTaskResponse, error) {
+	employeeTask, err := uc.findKindEmployeeTask(req.EmployeeTaskID, entity.TASK_KIND_ENUM_QUIZ)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureActorVerifiesEmployeeTask(employeeTask, req.Actor); err != nil {
+		return nil, err
+	}
+
+	if err := uc.Repository.UpdateQuizScoreByID(employeeTask.ID, req.Score); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.RecordEmployeeTaskQuizScore] error updating quiz score: ", err)
+		return nil, err
+	}
+	employeeTask.QuizScore = req.Score
+
+	if service.QuizPassed(employeeTask) {
+		if err := uc.completeEmployeeTaskByKind(employeeTask.ID, req.Actor.EmployeeID); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.RecordEmployeeTaskQuizScore] error completing employee task: ", err)
+			return nil, err
+		}
+	} else if err := uc.moveEmployeeTaskKanban(employeeTask, entity.EPMLOYEE_TASK_KANBAN_ENUM_IN_PROGRESS); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.RecordEmployeeTaskQuizScore] error sending employee task back: ", err)
+		return nil, err
+	}
+
+	return uc.FindByID(employeeTask.ID)
+}
+
+// CheckInEvent checks an invited employee in at an event and completes their event attendance
+// task of the event's template task, if they have one. Admins check employees in, employees may
+// check themselves in.
+func (uc *EmployeeTaskUseCase) CheckInEvent(req *request.CheckInEventRequest) (*response.EmployeeTaskResponse, error) {
+	parsedEventID, err := uuid.Parse(req.EventID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error parsing event id: ", err)
+		return nil, err
+	}
+	parsedEmployeeID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error parsing employee id: ", err)
+		return nil, err
+	}
+	if !req.Actor.IsAdmin && req.Actor.EmployeeID != parsedEmployeeID {
+		return nil, ErrTaskActorForbidden
+	}
+
+	event, err := uc.EventRepository.FindByID(parsedEventID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error finding event by id: ", err)
+		return nil, err
+	}
+	if event == nil {
+		return nil, errors.New("event not found")
+	}
+
+	eventEmployee, err := uc.EventEmployeeRepository.FindByEventIDAndEmployeeID(event.ID, parsedEmployeeID)
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error finding event employee: ", err)
+		return nil, err
+	}
+	if eventEmployee == nil {
+		return nil, errors.New("employee is not invited to the event")
+	}
+	if eventEmployee.CheckedInAt == nil {
+		now := time.Now()
+		eventEmployee.CheckedInAt = &now
+		if _, err := uc.EventEmployeeRepository.UpdateCheckedInAt(eventEmployee); err != nil {
+			uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error updating checked in at: ", err)
+			return nil, err
+		}
+	}
+
+	employeeTask, err := uc.Repository.FindByKeys(map[string]interface{}{
+		"employee_id":      parsedEmployeeID,
+		"template_task_id": event.TemplateTaskID,
+		"kind":             entity.TASK_KIND_ENUM_EVENT_ATTENDANCE,
+		"status":           entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
+	})
+	if err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error finding event attendance employee task: ", err)
+		return nil, err
+	}
+	if employeeTask == nil {
+		return nil, nil
+	}
+
+	if err := uc.completeEmployeeTaskByKind(employeeTask.ID, req.Actor.EmployeeID); err != nil {
+		uc.Log.Error("[EmployeeTaskUseCase.CheckInEvent] error completing employee task: ", err)
+		return nil, err
+	}
+
+	return uc.FindByID(employeeTask.ID)
+}
@@ -194,6 +194,8 @@ func (uc *EventUseCase) CreateEvent(ctx context.Context, req *request.CreateEven
 				Priority:       entity.EmployeeTaskPriorityEnum(templateTask.Priority),
 				IsDone:         "NO",
 				Source:         "ONBOARDING",
+				Kind:           templateTask.Kind,
+				PassingScore:   templateTask.PassingScore,
 			})
 			if err != nil {
 				tx.Rollback()
@@ -271,6 +273,14 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, req *request.UpdateEven
 		return nil, err
 	}
 
+	// the invitations are recreated, check-ins of employees that stay invited are kept
+	checkedInAt := make(map[uuid.UUID]*time.Time, len(exist.EventEmployees))
+	for _, eventEmployee := range exist.EventEmployees {
+		if eventEmployee.EmployeeID != nil {
+			checkedInAt[*eventEmployee.EmployeeID] = eventEmployee.CheckedInAt
+		}
+	}
+
 	// delete event employees
 	err = uc.EventEmployeeRepository.DeleteByEventID(event.ID)
 	if err != nil {
@@ -287,8 +297,9 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, req *request.UpdateEven
 		}
 
 		_, err = uc.EventEmployeeRepository.CreateEventEmployee(&entity.EventEmployee{
-			EventID:    event.ID,
-			EmployeeID: &parsedEmployeeID,
+			EventID:     event.ID,
+			EmployeeID:  &parsedEmployeeID,
+			CheckedInAt: checkedInAt[parsedEmployeeID],
 		})
 		if err != nil {
 			tx.Rollback()
@@ -337,6 +348,8 @@ func (uc *EventUseCase) UpdateEvent(ctx context.Context, req *request.UpdateEven
 				Priority:       entity.EmployeeTaskPriorityEnum(templateTask.Priority),
 				IsDone:         "NO",
 				Source:         "ONBOARDING",
+				Kind:           templateTask.Kind,
+				PassingScore:   templateTask.PassingScore,
 			})
 			if err != nil {
 				tx.Rollback()
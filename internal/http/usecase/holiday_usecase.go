@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+type IHolidayUseCase interface {
+	CreateHoliday(req *request.CreateHolidayRequest) (*response.HolidayResponse, error)
+	UpdateHoliday(req *request.UpdateHolidayRequest) (*response.HolidayResponse, error)
+	DeleteHoliday(id uuid.UUID) error
+	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]response.HolidayResponse, int64, error)
+	FindByID(id uuid.UUID) (*response.HolidayResponse, error)
+	ImportHolidays(ctx context.Context, req *request.ImportHolidayRequest) (*response.ImportHolidayResponse, error)
+	CalculateDueDate(organizationID *uuid.UUID, startDate string, days int) (*response.WorkingDayResponse, error)
+}
+
+type HolidayUseCase struct {
+	Log             *logrus.Logger
+	DTO             dto.IHolidayDTO
+	Repository      repository.IHolidayRepository
+	Viper           *viper.Viper
+	CalendarService service.ICalendarService
+	DB              *gorm.DB
+}
+
+func NewHolidayUseCase(
+	log *logrus.Logger,
+	dto dto.IHolidayDTO,
+	repository repository.IHolidayRepository,
+	viper *viper.Viper,
+	calendarService service.ICalendarService,
+	db *gorm.DB,
+) IHolidayUseCase {
+	return &HolidayUseCase{
+		Log:             log,
+		DTO:             dto,
+		Repository:      repository,
+		Viper:           viper,
+		CalendarService: calendarService,
+		DB:              db,
+	}
+}
+
+func HolidayUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IHolidayUseCase {
+	holidayDTO := dto.HolidayDTOFactory(log, viper)
+	holidayRepository := repository.HolidayRepositoryFactory(log)
+	calendarService := service.CalendarServiceFactory(log)
+	db := config.NewDatabase()
+	return NewHolidayUseCase(log, holidayDTO, holidayRepository, viper, calendarService, db)
+}
+
+func parseHolidayRequest(organizationID, date string) (*uuid.UUID, time.Time, error) {
+	var orgID *uuid.UUID
+	if organizationID != "" {
+		parsedOrgID, err := uuid.Parse(organizationID)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		orgID = &parsedOrgID
+	}
+
+	parsedDate, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return orgID, parsedDate, nil
+}
+
+func holidayKeys(orgID *uuid.UUID, date time.Time) map[string]interface{} {
+	keys := map[string]interface{}{
+		"date":            date.Format("2006-01-02"),
+		"organization_id": nil,
+	}
+	if orgID != nil {
+		keys["organization_id"] = *orgID
+	}
+	return keys
+}
+
+func (uc *HolidayUseCase) CreateHoliday(req *request.CreateHolidayRequest) (*response.HolidayResponse, error) {
+	orgID, parsedDate, err := parseHolidayRequest(req.OrganizationID, req.Date)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.CreateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByKeys(holidayKeys(orgID, parsedDate))
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.CreateHoliday] " + err.Error())
+		return nil, err
+	}
+	if exist != nil {
+		return nil, errors.New("holiday already exists on this date")
+	}
+
+	holidayType := entity.HOLIDAY_TYPE_ENUM_NATIONAL
+	if req.Type != "" {
+		holidayType = entity.HolidayTypeEnum(req.Type)
+	}
+
+	holiday, err := uc.Repository.CreateHoliday(&entity.Holiday{
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Date:           parsedDate,
+		Type:           holidayType,
+		Description:    req.Description,
+	})
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.CreateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(holiday), nil
+}
+
+func (uc *HolidayUseCase) UpdateHoliday(req *request.UpdateHolidayRequest) (*response.HolidayResponse, error) {
+	parsedID, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+	if exist == nil {
+		return nil, errors.New("holiday not found")
+	}
+
+	orgID, parsedDate, err := parseHolidayRequest(req.OrganizationID, req.Date)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	duplicate, err := uc.Repository.FindByKeys(holidayKeys(orgID, parsedDate))
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+	if duplicate != nil && duplicate.ID != exist.ID {
+		return nil, errors.New("holiday already exists on this date")
+	}
+
+	holidayType := exist.Type
+	if req.Type != "" {
+		holidayType = entity.HolidayTypeEnum(req.Type)
+	}
+
+	// organization_id is nullable, so it is written explicitly to allow
+	// turning an organization holiday into a national one
+	if err := uc.DB.Model(&entity.Holiday{}).Where("id = ?", exist.ID).Update("organization_id", orgID).Error; err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	holiday, err := uc.Repository.UpdateHoliday(&entity.Holiday{
+		ID:          exist.ID,
+		Name:        req.Name,
+		Date:        parsedDate,
+		Type:        holidayType,
+		Description: req.Description,
+	})
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.UpdateHoliday] " + err.Error())
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(holiday), nil
+}
+
+func (uc *HolidayUseCase) DeleteHoliday(id uuid.UUID) error {
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.DeleteHoliday] " + err.Error())
+		return err
+	}
+	if exist == nil {
+		return errors.New("holiday not found")
+	}
+
+	return uc.Repository.DeleteHoliday(id)
+}
+
+func (uc *HolidayUseCase) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]response.HolidayResponse, int64, error) {
+	holidays, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, sort)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.FindAllPaginated] " + err.Error())
+		return nil, 0, err
+	}
+
+	holidayResponses := make([]response.HolidayResponse, 0)
+	for _, holiday := range *holidays {
+		holidayResponses = append(holidayResponses, *uc.DTO.ConvertEntityToResponse(&holiday))
+	}
+
+	return &holidayResponses, total, nil
+}
+
+func (uc *HolidayUseCase) FindByID(id uuid.UUID) (*response.HolidayResponse, error) {
+	holiday, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.FindByID] " + err.Error())
+		return nil, err
+	}
+	if holiday == nil {
+		return nil, errors.New("holiday not found")
+	}
+
+	return uc.DTO.ConvertEntityToResponse(holiday), nil
+}
+
+// ImportHolidays upserts a list of holidays keyed by organization and date.
+// The whole list is rejected if any entry fails.
+func (uc *HolidayUseCase) ImportHolidays(ctx context.Context, req *request.ImportHolidayRequest) (*response.ImportHolidayResponse, error) {
+	result := &response.ImportHolidayResponse{}
+
+	err := uc.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		holidayRepository := repository.NewHolidayRepository(uc.Log, tx)
+
+		for _, holidayReq := range req.Holidays {
+			orgID, parsedDate, err := parseHolidayRequest(holidayReq.OrganizationID, holidayReq.Date)
+			if err != nil {
+				return errors.New("invalid holiday " + holidayReq.Name + ": " + err.Error())
+			}
+
+			holidayType := entity.HOLIDAY_TYPE_ENUM_NATIONAL
+			if holidayReq.Type != "" {
+				holidayType = entity.HolidayTypeEnum(holidayReq.Type)
+			}
+
+			exist, err := holidayRepository.FindByKeys(holidayKeys(orgID, parsedDate))
+			if err != nil {
+				return err
+			}
+
+			if exist != nil {
+				_, err = holidayRepository.UpdateHoliday(&entity.Holiday{
+					ID:          exist.ID,
+					Name:        holidayReq.Name,
+					Type:        holidayType,
+					Description: holidayReq.Description,
+				})
+				if err != nil {
+					return err
+				}
+				result.Updated++
+				continue
+			}
+
+			_, err = holidayRepository.CreateHoliday(&entity.Holiday{
+				OrganizationID: orgID,
+				Name:           holidayReq.Name,
+				Date:           parsedDate,
+				Type:           holidayType,
+				Description:    holidayReq.Description,
+			})
+			if err != nil {
+				return err
+			}
+			result.Created++
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.ImportHolidays] " + err.Error())
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (uc *HolidayUseCase) CalculateDueDate(organizationID *uuid.UUID, startDate string, days int) (*response.WorkingDayResponse, error) {
+	parsedStartDate, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.CalculateDueDate] " + err.Error())
+		return nil, err
+	}
+
+	dueDate, err := uc.CalendarService.AddWorkingDays(organizationID, parsedStartDate, days)
+	if err != nil {
+		uc.Log.Error("[HolidayUseCase.CalculateDueDate] " + err.Error())
+		return nil, err
+	}
+
+	return &response.WorkingDayResponse{
+		StartDate:   parsedStartDate.Format("2006-01-02"),
+		WorkingDays: days,
+		DueDate:     dueDate.Format("2006-01-02"),
+	}, nil
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// policyAcknowledgementChainBatch is how many acknowledgements are checked per query while
+// verifying the chain.
+const policyAcknowledgementChainBatch = 500
+
+type IPolicyDocumentUseCase interface {
+	CreatePolicyDocument(req *request.CreatePolicyDocumentRequest) (*response.PolicyDocumentResponse, error)
+	UpdatePolicyDocument(req *request.UpdatePolicyDocumentRequest) (*response.PolicyDocumentResponse, error)
+	CreatePolicyDocumentVersion(req *request.CreatePolicyDocumentVersionRequest) (*response.PolicyDocumentResponse, error)
+	FindByID(id uuid.UUID) (*response.PolicyDocumentResponse, error)
+	FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.PolicyDocumentResponse, int64, error)
+	AcknowledgePolicyDocument(req *request.AcknowledgePolicyDocumentRequest) (*response.PolicyAcknowledgementResponse, error)
+	FindAllAcknowledgementsPaginated(page, pageSize int, policyDocumentID, employeeID *uuid.UUID, actor request.TaskActor, sort map[string]interface{}) (*[]response.PolicyAcknowledgementResponse, int64, error)
+	FindPendingAcknowledgements(id uuid.UUID, page, pageSize int, actor request.TaskActor) (*[]response.PendingPolicyAcknowledgementResponse, int64, error)
+	VerifyPolicyAcknowledgementChain(actor request.TaskActor) (*response.PolicyAcknowledgementChainResponse, error)
+}
+
+type PolicyDocumentUseCase struct {
+	Log                             *logrus.Logger
+	DTO                             dto.IPolicyDocumentDTO
+	Repository                      repository.IPolicyDocumentRepository
+	PolicyAcknowledgementRepository repository.IPolicyAcknowledgementRepository
+	EmployeeHiringRepository        repository.IEmployeeHiringRepository
+	Viper                           *viper.Viper
+	EmployeeMessage                 messaging.IEmployeeMessage
+}
+
+func NewPolicyDocumentUseCase(
+	log *logrus.Logger,
+	dto dto.IPolicyDocumentDTO,
+	repository repository.IPolicyDocumentRepository,
+	paRepository repository.IPolicyAcknowledgementRepository,
+	ehRepository repository.IEmployeeHiringRepository,
+	viper *viper.Viper,
+	employeeMessage messaging.IEmployeeMessage,
+) IPolicyDocumentUseCase {
+	return &PolicyDocumentUseCase{
+		Log:                             log,
+		DTO:                             dto,
+		Repository:                      repository,
+		PolicyAcknowledgementRepository: paRepository,
+		EmployeeHiringRepository:        ehRepository,
+		Viper:                           viper,
+		EmployeeMessage:                 employeeMessage,
+	}
+}
+
+func PolicyDocumentUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IPolicyDocumentUseCase {
+	policyDocumentDTO := dto.PolicyDocumentDTOFactory(log, viper)
+	repo := repository.PolicyDocumentRepositoryFactory(log)
+	paRepository := repository.PolicyAcknowledgementRepositoryFactory(log)
+	ehRepository := repository.EmployeeHiringRepositoryFactory(log)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewPolicyDocumentUseCase(log, policyDocumentDTO, repo, paRepository, ehRepository, viper, employeeMessage)
+}
+
+// CreatePolicyDocument creates the document with the uploaded file as version 1. Only admins
+// manage policy documents.
+func (uc *PolicyDocumentUseCase) CreatePolicyDocument(req *request.CreatePolicyDocumentRequest) (*response.PolicyDocumentResponse, error) {
+	if !req.Actor.IsAdmin {
+		return nil, ErrTaskActorForbidden
+	}
+
+	contentHash, err := service.FileContentHash(req.Path)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocument] error hashing policy document file: ", err)
+		return nil, err
+	}
+
+	createdBy := req.Actor.EmployeeID
+	policyDocument, err := uc.Repository.CreatePolicyDocument(&entity.PolicyDocument{
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      entity.POLICY_DOCUMENT_STATUS_ENUM_ACTIVE,
+	}, &entity.PolicyDocumentVersion{
+		Path:        req.Path,
+		ContentHash: contentHash,
+		ChangeNote:  req.ChangeNote,
+		CreatedBy:   &createdBy,
+	})
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocument] error creating policy document: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(policyDocument), nil
+}
+
+func (uc *PolicyDocumentUseCase) UpdatePolicyDocument(req *request.UpdatePolicyDocumentRequest) (*response.PolicyDocumentResponse, error) {
+	if !req.Actor.IsAdmin {
+		return nil, ErrTaskActorForbidden
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.UpdatePolicyDocument] error parsing id: ", err)
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.UpdatePolicyDocument] error finding policy document: ", err)
+		return nil, err
+	}
+	if exist == nil {
+		return nil, errors.New("policy document not found")
+	}
+
+	policyDocument, err := uc.Repository.UpdatePolicyDocument(&entity.PolicyDocument{
+		ID:          exist.ID,
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      entity.PolicyDocumentStatusEnum(req.Status),
+	})
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.UpdatePolicyDocument] error updating policy document: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(policyDocument), nil
+}
+
+// CreatePolicyDocumentVersion uploads a new revision and makes it the current version.
+// Employees have to acknowledge it again, earlier acknowledgements stay in the log.
+func (uc *PolicyDocumentUseCase) CreatePolicyDocumentVersion(req *request.CreatePolicyDocumentVersionRequest) (*response.PolicyDocumentResponse, error) {
+	if !req.Actor.IsAdmin {
+		return nil, ErrTaskActorForbidden
+	}
+
+	id, err := uuid.Parse(req.PolicyDocumentID)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocumentVersion] error parsing policy document id: ", err)
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocumentVersion] error finding policy document: ", err)
+		return nil, err
+	}
+	if exist == nil {
+		return nil, errors.New("policy document not found")
+	}
+
+	contentHash, err := service.FileContentHash(req.Path)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocumentVersion] error hashing policy document file: ", err)
+		return nil, err
+	}
+	for _, version := range exist.PolicyDocumentVersions {
+		if version.VersionNumber == exist.CurrentVersionNumber && version.ContentHash == contentHash {
+			return nil, errors.New("the file is the same as the current version")
+		}
+	}
+
+	createdBy := req.Actor.EmployeeID
+	policyDocument, err := uc.Repository.AddPolicyDocumentVersion(&entity.PolicyDocumentVersion{
+		PolicyDocumentID: exist.ID,
+		Path:             req.Path,
+		ContentHash:      contentHash,
+		ChangeNote:       req.ChangeNote,
+		CreatedBy:        &createdBy,
+	})
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.CreatePolicyDocumentVersion] error creating policy document version: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(policyDocument), nil
+}
+
+func (uc *PolicyDocumentUseCase) FindByID(id uuid.UUID) (*response.PolicyDocumentResponse, error) {
+	policyDocument, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindByID] error finding policy document: ", err)
+		return nil, err
+	}
+	if policyDocument == nil {
+		return nil, errors.New("policy document not found")
+	}
+
+	return uc.DTO.ConvertEntityToResponse(policyDocument), nil
+}
+
+func (uc *PolicyDocumentUseCase) FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.PolicyDocumentResponse, int64, error) {
+	policyDocuments, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, status, sort)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindAllPaginated] error finding policy documents: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.PolicyDocumentResponse, 0, len(*policyDocuments))
+	for _, policyDocument := range *policyDocuments {
+		responses = append(responses, *uc.DTO.ConvertEntityToResponse(&policyDocument))
+	}
+
+	return &responses, total, nil
+}
+
+// AcknowledgePolicyDocument records that the logged in employee accepted the current version
+// of the document. The stored file is hashed again first, so an acknowledgement is never bound
+// to a file that was swapped after upload.
+func (uc *PolicyDocumentUseCase) AcknowledgePolicyDocument(req *request.AcknowledgePolicyDocumentRequest) (*response.PolicyAcknowledgementResponse, error) {
+	id, err := uuid.Parse(req.PolicyDocumentID)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] error parsing policy document id: ", err)
+		return nil, err
+	}
+
+	policyDocument, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] error finding policy document: ", err)
+		return nil, err
+	}
+	if policyDocument == nil {
+		return nil, errors.New("policy document not found")
+	}
+	if policyDocument.Status != entity.POLICY_DOCUMENT_STATUS_ENUM_ACTIVE {
+		return nil, errors.New("policy document is not active")
+	}
+	if req.VersionNumber != policyDocument.CurrentVersionNumber {
+		return nil, errors.New("version " + strconv.Itoa(req.VersionNumber) + " is not the current version " + strconv.Itoa(policyDocument.CurrentVersionNumber) + " of the policy document")
+	}
+
+	version, err := uc.Repository.FindVersionByDocumentIDAndNumber(policyDocument.ID, req.VersionNumber)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] error finding policy document version: ", err)
+		return nil, err
+	}
+	if version == nil {
+		return nil, errors.New("policy document version not found")
+	}
+
+	contentHash, err := service.FileContentHash(version.Path)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] error hashing policy document file: ", err)
+		return nil, err
+	}
+	if contentHash != version.ContentHash {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] policy document file does not match its hash: ", version.Path)
+		return nil, errors.New("the policy document file does not match the uploaded version")
+	}
+
+	policyAcknowledgement := &entity.PolicyAcknowledgement{
+		PolicyDocumentID:        policyDocument.ID,
+		PolicyDocumentVersionID: version.ID,
+		VersionNumber:           version.VersionNumber,
+		EmployeeID:              req.Actor.EmployeeID,
+		DocumentHash:            version.ContentHash,
+		AcknowledgedAt:          time.Now(),
+		IPAddress:               req.IPAddress,
+		UserAgent:               req.UserAgent,
+	}
+	// the repository checks for an earlier acknowledgement under the chain lock
+	policyAcknowledgement, err = uc.PolicyAcknowledgementRepository.AppendPolicyAcknowledgement(policyAcknowledgement, func(previous *entity.PolicyAcknowledgement) {
+		service.SealPolicyAcknowledgement(policyAcknowledgement, previous)
+	})
+	if errors.Is(err, repository.ErrPolicyAcknowledgementExists) {
+		return nil, err
+	}
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.AcknowledgePolicyDocument] error creating policy acknowledgement: ", err)
+		return nil, err
+	}
+	policyAcknowledgement.PolicyDocument = policyDocument
+
+	return uc.DTO.ConvertAcknowledgementEntityToResponse(policyAcknowledgement), nil
+}
+
+// FindAllAcknowledgementsPaginated lists acknowledgements. Employees who are not admins only
+// see their own.
+func (uc *PolicyDocumentUseCase) FindAllAcknowledgementsPaginated(page, pageSize int, policyDocumentID, employeeID *uuid.UUID, actor request.TaskActor, sort map[string]interface{}) (*[]response.PolicyAcknowledgementResponse, int64, error) {
+	if !actor.IsAdmin {
+		employeeID = &actor.EmployeeID
+	}
+
+	policyAcknowledgements, total, err := uc.PolicyAcknowledgementRepository.FindAllPaginated(page, pageSize, policyDocumentID, employeeID, sort)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindAllAcknowledgementsPaginated] error finding policy acknowledgements: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.PolicyAcknowledgementResponse, 0, len(*policyAcknowledgements))
+	for _, policyAcknowledgement := range *policyAcknowledgements {
+		responses = append(responses, *uc.DTO.ConvertAcknowledgementEntityToResponse(&policyAcknowledgement))
+	}
+
+	return &responses, total, nil
+}
+
+// FindPendingAcknowledgements lists the employees with an active hiring who have not
+// acknowledged the current version of the document, the longest hired first.
+func (uc *PolicyDocumentUseCase) FindPendingAcknowledgements(id uuid.UUID, page, pageSize int, actor request.TaskActor) (*[]response.PendingPolicyAcknowledgementResponse, int64, error) {
+	if !actor.IsAdmin {
+		return nil, 0, ErrTaskActorForbidden
+	}
+
+	policyDocument, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindPendingAcknowledgements] error finding policy document: ", err)
+		return nil, 0, err
+	}
+	if policyDocument == nil {
+		return nil, 0, errors.New("policy document not found")
+	}
+
+	employeeHirings, err := uc.EmployeeHiringRepository.FindAllActive()
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindPendingAcknowledgements] error finding active employee hirings: ", err)
+		return nil, 0, err
+	}
+
+	acknowledgedVersions, err := uc.PolicyAcknowledgementRepository.FindLatestVersionNumbersByDocumentID(policyDocument.ID)
+	if err != nil {
+		uc.Log.Error("[PolicyDocumentUseCase.FindPendingAcknowledgements] error finding acknowledged versions: ", err)
+		return nil, 0, err
+	}
+
+	pending := make([]entity.EmployeeHiring, 0, len(*employeeHirings))
+	for _, employeeHiring := range *employeeHirings {
+		if acknowledgedVersions[employeeHiring.EmployeeID] >= policyDocument.CurrentVersionNumber {
+			continue
+		}
+		pending = append(pending, employeeHiring)
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].HiringDate.Before(pending[j].HiringDate)
+	})
+
+	total := int64(len(pending))
+	start := (page - 1) * pageSize
+	if start > len(pending) {
+		start = len(pending)
+	}
+	end := start + pageSize
+	if end > len(pending) {
+		end = len(pending)
+	}
+
+	responses := make([]response.PendingPolicyAcknowledgementResponse, 0, end-start)
+	for _, employeeHiring := range pending[start:end] {
+		employeeName := ""
+		employee, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+			ID: employeeHiring.EmployeeID.String(),
+		})
+		if err != nil {
+			uc.Log.Error("[PolicyDocumentUseCase.FindPendingAcknowledgements] error finding employee: ", err)
+		} else if employee != nil {
+			employeeName = employee.Name
+		}
+
+		var lastAcknowledgedVersion *int
+		if versionNumber, ok := acknowledgedVersions[employeeHiring.EmployeeID]; ok {
+			lastAcknowledgedVersion = &versionNumber
+		}
+
+		responses = append(responses, response.PendingPolicyAcknowledgementResponse{
+			EmployeeID:              employeeHiring.EmployeeID,
+			EmployeeName:            employeeName,
+			HiringDate:              employeeHiring.HiringDate,
+			LastAcknowledgedVersion: lastAcknowledgedVersion,
+		})
+	}
+
+	return &responses, total, nil
+}
+
+// VerifyPolicyAcknowledgementChain walks the acknowledgement log from the first record and
+// stops at the first one that is missing, deleted, not linked to the record before it or
+// whose fields no longer match its hash.
+func (uc *PolicyDocumentUseCase) VerifyPolicyAcknowledgementChain(actor request.TaskActor) (*response.PolicyAcknowledgementChainResponse, error) {
+	if !actor.IsAdmin {
+		return nil, ErrTaskActorForbidden
+	}
+
+	result := &response.PolicyAcknowledgementChainResponse{
+		Valid:    true,
+		LastHash: service.PolicyAcknowledgementGenesisHash,
+	}
+	broken := func(sequence int64, reason string) (*response.PolicyAcknowledgementChainResponse, error) {
+		result.Valid = false
+		result.BrokenAtSequence = &sequence
+		result.Reason = reason
+		return result, nil
+	}
+
+	for {
+		policyAcknowledgements, err := uc.PolicyAcknowledgementRepository.FindAllAfterSequence(result.LastSequence, policyAcknowledgementChainBatch)
+		if err != nil {
+			uc.Log.Error("[PolicyDocumentUseCase.VerifyPolicyAcknowledgementChain] error finding policy acknowledgements: ", err)
+			return nil, err
+		}
+
+		for _, policyAcknowledgement := range *policyAcknowledgements {
+			expected := result.LastSequence + 1
+			if policyAcknowledgement.Sequence != expected {
+				return broken(expected, "the record is missing")
+			}
+			if policyAcknowledgement.DeletedAt.Valid {
+				return broken(expected, "the record is deleted")
+			}
+			if policyAcknowledgement.PreviousHash != result.LastHash {
+				return broken(expected, "the record does not link to the record before it")
+			}
+			if service.PolicyAcknowledgementHash(&policyAcknowledgement) != policyAcknowledgement.Hash {
+				return broken(expected, "the record was changed after it was written")
+			}
+
+			result.CheckedCount++
+			result.LastSequence = policyAcknowledgement.Sequence
+			result.LastHash = policyAcknowledgement.Hash
+		}
+
+		if len(*policyAcknowledgements) < policyAcknowledgementChainBatch {
+			break
+		}
+	}
+
+	return result, nil
+}
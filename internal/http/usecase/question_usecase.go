@@ -3,11 +3,15 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -21,14 +25,17 @@ type IQuestionUseCase interface {
 }
 
 type QuestionUseCase struct {
-	Log                      *logrus.Logger
-	Viper                    *viper.Viper
-	Repository               repository.IQuestionRepository
-	DTO                      dto.IQuestionDTO
-	QuestionOptionRepository repository.IQuestionOptionRepository
-	UserProfileRepository    repository.IUserProfileRepository
-	SurveyTemplateRepository repository.ISurveyTemplateRepository
-	SurveyTemplateDTO        dto.ISurveyTemplateDTO
+	Log                         *logrus.Logger
+	Viper                       *viper.Viper
+	Repository                  repository.IQuestionRepository
+	DTO                         dto.IQuestionDTO
+	QuestionOptionRepository    repository.IQuestionOptionRepository
+	UserProfileRepository       repository.IUserProfileRepository
+	SurveyTemplateRepository    repository.ISurveyTemplateRepository
+	SurveyTemplateDTO           dto.ISurveyTemplateDTO
+	QuestionConditionRepository repository.IQuestionConditionRepository
+	QuestionMatrixRowRepository repository.IQuestionMatrixRowRepository
+	SurveyResponseRepository    repository.ISurveyResponseRepository
 }
 
 func NewQuestionUseCase(
@@ -40,16 +47,22 @@ func NewQuestionUseCase(
 	userProfileRepository repository.IUserProfileRepository,
 	surveyTemplateRepository repository.ISurveyTemplateRepository,
 	surveyTemplateDTO dto.ISurveyTemplateDTO,
+	qcRepository repository.IQuestionConditionRepository,
+	qmrRepository repository.IQuestionMatrixRowRepository,
+	surveyResponseRepository repository.ISurveyResponseRepository,
 ) IQuestionUseCase {
 	return &QuestionUseCase{
-		Log:                      log,
-		Viper:                    viper,
-		Repository:               repo,
-		DTO:                      qDTO,
-		QuestionOptionRepository: qoRepository,
-		UserProfileRepository:    userProfileRepository,
-		SurveyTemplateRepository: surveyTemplateRepository,
-		SurveyTemplateDTO:        surveyTemplateDTO,
+		Log:                         log,
+		Viper:                       viper,
+		Repository:                  repo,
+		DTO:                         qDTO,
+		QuestionOptionRepository:    qoRepository,
+		UserProfileRepository:       userProfileRepository,
+		SurveyTemplateRepository:    surveyTemplateRepository,
+		SurveyTemplateDTO:           surveyTemplateDTO,
+		QuestionConditionRepository: qcRepository,
+		QuestionMatrixRowRepository: qmrRepository,
+		SurveyResponseRepository:    surveyResponseRepository,
 	}
 }
 
@@ -60,7 +73,10 @@ func QuestionUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IQuestionUse
 	userProfileRepository := repository.UserProfileRepositoryFactory(log)
 	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
 	surveyTemplateDTO := dto.SurveyTemplateDTOFactory(log, viper)
-	return NewQuestionUseCase(log, viper, repo, qDTO, qoRepository, userProfileRepository, surveyTemplateRepository, surveyTemplateDTO)
+	qcRepository := repository.QuestionConditionRepositoryFactory(log)
+	qmrRepository := repository.QuestionMatrixRowRepositoryFactory(log)
+	surveyResponseRepository := repository.SurveyResponseRepositoryFactory(log)
+	return NewQuestionUseCase(log, viper, repo, qDTO, qoRepository, userProfileRepository, surveyTemplateRepository, surveyTemplateDTO, qcRepository, qmrRepository, surveyResponseRepository)
 }
 
 func (u *QuestionUseCase) generateRandomSurveyNumber() (*string, error) {
@@ -107,7 +123,21 @@ func (u *QuestionUseCase) generateRandomSurveyNumber() (*string, error) {
 }
 
 func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQuestions) (*response.SurveyTemplateResponse, error) {
+	if err := service.ValidateSurveyQuiz(req); err != nil {
+		return nil, err
+	}
+	if err := service.ValidateSurveyQuestionRules(req); err != nil {
+		return nil, err
+	}
+	if err := service.ValidateSurveyQuestionConditions(req); err != nil {
+		return nil, err
+	}
+	if err := service.ValidateSurveyAnonymity(req); err != nil {
+		return nil, err
+	}
+
 	// check if survey template exist
+	var anonymitySettings *entity.SurveyTemplate
 	if req.SurveyTemplateID != "" {
 		tq, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
 			"id": req.SurveyTemplateID,
@@ -122,6 +152,27 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 			return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] survey template with id " + req.SurveyTemplateID + " not found")
 		}
 
+		anonymitySettings, err = uc.surveyAnonymitySettings(req, tq)
+		if err != nil {
+			return nil, err
+		}
+
+		// the questions of a published version stay as its responses answered them, the edits
+		// make the next version and every question of the request is created for it
+		published, err := uc.isSurveyTemplatePublished(tq)
+		if err != nil {
+			return nil, err
+		}
+		if published {
+			tq, err = uc.createSurveyTemplateVersion(tq, req.Title)
+			if err != nil {
+				return nil, err
+			}
+			for i := range req.Questions {
+				req.Questions[i].ID = ""
+			}
+		}
+
 		_, err = uc.SurveyTemplateRepository.UpdateSurveyTemplate(&entity.SurveyTemplate{
 			ID:    tq.ID,
 			Title: req.Title,
@@ -132,6 +183,12 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 		}
 		req.SurveyTemplateID = tq.ID.String()
 	} else {
+		settings, err := uc.surveyAnonymitySettings(req, nil)
+		if err != nil {
+			return nil, err
+		}
+		anonymitySettings = settings
+
 		surveyNumber, err := uc.generateRandomSurveyNumber()
 		if err != nil {
 			uc.Log.Error("[SurveyTemplateUseCase.CreateSurveyTemplate] Error when generating random survey number: ", err)
@@ -155,6 +212,24 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 		return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when parsing survey template id: " + err.Error())
 	}
 
+	err = uc.SurveyTemplateRepository.UpdateQuizSettings(&entity.SurveyTemplate{
+		ID:           parsedSurveyTemplateID,
+		IsQuiz:       req.IsQuiz,
+		PassingScore: req.PassingScore,
+		MaxAttempts:  req.MaxAttempts,
+	})
+	if err != nil {
+		uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when updating quiz settings: %s", err.Error())
+		return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when updating quiz settings: " + err.Error())
+	}
+
+	anonymitySettings.ID = parsedSurveyTemplateID
+	err = uc.SurveyTemplateRepository.UpdateAnonymitySettings(anonymitySettings)
+	if err != nil {
+		uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when updating anonymity settings: %s", err.Error())
+		return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when updating anonymity settings: " + err.Error())
+	}
+
 	uc.Log.Info("Payload questions: ", req.Questions)
 
 	var questionIDs []uuid.UUID
@@ -179,6 +254,7 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 	}
 
 	// create or update questions
+	savedQuestionIDs := make([]uuid.UUID, len(req.Questions))
 	for i, question := range req.Questions {
 		if question.ID != "" && question.ID != uuid.Nil.String() {
 			exist, err := uc.Repository.FindByID(uuid.MustParse(question.ID))
@@ -188,24 +264,27 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 			}
 
 			if exist == nil {
-				createdQuestion, err := uc.Repository.CreateQuestion(&entity.Question{
+				createdQuestion, err := uc.Repository.CreateQuestion(withQuestionRules(&entity.Question{
 					SurveyTemplateID: parsedSurveyTemplateID,
 					AnswerTypeID:     uuid.MustParse(question.AnswerTypeID),
 					Question:         question.Question,
 					Number:           i + 1,
 					MaxStars:         question.MaxStars,
+					Points:           question.Points,
 					Attachment:       &question.AttachmentPath,
-				})
+				}, &question))
 				if err != nil {
 					uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question: %s", err.Error())
 					return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question: " + err.Error())
 				}
+				savedQuestionIDs[i] = createdQuestion.ID
 
 				if len(question.QuestionOptions) > 0 {
 					for _, questionOption := range question.QuestionOptions {
 						_, err := uc.QuestionOptionRepository.CreateQuestionOption(&entity.QuestionOption{
 							QuestionID: createdQuestion.ID,
 							OptionText: questionOption.OptionText,
+							IsCorrect:  questionOption.IsCorrect,
 						})
 						if err != nil {
 							uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question option: %s", err.Error())
@@ -221,6 +300,7 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 					Question:         question.Question,
 					Number:           i + 1,
 					MaxStars:         question.MaxStars,
+					Points:           question.Points,
 					Attachment:       &question.AttachmentPath,
 				})
 				if err != nil {
@@ -228,6 +308,14 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 					return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when updating question: " + err.Error())
 				}
 
+				savedQuestionIDs[i] = updatedQuestion.ID
+
+				err = uc.Repository.UpdateQuestionRules(withQuestionRules(&entity.Question{ID: updatedQuestion.ID}, &question))
+				if err != nil {
+					uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when updating question rules: %s", err.Error())
+					return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when updating question rules: " + err.Error())
+				}
+
 				// delete question options
 				err = uc.QuestionOptionRepository.DeleteQuestionOptionsByQuestionID(updatedQuestion.ID)
 				if err != nil {
@@ -241,6 +329,7 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 						_, err := uc.QuestionOptionRepository.CreateQuestionOption(&entity.QuestionOption{
 							QuestionID: updatedQuestion.ID,
 							OptionText: questionOption.OptionText,
+							IsCorrect:  questionOption.IsCorrect,
 						})
 						if err != nil {
 							uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question option: %s", err.Error())
@@ -252,24 +341,27 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 		} else {
 			uc.Log.Info("Payloadku: ", question.Question)
 
-			createdQuestion, err := uc.Repository.CreateQuestion(&entity.Question{
+			createdQuestion, err := uc.Repository.CreateQuestion(withQuestionRules(&entity.Question{
 				SurveyTemplateID: parsedSurveyTemplateID,
 				AnswerTypeID:     uuid.MustParse(question.AnswerTypeID),
 				Question:         question.Question,
 				Number:           i + 1,
 				MaxStars:         question.MaxStars,
+				Points:           question.Points,
 				Attachment:       &question.AttachmentPath,
-			})
+			}, &question))
 			if err != nil {
 				uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question: %s", err.Error())
 				return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question: " + err.Error())
 			}
+			savedQuestionIDs[i] = createdQuestion.ID
 
 			if len(question.QuestionOptions) > 0 {
 				for _, questionOption := range question.QuestionOptions {
 					_, err := uc.QuestionOptionRepository.CreateQuestionOption(&entity.QuestionOption{
 						QuestionID: createdQuestion.ID,
 						OptionText: questionOption.OptionText,
+						IsCorrect:  questionOption.IsCorrect,
 					})
 					if err != nil {
 						uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question option: %s", err.Error())
@@ -280,6 +372,47 @@ func (uc *QuestionUseCase) CreateOrUpdateQuestions(req *request.CreateOrUpdateQu
 		}
 	}
 
+	// matrix rows and display conditions are saved once every question has an id, as the
+	// conditions point at questions by number
+	for i, question := range req.Questions {
+		err := uc.QuestionMatrixRowRepository.DeleteQuestionMatrixRowsByQuestionID(savedQuestionIDs[i])
+		if err != nil {
+			uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when deleting question matrix rows: %s", err.Error())
+			return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when deleting question matrix rows: " + err.Error())
+		}
+
+		for j, matrixRow := range question.MatrixRows {
+			_, err := uc.QuestionMatrixRowRepository.CreateQuestionMatrixRow(&entity.QuestionMatrixRow{
+				QuestionID: savedQuestionIDs[i],
+				RowText:    matrixRow.RowText,
+				Number:     j + 1,
+			})
+			if err != nil {
+				uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question matrix row: %s", err.Error())
+				return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question matrix row: " + err.Error())
+			}
+		}
+
+		err = uc.QuestionConditionRepository.DeleteQuestionConditionsByQuestionID(savedQuestionIDs[i])
+		if err != nil {
+			uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when deleting question conditions: %s", err.Error())
+			return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when deleting question conditions: " + err.Error())
+		}
+
+		for _, condition := range question.DisplayConditions {
+			_, err := uc.QuestionConditionRepository.CreateQuestionCondition(&entity.QuestionCondition{
+				QuestionID:       savedQuestionIDs[i],
+				SourceQuestionID: savedQuestionIDs[condition.SourceQuestionNumber-1],
+				Operator:         entity.QuestionConditionOperatorEnum(condition.Operator),
+				Value:            condition.Value,
+			})
+			if err != nil {
+				uc.Log.Errorf("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question condition: %s", err.Error())
+				return nil, errors.New("[QuestionUseCase.CreateOrUpdateQuestions] error when creating question condition: " + err.Error())
+			}
+		}
+	}
+
 	// delete questions
 	// if len(req.DeletedQuestionIDs) > 0 {
 	// 	for _, id := range req.DeletedQuestionIDs {
@@ -354,3 +487,105 @@ func (uc *QuestionUseCase) FindByID(questionID string) (*entity.Question, error)
 
 	return q, nil
 }
+
+// withQuestionRules copies the answer rules and how the display conditions combine from the
+// request onto the question.
+func withQuestionRules(ent *entity.Question, question *request.QuestionRequest) *entity.Question {
+	ent.IsRequired = question.IsRequired
+	ent.MinLength = question.MinLength
+	ent.MaxLength = question.MaxLength
+	ent.Pattern = question.Pattern
+	ent.MaxFileSizeKB = question.MaxFileSizeKB
+	ent.MinSelections = question.MinSelections
+	ent.MaxSelections = question.MaxSelections
+	ent.MinValue = question.MinValue
+	ent.MaxValue = question.MaxValue
+	ent.MinDate = parseQuestionDate(question.MinDate)
+	ent.MaxDate = parseQuestionDate(question.MaxDate)
+	ent.ConditionMatch = entity.QUESTION_CONDITION_MATCH_ENUM_ALL
+	if question.ConditionMatch != "" {
+		ent.ConditionMatch = entity.QuestionConditionMatchEnum(question.ConditionMatch)
+	}
+	if fileTypes := service.SurveyAnswerFileTypes(question.AllowedFileTypes); len(fileTypes) > 0 {
+		allowedFileTypes := strings.Join(fileTypes, ",")
+		ent.AllowedFileTypes = &allowedFileTypes
+	}
+
+	return ent
+}
+
+// parseQuestionDate reads a date bound of a question, which the request validated as YYYY-MM-DD.
+func parseQuestionDate(date *string) *time.Time {
+	if date == nil || *date == "" {
+		return nil
+	}
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	parsedDate, err := time.ParseInLocation("2006-01-02", *date, loc)
+	if err != nil {
+		return nil
+	}
+	return &parsedDate
+}
+
+// surveyAnonymitySettings resolves the anonymity a survey template gets from the request.
+func (uc *QuestionUseCase) surveyAnonymitySettings(req *request.CreateOrUpdateQuestions, current *entity.SurveyTemplate) (*entity.SurveyTemplate, error) {
+	settings := service.SurveyAnonymitySettings(req, current)
+	if err := service.ValidateSurveyAnonymitySettings(req.IsQuiz, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// createSurveyTemplateVersion makes the next version of a published survey template as a
+// draft. A survey has one draft at a time, so a second one is refused.
+func (uc *QuestionUseCase) createSurveyTemplateVersion(published *entity.SurveyTemplate, title string) (*entity.SurveyTemplate, error) {
+	versions, err := uc.SurveyTemplateRepository.FindAllVersions(published.SurveyNumber)
+	if err != nil {
+		uc.Log.Errorf("[QuestionUseCase.createSurveyTemplateVersion] error when finding survey template versions: %s", err.Error())
+		return nil, errors.New("[QuestionUseCase.createSurveyTemplateVersion] error when finding survey template versions: " + err.Error())
+	}
+
+	latest := published
+	if len(*versions) > 0 {
+		latest = &(*versions)[len(*versions)-1]
+	}
+	if latest.ID != published.ID {
+		latestPublished, err := uc.isSurveyTemplatePublished(latest)
+		if err != nil {
+			return nil, err
+		}
+		if !latestPublished {
+			return nil, errors.New("survey " + published.SurveyNumber + " already has the draft version " + strconv.Itoa(latest.VersionNumber) + ", edit that version instead")
+		}
+	}
+
+	version, err := uc.SurveyTemplateRepository.CreateSurveyTemplate(&entity.SurveyTemplate{
+		Title:         title,
+		SurveyNumber:  published.SurveyNumber,
+		VersionNumber: latest.VersionNumber + 1,
+		Status:        entity.SURVEY_TEMPLATE_STATUS_ENUM_DRAFT,
+	})
+	if err != nil {
+		uc.Log.Errorf("[QuestionUseCase.createSurveyTemplateVersion] error when creating survey template version: %s", err.Error())
+		return nil, errors.New("[QuestionUseCase.createSurveyTemplateVersion] error when creating survey template version: " + err.Error())
+	}
+
+	return version, nil
+}
+
+// isSurveyTemplatePublished tells whether a survey template can no longer be edited in place:
+// it is submitted, or it is a draft that employees already answered.
+func (uc *QuestionUseCase) isSurveyTemplatePublished(surveyTemplate *entity.SurveyTemplate) (bool, error) {
+	if surveyTemplate.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED {
+		return true, nil
+	}
+
+	total, err := uc.SurveyResponseRepository.CountBySurveyTemplateID(surveyTemplate.ID)
+	if err != nil {
+		uc.Log.Errorf("[QuestionUseCase.isSurveyTemplatePublished] error when counting survey responses: %s", err.Error())
+		return false, errors.New("[QuestionUseCase.isSurveyTemplatePublished] error when counting survey responses: " + err.Error())
+	}
+
+	return total > 0, nil
+}
@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyAnalyticsUseCase interface {
+	FindSurveyAnalytics(req *request.SurveyAnalyticsRequest) (*response.SurveyAnalyticsResponse, error)
+	FindSurveyVersionAnalytics(req *request.SurveyAnalyticsRequest) (*response.SurveyVersionAnalyticsResponse, error)
+}
+
+type SurveyAnalyticsUseCase struct {
+	Log                      *logrus.Logger
+	Viper                    *viper.Viper
+	SurveyTemplateRepository repository.ISurveyTemplateRepository
+	SurveyResponseRepository repository.ISurveyResponseRepository
+	EmployeeTaskRepository   repository.IEmployeeTaskRepository
+	EmployeeHiringRepository repository.IEmployeeHiringRepository
+	EmployeeMessage          messaging.IEmployeeMessage
+}
+
+func NewSurveyAnalyticsUseCase(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	surveyTemplateRepository repository.ISurveyTemplateRepository,
+	surveyResponseRepository repository.ISurveyResponseRepository,
+	employeeTaskRepository repository.IEmployeeTaskRepository,
+	employeeHiringRepository repository.IEmployeeHiringRepository,
+	employeeMessage messaging.IEmployeeMessage,
+) ISurveyAnalyticsUseCase {
+	return &SurveyAnalyticsUseCase{
+		Log:                      log,
+		Viper:                    viper,
+		SurveyTemplateRepository: surveyTemplateRepository,
+		SurveyResponseRepository: surveyResponseRepository,
+		EmployeeTaskRepository:   employeeTaskRepository,
+		EmployeeHiringRepository: employeeHiringRepository,
+		EmployeeMessage:          employeeMessage,
+	}
+}
+
+func SurveyAnalyticsUseCaseFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) ISurveyAnalyticsUseCase {
+	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
+	surveyResponseRepository := repository.SurveyResponseRepositoryFactory(log)
+	employeeTaskRepository := repository.EmployeeTaskRepositoryFactory(log)
+	employeeHiringRepository := repository.EmployeeHiringRepositoryFactory(log)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewSurveyAnalyticsUseCase(
+		log,
+		viper,
+		surveyTemplateRepository,
+		surveyResponseRepository,
+		employeeTaskRepository,
+		employeeHiringRepository,
+		employeeMessage,
+	)
+}
+
+// surveyAnalyticsFilter is a parsed analytics request. SubmittedTo is exclusive, the day after
+// the requested end date.
+type surveyAnalyticsFilter struct {
+	OrganizationID string
+	JoinedFrom     *time.Time
+	JoinedTo       *time.Time
+	SubmittedFrom  *time.Time
+	SubmittedTo    *time.Time
+}
+
+func parseSurveyAnalyticsRequest(req *request.SurveyAnalyticsRequest) (*surveyAnalyticsFilter, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	joinedFrom, err := parseSurveyAnalyticsDate(req.JoinedFrom, loc)
+	if err != nil {
+		return nil, err
+	}
+	joinedTo, err := parseSurveyAnalyticsDate(req.JoinedTo, loc)
+	if err != nil {
+		return nil, err
+	}
+	submittedFrom, err := parseSurveyAnalyticsDate(req.SubmittedFrom, loc)
+	if err != nil {
+		return nil, err
+	}
+	submittedTo, err := parseSurveyAnalyticsDate(req.SubmittedTo, loc)
+	if err != nil {
+		return nil, err
+	}
+	if submittedTo != nil {
+		// the range includes the whole last day
+		nextDay := submittedTo.AddDate(0, 0, 1)
+		submittedTo = &nextDay
+	}
+
+	return &surveyAnalyticsFilter{
+		OrganizationID: req.OrganizationID,
+		JoinedFrom:     joinedFrom,
+		JoinedTo:       joinedTo,
+		SubmittedFrom:  submittedFrom,
+		SubmittedTo:    submittedTo,
+	}, nil
+}
+
+// FindSurveyAnalytics aggregates the answers to a survey template of the employee tasks that
+// match the organization and join date filters. The organization of an employee is asked from
+// the employee service, so that filter is only applied when it is given.
+func (uc *SurveyAnalyticsUseCase) FindSurveyAnalytics(req *request.SurveyAnalyticsRequest) (*response.SurveyAnalyticsResponse, error) {
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": req.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.FindSurveyAnalytics] error finding survey template: ", err)
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, fmt.Errorf("survey template not found")
+	}
+
+	filter, err := parseSurveyAnalyticsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.surveyAnalytics(surveyTemplate, filter)
+}
+
+// FindSurveyVersionAnalytics aggregates every version of the survey of a survey template with
+// the same filters. Each version only counts the employee tasks that answer it.
+func (uc *SurveyAnalyticsUseCase) FindSurveyVersionAnalytics(req *request.SurveyAnalyticsRequest) (*response.SurveyVersionAnalyticsResponse, error) {
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": req.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.FindSurveyVersionAnalytics] error finding survey template: ", err)
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, fmt.Errorf("survey template not found")
+	}
+
+	filter, err := parseSurveyAnalyticsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := uc.SurveyTemplateRepository.FindAllVersions(surveyTemplate.SurveyNumber)
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.FindSurveyVersionAnalytics] error finding survey template versions: ", err)
+		return nil, err
+	}
+
+	versionAnalytics := make([]response.SurveyAnalyticsResponse, 0, len(*versions))
+	for _, version := range *versions {
+		// the versions are listed without their questions
+		versionTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+			"id": version.ID,
+		})
+		if err != nil {
+			uc.Log.Error("[SurveyAnalyticsUseCase.FindSurveyVersionAnalytics] error finding survey template: ", err)
+			return nil, err
+		}
+		if versionTemplate == nil {
+			continue
+		}
+
+		analytics, err := uc.surveyAnalytics(versionTemplate, filter)
+		if err != nil {
+			return nil, err
+		}
+		versionAnalytics = append(versionAnalytics, *analytics)
+	}
+
+	return service.BuildSurveyVersionAnalytics(surveyTemplate.SurveyNumber, versionAnalytics), nil
+}
+
+func (uc *SurveyAnalyticsUseCase) surveyAnalytics(surveyTemplate *entity.SurveyTemplate, filter *surveyAnalyticsFilter) (*response.SurveyAnalyticsResponse, error) {
+	employeeTasks, err := uc.EmployeeTaskRepository.FindAllBySurveyTemplateID(surveyTemplate.ID)
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.surveyAnalytics] error finding employee tasks: ", err)
+		return nil, err
+	}
+
+	employeeTasks, err = uc.filterByJoinDate(*employeeTasks, filter.JoinedFrom, filter.JoinedTo)
+	if err != nil {
+		return nil, err
+	}
+	if filter.OrganizationID != "" {
+		employeeTasks = uc.filterByOrganization(*employeeTasks, filter.OrganizationID)
+	}
+
+	employeeTaskIDs := make([]uuid.UUID, 0, len(*employeeTasks))
+	for _, employeeTask := range *employeeTasks {
+		employeeTaskIDs = append(employeeTaskIDs, employeeTask.ID)
+	}
+
+	surveyResponses, err := uc.SurveyResponseRepository.FindAllBySurveyTemplateIDAndEmployeeTaskIDs(surveyTemplate.ID, employeeTaskIDs, filter.SubmittedFrom, filter.SubmittedTo)
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.surveyAnalytics] error finding survey responses: ", err)
+		return nil, err
+	}
+
+	return service.BuildSurveyAnalytics(surveyTemplate, employeeTaskIDs, surveyResponses), nil
+}
+
+// filterByJoinDate keeps the tasks of employees hired within the range. Employees without a
+// hiring record only pass when no range is given.
+func (uc *SurveyAnalyticsUseCase) filterByJoinDate(employeeTasks []entity.EmployeeTask, joinedFrom, joinedTo *time.Time) (*[]entity.EmployeeTask, error) {
+	if joinedFrom == nil && joinedTo == nil {
+		return &employeeTasks, nil
+	}
+
+	employeeIDs := make([]uuid.UUID, 0, len(employeeTasks))
+	for _, employeeTask := range employeeTasks {
+		if employeeTask.EmployeeID != nil {
+			employeeIDs = append(employeeIDs, *employeeTask.EmployeeID)
+		}
+	}
+
+	employeeHirings, err := uc.EmployeeHiringRepository.FindAllLatestByEmployeeIDs(employeeIDs)
+	if err != nil {
+		uc.Log.Error("[SurveyAnalyticsUseCase.filterByJoinDate] error finding employee hirings: ", err)
+		return nil, err
+	}
+
+	hiringDates := make(map[uuid.UUID]string, len(*employeeHirings))
+	for _, employeeHiring := range *employeeHirings {
+		hiringDates[employeeHiring.EmployeeID] = employeeHiring.HiringDate.Format("2006-01-02")
+	}
+
+	filtered := make([]entity.EmployeeTask, 0, len(employeeTasks))
+	for _, employeeTask := range employeeTasks {
+		if employeeTask.EmployeeID == nil {
+			continue
+		}
+		hiringDate, ok := hiringDates[*employeeTask.EmployeeID]
+		if !ok {
+			continue
+		}
+		if joinedFrom != nil && hiringDate < joinedFrom.Format("2006-01-02") {
+			continue
+		}
+		if joinedTo != nil && hiringDate > joinedTo.Format("2006-01-02") {
+			continue
+		}
+		filtered = append(filtered, employeeTask)
+	}
+
+	return &filtered, nil
+}
+
+// filterByOrganization keeps the tasks of employees in the organization. An employee that
+// cannot be found is left out rather than failing the whole report.
+func (uc *SurveyAnalyticsUseCase) filterByOrganization(employeeTasks []entity.EmployeeTask, organizationID string) *[]entity.EmployeeTask {
+	inOrganization := make(map[uuid.UUID]bool)
+	filtered := make([]entity.EmployeeTask, 0, len(employeeTasks))
+	for _, employeeTask := range employeeTasks {
+		if employeeTask.EmployeeID == nil {
+			continue
+		}
+
+		matches, ok := inOrganization[*employeeTask.EmployeeID]
+		if !ok {
+			employee, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+				ID: employeeTask.EmployeeID.String(),
+			})
+			if err != nil {
+				uc.Log.Error("[SurveyAnalyticsUseCase.filterByOrganization] error finding employee: ", err)
+			}
+			matches = err == nil && employee != nil && employee.OrganizationID.String() == organizationID
+			inOrganization[*employeeTask.EmployeeID] = matches
+		}
+
+		if matches {
+			filtered = append(filtered, employeeTask)
+		}
+	}
+
+	return &filtered
+}
+
+func parseSurveyAnalyticsDate(date string, loc *time.Location) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+
+	parsedDate, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %s: %w", date, err)
+	}
+
+	return &parsedDate, nil
+}
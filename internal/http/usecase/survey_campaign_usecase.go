@@ -0,0 +1,615 @@
+package usecase
+
+import (
+	"errors"
+	"html"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// surveyCampaignRunMutex keeps the scheduler and a run asked for through the API from going
+// through the same hires at once.
+var surveyCampaignRunMutex sync.Mutex
+
+type ISurveyCampaignUseCase interface {
+	CreateSurveyCampaign(req *request.CreateSurveyCampaignRequest) (*response.SurveyCampaignResponse, error)
+	UpdateSurveyCampaign(req *request.UpdateSurveyCampaignRequest) (*response.SurveyCampaignResponse, error)
+	DeleteSurveyCampaign(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*response.SurveyCampaignResponse, error)
+	FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.SurveyCampaignResponse, int64, error)
+	FindAllDispatchesPaginated(surveyCampaignID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]response.SurveyCampaignDispatchResponse, int64, error)
+	RunSurveyCampaigns(now time.Time) (*response.SurveyCampaignRunResponse, error)
+}
+
+type SurveyCampaignUseCase struct {
+	Log                      *logrus.Logger
+	Viper                    *viper.Viper
+	DTO                      dto.ISurveyCampaignDTO
+	Repository               repository.ISurveyCampaignRepository
+	DispatchRepository       repository.ISurveyCampaignDispatchRepository
+	SurveyTemplateRepository repository.ISurveyTemplateRepository
+	EmployeeTaskRepository   repository.IEmployeeTaskRepository
+	EmployeeHiringRepository repository.IEmployeeHiringRepository
+	TemplateTaskRuleService  service.ITemplateTaskRuleService
+	MailService              service.IMailService
+	EmployeeMessage          messaging.IEmployeeMessage
+	CalendarService          service.ICalendarService
+}
+
+func NewSurveyCampaignUseCase(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	surveyCampaignDTO dto.ISurveyCampaignDTO,
+	repository repository.ISurveyCampaignRepository,
+	dispatchRepository repository.ISurveyCampaignDispatchRepository,
+	surveyTemplateRepository repository.ISurveyTemplateRepository,
+	employeeTaskRepository repository.IEmployeeTaskRepository,
+	employeeHiringRepository repository.IEmployeeHiringRepository,
+	templateTaskRuleService service.ITemplateTaskRuleService,
+	mailService service.IMailService,
+	employeeMessage messaging.IEmployeeMessage,
+	calendarService service.ICalendarService,
+) ISurveyCampaignUseCase {
+	return &SurveyCampaignUseCase{
+		Log:                      log,
+		Viper:                    viper,
+		DTO:                      surveyCampaignDTO,
+		Repository:               repository,
+		DispatchRepository:       dispatchRepository,
+		SurveyTemplateRepository: surveyTemplateRepository,
+		EmployeeTaskRepository:   employeeTaskRepository,
+		EmployeeHiringRepository: employeeHiringRepository,
+		TemplateTaskRuleService:  templateTaskRuleService,
+		MailService:              mailService,
+		EmployeeMessage:          employeeMessage,
+		CalendarService:          calendarService,
+	}
+}
+
+func SurveyCampaignUseCaseFactory(log *logrus.Logger, viper *viper.Viper) ISurveyCampaignUseCase {
+	surveyCampaignDTO := dto.SurveyCampaignDTOFactory(log, viper)
+	repo := repository.SurveyCampaignRepositoryFactory(log)
+	dispatchRepository := repository.SurveyCampaignDispatchRepositoryFactory(log)
+	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
+	employeeTaskRepository := repository.EmployeeTaskRepositoryFactory(log)
+	employeeHiringRepository := repository.EmployeeHiringRepositoryFactory(log)
+	templateTaskRuleService := service.TemplateTaskRuleServiceFactory(log)
+	mailService := service.MailServiceFactory(log, viper)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	calendarService := service.CalendarServiceFactory(log)
+	return NewSurveyCampaignUseCase(
+		log,
+		viper,
+		surveyCampaignDTO,
+		repo,
+		dispatchRepository,
+		surveyTemplateRepository,
+		employeeTaskRepository,
+		employeeHiringRepository,
+		templateTaskRuleService,
+		mailService,
+		employeeMessage,
+		calendarService,
+	)
+}
+
+func (uc *SurveyCampaignUseCase) CreateSurveyCampaign(req *request.CreateSurveyCampaignRequest) (*response.SurveyCampaignResponse, error) {
+	status := req.Status
+	if status == "" {
+		status = string(entity.SURVEY_CAMPAIGN_STATUS_ENUM_ACTIVE)
+	}
+	startDate := req.StartDate
+	if startDate == "" {
+		loc, _ := time.LoadLocation("Asia/Jakarta")
+		startDate = time.Now().In(loc).Format("2006-01-02")
+	}
+
+	surveyCampaign, err := uc.newSurveyCampaign(&request.UpdateSurveyCampaignRequest{
+		Name:             req.Name,
+		SurveyTemplateID: req.SurveyTemplateID,
+		Status:           status,
+		StartDate:        startDate,
+		ResponseDays:     req.ResponseDays,
+		ReminderDays:     req.ReminderDays,
+		OffsetDays:       req.OffsetDays,
+		Rules:            req.Rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if req.Actor.EmployeeID != uuid.Nil {
+		surveyCampaign.CreatedBy = &req.Actor.EmployeeID
+	}
+
+	surveyCampaign, err = uc.Repository.CreateSurveyCampaign(surveyCampaign)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.CreateSurveyCampaign] error creating survey campaign: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(surveyCampaign), nil
+}
+
+// UpdateSurveyCampaign changes the campaign for the surveys sent from now on.
+func (uc *SurveyCampaignUseCase) UpdateSurveyCampaign(req *request.UpdateSurveyCampaignRequest) (*response.SurveyCampaignResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.UpdateSurveyCampaign] error parsing id: ", err)
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.UpdateSurveyCampaign] error finding survey campaign: ", err)
+		return nil, err
+	}
+	if exist == nil {
+		return nil, errors.New("survey campaign not found")
+	}
+
+	surveyCampaign, err := uc.newSurveyCampaign(req)
+	if err != nil {
+		return nil, err
+	}
+	surveyCampaign.ID = exist.ID
+
+	surveyCampaign, err = uc.Repository.UpdateSurveyCampaign(surveyCampaign)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.UpdateSurveyCampaign] error updating survey campaign: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(surveyCampaign), nil
+}
+
+// newSurveyCampaign checks the request and builds the campaign it asks for. The survey
+// template has to be published, the tasks get its latest published version when sent.
+func (uc *SurveyCampaignUseCase) newSurveyCampaign(req *request.UpdateSurveyCampaignRequest) (*entity.SurveyCampaign, error) {
+	surveyTemplateID, err := uuid.Parse(req.SurveyTemplateID)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.newSurveyCampaign] error parsing survey template id: ", err)
+		return nil, err
+	}
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": surveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.newSurveyCampaign] error finding survey template: ", err)
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, errors.New("survey template not found")
+	}
+	if surveyTemplate.Status != entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED {
+		return nil, errors.New("survey template " + surveyTemplate.SurveyNumber + " is not published")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.newSurveyCampaign] error parsing start date: ", err)
+		return nil, err
+	}
+	if req.ReminderDays != nil && *req.ReminderDays >= req.ResponseDays {
+		return nil, errors.New("reminder_days must be less than response_days")
+	}
+	if err := uc.TemplateTaskRuleService.ValidateRules(req.Rules); err != nil {
+		return nil, err
+	}
+
+	surveyCampaign := &entity.SurveyCampaign{
+		Name:             req.Name,
+		SurveyTemplateID: surveyTemplate.ID,
+		Status:           entity.SurveyCampaignStatusEnum(req.Status),
+		StartDate:        startDate,
+		ResponseDays:     req.ResponseDays,
+		ReminderDays:     req.ReminderDays,
+	}
+
+	seen := make(map[int]bool, len(req.OffsetDays))
+	for _, offsetDays := range req.OffsetDays {
+		if seen[offsetDays] {
+			return nil, errors.New("offset of " + strconv.Itoa(offsetDays) + " days is given twice")
+		}
+		seen[offsetDays] = true
+		surveyCampaign.SurveyCampaignOffsets = append(surveyCampaign.SurveyCampaignOffsets, entity.SurveyCampaignOffset{
+			OffsetDays: offsetDays,
+		})
+	}
+
+	for _, rule := range req.Rules {
+		surveyCampaign.SurveyCampaignRules = append(surveyCampaign.SurveyCampaignRules, entity.SurveyCampaignRule{
+			GroupNumber: rule.GroupNumber,
+			Criterion:   entity.TemplateTaskRuleCriterionEnum(rule.Criterion),
+			Operator:    entity.TemplateTaskRuleOperatorEnum(rule.Operator),
+			Value:       rule.Value,
+		})
+	}
+
+	return surveyCampaign, nil
+}
+
+// DeleteSurveyCampaign stops the campaign. The surveys it sent stay open until their due date.
+func (uc *SurveyCampaignUseCase) DeleteSurveyCampaign(id uuid.UUID) error {
+	surveyCampaign, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.DeleteSurveyCampaign] error finding survey campaign: ", err)
+		return err
+	}
+	if surveyCampaign == nil {
+		return errors.New("survey campaign not found")
+	}
+
+	if err := uc.Repository.DeleteSurveyCampaign(surveyCampaign); err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.DeleteSurveyCampaign] error deleting survey campaign: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (uc *SurveyCampaignUseCase) FindByID(id uuid.UUID) (*response.SurveyCampaignResponse, error) {
+	surveyCampaign, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.FindByID] error finding survey campaign: ", err)
+		return nil, err
+	}
+	if surveyCampaign == nil {
+		return nil, errors.New("survey campaign not found")
+	}
+
+	return uc.DTO.ConvertEntityToResponse(surveyCampaign), nil
+}
+
+func (uc *SurveyCampaignUseCase) FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.SurveyCampaignResponse, int64, error) {
+	surveyCampaigns, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, status, sort)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.FindAllPaginated] error finding survey campaigns: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyCampaignResponse, 0, len(*surveyCampaigns))
+	for _, surveyCampaign := range *surveyCampaigns {
+		responses = append(responses, *uc.DTO.ConvertEntityToResponse(&surveyCampaign))
+	}
+
+	return &responses, total, nil
+}
+
+func (uc *SurveyCampaignUseCase) FindAllDispatchesPaginated(surveyCampaignID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]response.SurveyCampaignDispatchResponse, int64, error) {
+	dispatches, total, err := uc.DispatchRepository.FindAllPaginatedBySurveyCampaignID(surveyCampaignID, page, pageSize, status, sort)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.FindAllDispatchesPaginated] error finding survey campaign dispatches: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyCampaignDispatchResponse, 0, len(*dispatches))
+	for _, dispatch := range *dispatches {
+		responses = append(responses, *uc.DTO.ConvertDispatchEntityToResponse(&dispatch))
+	}
+
+	return &responses, total, nil
+}
+
+// RunSurveyCampaigns sends the surveys that are due by now, reminds the employees who have
+// not answered theirs and closes the surveys past their due date. A hire that cannot be
+// handled is counted as failed and tried again on the next run.
+func (uc *SurveyCampaignUseCase) RunSurveyCampaigns(now time.Time) (*response.SurveyCampaignRunResponse, error) {
+	surveyCampaignRunMutex.Lock()
+	defer surveyCampaignRunMutex.Unlock()
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	now = now.In(loc)
+
+	res := &response.SurveyCampaignRunResponse{}
+	if err := uc.sendSurveyCampaigns(now, res); err != nil {
+		return nil, err
+	}
+	if err := uc.followUpSurveyCampaignDispatches(now, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (uc *SurveyCampaignUseCase) sendSurveyCampaigns(now time.Time, res *response.SurveyCampaignRunResponse) error {
+	surveyCampaigns, err := uc.Repository.FindAllActive()
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.sendSurveyCampaigns] error finding active survey campaigns: ", err)
+		return err
+	}
+	if len(*surveyCampaigns) == 0 {
+		return nil
+	}
+
+	employeeHirings, err := uc.EmployeeHiringRepository.FindAllActive()
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.sendSurveyCampaigns] error finding active employee hirings: ", err)
+		return err
+	}
+
+	today := now.Format("2006-01-02")
+	profiles := make(map[uuid.UUID]*service.HireProfile)
+	for _, surveyCampaign := range *surveyCampaigns {
+		dispatches, err := uc.DispatchRepository.FindAllBySurveyCampaignID(surveyCampaign.ID)
+		if err != nil {
+			uc.Log.Error("[SurveyCampaignUseCase.sendSurveyCampaigns] error finding survey campaign dispatches: ", err)
+			return err
+		}
+		handled := make(map[string]bool, len(*dispatches))
+		for _, dispatch := range *dispatches {
+			handled[dispatch.EmployeeHiringID.String()+"/"+strconv.Itoa(dispatch.OffsetDays)] = true
+		}
+
+		startDate := surveyCampaign.StartDate.Format("2006-01-02")
+		for _, employeeHiring := range *employeeHirings {
+			for _, offset := range surveyCampaign.SurveyCampaignOffsets {
+				// the offsets are the pulse cadence after joining, e.g. 30/60/90 days, and stay calendar
+				// days on purpose, only the time given to answer counts working days
+				scheduledOn := employeeHiring.HiringDate.AddDate(0, 0, offset.OffsetDays)
+				day := scheduledOn.Format("2006-01-02")
+				if day > today || day < startDate || handled[employeeHiring.ID.String()+"/"+strconv.Itoa(offset.OffsetDays)] {
+					continue
+				}
+
+				dispatch := &entity.SurveyCampaignDispatch{
+					SurveyCampaignID: surveyCampaign.ID,
+					EmployeeHiringID: employeeHiring.ID,
+					OffsetDays:       offset.OffsetDays,
+					EmployeeID:       employeeHiring.EmployeeID,
+					ScheduledOn:      scheduledOn,
+				}
+				if err := uc.dispatchSurveyCampaign(&surveyCampaign, dispatch, now, profiles); err != nil {
+					uc.Log.Errorf("[SurveyCampaignUseCase.sendSurveyCampaigns] error sending survey campaign %s to employee %s: %s", surveyCampaign.ID, employeeHiring.EmployeeID, err.Error())
+					res.Failed++
+					continue
+				}
+				if dispatch.Status == entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SENT {
+					res.Sent++
+				} else {
+					res.Skipped++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// dispatchSurveyCampaign gives the hire the survey task, or records why it was skipped. The
+// task is removed again when the dispatch cannot be recorded, otherwise the next run would
+// send it a second time.
+func (uc *SurveyCampaignUseCase) dispatchSurveyCampaign(surveyCampaign *entity.SurveyCampaign, dispatch *entity.SurveyCampaignDispatch, now time.Time, profiles map[uuid.UUID]*service.HireProfile) error {
+	profile, err := uc.resolveSurveyCampaignProfile(dispatch.EmployeeID, profiles)
+	if err != nil {
+		return err
+	}
+	dueDate, err := uc.CalendarService.AddWorkingDays(profile.OrganizationID, dispatch.ScheduledOn, surveyCampaign.ResponseDays)
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.dispatchSurveyCampaign] error calculating due date: ", err)
+		return err
+	}
+	dispatch.DueDate = &dueDate
+
+	matched := false
+	if dueDate.Format("2006-01-02") >= now.Format("2006-01-02") {
+		matched, err = uc.matchSurveyCampaignAudience(surveyCampaign, dispatch.EmployeeID, profiles)
+		if err != nil {
+			return err
+		}
+	}
+
+	var employeeTask *entity.EmployeeTask
+	switch {
+	case dueDate.Format("2006-01-02") < now.Format("2006-01-02"):
+		dispatch.Status = entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SKIPPED
+		dispatch.Message = "the due date passed before the survey could be sent"
+	case !matched:
+		dispatch.Status = entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SKIPPED
+		dispatch.Message = "the employee is outside the audience of the campaign"
+	default:
+		// the survey is sent in its latest published version, the surveys sent before keep theirs
+		surveyTemplateID := surveyCampaign.SurveyTemplateID
+		var latest *entity.SurveyTemplate
+		latest, err = uc.SurveyTemplateRepository.FindLatestPublishedVersion(surveyTemplateID)
+		if err != nil {
+			uc.Log.Error("[SurveyCampaignUseCase.dispatchSurveyCampaign] error finding latest survey template version: ", err)
+			return err
+		}
+		if latest != nil {
+			surveyTemplateID = latest.ID
+		}
+
+		employeeID := dispatch.EmployeeID
+		employeeTask, err = uc.EmployeeTaskRepository.CreateEmployeeTask(&entity.EmployeeTask{
+			EmployeeID:       &employeeID,
+			SurveyTemplateID: &surveyTemplateID,
+			Name:             surveyCampaign.Name,
+			Priority:         entity.EMPLOYEE_TASK_PRIORITY_ENUM_MEDIUM,
+			Description:      "Survey sent " + strconv.Itoa(dispatch.OffsetDays) + " days after joining",
+			StartDate:        time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+			EndDate:          dueDate,
+			Status:           entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE,
+			Kanban:           entity.EMPLOYEE_TASK_KANBAN_ENUM_TODO,
+			Source:           entity.TASK_SOURCE_ONBOARDING,
+			Kind:             entity.TASK_KIND_ENUM_SURVEY,
+		})
+		if err != nil {
+			return err
+		}
+		dispatch.Status = entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SENT
+		dispatch.EmployeeTaskID = &employeeTask.ID
+		dispatch.SentAt = &now
+	}
+
+	if _, err := uc.DispatchRepository.CreateSurveyCampaignDispatch(dispatch); err != nil {
+		if employeeTask != nil {
+			if err := uc.EmployeeTaskRepository.DeleteEmployeeTask(employeeTask); err != nil {
+				uc.Log.Error("[SurveyCampaignUseCase.dispatchSurveyCampaign] error deleting employee task: ", err)
+			}
+		}
+		return err
+	}
+
+	if employeeTask != nil {
+		body := "<p>A new survey, <b>" + html.EscapeString(surveyCampaign.Name) + "</b>, is waiting for you in your onboarding tasks. " +
+			"Please answer it by " + dueDate.Format("2 January 2006") + ".</p>"
+		if err := uc.mailSurveyCampaignEmployee(dispatch.EmployeeID, surveyCampaign.Name, body); err != nil {
+			uc.Log.Warnf("[SurveyCampaignUseCase.dispatchSurveyCampaign] error mailing employee %s: %s", dispatch.EmployeeID, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// matchSurveyCampaignAudience evaluates the rules of the campaign against the current job of
+// the employee, which is looked up once per run.
+func (uc *SurveyCampaignUseCase) matchSurveyCampaignAudience(surveyCampaign *entity.SurveyCampaign, employeeID uuid.UUID, profiles map[uuid.UUID]*service.HireProfile) (bool, error) {
+	if len(surveyCampaign.SurveyCampaignRules) == 0 {
+		return true, nil
+	}
+
+	profile, err := uc.resolveSurveyCampaignProfile(employeeID, profiles)
+	if err != nil {
+		return false, err
+	}
+
+	return uc.TemplateTaskRuleService.MatchRules(profile, surveyCampaign.TemplateTaskRules())
+}
+
+// resolveSurveyCampaignProfile looks up the current job and organization of the employee once
+// per run.
+func (uc *SurveyCampaignUseCase) resolveSurveyCampaignProfile(employeeID uuid.UUID, profiles map[uuid.UUID]*service.HireProfile) (*service.HireProfile, error) {
+	if profile, ok := profiles[employeeID]; ok {
+		return profile, nil
+	}
+
+	profile, err := uc.TemplateTaskRuleService.ResolveHireProfile(&request.CreateEmployeeTasksForRecruitment{
+		EmployeeID: employeeID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	profiles[employeeID] = profile
+
+	return profile, nil
+}
+
+// followUpSurveyCampaignDispatches goes through the open surveys. Answered ones are completed,
+// the ones past their due date are closed and the others are reminded once, when their
+// campaign asks for it.
+func (uc *SurveyCampaignUseCase) followUpSurveyCampaignDispatches(now time.Time, res *response.SurveyCampaignRunResponse) error {
+	dispatches, err := uc.DispatchRepository.FindAllSent()
+	if err != nil {
+		uc.Log.Error("[SurveyCampaignUseCase.followUpSurveyCampaignDispatches] error finding sent survey campaign dispatches: ", err)
+		return err
+	}
+
+	today := now.Format("2006-01-02")
+	profiles := make(map[uuid.UUID]*service.HireProfile)
+	for _, dispatch := range *dispatches {
+		employeeTask := dispatch.EmployeeTask
+		switch {
+		case employeeTask != nil && employeeTask.Kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED:
+			dispatch.Status = entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_COMPLETED
+			if err := uc.DispatchRepository.UpdateSurveyCampaignDispatchStatus(&dispatch); err != nil {
+				res.Failed++
+				continue
+			}
+			res.Completed++
+		case employeeTask == nil || (dispatch.DueDate != nil && dispatch.DueDate.Format("2006-01-02") < today):
+			if employeeTask == nil {
+				dispatch.Message = "the employee task was deleted"
+			} else if err := uc.EmployeeTaskRepository.CloseByID(employeeTask.ID, now); err != nil {
+				res.Failed++
+				continue
+			}
+			dispatch.Status = entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_EXPIRED
+			dispatch.ClosedAt = &now
+			if err := uc.DispatchRepository.UpdateSurveyCampaignDispatchStatus(&dispatch); err != nil {
+				res.Failed++
+				continue
+			}
+			res.Expired++
+		default:
+			reminderDue, err := uc.isSurveyCampaignReminderDue(&dispatch, today, profiles)
+			if err != nil {
+				uc.Log.Warnf("[SurveyCampaignUseCase.followUpSurveyCampaignDispatches] error checking the reminder of employee %s: %s", dispatch.EmployeeID, err.Error())
+				res.Failed++
+				continue
+			}
+			if !reminderDue {
+				continue
+			}
+
+			body := "<p>This is a reminder to answer the survey <b>" + html.EscapeString(dispatch.SurveyCampaign.Name) + "</b> in your onboarding tasks. " +
+				"It closes after " + dispatch.DueDate.Format("2 January 2006") + ".</p>"
+			if err := uc.mailSurveyCampaignEmployee(dispatch.EmployeeID, "Reminder: "+dispatch.SurveyCampaign.Name, body); err != nil {
+				uc.Log.Warnf("[SurveyCampaignUseCase.followUpSurveyCampaignDispatches] error reminding employee %s: %s", dispatch.EmployeeID, err.Error())
+				res.Failed++
+				continue
+			}
+			if err := uc.DispatchRepository.UpdateRemindedAtByID(dispatch.ID, now); err != nil {
+				res.Failed++
+				continue
+			}
+			res.Reminded++
+		}
+	}
+
+	return nil
+}
+
+// isSurveyCampaignReminderDue reports whether an open survey is unanswered for the reminder
+// days of its campaign, counted in working days of the employee's organization. Paused tasks
+// are not reminded.
+func (uc *SurveyCampaignUseCase) isSurveyCampaignReminderDue(dispatch *entity.SurveyCampaignDispatch, today string, profiles map[uuid.UUID]*service.HireProfile) (bool, error) {
+	surveyCampaign := dispatch.SurveyCampaign
+	if surveyCampaign == nil || surveyCampaign.ReminderDays == nil || dispatch.RemindedAt != nil || dispatch.SentAt == nil || dispatch.DueDate == nil {
+		return false, nil
+	}
+	if dispatch.EmployeeTask.Status != entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE {
+		return false, nil
+	}
+
+	profile, err := uc.resolveSurveyCampaignProfile(dispatch.EmployeeID, profiles)
+	if err != nil {
+		return false, err
+	}
+	remindOn, err := uc.CalendarService.AddWorkingDays(profile.OrganizationID, *dispatch.SentAt, *surveyCampaign.ReminderDays)
+	if err != nil {
+		return false, err
+	}
+
+	return remindOn.Format("2006-01-02") <= today, nil
+}
+
+func (uc *SurveyCampaignUseCase) mailSurveyCampaignEmployee(employeeID uuid.UUID, subject, body string) error {
+	employee, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: employeeID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if employee.Email == "" {
+		return errors.New("employee has no email")
+	}
+
+	return uc.MailService.SendMail(service.MailData{
+		From:    uc.Viper.GetString("mail.from"),
+		To:      []string{employee.Email},
+		Subject: subject,
+		Body:    "<p>Dear " + html.EscapeString(employee.Name) + ",</p>" + body,
+	})
+}
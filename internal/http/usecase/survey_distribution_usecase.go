@@ -0,0 +1,534 @@
+package usecase
+
+import (
+	"errors"
+	"html"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type ISurveyDistributionUseCase interface {
+	CreateSurveyDistribution(req *request.CreateSurveyDistributionRequest) (*response.SurveyDistributionResponse, error)
+	UpdateSurveyDistribution(req *request.UpdateSurveyDistributionRequest) (*response.SurveyDistributionResponse, error)
+	CloseSurveyDistribution(id uuid.UUID) (*response.SurveyDistributionResponse, error)
+	DeleteSurveyDistribution(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*response.SurveyDistributionResponse, error)
+	FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.SurveyDistributionResponse, int64, error)
+	FindAllRecipientsPaginated(surveyDistributionID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]response.SurveyDistributionRecipientResponse, int64, error)
+	FindAllByEmployeeID(employeeID uuid.UUID) (*[]response.SurveyDistributionRecipientResponse, error)
+	FindSurveyDistributionAnalytics(id uuid.UUID) (*response.SurveyAnalyticsResponse, error)
+}
+
+type SurveyDistributionUseCase struct {
+	Log                      *logrus.Logger
+	Viper                    *viper.Viper
+	DTO                      dto.ISurveyDistributionDTO
+	Repository               repository.ISurveyDistributionRepository
+	RecipientRepository      repository.ISurveyDistributionRecipientRepository
+	SurveyTemplateRepository repository.ISurveyTemplateRepository
+	SurveyResponseRepository repository.ISurveyResponseRepository
+	EmployeeHiringRepository repository.IEmployeeHiringRepository
+	TemplateTaskRuleService  service.ITemplateTaskRuleService
+	MailService              service.IMailService
+	EmployeeMessage          messaging.IEmployeeMessage
+}
+
+func NewSurveyDistributionUseCase(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	surveyDistributionDTO dto.ISurveyDistributionDTO,
+	repository repository.ISurveyDistributionRepository,
+	recipientRepository repository.ISurveyDistributionRecipientRepository,
+	surveyTemplateRepository repository.ISurveyTemplateRepository,
+	surveyResponseRepository repository.ISurveyResponseRepository,
+	employeeHiringRepository repository.IEmployeeHiringRepository,
+	templateTaskRuleService service.ITemplateTaskRuleService,
+	mailService service.IMailService,
+	employeeMessage messaging.IEmployeeMessage,
+) ISurveyDistributionUseCase {
+	return &SurveyDistributionUseCase{
+		Log:                      log,
+		Viper:                    viper,
+		DTO:                      surveyDistributionDTO,
+		Repository:               repository,
+		RecipientRepository:      recipientRepository,
+		SurveyTemplateRepository: surveyTemplateRepository,
+		SurveyResponseRepository: surveyResponseRepository,
+		EmployeeHiringRepository: employeeHiringRepository,
+		TemplateTaskRuleService:  templateTaskRuleService,
+		MailService:              mailService,
+		EmployeeMessage:          employeeMessage,
+	}
+}
+
+func SurveyDistributionUseCaseFactory(log *logrus.Logger, viper *viper.Viper) ISurveyDistributionUseCase {
+	surveyDistributionDTO := dto.SurveyDistributionDTOFactory(log, viper)
+	repo := repository.SurveyDistributionRepositoryFactory(log)
+	recipientRepository := repository.SurveyDistributionRecipientRepositoryFactory(log)
+	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
+	surveyResponseRepository := repository.SurveyResponseRepositoryFactory(log)
+	employeeHiringRepository := repository.EmployeeHiringRepositoryFactory(log)
+	templateTaskRuleService := service.TemplateTaskRuleServiceFactory(log)
+	mailService := service.MailServiceFactory(log, viper)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewSurveyDistributionUseCase(
+		log,
+		viper,
+		surveyDistributionDTO,
+		repo,
+		recipientRepository,
+		surveyTemplateRepository,
+		surveyResponseRepository,
+		employeeHiringRepository,
+		templateTaskRuleService,
+		mailService,
+		employeeMessage,
+	)
+}
+
+// CreateSurveyDistribution sends the survey template to its recipients, who are mailed about it.
+// The distribution keeps the version of the survey template it was created with.
+func (uc *SurveyDistributionUseCase) CreateSurveyDistribution(req *request.CreateSurveyDistributionRequest) (*response.SurveyDistributionResponse, error) {
+	surveyTemplateID, err := uuid.Parse(req.SurveyTemplateID)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.CreateSurveyDistribution] error parsing survey template id: ", err)
+		return nil, err
+	}
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": surveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.CreateSurveyDistribution] error finding survey template: ", err)
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, errors.New("survey template not found")
+	}
+	if surveyTemplate.Status != entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED {
+		return nil, errors.New("survey template " + surveyTemplate.SurveyNumber + " is not published")
+	}
+	// quiz attempts are graded per employee task
+	if surveyTemplate.IsQuiz {
+		return nil, errors.New("survey template " + surveyTemplate.SurveyNumber + " is a quiz, quizzes are sent as employee tasks")
+	}
+
+	opensOn := req.OpensOn
+	if opensOn == "" {
+		loc, _ := time.LoadLocation("Asia/Jakarta")
+		opensOn = time.Now().In(loc).Format("2006-01-02")
+	}
+
+	surveyDistribution, err := uc.newSurveyDistribution(&request.UpdateSurveyDistributionRequest{
+		Name:        req.Name,
+		OpensOn:     opensOn,
+		ClosesOn:    req.ClosesOn,
+		EmployeeIDs: req.EmployeeIDs,
+		Rules:       req.Rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+	surveyDistribution.SurveyTemplateID = surveyTemplate.ID
+	if req.Actor.EmployeeID != uuid.Nil {
+		surveyDistribution.CreatedBy = &req.Actor.EmployeeID
+	}
+
+	recipients, err := uc.resolveSurveyDistributionRecipients(surveyDistribution, req.EmployeeIDs, map[uuid.UUID]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("the survey distribution reaches no employee")
+	}
+	surveyDistribution.SurveyDistributionRecipients = recipients
+
+	created, err := uc.Repository.CreateSurveyDistribution(surveyDistribution)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.CreateSurveyDistribution] error creating survey distribution: ", err)
+		return nil, err
+	}
+
+	uc.mailSurveyDistributionRecipients(created, recipients)
+
+	return uc.convertSurveyDistribution(created)
+}
+
+// UpdateSurveyDistribution changes the name, dates and rules of the distribution. The
+// employees it reaches now are added to its recipients and mailed, so an update also picks up
+// the hires that matched the rules since it was sent.
+func (uc *SurveyDistributionUseCase) UpdateSurveyDistribution(req *request.UpdateSurveyDistributionRequest) (*response.SurveyDistributionResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.UpdateSurveyDistribution] error parsing id: ", err)
+		return nil, err
+	}
+
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.UpdateSurveyDistribution] error finding survey distribution: ", err)
+		return nil, err
+	}
+	if exist == nil {
+		return nil, errors.New("survey distribution not found")
+	}
+	if exist.ClosedAt != nil {
+		return nil, errors.New("the survey distribution is closed")
+	}
+
+	surveyDistribution, err := uc.newSurveyDistribution(req)
+	if err != nil {
+		return nil, err
+	}
+	surveyDistribution.ID = exist.ID
+	surveyDistribution.SurveyTemplateID = exist.SurveyTemplateID
+
+	existingRecipients, err := uc.RecipientRepository.FindAllBySurveyDistributionID(exist.ID)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.UpdateSurveyDistribution] error finding survey distribution recipients: ", err)
+		return nil, err
+	}
+	sent := make(map[uuid.UUID]bool, len(*existingRecipients))
+	for _, recipient := range *existingRecipients {
+		sent[recipient.EmployeeID] = true
+	}
+
+	recipients, err := uc.resolveSurveyDistributionRecipients(surveyDistribution, req.EmployeeIDs, sent)
+	if err != nil {
+		return nil, err
+	}
+	surveyDistribution.SurveyDistributionRecipients = recipients
+
+	updated, err := uc.Repository.UpdateSurveyDistribution(surveyDistribution)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.UpdateSurveyDistribution] error updating survey distribution: ", err)
+		return nil, err
+	}
+
+	uc.mailSurveyDistributionRecipients(updated, recipients)
+
+	return uc.convertSurveyDistribution(updated)
+}
+
+// newSurveyDistribution checks the request and builds the distribution it asks for, without
+// its survey template and recipients.
+func (uc *SurveyDistributionUseCase) newSurveyDistribution(req *request.UpdateSurveyDistributionRequest) (*entity.SurveyDistribution, error) {
+	if len(req.EmployeeIDs) == 0 && len(req.Rules) == 0 {
+		return nil, errors.New("employee_ids or rules are required")
+	}
+
+	opensOn, err := time.Parse("2006-01-02", req.OpensOn)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.newSurveyDistribution] error parsing opens on: ", err)
+		return nil, err
+	}
+	var closesOn *time.Time
+	if req.ClosesOn != "" {
+		parsed, err := time.Parse("2006-01-02", req.ClosesOn)
+		if err != nil {
+			uc.Log.Error("[SurveyDistributionUseCase.newSurveyDistribution] error parsing closes on: ", err)
+			return nil, err
+		}
+		if parsed.Before(opensOn) {
+			return nil, errors.New("closes_on must not be before opens_on")
+		}
+		closesOn = &parsed
+	}
+	if err := uc.TemplateTaskRuleService.ValidateRules(req.Rules); err != nil {
+		return nil, err
+	}
+
+	surveyDistribution := &entity.SurveyDistribution{
+		Name:     req.Name,
+		OpensOn:  opensOn,
+		ClosesOn: closesOn,
+	}
+	for _, rule := range req.Rules {
+		surveyDistribution.SurveyDistributionRules = append(surveyDistribution.SurveyDistributionRules, entity.SurveyDistributionRule{
+			GroupNumber: rule.GroupNumber,
+			Criterion:   entity.TemplateTaskRuleCriterionEnum(rule.Criterion),
+			Operator:    entity.TemplateTaskRuleOperatorEnum(rule.Operator),
+			Value:       rule.Value,
+		})
+	}
+
+	return surveyDistribution, nil
+}
+
+// resolveSurveyDistributionRecipients lists the employees given and the current hires matching
+// the rules of the distribution, leaving out the ones in sent. A hire whose job cannot be
+// looked up is skipped, a later update of the distribution tries again.
+func (uc *SurveyDistributionUseCase) resolveSurveyDistributionRecipients(surveyDistribution *entity.SurveyDistribution, employeeIDs []string, sent map[uuid.UUID]bool) ([]entity.SurveyDistributionRecipient, error) {
+	recipients := make([]entity.SurveyDistributionRecipient, 0)
+	add := func(employeeID uuid.UUID) {
+		if sent[employeeID] {
+			return
+		}
+		sent[employeeID] = true
+		recipients = append(recipients, entity.SurveyDistributionRecipient{
+			EmployeeID: employeeID,
+			Status:     entity.SURVEY_DISTRIBUTION_RECIPIENT_STATUS_ENUM_PENDING,
+		})
+	}
+
+	for _, employeeID := range employeeIDs {
+		parsedEmployeeID, err := uuid.Parse(employeeID)
+		if err != nil {
+			uc.Log.Error("[SurveyDistributionUseCase.resolveSurveyDistributionRecipients] error parsing employee id: ", err)
+			return nil, err
+		}
+		add(parsedEmployeeID)
+	}
+
+	if len(surveyDistribution.SurveyDistributionRules) == 0 {
+		return recipients, nil
+	}
+
+	employeeHirings, err := uc.EmployeeHiringRepository.FindAllActive()
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.resolveSurveyDistributionRecipients] error finding active employee hirings: ", err)
+		return nil, err
+	}
+	rules := surveyDistribution.TemplateTaskRules()
+	for _, employeeHiring := range *employeeHirings {
+		if sent[employeeHiring.EmployeeID] {
+			continue
+		}
+		profile, err := uc.TemplateTaskRuleService.ResolveHireProfile(&request.CreateEmployeeTasksForRecruitment{
+			EmployeeID: employeeHiring.EmployeeID.String(),
+		})
+		if err != nil {
+			uc.Log.Warnf("[SurveyDistributionUseCase.resolveSurveyDistributionRecipients] error resolving employee %s: %s", employeeHiring.EmployeeID, err.Error())
+			continue
+		}
+		matched, err := uc.TemplateTaskRuleService.MatchRules(profile, rules)
+		if err != nil {
+			uc.Log.Warnf("[SurveyDistributionUseCase.resolveSurveyDistributionRecipients] error matching employee %s: %s", employeeHiring.EmployeeID, err.Error())
+			continue
+		}
+		if matched {
+			add(employeeHiring.EmployeeID)
+		}
+	}
+
+	return recipients, nil
+}
+
+// CloseSurveyDistribution stops the distribution from taking answers before its closing day.
+func (uc *SurveyDistributionUseCase) CloseSurveyDistribution(id uuid.UUID) (*response.SurveyDistributionResponse, error) {
+	surveyDistribution, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.CloseSurveyDistribution] error finding survey distribution: ", err)
+		return nil, err
+	}
+	if surveyDistribution == nil {
+		return nil, errors.New("survey distribution not found")
+	}
+	if surveyDistribution.ClosedAt != nil {
+		return nil, errors.New("the survey distribution is already closed")
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	if err := uc.Repository.CloseByID(surveyDistribution.ID, time.Now().In(loc)); err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.CloseSurveyDistribution] error closing survey distribution: ", err)
+		return nil, err
+	}
+
+	return uc.FindByID(surveyDistribution.ID)
+}
+
+func (uc *SurveyDistributionUseCase) DeleteSurveyDistribution(id uuid.UUID) error {
+	surveyDistribution, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.DeleteSurveyDistribution] error finding survey distribution: ", err)
+		return err
+	}
+	if surveyDistribution == nil {
+		return errors.New("survey distribution not found")
+	}
+
+	if err := uc.Repository.DeleteSurveyDistribution(surveyDistribution); err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.DeleteSurveyDistribution] error deleting survey distribution: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (uc *SurveyDistributionUseCase) FindByID(id uuid.UUID) (*response.SurveyDistributionResponse, error) {
+	surveyDistribution, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindByID] error finding survey distribution: ", err)
+		return nil, err
+	}
+	if surveyDistribution == nil {
+		return nil, errors.New("survey distribution not found")
+	}
+
+	return uc.convertSurveyDistribution(surveyDistribution)
+}
+
+func (uc *SurveyDistributionUseCase) FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]response.SurveyDistributionResponse, int64, error) {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	surveyDistributions, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, status, time.Now().In(loc), sort)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindAllPaginated] error finding survey distributions: ", err)
+		return nil, 0, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(*surveyDistributions))
+	for _, surveyDistribution := range *surveyDistributions {
+		ids = append(ids, surveyDistribution.ID)
+	}
+	counts, err := uc.RecipientRepository.CountByStatusAndSurveyDistributionIDs(ids)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindAllPaginated] error counting survey distribution recipients: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyDistributionResponse, 0, len(*surveyDistributions))
+	for _, surveyDistribution := range *surveyDistributions {
+		res := uc.DTO.ConvertEntityToResponse(&surveyDistribution)
+		setSurveyDistributionCounts(res, counts[surveyDistribution.ID])
+		responses = append(responses, *res)
+	}
+
+	return &responses, total, nil
+}
+
+func (uc *SurveyDistributionUseCase) FindAllRecipientsPaginated(surveyDistributionID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]response.SurveyDistributionRecipientResponse, int64, error) {
+	recipients, total, err := uc.RecipientRepository.FindAllPaginatedBySurveyDistributionID(surveyDistributionID, page, pageSize, status, sort)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindAllRecipientsPaginated] error finding survey distribution recipients: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyDistributionRecipientResponse, 0, len(*recipients))
+	for _, recipient := range *recipients {
+		responses = append(responses, *uc.DTO.ConvertRecipientEntityToResponse(&recipient))
+	}
+
+	return &responses, total, nil
+}
+
+// FindAllByEmployeeID lists the distributions sent to the employee with where the employee is
+// at with each of them.
+func (uc *SurveyDistributionUseCase) FindAllByEmployeeID(employeeID uuid.UUID) (*[]response.SurveyDistributionRecipientResponse, error) {
+	recipients, err := uc.RecipientRepository.FindAllByEmployeeID(employeeID)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindAllByEmployeeID] error finding survey distribution recipients: ", err)
+		return nil, err
+	}
+
+	responses := make([]response.SurveyDistributionRecipientResponse, 0, len(*recipients))
+	for _, recipient := range *recipients {
+		responses = append(responses, *uc.DTO.ConvertRecipientEntityToResponse(&recipient))
+	}
+
+	return &responses, nil
+}
+
+// FindSurveyDistributionAnalytics aggregates the answers of the recipients of the distribution
+// the way the answers of employee tasks are, anonymity settings included.
+func (uc *SurveyDistributionUseCase) FindSurveyDistributionAnalytics(id uuid.UUID) (*response.SurveyAnalyticsResponse, error) {
+	surveyDistribution, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindSurveyDistributionAnalytics] error finding survey distribution: ", err)
+		return nil, err
+	}
+	if surveyDistribution == nil {
+		return nil, errors.New("survey distribution not found")
+	}
+
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": surveyDistribution.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindSurveyDistributionAnalytics] error finding survey template: ", err)
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, errors.New("survey template not found")
+	}
+
+	recipients, err := uc.RecipientRepository.FindAllBySurveyDistributionID(surveyDistribution.ID)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindSurveyDistributionAnalytics] error finding survey distribution recipients: ", err)
+		return nil, err
+	}
+	recipientIDs := make([]uuid.UUID, 0, len(*recipients))
+	for _, recipient := range *recipients {
+		recipientIDs = append(recipientIDs, recipient.ID)
+	}
+
+	surveyResponses, err := uc.SurveyResponseRepository.FindAllBySurveyTemplateIDAndRecipientIDs(surveyTemplate.ID, recipientIDs)
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.FindSurveyDistributionAnalytics] error finding survey responses: ", err)
+		return nil, err
+	}
+
+	return service.BuildSurveyAnalytics(surveyTemplate, recipientIDs, surveyResponses), nil
+}
+
+func (uc *SurveyDistributionUseCase) convertSurveyDistribution(surveyDistribution *entity.SurveyDistribution) (*response.SurveyDistributionResponse, error) {
+	counts, err := uc.RecipientRepository.CountByStatusAndSurveyDistributionIDs([]uuid.UUID{surveyDistribution.ID})
+	if err != nil {
+		uc.Log.Error("[SurveyDistributionUseCase.convertSurveyDistribution] error counting survey distribution recipients: ", err)
+		return nil, err
+	}
+
+	res := uc.DTO.ConvertEntityToResponse(surveyDistribution)
+	setSurveyDistributionCounts(res, counts[surveyDistribution.ID])
+	return res, nil
+}
+
+func setSurveyDistributionCounts(res *response.SurveyDistributionResponse, counts map[entity.SurveyDistributionRecipientStatusEnum]int) {
+	for status, count := range counts {
+		res.Recipients += count
+		if status == entity.SURVEY_DISTRIBUTION_RECIPIENT_STATUS_ENUM_SUBMITTED {
+			res.Submitted += count
+		}
+	}
+}
+
+// mailSurveyDistributionRecipients tells the new recipients about the survey. A recipient who
+// cannot be mailed still finds the survey among their surveys.
+func (uc *SurveyDistributionUseCase) mailSurveyDistributionRecipients(surveyDistribution *entity.SurveyDistribution, recipients []entity.SurveyDistributionRecipient) {
+	body := "<p>A new survey, <b>" + html.EscapeString(surveyDistribution.Name) + "</b>, is waiting for you. " +
+		"It is open from " + surveyDistribution.OpensOn.Format("2 January 2006")
+	if surveyDistribution.ClosesOn != nil {
+		body += " until " + surveyDistribution.ClosesOn.Format("2 January 2006")
+	}
+	body += ".</p>"
+
+	for _, recipient := range recipients {
+		employee, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+			ID: recipient.EmployeeID.String(),
+		})
+		if err != nil {
+			uc.Log.Warnf("[SurveyDistributionUseCase.mailSurveyDistributionRecipients] error finding employee %s: %s", recipient.EmployeeID, err.Error())
+			continue
+		}
+		if employee.Email == "" {
+			continue
+		}
+
+		if err := uc.MailService.SendMail(service.MailData{
+			From:    uc.Viper.GetString("mail.from"),
+			To:      []string{employee.Email},
+			Subject: surveyDistribution.Name,
+			Body:    "<p>Dear " + html.EscapeString(employee.Name) + ",</p>" + body,
+		}); err != nil {
+			uc.Log.Warnf("[SurveyDistributionUseCase.mailSurveyDistributionRecipients] error mailing employee %s: %s", recipient.EmployeeID, err.Error())
+		}
+	}
+}
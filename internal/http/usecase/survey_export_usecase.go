@@ -0,0 +1,650 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	surveyExportDir       = "storage/survey_exports/"
+	surveyExportBatchSize = 200
+	// surveyExportSyncLimit is used when survey_export.sync_limit is not configured
+	surveyExportSyncLimit = 1000
+)
+
+// ErrSurveyExportTooLarge is returned by a direct export with more employee tasks than the
+// sync limit, those have to go through an export job.
+var ErrSurveyExportTooLarge = errors.New("too many survey responses to export directly, create an export job instead")
+
+type ISurveyExportUseCase interface {
+	ExportSurveyResponses(req *request.SurveyExportRequest, w io.Writer) error
+	ValidateDirectExport(req *request.SurveyExportRequest) error
+	CreateSurveyExportJob(req *request.SurveyExportRequest, actor request.TaskActor) (*response.SurveyExportJobResponse, error)
+	FindSurveyExportJobByID(id uuid.UUID) (*response.SurveyExportJobResponse, error)
+	FindAllSurveyExportJobsPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.SurveyExportJobResponse, int64, error)
+}
+
+type SurveyExportUseCase struct {
+	Log                       *logrus.Logger
+	Viper                     *viper.Viper
+	SurveyExportJobRepository repository.ISurveyExportJobRepository
+	SurveyTemplateRepository  repository.ISurveyTemplateRepository
+	SurveyResponseRepository  repository.ISurveyResponseRepository
+	EmployeeTaskRepository    repository.IEmployeeTaskRepository
+	EmployeeMessage           messaging.IEmployeeMessage
+	SurveyExportJobDTO        dto.ISurveyExportJobDTO
+}
+
+func NewSurveyExportUseCase(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	surveyExportJobRepository repository.ISurveyExportJobRepository,
+	surveyTemplateRepository repository.ISurveyTemplateRepository,
+	surveyResponseRepository repository.ISurveyResponseRepository,
+	employeeTaskRepository repository.IEmployeeTaskRepository,
+	employeeMessage messaging.IEmployeeMessage,
+	surveyExportJobDTO dto.ISurveyExportJobDTO,
+) ISurveyExportUseCase {
+	return &SurveyExportUseCase{
+		Log:                       log,
+		Viper:                     viper,
+		SurveyExportJobRepository: surveyExportJobRepository,
+		SurveyTemplateRepository:  surveyTemplateRepository,
+		SurveyResponseRepository:  surveyResponseRepository,
+		EmployeeTaskRepository:    employeeTaskRepository,
+		EmployeeMessage:           employeeMessage,
+		SurveyExportJobDTO:        surveyExportJobDTO,
+	}
+}
+
+func SurveyExportUseCaseFactory(
+	log *logrus.Logger,
+	viper *viper.Viper,
+) ISurveyExportUseCase {
+	surveyExportJobRepository := repository.SurveyExportJobRepositoryFactory(log)
+	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
+	surveyResponseRepository := repository.SurveyResponseRepositoryFactory(log)
+	employeeTaskRepository := repository.EmployeeTaskRepositoryFactory(log)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	surveyExportJobDTO := dto.SurveyExportJobDTOFactory(log, viper)
+	return NewSurveyExportUseCase(
+		log,
+		viper,
+		surveyExportJobRepository,
+		surveyTemplateRepository,
+		surveyResponseRepository,
+		employeeTaskRepository,
+		employeeMessage,
+		surveyExportJobDTO,
+	)
+}
+
+// surveyExportFilter is a parsed export request. SubmittedTo is exclusive, the day after the
+// requested end date. SurveyTemplateIDs are the versions exported, all when empty.
+type surveyExportFilter struct {
+	Format            entity.SurveyExportFormatEnum
+	SurveyTemplateID  *uuid.UUID
+	AllVersions       bool
+	SurveyTemplateIDs []uuid.UUID
+	OrganizationID    *uuid.UUID
+	SubmittedFrom     *time.Time
+	SubmittedTo       *time.Time
+}
+
+type surveyExportEmployee struct {
+	Name           string
+	OrganizationID *uuid.UUID
+}
+
+func parseSurveyExportRequest(req *request.SurveyExportRequest) (*surveyExportFilter, error) {
+	filter := &surveyExportFilter{
+		Format:      entity.SurveyExportFormatEnum(req.Format),
+		AllVersions: req.AllVersions,
+	}
+	if filter.Format == "" {
+		filter.Format = entity.SURVEY_EXPORT_FORMAT_ENUM_XLSX
+	}
+
+	if req.SurveyTemplateID != "" {
+		surveyTemplateID, err := uuid.Parse(req.SurveyTemplateID)
+		if err != nil {
+			return nil, err
+		}
+		filter.SurveyTemplateID = &surveyTemplateID
+	}
+	if req.OrganizationID != "" {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		filter.OrganizationID = &organizationID
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	if req.SubmittedFrom != "" {
+		submittedFrom, err := time.ParseInLocation("2006-01-02", req.SubmittedFrom, loc)
+		if err != nil {
+			return nil, err
+		}
+		filter.SubmittedFrom = &submittedFrom
+	}
+	if req.SubmittedTo != "" {
+		submittedTo, err := time.ParseInLocation("2006-01-02", req.SubmittedTo, loc)
+		if err != nil {
+			return nil, err
+		}
+		submittedTo = submittedTo.AddDate(0, 0, 1)
+		filter.SubmittedTo = &submittedTo
+	}
+
+	return filter, nil
+}
+
+// ValidateDirectExport rejects exports that are too large to be streamed within a request.
+// The organization filter is left out of the count, it is only known per employee.
+func (uc *SurveyExportUseCase) ValidateDirectExport(req *request.SurveyExportRequest) error {
+	filter, err := parseSurveyExportRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.resolveSurveyExportTemplates(filter); err != nil {
+		return err
+	}
+
+	total, err := uc.EmployeeTaskRepository.CountSurveyForExport(filter.SurveyTemplateIDs, filter.SubmittedFrom, filter.SubmittedTo)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.ValidateDirectExport] error counting employee tasks: ", err)
+		return err
+	}
+
+	limit := uc.Viper.GetInt("survey_export.sync_limit")
+	if limit <= 0 {
+		limit = surveyExportSyncLimit
+	}
+	if total > int64(limit) {
+		return ErrSurveyExportTooLarge
+	}
+
+	return nil
+}
+
+func (uc *SurveyExportUseCase) ExportSurveyResponses(req *request.SurveyExportRequest, w io.Writer) error {
+	filter, err := parseSurveyExportRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := uc.resolveSurveyExportTemplates(filter); err != nil {
+		return err
+	}
+
+	return uc.writeSurveyExport(filter, w, nil)
+}
+
+func (uc *SurveyExportUseCase) CreateSurveyExportJob(req *request.SurveyExportRequest, actor request.TaskActor) (*response.SurveyExportJobResponse, error) {
+	filter, err := parseSurveyExportRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.resolveSurveyExportTemplates(filter); err != nil {
+		return nil, err
+	}
+
+	total, err := uc.EmployeeTaskRepository.CountSurveyForExport(filter.SurveyTemplateIDs, filter.SubmittedFrom, filter.SubmittedTo)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.CreateSurveyExportJob] error counting employee tasks: ", err)
+		return nil, err
+	}
+
+	surveyExportJob := &entity.SurveyExportJob{
+		Format:           filter.Format,
+		SurveyTemplateID: filter.SurveyTemplateID,
+		AllVersions:      filter.AllVersions,
+		OrganizationID:   filter.OrganizationID,
+		SubmittedFrom:    filter.SubmittedFrom,
+		Status:           entity.SURVEY_EXPORT_JOB_STATUS_ENUM_PENDING,
+		Total:            int(total),
+	}
+	if filter.SubmittedTo != nil {
+		submittedTo := filter.SubmittedTo.AddDate(0, 0, -1)
+		surveyExportJob.SubmittedTo = &submittedTo
+	}
+	if actor.EmployeeID != uuid.Nil {
+		surveyExportJob.RequestedBy = &actor.EmployeeID
+	}
+
+	surveyExportJob, err = uc.SurveyExportJobRepository.CreateSurveyExportJob(surveyExportJob)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.CreateSurveyExportJob] error creating survey export job: ", err)
+		return nil, err
+	}
+
+	res := uc.SurveyExportJobDTO.ConvertEntityToResponse(surveyExportJob)
+
+	go uc.runSurveyExportJob(surveyExportJob, filter)
+
+	return res, nil
+}
+
+func (uc *SurveyExportUseCase) runSurveyExportJob(surveyExportJob *entity.SurveyExportJob, filter *surveyExportFilter) {
+	startedAt := time.Now()
+	surveyExportJob.Status = entity.SURVEY_EXPORT_JOB_STATUS_ENUM_RUNNING
+	surveyExportJob.StartedAt = &startedAt
+	if err := uc.SurveyExportJobRepository.UpdateSurveyExportJobProgress(surveyExportJob); err != nil {
+		uc.Log.Error("[SurveyExportUseCase.runSurveyExportJob] " + err.Error())
+	}
+
+	exportPath := surveyExportDir + surveyExportJob.ID.String() + service.SurveyExportExtension(filter.Format)
+	err := uc.writeSurveyExportFile(exportPath, filter, func(processed, rows int) {
+		surveyExportJob.Processed = processed
+		surveyExportJob.Rows = rows
+		if err := uc.SurveyExportJobRepository.UpdateSurveyExportJobProgress(surveyExportJob); err != nil {
+			uc.Log.Error("[SurveyExportUseCase.runSurveyExportJob] " + err.Error())
+		}
+	})
+
+	finishedAt := time.Now()
+	surveyExportJob.FinishedAt = &finishedAt
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.runSurveyExportJob] " + err.Error())
+		os.Remove(exportPath)
+		surveyExportJob.Status = entity.SURVEY_EXPORT_JOB_STATUS_ENUM_FAILED
+		surveyExportJob.Message = err.Error()
+	} else {
+		surveyExportJob.Status = entity.SURVEY_EXPORT_JOB_STATUS_ENUM_COMPLETED
+		surveyExportJob.Path = exportPath
+	}
+	if err := uc.SurveyExportJobRepository.UpdateSurveyExportJobProgress(surveyExportJob); err != nil {
+		uc.Log.Error("[SurveyExportUseCase.runSurveyExportJob] " + err.Error())
+	}
+}
+
+func (uc *SurveyExportUseCase) writeSurveyExportFile(exportPath string, filter *surveyExportFilter, progress func(processed, rows int)) error {
+	if err := os.MkdirAll(surveyExportDir, 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(exportPath)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.writeSurveyExport(filter, file, progress); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// writeSurveyExport walks the survey tasks in batches of surveyExportBatchSize, loading the
+// responses of a whole batch at once, and writes a row per task. progress is called after
+// every batch when given. The rows of anonymous surveys are held back and written last, see
+// writeAnonymousSurveyRows.
+func (uc *SurveyExportUseCase) writeSurveyExport(filter *surveyExportFilter, w io.Writer, progress func(processed, rows int)) error {
+	questions, columns, err := uc.surveyExportColumns(filter.SurveyTemplateIDs)
+	if err != nil {
+		return err
+	}
+
+	writer, err := service.NewSurveyExportWriter(filter.Format, w, questions)
+	if err != nil {
+		return err
+	}
+
+	employees := make(map[uuid.UUID]surveyExportEmployee)
+	anonymousRows := make(map[uuid.UUID]*anonymousSurveyExportRows)
+	processed, rows := 0, 0
+	var afterID *uuid.UUID
+	for {
+		employeeTasks, err := uc.EmployeeTaskRepository.FindSurveyForExportAfterID(filter.SurveyTemplateIDs, filter.SubmittedFrom, filter.SubmittedTo, afterID, surveyExportBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(*employeeTasks) == 0 {
+			break
+		}
+
+		employeeTaskIDs := make([]uuid.UUID, 0, len(*employeeTasks))
+		for _, employeeTask := range *employeeTasks {
+			employeeTaskIDs = append(employeeTaskIDs, employeeTask.ID)
+		}
+		surveyResponses, err := uc.SurveyResponseRepository.FindAllByEmployeeTaskIDs(employeeTaskIDs)
+		if err != nil {
+			return err
+		}
+		responsesByTask := make(map[uuid.UUID][]entity.SurveyResponse)
+		for _, surveyResponse := range surveyResponses {
+			responsesByTask[surveyResponse.RespondentID()] = append(responsesByTask[surveyResponse.RespondentID()], surveyResponse)
+		}
+
+		for _, employeeTask := range *employeeTasks {
+			processed++
+
+			var employee surveyExportEmployee
+			if employeeTask.EmployeeID != nil {
+				employee = uc.findSurveyExportEmployee(employees, *employeeTask.EmployeeID)
+			}
+			if filter.OrganizationID != nil && (employee.OrganizationID == nil || *employee.OrganizationID != *filter.OrganizationID) {
+				continue
+			}
+
+			row := service.SurveyExportRow{
+				EmployeeTaskID:   employeeTask.ID,
+				EmployeeTaskName: employeeTask.Name,
+				EmployeeID:       employeeTask.EmployeeID,
+				EmployeeName:     employee.Name,
+				Answers:          make([]string, len(questions)),
+			}
+			if employeeTask.SurveyTemplate != nil {
+				row.SurveyNumber = employeeTask.SurveyTemplate.SurveyNumber
+				row.SurveyTitle = employeeTask.SurveyTemplate.Title
+				row.SurveyVersion = employeeTask.SurveyTemplate.VersionNumber
+			}
+
+			for _, surveyResponse := range responsesByTask[employeeTask.ID] {
+				if row.SubmittedAt == nil || surveyResponse.UpdatedAt.After(*row.SubmittedAt) {
+					submittedAt := surveyResponse.UpdatedAt
+					row.SubmittedAt = &submittedAt
+				}
+
+				column, ok := columns[surveyResponse.QuestionID]
+				if !ok {
+					continue
+				}
+				answer := surveyResponse.Answer
+				if surveyResponse.AnswerFile != "" {
+					answer = uc.Viper.GetString("app.url") + surveyResponse.AnswerFile
+				}
+				if answer == "" {
+					continue
+				}
+				for index, value := range column.cells(answer) {
+					if row.Answers[index] != "" {
+						row.Answers[index] += ", "
+					}
+					row.Answers[index] += value
+				}
+			}
+
+			if employeeTask.SurveyTemplate != nil && employeeTask.SurveyTemplate.IsAnonymous() {
+				// the group size counts respondents, so tasks without answers are left out
+				if row.SubmittedAt == nil {
+					continue
+				}
+				templateRows, ok := anonymousRows[employeeTask.SurveyTemplate.ID]
+				if !ok {
+					templateRows = &anonymousSurveyExportRows{surveyTemplate: employeeTask.SurveyTemplate}
+					anonymousRows[employeeTask.SurveyTemplate.ID] = templateRows
+				}
+				templateRows.rows = append(templateRows.rows, service.AnonymizeSurveyExportRow(row))
+				continue
+			}
+
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+			rows++
+		}
+
+		lastID := (*employeeTasks)[len(*employeeTasks)-1].ID
+		afterID = &lastID
+		if progress != nil {
+			progress(processed, rows)
+		}
+	}
+
+	anonymousRowsWritten, err := writeAnonymousSurveyRows(writer, anonymousRows)
+	if err != nil {
+		return err
+	}
+	if anonymousRowsWritten > 0 && progress != nil {
+		rows += anonymousRowsWritten
+		progress(processed, rows)
+	}
+
+	return writer.Close()
+}
+
+type anonymousSurveyExportRows struct {
+	surveyTemplate *entity.SurveyTemplate
+	rows           []service.SurveyExportRow
+}
+
+// writeAnonymousSurveyRows writes the rows of every anonymous survey in a random order, as the
+// tasks are walked by id and their order would point back at the employees. The rows of a
+// confidential survey are left out when there are fewer than its minimum group size.
+func writeAnonymousSurveyRows(writer service.ISurveyExportWriter, anonymousRows map[uuid.UUID]*anonymousSurveyExportRows) (int, error) {
+	templateRows := make([]*anonymousSurveyExportRows, 0, len(anonymousRows))
+	for _, rows := range anonymousRows {
+		templateRows = append(templateRows, rows)
+	}
+	sort.Slice(templateRows, func(i, j int) bool {
+		return templateRows[i].surveyTemplate.SurveyNumber < templateRows[j].surveyTemplate.SurveyNumber
+	})
+
+	written := 0
+	for _, templateRow := range templateRows {
+		if len(templateRow.rows) < templateRow.surveyTemplate.ReportingGroupSize() {
+			continue
+		}
+
+		rand.Shuffle(len(templateRow.rows), func(i, j int) {
+			templateRow.rows[i], templateRow.rows[j] = templateRow.rows[j], templateRow.rows[i]
+		})
+		for _, row := range templateRow.rows {
+			if err := writer.WriteRow(row); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+
+	return written, nil
+}
+
+// surveyExportColumn is where the answers to a question go. A matrix question has a column
+// per row, the other questions a single column.
+type surveyExportColumn struct {
+	index      int
+	answerType string
+	rows       map[string]int
+}
+
+// cells spreads an answer over the columns of its question.
+func (c surveyExportColumn) cells(answer string) map[int]string {
+	switch c.answerType {
+	case entity.ANSWER_TYPE_MATRIX:
+		if c.rows == nil {
+			break
+		}
+		matrix, err := service.ParseSurveyMatrixAnswer(answer)
+		if err != nil {
+			return nil
+		}
+		cells := make(map[int]string, len(matrix))
+		for row, column := range matrix {
+			if index, ok := c.rows[strings.TrimSpace(row)]; ok {
+				cells[index] = column
+			}
+		}
+		return cells
+	case entity.ANSWER_TYPE_RANKING:
+		ranking, err := service.ParseSurveyRankingAnswer(answer)
+		if err != nil {
+			break
+		}
+		return map[int]string{c.index: strings.Join(ranking, " > ")}
+	}
+
+	return map[int]string{c.index: answer}
+}
+
+// surveyExportColumns lists the question columns of the export and the columns of every
+// question. Without survey templates the questions of all templates are exported, and
+// questions with the same text share a column, which also lines up the versions of a survey.
+func (uc *SurveyExportUseCase) surveyExportColumns(surveyTemplateIDs []uuid.UUID) ([]string, map[uuid.UUID]surveyExportColumn, error) {
+	var surveyTemplates []entity.SurveyTemplate
+	if len(surveyTemplateIDs) > 0 {
+		for _, surveyTemplateID := range surveyTemplateIDs {
+			surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+				"id": surveyTemplateID,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			if surveyTemplate == nil {
+				return nil, nil, fmt.Errorf("survey template not found")
+			}
+			surveyTemplates = append(surveyTemplates, *surveyTemplate)
+		}
+	} else {
+		allSurveyTemplates, err := uc.SurveyTemplateRepository.FindAllWithQuestions()
+		if err != nil {
+			return nil, nil, err
+		}
+		surveyTemplates = *allSurveyTemplates
+	}
+
+	questions := make([]string, 0)
+	columns := make(map[uuid.UUID]surveyExportColumn)
+	columnsByText := make(map[string]int)
+	columnIndex := func(text string) int {
+		index, ok := columnsByText[text]
+		if !ok {
+			index = len(questions)
+			columnsByText[text] = index
+			questions = append(questions, text)
+		}
+		return index
+	}
+	for _, surveyTemplate := range surveyTemplates {
+		templateQuestions := append([]entity.Question(nil), surveyTemplate.Questions...)
+		sort.SliceStable(templateQuestions, func(i, j int) bool {
+			return templateQuestions[i].Number < templateQuestions[j].Number
+		})
+		for _, question := range templateQuestions {
+			text := strings.TrimSpace(question.Question)
+			column := surveyExportColumn{}
+			if question.AnswerType != nil {
+				column.answerType = question.AnswerType.Name
+			}
+			if column.answerType == entity.ANSWER_TYPE_MATRIX && len(question.MatrixRows) > 0 {
+				column.rows = make(map[string]int, len(question.MatrixRows))
+				for _, matrixRow := range question.MatrixRows {
+					rowText := strings.TrimSpace(matrixRow.RowText)
+					column.rows[rowText] = columnIndex(text + " [" + rowText + "]")
+				}
+			} else {
+				column.index = columnIndex(text)
+			}
+			columns[question.ID] = column
+		}
+	}
+
+	return questions, columns, nil
+}
+
+// resolveSurveyExportTemplates finds the survey template of the filter, and its other versions
+// when all of them are exported.
+func (uc *SurveyExportUseCase) resolveSurveyExportTemplates(filter *surveyExportFilter) error {
+	if filter.SurveyTemplateID == nil {
+		return nil
+	}
+
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": *filter.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.resolveSurveyExportTemplates] error finding survey template: ", err)
+		return err
+	}
+	if surveyTemplate == nil {
+		return fmt.Errorf("survey template not found")
+	}
+
+	filter.SurveyTemplateIDs = []uuid.UUID{surveyTemplate.ID}
+	if !filter.AllVersions {
+		return nil
+	}
+
+	versions, err := uc.SurveyTemplateRepository.FindAllVersions(surveyTemplate.SurveyNumber)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.resolveSurveyExportTemplates] error finding survey template versions: ", err)
+		return err
+	}
+	filter.SurveyTemplateIDs = make([]uuid.UUID, 0, len(*versions))
+	for _, version := range *versions {
+		filter.SurveyTemplateIDs = append(filter.SurveyTemplateIDs, version.ID)
+	}
+
+	return nil
+}
+
+// findSurveyExportEmployee asks the employee service once per employee. An employee that
+// cannot be found is exported without a name and organization.
+func (uc *SurveyExportUseCase) findSurveyExportEmployee(employees map[uuid.UUID]surveyExportEmployee, employeeID uuid.UUID) surveyExportEmployee {
+	if employee, ok := employees[employeeID]; ok {
+		return employee
+	}
+
+	var employee surveyExportEmployee
+	employeeResponse, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: employeeID.String(),
+	})
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.findSurveyExportEmployee] error finding employee: ", err)
+	} else if employeeResponse != nil {
+		employee.Name = employeeResponse.Name
+		organizationID := employeeResponse.OrganizationID
+		employee.OrganizationID = &organizationID
+	}
+	employees[employeeID] = employee
+
+	return employee
+}
+
+func (uc *SurveyExportUseCase) FindSurveyExportJobByID(id uuid.UUID) (*response.SurveyExportJobResponse, error) {
+	surveyExportJob, err := uc.SurveyExportJobRepository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.FindSurveyExportJobByID] error finding survey export job: ", err)
+		return nil, err
+	}
+	if surveyExportJob == nil {
+		return nil, fmt.Errorf("survey export job not found")
+	}
+
+	return uc.SurveyExportJobDTO.ConvertEntityToResponse(surveyExportJob), nil
+}
+
+func (uc *SurveyExportUseCase) FindAllSurveyExportJobsPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.SurveyExportJobResponse, int64, error) {
+	surveyExportJobs, total, err := uc.SurveyExportJobRepository.FindAllPaginated(page, pageSize, sort)
+	if err != nil {
+		uc.Log.Error("[SurveyExportUseCase.FindAllSurveyExportJobsPaginated] error finding survey export jobs: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyExportJobResponse, 0, len(*surveyExportJobs))
+	for i := range *surveyExportJobs {
+		responses = append(responses, *uc.SurveyExportJobDTO.ConvertEntityToResponse(&(*surveyExportJobs)[i]))
+	}
+
+	return &responses, total, nil
+}
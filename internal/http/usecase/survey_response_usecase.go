@@ -2,12 +2,18 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -17,18 +23,30 @@ import (
 type ISurveyResponseUseCase interface {
 	CreateOrUpdateSurveyResponses(req *request.SurveyResponseRequest) (*response.QuestionResponse, error)
 	CreateOrUpdateSurveyResponsesBulk(req *request.SurveyResponseBulkRequest) (*response.SurveyTemplateResponse, error)
+	ValidateSurveyResponses(req *request.SurveyResponseRequest) error
+	ValidateSurveyResponsesBulk(req *request.SurveyResponseBulkRequest) error
+	FindSurveyQuizResult(employeeTaskID uuid.UUID) (*response.SurveyQuizResultResponse, error)
+	FindAllSurveyQuizAttemptsPaginated(page, pageSize int, surveyTemplateID uuid.UUID, passed *bool, sort map[string]interface{}) (*[]response.SurveyQuizAttemptResponse, int64, error)
+	ValidateSurveyDistributionResponses(req *request.SurveyDistributionResponseRequest) error
+	CreateOrUpdateSurveyDistributionResponses(req *request.SurveyDistributionResponseRequest) (*response.SurveyTemplateResponse, error)
+	FindSurveyDistributionResponses(surveyDistributionID, employeeID uuid.UUID) (*response.SurveyTemplateResponse, error)
 }
 
 type SurveyResponseUseCase struct {
-	Log                      *logrus.Logger
-	Viper                    *viper.Viper
-	QuestionRepository       repository.IQuestionRepository
-	SurveyTemplateRepository repository.ISurveyTemplateRepository
-	SurveyResponseRepository repository.ISurveyResponseRepository
-	EmployeeMessage          messaging.IEmployeeMessage
-	QuestionDTO              dto.IQuestionDTO
-	EmployeeTaskRepository   repository.IEmployeeTaskRepository
-	SurveyTemplateDTO        dto.ISurveyTemplateDTO
+	Log                                   *logrus.Logger
+	Viper                                 *viper.Viper
+	QuestionRepository                    repository.IQuestionRepository
+	SurveyTemplateRepository              repository.ISurveyTemplateRepository
+	SurveyResponseRepository              repository.ISurveyResponseRepository
+	EmployeeMessage                       messaging.IEmployeeMessage
+	QuestionDTO                           dto.IQuestionDTO
+	EmployeeTaskRepository                repository.IEmployeeTaskRepository
+	SurveyTemplateDTO                     dto.ISurveyTemplateDTO
+	SurveyQuizAttemptRepository           repository.ISurveyQuizAttemptRepository
+	SurveyQuizAttemptDTO                  dto.ISurveyQuizAttemptDTO
+	SurveyDistributionRepository          repository.ISurveyDistributionRepository
+	SurveyDistributionRecipientRepository repository.ISurveyDistributionRecipientRepository
+	EmployeeTaskUseCase                   IEmployeeTaskUseCase
 }
 
 func NewSurveyResponseUseCase(
@@ -41,17 +59,27 @@ func NewSurveyResponseUseCase(
 	QuestionDTO dto.IQuestionDTO,
 	EmployeeTaskRepository repository.IEmployeeTaskRepository,
 	SurveyTemplateDTO dto.ISurveyTemplateDTO,
+	SurveyQuizAttemptRepository repository.ISurveyQuizAttemptRepository,
+	SurveyQuizAttemptDTO dto.ISurveyQuizAttemptDTO,
+	SurveyDistributionRepository repository.ISurveyDistributionRepository,
+	SurveyDistributionRecipientRepository repository.ISurveyDistributionRecipientRepository,
+	EmployeeTaskUseCase IEmployeeTaskUseCase,
 ) ISurveyResponseUseCase {
 	return &SurveyResponseUseCase{
-		Log:                      Log,
-		Viper:                    Viper,
-		QuestionRepository:       QuestionRepository,
-		SurveyTemplateRepository: SurveyTemplateRepository,
-		SurveyResponseRepository: SurveyResponseRepository,
-		EmployeeMessage:          EmployeeMessage,
-		QuestionDTO:              QuestionDTO,
-		EmployeeTaskRepository:   EmployeeTaskRepository,
-		SurveyTemplateDTO:        SurveyTemplateDTO,
+		Log:                                   Log,
+		Viper:                                 Viper,
+		QuestionRepository:                    QuestionRepository,
+		SurveyTemplateRepository:              SurveyTemplateRepository,
+		SurveyResponseRepository:              SurveyResponseRepository,
+		EmployeeMessage:                       EmployeeMessage,
+		QuestionDTO:                           QuestionDTO,
+		EmployeeTaskRepository:                EmployeeTaskRepository,
+		SurveyTemplateDTO:                     SurveyTemplateDTO,
+		SurveyQuizAttemptRepository:           SurveyQuizAttemptRepository,
+		SurveyQuizAttemptDTO:                  SurveyQuizAttemptDTO,
+		SurveyDistributionRepository:          SurveyDistributionRepository,
+		SurveyDistributionRecipientRepository: SurveyDistributionRecipientRepository,
+		EmployeeTaskUseCase:                   EmployeeTaskUseCase,
 	}
 }
 
@@ -66,6 +94,11 @@ func SurveyResponseUseCaseFactory(
 	questionDTO := dto.QuestionDTOFactory(Log, Viper)
 	employeeTaskRepository := repository.EmployeeTaskRepositoryFactory(Log)
 	surveyTemplateDTO := dto.SurveyTemplateDTOFactory(Log, Viper)
+	surveyQuizAttemptRepository := repository.SurveyQuizAttemptRepositoryFactory(Log)
+	surveyQuizAttemptDTO := dto.SurveyQuizAttemptDTOFactory(Log, Viper)
+	surveyDistributionRepository := repository.SurveyDistributionRepositoryFactory(Log)
+	surveyDistributionRecipientRepository := repository.SurveyDistributionRecipientRepositoryFactory(Log)
+	employeeTaskUseCase := EmployeeTaskUseCaseFactory(Log, Viper)
 
 	return NewSurveyResponseUseCase(
 		Log,
@@ -77,10 +110,19 @@ func SurveyResponseUseCaseFactory(
 		questionDTO,
 		employeeTaskRepository,
 		surveyTemplateDTO,
+		surveyQuizAttemptRepository,
+		surveyQuizAttemptDTO,
+		surveyDistributionRepository,
+		surveyDistributionRecipientRepository,
+		employeeTaskUseCase,
 	)
 }
 
 func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.SurveyResponseRequest) (*response.QuestionResponse, error) {
+	if err := uc.ValidateSurveyResponses(req); err != nil {
+		return nil, err
+	}
+
 	// check if question is exist
 	parsedQuestionID, err := uuid.Parse(req.QuestionID)
 	if err != nil {
@@ -100,6 +142,8 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 	}
 
 	var employeeTaskUUID uuid.UUID
+	var answeredSurveyTemplate *entity.SurveyTemplate
+	var answeredEmployeeTask *entity.EmployeeTask
 
 	var answerIDs []uuid.UUID
 	for _, ans := range req.Answers {
@@ -156,6 +200,11 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 			uc.Log.Errorf("[QuestionResponseUseCase.CreateOrUpdateSurveyResponses] employee task with id %s not found", ans.EmployeeTaskID)
 			return nil, errors.New("employee task not found")
 		}
+		if err := ensureSurveyEmployeeTaskOpen(up); err != nil {
+			return nil, err
+		}
+		answeredSurveyTemplate = jp
+		answeredEmployeeTask = up
 		uc.Log.Info("Halooo")
 
 		// check if answer is exist
@@ -176,8 +225,8 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 				_, err := uc.SurveyResponseRepository.CreateSurveyResponse(&entity.SurveyResponse{
 					QuestionID:       question.ID,
 					SurveyTemplateID: jp.ID,
-					EmployeeTaskID:   up.ID,
-					Answer:           ans.Answer,
+					EmployeeTaskID:   &up.ID,
+					Answer:           service.NormalizeSurveyAnswer(question, ans.Answer),
 					AnswerFile:       ans.AnswerPath,
 				})
 				if err != nil {
@@ -190,8 +239,8 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 					ID:               exist.ID,
 					QuestionID:       question.ID,
 					SurveyTemplateID: jp.ID,
-					EmployeeTaskID:   up.ID,
-					Answer:           ans.Answer,
+					EmployeeTaskID:   &up.ID,
+					Answer:           service.NormalizeSurveyAnswer(question, ans.Answer),
 					AnswerFile:       ans.AnswerPath,
 				})
 
@@ -205,8 +254,8 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 			hasil, err := uc.SurveyResponseRepository.CreateSurveyResponse(&entity.SurveyResponse{
 				QuestionID:       question.ID,
 				SurveyTemplateID: jp.ID,
-				EmployeeTaskID:   up.ID,
-				Answer:           ans.Answer,
+				EmployeeTaskID:   &up.ID,
+				Answer:           service.NormalizeSurveyAnswer(question, ans.Answer),
 				AnswerFile:       ans.AnswerPath,
 			})
 			if err != nil {
@@ -227,6 +276,9 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 		uc.Log.Errorf("[QuestionResponseUseCase.CreateOrUpdateSurveyResponses] question with id %s not found", req.QuestionID)
 		return nil, err
 	}
+	if answeredEmployeeTask != nil && service.SurveyResponsesHiddenFrom(answeredSurveyTemplate, answeredEmployeeTask.EmployeeID, req.Actor.EmployeeID) {
+		rQuestion.SurveyResponses = []entity.SurveyResponse{}
+	}
 
 	// embed url to answer file
 	for _, qr := range rQuestion.SurveyResponses {
@@ -236,10 +288,14 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponses(req *request.Surv
 		}
 	}
 
-	return uc.QuestionDTO.ConvertEntityToResponse(rQuestion), nil
+	return dto.HideQuestionAnswerKey(uc.QuestionDTO.ConvertEntityToResponse(rQuestion)), nil
 }
 
 func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponsesBulk(req *request.SurveyResponseBulkRequest) (*response.SurveyTemplateResponse, error) {
+	if err := uc.ValidateSurveyResponsesBulk(req); err != nil {
+		return nil, err
+	}
+
 	parsedSurveyTemplateID, err := uuid.Parse(req.SurveyTemplateID)
 	if err != nil {
 		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when parsing survey template id: %s", err.Error())
@@ -271,123 +327,604 @@ func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyResponsesBulk(req *request.
 		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] employee task with id %s not found", req.EmployeeTaskID)
 		return nil, errors.New("employee task not found")
 	}
+	if err := ensureSurveyEmployeeTaskOpen(employeeTask); err != nil {
+		return nil, err
+	}
+
+	// moving a quiz out of progress submits it for grading
+	kanban := entity.EmployeeTaskKanbanEnum(req.Kanban)
+	submitsQuiz := surveyTemplate.IsQuiz && (kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW || kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED)
+	if submitsQuiz {
+		if err := uc.ensureSurveyQuizAttemptAllowed(surveyTemplate, employeeTask); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.saveSurveyBulkAnswers(surveyTemplate, entity.SurveyResponse{EmployeeTaskID: &employeeTask.ID}, req.Answers); err != nil {
+		return nil, err
+	}
+
+	if submitsQuiz {
+		attempt, err := uc.gradeSurveyQuiz(surveyTemplate, employeeTask)
+		if err != nil {
+			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when grading quiz: %s", err.Error())
+			return nil, err
+		}
+		employeeTask.QuizScore = &attempt.Percentage
+		// a failed attempt goes back to the employee, the task cannot be completed with it
+		if !attempt.Passed {
+			kanban = entity.EPMLOYEE_TASK_KANBAN_ENUM_IN_PROGRESS
+		}
+	}
+
+	// the task completes like any other once its approval chain, required checklist items and
+	// kind allow it, a quiz on a passing score, and waits for review otherwise
+	if kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED && employeeTask.Kanban != entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED {
+		if err := uc.EmployeeTaskUseCase.EnsureEmployeeTaskCanBeCompleted(employeeTask); err != nil {
+			uc.Log.Infof("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] employee task %s waits for review: %s", employeeTask.ID, err.Error())
+			kanban = entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW
+		}
+	}
 
 	_, err = uc.EmployeeTaskRepository.UpdateEmployeeTask(&entity.EmployeeTask{
 		ID:     employeeTask.ID,
-		Kanban: entity.EmployeeTaskKanbanEnum(req.Kanban),
+		Kanban: kanban,
 	})
 	if err != nil {
 		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when updating employee task: %s", err.Error())
 		return nil, err
 	}
 
+	// get survey template with questions and answers
+	surveyTemplate, err = uc.SurveyTemplateRepository.FindByIDForResponse(parsedSurveyTemplateID, parsedEmployeeTaskID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when finding survey template by id: %s", err.Error())
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] survey template with id %s not found", req.SurveyTemplateID)
+		return nil, errors.New("survey template not found")
+	}
+	if service.SurveyResponsesHiddenFrom(surveyTemplate, employeeTask.EmployeeID, req.Actor.EmployeeID) {
+		service.HideSurveyResponses(surveyTemplate.Questions)
+	}
+
+	resp := dto.HideQuizAnswerKey(uc.SurveyTemplateDTO.ConvertEntityToResponse(surveyTemplate))
+	service.MarkHiddenSurveyQuestions(resp, service.HiddenSurveyQuestions(surveyTemplate.Questions, surveyTemplateAnswers(surveyTemplate)))
+	return resp, nil
+}
+
+// ValidateSurveyResponses checks the answers to a question against its answer type and rules.
+// The answers are saved one question at a time, so a required question is not enforced here.
+func (uc *SurveyResponseUseCase) ValidateSurveyResponses(req *request.SurveyResponseRequest) error {
+	parsedQuestionID, err := uuid.Parse(req.QuestionID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.ValidateSurveyResponses] error when parsing question id: %s", err.Error())
+		return err
+	}
+	question, err := uc.QuestionRepository.FindByID(parsedQuestionID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.ValidateSurveyResponses] error when finding question by id: %s", err.Error())
+		return err
+	}
+	if question == nil {
+		return errors.New("question not found")
+	}
+
+	answers := make([]service.SurveyAnswerInput, 0, len(req.Answers))
+	for i, ans := range req.Answers {
+		answers = append(answers, service.SurveyAnswerInput{
+			Field:    fmt.Sprintf("answers[%d][answer]", i),
+			ID:       ans.ID,
+			Answer:   ans.Answer,
+			File:     ans.AnswerFile,
+			FilePath: ans.AnswerPath,
+		})
+	}
+
+	return service.NewSurveyAnswerValidationError(service.ValidateSurveyAnswers(question, answers, false))
+}
+
+// ValidateSurveyResponsesBulk checks the answers to a survey template. Moving the task to review
+// or completed submits the survey, which needs every required question answered.
+func (uc *SurveyResponseUseCase) ValidateSurveyResponsesBulk(req *request.SurveyResponseBulkRequest) error {
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": req.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.ValidateSurveyResponsesBulk] error when finding survey template by id: %s", err.Error())
+		return err
+	}
+	if surveyTemplate == nil {
+		return errors.New("survey template not found")
+	}
+
+	kanban := entity.EmployeeTaskKanbanEnum(req.Kanban)
+	submits := kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_NEED_REVIEW || kanban == entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED
+
+	return validateSurveyTemplateAnswers(surveyTemplate, req.Answers, submits)
+}
+
+// validateSurveyTemplateAnswers checks the answers against the questions of the survey
+// template. A submitted survey needs every required question that is not hidden answered.
+func validateSurveyTemplateAnswers(surveyTemplate *entity.SurveyTemplate, answers []request.AnswerBulkRequest, submits bool) error {
+	fieldErrors := make([]response.SurveyAnswerFieldErrorResponse, 0)
+	questionIDs := make(map[string]bool, len(surveyTemplate.Questions))
+	for _, question := range surveyTemplate.Questions {
+		questionIDs[question.ID.String()] = true
+	}
+
+	inputs := make(map[string][]service.SurveyAnswerInput)
+	for i, ans := range answers {
+		if !questionIDs[ans.QuestionID] {
+			questionID, _ := uuid.Parse(ans.QuestionID)
+			fieldErrors = append(fieldErrors, response.SurveyAnswerFieldErrorResponse{
+				Field:      fmt.Sprintf("answers[%d][question_id]", i),
+				QuestionID: questionID,
+				Message:    "the question is not part of the survey template",
+			})
+			continue
+		}
+		inputs[ans.QuestionID] = append(inputs[ans.QuestionID], service.SurveyAnswerInput{
+			Field:    fmt.Sprintf("answers[%d][answer]", i),
+			ID:       ans.ID,
+			Answer:   ans.Answer,
+			File:     ans.AnswerFile,
+			FilePath: ans.AnswerPath,
+		})
+	}
+
+	// hidden questions are neither required nor checked, their answers are dropped when saved
+	hidden := service.HiddenSurveyQuestions(surveyTemplate.Questions, surveyBulkRequestAnswers(answers))
+
+	questions := append([]entity.Question(nil), surveyTemplate.Questions...)
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Number < questions[j].Number
+	})
+	for i := range questions {
+		if hidden[questions[i].ID] {
+			continue
+		}
+		fieldErrors = append(fieldErrors, service.ValidateSurveyAnswers(&questions[i], inputs[questions[i].ID.String()], submits)...)
+	}
+
+	return service.NewSurveyAnswerValidationError(fieldErrors)
+}
+
+// saveSurveyBulkAnswers keeps the answers of a respondent to a survey template. The respondent
+// carries either the employee task or the distribution recipient the answers belong to.
+func (uc *SurveyResponseUseCase) saveSurveyBulkAnswers(surveyTemplate *entity.SurveyTemplate, respondent entity.SurveyResponse, answers []request.AnswerBulkRequest) error {
+	// answers to questions hidden by the other answers are not kept
+	hidden := service.HiddenSurveyQuestions(surveyTemplate.Questions, surveyBulkRequestAnswers(answers))
+	visibleAnswers := make([]request.AnswerBulkRequest, 0, len(answers))
+	for _, ans := range answers {
+		if questionID, err := uuid.Parse(ans.QuestionID); err == nil && hidden[questionID] {
+			continue
+		}
+		visibleAnswers = append(visibleAnswers, ans)
+	}
+
 	var answerIDs []uuid.UUID
-	for _, ans := range req.Answers {
+	for _, ans := range visibleAnswers {
 		if ans.ID != nil {
 			parsedAnswerID, err := uuid.Parse(*ans.ID)
 			if err != nil {
-				uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when parsing answer id: %s", err.Error())
-				return nil, err
+				uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when parsing answer id: %s", err.Error())
+				return err
 			}
 			answerIDs = append(answerIDs, parsedAnswerID)
 		}
 	}
 
-	// delete answers by survey template id, employee task id and not in ids
+	// delete answers by survey template id, respondent and not in ids
+	keys := map[string]interface{}{
+		"survey_template_id": surveyTemplate.ID,
+	}
+	if respondent.EmployeeTaskID != nil {
+		keys["employee_task_id"] = *respondent.EmployeeTaskID
+	} else {
+		keys["survey_distribution_recipient_id"] = *respondent.SurveyDistributionRecipientID
+	}
 	if len(answerIDs) > 0 {
-		err := uc.SurveyResponseRepository.DeleteNotInIDsAndKeys(map[string]interface{}{
-			"survey_template_id": parsedSurveyTemplateID,
-			"employee_task_id":   parsedEmployeeTaskID,
-		}, answerIDs)
+		err := uc.SurveyResponseRepository.DeleteNotInIDsAndKeys(keys, answerIDs)
 		if err != nil {
-			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when deleting answers by survey template id, employee task id and ids: %s", err.Error())
-			return nil, err
+			uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when deleting answers by survey template id, respondent and ids: %s", err.Error())
+			return err
 		}
 	}
 
 	// create or update answers
-	for _, ans := range req.Answers {
+	for _, ans := range visibleAnswers {
 		// check if question is exist
 		parsedQuestionID, err := uuid.Parse(ans.QuestionID)
 		if err != nil {
-			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when parsing question id: %s", err.Error())
-			return nil, err
+			uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when parsing question id: %s", err.Error())
+			return err
 		}
 		question, err := uc.QuestionRepository.FindByID(parsedQuestionID)
 		if err != nil {
-			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when finding question by id: %s", err.Error())
-			return nil, err
+			uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when finding question by id: %s", err.Error())
+			return err
 		}
 		if question == nil {
-			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] question with id %s not found", ans.QuestionID)
-			return nil, errors.New("question not found")
+			uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] question with id %s not found", ans.QuestionID)
+			return errors.New("question not found")
 		}
 		// check if answer is exist
 		if ans.ID != nil {
 			parsedAnswerID, err := uuid.Parse(*ans.ID)
 			if err != nil {
-				uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when parsing answer id: %s", err.Error())
-				return nil, err
+				uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when parsing answer id: %s", err.Error())
+				return err
 			}
 			exist, err := uc.SurveyResponseRepository.FindByID(parsedAnswerID)
 			if err != nil {
-				uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when finding answer by id: %s", err.Error())
-				return nil, err
+				uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when finding answer by id: %s", err.Error())
+				return err
 			}
 
-			if exist == nil {
+			// an answer of someone else is not taken over
+			if exist == nil || exist.RespondentID() != respondent.RespondentID() {
 				_, err := uc.SurveyResponseRepository.CreateSurveyResponse(&entity.SurveyResponse{
-					QuestionID:       question.ID,
-					SurveyTemplateID: surveyTemplate.ID,
-					EmployeeTaskID:   employeeTask.ID,
-					Answer:           ans.Answer,
-					AnswerFile:       ans.AnswerPath,
+					QuestionID:                    question.ID,
+					SurveyTemplateID:              surveyTemplate.ID,
+					EmployeeTaskID:                respondent.EmployeeTaskID,
+					SurveyDistributionRecipientID: respondent.SurveyDistributionRecipientID,
+					Answer:                        service.NormalizeSurveyAnswer(question, ans.Answer),
+					AnswerFile:                    ans.AnswerPath,
 				})
 				if err != nil {
-					uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when creating answer: %s", err.Error())
-					return nil, err
+					uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when creating answer: %s", err.Error())
+					return err
 				}
 			} else {
 				_, err := uc.SurveyResponseRepository.UpdateSurveyResponse(&entity.SurveyResponse{
-					ID:               exist.ID,
-					QuestionID:       question.ID,
-					SurveyTemplateID: surveyTemplate.ID,
-					EmployeeTaskID:   employeeTask.ID,
-					Answer:           ans.Answer,
-					AnswerFile:       ans.AnswerPath,
+					ID:                            exist.ID,
+					QuestionID:                    question.ID,
+					SurveyTemplateID:              surveyTemplate.ID,
+					EmployeeTaskID:                respondent.EmployeeTaskID,
+					SurveyDistributionRecipientID: respondent.SurveyDistributionRecipientID,
+					Answer:                        service.NormalizeSurveyAnswer(question, ans.Answer),
+					AnswerFile:                    ans.AnswerPath,
 				})
 
 				if err != nil {
-					uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when updating answer: %s", err.Error())
-					return nil, err
+					uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when updating answer: %s", err.Error())
+					return err
 				}
 			}
 		} else {
 			_, err := uc.SurveyResponseRepository.CreateSurveyResponse(&entity.SurveyResponse{
-				QuestionID:       question.ID,
-				SurveyTemplateID: surveyTemplate.ID,
-				EmployeeTaskID:   employeeTask.ID,
-				Answer:           ans.Answer,
-				AnswerFile:       ans.AnswerPath,
+				QuestionID:                    question.ID,
+				SurveyTemplateID:              surveyTemplate.ID,
+				EmployeeTaskID:                respondent.EmployeeTaskID,
+				SurveyDistributionRecipientID: respondent.SurveyDistributionRecipientID,
+				Answer:                        service.NormalizeSurveyAnswer(question, ans.Answer),
+				AnswerFile:                    ans.AnswerPath,
 			})
 			if err != nil {
-				uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when creating answer: %s", err.Error())
-				return nil, err
+				uc.Log.Errorf("[SurveyResponseUseCase.saveSurveyBulkAnswers] error when creating answer: %s", err.Error())
+				return err
 			}
 		}
 	}
 
-	// get survey template with questions and answers
-	surveyTemplate, err = uc.SurveyTemplateRepository.FindByIDForResponse(parsedSurveyTemplateID, parsedEmployeeTaskID)
+	return nil
+}
+
+// ensureSurveyQuizAttemptAllowed refuses a new quiz attempt once the quiz is passed or all the
+// attempts of the survey template are used.
+func (uc *SurveyResponseUseCase) ensureSurveyQuizAttemptAllowed(surveyTemplate *entity.SurveyTemplate, employeeTask *entity.EmployeeTask) error {
+	latest, err := uc.SurveyQuizAttemptRepository.FindLatestByEmployeeTaskID(employeeTask.ID)
 	if err != nil {
-		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] error when finding survey template by id: %s", err.Error())
+		uc.Log.Errorf("[SurveyResponseUseCase.ensureSurveyQuizAttemptAllowed] error when finding latest quiz attempt: %s", err.Error())
+		return err
+	}
+	if latest == nil {
+		return nil
+	}
+	if latest.Passed {
+		return errors.New("the quiz is already passed")
+	}
+	if surveyTemplate.MaxAttempts != nil && latest.AttemptNumber >= *surveyTemplate.MaxAttempts {
+		return errors.New("no quiz attempts left, all " + strconv.Itoa(*surveyTemplate.MaxAttempts) + " attempts are used")
+	}
+
+	return nil
+}
+
+// gradeSurveyQuiz scores the saved responses of the task as its next attempt and keeps the
+// percentage as the quiz score of the task.
+func (uc *SurveyResponseUseCase) gradeSurveyQuiz(surveyTemplate *entity.SurveyTemplate, employeeTask *entity.EmployeeTask) (*entity.SurveyQuizAttempt, error) {
+	surveyResponses, err := uc.SurveyResponseRepository.GetAllByKeys(map[string]interface{}{
+		"survey_template_id": surveyTemplate.ID,
+		"employee_task_id":   employeeTask.ID,
+	})
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.gradeSurveyQuiz] error when finding survey responses: %s", err.Error())
+		return nil, err
+	}
+
+	attemptsUsed, err := uc.SurveyQuizAttemptRepository.CountByEmployeeTaskID(employeeTask.ID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.gradeSurveyQuiz] error when counting quiz attempts: %s", err.Error())
+		return nil, err
+	}
+
+	// hidden questions are left out of the score
+	hidden := service.HiddenSurveyQuestions(surveyTemplate.Questions, service.SurveyQuestionAnswers(surveyResponses))
+	gradedTemplate := *surveyTemplate
+	gradedTemplate.Questions = make([]entity.Question, 0, len(surveyTemplate.Questions))
+	for _, question := range surveyTemplate.Questions {
+		if !hidden[question.ID] {
+			gradedTemplate.Questions = append(gradedTemplate.Questions, question)
+		}
+	}
+
+	attempt := service.GradeSurveyQuiz(&gradedTemplate, employeeTask, surveyResponses)
+	attempt.AttemptNumber = int(attemptsUsed) + 1
+	attempt, err = uc.SurveyQuizAttemptRepository.CreateSurveyQuizAttempt(attempt)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.gradeSurveyQuiz] error when creating quiz attempt: %s", err.Error())
+		return nil, err
+	}
+
+	if err := uc.EmployeeTaskRepository.UpdateQuizScoreByID(employeeTask.ID, &attempt.Percentage); err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.gradeSurveyQuiz] error when updating quiz score: %s", err.Error())
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// FindSurveyQuizResult returns every graded attempt of the employee task with the attempts it
+// has left.
+func (uc *SurveyResponseUseCase) FindSurveyQuizResult(employeeTaskID uuid.UUID) (*response.SurveyQuizResultResponse, error) {
+	employeeTask, err := uc.EmployeeTaskRepository.FindByID(employeeTaskID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.FindSurveyQuizResult] error when finding employee task by id: %s", err.Error())
+		return nil, err
+	}
+	if employeeTask == nil {
+		return nil, errors.New("employee task not found")
+	}
+	if employeeTask.SurveyTemplateID == nil {
+		return nil, errors.New("employee task has no survey template")
+	}
+
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": *employeeTask.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.FindSurveyQuizResult] error when finding survey template by id: %s", err.Error())
 		return nil, err
 	}
 	if surveyTemplate == nil {
-		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyResponsesBulk] survey template with id %s not found", req.SurveyTemplateID)
 		return nil, errors.New("survey template not found")
 	}
+	if !surveyTemplate.IsQuiz {
+		return nil, errors.New("survey template is not a quiz")
+	}
+
+	attempts, err := uc.SurveyQuizAttemptRepository.FindAllByEmployeeTaskID(employeeTask.ID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.FindSurveyQuizResult] error when finding quiz attempts: %s", err.Error())
+		return nil, err
+	}
 
-	resp := uc.SurveyTemplateDTO.ConvertEntityToResponse(surveyTemplate)
+	result := &response.SurveyQuizResultResponse{
+		EmployeeTaskID:   employeeTask.ID,
+		SurveyTemplateID: surveyTemplate.ID,
+		PassingScore:     service.SurveyQuizPassingScore(surveyTemplate, employeeTask),
+		MaxAttempts:      surveyTemplate.MaxAttempts,
+		AttemptsUsed:     len(*attempts),
+		Attempts:         make([]response.SurveyQuizAttemptResponse, 0, len(*attempts)),
+	}
+	for _, attempt := range *attempts {
+		if attempt.Passed {
+			result.Passed = true
+		}
+		if result.BestPercentage == nil || attempt.Percentage > *result.BestPercentage {
+			percentage := attempt.Percentage
+			result.BestPercentage = &percentage
+		}
+		result.Attempts = append(result.Attempts, *uc.SurveyQuizAttemptDTO.ConvertEntityToResponse(&attempt))
+	}
+	if surveyTemplate.MaxAttempts != nil {
+		attemptsLeft := *surveyTemplate.MaxAttempts - result.AttemptsUsed
+		if attemptsLeft < 0 || result.Passed {
+			attemptsLeft = 0
+		}
+		result.AttemptsLeft = &attemptsLeft
+	}
+
+	return result, nil
+}
+
+// FindAllSurveyQuizAttemptsPaginated lists the graded attempts of every employee at a quiz.
+func (uc *SurveyResponseUseCase) FindAllSurveyQuizAttemptsPaginated(page, pageSize int, surveyTemplateID uuid.UUID, passed *bool, sort map[string]interface{}) (*[]response.SurveyQuizAttemptResponse, int64, error) {
+	attempts, total, err := uc.SurveyQuizAttemptRepository.FindAllPaginatedBySurveyTemplateID(page, pageSize, surveyTemplateID, passed, sort)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.FindAllSurveyQuizAttemptsPaginated] error when finding quiz attempts: %s", err.Error())
+		return nil, 0, err
+	}
+
+	responses := make([]response.SurveyQuizAttemptResponse, 0, len(*attempts))
+	for _, attempt := range *attempts {
+		responses = append(responses, *uc.SurveyQuizAttemptDTO.ConvertEntityToResponse(&attempt))
+	}
+
+	return &responses, total, nil
+}
+
+// ValidateSurveyDistributionResponses checks that the survey distribution takes answers from
+// the employee and the answers against its survey template.
+func (uc *SurveyResponseUseCase) ValidateSurveyDistributionResponses(req *request.SurveyDistributionResponseRequest) error {
+	surveyDistribution, _, err := uc.findSurveyDistributionRecipient(req.SurveyDistributionID, req.Actor.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if err := ensureSurveyDistributionOpen(surveyDistribution); err != nil {
+		return err
+	}
+
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": surveyDistribution.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.ValidateSurveyDistributionResponses] error when finding survey template by id: %s", err.Error())
+		return err
+	}
+	if surveyTemplate == nil {
+		return errors.New("survey template not found")
+	}
+
+	return validateSurveyTemplateAnswers(surveyTemplate, req.Answers, req.Submit)
+}
+
+// CreateOrUpdateSurveyDistributionResponses keeps the answers of the employee to a survey
+// distribution and hands the survey in when asked to. A submitted survey can still be changed
+// while the distribution is open.
+func (uc *SurveyResponseUseCase) CreateOrUpdateSurveyDistributionResponses(req *request.SurveyDistributionResponseRequest) (*response.SurveyTemplateResponse, error) {
+	if err := uc.ValidateSurveyDistributionResponses(req); err != nil {
+		return nil, err
+	}
+
+	surveyDistribution, recipient, err := uc.findSurveyDistributionRecipient(req.SurveyDistributionID, req.Actor.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": surveyDistribution.SurveyTemplateID,
+	})
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyDistributionResponses] error when finding survey template by id: %s", err.Error())
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, errors.New("survey template not found")
+	}
+
+	if err := uc.saveSurveyBulkAnswers(surveyTemplate, entity.SurveyResponse{SurveyDistributionRecipientID: &recipient.ID}, req.Answers); err != nil {
+		return nil, err
+	}
+
+	if req.Submit {
+		loc, _ := time.LoadLocation("Asia/Jakarta")
+		if err := uc.SurveyDistributionRecipientRepository.MarkSubmittedByID(recipient.ID, time.Now().In(loc)); err != nil {
+			uc.Log.Errorf("[SurveyResponseUseCase.CreateOrUpdateSurveyDistributionResponses] error when submitting survey: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	return uc.FindSurveyDistributionResponses(surveyDistribution.ID, req.Actor.EmployeeID)
+}
+
+// FindSurveyDistributionResponses returns the survey template of the distribution with the
+// answers of the employee, once the distribution has opened.
+func (uc *SurveyResponseUseCase) FindSurveyDistributionResponses(surveyDistributionID, employeeID uuid.UUID) (*response.SurveyTemplateResponse, error) {
+	surveyDistribution, recipient, err := uc.findSurveyDistributionRecipient(surveyDistributionID.String(), employeeID)
+	if err != nil {
+		return nil, err
+	}
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	if surveyDistribution.Status(time.Now().In(loc)) == entity.SURVEY_DISTRIBUTION_STATUS_ENUM_SCHEDULED {
+		return nil, errors.New("the survey opens on " + surveyDistribution.OpensOn.Format("2006-01-02"))
+	}
+
+	surveyTemplate, err := uc.SurveyTemplateRepository.FindByIDForDistributionResponse(surveyDistribution.SurveyTemplateID, recipient.ID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.FindSurveyDistributionResponses] error when finding survey template by id: %s", err.Error())
+		return nil, err
+	}
+	if surveyTemplate == nil {
+		return nil, errors.New("survey template not found")
+	}
+
+	resp := dto.HideQuizAnswerKey(uc.SurveyTemplateDTO.ConvertEntityToResponse(surveyTemplate))
+	service.MarkHiddenSurveyQuestions(resp, service.HiddenSurveyQuestions(surveyTemplate.Questions, surveyTemplateAnswers(surveyTemplate)))
 	return resp, nil
 }
+
+// findSurveyDistributionRecipient finds the distribution and the employee among its recipients.
+func (uc *SurveyResponseUseCase) findSurveyDistributionRecipient(surveyDistributionID string, employeeID uuid.UUID) (*entity.SurveyDistribution, *entity.SurveyDistributionRecipient, error) {
+	parsedSurveyDistributionID, err := uuid.Parse(surveyDistributionID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.findSurveyDistributionRecipient] error when parsing survey distribution id: %s", err.Error())
+		return nil, nil, err
+	}
+	surveyDistribution, err := uc.SurveyDistributionRepository.FindByID(parsedSurveyDistributionID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.findSurveyDistributionRecipient] error when finding survey distribution by id: %s", err.Error())
+		return nil, nil, err
+	}
+	if surveyDistribution == nil {
+		return nil, nil, errors.New("survey distribution not found")
+	}
+
+	recipient, err := uc.SurveyDistributionRecipientRepository.FindBySurveyDistributionIDAndEmployeeID(surveyDistribution.ID, employeeID)
+	if err != nil {
+		uc.Log.Errorf("[SurveyResponseUseCase.findSurveyDistributionRecipient] error when finding survey distribution recipient: %s", err.Error())
+		return nil, nil, err
+	}
+	if recipient == nil {
+		return nil, nil, errors.New("the survey was not sent to you")
+	}
+
+	return surveyDistribution, recipient, nil
+}
+
+// ensureSurveyDistributionOpen refuses answers before the distribution opens and after it closed.
+func ensureSurveyDistributionOpen(surveyDistribution *entity.SurveyDistribution) error {
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	switch surveyDistribution.Status(time.Now().In(loc)) {
+	case entity.SURVEY_DISTRIBUTION_STATUS_ENUM_SCHEDULED:
+		return errors.New("the survey opens on " + surveyDistribution.OpensOn.Format("2006-01-02"))
+	case entity.SURVEY_DISTRIBUTION_STATUS_ENUM_CLOSED:
+		return errors.New("the survey is closed")
+	}
+
+	return nil
+}
+
+// ensureSurveyEmployeeTaskOpen refuses answers to a task closed by its survey campaign.
+func ensureSurveyEmployeeTaskOpen(employeeTask *entity.EmployeeTask) error {
+	if employeeTask.ClosedAt != nil {
+		return errors.New("the survey closed on " + employeeTask.ClosedAt.Format("2006-01-02"))
+	}
+
+	return nil
+}
+
+// surveyBulkRequestAnswers reads the answers of a request the way stored responses are read,
+// an uploaded file or a kept one counting as an answer.
+func surveyBulkRequestAnswers(answers []request.AnswerBulkRequest) map[uuid.UUID][]string {
+	questionAnswers := make(map[uuid.UUID][]string)
+	for _, ans := range answers {
+		questionID, err := uuid.Parse(ans.QuestionID)
+		if err != nil {
+			continue
+		}
+		answer := strings.TrimSpace(ans.Answer)
+		if answer == "" && ans.AnswerFile != nil {
+			answer = ans.AnswerFile.Filename
+		}
+		if answer == "" && ans.AnswerPath != "" {
+			answer = ans.AnswerPath
+		}
+		if answer == "" {
+			continue
+		}
+		questionAnswers[questionID] = append(questionAnswers[questionID], answer)
+	}
+	return questionAnswers
+}
+
+// surveyTemplateAnswers collects the responses preloaded on the questions of a survey template.
+func surveyTemplateAnswers(surveyTemplate *entity.SurveyTemplate) map[uuid.UUID][]string {
+	surveyResponses := make([]entity.SurveyResponse, 0)
+	for _, question := range surveyTemplate.Questions {
+		surveyResponses = append(surveyResponses, question.SurveyResponses...)
+	}
+	return service.SurveyQuestionAnswers(surveyResponses)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
@@ -18,6 +19,8 @@ type ISurveyTemplateUseCase interface {
 	DeleteSurveyTemplate(id string) error
 	FindSurveyTemplateByID(id string) (*response.SurveyTemplateResponse, error)
 	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]response.SurveyTemplateResponse, int64, error)
+	PublishSurveyTemplate(id string) (*response.SurveyTemplateResponse, error)
+	FindAllSurveyTemplateVersions(id string) (*[]response.SurveyTemplateResponse, error)
 }
 
 type SurveyTemplateUseCase struct {
@@ -25,6 +28,7 @@ type SurveyTemplateUseCase struct {
 	Viper                    *viper.Viper
 	SurveyTemplateRepository repository.ISurveyTemplateRepository
 	SurveyTemplateDTO        dto.ISurveyTemplateDTO
+	SurveyResponseRepository repository.ISurveyResponseRepository
 }
 
 func NewSurveyTemplateUseCase(
@@ -32,12 +36,14 @@ func NewSurveyTemplateUseCase(
 	viper *viper.Viper,
 	surveyTemplateRepository repository.ISurveyTemplateRepository,
 	surveyTemplateDTO dto.ISurveyTemplateDTO,
+	surveyResponseRepository repository.ISurveyResponseRepository,
 ) *SurveyTemplateUseCase {
 	return &SurveyTemplateUseCase{
 		Log:                      log,
 		Viper:                    viper,
 		SurveyTemplateRepository: surveyTemplateRepository,
 		SurveyTemplateDTO:        surveyTemplateDTO,
+		SurveyResponseRepository: surveyResponseRepository,
 	}
 }
 
@@ -47,7 +53,8 @@ func SurveyTemplateUseCaseFactory(
 ) ISurveyTemplateUseCase {
 	surveyTemplateRepository := repository.SurveyTemplateRepositoryFactory(log)
 	surveyTemplateDTO := dto.SurveyTemplateDTOFactory(log, viper)
-	return NewSurveyTemplateUseCase(log, viper, surveyTemplateRepository, surveyTemplateDTO)
+	surveyResponseRepository := repository.SurveyResponseRepositoryFactory(log)
+	return NewSurveyTemplateUseCase(log, viper, surveyTemplateRepository, surveyTemplateDTO, surveyResponseRepository)
 }
 
 func (u *SurveyTemplateUseCase) CreateSurveyTemplate(req *request.CreateSurveyTemplateRequest) (*response.SurveyTemplateResponse, error) {
@@ -85,6 +92,14 @@ func (u *SurveyTemplateUseCase) UpdateSurveyTemplate(req *request.UpdateSurveyTe
 		return nil, fmt.Errorf("survey template not found")
 	}
 
+	// a published version is changed by editing its questions, which makes the next version
+	if ent.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED && (ent.Title != req.Title || req.Status != string(entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED)) {
+		return nil, fmt.Errorf("survey template %s version %d is published and cannot be changed", ent.SurveyNumber, ent.VersionNumber)
+	}
+	if ent.Status != entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED && req.Status == string(entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED) {
+		return u.PublishSurveyTemplate(req.ID)
+	}
+
 	ent.Title = req.Title
 	ent.Status = entity.SurveyTemplateStatusEnum(req.Status)
 
@@ -111,6 +126,16 @@ func (u *SurveyTemplateUseCase) DeleteSurveyTemplate(id string) error {
 		return fmt.Errorf("survey template not found")
 	}
 
+	// deleting a survey template deletes its responses, answered versions are kept
+	total, err := u.SurveyResponseRepository.CountBySurveyTemplateID(ent.ID)
+	if err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.DeleteSurveyTemplate] Error when counting survey responses: ", err)
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("survey template %s version %d already has responses and cannot be deleted", ent.SurveyNumber, ent.VersionNumber)
+	}
+
 	err = u.SurveyTemplateRepository.DeleteSurveyTemplate(ent)
 	if err != nil {
 		u.Log.Error("[SurveyTemplateUseCase.DeleteSurveyTemplate] Error when deleting survey template: ", err)
@@ -152,6 +177,75 @@ func (u *SurveyTemplateUseCase) FindAllPaginated(page, pageSize int, search stri
 	return &resp, total, nil
 }
 
+// PublishSurveyTemplate makes a draft version the one new employee tasks are given. The
+// employee tasks of the earlier versions keep answering those.
+func (u *SurveyTemplateUseCase) PublishSurveyTemplate(id string) (*response.SurveyTemplateResponse, error) {
+	ent, err := u.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.PublishSurveyTemplate] Error when finding survey template: ", err)
+		return nil, err
+	}
+
+	if ent == nil {
+		return nil, fmt.Errorf("survey template not found")
+	}
+	if ent.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED {
+		return nil, fmt.Errorf("survey template %s version %d is already published", ent.SurveyNumber, ent.VersionNumber)
+	}
+	if len(ent.Questions) == 0 {
+		return nil, fmt.Errorf("survey template %s version %d has no questions to publish", ent.SurveyNumber, ent.VersionNumber)
+	}
+
+	versions, err := u.SurveyTemplateRepository.FindAllVersions(ent.SurveyNumber)
+	if err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.PublishSurveyTemplate] Error when finding survey template versions: ", err)
+		return nil, err
+	}
+	for _, version := range *versions {
+		if version.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED && version.VersionNumber > ent.VersionNumber {
+			return nil, fmt.Errorf("survey template %s already has the later version %d published", ent.SurveyNumber, version.VersionNumber)
+		}
+	}
+
+	loc, _ := time.LoadLocation("Asia/Jakarta")
+	if err := u.SurveyTemplateRepository.PublishSurveyTemplate(ent.ID, time.Now().In(loc)); err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.PublishSurveyTemplate] Error when publishing survey template: ", err)
+		return nil, err
+	}
+
+	return u.FindSurveyTemplateByID(id)
+}
+
+// FindAllSurveyTemplateVersions lists the versions of the survey of a survey template.
+func (u *SurveyTemplateUseCase) FindAllSurveyTemplateVersions(id string) (*[]response.SurveyTemplateResponse, error) {
+	ent, err := u.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+		"id": id,
+	})
+	if err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.FindAllSurveyTemplateVersions] Error when finding survey template: ", err)
+		return nil, err
+	}
+
+	if ent == nil {
+		return nil, fmt.Errorf("survey template not found")
+	}
+
+	versions, err := u.SurveyTemplateRepository.FindAllVersions(ent.SurveyNumber)
+	if err != nil {
+		u.Log.Error("[SurveyTemplateUseCase.FindAllSurveyTemplateVersions] Error when finding survey template versions: ", err)
+		return nil, err
+	}
+
+	resp := make([]response.SurveyTemplateResponse, 0, len(*versions))
+	for _, version := range *versions {
+		resp = append(resp, *u.SurveyTemplateDTO.ConvertEntityToResponse(&version))
+	}
+
+	return &resp, nil
+}
+
 func (u *SurveyTemplateUseCase) generateRandomSurveyNumber() (*string, error) {
 	// Find the latest survey number
 	latestSurvey, err := u.SurveyTemplateRepository.FindLatestSurveyNumber()
@@ -0,0 +1,1041 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+const (
+	// version 2 carries the quiz and anonymity settings of survey templates and the answer rules,
+	// display conditions and matrix rows of their questions
+	templateBundleFormatVersion = 2
+	templateBundleFileName      = "bundle.json"
+	templateBundleFilesDir      = "files/"
+	// an archive entry expanding past these sizes is rejected before it is read into memory
+	templateBundleMaxEntrySize = 20 << 20  // 20MB
+	templateBundleMaxTotalSize = 100 << 20 // 100MB
+
+	TEMPLATE_BUNDLE_CONFLICT_STRATEGY_SKIP       = "SKIP"
+	TEMPLATE_BUNDLE_CONFLICT_STRATEGY_CREATE_NEW = "CREATE_NEW"
+
+	TEMPLATE_BUNDLE_IMPORT_ACTION_CREATE  = "CREATE"
+	TEMPLATE_BUNDLE_IMPORT_ACTION_SKIP    = "SKIP"
+	TEMPLATE_BUNDLE_IMPORT_ACTION_REUSE   = "REUSE"
+	TEMPLATE_BUNDLE_IMPORT_ACTION_RENAME  = "RENAME"
+	TEMPLATE_BUNDLE_IMPORT_ACTION_MISSING = "MISSING"
+)
+
+type ITemplateBundleUseCase interface {
+	ExportTemplateBundle(req *request.ExportTemplateBundleRequest) (*response.TemplateBundleResponse, error)
+	WriteTemplateBundleArchive(bundle *response.TemplateBundleResponse) ([]byte, error)
+	ImportTemplateBundle(req *request.ImportTemplateBundleRequest) (*response.TemplateBundleImportResponse, error)
+}
+
+type TemplateBundleUseCase struct {
+	Log                      *logrus.Logger
+	Viper                    *viper.Viper
+	Validate                 *validator.Validate
+	DB                       *gorm.DB
+	TemplateTaskRepository   repository.ITemplateTaskRepository
+	SurveyTemplateRepository repository.ISurveyTemplateRepository
+	AnswerTypeRepository     repository.IAnswerTypeRepository
+	TemplateTaskRuleService  service.ITemplateTaskRuleService
+}
+
+func NewTemplateBundleUseCase(
+	log *logrus.Logger,
+	viper *viper.Viper,
+	validate *validator.Validate,
+	db *gorm.DB,
+	ttRepo repository.ITemplateTaskRepository,
+	stRepo repository.ISurveyTemplateRepository,
+	atRepo repository.IAnswerTypeRepository,
+	templateTaskRuleService service.ITemplateTaskRuleService,
+) ITemplateBundleUseCase {
+	return &TemplateBundleUseCase{
+		Log:                      log,
+		Viper:                    viper,
+		Validate:                 validate,
+		DB:                       db,
+		TemplateTaskRepository:   ttRepo,
+		SurveyTemplateRepository: stRepo,
+		AnswerTypeRepository:     atRepo,
+		TemplateTaskRuleService:  templateTaskRuleService,
+	}
+}
+
+func TemplateBundleUseCaseFactory(log *logrus.Logger, viper *viper.Viper) ITemplateBundleUseCase {
+	validate := config.NewValidator(viper)
+	db := config.NewDatabase()
+	ttRepo := repository.TemplateTaskRepositoryFactory(log)
+	stRepo := repository.SurveyTemplateRepositoryFactory(log)
+	atRepo := repository.AnswerTypeRepositoryFactory(log)
+	templateTaskRuleService := service.TemplateTaskRuleServiceFactory(log)
+	return NewTemplateBundleUseCase(log, viper, validate, db, ttRepo, stRepo, atRepo, templateTaskRuleService)
+}
+
+// ExportTemplateBundle collects the selected template tasks and survey templates into a bundle.
+func (uc *TemplateBundleUseCase) ExportTemplateBundle(req *request.ExportTemplateBundleRequest) (*response.TemplateBundleResponse, error) {
+	if len(req.TemplateTaskIDs) == 0 && len(req.SurveyTemplateIDs) == 0 && req.OrganizationType == "" {
+		return nil, errors.New("select template tasks, survey templates or an organization type to export")
+	}
+
+	templateTasks := make([]entity.TemplateTask, 0)
+	if len(req.TemplateTaskIDs) > 0 || req.OrganizationType != "" {
+		keys := map[string]interface{}{}
+		if len(req.TemplateTaskIDs) > 0 {
+			parsedIDs := make([]uuid.UUID, 0, len(req.TemplateTaskIDs))
+			for _, id := range req.TemplateTaskIDs {
+				parsedID, err := uuid.Parse(id)
+				if err != nil {
+					uc.Log.Error("[TemplateBundleUseCase.ExportTemplateBundle] " + err.Error())
+					return nil, err
+				}
+				parsedIDs = append(parsedIDs, parsedID)
+			}
+			keys["id"] = parsedIDs
+		}
+		if req.OrganizationType != "" {
+			keys["organization_type"] = req.OrganizationType
+		}
+
+		found, err := uc.TemplateTaskRepository.FindAllByKeysWithDetails(keys)
+		if err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ExportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+		if len(req.TemplateTaskIDs) > 0 && len(*found) != len(req.TemplateTaskIDs) {
+			return nil, errors.New("some template tasks were not found")
+		}
+		templateTasks = *found
+	}
+
+	surveyTemplateIDs := make([]string, 0)
+	seenSurveyTemplateIDs := make(map[string]bool)
+	for _, id := range req.SurveyTemplateIDs {
+		if !seenSurveyTemplateIDs[id] {
+			seenSurveyTemplateIDs[id] = true
+			surveyTemplateIDs = append(surveyTemplateIDs, id)
+		}
+	}
+	for _, templateTask := range templateTasks {
+		if templateTask.SurveyTemplateID != nil && !seenSurveyTemplateIDs[templateTask.SurveyTemplateID.String()] {
+			seenSurveyTemplateIDs[templateTask.SurveyTemplateID.String()] = true
+			surveyTemplateIDs = append(surveyTemplateIDs, templateTask.SurveyTemplateID.String())
+		}
+	}
+
+	bundle := &response.TemplateBundleResponse{
+		FormatVersion:   templateBundleFormatVersion,
+		ExportedAt:      time.Now(),
+		SurveyTemplates: make([]response.TemplateBundleSurveyTemplateResponse, 0, len(surveyTemplateIDs)),
+		TemplateTasks:   make([]response.TemplateBundleTemplateTaskResponse, 0, len(templateTasks)),
+	}
+
+	for _, id := range surveyTemplateIDs {
+		surveyTemplate, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+			"id": id,
+		})
+		if err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ExportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+		if surveyTemplate == nil {
+			return nil, errors.New("survey template " + id + " not found")
+		}
+
+		bundle.SurveyTemplates = append(bundle.SurveyTemplates, templateBundleSurveyTemplate(surveyTemplate))
+	}
+
+	for _, templateTask := range templateTasks {
+		var surveyTemplateRef string
+		if templateTask.SurveyTemplateID != nil {
+			surveyTemplateRef = templateTask.SurveyTemplateID.String()
+		}
+
+		checklists := make([]string, 0, len(templateTask.TemplateTaskChecklists))
+		for _, checklist := range templateTask.TemplateTaskChecklists {
+			checklists = append(checklists, checklist.Name)
+		}
+		attachments := make([]string, 0, len(templateTask.TemplateTaskAttachments))
+		for _, attachment := range templateTask.TemplateTaskAttachments {
+			attachments = append(attachments, attachment.Path)
+		}
+		rules := make([]entity.TemplateTaskSnapshotRule, 0, len(templateTask.TemplateTaskRules))
+		for _, rule := range templateTask.TemplateTaskRules {
+			rules = append(rules, entity.TemplateTaskSnapshotRule{
+				GroupNumber: rule.GroupNumber,
+				Criterion:   string(rule.Criterion),
+				Operator:    string(rule.Operator),
+				Value:       rule.Value,
+			})
+		}
+		var approvalSteps []entity.TemplateTaskSnapshotApprovalStep
+		for _, step := range templateTask.TemplateTaskApprovalSteps {
+			approvalSteps = append(approvalSteps, entity.TemplateTaskSnapshotApprovalStep{
+				StepOrder:          step.StepOrder,
+				ApproverType:       string(step.ApproverType),
+				ApproverEmployeeID: step.ApproverEmployeeID,
+			})
+		}
+
+		bundle.TemplateTasks = append(bundle.TemplateTasks, response.TemplateBundleTemplateTaskResponse{
+			Ref:               templateTask.ID.String(),
+			Name:              templateTask.Name,
+			Description:       templateTask.Description,
+			Priority:          string(templateTask.Priority),
+			DueDuration:       templateTask.DueDuration,
+			Status:            string(templateTask.Status),
+			Source:            templateTask.Source,
+			OrganizationType:  templateTask.OrganizationType,
+			CoverPath:         templateTask.CoverPath,
+			SurveyTemplateRef: surveyTemplateRef,
+			Checklists:        checklists,
+			Attachments:       attachments,
+			Rules:             rules,
+			ApprovalSteps:     approvalSteps,
+			ChecklistSettings: service.ChecklistSettings(templateTask.TemplateTaskChecklists),
+			Kind:              string(templateTask.Kind),
+			PassingScore:      templateTask.PassingScore,
+		})
+	}
+
+	return bundle, nil
+}
+
+// templateBundleSurveyTemplate writes a survey template, loaded with its questions, into the bundle.
+func templateBundleSurveyTemplate(surveyTemplate *entity.SurveyTemplate) response.TemplateBundleSurveyTemplateResponse {
+	questionNumbers := make(map[uuid.UUID]int, len(surveyTemplate.Questions))
+	for _, question := range surveyTemplate.Questions {
+		questionNumbers[question.ID] = question.Number
+	}
+
+	questions := make([]response.TemplateBundleQuestionResponse, 0, len(surveyTemplate.Questions))
+	for _, question := range surveyTemplate.Questions {
+		options := make([]response.TemplateBundleQuestionOptionResponse, 0, len(question.QuestionOptions))
+		for _, option := range question.QuestionOptions {
+			options = append(options, response.TemplateBundleQuestionOptionResponse{
+				OptionText: option.OptionText,
+				IsCorrect:  option.IsCorrect,
+			})
+		}
+		var answerType string
+		if question.AnswerType != nil {
+			answerType = question.AnswerType.Name
+		}
+		var allowedFileTypes []string
+		if question.AllowedFileTypes != nil && *question.AllowedFileTypes != "" {
+			allowedFileTypes = strings.Split(*question.AllowedFileTypes, ",")
+		}
+		displayConditions := make([]response.TemplateBundleQuestionConditionResponse, 0, len(question.DisplayConditions))
+		for _, condition := range question.DisplayConditions {
+			displayConditions = append(displayConditions, response.TemplateBundleQuestionConditionResponse{
+				SourceQuestionNumber: questionNumbers[condition.SourceQuestionID],
+				Operator:             string(condition.Operator),
+				Value:                condition.Value,
+			})
+		}
+		matrixRows := make([]string, 0, len(question.MatrixRows))
+		for _, matrixRow := range question.MatrixRows {
+			matrixRows = append(matrixRows, matrixRow.RowText)
+		}
+		questions = append(questions, response.TemplateBundleQuestionResponse{
+			Number:            question.Number,
+			Question:          question.Question,
+			AnswerType:        answerType,
+			MaxStars:          question.MaxStars,
+			Points:            question.Points,
+			Attachment:        question.Attachment,
+			Options:           options,
+			IsRequired:        question.IsRequired,
+			MinLength:         question.MinLength,
+			MaxLength:         question.MaxLength,
+			Pattern:           question.Pattern,
+			AllowedFileTypes:  allowedFileTypes,
+			MaxFileSizeKB:     question.MaxFileSizeKB,
+			MinSelections:     question.MinSelections,
+			MaxSelections:     question.MaxSelections,
+			MinValue:          question.MinValue,
+			MaxValue:          question.MaxValue,
+			MinDate:           templateBundleDate(question.MinDate),
+			MaxDate:           templateBundleDate(question.MaxDate),
+			MatrixRows:        matrixRows,
+			ConditionMatch:    string(question.ConditionMatch),
+			DisplayConditions: displayConditions,
+		})
+	}
+	sort.SliceStable(questions, func(i, j int) bool {
+		return questions[i].Number < questions[j].Number
+	})
+
+	return response.TemplateBundleSurveyTemplateResponse{
+		Ref:          surveyTemplate.ID.String(),
+		Title:        surveyTemplate.Title,
+		Status:       string(surveyTemplate.Status),
+		IsQuiz:       surveyTemplate.IsQuiz,
+		PassingScore: surveyTemplate.PassingScore,
+		MaxAttempts:  surveyTemplate.MaxAttempts,
+		Anonymity:    string(surveyTemplate.Anonymity),
+		MinGroupSize: surveyTemplate.MinGroupSize,
+		Questions:    questions,
+	}
+}
+
+// templateBundleDate writes a date bound of a question as the question editor sends it.
+func templateBundleDate(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+	date := value.Format("2006-01-02")
+	return &date
+}
+
+// templateBundleQuestionsRequest is a survey template of the bundle as the question editor
+// would send it, so that an import checks it like the editor does. Display conditions point at
+// their source question by its position, a number missing from the bundle points at none.
+func templateBundleQuestionsRequest(surveyTemplate response.TemplateBundleSurveyTemplateResponse, answerTypeIDs map[string]uuid.UUID) *request.CreateOrUpdateQuestions {
+	positions := make(map[int]int, len(surveyTemplate.Questions))
+	for i, question := range surveyTemplate.Questions {
+		positions[question.Number] = i + 1
+	}
+
+	questions := make([]request.QuestionRequest, 0, len(surveyTemplate.Questions))
+	for _, question := range surveyTemplate.Questions {
+		options := make([]request.QuestionOptionRequest, 0, len(question.Options))
+		for _, option := range question.Options {
+			options = append(options, request.QuestionOptionRequest{
+				OptionText: option.OptionText,
+				IsCorrect:  option.IsCorrect,
+			})
+		}
+		displayConditions := make([]request.QuestionConditionRequest, 0, len(question.DisplayConditions))
+		for _, condition := range question.DisplayConditions {
+			displayConditions = append(displayConditions, request.QuestionConditionRequest{
+				SourceQuestionNumber: positions[condition.SourceQuestionNumber],
+				Operator:             condition.Operator,
+				Value:                condition.Value,
+			})
+		}
+		matrixRows := make([]request.QuestionMatrixRowRequest, 0, len(question.MatrixRows))
+		for _, matrixRow := range question.MatrixRows {
+			matrixRows = append(matrixRows, request.QuestionMatrixRowRequest{RowText: matrixRow})
+		}
+		questions = append(questions, request.QuestionRequest{
+			AnswerTypeID:      answerTypeIDs[strings.ToLower(question.AnswerType)].String(),
+			Question:          question.Question,
+			MaxStars:          question.MaxStars,
+			Points:            question.Points,
+			QuestionOptions:   options,
+			IsRequired:        question.IsRequired,
+			MinLength:         question.MinLength,
+			MaxLength:         question.MaxLength,
+			Pattern:           question.Pattern,
+			AllowedFileTypes:  question.AllowedFileTypes,
+			MaxFileSizeKB:     question.MaxFileSizeKB,
+			MinSelections:     question.MinSelections,
+			MaxSelections:     question.MaxSelections,
+			MinValue:          question.MinValue,
+			MaxValue:          question.MaxValue,
+			MinDate:           question.MinDate,
+			MaxDate:           question.MaxDate,
+			MatrixRows:        matrixRows,
+			ConditionMatch:    question.ConditionMatch,
+			DisplayConditions: displayConditions,
+		})
+	}
+
+	return &request.CreateOrUpdateQuestions{
+		Title:        surveyTemplate.Title,
+		IsQuiz:       surveyTemplate.IsQuiz,
+		PassingScore: surveyTemplate.PassingScore,
+		MaxAttempts:  surveyTemplate.MaxAttempts,
+		Anonymity:    surveyTemplate.Anonymity,
+		MinGroupSize: surveyTemplate.MinGroupSize,
+		Questions:    questions,
+	}
+}
+
+// surveyTemplateFromBundle reads a survey template of the bundle. The caller gives it a survey
+// number, remapPath points attachments at where the import stored them. Answer rules are read
+// the way the question editor saves them.
+func surveyTemplateFromBundle(surveyTemplate response.TemplateBundleSurveyTemplateResponse, answerTypeIDs map[string]uuid.UUID, remapPath func(*string) *string) *entity.SurveyTemplate {
+	questionsReq := templateBundleQuestionsRequest(surveyTemplate, answerTypeIDs)
+	questions := make([]entity.Question, 0, len(surveyTemplate.Questions))
+	for i, question := range surveyTemplate.Questions {
+		options := make([]entity.QuestionOption, 0, len(question.Options))
+		for _, option := range question.Options {
+			options = append(options, entity.QuestionOption{
+				OptionText: option.OptionText,
+				IsCorrect:  option.IsCorrect,
+			})
+		}
+		matrixRows := make([]entity.QuestionMatrixRow, 0, len(question.MatrixRows))
+		for j, matrixRow := range question.MatrixRows {
+			matrixRows = append(matrixRows, entity.QuestionMatrixRow{
+				RowText: matrixRow,
+				Number:  j + 1,
+			})
+		}
+		questions = append(questions, *withQuestionRules(&entity.Question{
+			AnswerTypeID:    answerTypeIDs[strings.ToLower(question.AnswerType)],
+			Question:        question.Question,
+			Attachment:      remapPath(question.Attachment),
+			Number:          question.Number,
+			MaxStars:        question.MaxStars,
+			Points:          question.Points,
+			QuestionOptions: options,
+			MatrixRows:      matrixRows,
+		}, &questionsReq.Questions[i]))
+	}
+
+	anonymitySettings := service.SurveyAnonymitySettings(questionsReq, nil)
+	return &entity.SurveyTemplate{
+		Title:        surveyTemplate.Title,
+		Status:       entity.SurveyTemplateStatusEnum(surveyTemplate.Status),
+		IsQuiz:       surveyTemplate.IsQuiz,
+		PassingScore: surveyTemplate.PassingScore,
+		MaxAttempts:  surveyTemplate.MaxAttempts,
+		Anonymity:    anonymitySettings.Anonymity,
+		MinGroupSize: anonymitySettings.MinGroupSize,
+		Questions:    questions,
+	}
+}
+
+// templateBundleQuestionConditions reads the display conditions of a survey template of the
+// bundle once its questions are stored, as a condition points at its source question by id.
+func templateBundleQuestionConditions(surveyTemplate response.TemplateBundleSurveyTemplateResponse, answerTypeIDs map[string]uuid.UUID, questions []entity.Question) []entity.QuestionCondition {
+	questionsReq := templateBundleQuestionsRequest(surveyTemplate, answerTypeIDs)
+	conditions := make([]entity.QuestionCondition, 0)
+	for i, question := range questionsReq.Questions {
+		for _, condition := range question.DisplayConditions {
+			conditions = append(conditions, entity.QuestionCondition{
+				QuestionID:       questions[i].ID,
+				SourceQuestionID: questions[condition.SourceQuestionNumber-1].ID,
+				Operator:         entity.QuestionConditionOperatorEnum(condition.Operator),
+				Value:            condition.Value,
+			})
+		}
+	}
+
+	return conditions
+}
+
+// WriteTemplateBundleArchive zips the bundle together with the stored files it refers to.
+// Files that are missing on disk are left out, an import reports them as missing.
+func (uc *TemplateBundleUseCase) WriteTemplateBundleArchive(bundle *response.TemplateBundleResponse) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+
+	encoded, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+		return nil, err
+	}
+	bundleWriter, err := zipWriter.Create(templateBundleFileName)
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+		return nil, err
+	}
+	if _, err := bundleWriter.Write(encoded); err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+		return nil, err
+	}
+
+	for _, filePath := range templateBundleFilePaths(bundle) {
+		if !isTemplateBundleStoragePath(filePath) {
+			continue
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			uc.Log.Warn("[TemplateBundleUseCase.WriteTemplateBundleArchive] skipping file " + filePath + ": " + err.Error())
+			continue
+		}
+		fileWriter, err := zipWriter.Create(templateBundleFilesDir + filePath)
+		if err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+			return nil, err
+		}
+		if _, err := fileWriter.Write(content); err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.WriteTemplateBundleArchive] " + err.Error())
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// templateBundleFilePaths lists the stored files the bundle refers to, without duplicates.
+func templateBundleFilePaths(bundle *response.TemplateBundleResponse) []string {
+	paths := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(filePath string) {
+		if filePath == "" || seen[filePath] {
+			return
+		}
+		seen[filePath] = true
+		paths = append(paths, filePath)
+	}
+
+	for _, surveyTemplate := range bundle.SurveyTemplates {
+		for _, question := range surveyTemplate.Questions {
+			if question.Attachment != nil {
+				add(*question.Attachment)
+			}
+		}
+	}
+	for _, templateTask := range bundle.TemplateTasks {
+		if templateTask.CoverPath != nil {
+			add(*templateTask.CoverPath)
+		}
+		for _, attachment := range templateTask.Attachments {
+			add(attachment)
+		}
+	}
+
+	return paths
+}
+
+// isTemplateBundleStoragePath only accepts relative paths inside the storage directory, so a
+// bundle can neither read nor write files elsewhere on the server.
+func isTemplateBundleStoragePath(filePath string) bool {
+	if filePath == "" || filepath.IsAbs(filePath) || strings.Contains(filePath, "\\") {
+		return false
+	}
+	cleaned := path.Clean(filePath)
+	return cleaned == filePath && strings.HasPrefix(cleaned, "storage/") && !strings.Contains(cleaned, "..")
+}
+
+// readTemplateBundle reads a bundle from a JSON document or a ZIP archive made by
+// WriteTemplateBundleArchive. For archives the bundled files are returned by path.
+func readTemplateBundle(content []byte) (*response.TemplateBundleResponse, map[string][]byte, error) {
+	files := make(map[string][]byte)
+	bundleContent := content
+
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		bundleContent = nil
+		var totalSize int64
+		for _, zipFile := range zipReader.File {
+			if zipFile.FileInfo().IsDir() {
+				continue
+			}
+			if zipFile.Name != templateBundleFileName && !strings.HasPrefix(zipFile.Name, templateBundleFilesDir) {
+				continue
+			}
+
+			reader, err := zipFile.Open()
+			if err != nil {
+				return nil, nil, err
+			}
+			// the sizes in the archive header can lie, so the read itself is limited
+			fileContent, err := io.ReadAll(io.LimitReader(reader, templateBundleMaxEntrySize+1))
+			reader.Close()
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(fileContent) > templateBundleMaxEntrySize {
+				return nil, nil, errors.New("archive entry " + zipFile.Name + " is larger than " + strconv.Itoa(templateBundleMaxEntrySize>>20) + "MB")
+			}
+			totalSize += int64(len(fileContent))
+			if totalSize > templateBundleMaxTotalSize {
+				return nil, nil, errors.New("archive content is larger than " + strconv.Itoa(templateBundleMaxTotalSize>>20) + "MB")
+			}
+
+			if zipFile.Name == templateBundleFileName {
+				bundleContent = fileContent
+			} else {
+				files[strings.TrimPrefix(zipFile.Name, templateBundleFilesDir)] = fileContent
+			}
+		}
+		if bundleContent == nil {
+			return nil, nil, errors.New("archive does not contain " + templateBundleFileName)
+		}
+	}
+
+	var bundle response.TemplateBundleResponse
+	if err := json.Unmarshal(bundleContent, &bundle); err != nil {
+		return nil, nil, errors.New("invalid bundle: " + err.Error())
+	}
+
+	return &bundle, files, nil
+}
+
+type templateBundleFilePlan struct {
+	targetPath string
+	content    []byte
+	write      bool
+}
+
+// ImportTemplateBundle validates a bundle against this environment and, unless it is a dry
+// run, creates its survey templates and template tasks with fresh ids in one transaction.
+// Existing records with the same survey title, or the same template task name and
+// organization type, are conflicts that the conflict strategy resolves.
+func (uc *TemplateBundleUseCase) ImportTemplateBundle(req *request.ImportTemplateBundleRequest) (*response.TemplateBundleImportResponse, error) {
+	file, err := req.File.Open()
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+		return nil, err
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+		return nil, err
+	}
+
+	bundle, bundleFiles, err := readTemplateBundle(content)
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+		return nil, err
+	}
+
+	conflictStrategy := req.ConflictStrategy
+	if conflictStrategy == "" {
+		conflictStrategy = TEMPLATE_BUNDLE_CONFLICT_STRATEGY_SKIP
+	}
+
+	res := &response.TemplateBundleImportResponse{
+		DryRun:           req.DryRun,
+		FormatVersion:    bundle.FormatVersion,
+		ConflictStrategy: conflictStrategy,
+		SurveyTemplates:  make([]response.TemplateBundleImportItemResponse, 0, len(bundle.SurveyTemplates)),
+		TemplateTasks:    make([]response.TemplateBundleImportItemResponse, 0, len(bundle.TemplateTasks)),
+		Files:            make([]response.TemplateBundleImportItemResponse, 0),
+		Errors:           make([]string, 0),
+	}
+	if bundle.FormatVersion != templateBundleFormatVersion {
+		res.Errors = append(res.Errors, "unsupported bundle format version "+strconv.Itoa(bundle.FormatVersion))
+		return res, nil
+	}
+
+	answerTypes, err := uc.AnswerTypeRepository.FindAll()
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+		return nil, err
+	}
+	answerTypeIDs := make(map[string]uuid.UUID)
+	for _, answerType := range answerTypes {
+		answerTypeIDs[strings.ToLower(answerType.Name)] = answerType.ID
+	}
+
+	// survey templates
+	surveyTemplateRefs := make(map[string]int)
+	for i, surveyTemplate := range bundle.SurveyTemplates {
+		item := response.TemplateBundleImportItemResponse{
+			Ref:    surveyTemplate.Ref,
+			Name:   surveyTemplate.Title,
+			Action: TEMPLATE_BUNDLE_IMPORT_ACTION_CREATE,
+		}
+		if _, ok := surveyTemplateRefs[surveyTemplate.Ref]; ok || surveyTemplate.Ref == "" {
+			res.Errors = append(res.Errors, "survey template "+surveyTemplate.Title+": ref is empty or used twice")
+		}
+		surveyTemplateRefs[surveyTemplate.Ref] = i
+
+		if surveyTemplate.Title == "" {
+			res.Errors = append(res.Errors, "survey template "+surveyTemplate.Ref+": title is required")
+		}
+		switch entity.SurveyTemplateStatusEnum(surveyTemplate.Status) {
+		case entity.SURVEY_TEMPLATE_STATUS_ENUM_DRAFT, entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED:
+		default:
+			res.Errors = append(res.Errors, "survey template "+surveyTemplate.Title+": unknown status "+surveyTemplate.Status)
+		}
+		questionNumbers := make(map[int]bool, len(surveyTemplate.Questions))
+		for _, question := range surveyTemplate.Questions {
+			questionNumbers[question.Number] = true
+		}
+		for _, question := range surveyTemplate.Questions {
+			if _, ok := answerTypeIDs[strings.ToLower(question.AnswerType)]; !ok {
+				res.Errors = append(res.Errors, "survey template "+surveyTemplate.Title+", question "+strconv.Itoa(question.Number)+": answer type "+question.AnswerType+" does not exist")
+			}
+			for _, condition := range question.DisplayConditions {
+				if !questionNumbers[condition.SourceQuestionNumber] {
+					res.Errors = append(res.Errors, "survey template "+surveyTemplate.Title+", question "+strconv.Itoa(question.Number)+": display condition on question "+strconv.Itoa(condition.SourceQuestionNumber)+" that is not in the bundle")
+				}
+			}
+		}
+		questionsReq := templateBundleQuestionsRequest(surveyTemplate, answerTypeIDs)
+		questionsErr := uc.Validate.Struct(questionsReq)
+		if questionsErr == nil {
+			questionsErr = service.ValidateSurveyQuiz(questionsReq)
+		}
+		if questionsErr == nil {
+			questionsErr = service.ValidateSurveyQuestionRules(questionsReq)
+		}
+		if questionsErr == nil {
+			questionsErr = service.ValidateSurveyQuestionConditions(questionsReq)
+		}
+		if questionsErr == nil {
+			questionsErr = service.ValidateSurveyAnonymity(questionsReq)
+		}
+		if questionsErr == nil {
+			questionsErr = service.ValidateSurveyAnonymitySettings(questionsReq.IsQuiz, service.SurveyAnonymitySettings(questionsReq, nil))
+		}
+		if questionsErr != nil {
+			res.Errors = append(res.Errors, "survey template "+surveyTemplate.Title+": "+questionsErr.Error())
+		}
+
+		existing, err := uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+			"title": surveyTemplate.Title,
+		})
+		if err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+		if existing != nil {
+			item.ExistingID = &existing.ID
+			if conflictStrategy == TEMPLATE_BUNDLE_CONFLICT_STRATEGY_SKIP {
+				item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_REUSE
+				item.Message = "a survey template with this title exists, template tasks will use it"
+			} else {
+				item.Message = "a survey template with this title exists, a new one is created"
+			}
+		}
+		res.SurveyTemplates = append(res.SurveyTemplates, item)
+	}
+
+	// template tasks
+	templateTaskRefs := make(map[string]bool)
+	for _, templateTask := range bundle.TemplateTasks {
+		item := response.TemplateBundleImportItemResponse{
+			Ref:    templateTask.Ref,
+			Name:   templateTask.Name,
+			Action: TEMPLATE_BUNDLE_IMPORT_ACTION_CREATE,
+		}
+		label := "template task " + templateTask.Name
+		if templateTaskRefs[templateTask.Ref] || templateTask.Ref == "" {
+			res.Errors = append(res.Errors, label+": ref is empty or used twice")
+		}
+		templateTaskRefs[templateTask.Ref] = true
+
+		if templateTask.Name == "" {
+			res.Errors = append(res.Errors, "template task "+templateTask.Ref+": name is required")
+		}
+		if err := uc.Validate.Var(templateTask.Priority, "required,template_task_priority_validation"); err != nil {
+			res.Errors = append(res.Errors, label+": unknown priority "+templateTask.Priority)
+		}
+		if err := uc.Validate.Var(templateTask.Status, "required,template_task_status_validation"); err != nil {
+			res.Errors = append(res.Errors, label+": unknown status "+templateTask.Status)
+		}
+		if err := uc.Validate.Var(templateTask.Source, "omitempty,task_source_validation"); err != nil {
+			res.Errors = append(res.Errors, label+": unknown source "+templateTask.Source)
+		}
+		if templateTask.SurveyTemplateRef != "" {
+			if _, ok := surveyTemplateRefs[templateTask.SurveyTemplateRef]; !ok {
+				res.Errors = append(res.Errors, label+": survey template "+templateTask.SurveyTemplateRef+" is not in the bundle")
+			}
+		}
+		if err := uc.Validate.Var(templateTask.Kind, "omitempty,task_kind_validation"); err != nil {
+			res.Errors = append(res.Errors, label+": unknown kind "+templateTask.Kind)
+		} else if err := service.ValidateTaskKind(templateTask.Kind, &templateTask.SurveyTemplateRef, templateTask.PassingScore); err != nil {
+			res.Errors = append(res.Errors, label+": "+err.Error())
+		}
+
+		rules := make([]request.TemplateTaskRuleRequest, 0, len(templateTask.Rules))
+		for _, rule := range templateTask.Rules {
+			rules = append(rules, request.TemplateTaskRuleRequest{
+				GroupNumber: rule.GroupNumber,
+				Criterion:   rule.Criterion,
+				Operator:    rule.Operator,
+				Value:       rule.Value,
+			})
+		}
+		ruleErr := uc.Validate.Var(rules, "omitempty,dive")
+		if ruleErr == nil {
+			ruleErr = uc.TemplateTaskRuleService.ValidateRules(rules)
+		}
+		if ruleErr != nil {
+			res.Errors = append(res.Errors, label+": invalid rules: "+ruleErr.Error())
+		} else if len(rules) > 0 {
+			item.Message = "targeting rules refer to ids of the exporting environment, check them after the import"
+		}
+
+		approvalSteps := make([]request.TemplateTaskApprovalStepRequest, 0, len(templateTask.ApprovalSteps))
+		namesApprover := false
+		for _, step := range templateTask.ApprovalSteps {
+			approvalStep := request.TemplateTaskApprovalStepRequest{
+				ApproverType: step.ApproverType,
+			}
+			if step.ApproverEmployeeID != nil {
+				approvalStep.ApproverEmployeeID = step.ApproverEmployeeID.String()
+				namesApprover = true
+			}
+			approvalSteps = append(approvalSteps, approvalStep)
+		}
+		approvalStepErr := uc.Validate.Var(approvalSteps, "omitempty,dive")
+		if approvalStepErr == nil {
+			approvalStepErr = service.ValidateApprovalSteps(approvalSteps)
+		}
+		if approvalStepErr != nil {
+			res.Errors = append(res.Errors, label+": invalid approval steps: "+approvalStepErr.Error())
+		} else if namesApprover && item.Message == "" {
+			item.Message = "approval steps name employees of the exporting environment, check them after the import"
+		}
+
+		namesAssignee := false
+		for _, setting := range templateTask.ChecklistSettings {
+			if setting.IsRequired != "" && setting.IsRequired != "YES" && setting.IsRequired != "NO" {
+				res.Errors = append(res.Errors, label+": invalid checklist settings: is_required of "+setting.Name+" must be YES or NO")
+			}
+			if setting.DueOffsetDays != nil && *setting.DueOffsetDays < 0 {
+				res.Errors = append(res.Errors, label+": invalid checklist settings: due_offset_days of "+setting.Name+" cannot be negative")
+			}
+			if setting.AssigneeID != nil {
+				namesAssignee = true
+			}
+		}
+		if namesAssignee && item.Message == "" {
+			item.Message = "checklist items are assigned to employees of the exporting environment, check them after the import"
+		}
+
+		existing, err := uc.TemplateTaskRepository.FindAllByKeys(map[string]interface{}{
+			"name":              templateTask.Name,
+			"organization_type": templateTask.OrganizationType,
+		})
+		if err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+		if len(*existing) > 0 {
+			item.ExistingID = &(*existing)[0].ID
+			if conflictStrategy == TEMPLATE_BUNDLE_CONFLICT_STRATEGY_SKIP {
+				item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_SKIP
+				item.Message = "a template task with this name exists for the organization type"
+			} else {
+				item.Message = "a template task with this name exists for the organization type, a new one is created"
+			}
+		}
+		res.TemplateTasks = append(res.TemplateTasks, item)
+	}
+
+	// files
+	filePlans := make(map[string]*templateBundleFilePlan)
+	for _, filePath := range templateBundleFilePaths(bundle) {
+		item := response.TemplateBundleImportItemResponse{
+			Ref:  filePath,
+			Name: path.Base(filePath),
+		}
+		if !isTemplateBundleStoragePath(filePath) {
+			res.Errors = append(res.Errors, "file "+filePath+": path must be inside storage/")
+			continue
+		}
+
+		fileContent, inBundle := bundleFiles[filePath]
+		existingContent, readErr := os.ReadFile(filePath)
+		existsOnDisk := readErr == nil
+		plan := &templateBundleFilePlan{targetPath: filePath, content: fileContent}
+		switch {
+		case !inBundle && existsOnDisk:
+			item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_REUSE
+			item.Message = "not in the bundle, the existing file is used"
+		case !inBundle:
+			item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_MISSING
+			item.Message = "not in the bundle and not on this server, the path is kept as is"
+		case existsOnDisk && bytes.Equal(existingContent, fileContent):
+			item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_REUSE
+			item.Message = "an identical file exists"
+		case existsOnDisk:
+			plan.targetPath = path.Join(path.Dir(filePath), strconv.FormatInt(time.Now().UnixNano(), 10)+"_"+path.Base(filePath))
+			plan.write = true
+			item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_RENAME
+			item.Message = "a different file exists at this path, stored as " + plan.targetPath
+		default:
+			plan.write = true
+			item.Action = TEMPLATE_BUNDLE_IMPORT_ACTION_CREATE
+		}
+		filePlans[filePath] = plan
+		res.Files = append(res.Files, item)
+	}
+
+	if req.DryRun || len(res.Errors) > 0 {
+		return res, nil
+	}
+
+	for _, plan := range filePlans {
+		if !plan.write {
+			continue
+		}
+		if err := os.MkdirAll(path.Dir(plan.targetPath), 0755); err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+		if err := os.WriteFile(plan.targetPath, plan.content, 0644); err != nil {
+			uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+			return nil, err
+		}
+	}
+	remapPath := func(filePath *string) *string {
+		if filePath == nil {
+			return nil
+		}
+		if plan, ok := filePlans[*filePath]; ok {
+			return &plan.targetPath
+		}
+		return filePath
+	}
+
+	err = uc.DB.Transaction(func(tx *gorm.DB) error {
+		surveyTemplateRepository := repository.NewSurveyTemplateRepository(uc.Log, tx)
+		questionConditionRepository := repository.NewQuestionConditionRepository(uc.Log, tx)
+		templateTaskRepository := repository.NewTemplateTaskRepository(uc.Log, tx)
+		templateTaskVersionService := service.NewTemplateTaskVersionService(uc.Log, repository.NewTemplateTaskVersionRepository(uc.Log, tx))
+		surveyNumberGenerator := &SurveyTemplateUseCase{
+			Log:                      uc.Log,
+			Viper:                    uc.Viper,
+			SurveyTemplateRepository: surveyTemplateRepository,
+		}
+
+		surveyTemplateIDs := make(map[string]uuid.UUID)
+		for i, surveyTemplate := range bundle.SurveyTemplates {
+			item := &res.SurveyTemplates[i]
+			if item.Action == TEMPLATE_BUNDLE_IMPORT_ACTION_REUSE {
+				surveyTemplateIDs[surveyTemplate.Ref] = *item.ExistingID
+				continue
+			}
+
+			surveyNumber, err := surveyNumberGenerator.generateRandomSurveyNumber()
+			if err != nil {
+				return err
+			}
+
+			newSurveyTemplate := surveyTemplateFromBundle(surveyTemplate, answerTypeIDs, remapPath)
+			newSurveyTemplate.SurveyNumber = *surveyNumber
+			if newSurveyTemplate.Status == entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED {
+				publishedAt := time.Now()
+				newSurveyTemplate.PublishedAt = &publishedAt
+			}
+			created, err := surveyTemplateRepository.CreateSurveyTemplate(newSurveyTemplate)
+			if err != nil {
+				return err
+			}
+			for _, condition := range templateBundleQuestionConditions(surveyTemplate, answerTypeIDs, created.Questions) {
+				if _, err := questionConditionRepository.CreateQuestionCondition(&condition); err != nil {
+					return err
+				}
+			}
+			surveyTemplateIDs[surveyTemplate.Ref] = created.ID
+			item.NewID = &created.ID
+		}
+
+		for i, templateTask := range bundle.TemplateTasks {
+			item := &res.TemplateTasks[i]
+			if item.Action == TEMPLATE_BUNDLE_IMPORT_ACTION_SKIP {
+				continue
+			}
+
+			var surveyTemplateID *uuid.UUID
+			if templateTask.SurveyTemplateRef != "" {
+				id := surveyTemplateIDs[templateTask.SurveyTemplateRef]
+				surveyTemplateID = &id
+			}
+
+			checklistSettings := make(map[string]entity.TemplateTaskSnapshotChecklistSetting)
+			for _, setting := range templateTask.ChecklistSettings {
+				checklistSettings[setting.Name] = setting
+			}
+			checklists := make([]entity.TemplateTaskChecklist, 0, len(templateTask.Checklists))
+			for _, checklist := range templateTask.Checklists {
+				templateTaskChecklist := entity.TemplateTaskChecklist{Name: checklist, IsRequired: "NO"}
+				if setting, ok := checklistSettings[checklist]; ok {
+					templateTaskChecklist.SortOrder = setting.SortOrder
+					templateTaskChecklist.AssigneeID = setting.AssigneeID
+					templateTaskChecklist.DueOffsetDays = setting.DueOffsetDays
+					if setting.IsRequired != "" {
+						templateTaskChecklist.IsRequired = setting.IsRequired
+					}
+				}
+				checklists = append(checklists, templateTaskChecklist)
+			}
+			attachments := make([]entity.TemplateTaskAttachment, 0, len(templateTask.Attachments))
+			for _, attachment := range templateTask.Attachments {
+				attachments = append(attachments, entity.TemplateTaskAttachment{Path: *remapPath(&attachment)})
+			}
+			rules := make([]entity.TemplateTaskRule, 0, len(templateTask.Rules))
+			for _, rule := range templateTask.Rules {
+				rules = append(rules, entity.TemplateTaskRule{
+					GroupNumber: rule.GroupNumber,
+					Criterion:   entity.TemplateTaskRuleCriterionEnum(rule.Criterion),
+					Operator:    entity.TemplateTaskRuleOperatorEnum(rule.Operator),
+					Value:       rule.Value,
+				})
+			}
+			approvalSteps := make([]entity.TemplateTaskApprovalStep, 0, len(templateTask.ApprovalSteps))
+			for _, step := range templateTask.ApprovalSteps {
+				approvalSteps = append(approvalSteps, entity.TemplateTaskApprovalStep{
+					StepOrder:          step.StepOrder,
+					ApproverType:       entity.ApprovalApproverTypeEnum(step.ApproverType),
+					ApproverEmployeeID: step.ApproverEmployeeID,
+				})
+			}
+
+			// bundles exported before offboarding existed only hold onboarding templates
+			source := templateTask.Source
+			if source == "" {
+				source = entity.TASK_SOURCE_ONBOARDING
+			}
+
+			created, err := templateTaskRepository.CreateTemplateTask(&entity.TemplateTask{
+				SurveyTemplateID:          surveyTemplateID,
+				CoverPath:                 remapPath(templateTask.CoverPath),
+				Name:                      templateTask.Name,
+				Priority:                  entity.TemplateTaskPriorityEnum(templateTask.Priority),
+				DueDuration:               templateTask.DueDuration,
+				Status:                    entity.TemplateTaskStatusEnum(templateTask.Status),
+				Description:               templateTask.Description,
+				Source:                    source,
+				OrganizationType:          templateTask.OrganizationType,
+				Kind:                      entity.TaskKindEnum(templateTask.Kind),
+				PassingScore:              templateTask.PassingScore,
+				TemplateTaskChecklists:    checklists,
+				TemplateTaskAttachments:   attachments,
+				TemplateTaskRules:         rules,
+				TemplateTaskApprovalSteps: approvalSteps,
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := templateTaskVersionService.CreateVersion(created, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "imported from bundle"); err != nil {
+				return err
+			}
+			item.NewID = &created.ID
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.Log.Error("[TemplateBundleUseCase.ImportTemplateBundle] " + err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
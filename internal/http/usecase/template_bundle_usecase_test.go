@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/google/uuid"
+)
+
+func newTemplateBundleArchive(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for name, content := range entries {
+		writer, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("creating archive entry: %v", err)
+		}
+		if _, err := writer.Write(content); err != nil {
+			t.Fatalf("writing archive entry: %v", err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("closing archive: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadTemplateBundle(t *testing.T) {
+	bundle := []byte(`{"format_version": 2}`)
+
+	tests := []struct {
+		name      string
+		content   []byte
+		wantFiles int
+		wantErr   bool
+	}{
+		{"json document", bundle, 0, false},
+		{"archive with a file", newTemplateBundleArchive(t, map[string][]byte{
+			templateBundleFileName:                   bundle,
+			templateBundleFilesDir + "storage/a.pdf": []byte("pdf"),
+		}), 1, false},
+		{"archive without bundle", newTemplateBundleArchive(t, map[string][]byte{
+			templateBundleFilesDir + "storage/a.pdf": []byte("pdf"),
+		}), 0, true},
+		// zeros compress to a few kilobytes but expand past the entry limit
+		{"archive entry over the limit", newTemplateBundleArchive(t, map[string][]byte{
+			templateBundleFileName:                     bundle,
+			templateBundleFilesDir + "storage/big.pdf": make([]byte, templateBundleMaxEntrySize+1),
+		}), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, files, err := readTemplateBundle(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readTemplateBundle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(files) != tt.wantFiles {
+				t.Errorf("readTemplateBundle() returned %d files, want %d", len(files), tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestTemplateBundleSurveyTemplateRoundTrip(t *testing.T) {
+	multipleChoiceID := uuid.New()
+	fileID := uuid.New()
+	textID := uuid.New()
+	matrixID := uuid.New()
+	numberID := uuid.New()
+	dateID := uuid.New()
+	answerTypeIDs := map[string]uuid.UUID{"multiple choice": multipleChoiceID, "file": fileID, "text": textID, "matrix": matrixID, "number": numberID, "date": dateID}
+	minValue, maxValue := 1.5, 40.0
+	minDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxDate := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	exitQuestionID := uuid.New()
+	certificateQuestionID := uuid.New()
+	badgeQuestionID := uuid.New()
+	surveyTemplate := &entity.SurveyTemplate{
+		ID:           uuid.New(),
+		Title:        "Safety quiz",
+		Status:       entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED,
+		IsQuiz:       true,
+		PassingScore: intPointer(80),
+		MaxAttempts:  intPointer(3),
+		Anonymity:    entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE,
+		Questions: []entity.Question{
+			{
+				ID:           exitQuestionID,
+				Number:       1,
+				Question:     "Where is the fire exit?",
+				AnswerTypeID: multipleChoiceID,
+				AnswerType:   &entity.AnswerType{ID: multipleChoiceID, Name: "Multiple Choice"},
+				Points:       5,
+				QuestionOptions: []entity.QuestionOption{
+					{OptionText: "Next to the lift", IsCorrect: true},
+					{OptionText: "On the roof"},
+				},
+				IsRequired:     true,
+				MinSelections:  intPointer(1),
+				MaxSelections:  intPointer(1),
+				ConditionMatch: entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+			},
+			{
+				ID:               certificateQuestionID,
+				Number:           2,
+				Question:         "Upload your safety certificate",
+				AnswerTypeID:     fileID,
+				AnswerType:       &entity.AnswerType{ID: fileID, Name: "File"},
+				Points:           1,
+				QuestionOptions:  []entity.QuestionOption{},
+				AllowedFileTypes: stringPointer("pdf,jpg"),
+				MaxFileSizeKB:    intPointer(2048),
+				ConditionMatch:   entity.QUESTION_CONDITION_MATCH_ENUM_ANY,
+				DisplayConditions: []entity.QuestionCondition{
+					{QuestionID: certificateQuestionID, SourceQuestionID: exitQuestionID, Operator: entity.QUESTION_CONDITION_OPERATOR_ENUM_EQUALS, Value: "Next to the lift"},
+					{QuestionID: certificateQuestionID, SourceQuestionID: badgeQuestionID, Operator: entity.QUESTION_CONDITION_OPERATOR_ENUM_ANSWERED},
+				},
+			},
+			{
+				ID:              badgeQuestionID,
+				Number:          3,
+				Question:        "Badge number",
+				AnswerTypeID:    textID,
+				AnswerType:      &entity.AnswerType{ID: textID, Name: "Text"},
+				Points:          1,
+				QuestionOptions: []entity.QuestionOption{},
+				MinLength:       intPointer(4),
+				MaxLength:       intPointer(8),
+				Pattern:         stringPointer("^[0-9]+$"),
+				ConditionMatch:  entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+			},
+			{
+				Number:       4,
+				Question:     "How safe do you feel?",
+				AnswerTypeID: matrixID,
+				AnswerType:   &entity.AnswerType{ID: matrixID, Name: "Matrix"},
+				Points:       1,
+				QuestionOptions: []entity.QuestionOption{
+					{OptionText: "Safe"},
+					{OptionText: "Unsafe"},
+				},
+				MatrixRows: []entity.QuestionMatrixRow{
+					{RowText: "At the desk", Number: 1},
+					{RowText: "In the warehouse", Number: 2},
+				},
+				ConditionMatch: entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+			},
+			{
+				Number:          5,
+				Question:        "Hours of safety training",
+				AnswerTypeID:    numberID,
+				AnswerType:      &entity.AnswerType{ID: numberID, Name: "Number"},
+				Points:          1,
+				QuestionOptions: []entity.QuestionOption{},
+				MinValue:        &minValue,
+				MaxValue:        &maxValue,
+				ConditionMatch:  entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+			},
+			{
+				Number:          6,
+				Question:        "Date of the last fire drill",
+				AnswerTypeID:    dateID,
+				AnswerType:      &entity.AnswerType{ID: dateID, Name: "Date"},
+				Points:          1,
+				QuestionOptions: []entity.QuestionOption{},
+				MinDate:         &minDate,
+				MaxDate:         &maxDate,
+				ConditionMatch:  entity.QUESTION_CONDITION_MATCH_ENUM_ALL,
+			},
+		},
+	}
+
+	content, err := json.Marshal(templateBundleSurveyTemplate(surveyTemplate))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var exported response.TemplateBundleSurveyTemplateResponse
+	if err := json.Unmarshal(content, &exported); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	imported := surveyTemplateFromBundle(exported, answerTypeIDs, func(filePath *string) *string { return filePath })
+
+	// the questions get their ids when they are stored, the conditions are read after that
+	for i := range imported.Questions {
+		imported.Questions[i].ID = surveyTemplate.Questions[i].ID
+	}
+	conditions := templateBundleQuestionConditions(exported, answerTypeIDs, imported.Questions)
+	if want := surveyTemplate.Questions[1].DisplayConditions; !reflect.DeepEqual(conditions, want) {
+		t.Errorf("templateBundleQuestionConditions() = %+v, want %+v", conditions, want)
+	}
+
+	// date bounds are read in the server's time zone, they only have to keep their day
+	dateQuestion := imported.Questions[5]
+	if dateQuestion.MinDate == nil || dateQuestion.MaxDate == nil ||
+		dateQuestion.MinDate.Format("2006-01-02") != "2024-01-01" || dateQuestion.MaxDate.Format("2006-01-02") != "2024-12-31" {
+		t.Errorf("surveyTemplateFromBundle() date bounds = %v, %v, want 2024-01-01, 2024-12-31", dateQuestion.MinDate, dateQuestion.MaxDate)
+	}
+	imported.Questions[5].MinDate = &minDate
+	imported.Questions[5].MaxDate = &maxDate
+
+	// the copy is a new survey template, only what it is made of has to come back
+	surveyTemplate.ID = uuid.Nil
+	for i := range surveyTemplate.Questions {
+		surveyTemplate.Questions[i].AnswerType = nil
+		surveyTemplate.Questions[i].DisplayConditions = nil
+		if surveyTemplate.Questions[i].MatrixRows == nil {
+			surveyTemplate.Questions[i].MatrixRows = []entity.QuestionMatrixRow{}
+		}
+	}
+	if !reflect.DeepEqual(imported, surveyTemplate) {
+		t.Errorf("surveyTemplateFromBundle() = %+v, want %+v", imported, surveyTemplate)
+	}
+}
+
+func TestTemplateBundleSurveyTemplateAnonymityRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		anonymity    entity.SurveyTemplateAnonymityEnum
+		minGroupSize *int
+	}{
+		{"named", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_NONE, nil},
+		{"anonymous", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_ANONYMOUS, nil},
+		{"confidential", entity.SURVEY_TEMPLATE_ANONYMITY_ENUM_CONFIDENTIAL, intPointer(5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surveyTemplate := &entity.SurveyTemplate{
+				ID:           uuid.New(),
+				Title:        "Team climate",
+				Status:       entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED,
+				Anonymity:    tt.anonymity,
+				MinGroupSize: tt.minGroupSize,
+			}
+
+			content, err := json.Marshal(templateBundleSurveyTemplate(surveyTemplate))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var exported response.TemplateBundleSurveyTemplateResponse
+			if err := json.Unmarshal(content, &exported); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			imported := surveyTemplateFromBundle(exported, nil, func(filePath *string) *string { return filePath })
+
+			if imported.Anonymity != tt.anonymity {
+				t.Errorf("surveyTemplateFromBundle() anonymity = %s, want %s", imported.Anonymity, tt.anonymity)
+			}
+			if !reflect.DeepEqual(imported.MinGroupSize, tt.minGroupSize) {
+				t.Errorf("surveyTemplateFromBundle() min group size = %v, want %v", imported.MinGroupSize, tt.minGroupSize)
+			}
+		})
+	}
+}
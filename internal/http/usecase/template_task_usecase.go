@@ -2,23 +2,38 @@ package usecase
 
 import (
 	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/service"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
 )
 
 type ITemplateTaskUseCase interface {
 	CreateTemplateTask(req *request.CreateTemplateTaskRequest) (*response.TemplateTaskResponse, error)
 	UpdateTemplateTask(req *request.UpdateTemplateTaskRequest) (*response.TemplateTaskResponse, error)
 	DeleteTemplateTask(id uuid.UUID) error
-	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum) (*[]response.TemplateTaskResponse, int64, error)
+	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum, source string) (*[]response.TemplateTaskResponse, int64, error)
 	FindByID(id uuid.UUID) (*response.TemplateTaskResponse, error)
+	ReplaceTemplateTaskRules(req *request.ReplaceTemplateTaskRulesRequest) (*response.TemplateTaskResponse, error)
+	ReplaceTemplateTaskApprovalSteps(req *request.ReplaceTemplateTaskApprovalStepsRequest) (*response.TemplateTaskResponse, error)
+	FindAllVersionsByTemplateTaskID(templateTaskID uuid.UUID) (*[]response.TemplateTaskVersionResponse, error)
+	FindVersionByID(id uuid.UUID) (*response.TemplateTaskVersionResponse, error)
+	DiffVersions(templateTaskID uuid.UUID, fromVersion, toVersion int) (*response.TemplateTaskVersionDiffResponse, error)
+	ImportTemplateTasks(req *request.ImportTemplateTasksRequest) (*response.ImportTemplateTaskResponse, error)
+	WriteTemplateTaskImportTemplate() ([]byte, error)
 }
 
 type TemplateTaskUseCase struct {
@@ -29,6 +44,12 @@ type TemplateTaskUseCase struct {
 	TemplateTaskChecklistRepository  repository.ITemplateTaskChecklistRepository
 	Viper                            *viper.Viper
 	SurveyTemplateRepository         repository.ISurveyTemplateRepository
+	TemplateTaskRuleService          service.ITemplateTaskRuleService
+	DB                               *gorm.DB
+	TemplateTaskVersionRepository    repository.ITemplateTaskVersionRepository
+	TemplateTaskVersionService       service.ITemplateTaskVersionService
+	TemplateTaskVersionDTO           dto.ITemplateTaskVersionDTO
+	EmployeeTaskUseCase              IEmployeeTaskUseCase
 }
 
 func NewTemplateTaskUseCase(
@@ -39,6 +60,12 @@ func NewTemplateTaskUseCase(
 	checklistRepo repository.ITemplateTaskChecklistRepository,
 	viper *viper.Viper,
 	surveyTemplateRepo repository.ISurveyTemplateRepository,
+	templateTaskRuleService service.ITemplateTaskRuleService,
+	db *gorm.DB,
+	ttvRepo repository.ITemplateTaskVersionRepository,
+	templateTaskVersionService service.ITemplateTaskVersionService,
+	ttvDTO dto.ITemplateTaskVersionDTO,
+	employeeTaskUseCase IEmployeeTaskUseCase,
 ) ITemplateTaskUseCase {
 	return &TemplateTaskUseCase{
 		Log:                              log,
@@ -48,16 +75,28 @@ func NewTemplateTaskUseCase(
 		TemplateTaskChecklistRepository:  checklistRepo,
 		Viper:                            viper,
 		SurveyTemplateRepository:         surveyTemplateRepo,
+		TemplateTaskRuleService:          templateTaskRuleService,
+		DB:                               db,
+		TemplateTaskVersionRepository:    ttvRepo,
+		TemplateTaskVersionService:       templateTaskVersionService,
+		TemplateTaskVersionDTO:           ttvDTO,
+		EmployeeTaskUseCase:              employeeTaskUseCase,
 	}
 }
 
 func TemplateTaskUseCaseFactory(log *logrus.Logger, viper *viper.Viper) ITemplateTaskUseCase {
+	ttvDTO := dto.TemplateTaskVersionDTOFactory(log, viper)
 	dto := dto.TemplateTaskDTOFactory(log, viper)
 	repo := repository.TemplateTaskRepositoryFactory(log)
 	attachmentRepo := repository.TemplateTaskAttachmentRepositoryFactory(log)
 	checklistRepo := repository.TemplateTaskChecklistRepositoryFactory(log)
 	surveyTemplateRepo := repository.SurveyTemplateRepositoryFactory(log)
-	return NewTemplateTaskUseCase(log, dto, repo, attachmentRepo, checklistRepo, viper, surveyTemplateRepo)
+	templateTaskRuleService := service.TemplateTaskRuleServiceFactory(log)
+	db := config.NewDatabase()
+	ttvRepo := repository.TemplateTaskVersionRepositoryFactory(log)
+	templateTaskVersionService := service.TemplateTaskVersionServiceFactory(log)
+	employeeTaskUseCase := EmployeeTaskUseCaseFactory(log, viper)
+	return NewTemplateTaskUseCase(log, dto, repo, attachmentRepo, checklistRepo, viper, surveyTemplateRepo, templateTaskRuleService, db, ttvRepo, templateTaskVersionService, ttvDTO, employeeTaskUseCase)
 }
 
 func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTaskRequest) (*response.TemplateTaskResponse, error) {
@@ -87,6 +126,15 @@ func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTas
 		surveyTemplateUUID = &parsedSurveyTemplateID
 	}
 
+	source := req.Source
+	if source == "" {
+		source = entity.TASK_SOURCE_ONBOARDING
+	}
+
+	if err := service.ValidateTaskKind(req.Kind, req.SurveyTemplateID, req.PassingScore); err != nil {
+		return nil, err
+	}
+
 	templateTask, err := uc.Repository.CreateTemplateTask(&entity.TemplateTask{
 		Name:             req.Name,
 		CoverPath:        &req.CoverPath,
@@ -94,9 +142,11 @@ func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTas
 		DueDuration:      duration,
 		Status:           entity.TemplateTaskStatusEnum(req.Status),
 		Description:      req.Description,
-		Source:           "ONBOARDING",
+		Source:           source,
 		OrganizationType: req.OrganizationType,
 		SurveyTemplateID: surveyTemplateUUID,
+		Kind:             entity.TaskKindEnum(req.Kind),
+		PassingScore:     req.PassingScore,
 	})
 	if err != nil {
 		uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
@@ -122,7 +172,12 @@ func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTas
 		return nil, err
 	}
 	// create template task checklists
-	for _, checklist := range req.TemplateTaskChecklists {
+	for i, checklist := range req.TemplateTaskChecklists {
+		checklistEnt, err := templateTaskChecklistFromRequest(templateTask.ID, checklist, i)
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
+			return nil, err
+		}
 		if checklist.ID != nil {
 			parsedChecklistID, err := uuid.Parse(*checklist.ID)
 			if err != nil {
@@ -137,30 +192,21 @@ func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTas
 				return nil, err
 			}
 			if exist == nil {
-				_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-					TemplateTaskID: templateTask.ID,
-					Name:           checklist.Name,
-				})
+				_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(checklistEnt)
 				if err != nil {
 					uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 					return nil, err
 				}
 			} else {
-				_, err := uc.TemplateTaskChecklistRepository.UpdateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-					ID:             parsedChecklistID,
-					TemplateTaskID: templateTask.ID,
-					Name:           checklist.Name,
-				})
+				checklistEnt.ID = parsedChecklistID
+				_, err := uc.TemplateTaskChecklistRepository.UpdateTaskChecklistRepository(checklistEnt)
 				if err != nil {
 					uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 					return nil, err
 				}
 			}
 		} else {
-			_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-				TemplateTaskID: templateTask.ID,
-				Name:           checklist.Name,
-			})
+			_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(checklistEnt)
 			if err != nil {
 				uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 				return nil, err
@@ -176,7 +222,16 @@ func (uc *TemplateTaskUseCase) CreateTemplateTask(req *request.CreateTemplateTas
 	if findById == nil {
 		return nil, errors.New("Template task not found")
 	}
-	return uc.DTO.ConvertEntityToResponse(findById), nil
+
+	templateTaskVersion, err := uc.TemplateTaskVersionService.CreateVersion(findById, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "")
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
+		return nil, err
+	}
+
+	res := uc.DTO.ConvertEntityToResponse(findById)
+	res.TemplateTaskVersion = uc.TemplateTaskVersionDTO.ConvertEntityToResponse(templateTaskVersion)
+	return res, nil
 }
 
 func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTaskRequest) (*response.TemplateTaskResponse, error) {
@@ -193,11 +248,25 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 	if ttExist == nil {
 		return nil, errors.New("Template task not found")
 	}
+	// templates that predate versioning keep their original content as the first version
+	if _, err := uc.TemplateTaskVersionService.EnsureCurrentVersion(ttExist); err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.UpdateTemplateTask] " + err.Error())
+		return nil, err
+	}
 	var duration *int
 	if req.DueDuration != nil {
 		duration = req.DueDuration
 	}
 
+	// the source decides which flow uses the template, it is kept unless changed explicitly
+	source := req.Source
+	if source == "" {
+		source = ttExist.Source
+	}
+	if source == "" {
+		source = entity.TASK_SOURCE_ONBOARDING
+	}
+
 	var surveyTemplateUUID *uuid.UUID
 	if req.SurveyTemplateID != nil && *req.SurveyTemplateID != "" {
 		parsedSurveyTemplateID, err := uuid.Parse(*req.SurveyTemplateID)
@@ -219,6 +288,19 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 		surveyTemplateUUID = &parsedSurveyTemplateID
 	}
 
+	// the kind is kept unless changed explicitly, like the source
+	kind := req.Kind
+	if kind == "" {
+		kind = string(ttExist.Kind)
+	}
+	passingScore := req.PassingScore
+	if passingScore == nil {
+		passingScore = ttExist.PassingScore
+	}
+	if err := service.ValidateTaskKind(kind, req.SurveyTemplateID, passingScore); err != nil {
+		return nil, err
+	}
+
 	templateTask, err := uc.Repository.UpdateTemplateTask(&entity.TemplateTask{
 		ID:               parsedId,
 		Name:             req.Name,
@@ -228,8 +310,10 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 		DueDuration:      duration,
 		Status:           entity.TemplateTaskStatusEnum(req.Status),
 		Description:      req.Description,
-		Source:           "ONBOARDING",
+		Source:           source,
 		OrganizationType: req.OrganizationType,
+		Kind:             entity.TaskKindEnum(kind),
+		PassingScore:     passingScore,
 	})
 	if err != nil {
 		uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
@@ -255,7 +339,12 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 		return nil, err
 	}
 	// create template task checklists
-	for _, checklist := range req.TemplateTaskChecklists {
+	for i, checklist := range req.TemplateTaskChecklists {
+		checklistEnt, err := templateTaskChecklistFromRequest(templateTask.ID, checklist, i)
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.UpdateTemplateTask] " + err.Error())
+			return nil, err
+		}
 		if checklist.ID != nil {
 			parsedChecklistID, err := uuid.Parse(*checklist.ID)
 			if err != nil {
@@ -270,30 +359,21 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 				return nil, err
 			}
 			if exist == nil {
-				_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-					TemplateTaskID: templateTask.ID,
-					Name:           checklist.Name,
-				})
+				_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(checklistEnt)
 				if err != nil {
 					uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 					return nil, err
 				}
 			} else {
-				_, err := uc.TemplateTaskChecklistRepository.UpdateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-					ID:             parsedChecklistID,
-					TemplateTaskID: templateTask.ID,
-					Name:           checklist.Name,
-				})
+				checklistEnt.ID = parsedChecklistID
+				_, err := uc.TemplateTaskChecklistRepository.UpdateTaskChecklistRepository(checklistEnt)
 				if err != nil {
 					uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 					return nil, err
 				}
 			}
 		} else {
-			_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(&entity.TemplateTaskChecklist{
-				TemplateTaskID: templateTask.ID,
-				Name:           checklist.Name,
-			})
+			_, err := uc.TemplateTaskChecklistRepository.CreateTaskChecklistRepository(checklistEnt)
 			if err != nil {
 				uc.Log.Error("[TemplateTaskUseCase.CreateTemplateTask] " + err.Error())
 				return nil, err
@@ -309,7 +389,31 @@ func (uc *TemplateTaskUseCase) UpdateTemplateTask(req *request.UpdateTemplateTas
 	if findById == nil {
 		return nil, errors.New("Template task not found")
 	}
-	return uc.DTO.ConvertEntityToResponse(findById), nil
+
+	propagation := entity.TemplateTaskVersionPropagationEnum(req.Propagation)
+	if propagation == "" {
+		propagation = entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY
+	}
+	templateTaskVersion, err := uc.TemplateTaskVersionService.CreateVersion(findById, propagation, req.ChangeNote)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.UpdateTemplateTask] " + err.Error())
+		return nil, err
+	}
+
+	res := uc.DTO.ConvertEntityToResponse(findById)
+	res.TemplateTaskVersion = uc.TemplateTaskVersionDTO.ConvertEntityToResponse(templateTaskVersion)
+
+	// open tasks only follow the template when the editor asked for it, new hires always get the latest version
+	if propagation == entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_UPDATE_OPEN_TASKS {
+		onboardingBackfill, err := uc.EmployeeTaskUseCase.PropagateTemplateTaskVersion(findById.ID, templateTaskVersion.ID)
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.UpdateTemplateTask] " + err.Error())
+			return nil, err
+		}
+		res.OnboardingBackfill = onboardingBackfill
+	}
+
+	return res, nil
 }
 
 func (uc *TemplateTaskUseCase) DeleteTemplateTask(id uuid.UUID) error {
@@ -332,8 +436,8 @@ func (uc *TemplateTaskUseCase) DeleteTemplateTask(id uuid.UUID) error {
 	return nil
 }
 
-func (uc *TemplateTaskUseCase) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum) (*[]response.TemplateTaskResponse, int64, error) {
-	entities, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, sort, status)
+func (uc *TemplateTaskUseCase) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum, source string) (*[]response.TemplateTaskResponse, int64, error) {
+	entities, total, err := uc.Repository.FindAllPaginated(page, pageSize, search, sort, status, source)
 	if err != nil {
 		uc.Log.Error("[TemplateTaskUseCase.FindAllPaginated] " + err.Error())
 		return nil, 0, err
@@ -357,3 +461,727 @@ func (uc *TemplateTaskUseCase) FindByID(id uuid.UUID) (*response.TemplateTaskRes
 
 	return uc.DTO.ConvertEntityToResponse(templateTask), nil
 }
+
+// ReplaceTemplateTaskRules swaps the targeting rules of a template task for the given set.
+// An empty set removes all rules so the task applies to every hire of its organization type.
+func (uc *TemplateTaskUseCase) ReplaceTemplateTaskRules(req *request.ReplaceTemplateTaskRulesRequest) (*response.TemplateTaskResponse, error) {
+	parsedID, err := uuid.Parse(req.TemplateTaskID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+
+	templateTask, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, errors.New("Template task not found")
+	}
+
+	if err := uc.TemplateTaskRuleService.ValidateRules(req.Rules); err != nil {
+		return nil, err
+	}
+	if _, err := uc.TemplateTaskVersionService.EnsureCurrentVersion(templateTask); err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+
+	err = uc.DB.Transaction(func(tx *gorm.DB) error {
+		ruleRepository := repository.NewTemplateTaskRuleRepository(uc.Log, tx)
+		if err := ruleRepository.DeleteByTemplateTaskID(templateTask.ID); err != nil {
+			return err
+		}
+
+		for _, rule := range req.Rules {
+			_, err := ruleRepository.CreateTemplateTaskRule(&entity.TemplateTaskRule{
+				TemplateTaskID: templateTask.ID,
+				GroupNumber:    rule.GroupNumber,
+				Criterion:      entity.TemplateTaskRuleCriterionEnum(rule.Criterion),
+				Operator:       entity.TemplateTaskRuleOperatorEnum(rule.Operator),
+				Value:          rule.Value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+
+	findById, err := uc.Repository.FindByID(templateTask.ID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+	if findById == nil {
+		return nil, errors.New("Template task not found")
+	}
+
+	// targeting only decides who receives the task, so existing tasks never need to follow it
+	templateTaskVersion, err := uc.TemplateTaskVersionService.CreateVersion(findById, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "")
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskRules] " + err.Error())
+		return nil, err
+	}
+
+	res := uc.DTO.ConvertEntityToResponse(findById)
+	res.TemplateTaskVersion = uc.TemplateTaskVersionDTO.ConvertEntityToResponse(templateTaskVersion)
+	return res, nil
+}
+
+// ReplaceTemplateTaskApprovalSteps swaps the approval chain of a template task. Employee tasks
+// keep the chain they were created with, only tasks created afterwards use the new one.
+func (uc *TemplateTaskUseCase) ReplaceTemplateTaskApprovalSteps(req *request.ReplaceTemplateTaskApprovalStepsRequest) (*response.TemplateTaskResponse, error) {
+	parsedID, err := uuid.Parse(req.TemplateTaskID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+
+	templateTask, err := uc.Repository.FindByID(parsedID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, errors.New("Template task not found")
+	}
+
+	if err := service.ValidateApprovalSteps(req.Steps); err != nil {
+		return nil, err
+	}
+	if _, err := uc.TemplateTaskVersionService.EnsureCurrentVersion(templateTask); err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+
+	err = uc.DB.Transaction(func(tx *gorm.DB) error {
+		stepRepository := repository.NewTemplateTaskApprovalStepRepository(uc.Log, tx)
+		if err := stepRepository.DeleteByTemplateTaskID(templateTask.ID); err != nil {
+			return err
+		}
+
+		for i, step := range req.Steps {
+			var approverEmployeeID *uuid.UUID
+			if step.ApproverEmployeeID != "" {
+				parsedApproverEmployeeID, err := uuid.Parse(step.ApproverEmployeeID)
+				if err != nil {
+					return err
+				}
+				approverEmployeeID = &parsedApproverEmployeeID
+			}
+
+			_, err := stepRepository.CreateTemplateTaskApprovalStep(&entity.TemplateTaskApprovalStep{
+				TemplateTaskID:     templateTask.ID,
+				StepOrder:          i + 1,
+				ApproverType:       entity.ApprovalApproverTypeEnum(step.ApproverType),
+				ApproverEmployeeID: approverEmployeeID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+
+	findById, err := uc.Repository.FindByID(templateTask.ID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+	if findById == nil {
+		return nil, errors.New("Template task not found")
+	}
+
+	templateTaskVersion, err := uc.TemplateTaskVersionService.CreateVersion(findById, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "")
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ReplaceTemplateTaskApprovalSteps] " + err.Error())
+		return nil, err
+	}
+
+	res := uc.DTO.ConvertEntityToResponse(findById)
+	res.TemplateTaskVersion = uc.TemplateTaskVersionDTO.ConvertEntityToResponse(templateTaskVersion)
+	return res, nil
+}
+
+func (uc *TemplateTaskUseCase) FindAllVersionsByTemplateTaskID(templateTaskID uuid.UUID) (*[]response.TemplateTaskVersionResponse, error) {
+	templateTask, err := uc.Repository.FindByID(templateTaskID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.FindAllVersionsByTemplateTaskID] " + err.Error())
+		return nil, err
+	}
+	if templateTask == nil {
+		return nil, errors.New("Template task not found")
+	}
+
+	versions, err := uc.TemplateTaskVersionRepository.FindAllByTemplateTaskID(templateTaskID)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.FindAllVersionsByTemplateTaskID] " + err.Error())
+		return nil, err
+	}
+
+	responses := make([]response.TemplateTaskVersionResponse, 0, len(*versions))
+	for _, version := range *versions {
+		responses = append(responses, *uc.TemplateTaskVersionDTO.ConvertEntityToResponse(&version))
+	}
+
+	return &responses, nil
+}
+
+func (uc *TemplateTaskUseCase) FindVersionByID(id uuid.UUID) (*response.TemplateTaskVersionResponse, error) {
+	version, err := uc.TemplateTaskVersionRepository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.FindVersionByID] " + err.Error())
+		return nil, err
+	}
+	if version == nil {
+		return nil, errors.New("Template task version not found")
+	}
+
+	return uc.TemplateTaskVersionDTO.ConvertEntityToResponse(version), nil
+}
+
+// DiffVersions compares two versions of a template task by version number. A zero toVersion
+// means the latest version and a zero fromVersion the one before toVersion.
+func (uc *TemplateTaskUseCase) DiffVersions(templateTaskID uuid.UUID, fromVersion, toVersion int) (*response.TemplateTaskVersionDiffResponse, error) {
+	var to *entity.TemplateTaskVersion
+	var err error
+	if toVersion > 0 {
+		to, err = uc.TemplateTaskVersionRepository.FindByTemplateTaskIDAndVersionNumber(templateTaskID, toVersion)
+	} else {
+		to, err = uc.TemplateTaskVersionRepository.FindLatestByTemplateTaskID(templateTaskID)
+	}
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.DiffVersions] " + err.Error())
+		return nil, err
+	}
+	if to == nil {
+		return nil, errors.New("Template task version not found")
+	}
+
+	if fromVersion <= 0 {
+		fromVersion = to.VersionNumber - 1
+	}
+	if fromVersion <= 0 {
+		return nil, errors.New("Template task has no earlier version to compare with")
+	}
+	from, err := uc.TemplateTaskVersionRepository.FindByTemplateTaskIDAndVersionNumber(templateTaskID, fromVersion)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.DiffVersions] " + err.Error())
+		return nil, err
+	}
+	if from == nil {
+		return nil, errors.New("Template task version not found")
+	}
+
+	res, err := uc.TemplateTaskVersionService.DiffVersions(from, to)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.DiffVersions] " + err.Error())
+		return nil, err
+	}
+
+	return res, nil
+}
+
+const (
+	templateTaskImportTasksSheet      = "Tasks"
+	templateTaskImportChecklistsSheet = "Checklists"
+	templateTaskImportReportDir       = "storage/template_tasks/import_reports/"
+)
+
+var (
+	templateTaskImportTaskHeaders      = []string{"Key", "Name", "Description", "Priority", "Due Duration", "Status", "Organization Type", "Survey Template", "Cover Path", "Source", "Kind", "Passing Score"}
+	templateTaskImportChecklistHeaders = []string{"Task Key", "Checklist Name"}
+)
+
+type templateTaskImportRow struct {
+	row      int
+	key      string
+	task     entity.TemplateTask
+	errors   []string
+	children []*templateTaskImportChecklistRow
+}
+
+type templateTaskImportChecklistRow struct {
+	row    int
+	name   string
+	errors []string
+}
+
+// templateTaskImportColumns maps the header row of a sheet to column indexes, so columns
+// may come in any order. It returns the headers that are missing.
+func templateTaskImportColumns(header []string, expected []string) (map[string]int, []string) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	missing := make([]string, 0)
+	for _, name := range expected {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return columns, missing
+}
+
+func templateTaskImportCell(row []string, columns map[string]int, name string) string {
+	i, ok := columns[strings.ToLower(name)]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func isTemplateTaskImportRowEmpty(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTemplateTaskImportTemplate returns an empty workbook with the sheets and headers
+// ImportTemplateTasks expects.
+func (uc *TemplateTaskUseCase) WriteTemplateTaskImportTemplate() ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", templateTaskImportTasksSheet)
+	if _, err := f.NewSheet(templateTaskImportChecklistsSheet); err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.WriteTemplateTaskImportTemplate] " + err.Error())
+		return nil, err
+	}
+
+	sheets := map[string][]string{
+		templateTaskImportTasksSheet:      templateTaskImportTaskHeaders,
+		templateTaskImportChecklistsSheet: templateTaskImportChecklistHeaders,
+	}
+	for sheet, headers := range sheets {
+		if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.WriteTemplateTaskImportTemplate] " + err.Error())
+			return nil, err
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.WriteTemplateTaskImportTemplate] " + err.Error())
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ImportTemplateTasks creates template tasks and their checklists from an XLSX workbook with a
+// Tasks sheet and a Checklists sheet, linked by the task key. Nothing is created when any row
+// is invalid; the errors are returned together with a copy of the workbook that marks them.
+func (uc *TemplateTaskUseCase) ImportTemplateTasks(req *request.ImportTemplateTasksRequest) (*response.ImportTemplateTaskResponse, error) {
+	file, err := req.File.Open()
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+		return nil, err
+	}
+	defer file.Close()
+
+	res := &response.ImportTemplateTaskResponse{
+		Errors:        make([]response.ImportTemplateTaskRowErrorResponse, 0),
+		TemplateTasks: make([]response.TemplateTaskResponse, 0),
+	}
+	// problems with the workbook itself are reported on row 0, there is no report to download
+	invalidWorkbook := func(sheet, message string) (*response.ImportTemplateTaskResponse, error) {
+		res.Errors = append(res.Errors, response.ImportTemplateTaskRowErrorResponse{Sheet: sheet, Row: 0, Messages: []string{message}})
+		return res, nil
+	}
+
+	f, err := excelize.OpenReader(file)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+		return invalidWorkbook("", "file is not a valid XLSX workbook")
+	}
+	defer f.Close()
+
+	if index, _ := f.GetSheetIndex(templateTaskImportTasksSheet); index == -1 {
+		return invalidWorkbook(templateTaskImportTasksSheet, "workbook has no "+templateTaskImportTasksSheet+" sheet")
+	}
+	taskRows, err := f.GetRows(templateTaskImportTasksSheet)
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+		return nil, err
+	}
+	if len(taskRows) == 0 {
+		return invalidWorkbook(templateTaskImportTasksSheet, "sheet is empty")
+	}
+	taskColumns, missing := templateTaskImportColumns(taskRows[0], templateTaskImportTaskHeaders[:7])
+	if len(missing) > 0 {
+		return invalidWorkbook(templateTaskImportTasksSheet, "sheet is missing columns: "+strings.Join(missing, ", "))
+	}
+
+	var checklistRows [][]string
+	checklistColumns := map[string]int{}
+	if index, _ := f.GetSheetIndex(templateTaskImportChecklistsSheet); index != -1 {
+		checklistRows, err = f.GetRows(templateTaskImportChecklistsSheet)
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+			return nil, err
+		}
+		if len(checklistRows) > 0 {
+			checklistColumns, missing = templateTaskImportColumns(checklistRows[0], templateTaskImportChecklistHeaders)
+			if len(missing) > 0 {
+				return invalidWorkbook(templateTaskImportChecklistsSheet, "sheet is missing columns: "+strings.Join(missing, ", "))
+			}
+		}
+	}
+
+	// tasks
+	tasks := make([]*templateTaskImportRow, 0)
+	tasksByKey := make(map[string]*templateTaskImportRow)
+	importedNames := make(map[string]int)
+	surveyTemplates := make(map[string]*entity.SurveyTemplate)
+	for i := 1; i < len(taskRows); i++ {
+		row := taskRows[i]
+		if isTemplateTaskImportRowEmpty(row) {
+			continue
+		}
+
+		task := &templateTaskImportRow{
+			row: i + 1,
+			key: templateTaskImportCell(row, taskColumns, "Key"),
+			task: entity.TemplateTask{
+				Name:             templateTaskImportCell(row, taskColumns, "Name"),
+				Description:      templateTaskImportCell(row, taskColumns, "Description"),
+				Priority:         entity.TemplateTaskPriorityEnum(strings.ToUpper(templateTaskImportCell(row, taskColumns, "Priority"))),
+				Status:           entity.TemplateTaskStatusEnum(strings.ToUpper(templateTaskImportCell(row, taskColumns, "Status"))),
+				OrganizationType: templateTaskImportCell(row, taskColumns, "Organization Type"),
+				Source:           strings.ToUpper(templateTaskImportCell(row, taskColumns, "Source")),
+				Kind:             entity.TaskKindEnum(strings.ToUpper(templateTaskImportCell(row, taskColumns, "Kind"))),
+			},
+			errors: make([]string, 0),
+		}
+		tasks = append(tasks, task)
+
+		if task.key == "" {
+			task.errors = append(task.errors, "key is required")
+		} else if _, ok := tasksByKey[task.key]; ok {
+			task.errors = append(task.errors, "key "+task.key+" is used by an earlier row")
+		} else {
+			tasksByKey[task.key] = task
+		}
+
+		if task.task.Name == "" {
+			task.errors = append(task.errors, "name is required")
+		}
+		if task.task.OrganizationType == "" {
+			task.errors = append(task.errors, "organization type is required")
+		}
+		switch task.task.Priority {
+		case entity.TEMPLATE_TASK_PRIORITY_ENUM_LOW, entity.TEMPLATE_TASK_PRIORITY_ENUM_MEDIUM, entity.TEMPLATE_TASK_PRIORITY_ENUM_HIGH:
+		default:
+			task.errors = append(task.errors, "priority must be LOW, MEDIUM or HIGH")
+		}
+		switch task.task.Status {
+		case entity.TEMPLATE_TASK_STATUS_ENUM_ACTIVE, entity.TEMPLATE_TASK_STATUS_ENUM_INACTIVE:
+		default:
+			task.errors = append(task.errors, "status must be ACTIVE or INACTIVE")
+		}
+		switch task.task.Source {
+		case "":
+			task.task.Source = entity.TASK_SOURCE_ONBOARDING
+		case entity.TASK_SOURCE_ONBOARDING, entity.TASK_SOURCE_OFFBOARDING:
+		default:
+			task.errors = append(task.errors, "source must be ONBOARDING or OFFBOARDING")
+		}
+
+		if dueDuration := templateTaskImportCell(row, taskColumns, "Due Duration"); dueDuration != "" {
+			parsedDueDuration, err := strconv.Atoi(dueDuration)
+			if err != nil || parsedDueDuration < 0 {
+				task.errors = append(task.errors, "due duration must be a whole number of days")
+			} else {
+				task.task.DueDuration = &parsedDueDuration
+			}
+		}
+
+		if coverPath := templateTaskImportCell(row, taskColumns, "Cover Path"); coverPath != "" {
+			task.task.CoverPath = &coverPath
+		}
+
+		if surveyTemplateName := templateTaskImportCell(row, taskColumns, "Survey Template"); surveyTemplateName != "" {
+			surveyTemplate, ok := surveyTemplates[surveyTemplateName]
+			if !ok {
+				surveyTemplate, err = uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+					"survey_number": surveyTemplateName,
+				})
+				if err == nil && surveyTemplate == nil {
+					surveyTemplate, err = uc.SurveyTemplateRepository.FindByKeys(map[string]interface{}{
+						"title": surveyTemplateName,
+					})
+				}
+				if err != nil {
+					uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+					return nil, err
+				}
+				surveyTemplates[surveyTemplateName] = surveyTemplate
+			}
+			if surveyTemplate == nil {
+				task.errors = append(task.errors, "survey template "+surveyTemplateName+" not found by survey number or title")
+			} else {
+				task.task.SurveyTemplateID = &surveyTemplate.ID
+			}
+		}
+
+		if passingScore := templateTaskImportCell(row, taskColumns, "Passing Score"); passingScore != "" {
+			parsedPassingScore, err := strconv.Atoi(passingScore)
+			if err != nil {
+				task.errors = append(task.errors, "passing score must be a whole number")
+			} else {
+				task.task.PassingScore = &parsedPassingScore
+			}
+		}
+		switch task.task.Kind {
+		case "", entity.TASK_KIND_ENUM_GENERIC, entity.TASK_KIND_ENUM_SURVEY, entity.TASK_KIND_ENUM_DOCUMENT_UPLOAD,
+			entity.TASK_KIND_ENUM_ACKNOWLEDGEMENT, entity.TASK_KIND_ENUM_QUIZ, entity.TASK_KIND_ENUM_EVENT_ATTENDANCE:
+			// a survey template that is not found is already reported above, the cell is enough here
+			surveyTemplateName := templateTaskImportCell(row, taskColumns, "Survey Template")
+			if err := service.ValidateTaskKind(string(task.task.Kind), &surveyTemplateName, task.task.PassingScore); err != nil {
+				task.errors = append(task.errors, err.Error())
+			}
+		default:
+			task.errors = append(task.errors, "kind must be GENERIC, SURVEY, DOCUMENT_UPLOAD, ACKNOWLEDGEMENT, QUIZ or EVENT_ATTENDANCE")
+		}
+
+		if task.task.Name != "" && task.task.OrganizationType != "" {
+			nameKey := strings.ToLower(task.task.OrganizationType + "|" + task.task.Name)
+			if earlierRow, ok := importedNames[nameKey]; ok {
+				task.errors = append(task.errors, "same name and organization type as row "+strconv.Itoa(earlierRow))
+			} else {
+				importedNames[nameKey] = task.row
+				existing, err := uc.Repository.FindAllByKeys(map[string]interface{}{
+					"name":              task.task.Name,
+					"organization_type": task.task.OrganizationType,
+				})
+				if err != nil {
+					uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+					return nil, err
+				}
+				if len(*existing) > 0 {
+					task.errors = append(task.errors, "a template task with this name already exists for the organization type")
+				}
+			}
+		}
+	}
+	if len(tasks) == 0 {
+		return invalidWorkbook(templateTaskImportTasksSheet, "sheet has no tasks")
+	}
+
+	// checklists
+	checklists := make([]*templateTaskImportChecklistRow, 0)
+	checklistNames := make(map[string]bool)
+	for i := 1; i < len(checklistRows); i++ {
+		row := checklistRows[i]
+		if isTemplateTaskImportRowEmpty(row) {
+			continue
+		}
+
+		taskKey := templateTaskImportCell(row, checklistColumns, "Task Key")
+		checklist := &templateTaskImportChecklistRow{
+			row:    i + 1,
+			name:   templateTaskImportCell(row, checklistColumns, "Checklist Name"),
+			errors: make([]string, 0),
+		}
+		checklists = append(checklists, checklist)
+
+		if checklist.name == "" {
+			checklist.errors = append(checklist.errors, "checklist name is required")
+		}
+		task, ok := tasksByKey[taskKey]
+		if !ok {
+			checklist.errors = append(checklist.errors, "task key "+taskKey+" is not in the "+templateTaskImportTasksSheet+" sheet")
+			continue
+		}
+		if checklistNames[taskKey+"|"+checklist.name] {
+			checklist.errors = append(checklist.errors, "checklist is listed twice for task "+taskKey)
+			continue
+		}
+		checklistNames[taskKey+"|"+checklist.name] = true
+		task.children = append(task.children, checklist)
+	}
+
+	res.TotalTasks = len(tasks)
+	res.TotalChecklists = len(checklists)
+	taskErrors := make(map[int][]string)
+	for _, task := range tasks {
+		if len(task.errors) > 0 {
+			taskErrors[task.row] = task.errors
+			res.Errors = append(res.Errors, response.ImportTemplateTaskRowErrorResponse{Sheet: templateTaskImportTasksSheet, Row: task.row, Messages: task.errors})
+		}
+	}
+	checklistErrors := make(map[int][]string)
+	for _, checklist := range checklists {
+		if len(checklist.errors) > 0 {
+			checklistErrors[checklist.row] = checklist.errors
+			res.Errors = append(res.Errors, response.ImportTemplateTaskRowErrorResponse{Sheet: templateTaskImportChecklistsSheet, Row: checklist.row, Messages: checklist.errors})
+		}
+	}
+
+	if len(res.Errors) > 0 {
+		reportPath, err := uc.writeTemplateTaskImportReport(f, map[string]map[int][]string{
+			templateTaskImportTasksSheet:      taskErrors,
+			templateTaskImportChecklistsSheet: checklistErrors,
+		})
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+			return nil, err
+		}
+		res.ErrorReportURL = uc.Viper.GetString("app.url") + reportPath
+		return res, nil
+	}
+
+	createdIDs := make([]uuid.UUID, 0, len(tasks))
+	err = uc.DB.Transaction(func(tx *gorm.DB) error {
+		templateTaskRepository := repository.NewTemplateTaskRepository(uc.Log, tx)
+		templateTaskVersionService := service.NewTemplateTaskVersionService(uc.Log, repository.NewTemplateTaskVersionRepository(uc.Log, tx))
+
+		for _, task := range tasks {
+			templateTask := task.task
+			for i, checklist := range task.children {
+				templateTask.TemplateTaskChecklists = append(templateTask.TemplateTaskChecklists, entity.TemplateTaskChecklist{
+					Name:      checklist.name,
+					SortOrder: i,
+				})
+			}
+
+			created, err := templateTaskRepository.CreateTemplateTask(&templateTask)
+			if err != nil {
+				return errors.New("row " + strconv.Itoa(task.row) + ": " + err.Error())
+			}
+			if _, err := templateTaskVersionService.CreateVersion(created, entity.TEMPLATE_TASK_VERSION_PROPAGATION_ENUM_NEW_HIRES_ONLY, "imported from spreadsheet"); err != nil {
+				return err
+			}
+			createdIDs = append(createdIDs, created.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+		return nil, err
+	}
+
+	for _, id := range createdIDs {
+		templateTask, err := uc.Repository.FindByID(id)
+		if err != nil {
+			uc.Log.Error("[TemplateTaskUseCase.ImportTemplateTasks] " + err.Error())
+			return nil, err
+		}
+		if templateTask != nil {
+			res.TemplateTasks = append(res.TemplateTasks, *uc.DTO.ConvertEntityToResponse(templateTask))
+		}
+	}
+	res.Created = len(createdIDs)
+
+	return res, nil
+}
+
+// writeTemplateTaskImportReport stores a copy of the uploaded workbook with an Errors column
+// and the invalid rows highlighted, and returns its path.
+func (uc *TemplateTaskUseCase) writeTemplateTaskImportReport(f *excelize.File, rowErrors map[string]map[int][]string) (string, error) {
+	errorStyle, err := f.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"#F4CCCC"},
+			Pattern: 1,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold: true,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for sheet, errorsByRow := range rowErrors {
+		if index, _ := f.GetSheetIndex(sheet); index == -1 {
+			continue
+		}
+		cols, err := f.GetCols(sheet)
+		if err != nil {
+			return "", err
+		}
+		errorColumn := len(cols) + 1
+
+		headerCell, err := excelize.CoordinatesToCellName(errorColumn, 1)
+		if err != nil {
+			return "", err
+		}
+		f.SetCellValue(sheet, headerCell, "Errors")
+		f.SetCellStyle(sheet, headerCell, headerCell, headerStyle)
+
+		for row, messages := range errorsByRow {
+			firstCell, err := excelize.CoordinatesToCellName(1, row)
+			if err != nil {
+				return "", err
+			}
+			errorCell, err := excelize.CoordinatesToCellName(errorColumn, row)
+			if err != nil {
+				return "", err
+			}
+			f.SetCellValue(sheet, errorCell, strings.Join(messages, "; "))
+			f.SetCellStyle(sheet, firstCell, errorCell, errorStyle)
+		}
+	}
+
+	if err := os.MkdirAll(templateTaskImportReportDir, 0755); err != nil {
+		return "", err
+	}
+	reportPath := templateTaskImportReportDir + strconv.FormatInt(time.Now().UnixNano(), 10) + "_template_task_import_errors.xlsx"
+	if err := f.SaveAs(reportPath); err != nil {
+		return "", err
+	}
+
+	return reportPath, nil
+}
+
+// templateTaskChecklistFromRequest builds a template checklist item, falling back to the item's
+// position in the request when no sort order is given.
+func templateTaskChecklistFromRequest(templateTaskID uuid.UUID, req request.TemplateTaskChecklistRequest, index int) (*entity.TemplateTaskChecklist, error) {
+	checklist := &entity.TemplateTaskChecklist{
+		TemplateTaskID: templateTaskID,
+		Name:           req.Name,
+		SortOrder:      index,
+		DueOffsetDays:  req.DueOffsetDays,
+		IsRequired:     "NO",
+	}
+	if req.SortOrder != nil {
+		checklist.SortOrder = *req.SortOrder
+	}
+	if req.AssigneeID != nil && *req.AssigneeID != "" {
+		assigneeID, err := uuid.Parse(*req.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		checklist.AssigneeID = &assigneeID
+	}
+	if req.IsRequired != nil && *req.IsRequired != "" {
+		checklist.IsRequired = *req.IsRequired
+	}
+
+	return checklist, nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/messaging"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// ErrTaskActorForbidden is returned when the logged in employee may not reassign the task or
+// manage the delegation.
+var ErrTaskActorForbidden = errors.New("you are not allowed to do this")
+
+type IVerifierDelegationUseCase interface {
+	CreateVerifierDelegation(req *request.CreateVerifierDelegationRequest) (*response.VerifierDelegationResponse, error)
+	RevokeVerifierDelegation(req *request.RevokeVerifierDelegationRequest) (*response.VerifierDelegationResponse, error)
+	FindByID(id uuid.UUID) (*response.VerifierDelegationResponse, error)
+	FindAllPaginated(page, pageSize int, employeeID *uuid.UUID, actor request.TaskActor, sort map[string]interface{}) (*[]response.VerifierDelegationResponse, int64, error)
+}
+
+type VerifierDelegationUseCase struct {
+	Log             *logrus.Logger
+	DTO             dto.IVerifierDelegationDTO
+	Repository      repository.IVerifierDelegationRepository
+	Viper           *viper.Viper
+	EmployeeMessage messaging.IEmployeeMessage
+}
+
+func NewVerifierDelegationUseCase(
+	log *logrus.Logger,
+	dto dto.IVerifierDelegationDTO,
+	repository repository.IVerifierDelegationRepository,
+	viper *viper.Viper,
+	employeeMessage messaging.IEmployeeMessage,
+) IVerifierDelegationUseCase {
+	return &VerifierDelegationUseCase{
+		Log:             log,
+		DTO:             dto,
+		Repository:      repository,
+		Viper:           viper,
+		EmployeeMessage: employeeMessage,
+	}
+}
+
+func VerifierDelegationUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IVerifierDelegationUseCase {
+	verifierDelegationDTO := dto.VerifierDelegationDTOFactory(log, viper)
+	repo := repository.VerifierDelegationRepositoryFactory(log)
+	employeeMessage := messaging.EmployeeMessageFactory(log)
+	return NewVerifierDelegationUseCase(log, verifierDelegationDTO, repo, viper, employeeMessage)
+}
+
+// CreateVerifierDelegation lets the delegate verify the tasks of the delegator for a period.
+// Only the delegator or an admin may delegate, and the periods of a delegator may not overlap.
+func (uc *VerifierDelegationUseCase) CreateVerifierDelegation(req *request.CreateVerifierDelegationRequest) (*response.VerifierDelegationResponse, error) {
+	delegatorID, err := uuid.Parse(req.DelegatorID)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error parsing delegator id: ", err)
+		return nil, err
+	}
+	delegateID, err := uuid.Parse(req.DelegateID)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error parsing delegate id: ", err)
+		return nil, err
+	}
+	if !req.Actor.IsAdmin && req.Actor.EmployeeID != delegatorID {
+		return nil, ErrTaskActorForbidden
+	}
+	if delegatorID == delegateID {
+		return nil, errors.New("verification cannot be delegated to the verifier")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error parsing start date: ", err)
+		return nil, err
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error parsing end date: ", err)
+		return nil, err
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date is before the start date")
+	}
+	if endDate.Format("2006-01-02") < time.Now().Format("2006-01-02") {
+		return nil, errors.New("end date is in the past")
+	}
+
+	overlapping, err := uc.Repository.FindOverlapping(delegatorID, startDate, endDate)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error finding overlapping delegation: ", err)
+		return nil, err
+	}
+	if overlapping != nil {
+		return nil, errors.New("the verifier already delegates from " + overlapping.StartDate.Format("2006-01-02") + " to " + overlapping.EndDate.Format("2006-01-02"))
+	}
+
+	delegate, err := uc.EmployeeMessage.SendFindEmployeeByIDMessage(request.SendFindEmployeeByIDMessageRequest{
+		ID: delegateID.String(),
+	})
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error finding delegate: ", err)
+		return nil, err
+	}
+	if delegate == nil {
+		return nil, errors.New("delegate not found")
+	}
+
+	createdBy := req.Actor.EmployeeID
+	verifierDelegation, err := uc.Repository.CreateVerifierDelegation(&entity.VerifierDelegation{
+		DelegatorID: delegatorID,
+		DelegateID:  delegateID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Reason:      req.Reason,
+		CreatedBy:   &createdBy,
+	})
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.CreateVerifierDelegation] error creating verifier delegation: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(verifierDelegation), nil
+}
+
+// RevokeVerifierDelegation ends a delegation early, the delegator verifies again right away.
+func (uc *VerifierDelegationUseCase) RevokeVerifierDelegation(req *request.RevokeVerifierDelegationRequest) (*response.VerifierDelegationResponse, error) {
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.RevokeVerifierDelegation] error parsing id: ", err)
+		return nil, err
+	}
+
+	verifierDelegation, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.RevokeVerifierDelegation] error finding verifier delegation: ", err)
+		return nil, err
+	}
+	if verifierDelegation == nil {
+		return nil, errors.New("verifier delegation not found")
+	}
+	if !req.Actor.IsAdmin && req.Actor.EmployeeID != verifierDelegation.DelegatorID {
+		return nil, ErrTaskActorForbidden
+	}
+	if verifierDelegation.RevokedAt != nil {
+		return nil, errors.New("verifier delegation is already revoked")
+	}
+
+	now := time.Now()
+	revokedBy := req.Actor.EmployeeID
+	verifierDelegation, err = uc.Repository.UpdateVerifierDelegation(&entity.VerifierDelegation{
+		ID:        verifierDelegation.ID,
+		RevokedAt: &now,
+		RevokedBy: &revokedBy,
+	})
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.RevokeVerifierDelegation] error revoking verifier delegation: ", err)
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(verifierDelegation), nil
+}
+
+func (uc *VerifierDelegationUseCase) FindByID(id uuid.UUID) (*response.VerifierDelegationResponse, error) {
+	verifierDelegation, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.FindByID] error finding verifier delegation: ", err)
+		return nil, err
+	}
+	if verifierDelegation == nil {
+		return nil, errors.New("verifier delegation not found")
+	}
+
+	return uc.DTO.ConvertEntityToResponse(verifierDelegation), nil
+}
+
+// FindAllPaginated lists delegations. Employees who are not admins only see the delegations
+// they gave or received.
+func (uc *VerifierDelegationUseCase) FindAllPaginated(page, pageSize int, employeeID *uuid.UUID, actor request.TaskActor, sort map[string]interface{}) (*[]response.VerifierDelegationResponse, int64, error) {
+	if !actor.IsAdmin {
+		employeeID = &actor.EmployeeID
+	}
+
+	verifierDelegations, total, err := uc.Repository.FindAllPaginated(page, pageSize, employeeID, sort)
+	if err != nil {
+		uc.Log.Error("[VerifierDelegationUseCase.FindAllPaginated] error finding verifier delegations: ", err)
+		return nil, 0, err
+	}
+
+	responses := make([]response.VerifierDelegationResponse, 0, len(*verifierDelegations))
+	for _, verifierDelegation := range *verifierDelegations {
+		responses = append(responses, *uc.DTO.ConvertEntityToResponse(&verifierDelegation))
+	}
+
+	return &responses, total, nil
+}
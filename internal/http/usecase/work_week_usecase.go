@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/dto"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/request"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/response"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/repository"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+type IWorkWeekUseCase interface {
+	CreateOrUpdateWorkWeek(req *request.CreateOrUpdateWorkWeekRequest) (*response.WorkWeekResponse, error)
+	DeleteWorkWeek(id uuid.UUID) error
+	FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.WorkWeekResponse, int64, error)
+	FindByOrganizationID(organizationID uuid.UUID) (*response.WorkWeekResponse, error)
+}
+
+type WorkWeekUseCase struct {
+	Log        *logrus.Logger
+	DTO        dto.IWorkWeekDTO
+	Repository repository.IWorkWeekRepository
+	Viper      *viper.Viper
+}
+
+func NewWorkWeekUseCase(
+	log *logrus.Logger,
+	dto dto.IWorkWeekDTO,
+	repository repository.IWorkWeekRepository,
+	viper *viper.Viper,
+) IWorkWeekUseCase {
+	return &WorkWeekUseCase{
+		Log:        log,
+		DTO:        dto,
+		Repository: repository,
+		Viper:      viper,
+	}
+}
+
+func WorkWeekUseCaseFactory(log *logrus.Logger, viper *viper.Viper) IWorkWeekUseCase {
+	workWeekDTO := dto.WorkWeekDTOFactory(log, viper)
+	workWeekRepository := repository.WorkWeekRepositoryFactory(log)
+	return NewWorkWeekUseCase(log, workWeekDTO, workWeekRepository, viper)
+}
+
+func (uc *WorkWeekUseCase) CreateOrUpdateWorkWeek(req *request.CreateOrUpdateWorkWeekRequest) (*response.WorkWeekResponse, error) {
+	parsedOrgID, err := uuid.Parse(req.OrganizationID)
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.CreateOrUpdateWorkWeek] " + err.Error())
+		return nil, err
+	}
+
+	if req.Monday == "NO" && req.Tuesday == "NO" && req.Wednesday == "NO" && req.Thursday == "NO" &&
+		req.Friday == "NO" && req.Saturday == "NO" && req.Sunday == "NO" {
+		return nil, errors.New("work week must have at least one working day")
+	}
+
+	workWeek := &entity.WorkWeek{
+		OrganizationID: parsedOrgID,
+		Monday:         req.Monday,
+		Tuesday:        req.Tuesday,
+		Wednesday:      req.Wednesday,
+		Thursday:       req.Thursday,
+		Friday:         req.Friday,
+		Saturday:       req.Saturday,
+		Sunday:         req.Sunday,
+	}
+
+	exist, err := uc.Repository.FindByOrganizationID(parsedOrgID)
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.CreateOrUpdateWorkWeek] " + err.Error())
+		return nil, err
+	}
+
+	if exist != nil {
+		workWeek.ID = exist.ID
+		workWeek, err = uc.Repository.UpdateWorkWeek(workWeek)
+	} else {
+		workWeek, err = uc.Repository.CreateWorkWeek(workWeek)
+	}
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.CreateOrUpdateWorkWeek] " + err.Error())
+		return nil, err
+	}
+
+	return uc.DTO.ConvertEntityToResponse(workWeek), nil
+}
+
+func (uc *WorkWeekUseCase) DeleteWorkWeek(id uuid.UUID) error {
+	exist, err := uc.Repository.FindByID(id)
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.DeleteWorkWeek] " + err.Error())
+		return err
+	}
+	if exist == nil {
+		return errors.New("work week not found")
+	}
+
+	return uc.Repository.DeleteWorkWeek(id)
+}
+
+func (uc *WorkWeekUseCase) FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]response.WorkWeekResponse, int64, error) {
+	workWeeks, total, err := uc.Repository.FindAllPaginated(page, pageSize, sort)
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.FindAllPaginated] " + err.Error())
+		return nil, 0, err
+	}
+
+	workWeekResponses := make([]response.WorkWeekResponse, 0)
+	for _, workWeek := range *workWeeks {
+		workWeekResponses = append(workWeekResponses, *uc.DTO.ConvertEntityToResponse(&workWeek))
+	}
+
+	return &workWeekResponses, total, nil
+}
+
+func (uc *WorkWeekUseCase) FindByOrganizationID(organizationID uuid.UUID) (*response.WorkWeekResponse, error) {
+	workWeek, err := uc.Repository.FindByOrganizationID(organizationID)
+	if err != nil {
+		uc.Log.Error("[WorkWeekUseCase.FindByOrganizationID] " + err.Error())
+		return nil, err
+	}
+	if workWeek == nil {
+		return nil, errors.New("work week not found")
+	}
+
+	return uc.DTO.ConvertEntityToResponse(workWeek), nil
+}
@@ -1,14 +1,22 @@
 package repository
 
 import (
+	"errors"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type IEmployeeHiringRepository interface {
 	CreateEmployeeHiring(ent *entity.EmployeeHiring) (*entity.EmployeeHiring, error)
+	FindAllInFlightByOrganizationType(organizationType string, employeeIDs []uuid.UUID) (*[]entity.EmployeeHiring, error)
+	FindLatestByEmployeeID(employeeID uuid.UUID) (*entity.EmployeeHiring, error)
+	FindAllActive() (*[]entity.EmployeeHiring, error)
+	FindAllLatestByEmployeeIDs(employeeIDs []uuid.UUID) (*[]entity.EmployeeHiring, error)
+	UpdateEmployeeHiring(ent *entity.EmployeeHiring) (*entity.EmployeeHiring, error)
 }
 
 type EmployeeHiringRepository struct {
@@ -46,3 +54,117 @@ func (r *EmployeeHiringRepository) CreateEmployeeHiring(ent *entity.EmployeeHiri
 
 	return ent, nil
 }
+
+// FindAllInFlightByOrganizationType returns the latest hiring of every employee that
+// still has an unfinished onboarding task generated from a template of the organization type.
+func (r *EmployeeHiringRepository) FindAllInFlightByOrganizationType(organizationType string, employeeIDs []uuid.UUID) (*[]entity.EmployeeHiring, error) {
+	var employeeHirings []entity.EmployeeHiring
+
+	inFlight := r.DB.Model(&entity.EmployeeTask{}).
+		Select("employee_tasks.employee_id").
+		Joins("JOIN template_tasks ON template_tasks.id = employee_tasks.template_task_id AND template_tasks.deleted_at IS NULL").
+		Where("template_tasks.organization_type = ?", organizationType).
+		Where("employee_tasks.source = ?", "ONBOARDING").
+		Where("employee_tasks.status = ?", entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE).
+		Where("employee_tasks.kanban <> ?", entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED)
+
+	db := r.DB.Where("employee_id IN (?)", inFlight)
+	if len(employeeIDs) > 0 {
+		db = db.Where("employee_id IN ?", employeeIDs)
+	}
+
+	if err := db.Order("hiring_date desc").Find(&employeeHirings).Error; err != nil {
+		r.Log.Error("[EmployeeHiringRepository.FindAllInFlightByOrganizationType] Error when get in-flight employee hirings: ", err)
+		return nil, err
+	}
+
+	// an employee is hired once per recruitment message, keep the latest record only
+	seen := make(map[uuid.UUID]bool)
+	latest := make([]entity.EmployeeHiring, 0, len(employeeHirings))
+	for _, employeeHiring := range employeeHirings {
+		if seen[employeeHiring.EmployeeID] {
+			continue
+		}
+		seen[employeeHiring.EmployeeID] = true
+		latest = append(latest, employeeHiring)
+	}
+
+	return &latest, nil
+}
+
+func (r *EmployeeHiringRepository) FindLatestByEmployeeID(employeeID uuid.UUID) (*entity.EmployeeHiring, error) {
+	var employeeHiring entity.EmployeeHiring
+
+	if err := r.DB.Where("employee_id = ?", employeeID).Order("hiring_date desc").Order("created_at desc").First(&employeeHiring).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.Log.Error("[EmployeeHiringRepository.FindLatestByEmployeeID] Error when get employee hiring: ", err)
+		return nil, err
+	}
+
+	return &employeeHiring, nil
+}
+
+// FindAllActive returns the latest hiring of every employee whose latest hiring is active.
+func (r *EmployeeHiringRepository) FindAllActive() (*[]entity.EmployeeHiring, error) {
+	var employeeHirings []entity.EmployeeHiring
+
+	if err := r.DB.Order("hiring_date desc").Order("created_at desc").Find(&employeeHirings).Error; err != nil {
+		r.Log.Error("[EmployeeHiringRepository.FindAllActive] Error when get employee hirings: ", err)
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	active := make([]entity.EmployeeHiring, 0, len(employeeHirings))
+	for _, employeeHiring := range employeeHirings {
+		if seen[employeeHiring.EmployeeID] {
+			continue
+		}
+		seen[employeeHiring.EmployeeID] = true
+		if employeeHiring.Status == entity.EMPLOYEE_HIRING_STATUS_ENUM_ACTIVE {
+			active = append(active, employeeHiring)
+		}
+	}
+
+	return &active, nil
+}
+
+// FindAllLatestByEmployeeIDs returns the latest hiring of each of the employees that has one.
+func (r *EmployeeHiringRepository) FindAllLatestByEmployeeIDs(employeeIDs []uuid.UUID) (*[]entity.EmployeeHiring, error) {
+	var employeeHirings []entity.EmployeeHiring
+	if len(employeeIDs) == 0 {
+		return &employeeHirings, nil
+	}
+
+	if err := r.DB.Where("employee_id IN ?", employeeIDs).Order("hiring_date desc").Order("created_at desc").Find(&employeeHirings).Error; err != nil {
+		r.Log.Error("[EmployeeHiringRepository.FindAllLatestByEmployeeIDs] Error when get employee hirings: ", err)
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	latest := make([]entity.EmployeeHiring, 0, len(employeeHirings))
+	for _, employeeHiring := range employeeHirings {
+		if seen[employeeHiring.EmployeeID] {
+			continue
+		}
+		seen[employeeHiring.EmployeeID] = true
+		latest = append(latest, employeeHiring)
+	}
+
+	return &latest, nil
+}
+
+func (r *EmployeeHiringRepository) UpdateEmployeeHiring(ent *entity.EmployeeHiring) (*entity.EmployeeHiring, error) {
+	if err := r.DB.Model(&entity.EmployeeHiring{}).Where("id = ?", ent.ID).Updates(ent).Error; err != nil {
+		r.Log.Error("[EmployeeHiringRepository.UpdateEmployeeHiring] Error when update employee hiring: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeHiringRepository.UpdateEmployeeHiring] Error when get employee hiring: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IEmployeeOffboardingRepository interface {
+	CreateEmployeeOffboarding(ent *entity.EmployeeOffboarding) (*entity.EmployeeOffboarding, error)
+	FindLatestByEmployeeID(employeeID uuid.UUID) (*entity.EmployeeOffboarding, error)
+}
+
+type EmployeeOffboardingRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewEmployeeOffboardingRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *EmployeeOffboardingRepository {
+	return &EmployeeOffboardingRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func EmployeeOffboardingRepositoryFactory(
+	log *logrus.Logger,
+) IEmployeeOffboardingRepository {
+	db := config.NewDatabase()
+	return NewEmployeeOffboardingRepository(log, db)
+}
+
+func (r *EmployeeOffboardingRepository) CreateEmployeeOffboarding(ent *entity.EmployeeOffboarding) (*entity.EmployeeOffboarding, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[EmployeeOffboardingRepository.CreateEmployeeOffboarding] Error when create employee offboarding: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeOffboardingRepository.CreateEmployeeOffboarding] Error when get employee offboarding: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *EmployeeOffboardingRepository) FindLatestByEmployeeID(employeeID uuid.UUID) (*entity.EmployeeOffboarding, error) {
+	var employeeOffboarding entity.EmployeeOffboarding
+	if err := r.DB.Where("employee_id = ?", employeeID).Order("created_at desc").First(&employeeOffboarding).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[EmployeeOffboardingRepository.FindLatestByEmployeeID] Error when get employee offboarding: ", err)
+			return nil, err
+		}
+	}
+
+	return &employeeOffboarding, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IEmployeeTaskApprovalRepository interface {
+	CreateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error)
+	UpdateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error)
+	FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.EmployeeTaskApproval, error)
+	DeletePendingByEmployeeTaskID(employeeTaskID uuid.UUID) error
+}
+
+type EmployeeTaskApprovalRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewEmployeeTaskApprovalRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *EmployeeTaskApprovalRepository {
+	return &EmployeeTaskApprovalRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func EmployeeTaskApprovalRepositoryFactory(
+	log *logrus.Logger,
+) IEmployeeTaskApprovalRepository {
+	db := config.NewDatabase()
+	return NewEmployeeTaskApprovalRepository(log, db)
+}
+
+func (r *EmployeeTaskApprovalRepository) CreateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.CreateEmployeeTaskApproval] Error when create employee task approval: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.CreateEmployeeTaskApproval] Error when get employee task approval: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *EmployeeTaskApprovalRepository) UpdateEmployeeTaskApproval(ent *entity.EmployeeTaskApproval) (*entity.EmployeeTaskApproval, error) {
+	if err := r.DB.Model(&entity.EmployeeTaskApproval{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"approver_id": ent.ApproverID,
+		"status":      ent.Status,
+		"acted_by":    ent.ActedBy,
+		"acted_at":    ent.ActedAt,
+		"reason":      ent.Reason,
+	}).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.UpdateEmployeeTaskApproval] Error when update employee task approval: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.UpdateEmployeeTaskApproval] Error when get employee task approval: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// FindAllByEmployeeTaskID returns the approval steps of every round, oldest round first.
+func (r *EmployeeTaskApprovalRepository) FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.EmployeeTaskApproval, error) {
+	var approvals []entity.EmployeeTaskApproval
+	if err := r.DB.Where("employee_task_id = ?", employeeTaskID).Order("round asc").Order("step_order asc").Find(&approvals).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.FindAllByEmployeeTaskID] Error when get employee task approvals: ", err)
+		return nil, err
+	}
+
+	return &approvals, nil
+}
+
+// DeletePendingByEmployeeTaskID removes the steps nobody acted on yet. Approved and rejected
+// steps are kept as history.
+func (r *EmployeeTaskApprovalRepository) DeletePendingByEmployeeTaskID(employeeTaskID uuid.UUID) error {
+	if err := r.DB.Where("employee_task_id = ? AND status = ?", employeeTaskID, entity.EMPLOYEE_TASK_APPROVAL_STATUS_ENUM_PENDING).Delete(&entity.EmployeeTaskApproval{}).Error; err != nil {
+		r.Log.Error("[EmployeeTaskApprovalRepository.DeletePendingByEmployeeTaskID] Error when delete employee task approvals: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 type IEmployeeTaskChecklistRepository interface {
 	CreateEmployeeTaskChecklist(ent *entity.EmployeeTaskChecklist) (*entity.EmployeeTaskChecklist, error)
 	UpdateEmployeeTaskChecklist(ent *entity.EmployeeTaskChecklist) (*entity.EmployeeTaskChecklist, error)
+	UpdateEmployeeTaskChecklistColumns(ent *entity.EmployeeTaskChecklist, columns []string) (*entity.EmployeeTaskChecklist, error)
 	DeleteByEmployeeTaskID(id uuid.UUID) error
 	FindByKeys(keys map[string]interface{}) (*entity.EmployeeTaskChecklist, error)
 	DeleteByEmployeeTaskIDAndNotInChecklistIDs(employeeTaskID uuid.UUID, checklistIDs []uuid.UUID) error
@@ -61,6 +62,17 @@ func (r *EmployeeTaskChecklistRepository) UpdateEmployeeTaskChecklist(ent *entit
 	return ent, nil
 }
 
+// UpdateEmployeeTaskChecklistColumns writes only the given columns, including the ones that are
+// set back to nil or a zero value.
+func (r *EmployeeTaskChecklistRepository) UpdateEmployeeTaskChecklistColumns(ent *entity.EmployeeTaskChecklist, columns []string) (*entity.EmployeeTaskChecklist, error) {
+	if err := r.DB.Model(&entity.EmployeeTaskChecklist{}).Where("id = ?", ent.ID).Select(columns).Updates(ent).Error; err != nil {
+		r.Log.Error("[EmployeeTaskChecklistRepository.UpdateEmployeeTaskChecklistColumns] Error when update employee task checklist: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
 func (r *EmployeeTaskChecklistRepository) DeleteByEmployeeTaskID(id uuid.UUID) error {
 	if err := r.DB.Where("employee_task_id = ?", id).Delete(&entity.EmployeeTaskChecklist{}).Error; err != nil {
 		r.Log.Error("[EmployeeTaskChecklistRepository.DeleteByEmployeeTaskID] Error when delete employee task checklist: ", err)
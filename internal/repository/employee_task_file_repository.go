@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IEmployeeTaskFileRepository interface {
+	CreateEmployeeTaskFile(ent *entity.EmployeeTaskFiles) (*entity.EmployeeTaskFiles, error)
+	UpdateEmployeeTaskFileReview(ent *entity.EmployeeTaskFiles) (*entity.EmployeeTaskFiles, error)
+	FindByID(id uuid.UUID) (*entity.EmployeeTaskFiles, error)
+	CountByEmployeeTaskIDAndStatus(employeeTaskID uuid.UUID, status entity.EmployeeTaskFileStatusEnum) (int64, error)
+}
+
+type EmployeeTaskFileRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewEmployeeTaskFileRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *EmployeeTaskFileRepository {
+	return &EmployeeTaskFileRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func EmployeeTaskFileRepositoryFactory(
+	log *logrus.Logger,
+) IEmployeeTaskFileRepository {
+	db := config.NewDatabase()
+	return NewEmployeeTaskFileRepository(log, db)
+}
+
+func (r *EmployeeTaskFileRepository) CreateEmployeeTaskFile(ent *entity.EmployeeTaskFiles) (*entity.EmployeeTaskFiles, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[EmployeeTaskFileRepository.CreateEmployeeTaskFile] Error when create employee task file: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskFileRepository.CreateEmployeeTaskFile] Error when get employee task file: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *EmployeeTaskFileRepository) UpdateEmployeeTaskFileReview(ent *entity.EmployeeTaskFiles) (*entity.EmployeeTaskFiles, error) {
+	if err := r.DB.Model(&entity.EmployeeTaskFiles{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"status":      ent.Status,
+		"reviewed_by": ent.ReviewedBy,
+		"reviewed_at": ent.ReviewedAt,
+		"notes":       ent.Notes,
+	}).Error; err != nil {
+		r.Log.Error("[EmployeeTaskFileRepository.UpdateEmployeeTaskFileReview] Error when update employee task file: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskFileRepository.UpdateEmployeeTaskFileReview] Error when get employee task file: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *EmployeeTaskFileRepository) FindByID(id uuid.UUID) (*entity.EmployeeTaskFiles, error) {
+	var ent entity.EmployeeTaskFiles
+	if err := r.DB.Where("id = ?", id).First(&ent).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		} else {
+			r.Log.Error("[EmployeeTaskFileRepository.FindByID] Error when find employee task file: ", err)
+			return nil, err
+		}
+	}
+
+	return &ent, nil
+}
+
+func (r *EmployeeTaskFileRepository) CountByEmployeeTaskIDAndStatus(employeeTaskID uuid.UUID, status entity.EmployeeTaskFileStatusEnum) (int64, error) {
+	var count int64
+	if err := r.DB.Model(&entity.EmployeeTaskFiles{}).Where("employee_task_id = ? AND status = ?", employeeTaskID, status).Count(&count).Error; err != nil {
+		r.Log.Error("[EmployeeTaskFileRepository.CountByEmployeeTaskIDAndStatus] Error when count employee task files: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IEmployeeTaskHistoryRepository interface {
+	CreateEmployeeTaskHistory(ent *entity.EmployeeTaskHistory) (*entity.EmployeeTaskHistory, error)
+	FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.EmployeeTaskHistory, error)
+}
+
+type EmployeeTaskHistoryRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewEmployeeTaskHistoryRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *EmployeeTaskHistoryRepository {
+	return &EmployeeTaskHistoryRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func EmployeeTaskHistoryRepositoryFactory(
+	log *logrus.Logger,
+) IEmployeeTaskHistoryRepository {
+	db := config.NewDatabase()
+	return NewEmployeeTaskHistoryRepository(log, db)
+}
+
+func (r *EmployeeTaskHistoryRepository) CreateEmployeeTaskHistory(ent *entity.EmployeeTaskHistory) (*entity.EmployeeTaskHistory, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[EmployeeTaskHistoryRepository.CreateEmployeeTaskHistory] Error when create employee task history: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskHistoryRepository.CreateEmployeeTaskHistory] Error when get employee task history: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *EmployeeTaskHistoryRepository) FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.EmployeeTaskHistory, error) {
+	var employeeTaskHistories []entity.EmployeeTaskHistory
+	if err := r.DB.Where("employee_task_id = ?", employeeTaskID).Order("created_at desc").Find(&employeeTaskHistories).Error; err != nil {
+		r.Log.Error("[EmployeeTaskHistoryRepository.FindAllByEmployeeTaskID] Error when get employee task histories: ", err)
+		return nil, err
+	}
+
+	return &employeeTaskHistories, nil
+}
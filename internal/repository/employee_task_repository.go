@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/google/uuid"
@@ -14,14 +16,26 @@ type IEmployeeTaskRepository interface {
 	DeleteEmployeeTask(ent *entity.EmployeeTask) error
 	FindByID(id uuid.UUID) (*entity.EmployeeTask, error)
 	FindAllByEmployeeID(employeeID uuid.UUID) (*[]entity.EmployeeTask, error)
+	FindAllByEmployeeIDAndSource(employeeID uuid.UUID, source string) (*[]entity.EmployeeTask, error)
 	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error)
 	FindAllPaginatedByEmployeeID(employeeID uuid.UUID, page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error)
 	CountByKeys(keys map[string]interface{}) (int64, error)
-	FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error)
+	FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, source string, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error)
+	CountBySourceAndKeys(source string, keys map[string]interface{}) (int64, error)
 	FindByKeys(keys map[string]interface{}) (*entity.EmployeeTask, error)
 	FindByIDForResponse(id uuid.UUID) (*entity.EmployeeTask, error)
 	FindAllPaginatedSurvey(page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error)
 	FindAllSurvey() (*[]entity.EmployeeTask, error)
+	FindAllBySurveyTemplateID(surveyTemplateID uuid.UUID) (*[]entity.EmployeeTask, error)
+	CountSurveyForExport(surveyTemplateIDs []uuid.UUID, submittedFrom, submittedTo *time.Time) (int64, error)
+	FindSurveyForExportAfterID(surveyTemplateIDs []uuid.UUID, submittedFrom, submittedTo *time.Time, afterID *uuid.UUID, limit int) (*[]entity.EmployeeTask, error)
+	FindAllOpenByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.EmployeeTask, error)
+	UpdateTemplateTaskVersionByIDs(ids []uuid.UUID, templateTaskVersionID uuid.UUID) error
+	UpdateEmployeeTaskColumns(ent *entity.EmployeeTask, columns []string) error
+	UpdateStatusByID(id uuid.UUID, status entity.EmployeeTaskStatusEnum, pausedAt *time.Time) error
+	UpdateQuizScoreByID(id uuid.UUID, quizScore *int) error
+	UpdateAcknowledgedAtByID(id uuid.UUID, acknowledgedAt *time.Time) error
+	CloseByID(id uuid.UUID, closedAt time.Time) error
 }
 
 type EmployeeTaskRepository struct {
@@ -85,7 +99,7 @@ func (r *EmployeeTaskRepository) DeleteEmployeeTask(ent *entity.EmployeeTask) er
 
 func (r *EmployeeTaskRepository) FindByID(id uuid.UUID) (*entity.EmployeeTask, error) {
 	var ent entity.EmployeeTask
-	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("SurveyTemplate").First(&ent, id).Error; err != nil {
+	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("EmployeeTaskFiles").Preload("SurveyTemplate").First(&ent, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		} else {
@@ -141,11 +155,44 @@ func (r *EmployeeTaskRepository) FindAllByEmployeeID(employeeID uuid.UUID) (*[]e
 	return &employeeTasks, nil
 }
 
-func (r *EmployeeTaskRepository) FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error) {
+func (r *EmployeeTaskRepository) FindAllByEmployeeIDAndSource(employeeID uuid.UUID, source string) (*[]entity.EmployeeTask, error) {
 	var employeeTasks []entity.EmployeeTask
+
+	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("EmployeeTaskFiles").Preload("SurveyTemplate").Scopes(employeeTaskSourceScope(source)).Where("employee_id = ?", employeeID).Find(&employeeTasks).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.FindAllByEmployeeIDAndSource] Error when get employee tasks by employee id and source: ", err)
+		return nil, err
+	}
+
+	return &employeeTasks, nil
+}
+
+func (r *EmployeeTaskRepository) CountBySourceAndKeys(source string, keys map[string]interface{}) (int64, error) {
 	var total int64
 
-	query := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Where("employee_id = ?", employeeID).Where("kanban = ?", kanban)
+	if err := r.DB.Model(&entity.EmployeeTask{}).Scopes(employeeTaskSourceScope(source)).Where(keys).Count(&total).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.CountBySourceAndKeys] Error when count employee tasks: ", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// employeeTaskSourceScope limits a query to one source. Tasks created before the source
+// was recorded count as onboarding tasks.
+func employeeTaskSourceScope(source string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if source == entity.TASK_SOURCE_ONBOARDING {
+			return db.Where("(employee_tasks.source = ? OR employee_tasks.source IS NULL)", source)
+		}
+		return db.Where("employee_tasks.source = ?", source)
+	}
+}
+
+func (r *EmployeeTaskRepository) FindAllByEmployeeIDAndKanbanPaginated(employeeID uuid.UUID, source string, kanban entity.EmployeeTaskKanbanEnum, page, pageSize int, search string, sort map[string]interface{}) (*[]entity.EmployeeTask, int64, error) {
+	var employeeTasks []entity.EmployeeTask
+	var total int64
+
+	query := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("EmployeeTaskFiles").Preload("SurveyTemplate").Scopes(employeeTaskSourceScope(source)).Where("employee_id = ?", employeeID).Where("kanban = ?", kanban)
 	for key, value := range sort {
 		query = query.Order(key + " " + value.(string))
 	}
@@ -203,9 +250,13 @@ func (r *EmployeeTaskRepository) FindAllPaginatedByEmployeeID(employeeID uuid.UU
 func (r *EmployeeTaskRepository) FindByIDForResponse(id uuid.UUID) (*entity.EmployeeTask, error) {
 	var ent entity.EmployeeTask
 
-	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").
+	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("EmployeeTaskFiles").
 		Preload("SurveyTemplate.Questions.QuestionOptions").
 		Preload("SurveyTemplate.Questions.AnswerType").
+		Preload("SurveyTemplate.Questions.DisplayConditions.SourceQuestion").
+		Preload("SurveyTemplate.Questions.MatrixRows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number asc")
+		}).
 		Preload("SurveyTemplate.Questions.SurveyResponses", "employee_task_id = ?", id).Where("id = ?", id).First(&ent).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -250,3 +301,152 @@ func (r *EmployeeTaskRepository) FindAllSurvey() (*[]entity.EmployeeTask, error)
 
 	return &employeeTasks, nil
 }
+
+// FindAllBySurveyTemplateID returns the tasks linked to a survey template without their relations.
+func (r *EmployeeTaskRepository) FindAllBySurveyTemplateID(surveyTemplateID uuid.UUID) (*[]entity.EmployeeTask, error) {
+	var employeeTasks []entity.EmployeeTask
+
+	if err := r.DB.Where("survey_template_id = ?", surveyTemplateID).Find(&employeeTasks).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.FindAllBySurveyTemplateID] Error when get employee tasks: ", err)
+		return nil, err
+	}
+
+	return &employeeTasks, nil
+}
+
+// surveyExportScope limits survey tasks to the survey templates and to the ones with an answer
+// saved within the submitted range. Any of the filters may be nil.
+func surveyExportScope(surveyTemplateIDs []uuid.UUID, submittedFrom, submittedTo *time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("survey_template_id IS NOT NULL")
+		if len(surveyTemplateIDs) > 0 {
+			db = db.Where("survey_template_id IN ?", surveyTemplateIDs)
+		}
+		if submittedFrom != nil || submittedTo != nil {
+			submitted := db.Session(&gorm.Session{NewDB: true}).Model(&entity.SurveyResponse{}).Select("employee_task_id")
+			if submittedFrom != nil {
+				submitted = submitted.Where("updated_at >= ?", *submittedFrom)
+			}
+			if submittedTo != nil {
+				submitted = submitted.Where("updated_at < ?", *submittedTo)
+			}
+			db = db.Where("id IN (?)", submitted)
+		}
+		return db
+	}
+}
+
+func (r *EmployeeTaskRepository) CountSurveyForExport(surveyTemplateIDs []uuid.UUID, submittedFrom, submittedTo *time.Time) (int64, error) {
+	var total int64
+
+	if err := r.DB.Model(&entity.EmployeeTask{}).Scopes(surveyExportScope(surveyTemplateIDs, submittedFrom, submittedTo)).Count(&total).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.CountSurveyForExport] Error when count employee tasks: ", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// FindSurveyForExportAfterID returns the next batch of survey tasks ordered by id, starting
+// after afterID, so that an export can walk every task without offsets.
+func (r *EmployeeTaskRepository) FindSurveyForExportAfterID(surveyTemplateIDs []uuid.UUID, submittedFrom, submittedTo *time.Time, afterID *uuid.UUID, limit int) (*[]entity.EmployeeTask, error) {
+	var employeeTasks []entity.EmployeeTask
+
+	db := r.DB.Preload("SurveyTemplate").Scopes(surveyExportScope(surveyTemplateIDs, submittedFrom, submittedTo))
+	if afterID != nil {
+		db = db.Where("id > ?", *afterID)
+	}
+
+	if err := db.Order("id asc").Limit(limit).Find(&employeeTasks).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.FindSurveyForExportAfterID] Error when get employee tasks: ", err)
+		return nil, err
+	}
+
+	return &employeeTasks, nil
+}
+
+// FindAllOpenByTemplateTaskID returns the active, not yet completed tasks generated from a template task.
+func (r *EmployeeTaskRepository) FindAllOpenByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.EmployeeTask, error) {
+	var employeeTasks []entity.EmployeeTask
+
+	if err := r.DB.Preload("EmployeeTaskAttachments").Preload("EmployeeTaskChecklists").Preload("SurveyTemplate").
+		Where("template_task_id = ?", templateTaskID).
+		Where("status = ?", entity.EMPLOYEE_TASK_STATUS_ENUM_ACTIVE).
+		Where("kanban <> ?", entity.EMPLOYEE_TASK_KANBAN_ENUM_COMPLETED).
+		Find(&employeeTasks).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.FindAllOpenByTemplateTaskID] Error when get employee tasks: ", err)
+		return nil, err
+	}
+
+	return &employeeTasks, nil
+}
+
+func (r *EmployeeTaskRepository) UpdateTemplateTaskVersionByIDs(ids []uuid.UUID, templateTaskVersionID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id IN ?", ids).
+		Update("template_task_version_id", templateTaskVersionID).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.UpdateTemplateTaskVersionByIDs] Error when update employee tasks: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateEmployeeTaskColumns writes only the given columns, including the ones that are set back
+// to nil or a zero value.
+func (r *EmployeeTaskRepository) UpdateEmployeeTaskColumns(ent *entity.EmployeeTask, columns []string) error {
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id = ?", ent.ID).Select(columns).Updates(ent).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.UpdateEmployeeTaskColumns] Error when update employee task: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateStatusByID sets the status and paused_at of an employee task. Unlike UpdateEmployeeTask
+// it also writes a nil paused_at.
+func (r *EmployeeTaskRepository) UpdateStatusByID(id uuid.UUID, status entity.EmployeeTaskStatusEnum, pausedAt *time.Time) error {
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":    status,
+		"paused_at": pausedAt,
+	}).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.UpdateStatusByID] Error when update employee task status: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *EmployeeTaskRepository) UpdateQuizScoreByID(id uuid.UUID, quizScore *int) error {
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id = ?", id).Update("quiz_score", quizScore).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.UpdateQuizScoreByID] Error when update employee task quiz score: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *EmployeeTaskRepository) UpdateAcknowledgedAtByID(id uuid.UUID, acknowledgedAt *time.Time) error {
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id = ?", id).Update("acknowledged_at", acknowledgedAt).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.UpdateAcknowledgedAtByID] Error when update employee task acknowledged at: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// CloseByID deactivates a task whose response deadline passed.
+func (r *EmployeeTaskRepository) CloseByID(id uuid.UUID, closedAt time.Time) error {
+	if err := r.DB.Model(&entity.EmployeeTask{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":    entity.EMPLOYEE_TASK_STATUS_ENUM_INACTIVE,
+		"closed_at": closedAt,
+	}).Error; err != nil {
+		r.Log.Error("[EmployeeTaskRepository.CloseByID] Error when close employee task: ", err)
+		return err
+	}
+
+	return nil
+}
@@ -11,6 +11,10 @@ import (
 type IEventEmployeeRepository interface {
 	CreateEventEmployee(ent *entity.EventEmployee) (*entity.EventEmployee, error)
 	DeleteByEventID(eventID uuid.UUID) error
+	DeleteFromOpenEventsByEmployeeID(employeeID uuid.UUID) (int64, error)
+	FindByEventIDAndEmployeeID(eventID uuid.UUID, employeeID uuid.UUID) (*entity.EventEmployee, error)
+	UpdateCheckedInAt(ent *entity.EventEmployee) (*entity.EventEmployee, error)
+	FindCheckedInByEmployeeIDAndTemplateTaskID(employeeID uuid.UUID, templateTaskID uuid.UUID) (*entity.EventEmployee, error)
 }
 
 type EventEmployeeRepository struct {
@@ -52,3 +56,58 @@ func (r *EventEmployeeRepository) DeleteByEventID(eventID uuid.UUID) error {
 
 	return nil
 }
+
+// DeleteFromOpenEventsByEmployeeID removes the employee from every event that has not
+// finished yet. Finished events keep their attendees.
+func (r *EventEmployeeRepository) DeleteFromOpenEventsByEmployeeID(employeeID uuid.UUID) (int64, error) {
+	openEvents := r.DB.Model(&entity.Event{}).Select("id").Where("status <> ?", entity.EVENT_STATUS_ENUM_FINISHED)
+
+	result := r.DB.Where("employee_id = ?", employeeID).Where("event_id IN (?)", openEvents).Delete(&entity.EventEmployee{})
+	if result.Error != nil {
+		r.Log.Error("[EventEmployeeRepository.DeleteFromOpenEventsByEmployeeID] Error when delete event employee by employee id: ", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *EventEmployeeRepository) FindByEventIDAndEmployeeID(eventID uuid.UUID, employeeID uuid.UUID) (*entity.EventEmployee, error) {
+	var ent entity.EventEmployee
+	if err := r.DB.Where("event_id = ? AND employee_id = ?", eventID, employeeID).First(&ent).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		} else {
+			r.Log.Error("[EventEmployeeRepository.FindByEventIDAndEmployeeID] Error when find event employee: ", err)
+			return nil, err
+		}
+	}
+
+	return &ent, nil
+}
+
+func (r *EventEmployeeRepository) UpdateCheckedInAt(ent *entity.EventEmployee) (*entity.EventEmployee, error) {
+	if err := r.DB.Model(&entity.EventEmployee{}).Where("id = ?", ent.ID).Update("checked_in_at", ent.CheckedInAt).Error; err != nil {
+		r.Log.Error("[EventEmployeeRepository.UpdateCheckedInAt] Error when update event employee: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// FindCheckedInByEmployeeIDAndTemplateTaskID finds a check-in of the employee at any event of the
+// template task.
+func (r *EventEmployeeRepository) FindCheckedInByEmployeeIDAndTemplateTaskID(employeeID uuid.UUID, templateTaskID uuid.UUID) (*entity.EventEmployee, error) {
+	events := r.DB.Model(&entity.Event{}).Select("id").Where("template_task_id = ?", templateTaskID)
+
+	var ent entity.EventEmployee
+	if err := r.DB.Where("employee_id = ?", employeeID).Where("event_id IN (?)", events).Where("checked_in_at IS NOT NULL").First(&ent).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		} else {
+			r.Log.Error("[EventEmployeeRepository.FindCheckedInByEmployeeIDAndTemplateTaskID] Error when find event employee: ", err)
+			return nil, err
+		}
+	}
+
+	return &ent, nil
+}
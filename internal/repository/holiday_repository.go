@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IHolidayRepository interface {
+	CreateHoliday(ent *entity.Holiday) (*entity.Holiday, error)
+	UpdateHoliday(ent *entity.Holiday) (*entity.Holiday, error)
+	DeleteHoliday(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entity.Holiday, error)
+	FindByKeys(keys map[string]interface{}) (*entity.Holiday, error)
+	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]entity.Holiday, int64, error)
+	FindAllBetweenDates(organizationID *uuid.UUID, startDate, endDate time.Time) (*[]entity.Holiday, error)
+}
+
+type HolidayRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewHolidayRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *HolidayRepository {
+	return &HolidayRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func HolidayRepositoryFactory(
+	log *logrus.Logger,
+) IHolidayRepository {
+	db := config.NewDatabase()
+	return NewHolidayRepository(log, db)
+}
+
+func (r *HolidayRepository) CreateHoliday(ent *entity.Holiday) (*entity.Holiday, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[HolidayRepository.CreateHoliday] Error when create holiday: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[HolidayRepository.CreateHoliday] Error when get holiday: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *HolidayRepository) UpdateHoliday(ent *entity.Holiday) (*entity.Holiday, error) {
+	if err := r.DB.Model(&entity.Holiday{}).Where("id = ?", ent.ID).Updates(ent).Error; err != nil {
+		r.Log.Error("[HolidayRepository.UpdateHoliday] Error when update holiday: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[HolidayRepository.UpdateHoliday] Error when get holiday: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *HolidayRepository) DeleteHoliday(id uuid.UUID) error {
+	if err := r.DB.Where("id = ?", id).Delete(&entity.Holiday{}).Error; err != nil {
+		r.Log.Error("[HolidayRepository.DeleteHoliday] Error when delete holiday: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *HolidayRepository) FindByID(id uuid.UUID) (*entity.Holiday, error) {
+	var holiday entity.Holiday
+	if err := r.DB.Where("id = ?", id).First(&holiday).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[HolidayRepository.FindByID] Error when get holiday: ", err)
+			return nil, err
+		}
+	}
+
+	return &holiday, nil
+}
+
+func (r *HolidayRepository) FindByKeys(keys map[string]interface{}) (*entity.Holiday, error) {
+	var holiday entity.Holiday
+	if err := r.DB.Where(keys).First(&holiday).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[HolidayRepository.FindByKeys] Error when get holiday: ", err)
+			return nil, err
+		}
+	}
+
+	return &holiday, nil
+}
+
+func (r *HolidayRepository) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]entity.Holiday, int64, error) {
+	var holidays []entity.Holiday
+	var total int64
+
+	db := r.DB.Model(&entity.Holiday{})
+
+	if search != "" {
+		db = db.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[HolidayRepository.FindAllPaginated] Error when count holiday: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&holidays).Error; err != nil {
+		r.Log.Error("[HolidayRepository.FindAllPaginated] Error when get holidays: ", err)
+		return nil, 0, err
+	}
+
+	return &holidays, total, nil
+}
+
+// FindAllBetweenDates returns the national holidays plus, when organizationID is
+// given, the holidays specific to that organization within the inclusive range.
+func (r *HolidayRepository) FindAllBetweenDates(organizationID *uuid.UUID, startDate, endDate time.Time) (*[]entity.Holiday, error) {
+	var holidays []entity.Holiday
+
+	db := r.DB.Where("date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if organizationID != nil {
+		db = db.Where("organization_id IS NULL OR organization_id = ?", *organizationID)
+	} else {
+		db = db.Where("organization_id IS NULL")
+	}
+
+	if err := db.Order("date asc").Find(&holidays).Error; err != nil {
+		r.Log.Error("[HolidayRepository.FindAllBetweenDates] Error when get holidays: ", err)
+		return nil, err
+	}
+
+	return &holidays, nil
+}
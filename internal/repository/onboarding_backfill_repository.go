@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IOnboardingBackfillRepository interface {
+	CreateOnboardingBackfill(ent *entity.OnboardingBackfill) (*entity.OnboardingBackfill, error)
+	UpdateOnboardingBackfillProgress(ent *entity.OnboardingBackfill) error
+	UpdateOnboardingBackfillItem(ent *entity.OnboardingBackfillItem) error
+	FindByID(id uuid.UUID) (*entity.OnboardingBackfill, error)
+	FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.OnboardingBackfill, int64, error)
+}
+
+type OnboardingBackfillRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewOnboardingBackfillRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *OnboardingBackfillRepository {
+	return &OnboardingBackfillRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func OnboardingBackfillRepositoryFactory(
+	log *logrus.Logger,
+) IOnboardingBackfillRepository {
+	db := config.NewDatabase()
+	return NewOnboardingBackfillRepository(log, db)
+}
+
+func (r *OnboardingBackfillRepository) CreateOnboardingBackfill(ent *entity.OnboardingBackfill) (*entity.OnboardingBackfill, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.CreateOnboardingBackfill] Error when create onboarding backfill: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.Preload("OnboardingBackfillItems").First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.CreateOnboardingBackfill] Error when get onboarding backfill: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// UpdateOnboardingBackfillProgress stores the status, counters and timestamps of a
+// backfill. The columns are selected explicitly so that zero counters are written too.
+func (r *OnboardingBackfillRepository) UpdateOnboardingBackfillProgress(ent *entity.OnboardingBackfill) error {
+	if err := r.DB.Model(&entity.OnboardingBackfill{}).Where("id = ?", ent.ID).
+		Select("status", "total", "processed", "succeeded", "failed", "skipped", "started_at", "finished_at").
+		Updates(ent).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.UpdateOnboardingBackfillProgress] Error when update onboarding backfill: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *OnboardingBackfillRepository) UpdateOnboardingBackfillItem(ent *entity.OnboardingBackfillItem) error {
+	if err := r.DB.Model(&entity.OnboardingBackfillItem{}).Where("id = ?", ent.ID).
+		Select("employee_task_id", "status", "message").
+		Updates(ent).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.UpdateOnboardingBackfillItem] Error when update onboarding backfill item: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *OnboardingBackfillRepository) FindByID(id uuid.UUID) (*entity.OnboardingBackfill, error) {
+	var onboardingBackfill entity.OnboardingBackfill
+	if err := r.DB.Preload("OnboardingBackfillItems").Where("id = ?", id).First(&onboardingBackfill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[OnboardingBackfillRepository.FindByID] Error when get onboarding backfill: ", err)
+			return nil, err
+		}
+	}
+
+	return &onboardingBackfill, nil
+}
+
+func (r *OnboardingBackfillRepository) FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.OnboardingBackfill, int64, error) {
+	var onboardingBackfills []entity.OnboardingBackfill
+	var total int64
+
+	db := r.DB.Model(&entity.OnboardingBackfill{})
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.FindAllPaginated] Error when count onboarding backfills: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&onboardingBackfills).Error; err != nil {
+		r.Log.Error("[OnboardingBackfillRepository.FindAllPaginated] Error when get onboarding backfills: ", err)
+		return nil, 0, err
+	}
+
+	return &onboardingBackfills, total, nil
+}
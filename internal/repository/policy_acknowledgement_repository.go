@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// policyAcknowledgementChainLock is the postgres advisory lock key that serializes appends to
+// the acknowledgement chain.
+const policyAcknowledgementChainLock = 40400
+
+// ErrPolicyAcknowledgementExists is returned when the employee already acknowledged the version.
+var ErrPolicyAcknowledgementExists = errors.New("you already acknowledged this version of the policy document")
+
+type IPolicyAcknowledgementRepository interface {
+	AppendPolicyAcknowledgement(ent *entity.PolicyAcknowledgement, seal func(previous *entity.PolicyAcknowledgement)) (*entity.PolicyAcknowledgement, error)
+	FindAllPaginated(page, pageSize int, policyDocumentID, employeeID *uuid.UUID, sort map[string]interface{}) (*[]entity.PolicyAcknowledgement, int64, error)
+	FindAllAfterSequence(sequence int64, limit int) (*[]entity.PolicyAcknowledgement, error)
+	FindLatestVersionNumbersByDocumentID(policyDocumentID uuid.UUID) (map[uuid.UUID]int, error)
+}
+
+type PolicyAcknowledgementRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewPolicyAcknowledgementRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *PolicyAcknowledgementRepository {
+	return &PolicyAcknowledgementRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func PolicyAcknowledgementRepositoryFactory(
+	log *logrus.Logger,
+) IPolicyAcknowledgementRepository {
+	db := config.NewDatabase()
+	return NewPolicyAcknowledgementRepository(log, db)
+}
+
+// AppendPolicyAcknowledgement adds the acknowledgement at the end of the chain. seal receives
+// the last record of the chain, nil when the chain is empty, and has to fill the sequence and
+// the hashes of the new record. Appends run one at a time so no two records share a parent, and
+// the duplicate check runs under the same lock so an employee acknowledges a version once.
+func (r *PolicyAcknowledgementRepository) AppendPolicyAcknowledgement(ent *entity.PolicyAcknowledgement, seal func(previous *entity.PolicyAcknowledgement)) (*entity.PolicyAcknowledgement, error) {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", policyAcknowledgementChainLock).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when lock acknowledgement chain: ", err)
+		return nil, err
+	}
+
+	var existing int64
+	if err := tx.Unscoped().Model(&entity.PolicyAcknowledgement{}).
+		Where("employee_id = ? AND policy_document_version_id = ?", ent.EmployeeID, ent.PolicyDocumentVersionID).
+		Count(&existing).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when count policy acknowledgements: ", err)
+		return nil, err
+	}
+	if existing > 0 {
+		tx.Rollback()
+		return nil, ErrPolicyAcknowledgementExists
+	}
+
+	// deleted records stay part of the chain, verification reports them as missing
+	var previous *entity.PolicyAcknowledgement
+	var last entity.PolicyAcknowledgement
+	if err := tx.Unscoped().Order("sequence desc").First(&last).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			tx.Rollback()
+			r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when get last acknowledgement: ", err)
+			return nil, err
+		}
+	} else {
+		previous = &last
+	}
+
+	seal(previous)
+	if err := tx.Create(ent).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when create policy acknowledgement: ", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when commit transaction: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.AppendPolicyAcknowledgement] Error when get policy acknowledgement: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *PolicyAcknowledgementRepository) FindAllPaginated(page, pageSize int, policyDocumentID, employeeID *uuid.UUID, sort map[string]interface{}) (*[]entity.PolicyAcknowledgement, int64, error) {
+	var policyAcknowledgements []entity.PolicyAcknowledgement
+	var total int64
+
+	db := r.DB.Model(&entity.PolicyAcknowledgement{})
+	if policyDocumentID != nil {
+		db = db.Where("policy_document_id = ?", *policyDocumentID)
+	}
+	if employeeID != nil {
+		db = db.Where("employee_id = ?", *employeeID)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.FindAllPaginated] Error when count policy acknowledgements: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Preload("PolicyDocument").Limit(pageSize).Offset((page - 1) * pageSize).Find(&policyAcknowledgements).Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.FindAllPaginated] Error when get policy acknowledgements: ", err)
+		return nil, 0, err
+	}
+
+	return &policyAcknowledgements, total, nil
+}
+
+// FindAllAfterSequence returns the next records of the chain in order, soft deleted ones
+// included so that verification sees exactly what is stored.
+func (r *PolicyAcknowledgementRepository) FindAllAfterSequence(sequence int64, limit int) (*[]entity.PolicyAcknowledgement, error) {
+	var policyAcknowledgements []entity.PolicyAcknowledgement
+	if err := r.DB.Unscoped().Where("sequence > ?", sequence).Order("sequence asc").Limit(limit).Find(&policyAcknowledgements).Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.FindAllAfterSequence] Error when get policy acknowledgements: ", err)
+		return nil, err
+	}
+
+	return &policyAcknowledgements, nil
+}
+
+// FindLatestVersionNumbersByDocumentID maps every employee who acknowledged the document to the
+// highest version they acknowledged.
+func (r *PolicyAcknowledgementRepository) FindLatestVersionNumbersByDocumentID(policyDocumentID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		EmployeeID    uuid.UUID
+		VersionNumber int
+	}
+	if err := r.DB.Model(&entity.PolicyAcknowledgement{}).
+		Select("employee_id, MAX(version_number) AS version_number").
+		Where("policy_document_id = ?", policyDocumentID).
+		Group("employee_id").
+		Scan(&rows).Error; err != nil {
+		r.Log.Error("[PolicyAcknowledgementRepository.FindLatestVersionNumbersByDocumentID] Error when get acknowledged versions: ", err)
+		return nil, err
+	}
+
+	versionNumbers := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		versionNumbers[row.EmployeeID] = row.VersionNumber
+	}
+
+	return versionNumbers, nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPolicyDocumentRepository interface {
+	CreatePolicyDocument(ent *entity.PolicyDocument, version *entity.PolicyDocumentVersion) (*entity.PolicyDocument, error)
+	UpdatePolicyDocument(ent *entity.PolicyDocument) (*entity.PolicyDocument, error)
+	AddPolicyDocumentVersion(version *entity.PolicyDocumentVersion) (*entity.PolicyDocument, error)
+	FindByID(id uuid.UUID) (*entity.PolicyDocument, error)
+	FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]entity.PolicyDocument, int64, error)
+	FindVersionByDocumentIDAndNumber(policyDocumentID uuid.UUID, versionNumber int) (*entity.PolicyDocumentVersion, error)
+}
+
+type PolicyDocumentRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewPolicyDocumentRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *PolicyDocumentRepository {
+	return &PolicyDocumentRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func PolicyDocumentRepositoryFactory(
+	log *logrus.Logger,
+) IPolicyDocumentRepository {
+	db := config.NewDatabase()
+	return NewPolicyDocumentRepository(log, db)
+}
+
+// CreatePolicyDocument creates the document together with its first version.
+func (r *PolicyDocumentRepository) CreatePolicyDocument(ent *entity.PolicyDocument, version *entity.PolicyDocumentVersion) (*entity.PolicyDocument, error) {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	ent.CurrentVersionNumber = 1
+	if err := tx.Create(ent).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyDocumentRepository.CreatePolicyDocument] Error when create policy document: ", err)
+		return nil, err
+	}
+
+	version.PolicyDocumentID = ent.ID
+	version.VersionNumber = 1
+	if err := tx.Create(version).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyDocumentRepository.CreatePolicyDocument] Error when create policy document version: ", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Error("[PolicyDocumentRepository.CreatePolicyDocument] Error when commit transaction: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+func (r *PolicyDocumentRepository) UpdatePolicyDocument(ent *entity.PolicyDocument) (*entity.PolicyDocument, error) {
+	if err := r.DB.Model(&entity.PolicyDocument{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"name":        ent.Name,
+		"description": ent.Description,
+		"status":      ent.Status,
+	}).Error; err != nil {
+		r.Log.Error("[PolicyDocumentRepository.UpdatePolicyDocument] Error when update policy document: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+// AddPolicyDocumentVersion stores the next version of the document and makes it the current
+// one. The row of the document is locked so two uploads cannot take the same number.
+func (r *PolicyDocumentRepository) AddPolicyDocumentVersion(version *entity.PolicyDocumentVersion) (*entity.PolicyDocument, error) {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var policyDocument entity.PolicyDocument
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", version.PolicyDocumentID).First(&policyDocument).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyDocumentRepository.AddPolicyDocumentVersion] Error when get policy document: ", err)
+		return nil, err
+	}
+
+	version.VersionNumber = policyDocument.CurrentVersionNumber + 1
+	if err := tx.Create(version).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyDocumentRepository.AddPolicyDocumentVersion] Error when create policy document version: ", err)
+		return nil, err
+	}
+
+	if err := tx.Model(&entity.PolicyDocument{}).Where("id = ?", policyDocument.ID).Update("current_version_number", version.VersionNumber).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[PolicyDocumentRepository.AddPolicyDocumentVersion] Error when update current version: ", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Error("[PolicyDocumentRepository.AddPolicyDocumentVersion] Error when commit transaction: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(policyDocument.ID)
+}
+
+func (r *PolicyDocumentRepository) FindByID(id uuid.UUID) (*entity.PolicyDocument, error) {
+	var policyDocument entity.PolicyDocument
+	if err := r.DB.Preload("PolicyDocumentVersions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("version_number desc")
+	}).Where("id = ?", id).First(&policyDocument).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[PolicyDocumentRepository.FindByID] Error when get policy document: ", err)
+			return nil, err
+		}
+	}
+
+	return &policyDocument, nil
+}
+
+func (r *PolicyDocumentRepository) FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]entity.PolicyDocument, int64, error) {
+	var policyDocuments []entity.PolicyDocument
+	var total int64
+
+	db := r.DB.Model(&entity.PolicyDocument{})
+	if search != "" {
+		db = db.Where("name LIKE ?", "%"+search+"%")
+	}
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[PolicyDocumentRepository.FindAllPaginated] Error when count policy documents: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Preload("PolicyDocumentVersions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("version_number desc")
+	}).Limit(pageSize).Offset((page - 1) * pageSize).Find(&policyDocuments).Error; err != nil {
+		r.Log.Error("[PolicyDocumentRepository.FindAllPaginated] Error when get policy documents: ", err)
+		return nil, 0, err
+	}
+
+	return &policyDocuments, total, nil
+}
+
+func (r *PolicyDocumentRepository) FindVersionByDocumentIDAndNumber(policyDocumentID uuid.UUID, versionNumber int) (*entity.PolicyDocumentVersion, error) {
+	var version entity.PolicyDocumentVersion
+	if err := r.DB.Where("policy_document_id = ? AND version_number = ?", policyDocumentID, versionNumber).First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[PolicyDocumentRepository.FindVersionByDocumentIDAndNumber] Error when get policy document version: ", err)
+			return nil, err
+		}
+	}
+
+	return &version, nil
+}
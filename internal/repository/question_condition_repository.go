@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IQuestionConditionRepository interface {
+	CreateQuestionCondition(ent *entity.QuestionCondition) (*entity.QuestionCondition, error)
+	DeleteQuestionConditionsByQuestionID(questionID uuid.UUID) error
+}
+
+type QuestionConditionRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewQuestionConditionRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *QuestionConditionRepository {
+	return &QuestionConditionRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func QuestionConditionRepositoryFactory(
+	log *logrus.Logger,
+) IQuestionConditionRepository {
+	db := config.NewDatabase()
+	return NewQuestionConditionRepository(log, db)
+}
+
+func (r *QuestionConditionRepository) CreateQuestionCondition(ent *entity.QuestionCondition) (*entity.QuestionCondition, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Errorf("[QuestionConditionRepository.CreateQuestionCondition] error when creating question condition: %v", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *QuestionConditionRepository) DeleteQuestionConditionsByQuestionID(questionID uuid.UUID) error {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("question_id = ?", questionID).Delete(&entity.QuestionCondition{}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Errorf("[QuestionConditionRepository.DeleteQuestionConditionsByQuestionID] error when deleting question conditions: %v", err)
+		return errors.New("[QuestionConditionRepository.DeleteQuestionConditionsByQuestionID] error when deleting question conditions")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Errorf("[QuestionConditionRepository.DeleteQuestionConditionsByQuestionID] error when committing transaction: %v", err)
+		return errors.New("[QuestionConditionRepository.DeleteQuestionConditionsByQuestionID] error when committing transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IQuestionMatrixRowRepository interface {
+	CreateQuestionMatrixRow(ent *entity.QuestionMatrixRow) (*entity.QuestionMatrixRow, error)
+	DeleteQuestionMatrixRowsByQuestionID(questionID uuid.UUID) error
+}
+
+type QuestionMatrixRowRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewQuestionMatrixRowRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *QuestionMatrixRowRepository {
+	return &QuestionMatrixRowRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func QuestionMatrixRowRepositoryFactory(
+	log *logrus.Logger,
+) IQuestionMatrixRowRepository {
+	db := config.NewDatabase()
+	return NewQuestionMatrixRowRepository(log, db)
+}
+
+func (r *QuestionMatrixRowRepository) CreateQuestionMatrixRow(ent *entity.QuestionMatrixRow) (*entity.QuestionMatrixRow, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Errorf("[QuestionMatrixRowRepository.CreateQuestionMatrixRow] error when creating question matrix row: %v", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *QuestionMatrixRowRepository) DeleteQuestionMatrixRowsByQuestionID(questionID uuid.UUID) error {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("question_id = ?", questionID).Delete(&entity.QuestionMatrixRow{}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Errorf("[QuestionMatrixRowRepository.DeleteQuestionMatrixRowsByQuestionID] error when deleting question matrix rows: %v", err)
+		return errors.New("[QuestionMatrixRowRepository.DeleteQuestionMatrixRowsByQuestionID] error when deleting question matrix rows")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Errorf("[QuestionMatrixRowRepository.DeleteQuestionMatrixRowsByQuestionID] error when committing transaction: %v", err)
+		return errors.New("[QuestionMatrixRowRepository.DeleteQuestionMatrixRowsByQuestionID] error when committing transaction")
+	}
+
+	return nil
+}
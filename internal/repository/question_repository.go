@@ -13,6 +13,7 @@ import (
 type IQuestionRepository interface {
 	CreateQuestion(ent *entity.Question) (*entity.Question, error)
 	UpdateQuestion(ent *entity.Question) (*entity.Question, error)
+	UpdateQuestionRules(ent *entity.Question) error
 	DeleteQuestion(id uuid.UUID) error
 	FindByID(id uuid.UUID) (*entity.Question, error)
 	FindQuestionWithResponsesByIDAndUserProfileID(questionID, userProfileID uuid.UUID) (*entity.Question, error)
@@ -90,6 +91,31 @@ func (r *QuestionRepository) UpdateQuestion(ent *entity.Question) (*entity.Quest
 	return ent, nil
 }
 
+// UpdateQuestionRules writes the answer rules and the condition match as given, so that a rule
+// can be removed again.
+func (r *QuestionRepository) UpdateQuestionRules(ent *entity.Question) error {
+	if err := r.DB.Model(&entity.Question{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"is_required":        ent.IsRequired,
+		"min_length":         ent.MinLength,
+		"max_length":         ent.MaxLength,
+		"pattern":            ent.Pattern,
+		"allowed_file_types": ent.AllowedFileTypes,
+		"max_file_size_kb":   ent.MaxFileSizeKB,
+		"min_selections":     ent.MinSelections,
+		"max_selections":     ent.MaxSelections,
+		"min_value":          ent.MinValue,
+		"max_value":          ent.MaxValue,
+		"min_date":           ent.MinDate,
+		"max_date":           ent.MaxDate,
+		"condition_match":    ent.ConditionMatch,
+	}).Error; err != nil {
+		r.Log.Error("[QuestionRepository.UpdateQuestionRules] Error when update question rules: ", err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *QuestionRepository) DeleteQuestion(id uuid.UUID) error {
 	tx := r.DB.Begin()
 	if tx.Error != nil {
@@ -122,6 +148,9 @@ func (r *QuestionRepository) FindByID(id uuid.UUID) (*entity.Question, error) {
 	if err := r.DB.
 		Where("id = ?", id).
 		Preload("QuestionOptions").Preload("AnswerType").
+		Preload("MatrixRows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number asc")
+		}).
 		First(&q).
 		Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
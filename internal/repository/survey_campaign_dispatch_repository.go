@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyCampaignDispatchRepository interface {
+	CreateSurveyCampaignDispatch(ent *entity.SurveyCampaignDispatch) (*entity.SurveyCampaignDispatch, error)
+	UpdateSurveyCampaignDispatchStatus(ent *entity.SurveyCampaignDispatch) error
+	UpdateRemindedAtByID(id uuid.UUID, remindedAt time.Time) error
+	FindAllBySurveyCampaignID(surveyCampaignID uuid.UUID) (*[]entity.SurveyCampaignDispatch, error)
+	FindAllSent() (*[]entity.SurveyCampaignDispatch, error)
+	FindAllPaginatedBySurveyCampaignID(surveyCampaignID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]entity.SurveyCampaignDispatch, int64, error)
+}
+
+type SurveyCampaignDispatchRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyCampaignDispatchRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyCampaignDispatchRepository {
+	return &SurveyCampaignDispatchRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyCampaignDispatchRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyCampaignDispatchRepository {
+	db := config.NewDatabase()
+	return NewSurveyCampaignDispatchRepository(log, db)
+}
+
+func (r *SurveyCampaignDispatchRepository) CreateSurveyCampaignDispatch(ent *entity.SurveyCampaignDispatch) (*entity.SurveyCampaignDispatch, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.CreateSurveyCampaignDispatch] Error when create survey campaign dispatch: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// UpdateSurveyCampaignDispatchStatus writes the status, message and closing time as given.
+func (r *SurveyCampaignDispatchRepository) UpdateSurveyCampaignDispatchStatus(ent *entity.SurveyCampaignDispatch) error {
+	if err := r.DB.Model(&entity.SurveyCampaignDispatch{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"status":    ent.Status,
+		"message":   ent.Message,
+		"closed_at": ent.ClosedAt,
+	}).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.UpdateSurveyCampaignDispatchStatus] Error when update survey campaign dispatch status: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyCampaignDispatchRepository) UpdateRemindedAtByID(id uuid.UUID, remindedAt time.Time) error {
+	if err := r.DB.Model(&entity.SurveyCampaignDispatch{}).Where("id = ?", id).Update("reminded_at", remindedAt).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.UpdateRemindedAtByID] Error when update survey campaign dispatch reminded at: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyCampaignDispatchRepository) FindAllBySurveyCampaignID(surveyCampaignID uuid.UUID) (*[]entity.SurveyCampaignDispatch, error) {
+	var dispatches []entity.SurveyCampaignDispatch
+	if err := r.DB.Where("survey_campaign_id = ?", surveyCampaignID).Find(&dispatches).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.FindAllBySurveyCampaignID] Error when get survey campaign dispatches: ", err)
+		return nil, err
+	}
+
+	return &dispatches, nil
+}
+
+// FindAllSent returns the surveys that are sent and still open, with their task and campaign.
+// The campaign is loaded even when deleted, so that its surveys still close.
+func (r *SurveyCampaignDispatchRepository) FindAllSent() (*[]entity.SurveyCampaignDispatch, error) {
+	var dispatches []entity.SurveyCampaignDispatch
+	if err := r.DB.Preload("EmployeeTask").Preload("SurveyCampaign", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("status = ?", entity.SURVEY_CAMPAIGN_DISPATCH_STATUS_ENUM_SENT).
+		Order("scheduled_on asc").Find(&dispatches).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.FindAllSent] Error when get sent survey campaign dispatches: ", err)
+		return nil, err
+	}
+
+	return &dispatches, nil
+}
+
+func (r *SurveyCampaignDispatchRepository) FindAllPaginatedBySurveyCampaignID(surveyCampaignID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]entity.SurveyCampaignDispatch, int64, error) {
+	var dispatches []entity.SurveyCampaignDispatch
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyCampaignDispatch{}).Where("survey_campaign_id = ?", surveyCampaignID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.FindAllPaginatedBySurveyCampaignID] Error when count survey campaign dispatches: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&dispatches).Error; err != nil {
+		r.Log.Error("[SurveyCampaignDispatchRepository.FindAllPaginatedBySurveyCampaignID] Error when get survey campaign dispatches: ", err)
+		return nil, 0, err
+	}
+
+	return &dispatches, total, nil
+}
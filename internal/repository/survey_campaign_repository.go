@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyCampaignRepository interface {
+	CreateSurveyCampaign(ent *entity.SurveyCampaign) (*entity.SurveyCampaign, error)
+	UpdateSurveyCampaign(ent *entity.SurveyCampaign) (*entity.SurveyCampaign, error)
+	DeleteSurveyCampaign(ent *entity.SurveyCampaign) error
+	FindByID(id uuid.UUID) (*entity.SurveyCampaign, error)
+	FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]entity.SurveyCampaign, int64, error)
+	FindAllActive() (*[]entity.SurveyCampaign, error)
+}
+
+type SurveyCampaignRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyCampaignRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyCampaignRepository {
+	return &SurveyCampaignRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyCampaignRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyCampaignRepository {
+	db := config.NewDatabase()
+	return NewSurveyCampaignRepository(log, db)
+}
+
+// CreateSurveyCampaign creates the campaign together with its offsets and rules.
+func (r *SurveyCampaignRepository) CreateSurveyCampaign(ent *entity.SurveyCampaign) (*entity.SurveyCampaign, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.CreateSurveyCampaign] Error when create survey campaign: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+// UpdateSurveyCampaign writes the fields of the campaign as given and replaces its offsets and
+// rules. The surveys already sent are not changed.
+func (r *SurveyCampaignRepository) UpdateSurveyCampaign(ent *entity.SurveyCampaign) (*entity.SurveyCampaign, error) {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if err := tx.Model(&entity.SurveyCampaign{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"name":               ent.Name,
+		"survey_template_id": ent.SurveyTemplateID,
+		"status":             ent.Status,
+		"start_date":         ent.StartDate,
+		"response_days":      ent.ResponseDays,
+		"reminder_days":      ent.ReminderDays,
+	}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when update survey campaign: ", err)
+		return nil, err
+	}
+
+	if err := tx.Where("survey_campaign_id = ?", ent.ID).Delete(&entity.SurveyCampaignOffset{}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when delete survey campaign offsets: ", err)
+		return nil, err
+	}
+	for i := range ent.SurveyCampaignOffsets {
+		ent.SurveyCampaignOffsets[i].SurveyCampaignID = ent.ID
+		if err := tx.Create(&ent.SurveyCampaignOffsets[i]).Error; err != nil {
+			tx.Rollback()
+			r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when create survey campaign offset: ", err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Where("survey_campaign_id = ?", ent.ID).Delete(&entity.SurveyCampaignRule{}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when delete survey campaign rules: ", err)
+		return nil, err
+	}
+	for i := range ent.SurveyCampaignRules {
+		ent.SurveyCampaignRules[i].SurveyCampaignID = ent.ID
+		if err := tx.Create(&ent.SurveyCampaignRules[i]).Error; err != nil {
+			tx.Rollback()
+			r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when create survey campaign rule: ", err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.UpdateSurveyCampaign] Error when commit transaction: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+func (r *SurveyCampaignRepository) DeleteSurveyCampaign(ent *entity.SurveyCampaign) error {
+	if err := r.DB.Delete(ent).Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.DeleteSurveyCampaign] Error when delete survey campaign: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyCampaignRepository) FindByID(id uuid.UUID) (*entity.SurveyCampaign, error) {
+	var surveyCampaign entity.SurveyCampaign
+	if err := r.DB.Preload("SurveyTemplate").Preload("SurveyCampaignOffsets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("offset_days asc")
+	}).Preload("SurveyCampaignRules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("group_number asc")
+	}).Where("id = ?", id).First(&surveyCampaign).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[SurveyCampaignRepository.FindByID] Error when get survey campaign: ", err)
+			return nil, err
+		}
+	}
+
+	return &surveyCampaign, nil
+}
+
+func (r *SurveyCampaignRepository) FindAllPaginated(page, pageSize int, search string, status string, sort map[string]interface{}) (*[]entity.SurveyCampaign, int64, error) {
+	var surveyCampaigns []entity.SurveyCampaign
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyCampaign{}).Where("name ILIKE ?", "%"+search+"%")
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.FindAllPaginated] Error when count survey campaigns: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Preload("SurveyTemplate").Preload("SurveyCampaignOffsets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("offset_days asc")
+	}).Preload("SurveyCampaignRules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("group_number asc")
+	}).Limit(pageSize).Offset((page - 1) * pageSize).Find(&surveyCampaigns).Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.FindAllPaginated] Error when get survey campaigns: ", err)
+		return nil, 0, err
+	}
+
+	return &surveyCampaigns, total, nil
+}
+
+// FindAllActive returns the active campaigns with their offsets and rules, for the scheduler.
+func (r *SurveyCampaignRepository) FindAllActive() (*[]entity.SurveyCampaign, error) {
+	var surveyCampaigns []entity.SurveyCampaign
+	if err := r.DB.Preload("SurveyCampaignOffsets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("offset_days asc")
+	}).Preload("SurveyCampaignRules").Where("status = ?", entity.SURVEY_CAMPAIGN_STATUS_ENUM_ACTIVE).
+		Order("created_at asc").Find(&surveyCampaigns).Error; err != nil {
+		r.Log.Error("[SurveyCampaignRepository.FindAllActive] Error when get active survey campaigns: ", err)
+		return nil, err
+	}
+
+	return &surveyCampaigns, nil
+}
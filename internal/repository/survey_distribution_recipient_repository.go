@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyDistributionRecipientRepository interface {
+	FindBySurveyDistributionIDAndEmployeeID(surveyDistributionID, employeeID uuid.UUID) (*entity.SurveyDistributionRecipient, error)
+	FindAllBySurveyDistributionID(surveyDistributionID uuid.UUID) (*[]entity.SurveyDistributionRecipient, error)
+	FindAllPaginatedBySurveyDistributionID(surveyDistributionID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]entity.SurveyDistributionRecipient, int64, error)
+	FindAllByEmployeeID(employeeID uuid.UUID) (*[]entity.SurveyDistributionRecipient, error)
+	CountByStatusAndSurveyDistributionIDs(surveyDistributionIDs []uuid.UUID) (map[uuid.UUID]map[entity.SurveyDistributionRecipientStatusEnum]int, error)
+	MarkSubmittedByID(id uuid.UUID, submittedAt time.Time) error
+}
+
+type SurveyDistributionRecipientRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyDistributionRecipientRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyDistributionRecipientRepository {
+	return &SurveyDistributionRecipientRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyDistributionRecipientRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyDistributionRecipientRepository {
+	db := config.NewDatabase()
+	return NewSurveyDistributionRecipientRepository(log, db)
+}
+
+func (r *SurveyDistributionRecipientRepository) FindBySurveyDistributionIDAndEmployeeID(surveyDistributionID, employeeID uuid.UUID) (*entity.SurveyDistributionRecipient, error) {
+	var recipient entity.SurveyDistributionRecipient
+	if err := r.DB.Where("survey_distribution_id = ? AND employee_id = ?", surveyDistributionID, employeeID).First(&recipient).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[SurveyDistributionRecipientRepository.FindBySurveyDistributionIDAndEmployeeID] Error when get survey distribution recipient: ", err)
+			return nil, err
+		}
+	}
+
+	return &recipient, nil
+}
+
+func (r *SurveyDistributionRecipientRepository) FindAllBySurveyDistributionID(surveyDistributionID uuid.UUID) (*[]entity.SurveyDistributionRecipient, error) {
+	var recipients []entity.SurveyDistributionRecipient
+	if err := r.DB.Where("survey_distribution_id = ?", surveyDistributionID).Order("created_at asc").Find(&recipients).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.FindAllBySurveyDistributionID] Error when get survey distribution recipients: ", err)
+		return nil, err
+	}
+
+	return &recipients, nil
+}
+
+func (r *SurveyDistributionRecipientRepository) FindAllPaginatedBySurveyDistributionID(surveyDistributionID uuid.UUID, page, pageSize int, status string, sort map[string]interface{}) (*[]entity.SurveyDistributionRecipient, int64, error) {
+	var recipients []entity.SurveyDistributionRecipient
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyDistributionRecipient{}).Where("survey_distribution_id = ?", surveyDistributionID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.FindAllPaginatedBySurveyDistributionID] Error when count survey distribution recipients: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&recipients).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.FindAllPaginatedBySurveyDistributionID] Error when get survey distribution recipients: ", err)
+		return nil, 0, err
+	}
+
+	return &recipients, total, nil
+}
+
+// FindAllByEmployeeID returns the distributions sent to the employee, newest first, with the
+// distribution and its survey template.
+func (r *SurveyDistributionRecipientRepository) FindAllByEmployeeID(employeeID uuid.UUID) (*[]entity.SurveyDistributionRecipient, error) {
+	var recipients []entity.SurveyDistributionRecipient
+	if err := r.DB.Joins("SurveyDistribution").Preload("SurveyDistribution.SurveyTemplate").
+		Where("survey_distribution_recipients.employee_id = ?", employeeID).
+		Order("survey_distribution_recipients.created_at desc").Find(&recipients).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.FindAllByEmployeeID] Error when get survey distribution recipients: ", err)
+		return nil, err
+	}
+
+	return &recipients, nil
+}
+
+// CountByStatusAndSurveyDistributionIDs counts the recipients of every distribution per status.
+func (r *SurveyDistributionRecipientRepository) CountByStatusAndSurveyDistributionIDs(surveyDistributionIDs []uuid.UUID) (map[uuid.UUID]map[entity.SurveyDistributionRecipientStatusEnum]int, error) {
+	counts := make(map[uuid.UUID]map[entity.SurveyDistributionRecipientStatusEnum]int)
+	if len(surveyDistributionIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		SurveyDistributionID uuid.UUID
+		Status               entity.SurveyDistributionRecipientStatusEnum
+		Total                int
+	}
+	if err := r.DB.Model(&entity.SurveyDistributionRecipient{}).Select("survey_distribution_id, status, count(*) as total").
+		Where("survey_distribution_id IN ?", surveyDistributionIDs).Group("survey_distribution_id, status").
+		Scan(&rows).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.CountByStatusAndSurveyDistributionIDs] Error when count survey distribution recipients: ", err)
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if counts[row.SurveyDistributionID] == nil {
+			counts[row.SurveyDistributionID] = make(map[entity.SurveyDistributionRecipientStatusEnum]int)
+		}
+		counts[row.SurveyDistributionID][row.Status] = row.Total
+	}
+
+	return counts, nil
+}
+
+func (r *SurveyDistributionRecipientRepository) MarkSubmittedByID(id uuid.UUID, submittedAt time.Time) error {
+	if err := r.DB.Model(&entity.SurveyDistributionRecipient{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       entity.SURVEY_DISTRIBUTION_RECIPIENT_STATUS_ENUM_SUBMITTED,
+		"submitted_at": submittedAt,
+	}).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRecipientRepository.MarkSubmittedByID] Error when update survey distribution recipient: ", err)
+		return err
+	}
+
+	return nil
+}
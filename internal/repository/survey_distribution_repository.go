@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyDistributionRepository interface {
+	CreateSurveyDistribution(ent *entity.SurveyDistribution) (*entity.SurveyDistribution, error)
+	UpdateSurveyDistribution(ent *entity.SurveyDistribution) (*entity.SurveyDistribution, error)
+	DeleteSurveyDistribution(ent *entity.SurveyDistribution) error
+	CloseByID(id uuid.UUID, closedAt time.Time) error
+	FindByID(id uuid.UUID) (*entity.SurveyDistribution, error)
+	FindAllPaginated(page, pageSize int, search string, status string, today time.Time, sort map[string]interface{}) (*[]entity.SurveyDistribution, int64, error)
+}
+
+type SurveyDistributionRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyDistributionRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyDistributionRepository {
+	return &SurveyDistributionRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyDistributionRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyDistributionRepository {
+	db := config.NewDatabase()
+	return NewSurveyDistributionRepository(log, db)
+}
+
+// CreateSurveyDistribution creates the distribution together with its rules and recipients.
+func (r *SurveyDistributionRepository) CreateSurveyDistribution(ent *entity.SurveyDistribution) (*entity.SurveyDistribution, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.CreateSurveyDistribution] Error when create survey distribution: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+// UpdateSurveyDistribution writes the name and dates as given, replaces the rules and adds the
+// recipients that are new. Recipients are never removed, their answers stay with them.
+func (r *SurveyDistributionRepository) UpdateSurveyDistribution(ent *entity.SurveyDistribution) (*entity.SurveyDistribution, error) {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	if err := tx.Model(&entity.SurveyDistribution{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"name":      ent.Name,
+		"opens_on":  ent.OpensOn,
+		"closes_on": ent.ClosesOn,
+	}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[SurveyDistributionRepository.UpdateSurveyDistribution] Error when update survey distribution: ", err)
+		return nil, err
+	}
+
+	if err := tx.Where("survey_distribution_id = ?", ent.ID).Delete(&entity.SurveyDistributionRule{}).Error; err != nil {
+		tx.Rollback()
+		r.Log.Error("[SurveyDistributionRepository.UpdateSurveyDistribution] Error when delete survey distribution rules: ", err)
+		return nil, err
+	}
+	for i := range ent.SurveyDistributionRules {
+		ent.SurveyDistributionRules[i].SurveyDistributionID = ent.ID
+		if err := tx.Create(&ent.SurveyDistributionRules[i]).Error; err != nil {
+			tx.Rollback()
+			r.Log.Error("[SurveyDistributionRepository.UpdateSurveyDistribution] Error when create survey distribution rule: ", err)
+			return nil, err
+		}
+	}
+
+	for i := range ent.SurveyDistributionRecipients {
+		ent.SurveyDistributionRecipients[i].SurveyDistributionID = ent.ID
+		if err := tx.Create(&ent.SurveyDistributionRecipients[i]).Error; err != nil {
+			tx.Rollback()
+			r.Log.Error("[SurveyDistributionRepository.UpdateSurveyDistribution] Error when create survey distribution recipient: ", err)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.UpdateSurveyDistribution] Error when commit transaction: ", err)
+		return nil, err
+	}
+
+	return r.FindByID(ent.ID)
+}
+
+func (r *SurveyDistributionRepository) DeleteSurveyDistribution(ent *entity.SurveyDistribution) error {
+	if err := r.DB.Delete(ent).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.DeleteSurveyDistribution] Error when delete survey distribution: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyDistributionRepository) CloseByID(id uuid.UUID, closedAt time.Time) error {
+	if err := r.DB.Model(&entity.SurveyDistribution{}).Where("id = ?", id).Update("closed_at", closedAt).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.CloseByID] Error when close survey distribution: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyDistributionRepository) FindByID(id uuid.UUID) (*entity.SurveyDistribution, error) {
+	var surveyDistribution entity.SurveyDistribution
+	if err := r.DB.Preload("SurveyTemplate").Preload("SurveyDistributionRules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("group_number asc")
+	}).Where("id = ?", id).First(&surveyDistribution).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[SurveyDistributionRepository.FindByID] Error when get survey distribution: ", err)
+			return nil, err
+		}
+	}
+
+	return &surveyDistribution, nil
+}
+
+// FindAllPaginated filters by the status the distributions have on the day of today.
+func (r *SurveyDistributionRepository) FindAllPaginated(page, pageSize int, search string, status string, today time.Time, sort map[string]interface{}) (*[]entity.SurveyDistribution, int64, error) {
+	var surveyDistributions []entity.SurveyDistribution
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyDistribution{}).Where("name ILIKE ?", "%"+search+"%")
+	day := today.Format("2006-01-02")
+	switch entity.SurveyDistributionStatusEnum(status) {
+	case entity.SURVEY_DISTRIBUTION_STATUS_ENUM_SCHEDULED:
+		db = db.Where("closed_at IS NULL AND opens_on > ? AND (closes_on IS NULL OR closes_on >= ?)", day, day)
+	case entity.SURVEY_DISTRIBUTION_STATUS_ENUM_OPEN:
+		db = db.Where("closed_at IS NULL AND opens_on <= ? AND (closes_on IS NULL OR closes_on >= ?)", day, day)
+	case entity.SURVEY_DISTRIBUTION_STATUS_ENUM_CLOSED:
+		db = db.Where("closed_at IS NOT NULL OR closes_on < ?", day)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.FindAllPaginated] Error when count survey distributions: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Preload("SurveyTemplate").Preload("SurveyDistributionRules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("group_number asc")
+	}).Limit(pageSize).Offset((page - 1) * pageSize).Find(&surveyDistributions).Error; err != nil {
+		r.Log.Error("[SurveyDistributionRepository.FindAllPaginated] Error when get survey distributions: ", err)
+		return nil, 0, err
+	}
+
+	return &surveyDistributions, total, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyExportJobRepository interface {
+	CreateSurveyExportJob(ent *entity.SurveyExportJob) (*entity.SurveyExportJob, error)
+	UpdateSurveyExportJobProgress(ent *entity.SurveyExportJob) error
+	FindByID(id uuid.UUID) (*entity.SurveyExportJob, error)
+	FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.SurveyExportJob, int64, error)
+}
+
+type SurveyExportJobRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyExportJobRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyExportJobRepository {
+	return &SurveyExportJobRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyExportJobRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyExportJobRepository {
+	db := config.NewDatabase()
+	return NewSurveyExportJobRepository(log, db)
+}
+
+func (r *SurveyExportJobRepository) CreateSurveyExportJob(ent *entity.SurveyExportJob) (*entity.SurveyExportJob, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[SurveyExportJobRepository.CreateSurveyExportJob] Error when create survey export job: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[SurveyExportJobRepository.CreateSurveyExportJob] Error when get survey export job: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// UpdateSurveyExportJobProgress stores the status, counters, file and timestamps of a job.
+// The columns are selected explicitly so that zero counters are written too.
+func (r *SurveyExportJobRepository) UpdateSurveyExportJobProgress(ent *entity.SurveyExportJob) error {
+	if err := r.DB.Model(&entity.SurveyExportJob{}).Where("id = ?", ent.ID).
+		Select("status", "total", "processed", "rows", "path", "message", "started_at", "finished_at").
+		Updates(ent).Error; err != nil {
+		r.Log.Error("[SurveyExportJobRepository.UpdateSurveyExportJobProgress] Error when update survey export job: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyExportJobRepository) FindByID(id uuid.UUID) (*entity.SurveyExportJob, error) {
+	var surveyExportJob entity.SurveyExportJob
+	if err := r.DB.Where("id = ?", id).First(&surveyExportJob).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.Log.Error("[SurveyExportJobRepository.FindByID] Error when get survey export job: ", err)
+		return nil, err
+	}
+
+	return &surveyExportJob, nil
+}
+
+func (r *SurveyExportJobRepository) FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.SurveyExportJob, int64, error) {
+	var surveyExportJobs []entity.SurveyExportJob
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyExportJob{})
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyExportJobRepository.FindAllPaginated] Error when count survey export jobs: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&surveyExportJobs).Error; err != nil {
+		r.Log.Error("[SurveyExportJobRepository.FindAllPaginated] Error when get survey export jobs: ", err)
+		return nil, 0, err
+	}
+
+	return &surveyExportJobs, total, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ISurveyQuizAttemptRepository interface {
+	CreateSurveyQuizAttempt(ent *entity.SurveyQuizAttempt) (*entity.SurveyQuizAttempt, error)
+	CountByEmployeeTaskID(employeeTaskID uuid.UUID) (int64, error)
+	FindLatestByEmployeeTaskID(employeeTaskID uuid.UUID) (*entity.SurveyQuizAttempt, error)
+	FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.SurveyQuizAttempt, error)
+	FindAllPaginatedBySurveyTemplateID(page, pageSize int, surveyTemplateID uuid.UUID, passed *bool, sort map[string]interface{}) (*[]entity.SurveyQuizAttempt, int64, error)
+}
+
+type SurveyQuizAttemptRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewSurveyQuizAttemptRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *SurveyQuizAttemptRepository {
+	return &SurveyQuizAttemptRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func SurveyQuizAttemptRepositoryFactory(
+	log *logrus.Logger,
+) ISurveyQuizAttemptRepository {
+	db := config.NewDatabase()
+	return NewSurveyQuizAttemptRepository(log, db)
+}
+
+// CreateSurveyQuizAttempt stores the attempt together with its answers.
+func (r *SurveyQuizAttemptRepository) CreateSurveyQuizAttempt(ent *entity.SurveyQuizAttempt) (*entity.SurveyQuizAttempt, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.CreateSurveyQuizAttempt] Error when create survey quiz attempt: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.Preload("Answers").First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.CreateSurveyQuizAttempt] Error when get survey quiz attempt: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *SurveyQuizAttemptRepository) CountByEmployeeTaskID(employeeTaskID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.DB.Model(&entity.SurveyQuizAttempt{}).Where("employee_task_id = ?", employeeTaskID).Count(&count).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.CountByEmployeeTaskID] Error when count survey quiz attempts: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *SurveyQuizAttemptRepository) FindLatestByEmployeeTaskID(employeeTaskID uuid.UUID) (*entity.SurveyQuizAttempt, error) {
+	var attempt entity.SurveyQuizAttempt
+	if err := r.DB.Where("employee_task_id = ?", employeeTaskID).Order("attempt_number desc").First(&attempt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[SurveyQuizAttemptRepository.FindLatestByEmployeeTaskID] Error when get survey quiz attempt: ", err)
+			return nil, err
+		}
+	}
+
+	return &attempt, nil
+}
+
+func (r *SurveyQuizAttemptRepository) FindAllByEmployeeTaskID(employeeTaskID uuid.UUID) (*[]entity.SurveyQuizAttempt, error) {
+	var attempts []entity.SurveyQuizAttempt
+	if err := r.DB.Preload("Answers.Question").Where("employee_task_id = ?", employeeTaskID).Order("attempt_number asc").Find(&attempts).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.FindAllByEmployeeTaskID] Error when get survey quiz attempts: ", err)
+		return nil, err
+	}
+
+	return &attempts, nil
+}
+
+func (r *SurveyQuizAttemptRepository) FindAllPaginatedBySurveyTemplateID(page, pageSize int, surveyTemplateID uuid.UUID, passed *bool, sort map[string]interface{}) (*[]entity.SurveyQuizAttempt, int64, error) {
+	var attempts []entity.SurveyQuizAttempt
+	var total int64
+
+	db := r.DB.Model(&entity.SurveyQuizAttempt{}).Where("survey_template_id = ?", surveyTemplateID)
+	if passed != nil {
+		db = db.Where("passed = ?", *passed)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.FindAllPaginatedBySurveyTemplateID] Error when count survey quiz attempts: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Preload("Answers.Question").Limit(pageSize).Offset((page - 1) * pageSize).Find(&attempts).Error; err != nil {
+		r.Log.Error("[SurveyQuizAttemptRepository.FindAllPaginatedBySurveyTemplateID] Error when get survey quiz attempts: ", err)
+		return nil, 0, err
+	}
+
+	return &attempts, total, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/google/uuid"
@@ -19,6 +21,10 @@ type ISurveyResponseRepository interface {
 	DeleteByQuestionIDs(questionIDs []uuid.UUID) error
 	DeleteNotInIDsAndQuestionID(questionID uuid.UUID, ids []uuid.UUID) error
 	DeleteNotInIDsAndKeys(keys map[string]interface{}, ids []uuid.UUID) error
+	FindAllByEmployeeTaskIDs(employeeTaskIDs []uuid.UUID) ([]entity.SurveyResponse, error)
+	FindAllBySurveyTemplateIDAndEmployeeTaskIDs(surveyTemplateID uuid.UUID, employeeTaskIDs []uuid.UUID, submittedFrom, submittedTo *time.Time) ([]entity.SurveyResponse, error)
+	FindAllBySurveyTemplateIDAndRecipientIDs(surveyTemplateID uuid.UUID, recipientIDs []uuid.UUID) ([]entity.SurveyResponse, error)
+	CountBySurveyTemplateID(surveyTemplateID uuid.UUID) (int64, error)
 }
 
 type SurveyResponseRepository struct {
@@ -150,3 +156,67 @@ func (r *SurveyResponseRepository) DeleteNotInIDsAndKeys(keys map[string]interfa
 
 	return nil
 }
+
+// FindAllBySurveyTemplateIDAndEmployeeTaskIDs returns the responses of the employee tasks to a
+// survey template last saved within the submitted range. Either bound may be nil.
+func (r *SurveyResponseRepository) FindAllBySurveyTemplateIDAndEmployeeTaskIDs(surveyTemplateID uuid.UUID, employeeTaskIDs []uuid.UUID, submittedFrom, submittedTo *time.Time) ([]entity.SurveyResponse, error) {
+	var surveyResponses []entity.SurveyResponse
+	if len(employeeTaskIDs) == 0 {
+		return surveyResponses, nil
+	}
+
+	db := r.DB.Where("survey_template_id = ?", surveyTemplateID).Where("employee_task_id IN ?", employeeTaskIDs)
+	if submittedFrom != nil {
+		db = db.Where("updated_at >= ?", *submittedFrom)
+	}
+	if submittedTo != nil {
+		db = db.Where("updated_at < ?", *submittedTo)
+	}
+
+	if err := db.Find(&surveyResponses).Error; err != nil {
+		r.Log.Error("[SurveyResponseRepository.FindAllBySurveyTemplateIDAndEmployeeTaskIDs] Error when get survey responses: ", err)
+		return nil, err
+	}
+
+	return surveyResponses, nil
+}
+
+// FindAllBySurveyTemplateIDAndRecipientIDs returns the responses of the survey distribution
+// recipients to a survey template.
+func (r *SurveyResponseRepository) FindAllBySurveyTemplateIDAndRecipientIDs(surveyTemplateID uuid.UUID, recipientIDs []uuid.UUID) ([]entity.SurveyResponse, error) {
+	var surveyResponses []entity.SurveyResponse
+	if len(recipientIDs) == 0 {
+		return surveyResponses, nil
+	}
+
+	if err := r.DB.Where("survey_template_id = ?", surveyTemplateID).Where("survey_distribution_recipient_id IN ?", recipientIDs).Find(&surveyResponses).Error; err != nil {
+		r.Log.Error("[SurveyResponseRepository.FindAllBySurveyTemplateIDAndRecipientIDs] Error when get survey responses: ", err)
+		return nil, err
+	}
+
+	return surveyResponses, nil
+}
+
+func (r *SurveyResponseRepository) FindAllByEmployeeTaskIDs(employeeTaskIDs []uuid.UUID) ([]entity.SurveyResponse, error) {
+	var surveyResponses []entity.SurveyResponse
+	if len(employeeTaskIDs) == 0 {
+		return surveyResponses, nil
+	}
+
+	if err := r.DB.Where("employee_task_id IN ?", employeeTaskIDs).Order("created_at asc").Find(&surveyResponses).Error; err != nil {
+		r.Log.Error("[SurveyResponseRepository.FindAllByEmployeeTaskIDs] Error when get survey responses: ", err)
+		return nil, err
+	}
+
+	return surveyResponses, nil
+}
+
+func (r *SurveyResponseRepository) CountBySurveyTemplateID(surveyTemplateID uuid.UUID) (int64, error) {
+	var total int64
+	if err := r.DB.Model(&entity.SurveyResponse{}).Where("survey_template_id = ?", surveyTemplateID).Count(&total).Error; err != nil {
+		r.Log.Error("[SurveyResponseRepository.CountBySurveyTemplateID] Error when count survey responses: ", err)
+		return 0, err
+	}
+
+	return total, nil
+}
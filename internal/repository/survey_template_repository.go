@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
 	"github.com/google/uuid"
@@ -16,6 +18,13 @@ type ISurveyTemplateRepository interface {
 	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}) (*[]entity.SurveyTemplate, int64, error)
 	FindLatestSurveyNumber() (*entity.SurveyTemplate, error)
 	FindByIDForResponse(id, employeeTaskID uuid.UUID) (*entity.SurveyTemplate, error)
+	FindByIDForDistributionResponse(id, recipientID uuid.UUID) (*entity.SurveyTemplate, error)
+	UpdateQuizSettings(ent *entity.SurveyTemplate) error
+	UpdateAnonymitySettings(ent *entity.SurveyTemplate) error
+	FindAllWithQuestions() (*[]entity.SurveyTemplate, error)
+	FindAllVersions(surveyNumber string) (*[]entity.SurveyTemplate, error)
+	FindLatestPublishedVersion(id uuid.UUID) (*entity.SurveyTemplate, error)
+	PublishSurveyTemplate(id uuid.UUID, publishedAt time.Time) error
 }
 
 type SurveyTemplateRepository struct {
@@ -79,7 +88,10 @@ func (r *SurveyTemplateRepository) DeleteSurveyTemplate(ent *entity.SurveyTempla
 
 func (r *SurveyTemplateRepository) FindByKeys(keys map[string]interface{}) (*entity.SurveyTemplate, error) {
 	var ent entity.SurveyTemplate
-	if err := r.DB.Preload("Questions.QuestionOptions").Preload("Questions.AnswerType").Where(keys).First(&ent).Error; err != nil {
+	if err := r.DB.Preload("Questions.QuestionOptions").Preload("Questions.AnswerType").Preload("Questions.DisplayConditions.SourceQuestion").
+		Preload("Questions.MatrixRows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number asc")
+		}).Where(keys).First(&ent).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -94,7 +106,12 @@ func (r *SurveyTemplateRepository) FindAllPaginated(page, pageSize int, search s
 	var ents []entity.SurveyTemplate
 	var total int64
 
-	query := r.DB.Where("title ILIKE ?", "%"+search+"%")
+	// a survey is listed once, by its latest version
+	latestVersions := r.DB.Session(&gorm.Session{NewDB: true}).Table("survey_templates AS versions").
+		Select("MAX(versions.version_number)").
+		Where("versions.survey_number = survey_templates.survey_number").
+		Where("versions.deleted_at IS NULL")
+	query := r.DB.Where("title ILIKE ?", "%"+search+"%").Where("version_number = (?)", latestVersions)
 	for key, value := range sort {
 		query = query.Order(key + " " + value.(string))
 	}
@@ -128,7 +145,10 @@ func (r *SurveyTemplateRepository) FindLatestSurveyNumber() (*entity.SurveyTempl
 func (r *SurveyTemplateRepository) FindByIDForResponse(id, employeeTaskID uuid.UUID) (*entity.SurveyTemplate, error) {
 	var ent entity.SurveyTemplate
 	if err := r.DB.Where("id = ?", id).Preload("Questions.QuestionOptions").Preload("Questions.AnswerType").
-		Preload("Questions.SurveyResponses", "employee_task_id = ?", employeeTaskID).First(&ent).Error; err != nil {
+		Preload("Questions.DisplayConditions.SourceQuestion").Preload("Questions.SurveyResponses", "employee_task_id = ?", employeeTaskID).
+		Preload("Questions.MatrixRows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number asc")
+		}).First(&ent).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -138,3 +158,116 @@ func (r *SurveyTemplateRepository) FindByIDForResponse(id, employeeTaskID uuid.U
 
 	return &ent, nil
 }
+
+// FindByIDForDistributionResponse loads the survey template with the answers of a survey
+// distribution recipient.
+func (r *SurveyTemplateRepository) FindByIDForDistributionResponse(id, recipientID uuid.UUID) (*entity.SurveyTemplate, error) {
+	var ent entity.SurveyTemplate
+	if err := r.DB.Where("id = ?", id).Preload("Questions.QuestionOptions").Preload("Questions.AnswerType").
+		Preload("Questions.DisplayConditions.SourceQuestion").Preload("Questions.SurveyResponses", "survey_distribution_recipient_id = ?", recipientID).
+		Preload("Questions.MatrixRows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number asc")
+		}).First(&ent).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.Log.Error("[SurveyTemplateRepository.FindByIDForDistributionResponse] Error when find survey template by ID: ", err)
+		return nil, err
+	}
+
+	return &ent, nil
+}
+
+// UpdateQuizSettings writes the quiz fields as given, so a template can be turned back into a
+// plain survey and the limits can be cleared.
+func (r *SurveyTemplateRepository) UpdateQuizSettings(ent *entity.SurveyTemplate) error {
+	if err := r.DB.Model(&entity.SurveyTemplate{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"is_quiz":       ent.IsQuiz,
+		"passing_score": ent.PassingScore,
+		"max_attempts":  ent.MaxAttempts,
+	}).Error; err != nil {
+		r.Log.Error("[SurveyTemplateRepository.UpdateQuizSettings] Error when update quiz settings: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateAnonymitySettings writes the anonymity fields as given, clearing the group size of a
+// survey that is no longer confidential.
+func (r *SurveyTemplateRepository) UpdateAnonymitySettings(ent *entity.SurveyTemplate) error {
+	if err := r.DB.Model(&entity.SurveyTemplate{}).Where("id = ?", ent.ID).Updates(map[string]interface{}{
+		"anonymity":      ent.Anonymity,
+		"min_group_size": ent.MinGroupSize,
+	}).Error; err != nil {
+		r.Log.Error("[SurveyTemplateRepository.UpdateAnonymitySettings] Error when update anonymity settings: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SurveyTemplateRepository) FindAllWithQuestions() (*[]entity.SurveyTemplate, error) {
+	var ents []entity.SurveyTemplate
+	if err := r.DB.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number asc")
+	}).Preload("Questions.AnswerType").Preload("Questions.MatrixRows", func(db *gorm.DB) *gorm.DB {
+		return db.Order("number asc")
+	}).Order("created_at asc").Find(&ents).Error; err != nil {
+		r.Log.Error("[SurveyTemplateRepository.FindAllWithQuestions] Error when get survey templates: ", err)
+		return nil, err
+	}
+
+	return &ents, nil
+}
+
+// FindAllVersions returns the versions of a survey from the first, without their questions.
+func (r *SurveyTemplateRepository) FindAllVersions(surveyNumber string) (*[]entity.SurveyTemplate, error) {
+	var ents []entity.SurveyTemplate
+	if err := r.DB.Where("survey_number = ?", surveyNumber).Order("version_number asc").Find(&ents).Error; err != nil {
+		r.Log.Error("[SurveyTemplateRepository.FindAllVersions] Error when get survey template versions: ", err)
+		return nil, err
+	}
+
+	return &ents, nil
+}
+
+// FindLatestPublishedVersion returns the newest published version of the survey of a survey
+// template when it is newer than the template itself, nil otherwise.
+func (r *SurveyTemplateRepository) FindLatestPublishedVersion(id uuid.UUID) (*entity.SurveyTemplate, error) {
+	var current entity.SurveyTemplate
+	if err := r.DB.Where("id = ?", id).First(&current).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.Log.Error("[SurveyTemplateRepository.FindLatestPublishedVersion] Error when get survey template: ", err)
+		return nil, err
+	}
+
+	var latest entity.SurveyTemplate
+	if err := r.DB.Where("survey_number = ?", current.SurveyNumber).
+		Where("status = ?", entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED).
+		Where("version_number > ?", current.VersionNumber).
+		Order("version_number desc").
+		First(&latest).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.Log.Error("[SurveyTemplateRepository.FindLatestPublishedVersion] Error when get latest published survey template: ", err)
+		return nil, err
+	}
+
+	return &latest, nil
+}
+
+func (r *SurveyTemplateRepository) PublishSurveyTemplate(id uuid.UUID, publishedAt time.Time) error {
+	if err := r.DB.Model(&entity.SurveyTemplate{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       entity.SURVEY_TEMPLATE_STATUS_ENUM_SUBMITTED,
+		"published_at": publishedAt,
+	}).Error; err != nil {
+		r.Log.Error("[SurveyTemplateRepository.PublishSurveyTemplate] Error when publish survey template: ", err)
+		return err
+	}
+
+	return nil
+}
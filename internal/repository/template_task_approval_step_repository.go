@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ITemplateTaskApprovalStepRepository interface {
+	CreateTemplateTaskApprovalStep(ent *entity.TemplateTaskApprovalStep) (*entity.TemplateTaskApprovalStep, error)
+	DeleteByTemplateTaskID(templateTaskID uuid.UUID) error
+	FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskApprovalStep, error)
+}
+
+type TemplateTaskApprovalStepRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewTemplateTaskApprovalStepRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *TemplateTaskApprovalStepRepository {
+	return &TemplateTaskApprovalStepRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func TemplateTaskApprovalStepRepositoryFactory(
+	log *logrus.Logger,
+) ITemplateTaskApprovalStepRepository {
+	db := config.NewDatabase()
+	return NewTemplateTaskApprovalStepRepository(log, db)
+}
+
+func (r *TemplateTaskApprovalStepRepository) CreateTemplateTaskApprovalStep(ent *entity.TemplateTaskApprovalStep) (*entity.TemplateTaskApprovalStep, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[TemplateTaskApprovalStepRepository.CreateTemplateTaskApprovalStep] Error when create template task approval step: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[TemplateTaskApprovalStepRepository.CreateTemplateTaskApprovalStep] Error when get template task approval step: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *TemplateTaskApprovalStepRepository) DeleteByTemplateTaskID(templateTaskID uuid.UUID) error {
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Delete(&entity.TemplateTaskApprovalStep{}).Error; err != nil {
+		r.Log.Error("[TemplateTaskApprovalStepRepository.DeleteByTemplateTaskID] Error when delete template task approval steps: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *TemplateTaskApprovalStepRepository) FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskApprovalStep, error) {
+	var steps []entity.TemplateTaskApprovalStep
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Order("step_order asc").Find(&steps).Error; err != nil {
+		r.Log.Error("[TemplateTaskApprovalStepRepository.FindAllByTemplateTaskID] Error when get template task approval steps: ", err)
+		return nil, err
+	}
+
+	return &steps, nil
+}
@@ -51,7 +51,7 @@ func (r *TemplateTaskChecklistRepository) CreateTaskChecklistRepository(ent *ent
 }
 
 func (r *TemplateTaskChecklistRepository) UpdateTaskChecklistRepository(ent *entity.TemplateTaskChecklist) (*entity.TemplateTaskChecklist, error) {
-	if err := r.DB.Model(&entity.TemplateTaskChecklist{}).Where("id = ?", ent.ID).Updates(ent).Error; err != nil {
+	if err := r.DB.Model(&entity.TemplateTaskChecklist{}).Where("id = ?", ent.ID).Select("template_task_id", "name", "sort_order", "assignee_id", "due_offset_days", "is_required").Updates(ent).Error; err != nil {
 		r.Log.Error("[TemplateTaskChecklistRepository.UpdateTaskChecklistRepository] Error when update template task checklist: ", err)
 		return nil, err
 	}
@@ -13,10 +13,11 @@ type ITemplateTaskRepository interface {
 	UpdateTemplateTask(ent *entity.TemplateTask) (*entity.TemplateTask, error)
 	DeleteTemplateTask(ent *entity.TemplateTask) error
 	FindByID(id uuid.UUID) (*entity.TemplateTask, error)
-	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum) (*[]entity.TemplateTask, int64, error)
+	FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum, source string) (*[]entity.TemplateTask, int64, error)
 	FindAll() (*[]entity.TemplateTask, error)
 	CountKanbanProgressByEmployeeID(employeeID uuid.UUID, kanban entity.EmployeeTaskKanbanEnum) (int, error)
 	FindAllByKeys(keys map[string]interface{}) (*[]entity.TemplateTask, error)
+	FindAllByKeysWithDetails(keys map[string]interface{}) (*[]entity.TemplateTask, error)
 }
 
 type TemplateTaskRepository struct {
@@ -80,7 +81,9 @@ func (r *TemplateTaskRepository) DeleteTemplateTask(ent *entity.TemplateTask) er
 
 func (r *TemplateTaskRepository) FindByID(id uuid.UUID) (*entity.TemplateTask, error) {
 	var templateTask entity.TemplateTask
-	if err := r.DB.Preload("TemplateTaskAttachments").Preload("TemplateTaskChecklists").Preload("SurveyTemplate").First(&templateTask, id).Error; err != nil {
+	if err := r.DB.Preload("TemplateTaskAttachments").Preload("TemplateTaskChecklists").Preload("TemplateTaskRules").Preload("TemplateTaskApprovalSteps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("step_order asc")
+	}).Preload("SurveyTemplate").First(&templateTask, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		} else {
@@ -92,7 +95,7 @@ func (r *TemplateTaskRepository) FindByID(id uuid.UUID) (*entity.TemplateTask, e
 	return &templateTask, nil
 }
 
-func (r *TemplateTaskRepository) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum) (*[]entity.TemplateTask, int64, error) {
+func (r *TemplateTaskRepository) FindAllPaginated(page, pageSize int, search string, sort map[string]interface{}, status entity.TemplateTaskStatusEnum, source string) (*[]entity.TemplateTask, int64, error) {
 	var templateTasks []entity.TemplateTask
 	var total int64
 
@@ -105,6 +108,10 @@ func (r *TemplateTaskRepository) FindAllPaginated(page, pageSize int, search str
 		query = query.Where("status = ?", status)
 	}
 
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+
 	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&templateTasks).Error; err != nil {
 		r.Log.Error("[TemplateTaskRepository.FindAllPaginated] Error when get template tasks: ", err)
 		return nil, 0, err
@@ -142,10 +149,26 @@ func (r *TemplateTaskRepository) CountKanbanProgressByEmployeeID(employeeID uuid
 func (r *TemplateTaskRepository) FindAllByKeys(keys map[string]interface{}) (*[]entity.TemplateTask, error) {
 	var templateTasks []entity.TemplateTask
 
-	if err := r.DB.Where(keys).Find(&templateTasks).Error; err != nil {
+	if err := r.DB.Preload("TemplateTaskRules").Preload("TemplateTaskApprovalSteps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("step_order asc")
+	}).Preload("SurveyTemplate").Where(keys).Find(&templateTasks).Error; err != nil {
 		r.Log.Error("[TemplateTaskRepository.FindAllByKeys] Error when get template tasks by keys: ", err)
 		return nil, err
 	}
 
 	return &templateTasks, nil
 }
+
+// FindAllByKeysWithDetails also loads the checklists and attachments of the template tasks.
+func (r *TemplateTaskRepository) FindAllByKeysWithDetails(keys map[string]interface{}) (*[]entity.TemplateTask, error) {
+	var templateTasks []entity.TemplateTask
+
+	if err := r.DB.Preload("TemplateTaskAttachments").Preload("TemplateTaskChecklists").Preload("TemplateTaskRules").Preload("TemplateTaskApprovalSteps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("step_order asc")
+	}).Preload("SurveyTemplate").Where(keys).Find(&templateTasks).Error; err != nil {
+		r.Log.Error("[TemplateTaskRepository.FindAllByKeysWithDetails] Error when get template tasks by keys: ", err)
+		return nil, err
+	}
+
+	return &templateTasks, nil
+}
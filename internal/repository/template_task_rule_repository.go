@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ITemplateTaskRuleRepository interface {
+	CreateTemplateTaskRule(ent *entity.TemplateTaskRule) (*entity.TemplateTaskRule, error)
+	DeleteByTemplateTaskID(templateTaskID uuid.UUID) error
+	FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskRule, error)
+}
+
+type TemplateTaskRuleRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewTemplateTaskRuleRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *TemplateTaskRuleRepository {
+	return &TemplateTaskRuleRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func TemplateTaskRuleRepositoryFactory(
+	log *logrus.Logger,
+) ITemplateTaskRuleRepository {
+	db := config.NewDatabase()
+	return NewTemplateTaskRuleRepository(log, db)
+}
+
+func (r *TemplateTaskRuleRepository) CreateTemplateTaskRule(ent *entity.TemplateTaskRule) (*entity.TemplateTaskRule, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[TemplateTaskRuleRepository.CreateTemplateTaskRule] Error when create template task rule: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[TemplateTaskRuleRepository.CreateTemplateTaskRule] Error when get template task rule: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *TemplateTaskRuleRepository) DeleteByTemplateTaskID(templateTaskID uuid.UUID) error {
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Delete(&entity.TemplateTaskRule{}).Error; err != nil {
+		r.Log.Error("[TemplateTaskRuleRepository.DeleteByTemplateTaskID] Error when delete template task rules: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *TemplateTaskRuleRepository) FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskRule, error) {
+	var rules []entity.TemplateTaskRule
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Order("group_number asc").Find(&rules).Error; err != nil {
+		r.Log.Error("[TemplateTaskRuleRepository.FindAllByTemplateTaskID] Error when get template task rules: ", err)
+		return nil, err
+	}
+
+	return &rules, nil
+}
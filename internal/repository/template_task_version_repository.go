@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ITemplateTaskVersionRepository has no update or delete, versions are never changed once written.
+type ITemplateTaskVersionRepository interface {
+	CreateTemplateTaskVersion(ent *entity.TemplateTaskVersion) (*entity.TemplateTaskVersion, error)
+	FindByID(id uuid.UUID) (*entity.TemplateTaskVersion, error)
+	FindLatestByTemplateTaskID(templateTaskID uuid.UUID) (*entity.TemplateTaskVersion, error)
+	FindByTemplateTaskIDAndVersionNumber(templateTaskID uuid.UUID, versionNumber int) (*entity.TemplateTaskVersion, error)
+	FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskVersion, error)
+}
+
+type TemplateTaskVersionRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewTemplateTaskVersionRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *TemplateTaskVersionRepository {
+	return &TemplateTaskVersionRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func TemplateTaskVersionRepositoryFactory(
+	log *logrus.Logger,
+) ITemplateTaskVersionRepository {
+	db := config.NewDatabase()
+	return NewTemplateTaskVersionRepository(log, db)
+}
+
+func (r *TemplateTaskVersionRepository) CreateTemplateTaskVersion(ent *entity.TemplateTaskVersion) (*entity.TemplateTaskVersion, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[TemplateTaskVersionRepository.CreateTemplateTaskVersion] Error when create template task version: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *TemplateTaskVersionRepository) FindByID(id uuid.UUID) (*entity.TemplateTaskVersion, error) {
+	var templateTaskVersion entity.TemplateTaskVersion
+	if err := r.DB.Where("id = ?", id).First(&templateTaskVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[TemplateTaskVersionRepository.FindByID] Error when get template task version: ", err)
+			return nil, err
+		}
+	}
+
+	return &templateTaskVersion, nil
+}
+
+func (r *TemplateTaskVersionRepository) FindLatestByTemplateTaskID(templateTaskID uuid.UUID) (*entity.TemplateTaskVersion, error) {
+	var templateTaskVersion entity.TemplateTaskVersion
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Order("version_number DESC").First(&templateTaskVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[TemplateTaskVersionRepository.FindLatestByTemplateTaskID] Error when get template task version: ", err)
+			return nil, err
+		}
+	}
+
+	return &templateTaskVersion, nil
+}
+
+func (r *TemplateTaskVersionRepository) FindByTemplateTaskIDAndVersionNumber(templateTaskID uuid.UUID, versionNumber int) (*entity.TemplateTaskVersion, error) {
+	var templateTaskVersion entity.TemplateTaskVersion
+	if err := r.DB.Where("template_task_id = ? AND version_number = ?", templateTaskID, versionNumber).First(&templateTaskVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[TemplateTaskVersionRepository.FindByTemplateTaskIDAndVersionNumber] Error when get template task version: ", err)
+			return nil, err
+		}
+	}
+
+	return &templateTaskVersion, nil
+}
+
+func (r *TemplateTaskVersionRepository) FindAllByTemplateTaskID(templateTaskID uuid.UUID) (*[]entity.TemplateTaskVersion, error) {
+	var templateTaskVersions []entity.TemplateTaskVersion
+	if err := r.DB.Where("template_task_id = ?", templateTaskID).Order("version_number DESC").Find(&templateTaskVersions).Error; err != nil {
+		r.Log.Error("[TemplateTaskVersionRepository.FindAllByTemplateTaskID] Error when get template task versions: ", err)
+		return nil, err
+	}
+
+	return &templateTaskVersions, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IVerifierDelegationRepository interface {
+	CreateVerifierDelegation(ent *entity.VerifierDelegation) (*entity.VerifierDelegation, error)
+	UpdateVerifierDelegation(ent *entity.VerifierDelegation) (*entity.VerifierDelegation, error)
+	FindByID(id uuid.UUID) (*entity.VerifierDelegation, error)
+	FindActiveByDelegatorID(delegatorID uuid.UUID, on time.Time) (*entity.VerifierDelegation, error)
+	FindOverlapping(delegatorID uuid.UUID, startDate, endDate time.Time) (*entity.VerifierDelegation, error)
+	FindAllPaginated(page, pageSize int, employeeID *uuid.UUID, sort map[string]interface{}) (*[]entity.VerifierDelegation, int64, error)
+}
+
+type VerifierDelegationRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewVerifierDelegationRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *VerifierDelegationRepository {
+	return &VerifierDelegationRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func VerifierDelegationRepositoryFactory(
+	log *logrus.Logger,
+) IVerifierDelegationRepository {
+	db := config.NewDatabase()
+	return NewVerifierDelegationRepository(log, db)
+}
+
+func (r *VerifierDelegationRepository) CreateVerifierDelegation(ent *entity.VerifierDelegation) (*entity.VerifierDelegation, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.CreateVerifierDelegation] Error when create verifier delegation: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.CreateVerifierDelegation] Error when get verifier delegation: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *VerifierDelegationRepository) UpdateVerifierDelegation(ent *entity.VerifierDelegation) (*entity.VerifierDelegation, error) {
+	if err := r.DB.Model(&entity.VerifierDelegation{}).Where("id = ?", ent.ID).Updates(ent).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.UpdateVerifierDelegation] Error when update verifier delegation: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, "id = ?", ent.ID).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.UpdateVerifierDelegation] Error when get verifier delegation: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *VerifierDelegationRepository) FindByID(id uuid.UUID) (*entity.VerifierDelegation, error) {
+	var verifierDelegation entity.VerifierDelegation
+	if err := r.DB.Where("id = ?", id).First(&verifierDelegation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[VerifierDelegationRepository.FindByID] Error when get verifier delegation: ", err)
+			return nil, err
+		}
+	}
+
+	return &verifierDelegation, nil
+}
+
+// FindActiveByDelegatorID returns the delegation of the delegator that is not revoked and
+// covers the given day.
+func (r *VerifierDelegationRepository) FindActiveByDelegatorID(delegatorID uuid.UUID, on time.Time) (*entity.VerifierDelegation, error) {
+	var verifierDelegation entity.VerifierDelegation
+	day := on.Format("2006-01-02")
+	if err := r.DB.Where("delegator_id = ?", delegatorID).
+		Where("revoked_at IS NULL").
+		Where("start_date <= ? AND end_date >= ?", day, day).
+		Order("created_at desc").
+		First(&verifierDelegation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[VerifierDelegationRepository.FindActiveByDelegatorID] Error when get verifier delegation: ", err)
+			return nil, err
+		}
+	}
+
+	return &verifierDelegation, nil
+}
+
+// FindOverlapping returns a delegation of the delegator that is not revoked and shares at
+// least one day with the given period.
+func (r *VerifierDelegationRepository) FindOverlapping(delegatorID uuid.UUID, startDate, endDate time.Time) (*entity.VerifierDelegation, error) {
+	var verifierDelegation entity.VerifierDelegation
+	if err := r.DB.Where("delegator_id = ?", delegatorID).
+		Where("revoked_at IS NULL").
+		Where("start_date <= ? AND end_date >= ?", endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).
+		First(&verifierDelegation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[VerifierDelegationRepository.FindOverlapping] Error when get verifier delegation: ", err)
+			return nil, err
+		}
+	}
+
+	return &verifierDelegation, nil
+}
+
+// FindAllPaginated lists delegations, limited to those given or received by the employee
+// when employeeID is set.
+func (r *VerifierDelegationRepository) FindAllPaginated(page, pageSize int, employeeID *uuid.UUID, sort map[string]interface{}) (*[]entity.VerifierDelegation, int64, error) {
+	var verifierDelegations []entity.VerifierDelegation
+	var total int64
+
+	db := r.DB.Model(&entity.VerifierDelegation{})
+	if employeeID != nil {
+		db = db.Where("delegator_id = ? OR delegate_id = ?", *employeeID, *employeeID)
+	}
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.FindAllPaginated] Error when count verifier delegations: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&verifierDelegations).Error; err != nil {
+		r.Log.Error("[VerifierDelegationRepository.FindAllPaginated] Error when get verifier delegations: ", err)
+		return nil, 0, err
+	}
+
+	return &verifierDelegations, total, nil
+}
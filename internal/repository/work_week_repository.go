@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IWorkWeekRepository interface {
+	CreateWorkWeek(ent *entity.WorkWeek) (*entity.WorkWeek, error)
+	UpdateWorkWeek(ent *entity.WorkWeek) (*entity.WorkWeek, error)
+	DeleteWorkWeek(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entity.WorkWeek, error)
+	FindByOrganizationID(organizationID uuid.UUID) (*entity.WorkWeek, error)
+	FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.WorkWeek, int64, error)
+}
+
+type WorkWeekRepository struct {
+	Log *logrus.Logger
+	DB  *gorm.DB
+}
+
+func NewWorkWeekRepository(
+	log *logrus.Logger,
+	db *gorm.DB,
+) *WorkWeekRepository {
+	return &WorkWeekRepository{
+		Log: log,
+		DB:  db,
+	}
+}
+
+func WorkWeekRepositoryFactory(
+	log *logrus.Logger,
+) IWorkWeekRepository {
+	db := config.NewDatabase()
+	return NewWorkWeekRepository(log, db)
+}
+
+func (r *WorkWeekRepository) CreateWorkWeek(ent *entity.WorkWeek) (*entity.WorkWeek, error) {
+	if err := r.DB.Create(ent).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.CreateWorkWeek] Error when create work week: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.CreateWorkWeek] Error when get work week: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *WorkWeekRepository) UpdateWorkWeek(ent *entity.WorkWeek) (*entity.WorkWeek, error) {
+	if err := r.DB.Model(&entity.WorkWeek{}).Where("id = ?", ent.ID).Updates(ent).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.UpdateWorkWeek] Error when update work week: ", err)
+		return nil, err
+	}
+
+	if err := r.DB.First(ent, ent.ID).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.UpdateWorkWeek] Error when get work week: ", err)
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+func (r *WorkWeekRepository) DeleteWorkWeek(id uuid.UUID) error {
+	if err := r.DB.Where("id = ?", id).Delete(&entity.WorkWeek{}).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.DeleteWorkWeek] Error when delete work week: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkWeekRepository) FindByID(id uuid.UUID) (*entity.WorkWeek, error) {
+	var workWeek entity.WorkWeek
+	if err := r.DB.Where("id = ?", id).First(&workWeek).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[WorkWeekRepository.FindByID] Error when get work week: ", err)
+			return nil, err
+		}
+	}
+
+	return &workWeek, nil
+}
+
+func (r *WorkWeekRepository) FindByOrganizationID(organizationID uuid.UUID) (*entity.WorkWeek, error) {
+	var workWeek entity.WorkWeek
+	if err := r.DB.Where("organization_id = ?", organizationID).First(&workWeek).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		} else {
+			r.Log.Error("[WorkWeekRepository.FindByOrganizationID] Error when get work week: ", err)
+			return nil, err
+		}
+	}
+
+	return &workWeek, nil
+}
+
+func (r *WorkWeekRepository) FindAllPaginated(page, pageSize int, sort map[string]interface{}) (*[]entity.WorkWeek, int64, error) {
+	var workWeeks []entity.WorkWeek
+	var total int64
+
+	db := r.DB.Model(&entity.WorkWeek{})
+
+	for key, value := range sort {
+		db = db.Order(key + " " + value.(string))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.FindAllPaginated] Error when count work week: ", err)
+		return nil, 0, err
+	}
+
+	if err := db.Limit(pageSize).Offset((page - 1) * pageSize).Find(&workWeeks).Error; err != nil {
+		r.Log.Error("[WorkWeekRepository.FindAllPaginated] Error when get work weeks: ", err)
+		return nil, 0, err
+	}
+
+	return &workWeeks, total, nil
+}
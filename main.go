@@ -14,6 +14,7 @@ import (
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/config"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/rabbitmq"
 	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/route"
+	"github.com/IlhamSetiaji/julong-onboarding-be/internal/http/scheduler"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
@@ -73,7 +74,7 @@ func main() {
 	generateSwaggerDocs(viper.GetString("app.env"))
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 
 	go func() {
 		defer wg.Done()
@@ -85,6 +86,11 @@ func main() {
 		rabbitmq.InitProducer(viper, log)
 	}()
 
+	go func() {
+		defer wg.Done()
+		scheduler.InitSurveyCampaignScheduler(viper, log)
+	}()
+
 	app := gin.Default()
 	app.Static("/storage", "./storage")
 	app.Use(func(c *gin.Context) {